@@ -1,10 +1,14 @@
 package config
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bmizerany/lpx"
 )
@@ -14,6 +18,61 @@ type Config struct {
 	HerokuLogStream chan HerokuLogStreamItem
 
 	Servers []ServerConfig
+
+	// Address (e.g. "127.0.0.1:8080") of an HTTP server that answers health
+	// checks on /health and /ready - this applies to the whole collector, and
+	// is only read from the pganalyze section
+	//
+	// Defaults to none, i.e. no health check server
+	HealthCheckAddress string
+
+	// Maximum age of the last successful full snapshot of each server, for /ready
+	// to report the collector as ready (log submissions alone don't count, since
+	// they continue when statistics collection is broken)
+	//
+	// Defaults to 30 minutes
+	HealthCheckReadyWithin time.Duration
+
+	// Address (e.g. "127.0.0.1:9187") of an HTTP server that exports the
+	// statistics of the latest full snapshot of each server on /metrics, in the
+	// Prometheus text format - this applies to the whole collector, and is only
+	// read from the pganalyze section
+	//
+	// Defaults to none, i.e. no metrics are exported
+	MetricsAddress string
+
+	// Maximum random delay of all scheduled runs, chosen once when the collector
+	// starts, so that collectors on hosts that booted together don't send their
+	// data to the pganalyze API at the same time
+	//
+	// Defaults to 30 seconds
+	ScheduleStartupJitter time.Duration
+
+	// Maximum random delay added to each individual scheduled run (limited to
+	// half the interval of the run)
+	//
+	// Defaults to 5 seconds
+	ScheduleJitter time.Duration
+
+	// Path of a file whose existence pauses all collection and sending (e.g.
+	// during maintenance windows), which is checked before every scheduled run
+	//
+	// Defaults to none, i.e. collection can't be paused
+	PauseFile string
+
+	// Directory that batches of log lines are written to (together with a
+	// manifest describing them) when they get dropped after all retries to send
+	// them failed, so they can be submitted manually later
+	//
+	// Defaults to none, i.e. dropped batches are discarded
+	LogDeadLetterDir string
+
+	// Whether dropped batches may be written to the dead-letter directory without
+	// encryption, when no log encryption key is available (e.g. because getting
+	// a logs grant failed)
+	//
+	// Defaults to false, i.e. such batches are discarded
+	LogDeadLetterPlaintext bool
 }
 
 type HerokuLogStreamItem struct {
@@ -38,6 +97,13 @@ type ServerConfig struct {
 	APIKey     string `ini:"api_key"`
 	APIBaseURL string `ini:"api_base_url"`
 
+	// Comma-separated list of API endpoints that are tried in order when
+	// requesting a grant, in case the previous ones fail - data uploaded with a
+	// grant is always submitted to the endpoint the grant came from
+	//
+	// Defaults to only api_base_url
+	APIBaseURLs []string `ini:"api_base_urls"`
+
 	ErrorCallback   string `ini:"error_callback"`
 	SuccessCallback string `ini:"success_callback"`
 
@@ -55,6 +121,45 @@ type ServerConfig struct {
 	DbSslRootCert         string `ini:"db_sslrootcert"`
 	DbSslRootCertContents string `ini:"db_sslrootcert_contents"`
 
+	// Specifies the directory of the Postgres unix socket, for connecting on
+	// the same host without TCP (e.g. /var/run/postgresql), which allows using
+	// peer authentication instead of a password. The socket file in it is
+	// determined by db_port. Takes precedence over the host in db_host/db_url.
+	//
+	// SSL is not used on socket connections, so sslmode=prefer is treated like
+	// sslmode=disable, and modes that require SSL are an error.
+	//
+	// Defaults to none, i.e. connecting using TCP
+	DbSocketDir string `ini:"db_socket_dir"`
+
+	// Specifies the minimum TLS version for the database connection, with the
+	// same values as Postgres' ssl_min_protocol_version (TLSv1, TLSv1.1,
+	// TLSv1.2 or TLSv1.3) - the collector closes the connection and reports an
+	// error if an older version was negotiated, instead of collecting over it
+	//
+	// The Postgres driver used by the collector can't restrict the version
+	// during the TLS handshake, so this is checked after the connection was
+	// established, i.e. after the password was already sent. To keep
+	// credentials from being sent over older TLS versions, set
+	// ssl_min_protocol_version on the server instead (or in addition).
+	//
+	// This requires an SSL connection, so sslmode=prefer is treated like
+	// sslmode=require, and sslmode=disable is an error.
+	//
+	// Defaults to none
+	TLSMinVersion string `ini:"db_ssl_min_protocol_version"`
+
+	// Accepts the same values as libpq's channel_binding (disable, prefer or
+	// require), so connection settings can be shared with libpq-based clients
+	//
+	// Channel binding is never used: the Postgres driver used by the collector
+	// does not support SCRAM-SHA-256-PLUS, so "disable" and "prefer" both
+	// connect without it, and "require" is rejected with an error instead of
+	// silently connecting without channel binding.
+	//
+	// Defaults to prefer
+	ChannelBinding string `ini:"db_channel_binding"`
+
 	// We have to do some tricks to support sslmode=prefer, namely we have to
 	// first try an SSL connection (= require), and if that fails change the
 	// sslmode to none
@@ -63,11 +168,78 @@ type ServerConfig struct {
 	DbExtraNames []string // Additional databases that should be fetched (determined by additional databases in db_name)
 	DbAllNames   bool     // All databases except template databases should be fetched (determined by * in the db_name list)
 
+	// Comma-separated lists of database names to collect, or to skip - skipped
+	// databases are left out of the snapshot (including their query statistics),
+	// and we never connect to them to fetch their schema
+	//
+	// When a database is in both lists, the deny list wins. An empty allow list
+	// allows all databases.
+	DatabaseAllowList []string `ini:"db_allow_list"`
+	DatabaseDenyList  []string `ini:"db_deny_list"`
+
+	// Comma-separated lists of schema names to collect, or to skip - skipped
+	// schemas are excluded in the catalog queries themselves, so their tables,
+	// indexes and functions (including statistics) are never fetched or stored
+	//
+	// When a schema is in both lists, the deny list wins. An empty allow list
+	// allows all schemas.
+	SchemaAllowList []string `ini:"schema_allow_list"`
+	SchemaDenyList  []string `ini:"schema_deny_list"`
+
+	// Comma-separated list of settings (as named in pg_settings) to collect,
+	// to reduce the size of snapshots
+	//
+	// Defaults to none, i.e. all settings are collected
+	SettingsAllowList []string `ini:"settings_allow_list"`
+
+	// Only sends settings whose value (or any other column in pg_settings)
+	// changed since the last run, and leaves out unchanged settings (all
+	// settings are sent when there is no previous run to compare to)
+	//
+	// Defaults to false
+	SettingsChangedOnly bool `ini:"settings_changed_only"`
+
 	AwsRegion          string `ini:"aws_region"`
 	AwsDbInstanceID    string `ini:"aws_db_instance_id"`
 	AwsAccessKeyID     string `ini:"aws_access_key_id"`
 	AwsSecretAccessKey string `ini:"aws_secret_access_key"`
 
+	// Reads logs from the given CloudWatch Logs log group (e.g. when RDS log
+	// exports are enabled, "/aws/rds/instance/<instance>/postgresql") instead
+	// of downloading them through the RDS API - this enables log collection
+	// for the server
+	//
+	// Defaults to none
+	AwsCloudWatchLogGroup string `ini:"aws_cloudwatch_log_group"`
+
+	// Reads logs from the given Azure Log Analytics workspace (by its ID), to
+	// which the diagnostic settings of an Azure Database for PostgreSQL server
+	// send its "PostgreSQLLogs" - this enables log collection for the server
+	//
+	// Defaults to none
+	AzureLogAnalyticsWorkspaceID string `ini:"azure_log_analytics_workspace_id"`
+
+	// Name of the Azure Database for PostgreSQL server whose logs are read from
+	// the Log Analytics workspace (required when reading from a workspace)
+	//
+	// Defaults to none
+	AzureDbServerName string `ini:"azure_db_server_name"`
+
+	// Credentials for querying Log Analytics, in the format of Azure's
+	// AzureServicesAuthConnectionString, i.e. "RunAs=App;AppId=<client id>;
+	// TenantId=<tenant id>;AppKey=<client secret>" for a service principal, or
+	// "RunAs=App;AppId=<client id>" for a user-assigned managed identity
+	//
+	// Defaults to the system-assigned managed identity
+	AzureAuthConnectionString string `ini:"azure_auth_connection_string"`
+
+	// Connects using an IAM authentication token for the database user instead
+	// of a password, generated with the AWS credentials for aws_region before
+	// each connection (tokens are only valid for 15 minutes)
+	//
+	// Defaults to false
+	DbUseIamAuth bool `ini:"db_use_iam_auth"`
+
 	SectionName string
 	Identifier  ServerIdentifier
 
@@ -84,6 +256,11 @@ type ServerConfig struct {
 	// development and debugging. The value needs to be the name of the container.
 	LogDockerTail string `ini:"db_log_docker_tail"`
 
+	// Configures the collector to receive logs over syslog (RFC 5424), on the
+	// given address (e.g. "0.0.0.0:5140"), over both UDP and TCP. Postgres
+	// needs to send its logs there, either directly or through a syslog daemon.
+	LogSyslogServer string `ini:"db_log_syslog_server"`
+
 	// Specifies a table pattern to ignore - no statistics will be collected for
 	// tables that match the name. This uses Golang's filepath.Match function for
 	// comparison, so you can e.g. use "*" for wildcard matching.
@@ -103,6 +280,311 @@ type ServerConfig struct {
 	// This defaults to 10 connections, but you may want to raise this when running
 	// the collector multiple times against the same database server
 	MaxCollectorConnections int `ini:"max_collector_connections"`
+
+	// Keeps the monitoring connection open between runs, instead of connecting
+	// anew every time - the connection is checked before each use, and replaced
+	// if it stopped working (or reached its maximum lifetime, e.g. to pick up a
+	// new IAM authentication token)
+	//
+	// Defaults to false, and a maximum lifetime of 10 minutes
+	DbReuseConnection            bool          `ini:"db_reuse_connection"`
+	DbReuseConnectionMaxLifetime time.Duration `ini:"db_reuse_connection_max_lifetime"`
+
+	// Number of databases whose local catalog (tables, indexes and functions) is
+	// collected at the same time, when monitoring multiple databases
+	//
+	// Limited to one less than max_collector_connections, to leave room for the
+	// connection used for the server-wide statistics
+	//
+	// Defaults to 4
+	SchemaCollectionWorkers int `ini:"schema_collection_workers"`
+
+	// Specifies how long a log line is held back after it was received, before
+	// it gets analyzed and sent, so that follow-on lines belonging to it (e.g.
+	// STATEMENT, DETAIL or HINT) have a chance to arrive
+	//
+	// Accepts Go duration strings, e.g. "3s" or "500ms" - "0" sends log lines
+	// right away
+	//
+	// Defaults to 3 seconds
+	LogLinesReadyAfter time.Duration `ini:"log_lines_ready_after"`
+
+	// Specifies a customer-managed AWS KMS key that is used for server-side
+	// encryption (SSE-KMS) of log files uploaded to S3, in addition to the
+	// client-side encryption that always happens before upload
+	//
+	// Accepts a key ID, key ARN, alias name (e.g. "alias/my-key") or alias ARN
+	//
+	// The key is requested together with the upload grant, since it needs to be
+	// part of the signed upload policy - log files are never uploaded to S3 if
+	// the grant doesn't use the key. If not set, the defaults of the upload
+	// grant are used
+	LogEncryptionKeyID string `ini:"log_encryption_key_id"`
+
+	// Specifies the maximum size in bytes of each log file that gets uploaded -
+	// log data exceeding this is split into multiple files, which are still
+	// sent together as one log snapshot
+	//
+	// Defaults to 0, i.e. no limit
+	LogTempfileMaxBytes int64 `ini:"log_tempfile_max_bytes"`
+
+	// Specifies the maximum number of log lines that are kept in memory while
+	// waiting to be sent (e.g. when the upload is slow or failing), to protect
+	// against running out of memory during a burst of log output
+	//
+	// Defaults to 0, i.e. no limit
+	LogBufferMaxLines int `ini:"log_buffer_max_lines"`
+
+	// Specifies what happens once log_buffer_max_lines is reached
+	//
+	// Currently supported values: drop_oldest (drop the oldest lines that are
+	// waiting to be sent), block (stop reading new log lines until lines were
+	// sent - only supported for log sources that can be read later, e.g. files;
+	// log lines pushed to the collector over syslog or HTTP use drop_oldest)
+	//
+	// Defaults to drop_oldest
+	LogBufferPolicy string `ini:"log_buffer_policy"`
+
+	// Specifies the maximum size in bytes of a compressed snapshot that gets
+	// uploaded - larger snapshots (e.g. for databases with a very large number
+	// of tables) are not uploaded, and the run fails with an error that states
+	// the actual size
+	//
+	// Defaults to 0, i.e. no limit
+	MaxSnapshotUploadBytes int64 `ini:"max_snapshot_upload_bytes"`
+
+	// Specifies how query samples found in the logs are filtered before they
+	// are sent, to avoid sending sensitive data contained in literal values
+	//
+	// Currently supported values: none (send query text and parameters as they
+	// were logged), normalize (replace literal values in the query text with
+	// $n placeholders, and don't send any parameters)
+	//
+	// Defaults to none
+	FilterQuerySample string `ini:"filter_query_sample"`
+
+	// Specifies the maximum length in bytes of the query text of query samples -
+	// longer queries (e.g. bulk INSERTs) are cut off, keeping their beginning, and
+	// marked as truncated. This applies after filter_query_sample.
+	//
+	// Defaults to 0, i.e. no limit
+	MaxQuerySampleLength int `ini:"max_query_sample_length"`
+
+	// Specifies the length in bytes above which the query text of a query sample
+	// is uploaded as a separate (encrypted) object, instead of being part of the
+	// log snapshot - the snapshot then only contains the beginning of the query
+	// text, and a reference to the object. This applies after
+	// max_query_sample_length.
+	//
+	// Defaults to 0, i.e. query texts are always part of the log snapshot
+	SeparateQuerySampleMinLength int `ini:"separate_query_sample_min_length"`
+
+	// Specifies how many query samples are kept for each distinct query (by
+	// fingerprint) and database in a single batch of log data - when the same
+	// slow query runs repeatedly, the slowest samples (and those with EXPLAIN
+	// output) are kept, and the rest is dropped
+	//
+	// Defaults to 0, i.e. no limit
+	MaxQuerySamplesPerFingerprint int `ini:"max_query_samples_per_fingerprint"`
+
+	// Specifies how many query samples are sent at most with a single batch of
+	// log data, across all queries - during slow query storms the slowest samples
+	// are kept. This applies after max_query_samples_per_fingerprint, so that the
+	// kept samples are spread over more distinct queries.
+	//
+	// Defaults to 0, i.e. no limit
+	MaxQuerySamplesPerSnapshot int `ini:"max_query_samples_per_snapshot"`
+
+	// Specifies a secret used to replace database, role, schema, table, index,
+	// column, function, tablespace, replication slot, standby and host names with
+	// pseudonyms before snapshots are uploaded. The pseudonyms are derived from
+	// the secret (using HMAC-SHA256), so they stay the same between snapshots as
+	// long as the secret doesn't change. Query texts, object definitions, query
+	// samples, log contents, collector errors and the values of string settings
+	// can't be anonymized this way, and are not sent at all.
+	//
+	// Defaults to "", i.e. names are sent as-is
+	AnonymizeNamesSecret string `ini:"anonymize_names_secret"`
+
+	// Specifies whether partitions of declaratively partitioned tables are left
+	// out when sending relation information, and their statistics are instead
+	// reported summed up under the partitioned table they belong to
+	//
+	// Defaults to false
+	AggregatePartitions bool `ini:"aggregate_partitions"`
+
+	// Specifies whether pg_stat_activity gets sampled once a minute, to count
+	// how often backends were waiting on each wait event (type) between two full
+	// snapshots. Before Postgres 9.6 only waiting on locks can be counted.
+	//
+	// Defaults to false
+	CollectWaitEvents bool `ini:"collect_wait_events"`
+
+	// Specifies whether the progress of long-running operations (VACUUM, CREATE
+	// INDEX and base backups) is read from the pg_stat_progress_* views with each
+	// full snapshot. Views that don't exist in the Postgres version are skipped.
+	//
+	// Defaults to false
+	CollectProgress bool `ini:"collect_progress"`
+
+	// Specifies thresholds for the load seen in the previous full snapshot, above
+	// which the expensive parts of the next one (bloat estimates, as well as
+	// table, index and function statistics) are skipped, to avoid adding to the
+	// load of an overloaded database - the number of active connections, and
+	// the replication lag in bytes (of this standby, or of any standby of this
+	// primary)
+	//
+	// Defaults to 0, i.e. never skipped
+	ThrottleMaxActiveBackends      int   `ini:"throttle_max_active_backends"`
+	ThrottleMaxReplicationLagBytes int64 `ini:"throttle_max_replication_lag_bytes"`
+
+	// Specifies the minimum activity (the sum of scans, rows read and modified,
+	// blocks read and hit, and vacuum/analyze runs) a table needs to have since
+	// the previous snapshot for its scan, row and block counters to be sent -
+	// this reduces the snapshot size for schemas with many tables that are
+	// rarely used
+	//
+	// Sizes, row estimates and vacuum information are sent for all tables, and
+	// the statistics of all tables are still kept for calculating the next diff.
+	//
+	// Defaults to 0, i.e. all statistics of all tables are sent
+	RelationStatsMinChange int64 `ini:"relation_stats_min_change"`
+
+	// Specifies the minimum time between collecting table and index bloat
+	// estimates, which are expensive to calculate on large schemas - snapshots
+	// in between reuse the last collected estimates, whereas on-demand bloat
+	// reports always collect new ones
+	//
+	// Accepts Go duration strings, e.g. "1h" or "30m"
+	//
+	// Defaults to 1 hour
+	BloatCollectionInterval time.Duration `ini:"bloat_collection_interval"`
+
+	// Specifies when a table is considered overdue for vacuum: once the share of
+	// dead rows among all its rows reached the ratio, and it was neither
+	// vacuumed manually nor by autovacuum for the given time (or never)
+	//
+	// Accepts a Go duration string (e.g. "1h" or "30m") and a ratio between 0 and 1
+	//
+	// Defaults to 24 hours and 0.2 (the default of autovacuum_vacuum_scale_factor)
+	VacuumOverdueAfter          time.Duration `ini:"vacuum_overdue_after"`
+	VacuumOverdueDeadTupleRatio float64       `ini:"vacuum_overdue_dead_tuple_ratio"`
+
+	// Specifies the minimum time between collecting each category of data as
+	// part of the full snapshot - system information, query statistics, and
+	// tables, indexes and functions (including their statistics). Snapshots in
+	// between leave out the category, and the next snapshot that collects it
+	// sends statistics covering the whole time since it was last collected
+	// (together with the length of that time).
+	//
+	// Full snapshots run every 10 minutes, so a category gets collected on the
+	// first snapshot once its interval has passed - shorter intervals have no
+	// effect, and get warned about at startup.
+	//
+	// Accepts Go duration strings, e.g. "1h" or "30m"
+	//
+	// Defaults to 0, i.e. collected with every full snapshot
+	SystemCollectionInterval    time.Duration `ini:"system_collection_interval"`
+	StatementCollectionInterval time.Duration `ini:"statement_collection_interval"`
+	RelationCollectionInterval  time.Duration `ini:"relation_collection_interval"`
+
+	// Specifies the maximum age of the state file statistics that the first
+	// snapshot after startup gets diffed against - older statistics are not
+	// used, to avoid diffing against ones collected before the collector was
+	// stopped for a while (other state, e.g. log positions, is still used)
+	//
+	// Accepts Go duration strings, e.g. "1h" or "30m", and "0" to always use
+	// the state file
+	//
+	// Defaults to 1 hour
+	MaxStateAge time.Duration `ini:"max_state_age"`
+
+	// Specifies after how many full snapshots pg_stat_statements_reset() gets
+	// called, overriding the frequency requested by the pganalyze service
+	//
+	// Defaults to 0 (use the frequency requested by the pganalyze service)
+	StatementResetFrequency int `ini:"statement_reset_frequency"`
+
+	// Never calls pg_stat_statements_reset(), even if the pganalyze service or
+	// statement_reset_frequency request it
+	//
+	// Defaults to false
+	DisableStatementReset bool `ini:"disable_statement_reset"`
+
+	// Specifies log line classifications (comma-separated, using their numeric
+	// values in the snapshot format) that are never uploaded, e.g. to leave out
+	// routine checkpoint or autovacuum messages. Filtered lines are still counted
+	// in the collector statistics.
+	//
+	// Read from "log_classification_deny_list" (see readLogClassificationDenyList),
+	// since the ini mapping doesn't support lists of int32 values
+	//
+	// Defaults to none
+	LogClassificationDenyList []int32 `ini:"-"`
+
+	// Specifies the minimum severity of log lines that are uploaded, one of
+	// debug, info, notice, warning, error, log, fatal or panic (ordered like
+	// log_min_messages). Lines below it are left out before analysis, together
+	// with their DETAIL, HINT and other follow-on lines. Filtered lines are
+	// still counted in the collector statistics.
+	//
+	// Read from "log_min_level" (see readLogMinLevel), and kept as the numeric
+	// value of the level in the snapshot format
+	//
+	// Defaults to none, i.e. lines of all levels are uploaded
+	LogMinLevel int32 `ini:"-"`
+
+	// Specifies application names (comma-separated) whose log lines are never
+	// uploaded, e.g. backup jobs - this requires %a in the log_line_prefix.
+	// Log lines of the collector's own connections are always left out, except
+	// for those it uses to identify the server. Filtered lines are still counted
+	// in the collector statistics.
+	//
+	// Defaults to none
+	LogApplicationNameDenyList []string `ini:"log_application_name_deny_list"`
+
+	// Specifies parameters of query samples that are always redacted, even if
+	// filter_query_sample is set to none, e.g. bind parameters that contain
+	// personal data. Each entry maps a query fingerprint (as shown by
+	// --analyze-logfile) to the 1-based positions of its parameters, with entries
+	// separated by semicolons, e.g. "02a1b2...:2;02c3d4...:1,3"
+	//
+	// Read from "redact_query_parameters" (see readRedactQueryParameters)
+	//
+	// Defaults to none
+	RedactQueryParameters map[string][]int `ini:"-"`
+
+	// Specifies query fingerprints (as shown by --analyze-logfile, comma-separated)
+	// whose query samples are sent with their raw text, including literal values,
+	// even when filter_query_sample requires them to be removed - this is meant
+	// for debugging a few known-safe queries, and gets warned about at startup.
+	// Parameters listed in redact_query_parameters are still redacted.
+	//
+	// Defaults to none
+	RawQuerySampleFingerprints []string `ini:"raw_query_sample_fingerprints"`
+
+	// Labels included in all full and compact snapshots sent for this server, and
+	// attached as S3 object metadata to its uploaded log files, e.g. to tell apart
+	// environments, as comma-separated key=value pairs, e.g.
+	// "env=production,team=payments" (see ValidateTags for allowed values)
+	//
+	// Read from "tags" (see readTags)
+	//
+	// Defaults to none
+	Tags map[string]string `ini:"-"`
+
+	// Queries that each return a single numeric value, which are run on every full
+	// snapshot and sent as custom metrics, named after the setting, e.g.
+	// "custom_metric_queued_jobs = SELECT count(*) FROM jobs WHERE state = 'queued'"
+	//
+	// The queries run with the statement timeout, and a failing query only leaves
+	// out its own metric.
+	//
+	// Read from "custom_metric_<name>" settings (see readCustomMetrics), which add
+	// to the ones in the pganalyze section
+	//
+	// Defaults to none
+	CustomMetrics map[string]string `ini:"-"`
 }
 
 // GetPqOpenString - Gets the database configuration as a string that can be passed to lib/pq for connecting
@@ -165,6 +647,9 @@ func (config ServerConfig) GetPqOpenString(dbNameOverride string) string {
 	if config.DbSslRootCert != "" {
 		dbSslRootCert = config.DbSslRootCert
 	}
+	if config.DbSocketDir != "" {
+		dbHost = config.DbSocketDir
+	}
 
 	// Defaults if nothing is set
 	if dbHost == "" {
@@ -177,9 +662,14 @@ func (config ServerConfig) GetPqOpenString(dbNameOverride string) string {
 		dbSslMode = "prefer"
 	}
 
-	// Handle SSL mode prefer
+	// Socket connections never use SSL
+	if config.DbSocketDir != "" && (dbSslMode == "prefer" || dbSslMode == "allow") {
+		dbSslMode = "disable"
+	}
+
+	// Handle SSL mode prefer (a minimum TLS version requires SSL)
 	if dbSslMode == "prefer" {
-		if config.DbSslModePreferFailed {
+		if config.DbSslModePreferFailed && config.TLSMinVersion == "" {
 			dbSslMode = "disable"
 		} else {
 			dbSslMode = "require"
@@ -218,8 +708,173 @@ func (config ServerConfig) GetPqOpenString(dbNameOverride string) string {
 	return strings.Join(dbinfo, " ")
 }
 
+// GetDbSslMode - Gets the requested sslmode from the given configuration,
+// before any sslmode=prefer handling
+func (config ServerConfig) GetDbSslMode() string {
+	if config.DbSslMode != "" {
+		return config.DbSslMode
+	}
+	if config.DbURL != "" {
+		u, _ := url.Parse(config.DbURL)
+		if u != nil && u.Query().Get("sslmode") != "" {
+			return u.Query().Get("sslmode")
+		}
+	}
+	return "prefer"
+}
+
+// ValidateConnectionSecurity - Checks that the TLS version and channel binding
+// settings are valid and supported, before connecting
+func (config ServerConfig) ValidateConnectionSecurity() error {
+	if config.DbSocketDir != "" {
+		if config.TLSMinVersion != "" {
+			return fmt.Errorf("db_ssl_min_protocol_version can't be used with db_socket_dir, since socket connections don't use SSL")
+		}
+		switch config.GetDbSslMode() {
+		case "disable", "allow", "prefer":
+		default:
+			return fmt.Errorf("sslmode %s can't be used with db_socket_dir, since socket connections don't use SSL", config.GetDbSslMode())
+		}
+	}
+
+	if config.DbUseIamAuth {
+		if config.DbSocketDir != "" {
+			return fmt.Errorf("db_use_iam_auth can't be used with db_socket_dir, since IAM authentication requires SSL")
+		}
+		if config.GetDbSslMode() == "disable" {
+			return fmt.Errorf("db_use_iam_auth requires SSL, but sslmode is disable")
+		}
+	}
+
+	if config.TLSMinVersion != "" {
+		if _, err := ParseTLSVersion(config.TLSMinVersion); err != nil {
+			return err
+		}
+		if config.GetDbSslMode() == "disable" {
+			return fmt.Errorf("db_ssl_min_protocol_version = %s requires SSL, but sslmode is disable", config.TLSMinVersion)
+		}
+	}
+
+	switch config.ChannelBinding {
+	case "", "disable", "prefer":
+	case "require":
+		return fmt.Errorf("db_channel_binding = require is not supported by the collector's Postgres driver - use prefer or disable instead")
+	default:
+		return fmt.Errorf("unsupported db_channel_binding \"%s\" - only disable, prefer and require are supported", config.ChannelBinding)
+	}
+
+	return nil
+}
+
+// ParseTLSVersion - Returns the crypto/tls version for a TLS version name as
+// used by Postgres (e.g. "TLSv1.2")
+func ParseTLSVersion(name string) (uint16, error) {
+	switch name {
+	case "TLSv1":
+		return tls.VersionTLS10, nil
+	case "TLSv1.1":
+		return tls.VersionTLS11, nil
+	case "TLSv1.2":
+		return tls.VersionTLS12, nil
+	case "TLSv1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS version \"%s\" - only TLSv1, TLSv1.1, TLSv1.2 and TLSv1.3 are supported", name)
+}
+
+// ValidateDbSocketDir - Checks that the configured socket directory exists
+//
+// The socket file itself is not checked, since Postgres might not be running yet.
+func (config ServerConfig) ValidateDbSocketDir() error {
+	if config.DbSocketDir == "" {
+		return nil
+	}
+	info, err := os.Stat(config.DbSocketDir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", config.DbSocketDir)
+	}
+	return nil
+}
+
+const (
+	maxTagKeyLength   = 64
+	maxTagValueLength = 256
+)
+
+// FullSnapshotInterval - How often full snapshots run (see scheduler.GetSchedulerGroups)
+const FullSnapshotInterval = 10 * time.Minute
+
+// IneffectiveCollectionIntervals - Returns the collection interval settings
+// that are shorter than FullSnapshotInterval, and hence have no effect
+func (config ServerConfig) IneffectiveCollectionIntervals() (settings []string) {
+	for _, interval := range []struct {
+		setting string
+		value   time.Duration
+	}{
+		{"system_collection_interval", config.SystemCollectionInterval},
+		{"statement_collection_interval", config.StatementCollectionInterval},
+		{"relation_collection_interval", config.RelationCollectionInterval},
+	} {
+		if interval.value > 0 && interval.value < FullSnapshotInterval {
+			settings = append(settings, interval.setting)
+		}
+	}
+	return
+}
+
+// ValidateTags - Checks that tag keys and values can be sent as upload metadata
+//
+// Keys are limited to letters, digits, "_", "-" and ".", values to printable
+// ASCII characters, since S3 object metadata doesn't allow anything else.
+func (config ServerConfig) ValidateTags() error {
+	for key, value := range config.Tags {
+		if key == "" || len(key) > maxTagKeyLength {
+			return fmt.Errorf("tag key %q must be between 1 and %d characters long", key, maxTagKeyLength)
+		}
+		for _, c := range key {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.') {
+				return fmt.Errorf("tag key %q may only contain letters, digits, \"_\", \"-\" and \".\"", key)
+			}
+		}
+		if len(value) > maxTagValueLength {
+			return fmt.Errorf("value of tag %q must be at most %d characters long", key, maxTagValueLength)
+		}
+		for _, c := range value {
+			if c < ' ' || c > '~' {
+				return fmt.Errorf("value of tag %q may only contain printable ASCII characters", key)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateLogBufferPolicy - Checks that the log buffer policy is supported
+func (config ServerConfig) ValidateLogBufferPolicy() error {
+	switch config.LogBufferPolicy {
+	case "drop_oldest", "block":
+		return nil
+	}
+	return fmt.Errorf("unsupported policy %q, expected drop_oldest or block", config.LogBufferPolicy)
+}
+
+// GetAPIBaseURLs - Gets the API endpoints that should be tried in order
+func (config ServerConfig) GetAPIBaseURLs() []string {
+	if len(config.APIBaseURLs) > 0 {
+		return config.APIBaseURLs
+	}
+	return []string{config.APIBaseURL}
+}
+
 // GetDbHost - Gets the database hostname from the given configuration
+//
+// Socket connections are always to the local host.
 func (config ServerConfig) GetDbHost() string {
+	if config.DbSocketDir != "" {
+		return "localhost"
+	}
 	if config.DbURL != "" {
 		u, _ := url.Parse(config.DbURL)
 		parts := strings.Split(u.Host, ":")
@@ -269,3 +924,115 @@ func (config ServerConfig) GetDbName() string {
 
 	return config.DbName
 }
+
+// QueryParametersToRedact - Returns the 1-based positions of parameters that
+// need to be redacted for the query with the given fingerprint (in hex)
+func (config ServerConfig) QueryParametersToRedact(fingerprint string) []int {
+	return config.RedactQueryParameters[strings.ToLower(fingerprint)]
+}
+
+// IsRawQuerySampleAllowed - Whether query samples of the query with the given
+// fingerprint (in hex) are exempt from redaction
+func (config ServerConfig) IsRawQuerySampleAllowed(fingerprint string) bool {
+	for _, allowed := range config.RawQuerySampleFingerprints {
+		if strings.EqualFold(fingerprint, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLogClassificationDenied - Whether log lines with the given classification
+// should be left out when sending logs
+func (config ServerConfig) IsLogClassificationDenied(classification int32) bool {
+	for _, denied := range config.LogClassificationDenyList {
+		if classification == denied {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBelowLogMinLevel - Whether log lines with the given level (as its numeric
+// value in the snapshot format) should be left out when sending logs
+func (config ServerConfig) IsBelowLogMinLevel(level int32) bool {
+	return config.LogMinLevel > 0 && level < config.LogMinLevel
+}
+
+// IsLogApplicationNameDenied - Whether log lines of backends with the given
+// application name should be left out when sending logs
+func (config ServerConfig) IsLogApplicationNameDenied(applicationName string) bool {
+	for _, denied := range config.LogApplicationNameDenyList {
+		if applicationName == denied {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSettingCollected - Whether the given setting should be collected, based on the allow list
+func (config ServerConfig) IsSettingCollected(name string) bool {
+	if len(config.SettingsAllowList) == 0 {
+		return true
+	}
+	for _, allowed := range config.SettingsAllowList {
+		if name == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSchemaCollected - Whether the given schema should be collected, based on
+// the allow and deny lists (the deny list takes precedence)
+func (config ServerConfig) IsSchemaCollected(schemaName string) bool {
+	for _, denied := range config.SchemaDenyList {
+		if schemaName == denied {
+			return false
+		}
+	}
+
+	if len(config.SchemaAllowList) == 0 {
+		return true
+	}
+	for _, allowed := range config.SchemaAllowList {
+		if schemaName == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTableIgnored - Whether the given table matches the ignore_table_pattern
+// setting, and should therefore not be collected
+func (config ServerConfig) IsTableIgnored(schemaName string, relationName string) bool {
+	if config.IgnoreTablePattern == "" {
+		return false
+	}
+	for _, pattern := range strings.Split(config.IgnoreTablePattern, ",") {
+		if matched, _ := filepath.Match(pattern, schemaName+"."+relationName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDatabaseCollected - Whether the given database should be collected, based
+// on the allow and deny lists (the deny list takes precedence)
+func (config ServerConfig) IsDatabaseCollected(dbName string) bool {
+	for _, denied := range config.DatabaseDenyList {
+		if dbName == denied {
+			return false
+		}
+	}
+
+	if len(config.DatabaseAllowList) == 0 {
+		return true
+	}
+	for _, allowed := range config.DatabaseAllowList {
+		if dbName == allowed {
+			return true
+		}
+	}
+	return false
+}
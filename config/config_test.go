@@ -0,0 +1,424 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/util"
+)
+
+var pqOpenStringTests = []struct {
+	config   config.ServerConfig
+	expected string
+}{
+	{
+		config.ServerConfig{DbHost: "db", DbName: "app"},
+		"dbname='app' host='db' port=5432 sslmode=require connect_timeout=10",
+	},
+	// Falls back to no SSL after sslmode=prefer failed
+	{
+		config.ServerConfig{DbHost: "db", DbName: "app", DbSslModePreferFailed: true},
+		"dbname='app' host='db' port=5432 sslmode=disable connect_timeout=10",
+	},
+	// A minimum TLS version never falls back to no SSL
+	{
+		config.ServerConfig{DbHost: "db", DbName: "app", DbSslModePreferFailed: true, TLSMinVersion: "TLSv1.2"},
+		"dbname='app' host='db' port=5432 sslmode=require connect_timeout=10",
+	},
+	{
+		config.ServerConfig{DbURL: "postgres://app@db:5433/app?sslmode=verify-full", TLSMinVersion: "TLSv1.2", ChannelBinding: "prefer"},
+		"user='app' dbname='app' host='db' port=5433 sslmode=verify-full connect_timeout=10",
+	},
+	// Socket connections use the directory as host, without SSL or password
+	{
+		config.ServerConfig{DbSocketDir: "/var/run/postgresql", DbUsername: "pganalyze", DbName: "app"},
+		"user='pganalyze' dbname='app' host='/var/run/postgresql' port=5432 sslmode=disable connect_timeout=10",
+	},
+	{
+		config.ServerConfig{DbSocketDir: "/tmp", DbHost: "db", DbPort: 5433, DbName: "app", DbSslMode: "allow"},
+		"dbname='app' host='/tmp' port=5433 sslmode=disable connect_timeout=10",
+	},
+	{
+		config.ServerConfig{DbSocketDir: "/var/run/postgresql", DbURL: "postgres://app@db:5433/app"},
+		"user='app' dbname='app' host='/var/run/postgresql' port=5433 sslmode=disable connect_timeout=10",
+	},
+}
+
+func TestGetPqOpenString(t *testing.T) {
+	for _, test := range pqOpenStringTests {
+		actual := test.config.GetPqOpenString("")
+		if actual != test.expected {
+			t.Errorf("GetPqOpenString: expected %q, got %q", test.expected, actual)
+		}
+	}
+}
+
+var connectionSecurityTests = []struct {
+	config      config.ServerConfig
+	expectedErr string
+}{
+	{config.ServerConfig{}, ""},
+	{config.ServerConfig{TLSMinVersion: "TLSv1.2", ChannelBinding: "prefer"}, ""},
+	{config.ServerConfig{TLSMinVersion: "TLSv1.3", DbSslMode: "verify-full", ChannelBinding: "disable"}, ""},
+	{
+		config.ServerConfig{TLSMinVersion: "TLSv1.2", DbSslMode: "disable"},
+		"db_ssl_min_protocol_version = TLSv1.2 requires SSL, but sslmode is disable",
+	},
+	{
+		config.ServerConfig{TLSMinVersion: "TLSv1.2", DbURL: "postgres://db/app?sslmode=disable"},
+		"db_ssl_min_protocol_version = TLSv1.2 requires SSL, but sslmode is disable",
+	},
+	{
+		config.ServerConfig{TLSMinVersion: "1.2"},
+		`unsupported TLS version "1.2" - only TLSv1, TLSv1.1, TLSv1.2 and TLSv1.3 are supported`,
+	},
+	{
+		config.ServerConfig{ChannelBinding: "require"},
+		"db_channel_binding = require is not supported by the collector's Postgres driver - use prefer or disable instead",
+	},
+	{config.ServerConfig{DbSocketDir: "/var/run/postgresql"}, ""},
+	{config.ServerConfig{DbSocketDir: "/var/run/postgresql", DbSslMode: "disable"}, ""},
+	{
+		config.ServerConfig{DbSocketDir: "/var/run/postgresql", DbSslMode: "require"},
+		"sslmode require can't be used with db_socket_dir, since socket connections don't use SSL",
+	},
+	{
+		config.ServerConfig{DbSocketDir: "/var/run/postgresql", TLSMinVersion: "TLSv1.2"},
+		"db_ssl_min_protocol_version can't be used with db_socket_dir, since socket connections don't use SSL",
+	},
+	{config.ServerConfig{DbUseIamAuth: true}, ""},
+	{
+		config.ServerConfig{DbUseIamAuth: true, DbSslMode: "disable"},
+		"db_use_iam_auth requires SSL, but sslmode is disable",
+	},
+	{
+		config.ServerConfig{DbUseIamAuth: true, DbSocketDir: "/var/run/postgresql"},
+		"db_use_iam_auth can't be used with db_socket_dir, since IAM authentication requires SSL",
+	},
+	{
+		config.ServerConfig{ChannelBinding: "always"},
+		`unsupported db_channel_binding "always" - only disable, prefer and require are supported`,
+	},
+}
+
+func TestValidateConnectionSecurity(t *testing.T) {
+	for _, test := range connectionSecurityTests {
+		var actualErr string
+		if err := test.config.ValidateConnectionSecurity(); err != nil {
+			actualErr = err.Error()
+		}
+		if actualErr != test.expectedErr {
+			t.Errorf("ValidateConnectionSecurity(%+v): expected error %q, got %q", test.config, test.expectedErr, actualErr)
+		}
+	}
+}
+
+func TestValidateDbSocketDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = (config.ServerConfig{DbSocketDir: dir}).ValidateDbSocketDir(); err != nil {
+		t.Errorf("Expected existing directory to be accepted, got: %s", err)
+	}
+	if err = (config.ServerConfig{}).ValidateDbSocketDir(); err != nil {
+		t.Errorf("Expected no socket directory to be accepted, got: %s", err)
+	}
+	if err = (config.ServerConfig{DbSocketDir: filepath.Join(dir, "missing")}).ValidateDbSocketDir(); err == nil {
+		t.Errorf("Expected missing directory to be rejected")
+	}
+
+	file := filepath.Join(dir, "file")
+	ioutil.WriteFile(file, []byte{}, 0600)
+	if err = (config.ServerConfig{DbSocketDir: file}).ValidateDbSocketDir(); err == nil {
+		t.Errorf("Expected file to be rejected")
+	}
+}
+
+var validateTagsTests = []struct {
+	tags        map[string]string
+	expectedErr string
+}{
+	{nil, ""},
+	{map[string]string{"env": "production", "team.name": "payments-2"}, ""},
+	{map[string]string{"": "production"}, `tag key "" must be between 1 and 64 characters long`},
+	{map[string]string{strings.Repeat("k", 65): "v"}, `tag key "` + strings.Repeat("k", 65) + `" must be between 1 and 64 characters long`},
+	{map[string]string{"env name": "production"}, `tag key "env name" may only contain letters, digits, "_", "-" and "."`},
+	{map[string]string{"env": strings.Repeat("v", 257)}, `value of tag "env" must be at most 256 characters long`},
+	{map[string]string{"env": "prod\n"}, `value of tag "env" may only contain printable ASCII characters`},
+	{map[string]string{"env": "prödüction"}, `value of tag "env" may only contain printable ASCII characters`},
+}
+
+func TestValidateTags(t *testing.T) {
+	for _, test := range validateTagsTests {
+		var actualErr string
+		if err := (config.ServerConfig{Tags: test.tags}).ValidateTags(); err != nil {
+			actualErr = err.Error()
+		}
+		if actualErr != test.expectedErr {
+			t.Errorf("ValidateTags(%v): expected error %q, got %q", test.tags, test.expectedErr, actualErr)
+		}
+	}
+}
+
+func TestReadTags(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "pganalyze-collector.conf")
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\ntags = env=production\n\n[server1]\ndb_host = db1\ndb_name = app\n\n[server2]\ndb_host = db2\ndb_name = app\ntags = env=staging, team=payments\n"), 0600)
+	conf, err := config.Read(logger, filename)
+	if err != nil {
+		t.Fatalf("Could not read config: %s", err)
+	}
+	expected := []map[string]string{
+		{"env": "production"},
+		{"env": "staging", "team": "payments"},
+	}
+	for idx, server := range conf.Servers {
+		if diff := pretty.Compare(expected[idx], server.Tags); diff != "" {
+			t.Errorf("Unexpected tags for %s: (-want +got)\n%s", server.SectionName, diff)
+		}
+	}
+
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\ntags = env production\n"), 0600)
+	if _, err = config.Read(logger, filename); err == nil || err.Error() != `Invalid tags setting: expected key=value, got "env production"` {
+		t.Errorf("Expected tags without value to be rejected, got: %v", err)
+	}
+
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\ntags = env name=production\n"), 0600)
+	if _, err = config.Read(logger, filename); err == nil || !strings.HasPrefix(err.Error(), "Invalid tags setting in section server1:") {
+		t.Errorf("Expected invalid tag key to be rejected, got: %v", err)
+	}
+
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\n"), 0600)
+	os.Setenv("TAGS", "env production")
+	defer os.Unsetenv("TAGS")
+	if _, err = config.Read(logger, filename); err == nil || err.Error() != `Invalid TAGS setting: expected key=value, got "env production"` {
+		t.Errorf("Expected TAGS without value to be rejected, got: %v", err)
+	}
+}
+
+func TestReadMaxStateAge(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "pganalyze-collector.conf")
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\n\n[server2]\ndb_host = db2\ndb_name = app\nmax_state_age = 0\n\n[server3]\ndb_host = db3\ndb_name = app\nmax_state_age = 30m\n"), 0600)
+	conf, err := config.Read(logger, filename)
+	if err != nil {
+		t.Fatalf("Could not read config: %s", err)
+	}
+	expected := []time.Duration{time.Hour, 0, 30 * time.Minute}
+	for idx, server := range conf.Servers {
+		if server.MaxStateAge != expected[idx] {
+			t.Errorf("Expected max_state_age of %s for %s, got %s", expected[idx], server.SectionName, server.MaxStateAge)
+		}
+	}
+
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\nmax_state_age = soon\n"), 0600)
+	if _, err = config.Read(logger, filename); err == nil || !strings.HasPrefix(err.Error(), "Invalid max_state_age setting:") {
+		t.Errorf("Expected invalid max_state_age to be rejected, got: %v", err)
+	}
+}
+
+func TestReadLogLinesReadyAfter(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "pganalyze-collector.conf")
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\n\n[server2]\ndb_host = db2\ndb_name = app\nlog_lines_ready_after = 0\n\n[server3]\ndb_host = db3\ndb_name = app\nlog_lines_ready_after = 500ms\n"), 0600)
+	conf, err := config.Read(logger, filename)
+	if err != nil {
+		t.Fatalf("Could not read config: %s", err)
+	}
+	expected := []time.Duration{3 * time.Second, 0, 500 * time.Millisecond}
+	for idx, server := range conf.Servers {
+		if server.LogLinesReadyAfter != expected[idx] {
+			t.Errorf("Expected log_lines_ready_after of %s for %s, got %s", expected[idx], server.SectionName, server.LogLinesReadyAfter)
+		}
+	}
+
+	for _, value := range []string{"soon", "-1s"} {
+		ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\nlog_lines_ready_after = "+value+"\n"), 0600)
+		if _, err = config.Read(logger, filename); err == nil || !strings.HasPrefix(err.Error(), "Invalid log_lines_ready_after setting:") {
+			t.Errorf("Expected log_lines_ready_after = %s to be rejected, got: %v", value, err)
+		}
+	}
+
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\n"), 0600)
+	os.Setenv("LOG_LINES_READY_AFTER", "soon")
+	defer os.Unsetenv("LOG_LINES_READY_AFTER")
+	if _, err = config.Read(logger, filename); err == nil || !strings.HasPrefix(err.Error(), "Invalid LOG_LINES_READY_AFTER setting:") {
+		t.Errorf("Expected invalid LOG_LINES_READY_AFTER to be rejected, got: %v", err)
+	}
+}
+
+func TestReadLogBufferPolicy(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "pganalyze-collector.conf")
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\n\n[server2]\ndb_host = db2\ndb_name = app\nlog_buffer_policy = block\n"), 0600)
+	conf, err := config.Read(logger, filename)
+	if err != nil {
+		t.Fatalf("Could not read config: %s", err)
+	}
+	expected := []string{"drop_oldest", "block"}
+	for idx, server := range conf.Servers {
+		if server.LogBufferPolicy != expected[idx] {
+			t.Errorf("Expected log_buffer_policy %s for %s, got %s", expected[idx], server.SectionName, server.LogBufferPolicy)
+		}
+	}
+
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\nlog_buffer_policy = drop_newest\n"), 0600)
+	if _, err = config.Read(logger, filename); err == nil || !strings.HasPrefix(err.Error(), "Invalid log_buffer_policy setting in section server1:") {
+		t.Errorf("Expected unsupported log_buffer_policy to be rejected, got: %v", err)
+	}
+}
+
+func TestReadCustomMetrics(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "pganalyze-collector.conf")
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\ncustom_metric_sessions = SELECT count(*) FROM sessions\n\n[server1]\ndb_host = db1\ndb_name = app\ncustom_metric_queued_jobs = SELECT count(*) FROM jobs WHERE state = 'queued'\n\n[server2]\ndb_host = db2\ndb_name = app\n"), 0600)
+	conf, err := config.Read(logger, filename)
+	if err != nil {
+		t.Fatalf("Could not read config: %s", err)
+	}
+	expected := []map[string]string{
+		{"sessions": "SELECT count(*) FROM sessions", "queued_jobs": "SELECT count(*) FROM jobs WHERE state = 'queued'"},
+		{"sessions": "SELECT count(*) FROM sessions"},
+	}
+	for idx, server := range conf.Servers {
+		if diff := pretty.Compare(expected[idx], server.CustomMetrics); diff != "" {
+			t.Errorf("Unexpected custom metrics for %s: (-want +got)\n%s", server.SectionName, diff)
+		}
+	}
+
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\ncustom_metric_ = SELECT 1\n"), 0600)
+	if _, err = config.Read(logger, filename); err == nil || err.Error() != "Invalid custom_metric_ setting: expected a metric name and a query" {
+		t.Errorf("Expected custom metric without name to be rejected, got: %v", err)
+	}
+}
+
+func TestReadRedactQueryParametersFromEnv(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "pganalyze-collector.conf")
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\n"), 0600)
+
+	os.Setenv("REDACT_QUERY_PARAMETERS", "02ab:first")
+	defer os.Unsetenv("REDACT_QUERY_PARAMETERS")
+	if _, err = config.Read(logger, filename); err == nil || !strings.HasPrefix(err.Error(), "Invalid REDACT_QUERY_PARAMETERS setting:") {
+		t.Errorf("Expected invalid REDACT_QUERY_PARAMETERS to be rejected, got: %v", err)
+	}
+
+	os.Setenv("REDACT_QUERY_PARAMETERS", "02ab:1,2")
+	conf, err := config.Read(logger, filename)
+	if err != nil {
+		t.Fatalf("Could not read config: %s", err)
+	}
+	if diff := pretty.Compare(map[string][]int{"02ab": {1, 2}}, conf.Servers[0].RedactQueryParameters); diff != "" {
+		t.Errorf("Unexpected query parameter redactions: (-want +got)\n%s", diff)
+	}
+}
+
+func TestReadLogMinLevelFromEnv(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "pganalyze-collector.conf")
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\n"), 0600)
+
+	os.Setenv("LOG_MIN_LEVEL", "WARN")
+	defer os.Unsetenv("LOG_MIN_LEVEL")
+	if _, err = config.Read(logger, filename); err == nil || err.Error() != `Invalid LOG_MIN_LEVEL setting: unknown log level "WARN"` {
+		t.Errorf("Expected invalid LOG_MIN_LEVEL to be rejected, got: %v", err)
+	}
+}
+
+func TestReadLogClassificationDenyListFromEnv(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "pganalyze-collector.conf")
+	ioutil.WriteFile(filename, []byte("[pganalyze]\napi_key = abc\n\n[server1]\ndb_host = db1\ndb_name = app\n"), 0600)
+
+	os.Setenv("LOG_CLASSIFICATION_DENY_LIST", "12,autovacuum")
+	defer os.Unsetenv("LOG_CLASSIFICATION_DENY_LIST")
+	if _, err = config.Read(logger, filename); err == nil || !strings.HasPrefix(err.Error(), "Invalid LOG_CLASSIFICATION_DENY_LIST setting:") {
+		t.Errorf("Expected invalid LOG_CLASSIFICATION_DENY_LIST to be rejected, got: %v", err)
+	}
+
+	os.Setenv("LOG_CLASSIFICATION_DENY_LIST", "12, 34")
+	conf, err := config.Read(logger, filename)
+	if err != nil {
+		t.Fatalf("Could not read config: %s", err)
+	}
+	if diff := pretty.Compare([]int32{12, 34}, conf.Servers[0].LogClassificationDenyList); diff != "" {
+		t.Errorf("Unexpected log classification deny list: (-want +got)\n%s", diff)
+	}
+}
+
+func TestIneffectiveCollectionIntervals(t *testing.T) {
+	serverConfig := config.ServerConfig{
+		SystemCollectionInterval:    5 * time.Minute,
+		StatementCollectionInterval: 20 * time.Minute,
+		RelationCollectionInterval:  0,
+	}
+	if diff := pretty.Compare([]string{"system_collection_interval"}, serverConfig.IneffectiveCollectionIntervals()); diff != "" {
+		t.Errorf("Unexpected ineffective collection intervals: (-want +got)\n%s", diff)
+	}
+}
@@ -37,7 +37,7 @@ func (s *Config) logHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func handleHeroku() (conf Config) {
+func handleHeroku() (conf Config, err error) {
 	conf.HerokuLogStream = make(chan HerokuLogStreamItem, bufferLen)
 
 	// This is required to receive logs, as well as so Heroku doesn't think the dyno crashed
@@ -52,7 +52,11 @@ func handleHeroku() (conf Config) {
 	for _, kv := range os.Environ() {
 		parts := strings.Split(kv, "=")
 		if strings.HasSuffix(parts[0], "_URL") {
-			config := getDefaultConfig()
+			var config *ServerConfig
+			config, err = getDefaultConfig()
+			if err != nil {
+				return
+			}
 			config.SectionName = parts[0]
 			config.SystemID = strings.Replace(parts[0], "_URL", "", 1)
 			config.SystemType = "heroku"
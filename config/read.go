@@ -6,19 +6,32 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-ini/ini"
 
 	"github.com/pganalyze/collector/util"
 )
 
-func getDefaultConfig() *ServerConfig {
+func getDefaultConfig() (*ServerConfig, error) {
 	config := &ServerConfig{
 		APIBaseURL:              "https://api.pganalyze.com",
 		AwsRegion:               "us-east-1",
 		SectionName:             "default",
 		QueryStatsInterval:      60,
 		MaxCollectorConnections: 10,
+		SchemaCollectionWorkers: 4,
+		LogLinesReadyAfter:      3 * time.Second,
+		FilterQuerySample:       "none",
+		LogBufferPolicy:         "drop_oldest",
+		ChannelBinding:          "prefer",
+		BloatCollectionInterval: 1 * time.Hour,
+		MaxStateAge:             1 * time.Hour,
+
+		VacuumOverdueAfter:          24 * time.Hour,
+		VacuumOverdueDeadTupleRatio: 0.2,
+
+		DbReuseConnectionMaxLifetime: 10 * time.Minute,
 	}
 
 	// The environment variables are the default way to configure when running inside a Docker container.
@@ -28,6 +41,9 @@ func getDefaultConfig() *ServerConfig {
 	if apiBaseURL := os.Getenv("PGA_API_BASEURL"); apiBaseURL != "" {
 		config.APIBaseURL = apiBaseURL
 	}
+	if apiBaseURLs := os.Getenv("PGA_API_BASEURLS"); apiBaseURLs != "" {
+		config.APIBaseURLs = splitList(apiBaseURLs)
+	}
 	if systemID := os.Getenv("PGA_API_SYSTEM_ID"); systemID != "" {
 		config.SystemID = systemID
 	}
@@ -67,9 +83,36 @@ func getDefaultConfig() *ServerConfig {
 	if dbPort := os.Getenv("DB_PORT"); dbPort != "" {
 		config.DbPort, _ = strconv.Atoi(dbPort)
 	}
+	if dbAllowList := os.Getenv("DB_ALLOW_LIST"); dbAllowList != "" {
+		config.DatabaseAllowList = splitList(dbAllowList)
+	}
+	if dbDenyList := os.Getenv("DB_DENY_LIST"); dbDenyList != "" {
+		config.DatabaseDenyList = splitList(dbDenyList)
+	}
+	if schemaAllowList := os.Getenv("SCHEMA_ALLOW_LIST"); schemaAllowList != "" {
+		config.SchemaAllowList = splitList(schemaAllowList)
+	}
+	if schemaDenyList := os.Getenv("SCHEMA_DENY_LIST"); schemaDenyList != "" {
+		config.SchemaDenyList = splitList(schemaDenyList)
+	}
+	if settingsAllowList := os.Getenv("SETTINGS_ALLOW_LIST"); settingsAllowList != "" {
+		config.SettingsAllowList = splitList(settingsAllowList)
+	}
+	if settingsChangedOnly := os.Getenv("SETTINGS_CHANGED_ONLY"); settingsChangedOnly != "" && settingsChangedOnly != "0" {
+		config.SettingsChangedOnly = true
+	}
 	if dbSslMode := os.Getenv("DB_SSLMODE"); dbSslMode != "" {
 		config.DbSslMode = dbSslMode
 	}
+	if tlsMinVersion := os.Getenv("DB_SSL_MIN_PROTOCOL_VERSION"); tlsMinVersion != "" {
+		config.TLSMinVersion = tlsMinVersion
+	}
+	if channelBinding := os.Getenv("DB_CHANNEL_BINDING"); channelBinding != "" {
+		config.ChannelBinding = channelBinding
+	}
+	if dbSocketDir := os.Getenv("DB_SOCKET_DIR"); dbSocketDir != "" {
+		config.DbSocketDir = dbSocketDir
+	}
 	if dbSslRootCert := os.Getenv("DB_SSLROOTCERT"); dbSslRootCert != "" {
 		config.DbSslRootCert = dbSslRootCert
 	}
@@ -88,6 +131,24 @@ func getDefaultConfig() *ServerConfig {
 	if awsSecretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY"); awsSecretAccessKey != "" {
 		config.AwsSecretAccessKey = awsSecretAccessKey
 	}
+	if awsCloudWatchLogGroup := os.Getenv("AWS_CLOUDWATCH_LOG_GROUP"); awsCloudWatchLogGroup != "" {
+		config.AwsCloudWatchLogGroup = awsCloudWatchLogGroup
+	}
+	if azureLogAnalyticsWorkspaceID := os.Getenv("AZURE_LOG_ANALYTICS_WORKSPACE_ID"); azureLogAnalyticsWorkspaceID != "" {
+		config.AzureLogAnalyticsWorkspaceID = azureLogAnalyticsWorkspaceID
+	}
+	if azureDbServerName := os.Getenv("AZURE_DB_SERVER_NAME"); azureDbServerName != "" {
+		config.AzureDbServerName = azureDbServerName
+	}
+	if azureAuthConnectionString := os.Getenv("AZURE_AUTH_CONNECTION_STRING"); azureAuthConnectionString != "" {
+		config.AzureAuthConnectionString = azureAuthConnectionString
+	}
+	if dbUseIamAuth := os.Getenv("DB_USE_IAM_AUTH"); dbUseIamAuth != "" {
+		config.DbUseIamAuth = dbUseIamAuth == "1"
+	}
+	if logSyslogServer := os.Getenv("LOG_SYSLOG_SERVER"); logSyslogServer != "" {
+		config.LogSyslogServer = logSyslogServer
+	}
 	if ignoreTablePattern := os.Getenv("IGNORE_TABLE_PATTERN"); ignoreTablePattern != "" {
 		config.IgnoreTablePattern = ignoreTablePattern
 	}
@@ -97,8 +158,425 @@ func getDefaultConfig() *ServerConfig {
 	if maxCollectorConnections := os.Getenv("MAX_COLLECTOR_CONNECTION"); maxCollectorConnections != "" {
 		config.MaxCollectorConnections, _ = strconv.Atoi(maxCollectorConnections)
 	}
+	if dbReuseConnection := os.Getenv("DB_REUSE_CONNECTION"); dbReuseConnection != "" && dbReuseConnection != "0" {
+		config.DbReuseConnection = true
+	}
+	if dbReuseConnectionMaxLifetime := os.Getenv("DB_REUSE_CONNECTION_MAX_LIFETIME"); dbReuseConnectionMaxLifetime != "" {
+		config.DbReuseConnectionMaxLifetime, _ = time.ParseDuration(dbReuseConnectionMaxLifetime)
+	}
+	if schemaCollectionWorkers := os.Getenv("SCHEMA_COLLECTION_WORKERS"); schemaCollectionWorkers != "" {
+		config.SchemaCollectionWorkers, _ = strconv.Atoi(schemaCollectionWorkers)
+	}
+	if logLinesReadyAfter := os.Getenv("LOG_LINES_READY_AFTER"); logLinesReadyAfter != "" {
+		readyAfter, err := parseLogLinesReadyAfter(logLinesReadyAfter)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid LOG_LINES_READY_AFTER setting: %s", err)
+		}
+		config.LogLinesReadyAfter = readyAfter
+	}
+	if logEncryptionKeyID := os.Getenv("LOG_ENCRYPTION_KEY_ID"); logEncryptionKeyID != "" {
+		config.LogEncryptionKeyID = logEncryptionKeyID
+	}
+	if logBufferMaxLines := os.Getenv("LOG_BUFFER_MAX_LINES"); logBufferMaxLines != "" {
+		config.LogBufferMaxLines, _ = strconv.Atoi(logBufferMaxLines)
+	}
+	if logBufferPolicy := os.Getenv("LOG_BUFFER_POLICY"); logBufferPolicy != "" {
+		config.LogBufferPolicy = logBufferPolicy
+	}
+	if maxSnapshotUploadBytes := os.Getenv("MAX_SNAPSHOT_UPLOAD_BYTES"); maxSnapshotUploadBytes != "" {
+		config.MaxSnapshotUploadBytes, _ = strconv.ParseInt(maxSnapshotUploadBytes, 10, 64)
+	}
+	if filterQuerySample := os.Getenv("FILTER_QUERY_SAMPLE"); filterQuerySample != "" {
+		config.FilterQuerySample = filterQuerySample
+	}
+	if maxQuerySampleLength := os.Getenv("MAX_QUERY_SAMPLE_LENGTH"); maxQuerySampleLength != "" {
+		config.MaxQuerySampleLength, _ = strconv.Atoi(maxQuerySampleLength)
+	}
+	if separateQuerySampleMinLength := os.Getenv("SEPARATE_QUERY_SAMPLE_MIN_LENGTH"); separateQuerySampleMinLength != "" {
+		config.SeparateQuerySampleMinLength, _ = strconv.Atoi(separateQuerySampleMinLength)
+	}
+	if maxQuerySamplesPerFingerprint := os.Getenv("MAX_QUERY_SAMPLES_PER_FINGERPRINT"); maxQuerySamplesPerFingerprint != "" {
+		config.MaxQuerySamplesPerFingerprint, _ = strconv.Atoi(maxQuerySamplesPerFingerprint)
+	}
+	if maxQuerySamplesPerSnapshot := os.Getenv("MAX_QUERY_SAMPLES_PER_SNAPSHOT"); maxQuerySamplesPerSnapshot != "" {
+		config.MaxQuerySamplesPerSnapshot, _ = strconv.Atoi(maxQuerySamplesPerSnapshot)
+	}
+	if anonymizeNamesSecret := os.Getenv("ANONYMIZE_NAMES_SECRET"); anonymizeNamesSecret != "" {
+		config.AnonymizeNamesSecret = anonymizeNamesSecret
+	}
+	if aggregatePartitions := os.Getenv("AGGREGATE_PARTITIONS"); aggregatePartitions == "1" {
+		config.AggregatePartitions = true
+	}
+	if collectWaitEvents := os.Getenv("COLLECT_WAIT_EVENTS"); collectWaitEvents == "1" {
+		config.CollectWaitEvents = true
+	}
+	if collectProgress := os.Getenv("COLLECT_PROGRESS"); collectProgress == "1" {
+		config.CollectProgress = true
+	}
+	if throttleMaxActiveBackends := os.Getenv("THROTTLE_MAX_ACTIVE_BACKENDS"); throttleMaxActiveBackends != "" {
+		config.ThrottleMaxActiveBackends, _ = strconv.Atoi(throttleMaxActiveBackends)
+	}
+	if throttleMaxReplicationLagBytes := os.Getenv("THROTTLE_MAX_REPLICATION_LAG_BYTES"); throttleMaxReplicationLagBytes != "" {
+		config.ThrottleMaxReplicationLagBytes, _ = strconv.ParseInt(throttleMaxReplicationLagBytes, 10, 64)
+	}
+	if relationStatsMinChange := os.Getenv("RELATION_STATS_MIN_CHANGE"); relationStatsMinChange != "" {
+		config.RelationStatsMinChange, _ = strconv.ParseInt(relationStatsMinChange, 10, 64)
+	}
+	if bloatCollectionInterval := os.Getenv("BLOAT_COLLECTION_INTERVAL"); bloatCollectionInterval != "" {
+		config.BloatCollectionInterval, _ = time.ParseDuration(bloatCollectionInterval)
+	}
+	if vacuumOverdueAfter := os.Getenv("VACUUM_OVERDUE_AFTER"); vacuumOverdueAfter != "" {
+		config.VacuumOverdueAfter, _ = time.ParseDuration(vacuumOverdueAfter)
+	}
+	if vacuumOverdueDeadTupleRatio := os.Getenv("VACUUM_OVERDUE_DEAD_TUPLE_RATIO"); vacuumOverdueDeadTupleRatio != "" {
+		config.VacuumOverdueDeadTupleRatio, _ = strconv.ParseFloat(vacuumOverdueDeadTupleRatio, 64)
+	}
+	if systemCollectionInterval := os.Getenv("SYSTEM_COLLECTION_INTERVAL"); systemCollectionInterval != "" {
+		config.SystemCollectionInterval, _ = time.ParseDuration(systemCollectionInterval)
+	}
+	if statementCollectionInterval := os.Getenv("STATEMENT_COLLECTION_INTERVAL"); statementCollectionInterval != "" {
+		config.StatementCollectionInterval, _ = time.ParseDuration(statementCollectionInterval)
+	}
+	if relationCollectionInterval := os.Getenv("RELATION_COLLECTION_INTERVAL"); relationCollectionInterval != "" {
+		config.RelationCollectionInterval, _ = time.ParseDuration(relationCollectionInterval)
+	}
+	if maxStateAge := os.Getenv("MAX_STATE_AGE"); maxStateAge != "" {
+		config.MaxStateAge, _ = time.ParseDuration(maxStateAge)
+	}
+	if statementResetFrequency := os.Getenv("STATEMENT_RESET_FREQUENCY"); statementResetFrequency != "" {
+		config.StatementResetFrequency, _ = strconv.Atoi(statementResetFrequency)
+	}
+	if disableStatementReset := os.Getenv("DISABLE_STATEMENT_RESET"); disableStatementReset != "" {
+		config.DisableStatementReset = disableStatementReset == "1"
+	}
+	if logClassificationDenyList := os.Getenv("LOG_CLASSIFICATION_DENY_LIST"); logClassificationDenyList != "" {
+		denyList, err := parseInt32List(logClassificationDenyList)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid LOG_CLASSIFICATION_DENY_LIST setting: %s", err)
+		}
+		config.LogClassificationDenyList = denyList
+	}
+	if logMinLevel := os.Getenv("LOG_MIN_LEVEL"); logMinLevel != "" {
+		level, err := parseLogLevel(logMinLevel)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid LOG_MIN_LEVEL setting: %s", err)
+		}
+		config.LogMinLevel = level
+	}
+	if logApplicationNameDenyList := os.Getenv("LOG_APPLICATION_NAME_DENY_LIST"); logApplicationNameDenyList != "" {
+		config.LogApplicationNameDenyList = splitList(logApplicationNameDenyList)
+	}
+	if redactQueryParameters := os.Getenv("REDACT_QUERY_PARAMETERS"); redactQueryParameters != "" {
+		redactions, err := parseQueryParameterRedactions(redactQueryParameters)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid REDACT_QUERY_PARAMETERS setting: %s", err)
+		}
+		config.RedactQueryParameters = redactions
+	}
+	if rawQuerySampleFingerprints := os.Getenv("RAW_QUERY_SAMPLE_FINGERPRINTS"); rawQuerySampleFingerprints != "" {
+		config.RawQuerySampleFingerprints = splitList(rawQuerySampleFingerprints)
+	}
+	if tags := os.Getenv("TAGS"); tags != "" {
+		parsedTags, err := parseTags(tags)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid TAGS setting: %s", err)
+		}
+		config.Tags = parsedTags
+	}
+
+	return config, nil
+}
+
+// splitList - Splits a comma-separated list, ignoring whitespace around items
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		items = append(items, strings.TrimSpace(item))
+	}
+	return items
+}
+
+// parseInt32List - Parses a comma-separated list of numbers
+func parseInt32List(list string) ([]int32, error) {
+	values := []int32{}
+	for _, item := range splitList(list) {
+		value, err := strconv.ParseInt(item, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, int32(value))
+	}
+	return values, nil
+}
+
+// logLevels - Values of the log levels in the snapshot format, which are in
+// the order of their severity
+var logLevels = map[string]int32{
+	"debug":   1,
+	"info":    2,
+	"notice":  3,
+	"warning": 4,
+	"error":   5,
+	"log":     6,
+	"fatal":   7,
+	"panic":   8,
+}
+
+// parseLogLevel - Parses a log level name, as used by log_min_messages
+func parseLogLevel(name string) (int32, error) {
+	level, ok := logLevels[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("unknown log level %q", name)
+	}
+	return level, nil
+}
 
-	return config
+// readLogMinLevel - Sets LogMinLevel from the section, if specified
+func readLogMinLevel(section *ini.Section, config *ServerConfig) error {
+	if !section.HasKey("log_min_level") {
+		return nil
+	}
+	level, err := parseLogLevel(section.Key("log_min_level").String())
+	if err != nil {
+		return fmt.Errorf("Invalid log_min_level setting: %s", err)
+	}
+	config.LogMinLevel = level
+	return nil
+}
+
+// readLogClassificationDenyList - Sets LogClassificationDenyList from the section, if specified
+func readLogClassificationDenyList(section *ini.Section, config *ServerConfig) error {
+	if !section.HasKey("log_classification_deny_list") {
+		return nil
+	}
+	denyList, err := parseInt32List(section.Key("log_classification_deny_list").String())
+	if err != nil {
+		return fmt.Errorf("Invalid log_classification_deny_list setting: %s", err)
+	}
+	config.LogClassificationDenyList = denyList
+	return nil
+}
+
+// parseQueryParameterRedactions - Parses semicolon-separated entries of a query
+// fingerprint and a comma-separated list of parameter positions, e.g. "02ab:1,2;02cd:3"
+func parseQueryParameterRedactions(value string) (map[string][]int, error) {
+	redactions := make(map[string][]int)
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("expected fingerprint:positions, got %q", entry)
+		}
+		positions, err := parseInt32List(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		fingerprint := strings.ToLower(strings.TrimSpace(parts[0]))
+		for _, position := range positions {
+			if position < 1 {
+				return nil, fmt.Errorf("parameter positions start at 1, got %d", position)
+			}
+			redactions[fingerprint] = append(redactions[fingerprint], int(position))
+		}
+	}
+	return redactions, nil
+}
+
+// readRedactQueryParameters - Sets RedactQueryParameters from the section, if specified
+func readRedactQueryParameters(section *ini.Section, config *ServerConfig) error {
+	if !section.HasKey("redact_query_parameters") {
+		return nil
+	}
+	redactions, err := parseQueryParameterRedactions(section.Key("redact_query_parameters").String())
+	if err != nil {
+		return fmt.Errorf("Invalid redact_query_parameters setting: %s", err)
+	}
+	config.RedactQueryParameters = redactions
+	return nil
+}
+
+// parseTags - Parses comma-separated key=value pairs, e.g. "env=production,team=payments"
+func parseTags(value string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("expected key=value, got %q", entry)
+		}
+		tags[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return tags, nil
+}
+
+const customMetricKeyPrefix = "custom_metric_"
+
+// readCustomMetrics - Adds the custom metric queries specified in the section
+//
+// A new map is used, so the queries of one server don't end up in the map that
+// was copied from the defaults.
+func readCustomMetrics(section *ini.Section, config *ServerConfig) error {
+	customMetrics := make(map[string]string)
+	for name, query := range config.CustomMetrics {
+		customMetrics[name] = query
+	}
+	for _, key := range section.Keys() {
+		if !strings.HasPrefix(key.Name(), customMetricKeyPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(key.Name(), customMetricKeyPrefix)
+		query := strings.TrimSpace(key.String())
+		if name == "" || query == "" {
+			return fmt.Errorf("Invalid %s setting: expected a metric name and a query", key.Name())
+		}
+		customMetrics[name] = query
+	}
+	if len(customMetrics) > 0 {
+		config.CustomMetrics = customMetrics
+	}
+	return nil
+}
+
+// readTags - Sets Tags from the section, if specified
+func readTags(section *ini.Section, config *ServerConfig) error {
+	if !section.HasKey("tags") {
+		return nil
+	}
+	tags, err := parseTags(section.Key("tags").String())
+	if err != nil {
+		return fmt.Errorf("Invalid tags setting: %s", err)
+	}
+	config.Tags = tags
+	return nil
+}
+
+// readMaxStateAge - Sets MaxStateAge from the section, if specified
+//
+// This is read separately since mapping the section skips durations of zero,
+// which disable the check here.
+func readMaxStateAge(section *ini.Section, config *ServerConfig) error {
+	if !section.HasKey("max_state_age") {
+		return nil
+	}
+	maxStateAge, err := section.Key("max_state_age").Duration()
+	if err != nil {
+		return fmt.Errorf("Invalid max_state_age setting: %s", err)
+	}
+	config.MaxStateAge = maxStateAge
+	return nil
+}
+
+// readLogLinesReadyAfter - Sets LogLinesReadyAfter from the section, if specified
+//
+// This is read separately since mapping the section skips durations of zero,
+// which send log lines right away.
+func readLogLinesReadyAfter(section *ini.Section, config *ServerConfig) error {
+	if !section.HasKey("log_lines_ready_after") {
+		return nil
+	}
+	readyAfter, err := parseLogLinesReadyAfter(section.Key("log_lines_ready_after").String())
+	if err != nil {
+		return fmt.Errorf("Invalid log_lines_ready_after setting: %s", err)
+	}
+	config.LogLinesReadyAfter = readyAfter
+	return nil
+}
+
+func parseLogLinesReadyAfter(value string) (time.Duration, error) {
+	readyAfter, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if readyAfter < 0 {
+		return 0, fmt.Errorf("duration %s can't be negative", value)
+	}
+	return readyAfter, nil
+}
+
+const defaultHealthCheckReadyWithin = 30 * time.Minute
+const defaultScheduleStartupJitter = 30 * time.Second
+const defaultScheduleJitter = 5 * time.Second
+
+// readProcessConfig - Reads the settings that apply to the whole collector
+// process (e.g. the health check server), from the environment, and from the
+// pganalyze section of the config file (if any)
+func readProcessConfig(section *ini.Section, conf *Config) error {
+	conf.HealthCheckReadyWithin = defaultHealthCheckReadyWithin
+	conf.ScheduleStartupJitter = defaultScheduleStartupJitter
+	conf.ScheduleJitter = defaultScheduleJitter
+
+	if healthCheckAddress := os.Getenv("HEALTH_CHECK_ADDRESS"); healthCheckAddress != "" {
+		conf.HealthCheckAddress = healthCheckAddress
+	}
+	if healthCheckReadyWithin := os.Getenv("HEALTH_CHECK_READY_WITHIN"); healthCheckReadyWithin != "" {
+		conf.HealthCheckReadyWithin, _ = time.ParseDuration(healthCheckReadyWithin)
+	}
+	if metricsAddress := os.Getenv("METRICS_ADDRESS"); metricsAddress != "" {
+		conf.MetricsAddress = metricsAddress
+	}
+	if scheduleStartupJitter := os.Getenv("SCHEDULE_STARTUP_JITTER"); scheduleStartupJitter != "" {
+		conf.ScheduleStartupJitter, _ = time.ParseDuration(scheduleStartupJitter)
+	}
+	if scheduleJitter := os.Getenv("SCHEDULE_JITTER"); scheduleJitter != "" {
+		conf.ScheduleJitter, _ = time.ParseDuration(scheduleJitter)
+	}
+	if pauseFile := os.Getenv("PAUSE_FILE"); pauseFile != "" {
+		conf.PauseFile = pauseFile
+	}
+	if logDeadLetterDir := os.Getenv("LOG_DEAD_LETTER_DIR"); logDeadLetterDir != "" {
+		conf.LogDeadLetterDir = logDeadLetterDir
+	}
+	if logDeadLetterPlaintext := os.Getenv("LOG_DEAD_LETTER_PLAINTEXT"); logDeadLetterPlaintext != "" && logDeadLetterPlaintext != "0" {
+		conf.LogDeadLetterPlaintext = true
+	}
+
+	if section == nil {
+		return nil
+	}
+	if section.HasKey("health_check_address") {
+		conf.HealthCheckAddress = section.Key("health_check_address").String()
+	}
+	if section.HasKey("metrics_address") {
+		conf.MetricsAddress = section.Key("metrics_address").String()
+	}
+	if section.HasKey("pause_file") {
+		conf.PauseFile = section.Key("pause_file").String()
+	}
+	if section.HasKey("log_dead_letter_dir") {
+		conf.LogDeadLetterDir = section.Key("log_dead_letter_dir").String()
+	}
+	if section.HasKey("log_dead_letter_plaintext") {
+		conf.LogDeadLetterPlaintext = section.Key("log_dead_letter_plaintext").MustBool(false)
+	}
+	if section.HasKey("health_check_ready_within") {
+		readyWithin, err := section.Key("health_check_ready_within").Duration()
+		if err != nil {
+			return fmt.Errorf("Invalid health_check_ready_within setting: %s", err)
+		}
+		conf.HealthCheckReadyWithin = readyWithin
+	}
+	if section.HasKey("schedule_startup_jitter") {
+		jitter, err := section.Key("schedule_startup_jitter").Duration()
+		if err != nil {
+			return fmt.Errorf("Invalid schedule_startup_jitter setting: %s", err)
+		}
+		conf.ScheduleStartupJitter = jitter
+	}
+	if section.HasKey("schedule_jitter") {
+		jitter, err := section.Key("schedule_jitter").Duration()
+		if err != nil {
+			return fmt.Errorf("Invalid schedule_jitter setting: %s", err)
+		}
+		conf.ScheduleJitter = jitter
+	}
+
+	return nil
 }
 
 // Read - Reads the configuration from the specified filename, or fall back to the default config
@@ -112,12 +590,47 @@ func Read(logger *util.Logger, filename string) (Config, error) {
 			return conf, err
 		}
 
-		defaultConfig := getDefaultConfig()
+		defaultConfig, err := getDefaultConfig()
+		if err != nil {
+			return conf, err
+		}
 
 		err = configFile.Section("pganalyze").MapTo(defaultConfig)
 		if err != nil {
 			logger.PrintVerbose("Failed to map pganalyze section: %s", err)
 		}
+		err = readLogClassificationDenyList(configFile.Section("pganalyze"), defaultConfig)
+		if err != nil {
+			return conf, err
+		}
+		err = readLogMinLevel(configFile.Section("pganalyze"), defaultConfig)
+		if err != nil {
+			return conf, err
+		}
+		err = readRedactQueryParameters(configFile.Section("pganalyze"), defaultConfig)
+		if err != nil {
+			return conf, err
+		}
+		err = readTags(configFile.Section("pganalyze"), defaultConfig)
+		if err != nil {
+			return conf, err
+		}
+		err = readCustomMetrics(configFile.Section("pganalyze"), defaultConfig)
+		if err != nil {
+			return conf, err
+		}
+		err = readMaxStateAge(configFile.Section("pganalyze"), defaultConfig)
+		if err != nil {
+			return conf, err
+		}
+		err = readLogLinesReadyAfter(configFile.Section("pganalyze"), defaultConfig)
+		if err != nil {
+			return conf, err
+		}
+		err = readProcessConfig(configFile.Section("pganalyze"), &conf)
+		if err != nil {
+			return conf, err
+		}
 
 		sections := configFile.Sections()
 		for _, section := range sections {
@@ -128,6 +641,34 @@ func Read(logger *util.Logger, filename string) (Config, error) {
 			if err != nil {
 				return conf, err
 			}
+			err = readLogClassificationDenyList(section, config)
+			if err != nil {
+				return conf, err
+			}
+			err = readLogMinLevel(section, config)
+			if err != nil {
+				return conf, err
+			}
+			err = readRedactQueryParameters(section, config)
+			if err != nil {
+				return conf, err
+			}
+			err = readTags(section, config)
+			if err != nil {
+				return conf, err
+			}
+			err = readCustomMetrics(section, config)
+			if err != nil {
+				return conf, err
+			}
+			err = readMaxStateAge(section, config)
+			if err != nil {
+				return conf, err
+			}
+			err = readLogLinesReadyAfter(section, config)
+			if err != nil {
+				return conf, err
+			}
 
 			dbNameParts := []string{}
 			for _, s := range strings.Split(config.DbName, ",") {
@@ -157,6 +698,18 @@ func Read(logger *util.Logger, filename string) (Config, error) {
 			}
 
 			config.SectionName = section.Name()
+			err = config.ValidateDbSocketDir()
+			if err != nil {
+				return conf, fmt.Errorf("Invalid db_socket_dir setting in section %s: %s", config.SectionName, err)
+			}
+			err = config.ValidateTags()
+			if err != nil {
+				return conf, fmt.Errorf("Invalid tags setting in section %s: %s", config.SectionName, err)
+			}
+			err = config.ValidateLogBufferPolicy()
+			if err != nil {
+				return conf, fmt.Errorf("Invalid log_buffer_policy setting in section %s: %s", config.SectionName, err)
+			}
 			config.SystemType, config.SystemScope, config.SystemID = identifySystem(*config)
 
 			config.Identifier = ServerIdentifier{
@@ -188,11 +741,30 @@ func Read(logger *util.Logger, filename string) (Config, error) {
 		}
 	} else {
 		if os.Getenv("DYNO") != "" && os.Getenv("PORT") != "" {
-			conf = handleHeroku()
+			conf, err = handleHeroku()
+			if err != nil {
+				return conf, err
+			}
 		} else if os.Getenv("PGA_API_KEY") != "" {
-			config := getDefaultConfig()
+			config, err := getDefaultConfig()
+			if err != nil {
+				return conf, err
+			}
+			err = config.ValidateDbSocketDir()
+			if err != nil {
+				return conf, fmt.Errorf("Invalid DB_SOCKET_DIR setting: %s", err)
+			}
+			err = config.ValidateTags()
+			if err != nil {
+				return conf, fmt.Errorf("Invalid TAGS setting: %s", err)
+			}
+			err = config.ValidateLogBufferPolicy()
+			if err != nil {
+				return conf, fmt.Errorf("Invalid LOG_BUFFER_POLICY setting: %s", err)
+			}
 			config.SystemType, config.SystemScope, config.SystemID = identifySystem(*config)
 			conf.Servers = append(conf.Servers, *config)
+			readProcessConfig(nil, &conf)
 		} else {
 			return conf, fmt.Errorf("No configuration file found at %s, and no environment variables set", filename)
 		}
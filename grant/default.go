@@ -10,8 +10,18 @@ import (
 	"github.com/pganalyze/collector/util"
 )
 
-func GetDefaultGrant(server state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (state.Grant, error) {
-	req, err := http.NewRequest("GET", server.Config.APIBaseURL+"/v2/snapshots/grant", nil)
+// GetDefaultGrant - Requests a grant from the first API endpoint that answers
+// successfully (see APIBaseURLs)
+func GetDefaultGrant(server state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (grant state.Grant, err error) {
+	err = tryEndpoints(server, logger, func(apiBaseURL string) error {
+		grant, err = getDefaultGrantFrom(apiBaseURL, server)
+		return err
+	})
+	return
+}
+
+func getDefaultGrantFrom(apiBaseURL string, server state.Server) (state.Grant, error) {
+	req, err := http.NewRequest("GET", apiBaseURL+"/v2/snapshots/grant", nil)
 	if err != nil {
 		return state.Grant{}, err
 	}
@@ -44,6 +54,7 @@ func GetDefaultGrant(server state.Server, globalCollectionOpts state.CollectionO
 		return state.Grant{}, err
 	}
 	grant.Valid = true
+	grant.APIBaseURL = apiBaseURL
 
 	return grant, nil
 }
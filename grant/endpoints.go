@@ -0,0 +1,35 @@
+package grant
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// tryEndpoints - Calls the request function for each configured API endpoint
+// in order, until one of them succeeds
+//
+// Failures of all but the last endpoint are logged as warnings, and if all
+// endpoints fail, the returned error contains the error of each of them.
+func tryEndpoints(server state.Server, logger *util.Logger, request func(apiBaseURL string) error) error {
+	apiBaseURLs := server.Config.GetAPIBaseURLs()
+
+	var errs []string
+	for idx, apiBaseURL := range apiBaseURLs {
+		err := request(apiBaseURL)
+		if err == nil {
+			return nil
+		}
+		if len(apiBaseURLs) == 1 {
+			return err
+		}
+		if idx < len(apiBaseURLs)-1 {
+			logger.PrintWarning("Could not get grant from %s, trying next endpoint: %s", apiBaseURL, err)
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", apiBaseURL, err))
+	}
+
+	return fmt.Errorf("All %d API endpoints failed: %s", len(apiBaseURLs), strings.Join(errs, "; "))
+}
@@ -1,20 +1,47 @@
 package grant
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/pganalyze/collector/state"
 	"github.com/pganalyze/collector/util"
 )
 
-func GetLogsGrant(server state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (state.GrantLogs, error) {
-	req, err := http.NewRequest("GET", server.Config.APIBaseURL+"/v2/snapshots/grant_logs", nil)
+// GetLogsGrant - Requests a logs grant from the first API endpoint that answers
+// successfully (see APIBaseURLs)
+//
+// An endpoint denying log collection is a valid answer, and doesn't cause the
+// next endpoint to be tried.
+func GetLogsGrant(ctx context.Context, server state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (grant state.GrantLogs, err error) {
+	err = tryEndpoints(server, logger, func(apiBaseURL string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		grant, err = getLogsGrantFrom(ctx, apiBaseURL, server)
+		return err
+	})
+	return
+}
+
+func getLogsGrantFrom(ctx context.Context, apiBaseURL string, server state.Server) (state.GrantLogs, error) {
+	grantURL := apiBaseURL + "/v2/snapshots/grant_logs"
+	if server.Config.LogEncryptionKeyID != "" {
+		// The key needs to be part of the signed S3 upload policy, so the API adds
+		// the SSE-KMS fields for it to the grant
+		grantURL += "?" + url.Values{"log_encryption_key_id": {server.Config.LogEncryptionKeyID}}.Encode()
+	}
+
+	req, err := http.NewRequest("GET", grantURL, nil)
 	if err != nil {
 		return state.GrantLogs{}, err
 	}
+	req = req.WithContext(ctx)
 
 	req.Header.Set("Pganalyze-Api-Key", server.Config.APIKey)
 	req.Header.Set("Pganalyze-System-Id", server.Config.SystemID)
@@ -48,6 +75,25 @@ func GetLogsGrant(server state.Server, globalCollectionOpts state.CollectionOpts
 		return state.GrantLogs{}, err
 	}
 	grant.Valid = true
+	grant.APIBaseURL = apiBaseURL
+
+	return grant, nil
+}
+
+// GetCachedLogsGrant - Returns the cached logs grant of the server while it can
+// still be used, and requests a new one otherwise
+func GetCachedLogsGrant(ctx context.Context, server state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (state.GrantLogs, error) {
+	if grant, ok := server.LogsGrantCache.Get(time.Now()); ok {
+		return grant, nil
+	}
+
+	grant, err := GetLogsGrant(ctx, server, globalCollectionOpts, logger)
+	if err != nil {
+		return grant, err
+	}
+
+	// Grants that are not valid clear the cache, so we ask again next time
+	server.LogsGrantCache.Set(grant)
 
 	return grant, nil
 }
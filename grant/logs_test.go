@@ -0,0 +1,109 @@
+package grant_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/grant"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+func grantServer(status int, body string, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestGetLogsGrantFailover(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	var firstRequests, secondRequests int
+	first := grantServer(http.StatusInternalServerError, "unavailable", &firstRequests)
+	defer first.Close()
+	second := grantServer(http.StatusOK, `{"logdata": {"local_dir": "/tmp/logs"}}`, &secondRequests)
+	defer second.Close()
+
+	server := state.Server{Config: config.ServerConfig{APIBaseURLs: []string{first.URL, second.URL}}}
+	logsGrant, err := grant.GetLogsGrant(context.Background(), server, state.CollectionOpts{}, logger)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !logsGrant.Valid || logsGrant.Logdata.LocalDir != "/tmp/logs" {
+		t.Errorf("Expected grant of the second endpoint, got %+v", logsGrant)
+	}
+	if logsGrant.APIBaseURL != second.URL {
+		t.Errorf("Expected grant to remember endpoint %s, got %s", second.URL, logsGrant.APIBaseURL)
+	}
+	if firstRequests != 1 || secondRequests != 1 {
+		t.Errorf("Expected one request to each endpoint, got %d and %d", firstRequests, secondRequests)
+	}
+}
+
+func TestGetLogsGrantDeniedNoFailover(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	var firstRequests, secondRequests int
+	first := grantServer(http.StatusForbidden, "", &firstRequests)
+	defer first.Close()
+	second := grantServer(http.StatusOK, `{}`, &secondRequests)
+	defer second.Close()
+
+	server := state.Server{Config: config.ServerConfig{APIBaseURLs: []string{first.URL, second.URL}}}
+	logsGrant, err := grant.GetLogsGrant(context.Background(), server, state.CollectionOpts{}, logger)
+	if err != nil || logsGrant.Valid {
+		t.Errorf("Expected log collection to be denied, got %+v (error: %v)", logsGrant, err)
+	}
+	if secondRequests != 0 {
+		t.Errorf("Expected second endpoint not to be asked, got %d requests", secondRequests)
+	}
+}
+
+func TestGetDefaultGrantAllEndpointsFail(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	var firstRequests, secondRequests int
+	first := grantServer(http.StatusInternalServerError, "first down", &firstRequests)
+	defer first.Close()
+	second := grantServer(http.StatusBadGateway, "second down", &secondRequests)
+	defer second.Close()
+
+	server := state.Server{Config: config.ServerConfig{APIBaseURLs: []string{first.URL, second.URL}}}
+	_, err := grant.GetDefaultGrant(server, state.CollectionOpts{}, logger)
+	if err == nil {
+		t.Fatalf("Expected error when all endpoints fail")
+	}
+	for _, expected := range []string{first.URL + ": Error when getting grant: first down", second.URL + ": Error when getting grant: second down"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to contain %q, got %q", expected, err)
+		}
+	}
+}
+
+func TestGetLogsGrantEncryptionKeyID(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	var keyID string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyID = r.URL.Query().Get("log_encryption_key_id")
+		w.Write([]byte(`{}`))
+	}))
+	defer api.Close()
+
+	server := state.Server{Config: config.ServerConfig{APIBaseURL: api.URL, LogEncryptionKeyID: "alias/my-key"}}
+	_, err := grant.GetLogsGrant(context.Background(), server, state.CollectionOpts{}, logger)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if keyID != "alias/my-key" {
+		t.Errorf("Expected the KMS key to be requested with the grant, got %q", keyID)
+	}
+}
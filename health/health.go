@@ -0,0 +1,120 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// Checker - Answers health checks for the collector process
+//
+// /health succeeds as long as the process is up, /ready only succeeds when each
+// server submitted a full snapshot recently. The last log snapshot is reported
+// for information, but doesn't make a server ready on its own. Neither of them
+// returns anything but section names and timestamps, in particular no
+// connection settings.
+type Checker struct {
+	mutex       sync.Mutex
+	servers     []state.Server
+	readyWithin time.Duration
+	address     string
+
+	now func() time.Time
+}
+
+// NewChecker - Sets up a checker that reports no servers until Update is called
+func NewChecker() *Checker {
+	return &Checker{now: time.Now}
+}
+
+// Update - Sets the servers to report on, called again after reloading the config
+func (c *Checker) Update(servers []state.Server, readyWithin time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.servers = servers
+	c.readyWithin = readyWithin
+}
+
+// ListenAndServe - Starts serving health checks on the given address in the
+// background, unless that already happened (changing the address requires a
+// restart of the collector)
+func (c *Checker) ListenAndServe(address string, logger *util.Logger) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.address != "" {
+		if c.address != address {
+			logger.PrintWarning("Health check server keeps running on %s, restart the collector to use %s instead", c.address, address)
+		}
+		return
+	}
+	c.address = address
+
+	go func() {
+		err := http.ListenAndServe(address, c)
+		logger.PrintError("Health check server stopped: %s", err)
+	}()
+	logger.PrintVerbose("Serving health checks on %s", address)
+}
+
+type serverStatus struct {
+	Name           string     `json:"name"`
+	Ready          bool       `json:"ready"`
+	LastSnapshotAt *time.Time `json:"last_snapshot_at,omitempty"`
+	LastLogsAt     *time.Time `json:"last_logs_at,omitempty"`
+}
+
+type readyResponse struct {
+	Ready   bool           `json:"ready"`
+	Servers []serverStatus `json:"servers"`
+}
+
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/health":
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	case "/ready":
+		response := c.readiness()
+		status := http.StatusOK
+		if !response.Ready {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, response)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (c *Checker) readiness() readyResponse {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	response := readyResponse{Ready: len(c.servers) > 0, Servers: []serverStatus{}}
+	for _, server := range c.servers {
+		snapshotAt, logsAt := server.SubmissionTimes.Get()
+		status := serverStatus{Name: server.Config.SectionName}
+		if !snapshotAt.IsZero() {
+			status.LastSnapshotAt = &snapshotAt
+		}
+		if !logsAt.IsZero() {
+			status.LastLogsAt = &logsAt
+		}
+		status.Ready = !snapshotAt.IsZero() && now.Sub(snapshotAt) <= c.readyWithin
+		if !status.Ready {
+			response.Ready = false
+		}
+		response.Servers = append(response.Servers, status)
+	}
+	return response
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"runtime"
 
+	"github.com/pganalyze/collector/input/system/logs"
 	"github.com/pganalyze/collector/state"
 	"github.com/shirou/gopsutil/process"
 )
@@ -24,9 +25,11 @@ func getMemoryRssBytes() uint64 {
 	return mem.RSS
 }
 
-func getCollectorStats() state.CollectorStats {
+func getCollectorStats(server state.Server) state.CollectorStats {
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
+	logLinesStitched, logLinesDropped := logs.GetStitchingStats()
+	uploadedLogBytes, uploadedSnapshotBytes := state.GetUploadedBytes()
 
 	return state.CollectorStats{
 		GoVersion:                runtime.Version(),
@@ -36,5 +39,14 @@ func getCollectorStats() state.CollectorStats {
 		MemoryHeapObjects:        memStats.HeapObjects,
 		MemorySystemBytes:        memStats.Sys,
 		MemoryRssBytes:           getMemoryRssBytes(),
+		LogLinesStitched:         logLinesStitched,
+		LogLinesDropped:          logLinesDropped,
+		LogLinesFiltered:         logs.GetFilteredLogLines(),
+		LogLinesUnparseable:      logs.GetUnparseableLogLines(),
+		LogLinesOverflowed:       logs.GetOverflowedLogLines(),
+		QuerySamplesDropped:      logs.GetDroppedQuerySamples(),
+		UploadedLogBytes:         uploadedLogBytes,
+		UploadedSnapshotBytes:    uploadedSnapshotBytes,
+		LogProcessing:            logs.TakeLogProcessingStats(server),
 	}
 }
@@ -0,0 +1,45 @@
+package input
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pganalyze/collector/input/postgres"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// Query that gets run through the EXPLAIN pipeline when testing it
+const explainTestQuery = "SELECT 1"
+
+// TestExplain - Checks that query plans can be collected for the server, both
+// through auto_explain, and by running EXPLAIN on a known query, and returns
+// the reason when they can't
+func TestExplain(connection *sql.DB, logger *util.Logger) error {
+	minDuration, loaded, err := postgres.GetAutoExplainLogMinDuration(connection)
+	if err != nil {
+		return fmt.Errorf("could not check auto_explain settings: %s", err)
+	}
+	if !loaded {
+		return fmt.Errorf("auto_explain is not loaded - add it to shared_preload_libraries and restart Postgres")
+	}
+	if minDuration < 0 {
+		return fmt.Errorf("auto_explain.log_min_duration is -1, which disables logging of query plans")
+	}
+	logger.PrintVerbose("auto_explain is loaded, and logs plans of queries slower than %d ms", minDuration)
+
+	samples := postgres.RunExplain(connection, []state.PostgresQuerySample{{Query: explainTestQuery}})
+	if len(samples) != 1 || !samples[0].HasExplain {
+		return fmt.Errorf("test query was not explained")
+	}
+	if samples[0].ExplainError != "" {
+		return fmt.Errorf("could not EXPLAIN test query: %s", samples[0].ExplainError)
+	}
+	var plan []interface{}
+	if err = json.Unmarshal([]byte(samples[0].ExplainOutput), &plan); err != nil || len(plan) == 0 {
+		return fmt.Errorf("EXPLAIN of test query returned an unexpected result: %s", samples[0].ExplainOutput)
+	}
+
+	return nil
+}
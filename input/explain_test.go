@@ -0,0 +1,69 @@
+package input
+
+import (
+	"database/sql/driver"
+	"io/ioutil"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/pganalyze/collector/util"
+)
+
+const explainTestPlan = `[{"Plan": {"Node Type": "Result", "Output": ["1"]}}]`
+
+var explainTests = []struct {
+	description   string
+	responses     []fakePostgresResponse
+	expectedError string
+}{
+	{
+		"auto_explain loaded",
+		[]fakePostgresResponse{
+			{pattern: "auto_explain.log_min_duration", columns: []string{"setting"}, rows: [][]driver.Value{{"1000"}}},
+			{pattern: "EXPLAIN (VERBOSE, FORMAT JSON) SELECT 1", columns: []string{"QUERY PLAN"}, rows: [][]driver.Value{{explainTestPlan}}},
+		},
+		"",
+	},
+	{
+		"auto_explain missing",
+		[]fakePostgresResponse{
+			{pattern: "EXPLAIN (VERBOSE, FORMAT JSON) SELECT 1", columns: []string{"QUERY PLAN"}, rows: [][]driver.Value{{explainTestPlan}}},
+		},
+		"auto_explain is not loaded",
+	},
+	{
+		"auto_explain disabled",
+		[]fakePostgresResponse{
+			{pattern: "auto_explain.log_min_duration", columns: []string{"setting"}, rows: [][]driver.Value{{"-1"}}},
+		},
+		"disables logging of query plans",
+	},
+	{
+		"EXPLAIN not permitted",
+		[]fakePostgresResponse{
+			{pattern: "auto_explain.log_min_duration", columns: []string{"setting"}, rows: [][]driver.Value{{"1000"}}},
+			{pattern: "EXPLAIN (VERBOSE, FORMAT JSON) SELECT 1", err: &pq.Error{Code: "42501", Message: "permission denied"}},
+		},
+		"could not EXPLAIN test query",
+	},
+}
+
+func TestTestExplain(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	for _, test := range explainTests {
+		connection, _ := openFakePostgres(t.Name()+test.description, test.responses)
+		err := TestExplain(connection, logger)
+		connection.Close()
+
+		if test.expectedError == "" {
+			if err != nil {
+				t.Errorf("%s: expected EXPLAIN test to succeed, got: %s", test.description, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+			t.Errorf("%s: expected error containing %q, got: %v", test.description, test.expectedError, err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package input
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+)
+
+// fakePostgres - A database/sql driver that answers each query with the first
+// canned response whose pattern is contained in the query, and an empty result
+// for everything else (i.e. QueryRow returns sql.ErrNoRows)
+type fakePostgres struct {
+	mutex   sync.Mutex
+	servers map[string]*fakePostgresServer
+}
+
+type fakePostgresServer struct {
+	responses []fakePostgresResponse
+
+	mutex   sync.Mutex
+	queries []string
+}
+
+type fakePostgresResponse struct {
+	pattern string
+	columns []string
+	rows    [][]driver.Value
+	err     error // Returned when preparing (or directly executing) the query
+}
+
+var fakePostgresDriver = &fakePostgres{servers: make(map[string]*fakePostgresServer)}
+
+func init() {
+	sql.Register("fakepostgres", fakePostgresDriver)
+}
+
+// openFakePostgres - Returns a connection that gets answered by the given
+// responses, and the server, to check which queries were run
+func openFakePostgres(name string, responses []fakePostgresResponse) (*sql.DB, *fakePostgresServer) {
+	server := &fakePostgresServer{responses: responses}
+	fakePostgresDriver.mutex.Lock()
+	fakePostgresDriver.servers[name] = server
+	fakePostgresDriver.mutex.Unlock()
+
+	db, _ := sql.Open("fakepostgres", name)
+	return db, server
+}
+
+func (d *fakePostgres) Open(name string) (driver.Conn, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return fakePostgresConn{d.servers[name]}, nil
+}
+
+// ranQuery - Whether a query containing the given text was run
+func (s *fakePostgresServer) ranQuery(text string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, query := range s.queries {
+		if strings.Contains(query, text) {
+			return true
+		}
+	}
+	return false
+}
+
+type fakePostgresConn struct {
+	server *fakePostgresServer
+}
+
+func (c fakePostgresConn) Prepare(query string) (driver.Stmt, error) {
+	c.server.mutex.Lock()
+	c.server.queries = append(c.server.queries, query)
+	c.server.mutex.Unlock()
+
+	for _, response := range c.server.responses {
+		if strings.Contains(query, response.pattern) {
+			if response.err != nil {
+				return nil, response.err
+			}
+			return fakePostgresStmt{response}, nil
+		}
+	}
+	return fakePostgresStmt{}, nil
+}
+
+func (c fakePostgresConn) Close() error {
+	return nil
+}
+
+func (c fakePostgresConn) Begin() (driver.Tx, error) {
+	return nil, io.ErrUnexpectedEOF
+}
+
+type fakePostgresStmt struct {
+	response fakePostgresResponse
+}
+
+func (s fakePostgresStmt) Close() error {
+	return nil
+}
+
+func (s fakePostgresStmt) NumInput() int {
+	return -1
+}
+
+func (s fakePostgresStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+
+func (s fakePostgresStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakePostgresRows{columns: s.response.columns, rows: s.response.rows}, nil
+}
+
+type fakePostgresRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakePostgresRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakePostgresRows) Close() error {
+	return nil
+}
+
+func (r *fakePostgresRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
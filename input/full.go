@@ -3,10 +3,9 @@ package input
 import (
 	"database/sql"
 	"fmt"
-	"path/filepath"
-	"strings"
 	"time"
 
+	"github.com/pganalyze/collector/config"
 	"github.com/pganalyze/collector/input/postgres"
 	"github.com/pganalyze/collector/input/system"
 	"github.com/pganalyze/collector/state"
@@ -42,16 +41,43 @@ func CollectFull(server state.Server, connection *sql.DB, collectionOpts state.C
 		return
 	}
 
-	ps.LastStatementStatsAt = time.Now()
-	ts.Statements, ps.StatementStats, err = postgres.GetStatements(logger, connection, ts.Version, true, isHeroku)
-	if err != nil {
+	// Categories that are not due yet keep the data of the run they were last
+	// collected in, so the next diff covers the whole time since then
+	dueCategories := dueCollectionCategories(server.Config, server.PrevState, ps.CollectedAt)
+
+	statementStatsAvailable := true
+	if !dueCategories[state.CollectionCategoryStatements] {
+		logger.PrintVerbose("Skipping query statistics this run, since they were collected less than %s ago", server.Config.StatementCollectionInterval)
+		ts.Statements = make(state.PostgresStatementMap)
+		ps.StatementStats = server.PrevState.StatementStats
+		ps.LastStatementStatsAt = server.PrevState.LastStatementStatsAt
+		statementStatsAvailable = false
+	} else {
+		ps.LastStatementStatsAt = time.Now()
+		ts.Statements, ps.StatementStats, err = postgres.GetStatements(logger, connection, ts.Version, true, isHeroku)
+	}
+	if postgres.IsStatementStatsUnavailable(err) {
+		// Everything else can still be collected, we just send no query statistics,
+		// and start diffing once pg_stat_statements becomes available
+		logger.PrintWarning("Skipping query statistics, %s (it needs to be in shared_preload_libraries, and created with CREATE EXTENSION pg_stat_statements)", err)
+		ts.Statements = make(state.PostgresStatementMap)
+		ps.StatementStats = make(state.PostgresStatementStatsMap)
+		statementStatsAvailable = false
+		err = nil
+	} else if err != nil {
 		logger.PrintError("Error collecting pg_stat_statements")
 		return
 	}
 
-	ps.StatementResetCounter = server.PrevState.StatementResetCounter + 1
-	if server.Grant.Config.Features.StatementResetFrequency != 0 && ps.StatementResetCounter >= server.Grant.Config.Features.StatementResetFrequency {
-		ps.StatementResetCounter = 0
+	// Resets only happen on runs that collect query statistics, so the counter
+	// only counts those
+	var resetStatements bool
+	if dueCategories[state.CollectionCategoryStatements] {
+		ps.StatementResetCounter, resetStatements = nextStatementResetCounter(server)
+	} else {
+		ps.StatementResetCounter = server.PrevState.StatementResetCounter
+	}
+	if resetStatements && statementStatsAvailable {
 		err = postgres.ResetStatements(logger, connection)
 		if err != nil {
 			logger.PrintError("Error calling pg_stat_statements_reset() as requested: %s", err)
@@ -65,51 +91,308 @@ func CollectFull(server state.Server, connection *sql.DB, collectionOpts state.C
 	}
 
 	if collectionOpts.CollectPostgresSettings {
-		ts.Settings, err = postgres.GetSettings(connection, ts.Version)
+		var settings []state.PostgresSetting
+		settings, err = postgres.GetSettings(connection, ts.Version)
 		if err != nil {
 			logger.PrintError("Error collecting config settings")
 			return
 		}
+		settings = filterSettings(server.Config, settings)
+		ts.NewlyPendingRestartSettings = state.NewlyPendingRestart(server.PrevState.PendingRestartSettings, settings)
+		for _, name := range ts.NewlyPendingRestartSettings {
+			logger.PrintInfo("Setting %s was changed, but only gets applied once the server is restarted", name)
+		}
+		ps.PendingRestartSettings = state.PendingRestartSettingNames(settings)
+		ts.Settings = settings
+		if server.Config.SettingsChangedOnly {
+			// The first run sends all settings, since there is nothing to compare to
+			if server.PrevState.Settings != nil {
+				ts.Settings = state.ChangedSettings(server.PrevState.Settings, settings)
+				ts.SettingsChangedOnly = true
+			}
+			ps.Settings = settings
+		}
+	} else {
+		ps.PendingRestartSettings = server.PrevState.PendingRestartSettings
+		ps.Settings = server.PrevState.Settings
 	}
 
-	ts.Replication, err = postgres.GetReplication(logger, connection, isHeroku, ts.Version)
+	ps.Replication, err = postgres.GetReplication(logger, connection, isHeroku, ts.Version)
 	if err != nil {
 		logger.PrintWarning("Error collecting replication statistics: %s", err)
 		// We intentionally accept this as a non-fatal issue (at least for now)
 		err = nil
 	}
 
-	ts.BackendCounts, err = postgres.GetBackendCounts(logger, connection, ts.Version)
+	ps.BackendCounts, err = postgres.GetBackendCounts(logger, connection, ts.Version)
 	if err != nil {
 		logger.PrintError("Error collecting backend counts: %s", err)
 		return
 	}
 
-	ps, ts = postgres.CollectAllSchemas(server, collectionOpts, logger, ps, ts)
+	if server.Config.CollectWaitEvents {
+		ts.WaitEvents = server.WaitEventSampler.Take()
+	}
+
+	if server.Config.CollectProgress {
+		ts.Progress, err = postgres.GetProgress(logger, connection, ts.Version)
+		if err != nil {
+			logger.PrintWarning("Error collecting progress of long-running operations: %s", err)
+			// We intentionally accept this as a non-fatal issue (at least for now)
+			err = nil
+		}
+	}
+
+	if collectionOpts.CollectPostgresLocks {
+		ts.Locks, err = postgres.GetLocks(connection, ts.Version)
+		if err != nil {
+			logger.PrintWarning("Error collecting lock waits: %s", err)
+			// We intentionally accept this as a non-fatal issue (at least for now)
+			err = nil
+		}
+	}
+
+	ts.Wraparound, err = postgres.GetWraparound(connection, server.Config)
+	if err != nil {
+		logger.PrintWarning("Error collecting transaction ID wraparound information: %s", err)
+		// We intentionally accept this as a non-fatal issue (at least for now)
+		err = nil
+	}
+
+	if len(server.Config.CustomMetrics) > 0 {
+		ts.CustomMetrics = postgres.GetCustomMetrics(logger, connection, server.Config.CustomMetrics)
+	}
+
+	ps.IOStats, err = postgres.GetIOStats(logger, connection, ts.Version)
+	if err != nil {
+		logger.PrintWarning("Error collecting I/O statistics: %s", err)
+		// We intentionally accept this as a non-fatal issue (at least for now)
+		err = nil
+	}
+
+	ps.BgwriterStats, err = postgres.GetBgwriterStats(logger, connection, ts.Version)
+	if err != nil {
+		logger.PrintWarning("Error collecting background writer and checkpointer statistics: %s", err)
+		// We intentionally accept this as a non-fatal issue (at least for now)
+		err = nil
+	}
+
+	ps, ts = filterExcludedDatabases(server.Config, ps, ts)
+
+	for _, database := range ts.Wraparound.AtRisk() {
+		logger.PrintWarning("Database %s is at %s risk of transaction ID wraparound: age(datfrozenxid) is %d, %.0f%% of autovacuum_freeze_max_age", database.DatabaseName, database.Risk, database.XIDAge, database.FreezeMaxAgePercent)
+	}
+
+	// Avoid adding to the load of a database that is already struggling
+	throttle := throttleReason(server.Config, server.PrevState)
+	if throttle != "" {
+		logger.PrintWarning("Skipping bloat, table, index and function statistics this run, since %s", throttle)
+		ps = carryOverSchemaData(server.PrevState, ps)
+		dueCategories[state.CollectionCategoryRelations] = false
+	} else if !dueCategories[state.CollectionCategoryRelations] {
+		logger.PrintVerbose("Skipping table, index and function statistics this run, since they were collected less than %s ago", server.Config.RelationCollectionInterval)
+		ps = carryOverSchemaData(server.PrevState, ps)
+	} else {
+		ps, ts = postgres.CollectAllSchemas(server, connection, collectionOpts, logger, ps, ts)
+	}
 
 	if server.Config.IgnoreTablePattern != "" {
 		var filteredRelations []state.PostgresRelation
-		patterns := strings.Split(server.Config.IgnoreTablePattern, ",")
 		for _, relation := range ps.Relations {
-			var matched bool
-			for _, pattern := range patterns {
-				matched, _ = filepath.Match(pattern, relation.SchemaName+"."+relation.RelationName)
-				if matched {
-					break
-				}
-			}
-			if !matched {
+			if !server.Config.IsTableIgnored(relation.SchemaName, relation.RelationName) {
 				filteredRelations = append(filteredRelations, relation)
 			}
 		}
 		ps.Relations = filteredRelations
+
+		var filteredWraparoundTables []state.PostgresWraparoundTable
+		for _, table := range ts.Wraparound.Tables {
+			if !server.Config.IsTableIgnored(table.OwnerSchemaName, table.OwnerRelationName) {
+				filteredWraparoundTables = append(filteredWraparoundTables, table)
+			}
+		}
+		ts.Wraparound.Tables = filteredWraparoundTables
+	}
+
+	if collectionOpts.CollectPostgresBloat {
+		if throttle == "" && collectionDue(server.PrevState.BloatCollectedAt, ps.CollectedAt, server.Config.BloatCollectionInterval) {
+			ps.BloatStats, err = postgres.GetBloatStats(logger, connection)
+			if err != nil {
+				logger.PrintWarning("Error collecting bloat statistics: %s", err)
+				// We intentionally accept this as a non-fatal issue, and retry on the next run
+				ps.BloatStats = server.PrevState.BloatStats
+				ps.BloatCollectedAt = server.PrevState.BloatCollectedAt
+				err = nil
+			} else {
+				ps.BloatCollectedAt = ps.CollectedAt
+			}
+		} else {
+			ps.BloatStats = server.PrevState.BloatStats
+			ps.BloatCollectedAt = server.PrevState.BloatCollectedAt
+		}
 	}
 
+	// Catalog queries already skip excluded schemas, this also covers data that
+	// gets collected for the whole server (e.g. bloat estimates)
+	ps = filterExcludedSchemas(server.Config, ps)
+
 	if collectionOpts.CollectSystemInformation {
-		ps.System = system.GetSystemState(server.Config, logger)
+		if dueCategories[state.CollectionCategorySystem] {
+			ps.System = system.GetSystemState(server.Config, logger)
+		} else {
+			logger.PrintVerbose("Skipping system information this run, since it was collected less than %s ago", server.Config.SystemCollectionInterval)
+			ps.System = server.PrevState.System
+		}
 	}
 
-	ps.CollectorStats = getCollectorStats()
+	ps.CategoryCollectedAt = updateCategoryCollectedAt(server.PrevState.CategoryCollectedAt, dueCategories, ps.CollectedAt)
+
+	ps.CollectorStats = getCollectorStats(server)
 
 	return
 }
+
+// filterExcludedDatabases - Removes databases that are excluded by the database
+// allow/deny lists, as well as all statistics that were collected for them
+func filterExcludedDatabases(serverConfig config.ServerConfig, ps state.PersistedState, ts state.TransientState) (state.PersistedState, state.TransientState) {
+	if len(serverConfig.DatabaseAllowList) == 0 && len(serverConfig.DatabaseDenyList) == 0 {
+		return ps, ts
+	}
+
+	excludedOids := make(map[state.Oid]bool)
+	var databases []state.PostgresDatabase
+	for _, database := range ts.Databases {
+		if serverConfig.IsDatabaseCollected(database.Name) {
+			databases = append(databases, database)
+		} else {
+			excludedOids[database.Oid] = true
+		}
+	}
+	ts.Databases = databases
+
+	var wraparoundDatabases []state.PostgresWraparoundDatabase
+	for _, database := range ts.Wraparound.Databases {
+		if serverConfig.IsDatabaseCollected(database.DatabaseName) {
+			wraparoundDatabases = append(wraparoundDatabases, database)
+		}
+	}
+	ts.Wraparound.Databases = wraparoundDatabases
+
+	for key := range ts.Statements {
+		if excludedOids[key.DatabaseOid] {
+			delete(ts.Statements, key)
+		}
+	}
+	for key := range ps.StatementStats {
+		if excludedOids[key.DatabaseOid] {
+			delete(ps.StatementStats, key)
+		}
+	}
+	for key := range ts.ResetStatementStats {
+		if excludedOids[key.DatabaseOid] {
+			delete(ts.ResetStatementStats, key)
+		}
+	}
+
+	var backendCounts state.PostgresBackendCounts
+	for _, backendCount := range ps.BackendCounts {
+		if !backendCount.DatabaseOid.Valid || !excludedOids[state.Oid(backendCount.DatabaseOid.Int64)] {
+			backendCounts = append(backendCounts, backendCount)
+		}
+	}
+	ps.BackendCounts = backendCounts
+
+	return ps, ts
+}
+
+// filterExcludedSchemas - Removes tables, indexes and functions in schemas that
+// are excluded by the schema allow/deny lists, as well as their statistics
+func filterExcludedSchemas(serverConfig config.ServerConfig, ps state.PersistedState) state.PersistedState {
+	if len(serverConfig.SchemaAllowList) == 0 && len(serverConfig.SchemaDenyList) == 0 {
+		return ps
+	}
+
+	var relations []state.PostgresRelation
+	for _, relation := range ps.Relations {
+		if serverConfig.IsSchemaCollected(relation.SchemaName) {
+			relations = append(relations, relation)
+			continue
+		}
+		delete(ps.RelationStats, relation.Oid)
+		for _, index := range relation.Indices {
+			delete(ps.IndexStats, index.IndexOid)
+		}
+	}
+	ps.Relations = relations
+
+	var functions []state.PostgresFunction
+	for _, function := range ps.Functions {
+		if serverConfig.IsSchemaCollected(function.SchemaName) {
+			functions = append(functions, function)
+		}
+	}
+	ps.Functions = functions
+
+	var relationBloat []state.PostgresRelationBloat
+	for _, bloat := range ps.BloatStats.Relations {
+		if serverConfig.IsSchemaCollected(bloat.SchemaName) {
+			relationBloat = append(relationBloat, bloat)
+		}
+	}
+	ps.BloatStats.Relations = relationBloat
+
+	var indexBloat []state.PostgresIndexBloat
+	for _, bloat := range ps.BloatStats.Indices {
+		if serverConfig.IsSchemaCollected(bloat.SchemaName) {
+			indexBloat = append(indexBloat, bloat)
+		}
+	}
+	ps.BloatStats.Indices = indexBloat
+
+	return ps
+}
+
+// filterSettings - Removes settings that are not in the settings allow list
+func filterSettings(serverConfig config.ServerConfig, settings []state.PostgresSetting) []state.PostgresSetting {
+	if len(serverConfig.SettingsAllowList) == 0 {
+		return settings
+	}
+
+	var filtered []state.PostgresSetting
+	for _, setting := range settings {
+		if serverConfig.IsSettingCollected(setting.Name) {
+			filtered = append(filtered, setting)
+		}
+	}
+	return filtered
+}
+
+// nextStatementResetCounter - Increments the counter of runs since the last
+// pg_stat_statements_reset(), and returns whether a reset should be done now
+// (in which case the counter starts over)
+//
+// The reset frequency in the server config takes precedence over the grant,
+// and disabling resets in the server config keeps the counter where it is.
+func nextStatementResetCounter(server state.Server) (counter int, reset bool) {
+	if server.Config.DisableStatementReset {
+		return server.PrevState.StatementResetCounter, false
+	}
+
+	frequency := server.Grant.Config.Features.StatementResetFrequency
+	if server.Config.StatementResetFrequency != 0 {
+		frequency = server.Config.StatementResetFrequency
+	}
+
+	counter = server.PrevState.StatementResetCounter + 1
+	if frequency != 0 && counter >= frequency {
+		return 0, true
+	}
+	return counter, false
+}
+
+// collectionDue - Whether data last collected at lastCollectedAt (e.g. bloat
+// estimates) is older than the configured interval (or was never collected),
+// and should be collected again
+func collectionDue(lastCollectedAt time.Time, now time.Time, interval time.Duration) bool {
+	return lastCollectedAt.IsZero() || now.Sub(lastCollectedAt) >= interval
+}
@@ -0,0 +1,755 @@
+package input
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/lib/pq"
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/input/postgres"
+	"github.com/pganalyze/collector/output/transform"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+func TestBloatCollectionDue(t *testing.T) {
+	now := time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		lastCollectedAt time.Time
+		expected        bool
+	}{
+		{time.Time{}, true},
+		{now.Add(-59 * time.Minute), false},
+		{now.Add(-time.Hour + time.Second), false},
+		{now.Add(-time.Hour), true},
+		{now.Add(-2 * time.Hour), true},
+	}
+
+	for _, test := range tests {
+		due := collectionDue(test.lastCollectedAt, now, time.Hour)
+		if due != test.expected {
+			t.Errorf("For last collection at %s: expected due to be %v, got %v", test.lastCollectedAt, test.expected, due)
+		}
+	}
+}
+
+func TestNextStatementResetCounter(t *testing.T) {
+	tests := []struct {
+		description     string
+		configFrequency int
+		grantFrequency  int
+		expectedResets  []bool // Whether a reset happens on each consecutive run
+	}{
+		{"no frequency", 0, 0, []bool{false, false, false, false}},
+		{"grant frequency", 0, 2, []bool{false, true, false, true}},
+		{"config override", 3, 2, []bool{false, false, true, false, false, true}},
+		{"config override without grant frequency", 1, 0, []bool{true, true, true}},
+	}
+
+	for _, test := range tests {
+		server := state.Server{
+			Config: config.ServerConfig{StatementResetFrequency: test.configFrequency},
+			Grant:  state.Grant{Config: state.GrantConfig{Features: state.GrantFeatures{StatementResetFrequency: test.grantFrequency}}},
+		}
+
+		for run, expected := range test.expectedResets {
+			counter, reset := nextStatementResetCounter(server)
+			if reset != expected {
+				t.Errorf("%s: expected reset on run %d to be %v, got %v", test.description, run+1, expected, reset)
+			}
+			if reset && counter != 0 {
+				t.Errorf("%s: expected counter to start over after reset on run %d, got %d", test.description, run+1, counter)
+			}
+			server.PrevState.StatementResetCounter = counter
+		}
+	}
+}
+
+func TestNextStatementResetCounterDisabled(t *testing.T) {
+	server := state.Server{
+		Config: config.ServerConfig{StatementResetFrequency: 1, DisableStatementReset: true},
+		Grant:  state.Grant{Config: state.GrantConfig{Features: state.GrantFeatures{StatementResetFrequency: 2}}},
+	}
+	server.PrevState.StatementResetCounter = 5
+
+	for run := 0; run < 100; run++ {
+		counter, reset := nextStatementResetCounter(server)
+		if reset {
+			t.Fatalf("Expected no reset when disabled, got one on run %d", run+1)
+		}
+		if counter != 5 {
+			t.Fatalf("Expected counter to stay at 5 when disabled, got %d on run %d", counter, run+1)
+		}
+		server.PrevState.StatementResetCounter = counter
+	}
+}
+
+func TestFilterExcludedDatabases(t *testing.T) {
+	serverConfig := config.ServerConfig{DatabaseAllowList: []string{"app", "noisy"}, DatabaseDenyList: []string{"noisy"}}
+
+	appKey := state.PostgresStatementKey{DatabaseOid: 16384, QueryID: 1}
+	noisyKey := state.PostgresStatementKey{DatabaseOid: 16385, QueryID: 1}
+	otherKey := state.PostgresStatementKey{DatabaseOid: 16386, QueryID: 1}
+
+	ps := state.PersistedState{
+		StatementStats: state.PostgresStatementStatsMap{appKey: {Calls: 1}, noisyKey: {Calls: 2}, otherKey: {Calls: 3}},
+		BackendCounts: state.PostgresBackendCounts{
+			{DatabaseOid: null.IntFrom(16384), State: "active", Count: 1},
+			{DatabaseOid: null.IntFrom(16385), State: "active", Count: 2},
+			{State: "unknown", BackendType: "checkpointer", Count: 1},
+		},
+	}
+	ts := state.TransientState{
+		Databases: []state.PostgresDatabase{
+			{Oid: 16384, Name: "app"},
+			{Oid: 16385, Name: "noisy"},
+			{Oid: 16386, Name: "other"},
+		},
+		Statements: state.PostgresStatementMap{appKey: {}, noisyKey: {}, otherKey: {}},
+	}
+
+	ps, ts = filterExcludedDatabases(serverConfig, ps, ts)
+
+	if diff := pretty.Compare([]state.PostgresDatabase{{Oid: 16384, Name: "app"}}, ts.Databases); diff != "" {
+		t.Errorf("Databases: (-want +got)\n%s", diff)
+	}
+	if _, exists := ts.Statements[appKey]; len(ts.Statements) != 1 || !exists {
+		t.Errorf("Expected only statements of the allowed database, got %v", ts.Statements)
+	}
+	if _, exists := ps.StatementStats[appKey]; len(ps.StatementStats) != 1 || !exists {
+		t.Errorf("Expected only statement stats of the allowed database, got %v", ps.StatementStats)
+	}
+	expectedBackendCounts := state.PostgresBackendCounts{
+		{DatabaseOid: null.IntFrom(16384), State: "active", Count: 1},
+		{State: "unknown", BackendType: "checkpointer", Count: 1},
+	}
+	if diff := pretty.Compare(expectedBackendCounts, ps.BackendCounts); diff != "" {
+		t.Errorf("Backend counts: (-want +got)\n%s", diff)
+	}
+}
+
+func TestFilterExcludedSchemas(t *testing.T) {
+	serverConfig := config.ServerConfig{SchemaAllowList: []string{"public", "audit"}, SchemaDenyList: []string{"audit"}}
+
+	ps := state.PersistedState{
+		Relations: []state.PostgresRelation{
+			{Oid: 1, DatabaseOid: 16384, SchemaName: "public", RelationName: "users", Indices: []state.PostgresIndex{{IndexOid: 11}}},
+			{Oid: 2, DatabaseOid: 16384, SchemaName: "audit", RelationName: "changes", Indices: []state.PostgresIndex{{IndexOid: 12}}},
+			{Oid: 3, DatabaseOid: 16385, SchemaName: "tenant_1", RelationName: "users", Indices: []state.PostgresIndex{{IndexOid: 13}}},
+		},
+		RelationStats: state.PostgresRelationStatsMap{1: {SeqScan: 1}, 2: {SeqScan: 2}, 3: {SeqScan: 3}},
+		IndexStats:    state.PostgresIndexStatsMap{11: {IdxScan: 1}, 12: {IdxScan: 2}, 13: {IdxScan: 3}},
+		Functions: []state.PostgresFunction{
+			{Oid: 21, DatabaseOid: 16384, SchemaName: "public", FunctionName: "now_utc"},
+			{Oid: 22, DatabaseOid: 16384, SchemaName: "audit", FunctionName: "log_change"},
+		},
+		BloatStats: state.PostgresBloatStats{
+			Relations: []state.PostgresRelationBloat{{SchemaName: "public", RelationName: "users"}, {SchemaName: "audit", RelationName: "changes"}},
+			Indices:   []state.PostgresIndexBloat{{SchemaName: "public", IndexName: "users_pkey"}, {SchemaName: "tenant_1", IndexName: "users_pkey"}},
+		},
+	}
+
+	ps = filterExcludedSchemas(serverConfig, ps)
+
+	for _, relation := range ps.Relations {
+		if relation.SchemaName != "public" {
+			t.Errorf("Expected only relations of the allowed schema, got %s.%s", relation.SchemaName, relation.RelationName)
+		}
+	}
+	if diff := pretty.Compare(state.PostgresRelationStatsMap{1: {SeqScan: 1}}, ps.RelationStats); diff != "" {
+		t.Errorf("Relation stats: (-want +got)\n%s", diff)
+	}
+	if diff := pretty.Compare(state.PostgresIndexStatsMap{11: {IdxScan: 1}}, ps.IndexStats); diff != "" {
+		t.Errorf("Index stats: (-want +got)\n%s", diff)
+	}
+	if len(ps.Functions) != 1 || ps.Functions[0].SchemaName != "public" {
+		t.Errorf("Expected only functions of the allowed schema, got %v", ps.Functions)
+	}
+	expectedBloat := state.PostgresBloatStats{
+		Relations: []state.PostgresRelationBloat{{SchemaName: "public", RelationName: "users"}},
+		Indices:   []state.PostgresIndexBloat{{SchemaName: "public", IndexName: "users_pkey"}},
+	}
+	if diff := pretty.Compare(expectedBloat, ps.BloatStats); diff != "" {
+		t.Errorf("Bloat stats: (-want +got)\n%s", diff)
+	}
+
+	// Databases where all relations got excluded still count as having their
+	// local catalog collected, so their relations show up as gone
+	ts := state.TransientState{
+		Databases:                    []state.PostgresDatabase{{Oid: 16384, Name: "app"}, {Oid: 16385, Name: "tenants"}},
+		DatabaseOidsWithLocalCatalog: []state.Oid{16384, 16385},
+	}
+	s := transform.StateToSnapshot(ps, state.DiffState{}, ts)
+	for _, info := range s.DatabaseInformations {
+		if !info.CollectedLocalCatalogData {
+			t.Errorf("Expected database %s to have its local catalog collected", s.DatabaseReferences[info.DatabaseIdx].Name)
+		}
+	}
+	var relationNames []string
+	for _, ref := range s.RelationReferences {
+		relationNames = append(relationNames, s.DatabaseReferences[ref.DatabaseIdx].Name+"/"+ref.SchemaName+"."+ref.RelationName)
+	}
+	if diff := pretty.Compare([]string{"app/public.users"}, relationNames); diff != "" {
+		t.Errorf("Snapshot relations: (-want +got)\n%s", diff)
+	}
+}
+
+func TestCollectFullWithoutPgStatStatements(t *testing.T) {
+	connection, fakeServer := openFakePostgres(t.Name(), []fakePostgresResponse{
+		{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL 10.5 on x86_64-pc-linux-gnu"}}},
+		{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{"100005"}}},
+		{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{"10.5"}}},
+		{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+		{pattern: "FROM public.pg_stat_statements", err: &pq.Error{Code: "42P01", Message: `relation "public.pg_stat_statements" does not exist`}},
+		{pattern: "CREATE EXTENSION IF NOT EXISTS pg_stat_statements", err: &pq.Error{Code: "42501", Message: `permission denied to create extension "pg_stat_statements"`}},
+	})
+	defer connection.Close()
+
+	var output bytes.Buffer
+	logger := &util.Logger{Destination: log.New(&output, "", 0)}
+	server := state.Server{Config: config.ServerConfig{SectionName: "test", DbAllNames: true}}
+
+	ps, ts, err := CollectFull(server, connection, state.CollectionOpts{}, logger)
+	if err != nil {
+		t.Fatalf("Expected collection to succeed, got error: %s", err)
+	}
+
+	if len(ts.Statements) != 0 || len(ps.StatementStats) != 0 {
+		t.Errorf("Expected no statements, got %d statements and %d statement stats", len(ts.Statements), len(ps.StatementStats))
+	}
+	if ts.Version.Numeric != 100005 {
+		t.Errorf("Expected version to be collected, got %v", ts.Version)
+	}
+	if !fakeServer.ranQuery("FROM pg_stat_activity") {
+		t.Errorf("Expected backend counts to be collected after pg_stat_statements failed")
+	}
+	if ps.CollectorStats.GoVersion == "" {
+		t.Errorf("Expected collector stats to be collected")
+	}
+	if count := strings.Count(output.String(), "pg_stat_statements is not available"); count != 1 {
+		t.Errorf("Expected exactly one warning about pg_stat_statements, got %d in:\n%s", count, output.String())
+	}
+}
+
+func TestCollectFullSettingsPendingRestart(t *testing.T) {
+	connection, _ := openFakePostgres(t.Name(), []fakePostgresResponse{
+		{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL 14.0 on x86_64-pc-linux-gnu"}}},
+		{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{"140000"}}},
+		{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{"14.0"}}},
+		{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+		{pattern: "FROM pg_settings", columns: []string{"name", "current_value", "unit", "boot_value", "reset_value", "source", "sourcefile", "sourceline", "pending_restart"}, rows: [][]driver.Value{
+			{"shared_buffers", "16384", "8kB", "1024", "16384", "configuration file", "/etc/postgresql.conf", "12", true},
+			{"work_mem", "4096", "kB", "4096", "4096", "default", nil, nil, false},
+		}},
+	})
+	defer connection.Close()
+
+	var output bytes.Buffer
+	logger := &util.Logger{Destination: log.New(&output, "", 0)}
+	server := state.Server{Config: config.ServerConfig{SectionName: "test"}}
+	opts := state.CollectionOpts{CollectPostgresSettings: true}
+
+	ps, ts, err := CollectFull(server, connection, opts, logger)
+	if err != nil {
+		t.Fatalf("Expected collection to succeed, got error: %s", err)
+	}
+	var pending []bool
+	for _, setting := range ts.Settings {
+		pending = append(pending, setting.PendingRestart)
+	}
+	if diff := pretty.Compare([]bool{true, false}, pending); diff != "" {
+		t.Errorf("Pending restart flags: (-want +got)\n%s", diff)
+	}
+	if diff := pretty.Compare([]string{"shared_buffers"}, ps.PendingRestartSettings); diff != "" {
+		t.Errorf("Pending restart settings: (-want +got)\n%s", diff)
+	}
+	if diff := pretty.Compare([]string{"shared_buffers"}, ts.NewlyPendingRestartSettings); diff != "" {
+		t.Errorf("Newly pending restart settings: (-want +got)\n%s", diff)
+	}
+	if !strings.Contains(output.String(), "Setting shared_buffers was changed") || strings.Contains(output.String(), "work_mem") {
+		t.Errorf("Expected only shared_buffers to be pointed out as newly pending a restart, got:\n%s", output.String())
+	}
+
+	// Settings that were already pending a restart in the last run are not pointed out again
+	output.Reset()
+	server.PrevState = ps
+	_, _, err = CollectFull(server, connection, opts, logger)
+	if err != nil {
+		t.Fatalf("Expected collection to succeed, got error: %s", err)
+	}
+	if strings.Contains(output.String(), "was changed") {
+		t.Errorf("Expected no settings to be pointed out again, got:\n%s", output.String())
+	}
+}
+
+func settingsTestResponses(workMem string) []fakePostgresResponse {
+	return []fakePostgresResponse{
+		{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL 14.0 on x86_64-pc-linux-gnu"}}},
+		{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{"140000"}}},
+		{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{"14.0"}}},
+		{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+		{pattern: "FROM pg_settings", columns: []string{"name", "current_value", "unit", "boot_value", "reset_value", "source", "sourcefile", "sourceline", "pending_restart"}, rows: [][]driver.Value{
+			{"max_connections", "100", nil, "100", "100", "configuration file", "/etc/postgresql.conf", "5", false},
+			{"shared_buffers", "16384", "8kB", "1024", "16384", "configuration file", "/etc/postgresql.conf", "12", false},
+			{"work_mem", workMem, "kB", "4096", workMem, "configuration file", "/etc/postgresql.conf", "20", false},
+		}},
+	}
+}
+
+func settingNames(settings []state.PostgresSetting) (names []string) {
+	for _, setting := range settings {
+		names = append(names, setting.Name)
+	}
+	return
+}
+
+func TestCollectFullSettingsAllowList(t *testing.T) {
+	connection, _ := openFakePostgres(t.Name(), settingsTestResponses("4096"))
+	defer connection.Close()
+
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	server := state.Server{Config: config.ServerConfig{SectionName: "test", SettingsAllowList: []string{"work_mem", "max_connections"}}}
+
+	_, ts, err := CollectFull(server, connection, state.CollectionOpts{CollectPostgresSettings: true}, logger)
+	if err != nil {
+		t.Fatalf("Expected collection to succeed, got error: %s", err)
+	}
+	if diff := pretty.Compare([]string{"max_connections", "work_mem"}, settingNames(ts.Settings)); diff != "" {
+		t.Errorf("Collected settings: (-want +got)\n%s", diff)
+	}
+}
+
+func TestCollectFullSettingsChangedOnly(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	server := state.Server{Config: config.ServerConfig{SectionName: "test", SettingsChangedOnly: true}}
+	opts := state.CollectionOpts{CollectPostgresSettings: true}
+
+	connection, _ := openFakePostgres(t.Name()+"first", settingsTestResponses("4096"))
+	defer connection.Close()
+	ps, ts, err := CollectFull(server, connection, opts, logger)
+	if err != nil {
+		t.Fatalf("Expected collection to succeed, got error: %s", err)
+	}
+	if diff := pretty.Compare([]string{"max_connections", "shared_buffers", "work_mem"}, settingNames(ts.Settings)); diff != "" {
+		t.Errorf("Settings sent in the first run: (-want +got)\n%s", diff)
+	}
+	if ts.SettingsChangedOnly {
+		t.Errorf("Expected the first run to send all settings")
+	}
+
+	server.PrevState = ps
+	connection, _ = openFakePostgres(t.Name()+"second", settingsTestResponses("8192"))
+	defer connection.Close()
+	ps, ts, err = CollectFull(server, connection, opts, logger)
+	if err != nil {
+		t.Fatalf("Expected collection to succeed, got error: %s", err)
+	}
+	if diff := pretty.Compare([]string{"work_mem"}, settingNames(ts.Settings)); diff != "" {
+		t.Errorf("Settings sent in the second run: (-want +got)\n%s", diff)
+	}
+	if !ts.SettingsChangedOnly {
+		t.Errorf("Expected the second run to only send changed settings")
+	}
+	if ts.Settings[0].CurrentValue.String != "8192" {
+		t.Errorf("Expected the changed value to be sent, got %s", ts.Settings[0].CurrentValue.String)
+	}
+	if len(ps.Settings) != 3 {
+		t.Errorf("Expected all settings to be kept for the next run, got %d", len(ps.Settings))
+	}
+}
+
+func TestCollectFullLocks(t *testing.T) {
+	connection, _ := openFakePostgres(t.Name(), []fakePostgresResponse{
+		{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL 14.0 on x86_64-pc-linux-gnu"}}},
+		{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{"140000"}}},
+		{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{"14.0"}}},
+		{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+		{pattern: "FROM pg_catalog.pg_locks", columns: []string{"pid", "locktype", "mode", "database", "relation", "blocking_pids"}, rows: [][]driver.Value{
+			{int64(200), "relation", "AccessExclusiveLock", int64(16385), int64(16384), "100"},
+			{int64(300), "relation", "AccessShareLock", int64(16385), int64(16384), "200"},
+		}},
+		{pattern: "WHERE pid = ANY", columns: []string{"pid", "state", "query"}, rows: [][]driver.Value{
+			{int64(100), "idle in transaction", "SELECT * FROM users"},
+			{int64(200), "active", "ALTER TABLE users ADD COLUMN age int"},
+			{int64(300), "active", "SELECT count(*) FROM users"},
+		}},
+	})
+	defer connection.Close()
+
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	server := state.Server{Config: config.ServerConfig{SectionName: "test"}}
+
+	_, ts, err := CollectFull(server, connection, state.CollectionOpts{CollectPostgresLocks: true}, logger)
+	if err != nil {
+		t.Fatalf("Expected collection to succeed, got error: %s", err)
+	}
+
+	if len(ts.Locks.Waits) != 2 {
+		t.Fatalf("Expected 2 lock waits, got %d", len(ts.Locks.Waits))
+	}
+	var chain []string
+	for trees := ts.Locks.BlockingTrees; len(trees) > 0; trees = trees[0].Blocked {
+		if len(trees) != 1 {
+			t.Fatalf("Expected a single chain, got %d branches", len(trees))
+		}
+		chain = append(chain, fmt.Sprintf("%d: %s", trees[0].Pid, trees[0].Query.String))
+	}
+	expected := []string{"100: SELECT * FROM users", "200: ALTER TABLE users ADD COLUMN age int", "300: SELECT count(*) FROM users"}
+	if diff := pretty.Compare(expected, chain); diff != "" {
+		t.Errorf("Blocking chain: (-want +got)\n%s", diff)
+	}
+
+	disabledConnection, fakeServer := openFakePostgres(t.Name()+"disabled", settingsTestResponses("4096"))
+	defer disabledConnection.Close()
+	_, _, err = CollectFull(server, disabledConnection, state.CollectionOpts{}, logger)
+	if err != nil {
+		t.Fatalf("Expected collection to succeed, got error: %s", err)
+	}
+	if fakeServer.ranQuery("pg_catalog.pg_locks") {
+		t.Errorf("Expected locks not to be collected when disabled")
+	}
+}
+
+func TestCollectFullWraparound(t *testing.T) {
+	connection, fakeServer := openFakePostgres(t.Name(), []fakePostgresResponse{
+		{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL 14.0 on x86_64-pc-linux-gnu"}}},
+		{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{"140000"}}},
+		{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{"14.0"}}},
+		{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+		{pattern: "age(datfrozenxid)", columns: []string{"oid", "datname", "age", "current_setting"}, rows: [][]driver.Value{
+			{int64(1), "template1", int64(3000000), int64(200000000)},
+			{int64(16384), "app", int64(1700000000), int64(200000000)},
+			{int64(16385), "excluded", int64(400000000), int64(200000000)},
+		}},
+		// Excluded schemas are filtered by the query, ignored tables afterwards
+		{pattern: "age(c.relfrozenxid)", columns: []string{"oid", "nspname", "relname", "age", "coalesce", "coalesce"}, rows: [][]driver.Value{
+			{int64(16400), "public", "events", int64(1700000000), "public", "events"},
+			{int64(16402), "pg_toast", "pg_toast_16405", int64(900000000), "public", "events_archive"},
+			{int64(16410), "public", "users", int64(12000000), "public", "users"},
+		}},
+	})
+	defer connection.Close()
+
+	var output bytes.Buffer
+	logger := &util.Logger{Destination: log.New(&output, "", 0)}
+	server := state.Server{Config: config.ServerConfig{SectionName: "test", DatabaseDenyList: []string{"excluded"}, IgnoreTablePattern: "public.*_archive", SchemaDenyList: []string{"audit"}}}
+
+	_, ts, err := CollectFull(server, connection, state.CollectionOpts{}, logger)
+	if err != nil {
+		t.Fatalf("Expected collection to succeed, got error: %s", err)
+	}
+
+	expected := state.PostgresWraparound{
+		FreezeMaxAge: 200000000,
+		Databases: []state.PostgresWraparoundDatabase{
+			{DatabaseOid: 16384, DatabaseName: "app", XIDAge: 1700000000, FreezeMaxAgePercent: 850, Risk: state.WraparoundRiskCritical},
+			{DatabaseOid: 1, DatabaseName: "template1", XIDAge: 3000000, FreezeMaxAgePercent: 1.5, Risk: state.WraparoundRiskLow},
+		},
+		Tables: []state.PostgresWraparoundTable{
+			{RelationOid: 16400, SchemaName: "public", RelationName: "events", XIDAge: 1700000000, OwnerSchemaName: "public", OwnerRelationName: "events", FreezeMaxAgePercent: 850, Risk: state.WraparoundRiskCritical},
+			{RelationOid: 16410, SchemaName: "public", RelationName: "users", XIDAge: 12000000, OwnerSchemaName: "public", OwnerRelationName: "users", FreezeMaxAgePercent: 6, Risk: state.WraparoundRiskLow},
+		},
+	}
+	if diff := pretty.Compare(expected, ts.Wraparound); diff != "" {
+		t.Errorf("Wraparound: (-want +got)\n%s", diff)
+	}
+	if !fakeServer.ranQuery("COALESCE(owner_n.nspname, n.nspname) NOT IN ('audit')") {
+		t.Errorf("Expected wraparound tables to be filtered by schema")
+	}
+	if !strings.Contains(output.String(), "Database app is at critical risk of transaction ID wraparound") {
+		t.Errorf("Expected a warning about app, got:\n%s", output.String())
+	}
+	if strings.Contains(output.String(), "template1") || strings.Contains(output.String(), "Database excluded") {
+		t.Errorf("Expected no warning about other databases, got:\n%s", output.String())
+	}
+}
+
+func TestCollectFullIOStats(t *testing.T) {
+	tests := []struct {
+		versionNum string
+		version    string
+		expected   state.PostgresIOStatsMap
+	}{
+		{"160001", "16.1", state.PostgresIOStatsMap{
+			{BackendType: "client backend", Object: "relation", Context: "normal"}: {Reads: 100, ReadTime: 12.5, Writes: 10, Extends: 5, Hits: 1000},
+			{BackendType: "checkpointer", Object: "relation", Context: "normal"}:   {Writes: 200, WriteTime: 40},
+		}},
+		{"150004", "15.4", nil},
+	}
+
+	for _, test := range tests {
+		connection, fakeServer := openFakePostgres(t.Name()+test.version, []fakePostgresResponse{
+			{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL " + test.version + " on x86_64-pc-linux-gnu"}}},
+			{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{test.versionNum}}},
+			{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{test.version}}},
+			{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+			{pattern: "FROM pg_stat_io", columns: []string{"backend_type", "object", "context", "reads", "read_time", "writes", "write_time", "extends", "extend_time", "hits", "evictions"}, rows: [][]driver.Value{
+				{"client backend", "relation", "normal", int64(100), 12.5, int64(10), 0.0, int64(5), 0.0, int64(1000), int64(0)},
+				{"checkpointer", "relation", "normal", int64(0), 0.0, int64(200), 40.0, int64(0), 0.0, int64(0), int64(0)},
+			}},
+		})
+
+		logger := &util.Logger{Destination: log.New(&bytes.Buffer{}, "", 0)}
+		server := state.Server{Config: config.ServerConfig{SectionName: "test", DbAllNames: true}}
+
+		ps, _, err := CollectFull(server, connection, state.CollectionOpts{}, logger)
+		connection.Close()
+		if err != nil {
+			t.Fatalf("Postgres %s: expected collection to succeed, got error: %s", test.version, err)
+		}
+		if diff := pretty.Compare(test.expected, ps.IOStats); diff != "" {
+			t.Errorf("Postgres %s: I/O stats diff: (-want +got)\n%s", test.version, diff)
+		}
+		if ran := fakeServer.ranQuery("FROM pg_stat_io"); ran != (test.expected != nil) {
+			t.Errorf("Postgres %s: expected pg_stat_io to be queried: %v, but it was: %v", test.version, test.expected != nil, ran)
+		}
+	}
+}
+
+func TestCollectFullProgress(t *testing.T) {
+	startedAt := time.Date(2020, time.March, 1, 12, 0, 0, 0, time.UTC)
+	vacuum := state.PostgresVacuumProgress{VacuumIdentity: 1583064000012345, BackendIdentity: 1583060000012345, DatabaseName: "app", SchemaName: "public", RelationName: "events", RoleName: "postgres", StartedAt: startedAt, Autovacuum: true, Phase: "scanning heap", HeapBlksTotal: 1000, HeapBlksScanned: 250}
+	createIndex := state.PostgresCreateIndexProgress{Pid: 4321, DatabaseOid: 16384, RelationOid: 16390, IndexOid: 16400, Command: "CREATE INDEX CONCURRENTLY", Phase: "building index: scanning table", BlocksTotal: 5000, BlocksDone: 1200}
+	baseBackup := state.PostgresBaseBackupProgress{Pid: 5432, Phase: "streaming database files", BackupTotal: null.IntFrom(1 << 30), BackupStreamed: 1 << 28, TablespacesTotal: 1}
+
+	tests := []struct {
+		versionNum string
+		version    string
+		expected   state.PostgresProgress
+	}{
+		{"130002", "13.2", state.PostgresProgress{
+			Vacuums:       []state.PostgresVacuumProgress{vacuum},
+			CreateIndexes: []state.PostgresCreateIndexProgress{createIndex},
+			BaseBackups:   []state.PostgresBaseBackupProgress{baseBackup},
+		}},
+		{"120006", "12.6", state.PostgresProgress{
+			Vacuums:       []state.PostgresVacuumProgress{vacuum},
+			CreateIndexes: []state.PostgresCreateIndexProgress{createIndex},
+		}},
+		{"110011", "11.11", state.PostgresProgress{
+			Vacuums: []state.PostgresVacuumProgress{vacuum},
+		}},
+	}
+
+	for _, test := range tests {
+		connection, fakeServer := openFakePostgres(t.Name()+test.version, []fakePostgresResponse{
+			{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL " + test.version + " on x86_64-pc-linux-gnu"}}},
+			{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{test.versionNum}}},
+			{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{test.version}}},
+			{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+			{pattern: "FROM pg_stat_progress_vacuum", columns: []string{"vacuum_identity", "backend_identity", "datname", "nspname", "relname", "usename", "started_at", "autovacuum", "phase", "heap_blks_total", "heap_blks_scanned", "heap_blks_vacuumed", "index_vacuum_count", "max_dead_tuples", "num_dead_tuples"}, rows: [][]driver.Value{
+				{int64(1583064000012345), int64(1583060000012345), "app", "public", "events", "postgres", startedAt, true, "scanning heap", int64(1000), int64(250), int64(0), int64(0), int64(0), int64(0)},
+			}},
+			{pattern: "FROM pg_stat_progress_create_index", columns: []string{"pid", "datid", "relid", "index_relid", "command", "phase", "lockers_total", "lockers_done", "blocks_total", "blocks_done", "tuples_total", "tuples_done", "partitions_total", "partitions_done"}, rows: [][]driver.Value{
+				{int64(4321), int64(16384), int64(16390), int64(16400), "CREATE INDEX CONCURRENTLY", "building index: scanning table", int64(0), int64(0), int64(5000), int64(1200), int64(0), int64(0), int64(0), int64(0)},
+			}},
+			{pattern: "FROM pg_stat_progress_basebackup", columns: []string{"pid", "phase", "backup_total", "backup_streamed", "tablespaces_total", "tablespaces_streamed"}, rows: [][]driver.Value{
+				{int64(5432), "streaming database files", int64(1 << 30), int64(1 << 28), int64(1), int64(0)},
+			}},
+		})
+
+		logger := &util.Logger{Destination: log.New(&bytes.Buffer{}, "", 0)}
+		server := state.Server{Config: config.ServerConfig{SectionName: "test", DbAllNames: true, CollectProgress: true}}
+
+		_, ts, err := CollectFull(server, connection, state.CollectionOpts{}, logger)
+		connection.Close()
+		if err != nil {
+			t.Fatalf("Postgres %s: expected collection to succeed, got error: %s", test.version, err)
+		}
+		if diff := pretty.Compare(test.expected, ts.Progress); diff != "" {
+			t.Errorf("Postgres %s: progress diff: (-want +got)\n%s", test.version, diff)
+		}
+		if ran := fakeServer.ranQuery("FROM pg_stat_progress_create_index"); ran != (test.expected.CreateIndexes != nil) {
+			t.Errorf("Postgres %s: expected pg_stat_progress_create_index to be queried: %v, but it was: %v", test.version, test.expected.CreateIndexes != nil, ran)
+		}
+		if ran := fakeServer.ranQuery("FROM pg_stat_progress_basebackup"); ran != (test.expected.BaseBackups != nil) {
+			t.Errorf("Postgres %s: expected pg_stat_progress_basebackup to be queried: %v, but it was: %v", test.version, test.expected.BaseBackups != nil, ran)
+		}
+	}
+}
+
+func TestCollectFullProgressDisabled(t *testing.T) {
+	connection, fakeServer := openFakePostgres(t.Name(), []fakePostgresResponse{
+		{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL 13.2 on x86_64-pc-linux-gnu"}}},
+		{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{"130002"}}},
+		{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{"13.2"}}},
+		{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+	})
+	defer connection.Close()
+
+	logger := &util.Logger{Destination: log.New(&bytes.Buffer{}, "", 0)}
+	server := state.Server{Config: config.ServerConfig{SectionName: "test", DbAllNames: true}}
+
+	_, _, err := CollectFull(server, connection, state.CollectionOpts{}, logger)
+	if err != nil {
+		t.Fatalf("Expected collection to succeed, got error: %s", err)
+	}
+	if fakeServer.ranQuery("pg_stat_progress") {
+		t.Errorf("Expected no progress views to be queried when collect_progress is disabled")
+	}
+}
+
+func TestCollectFullBgwriterStats(t *testing.T) {
+	tests := []struct {
+		versionNum string
+		version    string
+		split      bool
+		expected   state.PostgresBgwriterStats
+	}{
+		// Combined layout of pg_stat_bgwriter before Postgres 17
+		{"160001", "16.1", false, state.PostgresBgwriterStats{CheckpointsTimed: 10, CheckpointsRequested: 2, CheckpointWriteTime: 1000.5, CheckpointSyncTime: 50.25, BuffersCheckpoint: 6000, BuffersClean: 1200, MaxwrittenClean: 3, BuffersBackend: 600, BuffersBackendFsync: 1, BuffersAlloc: 12000}},
+		// Checkpointer counters in pg_stat_checkpointer since Postgres 17
+		{"170000", "17.0", true, state.PostgresBgwriterStats{CheckpointsTimed: 20, CheckpointsRequested: 4, CheckpointWriteTime: 2000.5, CheckpointSyncTime: 100.25, BuffersCheckpoint: 7000, BuffersClean: 1300, MaxwrittenClean: 5, BuffersAlloc: 13000}},
+	}
+
+	for _, test := range tests {
+		connection, fakeServer := openFakePostgres(t.Name()+test.version, []fakePostgresResponse{
+			{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL " + test.version + " on x86_64-pc-linux-gnu"}}},
+			{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{test.versionNum}}},
+			{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{test.version}}},
+			{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+			{pattern: "FROM pg_stat_checkpointer c, pg_stat_bgwriter b", columns: []string{"num_timed", "num_requested", "write_time", "sync_time", "buffers_written", "buffers_clean", "maxwritten_clean", "?column?", "?column?", "buffers_alloc"}, rows: [][]driver.Value{
+				{int64(20), int64(4), 2000.5, 100.25, int64(7000), int64(1300), int64(5), int64(0), int64(0), int64(13000)},
+			}},
+			{pattern: "FROM pg_stat_bgwriter", columns: []string{"checkpoints_timed", "checkpoints_req", "checkpoint_write_time", "checkpoint_sync_time", "buffers_checkpoint", "buffers_clean", "maxwritten_clean", "buffers_backend", "buffers_backend_fsync", "buffers_alloc"}, rows: [][]driver.Value{
+				{int64(10), int64(2), 1000.5, 50.25, int64(6000), int64(1200), int64(3), int64(600), int64(1), int64(12000)},
+			}},
+		})
+
+		logger := &util.Logger{Destination: log.New(&bytes.Buffer{}, "", 0)}
+		server := state.Server{Config: config.ServerConfig{SectionName: "test", DbAllNames: true}}
+
+		ps, _, err := CollectFull(server, connection, state.CollectionOpts{}, logger)
+		connection.Close()
+		if err != nil {
+			t.Fatalf("Postgres %s: expected collection to succeed, got error: %s", test.version, err)
+		}
+		if diff := pretty.Compare(test.expected, ps.BgwriterStats); diff != "" {
+			t.Errorf("Postgres %s: bgwriter stats diff: (-want +got)\n%s", test.version, diff)
+		}
+		if ran := fakeServer.ranQuery("FROM pg_stat_checkpointer"); ran != test.split {
+			t.Errorf("Postgres %s: expected pg_stat_checkpointer to be queried: %v, but it was: %v", test.version, test.split, ran)
+		}
+	}
+}
+
+func TestGetRelationStatsSizes(t *testing.T) {
+	columns := []string{"relid", "size_bytes", "main_size_bytes", "toast_size_bytes", "index_size_bytes"}
+	row := []driver.Value{int64(16390), int64(120000000), int64(9000000), int64(110000000), int64(2500000)}
+	for len(columns) < 32 {
+		columns = append(columns, "?column?")
+		row = append(row, int64(0))
+	}
+	// n_mod_since_analyze, last_vacuum, last_autovacuum, last_analyze and last_autoanalyze
+	for idx := 15; idx <= 19; idx++ {
+		row[idx] = nil
+	}
+
+	connection, fakeServer := openFakePostgres(t.Name(), []fakePostgresResponse{
+		{pattern: "FROM pg_stat_user_tables", columns: columns, rows: [][]driver.Value{row}},
+	})
+	defer connection.Close()
+
+	relStats, err := postgres.GetRelationStats(connection, state.PostgresVersion{Numeric: state.PostgresVersion14}, config.ServerConfig{})
+	if err != nil {
+		t.Fatalf("Expected relation stats to be collected, got error: %s", err)
+	}
+
+	expected := state.PostgresRelationStatsMap{16390: {SizeBytes: 120000000, MainSizeBytes: 9000000, ToastSizeBytes: 110000000, IndexSizeBytes: 2500000}}
+	if diff := pretty.Compare(expected, relStats); diff != "" {
+		t.Errorf("Relation stats: (-want +got)\n%s", diff)
+	}
+	if !fakeServer.ranQuery("pg_total_relation_size(NULLIF(c.reltoastrelid, 0))") {
+		t.Errorf("Expected the TOAST table size to be queried")
+	}
+}
+
+func TestCollectFullLogicalReplicationSlots(t *testing.T) {
+	tests := []struct {
+		versionNum string
+		version    string
+		expected   []state.PostgresReplicationSlot
+	}{
+		{"140005", "14.5", []state.PostgresReplicationSlot{
+			{SlotName: "cdc_active", Plugin: "pgoutput", DatabaseName: "app", Active: true, RestartLsn: null.StringFrom("0/4FF0000"), ConfirmedFlushLsn: null.StringFrom("0/4FFF000"), RestartByteLag: null.IntFrom(65536), ConfirmedFlushByteLag: null.IntFrom(4096)},
+			{SlotName: "cdc_stuck", Plugin: "wal2json", DatabaseName: "app", Active: false, RestartLsn: null.StringFrom("0/1000000"), ConfirmedFlushLsn: null.StringFrom("0/1000000"), RestartByteLag: null.IntFrom(67108864), ConfirmedFlushByteLag: null.IntFrom(67108864)},
+		}},
+		// No logical decoding before Postgres 9.4
+		{"90324", "9.3.24", nil},
+	}
+
+	for _, test := range tests {
+		connection, fakeServer := openFakePostgres(t.Name()+test.version, []fakePostgresResponse{
+			{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL " + test.version + " on x86_64-pc-linux-gnu"}}},
+			{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{test.versionNum}}},
+			{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{test.version}}},
+			{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+			{pattern: "pg_is_in_recovery() AS in_recovery", columns: []string{"in_recovery", "current_xlog_location", "is_streaming", "receive_location", "replay_location", "apply_byte_lag", "replay_ts", "replay_ts_age"}, rows: [][]driver.Value{
+				{false, "0/5000000", nil, nil, nil, nil, nil, nil},
+			}},
+			{pattern: "FROM pg_replication_slots", columns: []string{"slot_name", "plugin", "database", "active", "restart_lsn", "confirmed_flush_lsn", "restart_byte_lag", "confirmed_flush_byte_lag"}, rows: [][]driver.Value{
+				{"cdc_active", "pgoutput", "app", true, "0/4FF0000", "0/4FFF000", int64(65536), int64(4096)},
+				{"cdc_stuck", "wal2json", "app", false, "0/1000000", "0/1000000", int64(67108864), int64(67108864)},
+			}},
+		})
+
+		logger := &util.Logger{Destination: log.New(&bytes.Buffer{}, "", 0)}
+		server := state.Server{Config: config.ServerConfig{SectionName: "test", DbAllNames: true}}
+
+		ps, _, err := CollectFull(server, connection, state.CollectionOpts{}, logger)
+		connection.Close()
+		if err != nil {
+			t.Fatalf("Postgres %s: expected collection to succeed, got error: %s", test.version, err)
+		}
+		if diff := pretty.Compare(test.expected, ps.Replication.LogicalSlots); diff != "" {
+			t.Errorf("Postgres %s: logical slots diff: (-want +got)\n%s", test.version, diff)
+		}
+		if ran := fakeServer.ranQuery("FROM pg_replication_slots"); ran != (test.expected != nil) {
+			t.Errorf("Postgres %s: expected pg_replication_slots to be queried: %v, but it was: %v", test.version, test.expected != nil, ran)
+		}
+	}
+}
+
+func TestCollectFullCustomMetrics(t *testing.T) {
+	connection, _ := openFakePostgres(t.Name(), []fakePostgresResponse{
+		{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL 14.5 on x86_64-pc-linux-gnu"}}},
+		{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{"140005"}}},
+		{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{"14.5"}}},
+		{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+		{pattern: "FROM jobs", columns: []string{"count"}, rows: [][]driver.Value{{int64(42)}}},
+		{pattern: "FROM missing_table", err: &pq.Error{Code: "42P01", Message: `relation "missing_table" does not exist`}},
+		{pattern: "FROM slow_table", err: &pq.Error{Code: "57014", Message: "canceling statement due to statement timeout"}},
+	})
+	defer connection.Close()
+
+	var output bytes.Buffer
+	logger := &util.Logger{Destination: log.New(&output, "", 0)}
+	server := state.Server{Config: config.ServerConfig{SectionName: "test", DbAllNames: true, CustomMetrics: map[string]string{
+		"queued_jobs":   "SELECT count(*) FROM jobs WHERE state = 'queued'",
+		"missing":       "SELECT count(*) FROM missing_table",
+		"timed_out_sum": "SELECT sum(amount) FROM slow_table",
+	}}}
+
+	_, ts, err := CollectFull(server, connection, state.CollectionOpts{}, logger)
+	if err != nil {
+		t.Fatalf("Expected failing custom metrics not to fail the snapshot, got error: %s", err)
+	}
+	expected := []state.PostgresCustomMetric{{Name: "queued_jobs", Value: 42}}
+	if diff := pretty.Compare(expected, ts.CustomMetrics); diff != "" {
+		t.Errorf("Custom metrics diff: (-want +got)\n%s", diff)
+	}
+	for _, name := range []string{"missing", "timed_out_sum"} {
+		if !strings.Contains(output.String(), "Error collecting custom metric "+name+":") {
+			t.Errorf("Expected failure of custom metric %s to be logged, got: %s", name, output.String())
+		}
+	}
+}
@@ -2,20 +2,47 @@ package input
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pganalyze/collector/input/postgres"
 	"github.com/pganalyze/collector/input/system"
+	"github.com/pganalyze/collector/input/system/logs"
 	"github.com/pganalyze/collector/state"
 	"github.com/pganalyze/collector/util"
 )
 
 // DownloadLogs - Downloads a "logs" snapshot of log data we need on a regular interval
-func DownloadLogs(server state.Server, connection *sql.DB, collectionOpts state.CollectionOpts, logger *util.Logger) (ls state.LogState, err error) {
+//
+// Also returns where reading the log files left off, which should only be
+// remembered once the logs were sent successfully.
+func DownloadLogs(server state.Server, rdsPosition state.RdsLogsPosition, connection *sql.DB, collectionOpts state.CollectionOpts, logger *util.Logger) (ls state.LogState, newRdsPosition state.RdsLogsPosition, err error) {
 	var querySamples []state.PostgresQuerySample
 
 	ls.CollectedAt = time.Now()
-	ls.LogFiles, querySamples = system.DownloadLogFiles(server.Config, logger)
+	ls.LogFiles, querySamples, newRdsPosition = system.DownloadLogFiles(server.Config, rdsPosition, collectionOpts, logger)
+	ls.LogFiles, querySamples, err = logs.FilterDownloadedLogFiles(server, ls.LogFiles, querySamples, collectionOpts)
+	if err != nil {
+		err = fmt.Errorf("could not filter log files: %s", err)
+		return
+	}
+	if !server.Grant.CollectQuerySamples() {
+		querySamples = nil
+	}
+	querySamples = logs.RedactQueryParametersByPosition(querySamples, server.Config)
+	querySamples = logs.NormalizeQuerySamples(querySamples)
+	querySamples = logs.DeduplicateQuerySamples(querySamples, server.Config.MaxQuerySamplesPerFingerprint)
+	querySamples = logs.LimitQuerySamples(querySamples, server.Config.MaxQuerySamplesPerSnapshot)
+	querySamples = logs.RedactQuerySamples(querySamples, server.Config)
+	if collectionOpts.TestRun {
+		problems := logs.ValidateQuerySampleRedaction(querySamples, server.Config)
+		if len(problems) > 0 {
+			err = fmt.Errorf("query samples were not redacted according to the filter_query_sample setting: %s", strings.Join(problems, ", "))
+			return
+		}
+	}
+	querySamples = logs.TruncateQuerySamples(querySamples, server.Config.MaxQuerySampleLength)
 
 	if false && collectionOpts.CollectExplain && server.Grant.Config.Features.Explain {
 		ls.QuerySamples = postgres.RunExplain(connection, querySamples)
@@ -0,0 +1,20 @@
+package input
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pganalyze/collector/input/postgres"
+	"github.com/pganalyze/collector/util"
+)
+
+// CheckPermissions - Detects the Postgres version, and checks that the connected
+// role can access everything the collector needs for it
+func CheckPermissions(connection *sql.DB, logger *util.Logger) ([]postgres.PermissionProblem, error) {
+	postgresVersion, err := postgres.GetPostgresVersion(logger, connection)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine Postgres version: %s", err)
+	}
+
+	return postgres.CheckPermissions(connection, postgresVersion)
+}
@@ -0,0 +1,99 @@
+package input
+
+import (
+	"database/sql/driver"
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/lib/pq"
+	"github.com/pganalyze/collector/util"
+)
+
+func permissionTestVersionResponses(version string, versionNum string) []fakePostgresResponse {
+	return []fakePostgresResponse{
+		{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL " + version + " on x86_64-pc-linux-gnu"}}},
+		{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{versionNum}}},
+		{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{version}}},
+		{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+		{pattern: "SELECT current_user", columns: []string{"current_user"}, rows: [][]driver.Value{{"pganalyze"}}},
+	}
+}
+
+var permissionTests = []struct {
+	description string
+	version     string
+	versionNum  string
+	responses   []fakePostgresResponse
+	expected    []string // Check and remediation of each problem
+}{
+	{
+		"role without pg_monitor, and pg_stat_statements not installed",
+		"14.5", "140005",
+		[]fakePostgresResponse{
+			{pattern: "FROM pg_stat_statements", err: &pq.Error{Severity: "ERROR", Code: "42P01", Message: `relation "pg_stat_statements" does not exist`}},
+		},
+		[]string{
+			"pg_stat_statements: CREATE EXTENSION IF NOT EXISTS pg_stat_statements; -- requires pg_stat_statements in shared_preload_libraries",
+			`pg_stat_activity: GRANT pg_monitor TO "pganalyze";`,
+			`pg_stat_replication: GRANT pg_monitor TO "pganalyze";`,
+			`pg_stat_progress_vacuum: GRANT pg_monitor TO "pganalyze";`,
+		},
+	},
+	{
+		"role with pg_monitor",
+		"14.5", "140005",
+		[]fakePostgresResponse{
+			{pattern: "FROM pg_auth_members", columns: []string{"?column?"}, rows: [][]driver.Value{{true}}},
+		},
+		nil,
+	},
+	{
+		"superuser without access to statio views",
+		"14.5", "140005",
+		[]fakePostgresResponse{
+			{pattern: "is_superuser", columns: []string{"?column?"}, rows: [][]driver.Value{{true}}},
+			{pattern: "pg_statio_user_tables", err: &pq.Error{Severity: "ERROR", Code: "42501", Message: "permission denied for view pg_statio_user_tables"}},
+		},
+		[]string{
+			`relation statistics: GRANT pg_monitor TO "pganalyze";`,
+		},
+	},
+	{
+		"role on Postgres 9.6 with only the pg_stat_statements helper",
+		"9.6.24", "90624",
+		[]fakePostgresResponse{
+			{pattern: "proname = 'get_stat_statements'", columns: []string{"enabled"}, rows: [][]driver.Value{{true}}},
+			// pg_monitor doesn't exist before Postgres 10, so membership must not be trusted
+			{pattern: "FROM pg_auth_members", columns: []string{"?column?"}, rows: [][]driver.Value{{true}}},
+		},
+		[]string{
+			"pg_stat_activity: -- Create the pganalyze.get_stat_activity() helper function as a superuser",
+			"pg_stat_replication: -- Create the pganalyze.get_stat_replication() helper function as a superuser",
+			"pg_stat_progress_vacuum: -- Create the pganalyze.get_stat_progress_vacuum() helper function as a superuser",
+		},
+	},
+}
+
+func TestCheckPermissions(t *testing.T) {
+	for _, test := range permissionTests {
+		responses := append(permissionTestVersionResponses(test.version, test.versionNum), test.responses...)
+		connection, _ := openFakePostgres(t.Name()+test.description, responses)
+		logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+		problems, err := CheckPermissions(connection, logger)
+		connection.Close()
+		if err != nil {
+			t.Fatalf("%s: expected permission check to succeed, got error: %s", test.description, err)
+		}
+
+		var actual []string
+		for _, problem := range problems {
+			actual = append(actual, problem.Check+": "+problem.Remediation)
+		}
+		if diff := pretty.Compare(test.expected, actual); diff != "" {
+			t.Errorf("%s: problems diff: (-want +got)\n%s", test.description, diff)
+		}
+	}
+}
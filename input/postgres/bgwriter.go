@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+const bgwriterStatsSQL string = `
+SELECT checkpoints_timed, checkpoints_req, checkpoint_write_time, checkpoint_sync_time,
+			 buffers_checkpoint, buffers_clean, maxwritten_clean,
+			 buffers_backend, buffers_backend_fsync, buffers_alloc
+	FROM pg_stat_bgwriter`
+
+// Postgres 17 moved the checkpointer counters to their own view, and dropped
+// the backend counters (which are now in pg_stat_io)
+const bgwriterStatsSQLpg17 string = `
+SELECT c.num_timed, c.num_requested, c.write_time, c.sync_time,
+			 c.buffers_written, b.buffers_clean, b.maxwritten_clean,
+			 0, 0, b.buffers_alloc
+	FROM pg_stat_checkpointer c, pg_stat_bgwriter b`
+
+// GetBgwriterStats - Reads the background writer and checkpointer counters
+func GetBgwriterStats(logger *util.Logger, db *sql.DB, postgresVersion state.PostgresVersion) (stats state.PostgresBgwriterStats, err error) {
+	sql := bgwriterStatsSQL
+	if postgresVersion.Numeric >= state.PostgresVersion17 {
+		sql = bgwriterStatsSQLpg17
+	}
+
+	err = db.QueryRow(QueryMarkerSQL+sql).Scan(&stats.CheckpointsTimed, &stats.CheckpointsRequested,
+		&stats.CheckpointWriteTime, &stats.CheckpointSyncTime, &stats.BuffersCheckpoint,
+		&stats.BuffersClean, &stats.MaxwrittenClean, &stats.BuffersBackend,
+		&stats.BuffersBackendFsync, &stats.BuffersAlloc)
+	return
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// GetCustomMetrics - Runs the configured custom metric queries, ordered by name
+//
+// Each query has to return a single numeric value. A query that fails (e.g.
+// because it hit the statement timeout) only leaves out its own metric, and
+// gets logged as a warning.
+func GetCustomMetrics(logger *util.Logger, db *sql.DB, queries map[string]string) (metrics []state.PostgresCustomMetric) {
+	var names []string
+	for name := range queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, err := getCustomMetric(db, queries[name])
+		if err != nil {
+			logger.PrintWarning("Error collecting custom metric %s: %s", name, err)
+			continue
+		}
+		metrics = append(metrics, state.PostgresCustomMetric{Name: name, Value: value})
+	}
+
+	return
+}
+
+func getCustomMetric(db *sql.DB, query string) (float64, error) {
+	var value sql.NullFloat64
+
+	err := db.QueryRow(QueryMarkerSQL + query).Scan(&value)
+	if err != nil {
+		return 0, err
+	}
+	if !value.Valid {
+		return 0, fmt.Errorf("query returned NULL")
+	}
+
+	return value.Float64, nil
+}
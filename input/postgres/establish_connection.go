@@ -2,20 +2,24 @@ package postgres
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"time"
 
 	"github.com/pganalyze/collector/config"
 	"github.com/pganalyze/collector/state"
 	"github.com/pganalyze/collector/util"
+	"github.com/pganalyze/collector/util/awsutil"
 )
 
 func EstablishConnection(server state.Server, logger *util.Logger, globalCollectionOpts state.CollectionOpts, databaseName string) (connection *sql.DB, err error) {
-	connection, err = connectToDb(server.Config, logger, globalCollectionOpts, databaseName)
+	statementTimeoutMs := getStatementTimeoutMs(logger, server.Grant.Config.Features.StatementTimeoutMs)
+
+	connection, err = connectToDb(server.Config, logger, globalCollectionOpts, databaseName, statementTimeoutMs)
 	if err != nil {
-		if err.Error() == "pq: SSL is not enabled on the server" && (server.Config.DbSslMode == "prefer" || server.Config.DbSslMode == "") {
+		if err.Error() == "pq: SSL is not enabled on the server" && (server.Config.DbSslMode == "prefer" || server.Config.DbSslMode == "") && server.Config.TLSMinVersion == "" {
 			server.Config.DbSslModePreferFailed = true
-			connection, err = connectToDb(server.Config, logger, globalCollectionOpts, databaseName)
+			connection, err = connectToDb(server.Config, logger, globalCollectionOpts, databaseName, statementTimeoutMs)
 		}
 	}
 
@@ -28,57 +32,123 @@ func EstablishConnection(server state.Server, logger *util.Logger, globalCollect
 		return
 	}
 
-	setStatementTimeout(connection, logger, server.Grant.Config.Features.StatementTimeoutMs)
-
 	return
 }
 
-func connectToDb(config config.ServerConfig, logger *util.Logger, globalCollectionOpts state.CollectionOpts, databaseName string) (*sql.DB, error) {
-	connectString := config.GetPqOpenString(databaseName)
-	connectString += " application_name=" + globalCollectionOpts.CollectorApplicationName
-
-	// logger.PrintVerbose("sql.Open(\"postgres\", \"%s\")", connectString)
-
-	db, err := sql.Open("postgres", connectString)
+func connectToDb(config config.ServerConfig, logger *util.Logger, globalCollectionOpts state.CollectionOpts, databaseName string, statementTimeoutMs int32) (*sql.DB, error) {
+	err := config.ValidateConnectionSecurity()
 	if err != nil {
 		return nil, err
 	}
 
+	db := sql.OpenDB(newConnector(config, databaseName, globalCollectionOpts, statementTimeoutMs))
+
 	db.SetMaxOpenConns(1)
 	db.SetConnMaxLifetime(30 * time.Second)
 
 	err = db.Ping()
 	if err != nil {
+		db.Close()
 		return nil, err
 	}
 
 	return db, nil
 }
 
-func validateConnectionCount(connection *sql.DB, logger *util.Logger, maxCollectorConnections int, globalCollectionOpts state.CollectionOpts) error {
-	var connectionCount int
-
-	connection.QueryRow(QueryMarkerSQL + "SELECT COUNT(*) FROM pg_stat_activity WHERE application_name = '" + globalCollectionOpts.CollectorApplicationName + "'").Scan(&connectionCount)
+// newConnector - Returns the connector that opens each new connection of the pool
+//
+// The connection string is built and the TLS version checked for every new
+// connection, instead of once upfront, since database/sql replaces connections
+// after their maximum lifetime (and an IAM authentication token might have
+// expired by then).
+func newConnector(config config.ServerConfig, databaseName string, globalCollectionOpts state.CollectionOpts, statementTimeoutMs int32) *statementTimeoutConnector {
+	connector := newStatementTimeoutConnector(func() (string, error) {
+		return getConnectString(config, databaseName, globalCollectionOpts)
+	}, statementTimeoutMs)
+	connector.tlsMinVersion = config.TLSMinVersion
+	return connector
+}
 
-	if connectionCount > maxCollectorConnections {
-		return fmt.Errorf("Too many open monitoring connections (current: %d, maximum allowed: %d), exiting", connectionCount, maxCollectorConnections)
+// getRdsAuthToken - Generates the password for IAM authentication, replaced in tests
+var getRdsAuthToken = awsutil.GetRdsAuthToken
+
+// getConnectString - Returns the lib/pq connection string for the given database
+//
+// With IAM authentication a new token is used as the password every time,
+// since tokens expire after 15 minutes.
+func getConnectString(config config.ServerConfig, databaseName string, globalCollectionOpts state.CollectionOpts) (string, error) {
+	if config.DbUseIamAuth {
+		token, err := getRdsAuthToken(config)
+		if err != nil {
+			return "", fmt.Errorf("Could not generate IAM authentication token: %s", err)
+		}
+		config.DbPassword = token
 	}
 
-	return nil
+	connectString := config.GetPqOpenString(databaseName)
+	connectString += " application_name=" + globalCollectionOpts.CollectorApplicationName
+
+	return connectString, nil
 }
 
-func setStatementTimeout(connection *sql.DB, logger *util.Logger, statementTimeoutMs int32) {
-	if statementTimeoutMs == 0 { // Default value
-		statementTimeoutMs = 30000
+const tlsVersionSQL string = `
+SELECT COALESCE(version, '')
+	FROM pg_stat_ssl
+ WHERE pid = pg_backend_pid()`
+
+// validateTLSVersion - Checks the TLS version that was negotiated for the
+// connection, since the Postgres driver doesn't let us restrict it upfront
+//
+// This runs after authentication, so it only keeps the collector from using
+// the connection, not the password from being sent over it.
+func validateTLSVersion(conn driver.Conn, minVersionName string) error {
+	minVersion, err := config.ParseTLSVersion(minVersionName)
+	if err != nil {
+		return err
 	}
 
-	// Assume anything below 100ms to be set in error - its not reasonable to have our queries run faster than that
-	if statementTimeoutMs < 100 {
-		logger.PrintVerbose("Ignoring invalid statement timeout of %dms (set it to at least 100ms)", statementTimeoutMs)
-		return
+	queryer, ok := conn.(driver.Queryer)
+	if !ok {
+		return fmt.Errorf("Could not check TLS version of the connection: database connection does not support queries")
 	}
+	rows, err := queryer.Query(QueryMarkerSQL+tlsVersionSQL, nil)
+	if err != nil {
+		return fmt.Errorf("Could not check TLS version of the connection: %s", err)
+	}
+	defer rows.Close()
 
-	connection.Exec(fmt.Sprintf("%sSET statement_timeout = %d", QueryMarkerSQL, statementTimeoutMs))
+	values := make([]driver.Value, 1)
+	err = rows.Next(values)
+	if err != nil {
+		return fmt.Errorf("Could not check TLS version of the connection: %s", err)
+	}
+	var versionName string
+	switch value := values[0].(type) {
+	case string:
+		versionName = value
+	case []byte:
+		versionName = string(value)
+	}
+	if versionName == "" {
+		return fmt.Errorf("Connection does not use SSL, but db_ssl_min_protocol_version = %s requires it", minVersionName)
+	}
 
-	return
+	version, err := config.ParseTLSVersion(versionName)
+	if err != nil || version < minVersion {
+		return fmt.Errorf("Connection uses %s, but db_ssl_min_protocol_version = %s requires a newer TLS version", versionName, minVersionName)
+	}
+
+	return nil
+}
+
+func validateConnectionCount(connection *sql.DB, logger *util.Logger, maxCollectorConnections int, globalCollectionOpts state.CollectionOpts) error {
+	var connectionCount int
+
+	connection.QueryRow(QueryMarkerSQL + "SELECT COUNT(*) FROM pg_stat_activity WHERE application_name = '" + globalCollectionOpts.CollectorApplicationName + "'").Scan(&connectionCount)
+
+	if connectionCount > maxCollectorConnections {
+		return fmt.Errorf("Too many open monitoring connections (current: %d, maximum allowed: %d), exiting", connectionCount, maxCollectorConnections)
+	}
+
+	return nil
 }
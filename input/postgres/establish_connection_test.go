@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+)
+
+func TestGetConnectStringIamAuth(t *testing.T) {
+	var calls int
+	prevGetRdsAuthToken := getRdsAuthToken
+	getRdsAuthToken = func(config config.ServerConfig) (string, error) {
+		calls++
+		return "mydb.123456789012.us-east-1.rds.amazonaws.com:5432/?Action=connect&DBUser=myuser&X-Amz-Signature=abc" + strings.Repeat("x", calls), nil
+	}
+	defer func() { getRdsAuthToken = prevGetRdsAuthToken }()
+
+	serverConfig := config.ServerConfig{DbHost: "mydb.123456789012.us-east-1.rds.amazonaws.com", DbUsername: "myuser", DbPassword: "unused", DbUseIamAuth: true}
+	opts := state.CollectionOpts{CollectorApplicationName: "pganalyze_collector"}
+
+	// Each connection gets a new token, since they expire
+	for i := 1; i <= 2; i++ {
+		connectString, err := getConnectString(serverConfig, "", opts)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if calls != i {
+			t.Errorf("Expected token to be generated %d times, got %d", i, calls)
+		}
+		expected := "password='mydb.123456789012.us-east-1.rds.amazonaws.com:5432/?Action=connect&DBUser=myuser&X-Amz-Signature=abc" + strings.Repeat("x", i) + "'"
+		if !strings.Contains(connectString, expected) {
+			t.Errorf("Expected connect string to contain %s, got %s", expected, connectString)
+		}
+		if strings.Contains(connectString, "unused") {
+			t.Errorf("Expected configured password to be replaced by the token, got %s", connectString)
+		}
+	}
+}
+
+func TestGetConnectStringWithoutIamAuth(t *testing.T) {
+	prevGetRdsAuthToken := getRdsAuthToken
+	getRdsAuthToken = func(config config.ServerConfig) (string, error) {
+		t.Errorf("Expected no token to be generated without IAM authentication")
+		return "", nil
+	}
+	defer func() { getRdsAuthToken = prevGetRdsAuthToken }()
+
+	connectString, err := getConnectString(config.ServerConfig{DbUsername: "myuser", DbPassword: "secret"}, "", state.CollectionOpts{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !strings.Contains(connectString, "password='secret'") {
+		t.Errorf("Expected configured password to be used, got %s", connectString)
+	}
+}
+
+func TestConnectorIamAuthTokenPerConnection(t *testing.T) {
+	var calls int
+	prevGetRdsAuthToken := getRdsAuthToken
+	getRdsAuthToken = func(config config.ServerConfig) (string, error) {
+		calls++
+		return "token" + strings.Repeat("x", calls), nil
+	}
+	defer func() { getRdsAuthToken = prevGetRdsAuthToken }()
+
+	serverConfig := config.ServerConfig{DbHost: "mydb.123456789012.us-east-1.rds.amazonaws.com", DbUsername: "myuser", DbUseIamAuth: true}
+	var statements []string
+	var dsns []string
+	connector := newConnector(serverConfig, "", state.CollectionOpts{}, 30000)
+	connector.open = func(dsn string) (driver.Conn, error) {
+		dsns = append(dsns, dsn)
+		return fakeConn{statements: &statements}, nil
+	}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(0)
+
+	// Connections that replace an earlier one (e.g. after their maximum
+	// lifetime) must not reuse an expired token
+	db.Exec("SELECT 1")
+	db.Exec("SELECT 2")
+
+	if len(dsns) != 2 {
+		t.Fatalf("Expected 2 connections, got %d", len(dsns))
+	}
+	for i, dsn := range dsns {
+		expected := "password='token" + strings.Repeat("x", i+1) + "'"
+		if !strings.Contains(dsn, expected) {
+			t.Errorf("Expected connection %d to use %s, got %s", i+1, expected, dsn)
+		}
+	}
+}
+
+// fakeTLSConn - Answers the TLS version check with the given version
+type fakeTLSConn struct {
+	fakeConn
+	version string
+}
+
+func (c fakeTLSConn) Query(query string, args []driver.Value) (driver.Rows, error) {
+	return &fakeTLSRows{version: c.version}, nil
+}
+
+type fakeTLSRows struct {
+	version string
+	done    bool
+}
+
+func (r *fakeTLSRows) Columns() []string { return []string{"version"} }
+func (r *fakeTLSRows) Close() error      { return nil }
+func (r *fakeTLSRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = []byte(r.version)
+	return nil
+}
+
+func TestConnectorTLSVersionPerConnection(t *testing.T) {
+	serverConfig := config.ServerConfig{DbHost: "db", DbUsername: "myuser", TLSMinVersion: "TLSv1.2"}
+	var statements []string
+	versions := []string{"TLSv1.3", "TLSv1.1"}
+	connector := newConnector(serverConfig, "", state.CollectionOpts{}, 30000)
+	connector.open = func(dsn string) (driver.Conn, error) {
+		version := versions[0]
+		versions = versions[1:]
+		return fakeTLSConn{fakeConn: fakeConn{statements: &statements}, version: version}, nil
+	}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(0)
+
+	if _, err := db.Exec("SELECT 1"); err != nil {
+		t.Errorf("Expected first connection (TLSv1.3) to be used, got error: %s", err)
+	}
+
+	// A connection that replaces an earlier one gets checked as well
+	_, err := db.Exec("SELECT 2")
+	if err == nil || !strings.Contains(err.Error(), "Connection uses TLSv1.1") {
+		t.Errorf("Expected second connection (TLSv1.1) to be rejected, got: %v", err)
+	}
+	if len(statements) != 2 || statements[1] != "SELECT 1" {
+		t.Errorf("Expected no statements on the rejected connection, got %v", statements)
+	}
+}
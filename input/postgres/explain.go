@@ -35,3 +35,17 @@ func RunExplain(db *sql.DB, inputs []state.PostgresQuerySample) (outputs []state
 
 	return
 }
+
+// GetAutoExplainLogMinDuration - Returns the auto_explain.log_min_duration
+// setting (in milliseconds), and whether auto_explain is loaded at all - its
+// settings only exist once the library was loaded
+func GetAutoExplainLogMinDuration(db *sql.DB) (minDuration int, loaded bool, err error) {
+	err = db.QueryRow(QueryMarkerSQL + "SELECT setting FROM pg_settings WHERE name = 'auto_explain.log_min_duration'").Scan(&minDuration)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return minDuration, true, nil
+}
@@ -5,12 +5,18 @@ import (
 	"fmt"
 
 	"github.com/guregu/null"
+	"github.com/pganalyze/collector/config"
 	"github.com/pganalyze/collector/state"
 )
 
 const functionsSQLDefaultKindFields = "pp.proisagg, pp.proiswindow"
 const functionsSQLpg11KindFields = "pp.prokind = 'a', pp.prokind = 'w'"
 
+var functionsSQLKindFields = []versionedSQL{
+	{state.PostgresVersion11, functionsSQLpg11KindFields},
+	{0, functionsSQLDefaultKindFields},
+}
+
 const functionsSQL string = `
 SELECT pp.oid,
 			 pn.nspname,
@@ -32,22 +38,18 @@ SELECT pp.oid,
  INNER JOIN pg_language pl ON (pp.prolang = pl.oid)
  WHERE pl.lanname NOT IN ('internal', 'c')
 			 AND pn.nspname NOT IN ('pg_catalog', 'information_schema')
-			 AND pp.proname NOT IN ('pg_stat_statements', 'pg_stat_statements_reset')`
+			 AND pp.proname NOT IN ('pg_stat_statements', 'pg_stat_statements_reset')
+			 AND %s`
 
 const functionStatsSQL string = `
 SELECT funcid, calls, total_time, self_time
-	FROM pg_stat_user_functions`
+	FROM pg_stat_user_functions
+ WHERE %s`
 
-func GetFunctions(db *sql.DB, postgresVersion state.PostgresVersion, currentDatabaseOid state.Oid) ([]state.PostgresFunction, error) {
-	var kindFields string
-
-	if postgresVersion.Numeric >= state.PostgresVersion11 {
-		kindFields = functionsSQLpg11KindFields
-	} else {
-		kindFields = functionsSQLDefaultKindFields
-	}
+func GetFunctions(db *sql.DB, postgresVersion state.PostgresVersion, currentDatabaseOid state.Oid, serverConfig config.ServerConfig) ([]state.PostgresFunction, error) {
+	kindFields := sqlForVersion(postgresVersion, functionsSQLKindFields)
 
-	stmt, err := db.Prepare(QueryMarkerSQL + fmt.Sprintf(functionsSQL, kindFields))
+	stmt, err := db.Prepare(QueryMarkerSQL + fmt.Sprintf(functionsSQL, kindFields, schemaFilterSQL(serverConfig, "pn.nspname")))
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +78,7 @@ func GetFunctions(db *sql.DB, postgresVersion state.PostgresVersion, currentData
 
 		row.DatabaseOid = currentDatabaseOid
 		row.Config = unpackPostgresStringArray(config)
+		row.DefinitionHash = row.HashDefinition()
 
 		functions = append(functions, row)
 	}
@@ -83,8 +86,8 @@ func GetFunctions(db *sql.DB, postgresVersion state.PostgresVersion, currentData
 	return functions, nil
 }
 
-func GetFunctionStats(db *sql.DB, postgresVersion state.PostgresVersion) (functionStats state.PostgresFunctionStatsMap, err error) {
-	stmt, err := db.Prepare(QueryMarkerSQL + functionStatsSQL)
+func GetFunctionStats(db *sql.DB, postgresVersion state.PostgresVersion, serverConfig config.ServerConfig) (functionStats state.PostgresFunctionStatsMap, err error) {
+	stmt, err := db.Prepare(QueryMarkerSQL + fmt.Sprintf(functionStatsSQL, schemaFilterSQL(serverConfig, "schemaname")))
 	if err != nil {
 		err = fmt.Errorf("FunctionStats/Prepare: %s", err)
 		return
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+const ioStatsSQL string = `
+SELECT backend_type, object, context,
+			 COALESCE(reads, 0), COALESCE(read_time, 0),
+			 COALESCE(writes, 0), COALESCE(write_time, 0),
+			 COALESCE(extends, 0), COALESCE(extend_time, 0),
+			 COALESCE(hits, 0), COALESCE(evictions, 0)
+	FROM pg_stat_io`
+
+// GetIOStats - Reads pg_stat_io, which only exists on Postgres 16 and newer
+// (older versions return no statistics)
+func GetIOStats(logger *util.Logger, db *sql.DB, postgresVersion state.PostgresVersion) (state.PostgresIOStatsMap, error) {
+	if postgresVersion.Numeric < state.PostgresVersion16 {
+		return nil, nil
+	}
+
+	stmt, err := db.Prepare(QueryMarkerSQL + ioStatsSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ioStats := make(state.PostgresIOStatsMap)
+	for rows.Next() {
+		var key state.PostgresIOStatsKey
+		var stats state.PostgresIOStats
+
+		err := rows.Scan(&key.BackendType, &key.Object, &key.Context,
+			&stats.Reads, &stats.ReadTime, &stats.Writes, &stats.WriteTime,
+			&stats.Extends, &stats.ExtendTime, &stats.Hits, &stats.Evictions)
+		if err != nil {
+			return nil, err
+		}
+		ioStats[key] = stats
+	}
+
+	return ioStats, rows.Err()
+}
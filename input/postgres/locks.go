@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pganalyze/collector/state"
+)
+
+// pg_blocking_pids was added in Postgres 9.6 - the array is returned as text,
+// since the database driver can't scan arrays
+const lockWaitsSQL string = `
+SELECT pid, locktype, mode, COALESCE(database, 0), COALESCE(relation, 0),
+			 array_to_string(pg_catalog.pg_blocking_pids(pid), ',')
+	FROM pg_catalog.pg_locks
+ WHERE NOT granted AND pid IS NOT NULL`
+
+const lockBackendsSQL string = `
+SELECT pid, state, query
+	FROM %s
+ WHERE pid = ANY(string_to_array($1, ',')::int[])`
+
+// GetLocks - Reads the locks that backends are waiting for, and builds the
+// blocking trees from them (including the queries of the involved backends)
+func GetLocks(db *sql.DB, postgresVersion state.PostgresVersion) (locks state.PostgresLocks, err error) {
+	if postgresVersion.Numeric < state.PostgresVersion96 {
+		return
+	}
+
+	locks.Waits, err = getLockWaits(db)
+	if err != nil || len(locks.Waits) == 0 {
+		return
+	}
+
+	involved := make(map[int32]bool)
+	var pids []string
+	for _, wait := range locks.Waits {
+		for _, pid := range append([]int32{wait.Pid}, wait.BlockingPids...) {
+			if !involved[pid] {
+				involved[pid] = true
+				pids = append(pids, strconv.Itoa(int(pid)))
+			}
+		}
+	}
+
+	backends, err := getLockBackends(db, strings.Join(pids, ","))
+	if err != nil {
+		return
+	}
+
+	locks.BlockingTrees = state.BuildBlockingTrees(locks.Waits, backends)
+	return
+}
+
+func getLockWaits(db *sql.DB) ([]state.PostgresLockWait, error) {
+	rows, err := db.Query(QueryMarkerSQL + lockWaitsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("Locks/Query: %s", err)
+	}
+	defer rows.Close()
+
+	var waits []state.PostgresLockWait
+	for rows.Next() {
+		var wait state.PostgresLockWait
+		var blockingPids string
+
+		err = rows.Scan(&wait.Pid, &wait.LockType, &wait.Mode, &wait.DatabaseOid, &wait.RelationOid, &blockingPids)
+		if err != nil {
+			return nil, fmt.Errorf("Locks/Scan: %s", err)
+		}
+		for _, pidStr := range strings.Split(blockingPids, ",") {
+			pid, err := strconv.ParseInt(pidStr, 10, 32)
+			if err == nil {
+				wait.BlockingPids = append(wait.BlockingPids, int32(pid))
+			}
+		}
+		waits = append(waits, wait)
+	}
+
+	return waits, rows.Err()
+}
+
+func getLockBackends(db *sql.DB, pids string) ([]state.PostgresLockBackend, error) {
+	var sourceTable string
+	if statsHelperExists(db, "get_stat_activity") {
+		sourceTable = "pganalyze.get_stat_activity()"
+	} else {
+		sourceTable = "pg_stat_activity"
+	}
+
+	rows, err := db.Query(QueryMarkerSQL+fmt.Sprintf(lockBackendsSQL, sourceTable), pids)
+	if err != nil {
+		return nil, fmt.Errorf("Locks/Backends/Query: %s", err)
+	}
+	defer rows.Close()
+
+	var backends []state.PostgresLockBackend
+	for rows.Next() {
+		var backend state.PostgresLockBackend
+
+		err = rows.Scan(&backend.Pid, &backend.State, &backend.Query)
+		if err != nil {
+			return nil, fmt.Errorf("Locks/Backends/Scan: %s", err)
+		}
+		backends = append(backends, backend)
+	}
+
+	return backends, rows.Err()
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pganalyze/collector/state"
+)
+
+// PermissionProblem - Something the monitoring role can't access, together with
+// the statement (or instruction) that fixes it
+type PermissionProblem struct {
+	Check       string
+	Message     string
+	Remediation string
+}
+
+type permissionCheck struct {
+	name        string
+	sql         string
+	statsHelper string // Helper function that is used instead, if it exists
+	minVersion  int    // Skipped on older versions
+}
+
+var permissionChecks = []permissionCheck{
+	{name: "pg_stat_statements", sql: "SELECT 1 FROM pg_stat_statements LIMIT 1", statsHelper: "get_stat_statements"},
+	{name: "pg_stat_activity", sql: "SELECT 1 FROM pg_stat_activity LIMIT 1", statsHelper: "get_stat_activity"},
+	{name: "pg_stat_replication", sql: "SELECT 1 FROM pg_stat_replication LIMIT 1", statsHelper: "get_stat_replication"},
+	{name: "relation statistics", sql: "SELECT 1 FROM pg_stat_user_tables s LEFT JOIN pg_statio_user_tables sio USING (relid) LIMIT 1"},
+	{name: "index statistics", sql: "SELECT 1 FROM pg_stat_user_indexes s LEFT JOIN pg_statio_user_indexes sio USING (indexrelid) LIMIT 1"},
+	{name: "pg_stat_progress_vacuum", sql: "SELECT 1 FROM pg_stat_progress_vacuum LIMIT 1", statsHelper: "get_stat_progress_vacuum", minVersion: state.PostgresVersion96},
+}
+
+// CheckPermissions - Verifies that the connected role can access the views and
+// functions the collector relies on, and returns what's missing
+//
+// Statistics of other roles' connections and queries are only visible to
+// superusers and members of pg_monitor (Postgres 10+), or through the pganalyze
+// helper functions on older versions.
+func CheckPermissions(db *sql.DB, postgresVersion state.PostgresVersion) (problems []PermissionProblem, err error) {
+	var role string
+	err = db.QueryRow(QueryMarkerSQL + "SELECT current_user").Scan(&role)
+	if err != nil {
+		err = fmt.Errorf("Permissions/CurrentUser: %s", err)
+		return
+	}
+
+	privileged := connectedAsSuperUser(db) ||
+		(postgresVersion.Numeric >= state.PostgresVersion10 && connectedAsMonitoringRole(db))
+
+	for _, check := range permissionChecks {
+		if postgresVersion.Numeric < check.minVersion {
+			continue
+		}
+		if check.statsHelper != "" && statsHelperExists(db, check.statsHelper) {
+			continue
+		}
+
+		checkErr := runPermissionCheck(db, check.sql)
+		if checkErr != nil {
+			problems = append(problems, PermissionProblem{
+				Check:       check.name,
+				Message:     checkErr.Error(),
+				Remediation: permissionRemediation(check, checkErr, role, postgresVersion),
+			})
+		} else if check.statsHelper != "" && !privileged {
+			problems = append(problems, PermissionProblem{
+				Check:       check.name,
+				Message:     "statistics of other roles are not visible to " + role,
+				Remediation: privilegeRemediation(check, role, postgresVersion),
+			})
+		}
+	}
+
+	return
+}
+
+func runPermissionCheck(db *sql.DB, query string) error {
+	rows, err := db.Query(QueryMarkerSQL + query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func permissionRemediation(check permissionCheck, err error, role string, postgresVersion state.PostgresVersion) string {
+	if check.name == "pg_stat_statements" && strings.Contains(err.Error(), "does not exist") {
+		return "CREATE EXTENSION IF NOT EXISTS pg_stat_statements; -- requires pg_stat_statements in shared_preload_libraries"
+	}
+	if strings.Contains(err.Error(), "permission denied") {
+		return privilegeRemediation(check, role, postgresVersion)
+	}
+	return ""
+}
+
+func privilegeRemediation(check permissionCheck, role string, postgresVersion state.PostgresVersion) string {
+	if postgresVersion.Numeric >= state.PostgresVersion10 {
+		return "GRANT pg_monitor TO " + pq.QuoteIdentifier(role) + ";"
+	}
+	if check.statsHelper != "" {
+		return "-- Create the pganalyze." + check.statsHelper + "() helper function as a superuser"
+	}
+	return "-- Connect as a superuser"
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+const createIndexProgressSQL string = `
+SELECT pid, datid, relid, index_relid, command, phase,
+			 COALESCE(lockers_total, 0), COALESCE(lockers_done, 0),
+			 COALESCE(blocks_total, 0), COALESCE(blocks_done, 0),
+			 COALESCE(tuples_total, 0), COALESCE(tuples_done, 0),
+			 COALESCE(partitions_total, 0), COALESCE(partitions_done, 0)
+	FROM pg_stat_progress_create_index`
+
+const baseBackupProgressSQL string = `
+SELECT pid, phase, backup_total, COALESCE(backup_streamed, 0),
+			 COALESCE(tablespaces_total, 0), COALESCE(tablespaces_streamed, 0)
+	FROM pg_stat_progress_basebackup`
+
+// GetProgress - Reads the progress of running vacuums, index builds and base
+// backups, skipping the views that don't exist in the Postgres version
+func GetProgress(logger *util.Logger, db *sql.DB, postgresVersion state.PostgresVersion) (progress state.PostgresProgress, err error) {
+	progress.Vacuums, err = GetVacuumProgress(logger, db, postgresVersion)
+	if err != nil {
+		return
+	}
+
+	if postgresVersion.Numeric >= state.PostgresVersion12 {
+		progress.CreateIndexes, err = getCreateIndexProgress(db)
+		if err != nil {
+			return
+		}
+	}
+
+	if postgresVersion.Numeric >= state.PostgresVersion13 {
+		progress.BaseBackups, err = getBaseBackupProgress(db)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func getCreateIndexProgress(db *sql.DB) ([]state.PostgresCreateIndexProgress, error) {
+	stmt, err := db.Prepare(QueryMarkerSQL + createIndexProgressSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var createIndexes []state.PostgresCreateIndexProgress
+	for rows.Next() {
+		var row state.PostgresCreateIndexProgress
+
+		err := rows.Scan(&row.Pid, &row.DatabaseOid, &row.RelationOid, &row.IndexOid, &row.Command, &row.Phase,
+			&row.LockersTotal, &row.LockersDone, &row.BlocksTotal, &row.BlocksDone,
+			&row.TuplesTotal, &row.TuplesDone, &row.PartitionsTotal, &row.PartitionsDone)
+		if err != nil {
+			return nil, err
+		}
+		createIndexes = append(createIndexes, row)
+	}
+
+	return createIndexes, rows.Err()
+}
+
+func getBaseBackupProgress(db *sql.DB) ([]state.PostgresBaseBackupProgress, error) {
+	stmt, err := db.Prepare(QueryMarkerSQL + baseBackupProgressSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var baseBackups []state.PostgresBaseBackupProgress
+	for rows.Next() {
+		var row state.PostgresBaseBackupProgress
+
+		err := rows.Scan(&row.Pid, &row.Phase, &row.BackupTotal, &row.BackupStreamed,
+			&row.TablespacesTotal, &row.TablespacesStreamed)
+		if err != nil {
+			return nil, err
+		}
+		baseBackups = append(baseBackups, row)
+	}
+
+	return baseBackups, rows.Err()
+}
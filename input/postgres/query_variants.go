@@ -0,0 +1,39 @@
+package postgres
+
+import "github.com/pganalyze/collector/state"
+
+// versionedSQL - A variant of a query (or a part of it) that works with the
+// given Postgres version and newer
+type versionedSQL struct {
+	minVersion int
+	sql        string
+}
+
+// sqlForVersion - Returns the variant with the newest minimum version that the
+// server satisfies, so we never reference catalog or statistics columns that
+// don't exist in the server version we're connected to
+//
+// Servers older than all variants get the oldest one, since we refuse to collect
+// from them anyway (see state.MinRequiredPostgresVersion).
+func sqlForVersion(postgresVersion state.PostgresVersion, variants []versionedSQL) string {
+	var selected *versionedSQL
+	var oldest *versionedSQL
+
+	for idx := range variants {
+		variant := &variants[idx]
+		if oldest == nil || variant.minVersion < oldest.minVersion {
+			oldest = variant
+		}
+		if postgresVersion.Numeric >= variant.minVersion && (selected == nil || variant.minVersion > selected.minVersion) {
+			selected = variant
+		}
+	}
+
+	if selected == nil {
+		selected = oldest
+	}
+	if selected == nil {
+		return ""
+	}
+	return selected.sql
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/pganalyze/collector/state"
+)
+
+var (
+	postgres92 = state.PostgresVersion{Short: "9.2.24", Numeric: 90224}
+	postgres94 = state.PostgresVersion{Short: "9.4.19", Numeric: 90419}
+	postgres96 = state.PostgresVersion{Short: "9.6.10", Numeric: 90610}
+	postgres10 = state.PostgresVersion{Short: "10.5", Numeric: 100005}
+	postgres11 = state.PostgresVersion{Short: "11.0", Numeric: 110000}
+	postgres12 = state.PostgresVersion{Short: "12.8", Numeric: 120008}
+	postgres14 = state.PostgresVersion{Short: "14.0", Numeric: 140000}
+)
+
+var sqlForVersionTests = []struct {
+	name     string
+	version  state.PostgresVersion
+	variants []versionedSQL
+	expected string
+}{
+	{"statement fields on 9.2", postgres92, statementSQLOptionalFields, statementSQLDefaultOptionalFields},
+	{"statement fields on 9.4", postgres94, statementSQLOptionalFields, statementSQLpg94OptionalFields},
+	{"statement fields on 9.6", postgres96, statementSQLOptionalFields, statementSQLpg95OptionalFields},
+	{"statement fields on 12", postgres12, statementSQLOptionalFields, statementSQLpg95OptionalFields},
+	{"statement fields on 14", postgres14, statementSQLOptionalFields, statementSQLpg95OptionalFields},
+	{"relation oids on 9.6", postgres96, relationsSQLHasOidsField, relationsSQLDefaultHasOidsField},
+	{"relation oids on 12", postgres12, relationsSQLHasOidsField, relationsSQLpg12HasOidsField},
+	{"relation fields on 9.2", postgres92, relationsSQLOptionalFields, relationsSQLDefaultOptionalFields},
+	{"relation fields on 9.6", postgres96, relationsSQLOptionalFields, relationsSQLpg93OptionalFields},
+	{"relation partitions on 9.6", postgres96, relationsSQLPartitionFields, relationsSQLDefaultPartitionFields},
+	{"relation partitions on 10", postgres10, relationsSQLPartitionFields, relationsSQLpg10PartitionFields},
+	{"relation stats on 9.2", postgres92, relationStatsSQLOptionalFields, relationStatsSQLDefaultOptionalFields},
+	{"relation stats on 14", postgres14, relationStatsSQLOptionalFields, relationStatsSQLpg94OptionalFields},
+	{"index stats on 14", postgres14, indexStatsSQLVariants, indexStatsSQL},
+	{"function kinds on 10", postgres10, functionsSQLKindFields, functionsSQLDefaultKindFields},
+	{"function kinds on 11", postgres11, functionsSQLKindFields, functionsSQLpg11KindFields},
+	{"function kinds on 14", postgres14, functionsSQLKindFields, functionsSQLpg11KindFields},
+	{"replication slots on 9.4", postgres94, replicationSlotsSQLVariants, replicationSlotsSQLPg94},
+	{"replication slots on 9.6", postgres96, replicationSlotsSQLVariants, replicationSlotsSQLPg96},
+	{"replication slots on 14", postgres14, replicationSlotsSQLVariants, replicationSlotsSQLPg10},
+	{"settings pending restart on 9.4", postgres94, settingsSQLPendingRestartField, settingsSQLDefaultPendingRestartField},
+	{"settings pending restart on 9.6", postgres96, settingsSQLPendingRestartField, settingsSQLpg95PendingRestartField},
+	// Order of the variants doesn't matter
+	{"unordered variants", postgres96, []versionedSQL{{0, "old"}, {state.PostgresVersion10, "new"}, {state.PostgresVersion94, "mid"}}, "mid"},
+	// Versions older than all variants fall back to the oldest one
+	{"unsupported version", state.PostgresVersion{Numeric: 80400}, []versionedSQL{{state.PostgresVersion10, "new"}, {state.PostgresVersion92, "old"}}, "old"},
+	{"no variants", postgres14, nil, ""},
+}
+
+func TestSQLForVersion(t *testing.T) {
+	for _, test := range sqlForVersionTests {
+		actual := sqlForVersion(test.version, test.variants)
+		if actual != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, actual)
+		}
+	}
+}
+
+var extensionVersionAtLeastTests = []struct {
+	extVersion string
+	expected   bool
+}{
+	{"1.4", false},
+	{"1.7", false},
+	{"1.8", true},
+	{"1.10", true},
+	{"2", true},
+	{"0.9", false},
+	{"", false},
+}
+
+func TestExtensionVersionAtLeast(t *testing.T) {
+	for _, test := range extensionVersionAtLeastTests {
+		actual := extensionVersionAtLeast(test.extVersion, 1, 8)
+		if actual != test.expected {
+			t.Errorf("%q: expected %t, got %t", test.extVersion, test.expected, actual)
+		}
+	}
+}
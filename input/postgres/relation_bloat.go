@@ -292,5 +292,16 @@ func GetBloatStats(logger *util.Logger, db *sql.DB) (report state.PostgresBloatS
 		return
 	}
 
+	databaseOid, err := CurrentDatabaseOid(db)
+	if err != nil {
+		return
+	}
+	for idx := range report.Relations {
+		report.Relations[idx].DatabaseOid = databaseOid
+	}
+	for idx := range report.Indices {
+		report.Indices[idx].DatabaseOid = databaseOid
+	}
+
 	return
 }
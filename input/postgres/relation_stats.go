@@ -3,16 +3,26 @@ package postgres
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
+	"github.com/pganalyze/collector/config"
 	"github.com/pganalyze/collector/state"
 )
 
 const relationStatsSQLDefaultOptionalFields = "NULL"
 const relationStatsSQLpg94OptionalFields = "s.n_mod_since_analyze"
 
+var relationStatsSQLOptionalFields = []versionedSQL{
+	{state.PostgresVersion94, relationStatsSQLpg94OptionalFields},
+	{0, relationStatsSQLDefaultOptionalFields},
+}
+
 const relationStatsSQL = `
 SELECT s.relid,
 			 COALESCE(pg_catalog.pg_table_size(s.relid), 0) AS size_bytes,
+			 COALESCE(pg_catalog.pg_relation_size(s.relid), 0) AS main_size_bytes,
+			 COALESCE(pg_catalog.pg_total_relation_size(NULLIF(c.reltoastrelid, 0)), 0) AS toast_size_bytes,
+			 COALESCE(pg_catalog.pg_indexes_size(s.relid), 0) AS index_size_bytes,
 			 COALESCE(s.seq_scan, 0),
 			 COALESCE(s.seq_tup_read, 0),
 			 COALESCE(s.idx_scan, 0),
@@ -41,7 +51,9 @@ SELECT s.relid,
 			 COALESCE(sio.tidx_blks_read, 0),
 			 COALESCE(sio.tidx_blks_hit, 0)
 	FROM pg_stat_user_tables s
-			 LEFT JOIN pg_statio_user_tables sio USING (relid);
+			 JOIN pg_catalog.pg_class c ON (c.oid = s.relid)
+			 LEFT JOIN pg_statio_user_tables sio USING (relid)
+ WHERE %s;
 `
 
 const indexStatsSQL = `
@@ -53,19 +65,19 @@ SELECT s.indexrelid,
 			 COALESCE(sio.idx_blks_read, 0),
 			 COALESCE(sio.idx_blks_hit, 0)
 	FROM pg_stat_user_indexes s
-			 LEFT JOIN pg_statio_user_indexes sio USING (indexrelid);
+			 LEFT JOIN pg_statio_user_indexes sio USING (indexrelid)
+ WHERE %s;
 `
 
-func GetRelationStats(db *sql.DB, postgresVersion state.PostgresVersion) (relStats state.PostgresRelationStatsMap, err error) {
-	var optionalFields string
+// Index statistics don't differ between supported versions so far
+var indexStatsSQLVariants = []versionedSQL{
+	{0, indexStatsSQL},
+}
 
-	if postgresVersion.Numeric >= state.PostgresVersion94 {
-		optionalFields = relationStatsSQLpg94OptionalFields
-	} else {
-		optionalFields = relationStatsSQLDefaultOptionalFields
-	}
+func GetRelationStats(db *sql.DB, postgresVersion state.PostgresVersion, serverConfig config.ServerConfig) (relStats state.PostgresRelationStatsMap, err error) {
+	optionalFields := sqlForVersion(postgresVersion, relationStatsSQLOptionalFields)
 
-	stmt, err := db.Prepare(QueryMarkerSQL + fmt.Sprintf(relationStatsSQL, optionalFields))
+	stmt, err := db.Prepare(QueryMarkerSQL + fmt.Sprintf(relationStatsSQL, optionalFields, schemaFilterSQL(serverConfig, "s.schemaname")))
 	if err != nil {
 		err = fmt.Errorf("RelationStats/Prepare: %s", err)
 		return
@@ -79,12 +91,14 @@ func GetRelationStats(db *sql.DB, postgresVersion state.PostgresVersion) (relSta
 	}
 	defer rows.Close()
 
+	now := time.Now()
 	relStats = make(state.PostgresRelationStatsMap)
 	for rows.Next() {
 		var oid state.Oid
 		var stats state.PostgresRelationStats
 
-		err = rows.Scan(&oid, &stats.SizeBytes, &stats.SeqScan, &stats.SeqTupRead,
+		err = rows.Scan(&oid, &stats.SizeBytes, &stats.MainSizeBytes,
+			&stats.ToastSizeBytes, &stats.IndexSizeBytes, &stats.SeqScan, &stats.SeqTupRead,
 			&stats.IdxScan, &stats.IdxTupFetch, &stats.NTupIns,
 			&stats.NTupUpd, &stats.NTupDel, &stats.NTupHotUpd,
 			&stats.NLiveTup, &stats.NDeadTup, &stats.NModSinceAnalyze,
@@ -99,14 +113,15 @@ func GetRelationStats(db *sql.DB, postgresVersion state.PostgresVersion) (relSta
 			return
 		}
 
+		stats.VacuumOverdue = stats.IsVacuumOverdue(now, serverConfig.VacuumOverdueAfter, serverConfig.VacuumOverdueDeadTupleRatio)
 		relStats[oid] = stats
 	}
 
 	return
 }
 
-func GetIndexStats(db *sql.DB, postgresVersion state.PostgresVersion) (indexStats state.PostgresIndexStatsMap, err error) {
-	stmt, err := db.Prepare(QueryMarkerSQL + indexStatsSQL)
+func GetIndexStats(db *sql.DB, postgresVersion state.PostgresVersion, serverConfig config.ServerConfig) (indexStats state.PostgresIndexStatsMap, err error) {
+	stmt, err := db.Prepare(QueryMarkerSQL + fmt.Sprintf(sqlForVersion(postgresVersion, indexStatsSQLVariants), schemaFilterSQL(serverConfig, "s.schemaname")))
 	if err != nil {
 		err = fmt.Errorf("IndexStats/Prepare: %s", err)
 		return
@@ -7,12 +7,35 @@ import (
 	"strings"
 
 	"github.com/guregu/null"
+	"github.com/pganalyze/collector/config"
 	"github.com/pganalyze/collector/state"
 )
 
 const relationsSQLDefaultOptionalFields = "0"
 const relationsSQLpg93OptionalFields = "c.relminmxid"
 
+var relationsSQLOptionalFields = []versionedSQL{
+	{state.PostgresVersion93, relationsSQLpg93OptionalFields},
+	{0, relationsSQLDefaultOptionalFields},
+}
+
+const relationsSQLDefaultPartitionFields = "0"
+const relationsSQLpg10PartitionFields = "COALESCE((SELECT inhparent FROM pg_catalog.pg_inherits WHERE inhrelid = c.oid AND c.relispartition), 0)"
+
+var relationsSQLPartitionFields = []versionedSQL{
+	{state.PostgresVersion10, relationsSQLpg10PartitionFields},
+	{0, relationsSQLDefaultPartitionFields},
+}
+
+// Postgres 12 removed support for WITH OIDS tables, together with relhasoids
+const relationsSQLDefaultHasOidsField = "c.relhasoids"
+const relationsSQLpg12HasOidsField = "false"
+
+var relationsSQLHasOidsField = []versionedSQL{
+	{state.PostgresVersion12, relationsSQLpg12HasOidsField},
+	{0, relationsSQLDefaultHasOidsField},
+}
+
 const relationsSQL string = `
 	 WITH locked_relids AS (SELECT DISTINCT relation relid FROM pg_locks WHERE mode = 'AccessExclusiveLock')
  SELECT c.oid,
@@ -20,20 +43,22 @@ const relationsSQL string = `
 				c.relname AS table_name,
 				c.relkind AS relation_type,
 				c.reloptions AS relation_options,
-				c.relhasoids AS relation_has_oids,
+				%s AS relation_has_oids,
 				c.relpersistence AS relation_persistence,
 				c.relhassubclass AS relation_has_inheritance_children,
 				c.reltoastrelid IS NULL AS relation_has_toast,
 				c.relfrozenxid AS relation_frozen_xid,
 				%s,
-				locked_relids.relid IS NOT NULL
+				locked_relids.relid IS NOT NULL,
+				%s AS partition_parent_oid
 	 FROM pg_catalog.pg_class c
 	 LEFT JOIN pg_catalog.pg_namespace n ON (n.oid = c.relnamespace)
 	 LEFT JOIN locked_relids ON (c.oid = locked_relids.relid)
-	WHERE c.relkind IN ('r','v','m')
+	WHERE c.relkind IN ('r','v','m','p')
 				AND c.relpersistence <> 't'
 				AND c.relname NOT IN ('pg_stat_statements')
-				AND n.nspname NOT IN ('pg_catalog','pg_toast','information_schema')`
+				AND n.nspname NOT IN ('pg_catalog','pg_toast','information_schema')
+				AND %s`
 
 const columnsSQL string = `
 	 WITH locked_relids AS (SELECT DISTINCT relation relid FROM pg_locks WHERE mode = 'AccessExclusiveLock')
@@ -50,13 +75,14 @@ const columnsSQL string = `
  FROM pg_catalog.pg_class c
  LEFT JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
  LEFT JOIN pg_catalog.pg_attribute a ON c.oid = a.attrelid
- WHERE c.relkind IN ('r','v','m')
+ WHERE c.relkind IN ('r','v','m','p')
 			 AND c.relpersistence <> 't'
 			 AND c.relname NOT IN ('pg_stat_statements')
 			 AND n.nspname NOT IN ('pg_catalog','pg_toast','information_schema')
 			 AND a.attnum > 0
 			 AND NOT a.attisdropped
 			 AND c.oid NOT IN (SELECT relid FROM locked_relids)
+			 AND %s
  ORDER BY a.attnum`
 
 const indicesSQL string = `
@@ -83,7 +109,8 @@ SELECT c.oid,
 			 AND c.relpersistence <> 't'
 			 AND n.nspname NOT IN ('pg_catalog','pg_toast','information_schema')
 			 AND c.oid NOT IN (SELECT relid FROM locked_relids)
-			 AND c2.oid NOT IN (SELECT relid FROM locked_relids)`
+			 AND c2.oid NOT IN (SELECT relid FROM locked_relids)
+			 AND %s`
 
 const constraintsSQL string = `
 	WITH locked_relids AS (SELECT DISTINCT relation relid FROM pg_locks WHERE mode = 'AccessExclusiveLock')
@@ -101,7 +128,8 @@ SELECT c.oid,
 			 JOIN pg_catalog.pg_class c ON r.conrelid = c.oid
 			 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
 WHERE n.nspname NOT IN ('pg_catalog','pg_toast','information_schema')
-			AND c.oid NOT IN (SELECT relid FROM locked_relids)`
+			AND c.oid NOT IN (SELECT relid FROM locked_relids)
+			AND %s`
 
 const viewDefinitionSQL string = `
 	WITH locked_relids AS (SELECT DISTINCT relation relid FROM pg_locks WHERE mode = 'AccessExclusiveLock')
@@ -113,21 +141,20 @@ SELECT c.oid,
 			 AND c.relpersistence <> 't'
 			 AND c.relname NOT IN ('pg_stat_statements')
 			 AND n.nspname NOT IN ('pg_catalog','pg_toast','information_schema')
-			 AND c.oid NOT IN (SELECT relid FROM locked_relids)`
+			 AND c.oid NOT IN (SELECT relid FROM locked_relids)
+			 AND %s`
 
-func GetRelations(db *sql.DB, postgresVersion state.PostgresVersion, currentDatabaseOid state.Oid) ([]state.PostgresRelation, error) {
+func GetRelations(db *sql.DB, postgresVersion state.PostgresVersion, currentDatabaseOid state.Oid, serverConfig config.ServerConfig) ([]state.PostgresRelation, error) {
 	relations := make(map[state.Oid]state.PostgresRelation, 0)
 
 	// Relations
-	var optionalFields string
+	hasOidsField := sqlForVersion(postgresVersion, relationsSQLHasOidsField)
+	optionalFields := sqlForVersion(postgresVersion, relationsSQLOptionalFields)
+	partitionFields := sqlForVersion(postgresVersion, relationsSQLPartitionFields)
 
-	if postgresVersion.Numeric >= state.PostgresVersion93 {
-		optionalFields = relationsSQLpg93OptionalFields
-	} else {
-		optionalFields = relationsSQLDefaultOptionalFields
-	}
+	schemaFilter := schemaFilterSQL(serverConfig, "n.nspname")
 
-	rows, err := db.Query(QueryMarkerSQL + fmt.Sprintf(relationsSQL, optionalFields))
+	rows, err := db.Query(QueryMarkerSQL + fmt.Sprintf(relationsSQL, hasOidsField, optionalFields, partitionFields, schemaFilter))
 	if err != nil {
 		err = fmt.Errorf("Relations/Query: %s", err)
 		return nil, err
@@ -141,7 +168,8 @@ func GetRelations(db *sql.DB, postgresVersion state.PostgresVersion, currentData
 
 		err = rows.Scan(&row.Oid, &row.SchemaName, &row.RelationName, &row.RelationType,
 			&options, &row.HasOids, &row.PersistenceType, &row.HasInheritanceChildren,
-			&row.HasToast, &row.FrozenXID, &row.MinimumMultixactXID, &row.ExclusivelyLocked)
+			&row.HasToast, &row.FrozenXID, &row.MinimumMultixactXID, &row.ExclusivelyLocked,
+			&row.ParentRelationOid)
 		if err != nil {
 			err = fmt.Errorf("Relations/Scan: %s", err)
 			return nil, err
@@ -161,7 +189,7 @@ func GetRelations(db *sql.DB, postgresVersion state.PostgresVersion, currentData
 	}
 
 	// Columns
-	rows, err = db.Query(QueryMarkerSQL + columnsSQL)
+	rows, err = db.Query(QueryMarkerSQL + fmt.Sprintf(columnsSQL, schemaFilter))
 	if err != nil {
 		err = fmt.Errorf("Columns/Query: %s", err)
 		return nil, err
@@ -185,7 +213,7 @@ func GetRelations(db *sql.DB, postgresVersion state.PostgresVersion, currentData
 	}
 
 	// Indices
-	rows, err = db.Query(QueryMarkerSQL + indicesSQL)
+	rows, err = db.Query(QueryMarkerSQL + fmt.Sprintf(indicesSQL, schemaFilter))
 	if err != nil {
 		err = fmt.Errorf("Indices/Query: %s", err)
 		return nil, err
@@ -224,7 +252,7 @@ func GetRelations(db *sql.DB, postgresVersion state.PostgresVersion, currentData
 	}
 
 	// Constraints
-	rows, err = db.Query(QueryMarkerSQL + constraintsSQL)
+	rows, err = db.Query(QueryMarkerSQL + fmt.Sprintf(constraintsSQL, schemaFilter))
 	if err != nil {
 		err = fmt.Errorf("Constraints/Query: %s", err)
 		return nil, err
@@ -273,7 +301,7 @@ func GetRelations(db *sql.DB, postgresVersion state.PostgresVersion, currentData
 	}
 
 	// View definitions
-	rows, err = db.Query(QueryMarkerSQL + viewDefinitionSQL)
+	rows, err = db.Query(QueryMarkerSQL + fmt.Sprintf(viewDefinitionSQL, schemaFilter))
 	if err != nil {
 		err = fmt.Errorf("Views/Prepare: %s", err)
 		return nil, err
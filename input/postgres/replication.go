@@ -74,6 +74,48 @@ SELECT client_addr,
 	FROM %s
  WHERE client_addr IS NOT NULL`
 
+const replicationSlotsSQLPg10 string = `
+SELECT slot_name,
+			 plugin,
+			 database,
+			 active,
+			 restart_lsn,
+			 confirmed_flush_lsn,
+			 pg_wal_lsn_diff(pg_current_wal_lsn(), restart_lsn) AS restart_byte_lag,
+			 pg_wal_lsn_diff(pg_current_wal_lsn(), confirmed_flush_lsn) AS confirmed_flush_byte_lag
+	FROM pg_replication_slots
+ WHERE slot_type = 'logical'`
+
+const replicationSlotsSQLPg96 string = `
+SELECT slot_name,
+			 plugin,
+			 database,
+			 active,
+			 restart_lsn,
+			 confirmed_flush_lsn,
+			 pg_xlog_location_diff(pg_current_xlog_location(), restart_lsn) AS restart_byte_lag,
+			 pg_xlog_location_diff(pg_current_xlog_location(), confirmed_flush_lsn) AS confirmed_flush_byte_lag
+	FROM pg_replication_slots
+ WHERE slot_type = 'logical'`
+
+const replicationSlotsSQLPg94 string = `
+SELECT slot_name,
+			 plugin,
+			 database,
+			 active,
+			 restart_lsn,
+			 NULL,
+			 pg_xlog_location_diff(pg_current_xlog_location(), restart_lsn) AS restart_byte_lag,
+			 NULL
+	FROM pg_replication_slots
+ WHERE slot_type = 'logical'`
+
+var replicationSlotsSQLVariants = []versionedSQL{
+	{state.PostgresVersion10, replicationSlotsSQLPg10},
+	{state.PostgresVersion96, replicationSlotsSQLPg96},
+	{state.PostgresVersion94, replicationSlotsSQLPg94},
+}
+
 func GetReplication(logger *util.Logger, db *sql.DB, isHeroku bool, postgresVersion state.PostgresVersion) (state.PostgresReplication, error) {
 	var err error
 	var repl state.PostgresReplication
@@ -135,5 +177,35 @@ func GetReplication(logger *util.Logger, db *sql.DB, isHeroku bool, postgresVers
 		repl.Standbys = append(repl.Standbys, s)
 	}
 
+	// Logical slots only exist on the primary
+	if postgresVersion.Numeric >= state.PostgresVersion94 && !repl.InRecovery {
+		repl.LogicalSlots, err = getLogicalReplicationSlots(db, postgresVersion)
+		if err != nil {
+			return repl, err
+		}
+	}
+
 	return repl, nil
 }
+
+func getLogicalReplicationSlots(db *sql.DB, postgresVersion state.PostgresVersion) (slots []state.PostgresReplicationSlot, err error) {
+	rows, err := db.Query(QueryMarkerSQL + sqlForVersion(postgresVersion, replicationSlotsSQLVariants))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s state.PostgresReplicationSlot
+
+		err = rows.Scan(&s.SlotName, &s.Plugin, &s.DatabaseName, &s.Active, &s.RestartLsn,
+			&s.ConfirmedFlushLsn, &s.RestartByteLag, &s.ConfirmedFlushByteLag)
+		if err != nil {
+			return nil, err
+		}
+
+		slots = append(slots, s)
+	}
+
+	return slots, rows.Err()
+}
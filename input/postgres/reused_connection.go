@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// How long checking a reused connection may take, before it is considered broken
+const reusedConnectionPingTimeout = 5 * time.Second
+
+// Reused connections are shared between runs happening at the same time (e.g.
+// wait event sampling during a full snapshot), so they may open a few
+// underlying connections, instead of making those runs wait for each other
+const reusedConnectionMaxOpen = 3
+
+// reusedConnection - Connection that is kept open between runs (see db_reuse_connection)
+type reusedConnection struct {
+	db       *sql.DB
+	settings string // Connection settings it was established with, to reconnect when they change
+	openedAt time.Time
+	users    int // Runs currently using the connection, which is never checked or replaced while in use
+}
+
+// Connections that are kept open between runs, by config section
+var reusedConnections = make(map[string]*reusedConnection)
+var reusedConnectionsMutex sync.Mutex
+
+// Indirections for establishing and checking connections, replaced in tests
+var establishReusedConnection = EstablishConnection
+var pingReusedConnection = func(ctx context.Context, db *sql.DB) error { return db.PingContext(ctx) }
+
+// AcquireConnection - Returns a connection to the server's primary database,
+// together with a function that needs to be called once it's not used anymore
+// (instead of closing the connection)
+//
+// With db_reuse_connection the connection is kept open between runs. It gets
+// checked before being handed out, and is replaced with a new connection (which
+// has the statement timeout and other session settings applied again) if the
+// check fails, the connection settings changed, or it reached its maximum lifetime.
+func AcquireConnection(server state.Server, logger *util.Logger, globalCollectionOpts state.CollectionOpts) (*sql.DB, func(), error) {
+	if !server.Config.DbReuseConnection {
+		connection, err := EstablishConnection(server, logger, globalCollectionOpts, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		return connection, func() { connection.Close() }, nil
+	}
+
+	reusedConnectionsMutex.Lock()
+	defer reusedConnectionsMutex.Unlock()
+
+	settings := fmt.Sprintf("%s application_name=%s statement_timeout=%d", server.Config.GetPqOpenString(""),
+		globalCollectionOpts.CollectorApplicationName, server.Grant.Config.Features.StatementTimeoutMs)
+
+	reused, exists := reusedConnections[server.Config.SectionName]
+	if exists && reused.users > 0 {
+		reused.users++
+		return reused.db, reused.release, nil
+	}
+	if exists {
+		var reason string
+		if reused.settings != settings {
+			reason = "connection settings changed"
+		} else if time.Since(reused.openedAt) >= server.Config.DbReuseConnectionMaxLifetime {
+			reason = "connection reached its maximum lifetime"
+		} else {
+			ctx, cancel := context.WithTimeout(context.Background(), reusedConnectionPingTimeout)
+			err := pingReusedConnection(ctx, reused.db)
+			cancel()
+			if err == nil {
+				reused.users++
+				return reused.db, reused.release, nil
+			}
+			reason = fmt.Sprintf("connection check failed: %s", err)
+		}
+
+		logger.PrintVerbose("Reconnecting to the database, since the %s", reason)
+		reused.db.Close()
+		delete(reusedConnections, server.Config.SectionName)
+	}
+
+	connection, err := establishReusedConnection(server, logger, globalCollectionOpts, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The connection gets replaced as a whole after its maximum lifetime, so
+	// database/sql should keep the underlying connections open until then
+	connection.SetMaxOpenConns(reusedConnectionMaxOpen)
+	connection.SetMaxIdleConns(reusedConnectionMaxOpen)
+	connection.SetConnMaxLifetime(0)
+
+	reused = &reusedConnection{db: connection, settings: settings, openedAt: time.Now(), users: 1}
+	reusedConnections[server.Config.SectionName] = reused
+	return connection, reused.release, nil
+}
+
+func (reused *reusedConnection) release() {
+	reusedConnectionsMutex.Lock()
+	defer reusedConnectionsMutex.Unlock()
+
+	reused.users--
+}
+
+// CloseReusedConnections - Closes all connections that are kept open between
+// runs, e.g. when shutting down
+func CloseReusedConnections() {
+	reusedConnectionsMutex.Lock()
+	defer reusedConnectionsMutex.Unlock()
+
+	for sectionName, reused := range reusedConnections {
+		reused.db.Close()
+		delete(reusedConnections, sectionName)
+	}
+}
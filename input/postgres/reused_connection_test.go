@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io/ioutil"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// fakeReusedConnections - Establishes connections that record their statements,
+// and lets tests decide whether checking a connection succeeds
+type fakeReusedConnections struct {
+	statements  []string
+	established int
+	pingErr     error
+}
+
+func (f *fakeReusedConnections) establish(server state.Server, logger *util.Logger, globalCollectionOpts state.CollectionOpts, databaseName string) (*sql.DB, error) {
+	f.established++
+	connector := newStatementTimeoutConnector(func() (string, error) { return "", nil }, 30000)
+	connector.open = func(dsn string) (driver.Conn, error) {
+		return fakeConn{statements: &f.statements}, nil
+	}
+	return sql.OpenDB(connector), nil
+}
+
+func (f *fakeReusedConnections) ping(ctx context.Context, db *sql.DB) error {
+	return f.pingErr
+}
+
+func setupFakeReusedConnections(t *testing.T) *fakeReusedConnections {
+	fake := &fakeReusedConnections{}
+	prevEstablish, prevPing := establishReusedConnection, pingReusedConnection
+	establishReusedConnection, pingReusedConnection = fake.establish, fake.ping
+	t.Cleanup(func() {
+		CloseReusedConnections()
+		establishReusedConnection, pingReusedConnection = prevEstablish, prevPing
+	})
+	return fake
+}
+
+func reusedConnectionTestServer() state.Server {
+	return state.Server{Config: config.ServerConfig{SectionName: "reuse-test", DbHost: "localhost", DbReuseConnection: true, DbReuseConnectionMaxLifetime: time.Hour}}
+}
+
+func TestAcquireConnectionReuse(t *testing.T) {
+	fake := setupFakeReusedConnections(t)
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	server := reusedConnectionTestServer()
+
+	first, release, err := AcquireConnection(server, logger, state.CollectionOpts{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	first.Exec("SELECT 1")
+	release()
+
+	second, release, err := AcquireConnection(server, logger, state.CollectionOpts{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	second.Exec("SELECT 2")
+	release()
+
+	if first != second || fake.established != 1 {
+		t.Errorf("Expected the connection to be reused, got %d connections established", fake.established)
+	}
+	expected := []string{"SET statement_timeout = 30000", "SELECT 1", "SELECT 2"}
+	if diff := pretty.Compare(expected, fake.statements); diff != "" {
+		t.Errorf("Unexpected statements: (-want +got)\n%s", diff)
+	}
+}
+
+func TestAcquireConnectionReconnectAfterFailure(t *testing.T) {
+	fake := setupFakeReusedConnections(t)
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	server := reusedConnectionTestServer()
+
+	first, release, err := AcquireConnection(server, logger, state.CollectionOpts{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	first.Exec("SELECT 1")
+	release()
+
+	fake.pingErr = errors.New("connection reset by peer")
+	second, release, err := AcquireConnection(server, logger, state.CollectionOpts{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	second.Exec("SELECT 2")
+	release()
+
+	if first == second || fake.established != 2 {
+		t.Errorf("Expected a new connection after the check failed, got %d connections established", fake.established)
+	}
+	if _, err := first.Exec("SELECT 3"); err == nil {
+		t.Errorf("Expected the failed connection to be closed")
+	}
+	// The statement timeout is set again on the new connection
+	expected := []string{"SET statement_timeout = 30000", "SELECT 1", "SET statement_timeout = 30000", "SELECT 2"}
+	if diff := pretty.Compare(expected, fake.statements); diff != "" {
+		t.Errorf("Unexpected statements: (-want +got)\n%s", diff)
+	}
+}
+
+func TestAcquireConnectionInUse(t *testing.T) {
+	fake := setupFakeReusedConnections(t)
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	server := reusedConnectionTestServer()
+
+	first, releaseFirst, err := AcquireConnection(server, logger, state.CollectionOpts{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// A connection that is in use is shared without being checked (or replaced)
+	fake.pingErr = errors.New("timeout")
+	second, releaseSecond, err := AcquireConnection(server, logger, state.CollectionOpts{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if first != second || fake.established != 1 {
+		t.Errorf("Expected the connection in use to be shared, got %d connections established", fake.established)
+	}
+	releaseFirst()
+	releaseSecond()
+}
+
+func TestAcquireConnectionMaxLifetime(t *testing.T) {
+	fake := setupFakeReusedConnections(t)
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	server := reusedConnectionTestServer()
+	server.Config.DbReuseConnectionMaxLifetime = time.Nanosecond
+
+	for i := 0; i < 2; i++ {
+		_, release, err := AcquireConnection(server, logger, state.CollectionOpts{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		release()
+		time.Sleep(time.Millisecond)
+	}
+	if fake.established != 2 {
+		t.Errorf("Expected a new connection after the maximum lifetime, got %d connections established", fake.established)
+	}
+}
@@ -2,23 +2,42 @@ package postgres
 
 import (
 	"database/sql"
+	"sync"
 
+	"github.com/pganalyze/collector/config"
 	"github.com/pganalyze/collector/state"
 	"github.com/pganalyze/collector/util"
 )
 
-func CollectAllSchemas(server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, ps state.PersistedState, ts state.TransientState) (state.PersistedState, state.TransientState) {
-	schemaDbNames := []string{}
+// CollectAllSchemas - Collects the local catalog of each database, running up
+// to schemaCollectionWorkers databases at the same time
+//
+// Results are combined in the order of the databases, independent of which
+// finished first, so that the resulting state is deterministic. When the
+// monitoring connection already uses up all allowed connections, only the
+// monitored database gets collected, over the monitoring connection.
+func CollectAllSchemas(server state.Server, connection *sql.DB, collectionOpts state.CollectionOpts, logger *util.Logger, ps state.PersistedState, ts state.TransientState) (state.PersistedState, state.TransientState) {
+	schemaDbNames := getSchemaDbNames(server.Config, ts.Databases)
 
-	if server.Config.DbAllNames {
-		for _, database := range ts.Databases {
-			if !database.IsTemplate && database.AllowConnections {
-				schemaDbNames = append(schemaDbNames, database.Name)
+	results := make([]databaseSchemaResult, len(schemaDbNames))
+	workers := schemaCollectionWorkers(server.Config, len(schemaDbNames))
+	if workers == 0 {
+		for idx, dbName := range schemaDbNames {
+			if dbName != server.Config.DbName {
+				logger.PrintWarning("Skipping schema collection for database %s, since max_collector_connections doesn't allow connections besides the monitoring connection", dbName)
+				continue
 			}
+			results[idx] = collectDatabaseSchemaOnConnection(server, collectionOpts, logger, connection, dbName, ts.Version)
 		}
 	} else {
-		schemaDbNames = append(schemaDbNames, server.Config.DbName)
-		schemaDbNames = append(schemaDbNames, server.Config.DbExtraNames...)
+		forEachDatabase(schemaDbNames, workers, func(idx int, dbName string) {
+			// Each database gets its own copy of the logger, since remembering errors
+			// is not safe for concurrent use
+			dbLogger := *logger
+			dbLogger.ErrorMessages = nil
+			results[idx] = collectDatabaseSchema(server, collectionOpts, &dbLogger, dbName, ts.Version)
+			results[idx].errorMessages = dbLogger.ErrorMessages
+		})
 	}
 
 	ps.Relations = []state.PostgresRelation{}
@@ -26,39 +45,139 @@ func CollectAllSchemas(server state.Server, collectionOpts state.CollectionOpts,
 	ps.IndexStats = make(state.PostgresIndexStatsMap)
 	ps.Functions = []state.PostgresFunction{}
 
-	for _, dbName := range schemaDbNames {
-		schemaConnection, err := EstablishConnection(server, logger, collectionOpts, dbName)
-		if err != nil {
-			logger.PrintVerbose("Failed to connect to database %s to retrieve schema: %s", dbName, err)
+	for _, result := range results {
+		logger.ErrorMessages = append(logger.ErrorMessages, result.errorMessages...)
+		if !result.collected {
 			continue
 		}
 
-		databaseOid, err := CurrentDatabaseOid(schemaConnection)
-		if err != nil {
-			logger.PrintError("Error getting OID of database %s")
-			schemaConnection.Close()
-			continue
+		ps.Relations = append(ps.Relations, result.ps.Relations...)
+		for k, v := range result.ps.RelationStats {
+			ps.RelationStats[k] = v
 		}
+		for k, v := range result.ps.IndexStats {
+			ps.IndexStats[k] = v
+		}
+		ps.Functions = append(ps.Functions, result.ps.Functions...)
+		ts.DatabaseOidsWithLocalCatalog = append(ts.DatabaseOidsWithLocalCatalog, result.databaseOid)
+	}
 
-		ps = collectSchemaData(collectionOpts, logger, schemaConnection, ps, databaseOid, ts.Version)
-		ts.DatabaseOidsWithLocalCatalog = append(ts.DatabaseOidsWithLocalCatalog, databaseOid)
+	return ps, ts
+}
 
-		schemaConnection.Close()
+type databaseSchemaResult struct {
+	collected     bool
+	databaseOid   state.Oid
+	ps            state.PersistedState
+	errorMessages []string
+}
+
+// schemaCollectionWorkers - Returns how many databases get collected at the same
+// time, staying below max_collector_connections together with the connection
+// that is held open for the server-wide statistics (zero if that connection is
+// the only one allowed)
+func schemaCollectionWorkers(serverConfig config.ServerConfig, databaseCount int) int {
+	workers := serverConfig.SchemaCollectionWorkers
+	if workers > databaseCount {
+		workers = databaseCount
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	// The monitoring connection stays open while schemas get collected
+	if serverConfig.MaxCollectorConnections > 0 && workers > serverConfig.MaxCollectorConnections-1 {
+		workers = serverConfig.MaxCollectorConnections - 1
+	}
+	if workers < 0 {
+		workers = 0
 	}
+	return workers
+}
 
-	return ps, ts
+// forEachDatabase - Calls fn for each database on a pool of workers, and waits
+// for all of them to finish
+func forEachDatabase(dbNames []string, workers int, fn func(idx int, dbName string)) {
+	var wg sync.WaitGroup
+	idxs := make(chan int)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range idxs {
+				fn(idx, dbNames[idx])
+			}
+		}()
+	}
+	for idx := range dbNames {
+		idxs <- idx
+	}
+	close(idxs)
+	wg.Wait()
+}
+
+// collectDatabaseSchema - Connects to a single database and collects its local catalog, replaced in tests
+var collectDatabaseSchema = func(server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, dbName string, postgresVersion state.PostgresVersion) (result databaseSchemaResult) {
+	schemaConnection, err := EstablishConnection(server, logger, collectionOpts, dbName)
+	if err != nil {
+		logger.PrintVerbose("Failed to connect to database %s to retrieve schema: %s", dbName, err)
+		return
+	}
+	defer schemaConnection.Close()
+
+	return collectDatabaseSchemaOnConnection(server, collectionOpts, logger, schemaConnection, dbName, postgresVersion)
+}
+
+var collectDatabaseSchemaOnConnection = func(server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, schemaConnection *sql.DB, dbName string, postgresVersion state.PostgresVersion) (result databaseSchemaResult) {
+	var err error
+	result.databaseOid, err = CurrentDatabaseOid(schemaConnection)
+	if err != nil {
+		logger.PrintError("Error getting OID of database %s", dbName)
+		return
+	}
+
+	ps := state.PersistedState{
+		RelationStats: make(state.PostgresRelationStatsMap),
+		IndexStats:    make(state.PostgresIndexStatsMap),
+	}
+	result.ps = collectSchemaData(server.Config, collectionOpts, logger, schemaConnection, ps, result.databaseOid, postgresVersion)
+	result.collected = true
+	return
+}
+
+// getSchemaDbNames - Returns the databases we connect to for fetching their local
+// catalog (e.g. tables and functions), skipping those excluded in the config
+func getSchemaDbNames(serverConfig config.ServerConfig, databases []state.PostgresDatabase) []string {
+	var candidateDbNames []string
+	if serverConfig.DbAllNames {
+		for _, database := range databases {
+			if !database.IsTemplate && database.AllowConnections {
+				candidateDbNames = append(candidateDbNames, database.Name)
+			}
+		}
+	} else {
+		candidateDbNames = append(candidateDbNames, serverConfig.DbName)
+		candidateDbNames = append(candidateDbNames, serverConfig.DbExtraNames...)
+	}
+
+	schemaDbNames := []string{}
+	for _, dbName := range candidateDbNames {
+		if serverConfig.IsDatabaseCollected(dbName) {
+			schemaDbNames = append(schemaDbNames, dbName)
+		}
+	}
+	return schemaDbNames
 }
 
-func collectSchemaData(collectionOpts state.CollectionOpts, logger *util.Logger, db *sql.DB, ps state.PersistedState, databaseOid state.Oid, postgresVersion state.PostgresVersion) state.PersistedState {
+func collectSchemaData(serverConfig config.ServerConfig, collectionOpts state.CollectionOpts, logger *util.Logger, db *sql.DB, ps state.PersistedState, databaseOid state.Oid, postgresVersion state.PostgresVersion) state.PersistedState {
 	if collectionOpts.CollectPostgresRelations {
-		newRelations, err := GetRelations(db, postgresVersion, databaseOid)
+		newRelations, err := GetRelations(db, postgresVersion, databaseOid, serverConfig)
 		if err != nil {
 			logger.PrintError("Error collecting relation/index information: %s", err)
 			return ps
 		}
 		ps.Relations = append(ps.Relations, newRelations...)
 
-		newRelationStats, err := GetRelationStats(db, postgresVersion)
+		newRelationStats, err := GetRelationStats(db, postgresVersion, serverConfig)
 		if err != nil {
 			logger.PrintError("Error collecting relation stats: %s", err)
 			return ps
@@ -67,7 +186,7 @@ func collectSchemaData(collectionOpts state.CollectionOpts, logger *util.Logger,
 			ps.RelationStats[k] = v
 		}
 
-		newIndexStats, err := GetIndexStats(db, postgresVersion)
+		newIndexStats, err := GetIndexStats(db, postgresVersion, serverConfig)
 		if err != nil {
 			logger.PrintError("Error collecting index stats: %s", err)
 			return ps
@@ -78,7 +197,7 @@ func collectSchemaData(collectionOpts state.CollectionOpts, logger *util.Logger,
 	}
 
 	if collectionOpts.CollectPostgresFunctions {
-		newFunctions, err := GetFunctions(db, postgresVersion, databaseOid)
+		newFunctions, err := GetFunctions(db, postgresVersion, databaseOid, serverConfig)
 		if err != nil {
 			logger.PrintError("Error collecting stored procedures")
 			return ps
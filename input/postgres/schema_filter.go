@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/pganalyze/collector/config"
+)
+
+// schemaFilterSQL - Returns a condition for the WHERE clause of catalog queries
+// that skips schemas excluded by the schema allow/deny lists, with column
+// being the expression that holds the schema name
+func schemaFilterSQL(serverConfig config.ServerConfig, column string) string {
+	var conditions []string
+
+	if len(serverConfig.SchemaDenyList) > 0 {
+		conditions = append(conditions, column+" NOT IN ("+quoteLiteralList(serverConfig.SchemaDenyList)+")")
+	}
+	if len(serverConfig.SchemaAllowList) > 0 {
+		conditions = append(conditions, column+" IN ("+quoteLiteralList(serverConfig.SchemaAllowList)+")")
+	}
+	if len(conditions) == 0 {
+		return "true"
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+func quoteLiteralList(values []string) string {
+	var quoted []string
+	for _, value := range values {
+		quoted = append(quoted, quoteLiteral(value))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// quoteLiteral - Quotes a string for use as a literal in a query, which works
+// regardless of the standard_conforming_strings setting
+func quoteLiteral(value string) string {
+	value = strings.Replace(value, "'", "''", -1)
+	if strings.Contains(value, `\`) {
+		return `E'` + strings.Replace(value, `\`, `\\`, -1) + `'`
+	}
+	return "'" + value + "'"
+}
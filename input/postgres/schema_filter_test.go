@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/pganalyze/collector/config"
+)
+
+var schemaFilterSQLTests = []struct {
+	description string
+	config      config.ServerConfig
+	expected    string
+}{
+	{
+		"no lists",
+		config.ServerConfig{},
+		"true",
+	},
+	{
+		"deny list",
+		config.ServerConfig{SchemaDenyList: []string{"audit", "partman"}},
+		"n.nspname NOT IN ('audit', 'partman')",
+	},
+	{
+		"allow and deny list",
+		config.ServerConfig{SchemaAllowList: []string{"public"}, SchemaDenyList: []string{"audit"}},
+		"n.nspname NOT IN ('audit') AND n.nspname IN ('public')",
+	},
+	{
+		"names that need quoting",
+		config.ServerConfig{SchemaDenyList: []string{"o'brien", `back\slash`}},
+		`n.nspname NOT IN ('o''brien', E'back\\slash')`,
+	},
+}
+
+func TestSchemaFilterSQL(t *testing.T) {
+	for _, test := range schemaFilterSQLTests {
+		actual := schemaFilterSQL(test.config, "n.nspname")
+		if actual != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.description, test.expected, actual)
+		}
+	}
+}
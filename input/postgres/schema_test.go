@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+var schemaTestDatabases = []state.PostgresDatabase{
+	{Name: "template1", IsTemplate: true, AllowConnections: true},
+	{Name: "postgres", AllowConnections: true},
+	{Name: "app", AllowConnections: true},
+	{Name: "noisy", AllowConnections: true},
+}
+
+var schemaDbNamesTests = []struct {
+	description string
+	config      config.ServerConfig
+	expected    []string
+}{
+	{
+		"all databases",
+		config.ServerConfig{DbAllNames: true},
+		[]string{"postgres", "app", "noisy"},
+	},
+	{
+		"all databases with deny list",
+		config.ServerConfig{DbAllNames: true, DatabaseDenyList: []string{"noisy"}},
+		[]string{"postgres", "app"},
+	},
+	{
+		"all databases with allow list",
+		config.ServerConfig{DbAllNames: true, DatabaseAllowList: []string{"app", "template1"}},
+		[]string{"app"},
+	},
+	{
+		"deny list takes precedence",
+		config.ServerConfig{DbAllNames: true, DatabaseAllowList: []string{"app", "noisy"}, DatabaseDenyList: []string{"noisy"}},
+		[]string{"app"},
+	},
+	{
+		"explicit databases with deny list",
+		config.ServerConfig{DbName: "app", DbExtraNames: []string{"noisy"}, DatabaseDenyList: []string{"noisy"}},
+		[]string{"app"},
+	},
+}
+
+func TestGetSchemaDbNames(t *testing.T) {
+	for _, test := range schemaDbNamesTests {
+		actual := getSchemaDbNames(test.config, schemaTestDatabases)
+		if diff := pretty.Compare(test.expected, actual); diff != "" {
+			t.Errorf("%s: (-want +got)\n%s", test.description, diff)
+		}
+	}
+}
+
+var schemaCollectionWorkersTests = []struct {
+	config        config.ServerConfig
+	databaseCount int
+	expected      int
+}{
+	{config.ServerConfig{SchemaCollectionWorkers: 4, MaxCollectorConnections: 10}, 20, 4},
+	{config.ServerConfig{SchemaCollectionWorkers: 4, MaxCollectorConnections: 10}, 2, 2},
+	{config.ServerConfig{SchemaCollectionWorkers: 8, MaxCollectorConnections: 5}, 20, 4},
+	{config.ServerConfig{SchemaCollectionWorkers: 4, MaxCollectorConnections: 2}, 20, 1},
+	{config.ServerConfig{SchemaCollectionWorkers: 4, MaxCollectorConnections: 1}, 20, 0},
+	{config.ServerConfig{SchemaCollectionWorkers: 4, MaxCollectorConnections: 0}, 20, 4},
+	{config.ServerConfig{SchemaCollectionWorkers: 0, MaxCollectorConnections: 10}, 20, 1},
+}
+
+func TestSchemaCollectionWorkers(t *testing.T) {
+	for _, test := range schemaCollectionWorkersTests {
+		actual := schemaCollectionWorkers(test.config, test.databaseCount)
+		if actual != test.expected {
+			t.Errorf("schemaCollectionWorkers(%+v, %d): expected %d, got %d", test.config, test.databaseCount, test.expected, actual)
+		}
+	}
+}
+
+func TestCollectAllSchemasConcurrently(t *testing.T) {
+	var active, maxActive int32
+	defer func(prev func(state.Server, state.CollectionOpts, *util.Logger, string, state.PostgresVersion) databaseSchemaResult) {
+		collectDatabaseSchema = prev
+	}(collectDatabaseSchema)
+	collectDatabaseSchema = func(server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, dbName string, postgresVersion state.PostgresVersion) databaseSchemaResult {
+		current := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			prevMax := atomic.LoadInt32(&maxActive)
+			if current <= prevMax || atomic.CompareAndSwapInt32(&maxActive, prevMax, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		var idx int
+		fmt.Sscanf(dbName, "db%d", &idx)
+		if idx%10 == 5 {
+			logger.PrintError("Error getting OID of database %s", dbName)
+			return databaseSchemaResult{}
+		}
+		oid := state.Oid(16384 + idx)
+		return databaseSchemaResult{
+			collected:   true,
+			databaseOid: oid,
+			ps: state.PersistedState{
+				Relations:     []state.PostgresRelation{{Oid: oid*10 + 1, DatabaseOid: oid, RelationName: dbName}},
+				RelationStats: state.PostgresRelationStatsMap{oid*10 + 1: {SizeBytes: int64(idx)}},
+				IndexStats:    make(state.PostgresIndexStatsMap),
+				Functions:     []state.PostgresFunction{{Oid: oid*10 + 2, DatabaseOid: oid, FunctionName: dbName}},
+			},
+		}
+	}
+
+	var databases []state.PostgresDatabase
+	var expectedOids []state.Oid
+	var expectedRelationNames, expectedErrors []string
+	for idx := 0; idx < 50; idx++ {
+		dbName := fmt.Sprintf("db%d", idx)
+		databases = append(databases, state.PostgresDatabase{Name: dbName, AllowConnections: true})
+		if idx%10 == 5 {
+			expectedErrors = append(expectedErrors, "Error getting OID of database "+dbName)
+			continue
+		}
+		expectedOids = append(expectedOids, state.Oid(16384+idx))
+		expectedRelationNames = append(expectedRelationNames, dbName)
+	}
+
+	server := state.Server{Config: config.ServerConfig{DbAllNames: true, SchemaCollectionWorkers: 8, MaxCollectorConnections: 5}}
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0), RememberErrors: true}
+	ps, ts := CollectAllSchemas(server, nil, state.CollectionOpts{}, logger, state.PersistedState{}, state.TransientState{Databases: databases})
+
+	if maxActive > 4 {
+		t.Errorf("Expected at most 4 databases to be collected at the same time, got %d", maxActive)
+	}
+	if diff := pretty.Compare(expectedOids, ts.DatabaseOidsWithLocalCatalog); diff != "" {
+		t.Errorf("Unexpected databases with local catalog: (-want +got)\n%s", diff)
+	}
+	var relationNames, functionNames []string
+	for _, relation := range ps.Relations {
+		relationNames = append(relationNames, relation.RelationName)
+	}
+	for _, function := range ps.Functions {
+		functionNames = append(functionNames, function.FunctionName)
+	}
+	if diff := pretty.Compare(expectedRelationNames, relationNames); diff != "" {
+		t.Errorf("Unexpected relations: (-want +got)\n%s", diff)
+	}
+	if diff := pretty.Compare(expectedRelationNames, functionNames); diff != "" {
+		t.Errorf("Unexpected functions: (-want +got)\n%s", diff)
+	}
+	if len(ps.RelationStats) != len(expectedOids) {
+		t.Errorf("Expected relation stats of %d databases, got %d", len(expectedOids), len(ps.RelationStats))
+	}
+	if diff := pretty.Compare(expectedErrors, logger.ErrorMessages); diff != "" {
+		t.Errorf("Unexpected error messages: (-want +got)\n%s", diff)
+	}
+}
+
+func TestCollectAllSchemasSingleConnection(t *testing.T) {
+	defer func(prev func(state.Server, state.CollectionOpts, *util.Logger, string, state.PostgresVersion) databaseSchemaResult) {
+		collectDatabaseSchema = prev
+	}(collectDatabaseSchema)
+	collectDatabaseSchema = func(server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, dbName string, postgresVersion state.PostgresVersion) databaseSchemaResult {
+		t.Errorf("Unexpected new connection to database %s", dbName)
+		return databaseSchemaResult{}
+	}
+	defer func(prev func(state.Server, state.CollectionOpts, *util.Logger, *sql.DB, string, state.PostgresVersion) databaseSchemaResult) {
+		collectDatabaseSchemaOnConnection = prev
+	}(collectDatabaseSchemaOnConnection)
+	var collectedDbNames []string
+	collectDatabaseSchemaOnConnection = func(server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, schemaConnection *sql.DB, dbName string, postgresVersion state.PostgresVersion) databaseSchemaResult {
+		collectedDbNames = append(collectedDbNames, dbName)
+		return databaseSchemaResult{collected: true, databaseOid: 16384}
+	}
+
+	server := state.Server{Config: config.ServerConfig{DbName: "app", DbAllNames: true, SchemaCollectionWorkers: 4, MaxCollectorConnections: 1}}
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	_, ts := CollectAllSchemas(server, nil, state.CollectionOpts{}, logger, state.PersistedState{}, state.TransientState{Databases: schemaTestDatabases})
+
+	if diff := pretty.Compare([]string{"app"}, collectedDbNames); diff != "" {
+		t.Errorf("Unexpected collected databases: (-want +got)\n%s", diff)
+	}
+	if diff := pretty.Compare([]state.Oid{16384}, ts.DatabaseOidsWithLocalCatalog); diff != "" {
+		t.Errorf("Unexpected databases with local catalog: (-want +got)\n%s", diff)
+	}
+}
@@ -7,6 +7,15 @@ import (
 	"github.com/pganalyze/collector/state"
 )
 
+// Postgres 9.5 added pending_restart
+const settingsSQLDefaultPendingRestartField = "false"
+const settingsSQLpg95PendingRestartField = "pending_restart"
+
+var settingsSQLPendingRestartField = []versionedSQL{
+	{state.PostgresVersion95, settingsSQLpg95PendingRestartField},
+	{0, settingsSQLDefaultPendingRestartField},
+}
+
 const settingsSQL string = `
 SELECT name,
 			 setting AS current_value,
@@ -15,11 +24,12 @@ SELECT name,
 			 reset_val AS reset_value,
 			 source,
 			 sourcefile,
-			 sourceline
+			 sourceline,
+			 %s AS pending_restart
 	FROM pg_settings`
 
 func GetSettings(db *sql.DB, postgresVersion state.PostgresVersion) ([]state.PostgresSetting, error) {
-	stmt, err := db.Prepare(QueryMarkerSQL + settingsSQL)
+	stmt, err := db.Prepare(QueryMarkerSQL + fmt.Sprintf(settingsSQL, sqlForVersion(postgresVersion, settingsSQLPendingRestartField)))
 	if err != nil {
 		err = fmt.Errorf("Settings/Prepare: %s", err)
 		return nil, err
@@ -41,7 +51,7 @@ func GetSettings(db *sql.DB, postgresVersion state.PostgresVersion) ([]state.Pos
 		var row state.PostgresSetting
 
 		err := rows.Scan(&row.Name, &row.CurrentValue, &row.Unit, &row.BootValue,
-			&row.ResetValue, &row.Source, &row.SourceFile, &row.SourceLine)
+			&row.ResetValue, &row.Source, &row.SourceFile, &row.SourceLine, &row.PendingRestart)
 		if err != nil {
 			err = fmt.Errorf("Settings/Scan: %s", err)
 			return nil, err
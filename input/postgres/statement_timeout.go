@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/pganalyze/collector/util"
+)
+
+const defaultStatementTimeoutMs = 30000
+
+// getStatementTimeoutMs - Returns the statement timeout to use for all of our
+// queries, or 0 if the configured value is invalid and should be ignored
+func getStatementTimeoutMs(logger *util.Logger, statementTimeoutMs int32) int32 {
+	if statementTimeoutMs == 0 { // Default value
+		return defaultStatementTimeoutMs
+	}
+
+	// Assume anything below 100ms to be set in error - its not reasonable to have our queries run faster than that
+	if statementTimeoutMs < 100 {
+		logger.PrintVerbose("Ignoring invalid statement timeout of %dms (set it to at least 100ms)", statementTimeoutMs)
+		return 0
+	}
+
+	return statementTimeoutMs
+}
+
+// statementTimeoutConnector - Opens new database connections, and sets the
+// statement timeout on each of them before they get used for any query
+//
+// This is necessary since database/sql transparently replaces connections
+// (e.g. after they reached their maximum lifetime), which would otherwise
+// lose a statement_timeout that was only set once. For the same reason the
+// connection string is determined for each new connection, so that IAM
+// authentication tokens don't expire on long-lived connection pools, and the
+// TLS version is checked on each of them (if a minimum version is required).
+type statementTimeoutConnector struct {
+	getDsn             func() (string, error)
+	statementTimeoutMs int32
+	tlsMinVersion      string
+	open               func(dsn string) (driver.Conn, error)
+}
+
+func newStatementTimeoutConnector(getDsn func() (string, error), statementTimeoutMs int32) *statementTimeoutConnector {
+	return &statementTimeoutConnector{getDsn: getDsn, statementTimeoutMs: statementTimeoutMs, open: pq.Open}
+}
+
+func (c *statementTimeoutConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dsn, err := c.getDsn()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := c.open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.tlsMinVersion != "" {
+		err = validateTLSVersion(conn, c.tlsMinVersion)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if c.statementTimeoutMs == 0 {
+		return conn, nil
+	}
+
+	execer, ok := conn.(driver.Execer)
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("database connection does not support setting the statement timeout")
+	}
+
+	_, err = execer.Exec(fmt.Sprintf("%sSET statement_timeout = %d", QueryMarkerSQL, c.statementTimeoutMs), nil)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("could not set statement timeout: %s", err)
+	}
+
+	return conn, nil
+}
+
+func (c *statementTimeoutConnector) Driver() driver.Driver {
+	return c
+}
+
+// Open - Implements driver.Driver, only used by database/sql when the
+// connector itself is not available
+func (c *statementTimeoutConnector) Open(dsn string) (driver.Conn, error) {
+	getDsn := func() (string, error) { return dsn, nil }
+	return (&statementTimeoutConnector{getDsn: getDsn, statementTimeoutMs: c.statementTimeoutMs, tlsMinVersion: c.tlsMinVersion, open: c.open}).Connect(context.Background())
+}
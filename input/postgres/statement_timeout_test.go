@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io/ioutil"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/util"
+)
+
+// fakeConn - Records all statements that get run, instead of sending them to a database
+type fakeConn struct {
+	statements *[]string
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c fakeConn) Close() error {
+	return nil
+}
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c fakeConn) Exec(query string, args []driver.Value) (driver.Result, error) {
+	*c.statements = append(*c.statements, strings.TrimPrefix(query, QueryMarkerSQL))
+	return driver.RowsAffected(0), nil
+}
+
+func TestStatementTimeoutConnector(t *testing.T) {
+	var statements []string
+	connector := newStatementTimeoutConnector(func() (string, error) { return "", nil }, 30000)
+	connector.open = func(dsn string) (driver.Conn, error) {
+		return fakeConn{statements: &statements}, nil
+	}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	db.Exec("SELECT 1")
+	db.Exec("SELECT 2")
+
+	// Without idle connections every statement runs on a new connection, which
+	// needs to have the timeout set again
+	db.SetMaxIdleConns(0)
+	db.Exec("SELECT 3")
+	db.Exec("SELECT 4")
+
+	expected := []string{
+		"SET statement_timeout = 30000",
+		"SELECT 1",
+		"SELECT 2",
+		"SET statement_timeout = 30000",
+		"SELECT 3",
+		"SET statement_timeout = 30000",
+		"SELECT 4",
+	}
+	if diff := pretty.Compare(expected, statements); diff != "" {
+		t.Errorf("Unexpected statements: (-want +got)\n%s", diff)
+	}
+}
+
+var statementTimeoutMsTests = []struct {
+	configured int32
+	expected   int32
+}{
+	{0, 30000},
+	{50, 0},
+	{100, 100},
+	{5000, 5000},
+}
+
+func TestGetStatementTimeoutMs(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	for _, test := range statementTimeoutMsTests {
+		actual := getStatementTimeoutMs(logger, test.configured)
+		if actual != test.expected {
+			t.Errorf("getStatementTimeoutMs(%d): expected %d, got %d", test.configured, test.expected, actual)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"hash/fnv"
+	"strconv"
+	"strings"
 
 	"github.com/guregu/null"
 	"github.com/lib/pq"
@@ -14,9 +16,58 @@ import (
 const statementSQLDefaultOptionalFields = "NULL, NULL, NULL, NULL, NULL"
 const statementSQLpg94OptionalFields = "queryid, NULL, NULL, NULL, NULL"
 const statementSQLpg95OptionalFields = "queryid, min_time, max_time, mean_time, stddev_time"
+const statementSQLExecTimeOptionalFields = "queryid, min_exec_time, max_exec_time, mean_exec_time, stddev_exec_time"
+
+var statementSQLOptionalFields = []versionedSQL{
+	{state.PostgresVersion95, statementSQLpg95OptionalFields},
+	{state.PostgresVersion94, statementSQLpg94OptionalFields},
+	{0, statementSQLDefaultOptionalFields},
+}
+
+// pg_stat_statements 1.8 (shipped with Postgres 13) split timing into planning
+// and execution, and renamed the existing columns to refer to execution only
+const statementSQLDefaultTotalTimeField = "total_time"
+const statementSQLExecTimeTotalTimeField = "total_exec_time"
+
+// The installed extension version decides which columns exist, since it can be
+// older than the one shipped with the server (e.g. after pg_upgrade, until
+// ALTER EXTENSION pg_stat_statements UPDATE is run)
+const statementExtensionVersionSQL string = `
+SELECT extversion
+	FROM pg_catalog.pg_extension
+ WHERE extname = 'pg_stat_statements'`
+
+// statementStatsHaveExecTime - Whether the installed pg_stat_statements has the
+// separate execution time columns of version 1.8 and newer
+//
+// If the extension is not installed (yet), CREATE EXTENSION installs the version
+// shipped with the server, which has them starting with Postgres 13.
+func statementStatsHaveExecTime(db *sql.DB, postgresVersion state.PostgresVersion) bool {
+	var extVersion string
+	err := db.QueryRow(QueryMarkerSQL + statementExtensionVersionSQL).Scan(&extVersion)
+	if err != nil {
+		return postgresVersion.Numeric >= state.PostgresVersion13
+	}
+	return extensionVersionAtLeast(extVersion, 1, 8)
+}
+
+// extensionVersionAtLeast - Whether the extension version (e.g. "1.10") is at
+// least the given major and minor version
+func extensionVersionAtLeast(extVersion string, major int, minor int) bool {
+	parts := strings.SplitN(extVersion, ".", 3)
+	extMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	var extMinor int
+	if len(parts) > 1 {
+		extMinor, _ = strconv.Atoi(parts[1])
+	}
+	return extMajor > major || (extMajor == major && extMinor >= minor)
+}
 
 const statementSQL string = `
-SELECT dbid, userid, query, calls, total_time, rows, shared_blks_hit, shared_blks_read,
+SELECT dbid, userid, query, calls, %s, rows, shared_blks_hit, shared_blks_read,
 			 shared_blks_dirtied, shared_blks_written, local_blks_hit, local_blks_read,
 			 local_blks_dirtied, local_blks_written, temp_blks_read, temp_blks_written,
 			 blk_read_time, blk_write_time, %s
@@ -65,17 +116,46 @@ func ResetStatements(logger *util.Logger, db *sql.DB) error {
 	return nil
 }
 
+// StatementStatsUnavailableError - Returned by GetStatements when
+// pg_stat_statements isn't installed (and we couldn't create it), or the
+// module isn't loaded through shared_preload_libraries
+type StatementStatsUnavailableError struct {
+	Err error
+}
+
+func (e StatementStatsUnavailableError) Error() string {
+	return fmt.Sprintf("pg_stat_statements is not available: %s", e.Err)
+}
+
+// IsStatementStatsUnavailable - Whether the error indicates that pg_stat_statements
+// can't be used on this server, as opposed to a failure of a single query
+func IsStatementStatsUnavailable(err error) bool {
+	_, ok := err.(StatementStatsUnavailableError)
+	return ok
+}
+
+func isPostgresErrorCode(err error, codes ...string) bool {
+	pqErr, ok := err.(*pq.Error)
+	if !ok {
+		return false
+	}
+	for _, code := range codes {
+		if string(pqErr.Code) == code {
+			return true
+		}
+	}
+	return false
+}
+
 func GetStatements(logger *util.Logger, db *sql.DB, postgresVersion state.PostgresVersion, showtext bool, isHeroku bool) (state.PostgresStatementMap, state.PostgresStatementStatsMap, error) {
 	var err error
-	var optionalFields string
 	var sourceTable string
 
-	if postgresVersion.Numeric >= state.PostgresVersion95 {
-		optionalFields = statementSQLpg95OptionalFields
-	} else if postgresVersion.Numeric >= state.PostgresVersion94 {
-		optionalFields = statementSQLpg94OptionalFields
-	} else {
-		optionalFields = statementSQLDefaultOptionalFields
+	totalTimeField := statementSQLDefaultTotalTimeField
+	optionalFields := sqlForVersion(postgresVersion, statementSQLOptionalFields)
+	if statementStatsHaveExecTime(db, postgresVersion) {
+		totalTimeField = statementSQLExecTimeTotalTimeField
+		optionalFields = statementSQLExecTimeOptionalFields
 	}
 
 	usingStatsHelper := false
@@ -102,17 +182,16 @@ func GetStatements(logger *util.Logger, db *sql.DB, postgresVersion state.Postgr
 		}
 	}
 
-	sql := QueryMarkerSQL + fmt.Sprintf(statementSQL, optionalFields, sourceTable)
+	sql := QueryMarkerSQL + fmt.Sprintf(statementSQL, totalTimeField, optionalFields, sourceTable)
 
 	stmt, err := db.Prepare(sql)
 	if err != nil {
-		errCode := err.(*pq.Error).Code
-		if !usingStatsHelper && (errCode == "42P01" || errCode == "42883") { // undefined_table / undefined_function
-			logger.PrintInfo("pg_stat_statements does not exist, trying to create extension...")
+		if !usingStatsHelper && isPostgresErrorCode(err, "42P01", "42883") { // undefined_table / undefined_function
+			logger.PrintVerbose("pg_stat_statements does not exist, trying to create extension...")
 
 			_, err = db.Exec(QueryMarkerSQL + "CREATE EXTENSION IF NOT EXISTS pg_stat_statements")
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, StatementStatsUnavailableError{err}
 			}
 
 			stmt, err = db.Prepare(sql)
@@ -128,6 +207,9 @@ func GetStatements(logger *util.Logger, db *sql.DB, postgresVersion state.Postgr
 
 	rows, err := stmt.Query()
 	if err != nil {
+		if isPostgresErrorCode(err, "55000") { // object_not_in_prerequisite_state, i.e. missing from shared_preload_libraries
+			return nil, nil, StatementStatsUnavailableError{err}
+		}
 		return nil, nil, err
 	}
 	defer rows.Close()
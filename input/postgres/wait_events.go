@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// Idle connections wait for the client (ClientRead), which isn't of interest here
+const waitEventsSQL string = `
+SELECT wait_event_type, wait_event, COUNT(*)
+	FROM %s
+ WHERE pid <> pg_backend_pid() AND wait_event IS NOT NULL AND COALESCE(state, '') <> 'idle'
+ GROUP BY 1, 2`
+
+const waitEventsSQLpg92 string = `
+SELECT 'Lock', '', COUNT(*)
+	FROM %s
+ WHERE pid <> pg_backend_pid() AND waiting`
+
+// GetWaitEventCounts - Samples pg_stat_activity once, and returns how many
+// backends are currently waiting, by wait event
+func GetWaitEventCounts(logger *util.Logger, db *sql.DB, postgresVersion state.PostgresVersion) (state.PostgresWaitEventCounts, error) {
+	var sourceTable string
+
+	if statsHelperExists(db, "get_stat_activity") {
+		sourceTable = "pganalyze.get_stat_activity()"
+	} else {
+		sourceTable = "pg_stat_activity"
+	}
+
+	querySQL := waitEventsSQL
+	if postgresVersion.Numeric < state.PostgresVersion96 {
+		querySQL = waitEventsSQLpg92
+	}
+
+	rows, err := db.Query(QueryMarkerSQL + fmt.Sprintf(querySQL, sourceTable))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(state.PostgresWaitEventCounts)
+	for rows.Next() {
+		var key state.PostgresWaitEventKey
+		var count int64
+
+		err = rows.Scan(&key.WaitEventType, &key.WaitEvent, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		if count > 0 {
+			counts[key] += count
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
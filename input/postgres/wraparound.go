@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+)
+
+// Number of tables with the oldest unfrozen transaction IDs that get collected
+const wraparoundTableLimit = 10
+
+// Transaction ID ages are computed by Postgres, since they need the current
+// transaction ID (including the epoch) to be correct
+const wraparoundDatabasesSQL string = `
+SELECT oid, datname, pg_catalog.age(datfrozenxid),
+			 pg_catalog.current_setting('autovacuum_freeze_max_age')::bigint
+	FROM pg_catalog.pg_database`
+
+// Only tables, materialized views and TOAST tables have a relfrozenxid (for
+// other kinds it's zero, which would make them look close to wraparound)
+//
+// TOAST tables are filtered by the schema of the table they belong to, since
+// the pg_toast schema is not something users would list in their schema filters.
+const wraparoundTablesSQL string = `
+SELECT c.oid, n.nspname, c.relname, pg_catalog.age(c.relfrozenxid),
+			 COALESCE(owner_n.nspname, n.nspname), COALESCE(owner.relname, c.relname)
+	FROM pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON (n.oid = c.relnamespace)
+	LEFT JOIN pg_catalog.pg_class owner ON (c.relkind = 't' AND owner.reltoastrelid = c.oid)
+	LEFT JOIN pg_catalog.pg_namespace owner_n ON (owner_n.oid = owner.relnamespace)
+ WHERE c.relkind IN ('r', 'm', 't')
+			 AND %s
+ ORDER BY 4 DESC
+ LIMIT %d`
+
+// GetWraparound - Reads the transaction ID age of all databases, and of the
+// tables in the monitored database that are closest to wraparound
+//
+// Tables are only read for the database we're connected to (db_name), not for
+// any other databases that are monitored, and skip schemas excluded by the
+// schema allow/deny lists.
+func GetWraparound(db *sql.DB, serverConfig config.ServerConfig) (wraparound state.PostgresWraparound, err error) {
+	rows, err := db.Query(QueryMarkerSQL + wraparoundDatabasesSQL)
+	if err != nil {
+		err = fmt.Errorf("Wraparound/Query: %s", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var database state.PostgresWraparoundDatabase
+		err = rows.Scan(&database.DatabaseOid, &database.DatabaseName, &database.XIDAge, &wraparound.FreezeMaxAge)
+		if err != nil {
+			err = fmt.Errorf("Wraparound/Scan: %s", err)
+			return
+		}
+		wraparound.Databases = append(wraparound.Databases, database)
+	}
+	if err = rows.Err(); err != nil {
+		err = fmt.Errorf("Wraparound/Rows: %s", err)
+		return
+	}
+
+	tableRows, err := db.Query(QueryMarkerSQL + fmt.Sprintf(wraparoundTablesSQL, schemaFilterSQL(serverConfig, "COALESCE(owner_n.nspname, n.nspname)"), wraparoundTableLimit))
+	if err != nil {
+		err = fmt.Errorf("Wraparound/Query: %s", err)
+		return
+	}
+	defer tableRows.Close()
+
+	for tableRows.Next() {
+		var table state.PostgresWraparoundTable
+		err = tableRows.Scan(&table.RelationOid, &table.SchemaName, &table.RelationName, &table.XIDAge, &table.OwnerSchemaName, &table.OwnerRelationName)
+		if err != nil {
+			err = fmt.Errorf("Wraparound/Scan: %s", err)
+			return
+		}
+		wraparound.Tables = append(wraparound.Tables, table)
+	}
+	if err = tableRows.Err(); err != nil {
+		err = fmt.Errorf("Wraparound/Rows: %s", err)
+		return
+	}
+
+	wraparound = state.AssessWraparound(wraparound)
+	return
+}
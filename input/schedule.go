@@ -0,0 +1,43 @@
+package input
+
+import (
+	"time"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+)
+
+// collectionIntervals - Returns the configured interval of each category
+func collectionIntervals(serverConfig config.ServerConfig) map[state.CollectionCategory]time.Duration {
+	return map[state.CollectionCategory]time.Duration{
+		state.CollectionCategorySystem:     serverConfig.SystemCollectionInterval,
+		state.CollectionCategoryStatements: serverConfig.StatementCollectionInterval,
+		state.CollectionCategoryRelations:  serverConfig.RelationCollectionInterval,
+	}
+}
+
+// dueCollectionCategories - Returns whether each category should be collected
+// in the run starting now, which is the case once its interval has passed since
+// it was last collected (or if it never was)
+func dueCollectionCategories(serverConfig config.ServerConfig, prevState state.PersistedState, now time.Time) map[state.CollectionCategory]bool {
+	due := make(map[state.CollectionCategory]bool)
+	for category, interval := range collectionIntervals(serverConfig) {
+		due[category] = collectionDue(prevState.CategoryCollectedAt[category], now, interval)
+	}
+	return due
+}
+
+// updateCategoryCollectedAt - Returns when each category was last collected,
+// after the categories in collected were collected now
+func updateCategoryCollectedAt(prev map[state.CollectionCategory]time.Time, collected map[state.CollectionCategory]bool, now time.Time) map[state.CollectionCategory]time.Time {
+	collectedAt := make(map[state.CollectionCategory]time.Time)
+	for category, at := range prev {
+		collectedAt[category] = at
+	}
+	for category, wasCollected := range collected {
+		if wasCollected {
+			collectedAt[category] = now
+		}
+	}
+	return collectedAt
+}
@@ -0,0 +1,56 @@
+package input
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+)
+
+func TestDueCollectionCategories(t *testing.T) {
+	serverConfig := config.ServerConfig{
+		StatementCollectionInterval: 20 * time.Minute,
+		RelationCollectionInterval:  30 * time.Minute,
+	}
+
+	// Full snapshots every 10 minutes, system information is collected every
+	// time since it has no interval
+	expected := []map[state.CollectionCategory]bool{
+		{state.CollectionCategorySystem: true, state.CollectionCategoryStatements: true, state.CollectionCategoryRelations: true},
+		{state.CollectionCategorySystem: true, state.CollectionCategoryStatements: false, state.CollectionCategoryRelations: false},
+		{state.CollectionCategorySystem: true, state.CollectionCategoryStatements: true, state.CollectionCategoryRelations: false},
+		{state.CollectionCategorySystem: true, state.CollectionCategoryStatements: false, state.CollectionCategoryRelations: true},
+		{state.CollectionCategorySystem: true, state.CollectionCategoryStatements: true, state.CollectionCategoryRelations: false},
+		{state.CollectionCategorySystem: true, state.CollectionCategoryStatements: false, state.CollectionCategoryRelations: false},
+		{state.CollectionCategorySystem: true, state.CollectionCategoryStatements: true, state.CollectionCategoryRelations: true},
+	}
+
+	start := time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+	var prevState state.PersistedState
+	for tick, expectedDue := range expected {
+		now := start.Add(time.Duration(tick) * 10 * time.Minute)
+		due := dueCollectionCategories(serverConfig, prevState, now)
+		if diff := pretty.Compare(expectedDue, due); diff != "" {
+			t.Errorf("Tick %d: due categories diff: (-want +got)\n%s", tick, diff)
+		}
+		prevState = state.PersistedState{CollectedAt: now, CategoryCollectedAt: updateCategoryCollectedAt(prevState.CategoryCollectedAt, due, now)}
+	}
+}
+
+func TestUpdateCategoryCollectedAt(t *testing.T) {
+	earlier := time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+	now := earlier.Add(10 * time.Minute)
+
+	prev := map[state.CollectionCategory]time.Time{state.CollectionCategoryStatements: earlier, state.CollectionCategoryRelations: earlier}
+	actual := updateCategoryCollectedAt(prev, map[state.CollectionCategory]bool{state.CollectionCategoryStatements: true, state.CollectionCategoryRelations: false}, now)
+
+	expected := map[state.CollectionCategory]time.Time{state.CollectionCategoryStatements: now, state.CollectionCategoryRelations: earlier}
+	if diff := pretty.Compare(expected, actual); diff != "" {
+		t.Errorf("Collected at diff: (-want +got)\n%s", diff)
+	}
+	if !prev[state.CollectionCategoryStatements].Equal(earlier) {
+		t.Errorf("Expected the previous state to be left unchanged")
+	}
+}
@@ -0,0 +1,113 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/util"
+	"github.com/pganalyze/collector/util/azureutil"
+)
+
+// Base URL of the Log Analytics query API, which is only changed in tests
+var logAnalyticsBaseURL = "https://api.loganalytics.io"
+
+// How long a single query (including getting an access token) may take
+const logAnalyticsQueryTimeout = 60 * time.Second
+
+// Resource that access tokens for querying Log Analytics are requested for
+const logAnalyticsResource = "https://api.loganalytics.io"
+
+// LogAnalyticsAPI - Runs KQL queries against a Log Analytics workspace, and
+// returns the rows of the result by column name
+type LogAnalyticsAPI interface {
+	Query(ctx context.Context, query string) ([]map[string]interface{}, error)
+}
+
+type logAnalyticsClient struct {
+	workspaceID string
+	tokens      *azureutil.TokenSource
+}
+
+// NewLogAnalyticsClient - Sets up querying the server's Log Analytics workspace,
+// authenticated according to azure_auth_connection_string
+func NewLogAnalyticsClient(config config.ServerConfig) (LogAnalyticsAPI, error) {
+	tokens, err := azureutil.NewTokenSource(config.AzureAuthConnectionString, logAnalyticsResource)
+	if err != nil {
+		return nil, err
+	}
+	return &logAnalyticsClient{workspaceID: config.AzureLogAnalyticsWorkspaceID, tokens: tokens}, nil
+}
+
+type logAnalyticsResponse struct {
+	Tables []struct {
+		Columns []struct {
+			Name string `json:"name"`
+		} `json:"columns"`
+		Rows [][]interface{} `json:"rows"`
+	} `json:"tables"`
+}
+
+func (c *logAnalyticsClient) Query(ctx context.Context, query string) ([]map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, logAnalyticsQueryTimeout)
+	defer cancel()
+
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", logAnalyticsBaseURL+"/v1/workspaces/"+url.PathEscape(c.workspaceID)+"/query", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", util.CollectorNameAndVersion)
+
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Log Analytics query failed: %s: %s", resp.Status, body)
+	}
+
+	var result logAnalyticsResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, fmt.Errorf("Log Analytics response could not be decoded: %s", err)
+	}
+	if len(result.Tables) == 0 {
+		return nil, nil
+	}
+
+	table := result.Tables[0]
+	rows := make([]map[string]interface{}, 0, len(table.Rows))
+	for _, values := range table.Rows {
+		row := make(map[string]interface{})
+		for idx, column := range table.Columns {
+			if idx < len(values) {
+				row[column.Name] = values[idx]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
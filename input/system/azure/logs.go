@@ -0,0 +1,163 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pganalyze/collector/input/system/logs"
+	"github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Upper bound for queries per run, so a large backlog (e.g. after the collector
+// was stopped for a while) gets worked off over multiple runs
+const logsMaxPagesPerRun = 10
+
+// Records returned by a single query
+const logsPageSize = 1000
+
+// How far back we start reading when we don't have a position yet - records
+// usually show up in Log Analytics a few minutes after they were logged
+const logsInitialLookback = 5 * time.Minute
+
+// Records are read in the order they were ingested into the workspace (not in
+// the order they were logged), so records that arrive late are not skipped
+//
+// Records ingested at the same time are ordered by their ID, so the ones at the
+// last ingestion time we've read can be skipped in the query itself - a batch
+// of more records than fit on a page would otherwise never be read past.
+const logsQuery = `AzureDiagnostics
+| where Category == "PostgreSQLLogs" and Resource =~ %s
+| extend IngestedAt = ingestion_time()
+| where IngestedAt >= datetime(%s)
+| order by IngestedAt asc, _ItemId asc
+| extend RowNumber = row_number()
+| where RowNumber > %d
+| project IngestedAt, TimeGenerated, Message, processId_d
+| take %d`
+
+// Azure's default log_line_prefix is "%t-%c-", where the session ID (%c) is
+// made up of the hex encoded process start time and PID
+var defaultPrefixRegexp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(?:\.\d+)? \w+-[0-9a-f]+\.([0-9a-f]+)-(\w+):\s+(.*)$`)
+
+// LogSource - Reads the Postgres logs of an Azure Database for PostgreSQL
+// server from the Log Analytics workspace its diagnostic settings send them to
+//
+// The position we left off at is kept, so it can be persisted between runs
+// (see Position).
+type LogSource struct {
+	client     LogAnalyticsAPI
+	serverName string
+	position   state.AzureLogsPosition
+
+	now func() time.Time
+}
+
+// NewLogSource - Sets up a log source for the given server, continuing at the given position
+func NewLogSource(client LogAnalyticsAPI, serverName string, position state.AzureLogsPosition) *LogSource {
+	return &LogSource{client: client, serverName: serverName, position: position, now: time.Now}
+}
+
+// Position - Returns where the source left off, to be passed to NewLogSource on the next run
+func (s *LogSource) Position() state.AzureLogsPosition {
+	return s.position
+}
+
+// GetLogLines - Returns all log lines that were ingested into the workspace since the last call
+func (s *LogSource) GetLogLines(ctx context.Context) ([]state.LogLine, error) {
+	if s.serverName == "" {
+		return nil, fmt.Errorf("azure_db_server_name needs to be set for reading logs from Log Analytics")
+	}
+
+	if s.position.LastIngestedAt.IsZero() {
+		s.position = state.AzureLogsPosition{LastIngestedAt: s.now().Add(-logsInitialLookback)}
+	}
+
+	var logLines []state.LogLine
+	collectedAt := s.now()
+
+	for page := 0; page < logsMaxPagesPerRun; page++ {
+		if err := ctx.Err(); err != nil {
+			return logLines, err
+		}
+
+		// The query includes the records at the last ingestion time we've seen
+		// (except for those we already read), since more could have been
+		// ingested at that time after we read it
+		queryFrom := s.position.LastIngestedAt.UTC().Format(time.RFC3339Nano)
+		rows, err := s.client.Query(ctx, fmt.Sprintf(logsQuery, strconv.Quote(s.serverName), queryFrom, s.position.RecordsAtLastIngestedAt, logsPageSize))
+		if err != nil {
+			return logLines, err
+		}
+
+		for _, row := range rows {
+			ingestedAt, err := rowTime(row, "IngestedAt")
+			if err != nil {
+				return logLines, err
+			}
+
+			logLines = append(logLines, logLineFromRow(row, collectedAt))
+			if ingestedAt.After(s.position.LastIngestedAt) {
+				s.position = state.AzureLogsPosition{LastIngestedAt: ingestedAt, RecordsAtLastIngestedAt: 1}
+			} else {
+				s.position.RecordsAtLastIngestedAt++
+			}
+		}
+
+		if len(rows) < logsPageSize {
+			break
+		}
+	}
+
+	return logLines, nil
+}
+
+func logLineFromRow(row map[string]interface{}, collectedAt time.Time) state.LogLine {
+	message, _ := row["Message"].(string)
+
+	// Each record is a complete log entry, with any continuation lines (e.g. of a
+	// multi-line query) included in the message
+	lines := strings.Split(strings.TrimSuffix(message, "\n"), "\n")
+
+	// Messages use whichever log_line_prefix is configured on the server, so we
+	// try the prefixes we know first, and then fall back to Azure's default
+	logLine, ok := logs.ParseLogLineWithPrefix("", lines[0])
+	if !ok {
+		logLine = state.LogLine{}
+		if parts := defaultPrefixRegexp.FindStringSubmatch(lines[0]); parts != nil {
+			pid, _ := strconv.ParseInt(parts[1], 16, 32)
+			logLine.BackendPid = int32(pid)
+			logLine.LogLevel = pganalyze_collector.LogLineInformation_LogLevel(pganalyze_collector.LogLineInformation_LogLevel_value[parts[2]])
+			logLine.Content = parts[3]
+		} else {
+			logLine.Content = lines[0]
+		}
+	}
+	for _, line := range lines[1:] {
+		logLine.Content += "\n" + line
+	}
+
+	if pid, ok := row["processId_d"].(float64); ok && logLine.BackendPid == 0 {
+		logLine.BackendPid = int32(pid)
+	}
+	if logLine.OccurredAt.IsZero() {
+		logLine.OccurredAt, _ = rowTime(row, "TimeGenerated")
+	}
+	logLine.CollectedAt = collectedAt
+	logLine.UUID = uuid.NewV4()
+	return logLine
+}
+
+func rowTime(row map[string]interface{}, column string) (time.Time, error) {
+	value, _ := row[column].(string)
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return t, fmt.Errorf("invalid %s in Log Analytics result: %s", column, err)
+	}
+	return t, nil
+}
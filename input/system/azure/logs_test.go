@@ -0,0 +1,165 @@
+package azure
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+)
+
+var fakeQueryFromRegexp = regexp.MustCompile(`IngestedAt >= datetime\(([^)]+)\)`)
+var fakeQuerySkipRegexp = regexp.MustCompile(`RowNumber > (\d+)`)
+var fakeQueryTakeRegexp = regexp.MustCompile(`take (\d+)`)
+
+// fakeLogAnalytics - Serves in-memory records (ordered by ingestion time, and
+// then by when they were added), filtered by the ingestion time the query
+// starts at, the rows it skips and the number of rows it takes
+type fakeLogAnalytics struct {
+	records []map[string]interface{}
+	queries []string
+}
+
+func (f *fakeLogAnalytics) Query(ctx context.Context, query string) ([]map[string]interface{}, error) {
+	f.queries = append(f.queries, query)
+	from, err := time.Parse(time.RFC3339Nano, fakeQueryFromRegexp.FindStringSubmatch(query)[1])
+	if err != nil {
+		return nil, err
+	}
+
+	skip, _ := strconv.Atoi(fakeQuerySkipRegexp.FindStringSubmatch(query)[1])
+	take, _ := strconv.Atoi(fakeQueryTakeRegexp.FindStringSubmatch(query)[1])
+
+	var rows []map[string]interface{}
+	for _, record := range f.records {
+		ingestedAt, _ := time.Parse(time.RFC3339Nano, record["IngestedAt"].(string))
+		if ingestedAt.Before(from) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if len(rows) < take {
+			rows = append(rows, record)
+		}
+	}
+	return rows, nil
+}
+
+func (f *fakeLogAnalytics) addRecord(ingestedAt time.Time, message string, pid float64) {
+	f.records = append(f.records, map[string]interface{}{
+		"IngestedAt":    ingestedAt.Format(time.RFC3339Nano),
+		"TimeGenerated": ingestedAt.Add(-time.Minute).Format(time.RFC3339Nano),
+		"Message":       message,
+		"processId_d":   pid,
+	})
+}
+
+type azureLogLine struct {
+	OccurredAt time.Time
+	BackendPid int32
+	LogLevel   pganalyze_collector.LogLineInformation_LogLevel
+	Content    string
+}
+
+func summarizeAzureLogLines(logLines []state.LogLine) (result []azureLogLine) {
+	for _, logLine := range logLines {
+		result = append(result, azureLogLine{logLine.OccurredAt, logLine.BackendPid, logLine.LogLevel, logLine.Content})
+	}
+	return
+}
+
+func TestLogSourceGetLogLines(t *testing.T) {
+	now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	client := &fakeLogAnalytics{}
+	client.addRecord(now.Add(-10*time.Minute), "2024-01-02 09:49:00 UTC-65940a2c.1f4-LOG:  too old", 500)
+	client.addRecord(now.Add(-2*time.Minute), "2024-01-02 09:57:00 UTC-65940a2c.1f4-LOG:  statement: SELECT 1", 500)
+	client.addRecord(now.Add(-time.Minute), "2024-01-02 09:58:00 UTC-65940a2c.1f4-ERROR:  syntax error\nat character 5", 500)
+
+	source := NewLogSource(client, "mydb", state.AzureLogsPosition{})
+	source.now = func() time.Time { return now }
+
+	logLines, err := source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []azureLogLine{
+		{now.Add(-3 * time.Minute), 500, pganalyze_collector.LogLineInformation_LOG, "statement: SELECT 1"},
+		{now.Add(-2 * time.Minute), 500, pganalyze_collector.LogLineInformation_ERROR, "syntax error\nat character 5"},
+	}
+	if diff := pretty.Compare(summarizeAzureLogLines(logLines), expected); diff != "" {
+		t.Errorf("unexpected log lines (-got +want)\n%s", diff)
+	}
+	if position := source.Position(); !position.LastIngestedAt.Equal(now.Add(-time.Minute)) || position.RecordsAtLastIngestedAt != 1 {
+		t.Errorf("unexpected position: %+v", position)
+	}
+
+	// A record ingested at the same time as the last one we've read is picked
+	// up, without returning the one we already read again
+	client.addRecord(now.Add(-time.Minute), "plain message without prefix", 600)
+	logLines, err = source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected = []azureLogLine{
+		{now.Add(-2 * time.Minute), 600, 0, "plain message without prefix"},
+	}
+	if diff := pretty.Compare(summarizeAzureLogLines(logLines), expected); diff != "" {
+		t.Errorf("unexpected log lines after new record (-got +want)\n%s", diff)
+	}
+	if position := source.Position(); position.RecordsAtLastIngestedAt != 2 {
+		t.Errorf("unexpected position: %+v", position)
+	}
+
+	logLines, err = source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(logLines) != 0 {
+		t.Errorf("expected no new log lines, got %d", len(logLines))
+	}
+}
+
+func TestLogSourceGetLogLinesSameIngestionTime(t *testing.T) {
+	now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	client := &fakeLogAnalytics{}
+	for i := 0; i < 1500; i++ {
+		client.addRecord(now.Add(-time.Minute), "batch", 500)
+	}
+	client.addRecord(now.Add(-30*time.Second), "after the batch", 500)
+
+	source := NewLogSource(client, "mydb", state.AzureLogsPosition{})
+	source.now = func() time.Time { return now }
+
+	logLines, err := source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(logLines) != 1501 || logLines[1500].Content != "after the batch" {
+		t.Errorf("expected all 1501 records to be read, got %d", len(logLines))
+	}
+	if len(client.queries) != 2 {
+		t.Errorf("expected 2 queries, got %d", len(client.queries))
+	}
+
+	logLines, err = source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(logLines) != 0 {
+		t.Errorf("expected no new log lines, got %d", len(logLines))
+	}
+}
+
+func TestLogSourceRequiresServerName(t *testing.T) {
+	source := NewLogSource(&fakeLogAnalytics{}, "", state.AzureLogsPosition{})
+	_, err := source.GetLogLines(context.Background())
+	if err == nil {
+		t.Errorf("expected error when azure_db_server_name is not set")
+	}
+}
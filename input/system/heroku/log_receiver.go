@@ -1,6 +1,7 @@
 package heroku
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -210,6 +211,7 @@ func logReceiver(servers []state.Server, in <-chan config.HerokuLogStreamItem, g
 				logLinesByName[sourceName] = []state.LogLine{}
 				continue
 			}
+			server = logs.WithoutBlockingLogBuffer(server)
 
 			for idx, logLine := range logLines {
 				logLine.Username = server.Config.GetDbUsername()
@@ -218,7 +220,7 @@ func logReceiver(servers []state.Server, in <-chan config.HerokuLogStreamItem, g
 			}
 
 			prefixedLogger := logger.WithPrefix(server.Config.SectionName)
-			logLinesByName[sourceName] = logs.AnalyzeInGroupsAndSend(server, logLines, globalCollectionOpts, prefixedLogger, nil)
+			logLinesByName[sourceName] = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, globalCollectionOpts, prefixedLogger, nil)
 		}
 	}
 }
@@ -79,15 +79,42 @@ var autoExplainTextPlanDetailsRegexp = regexp.MustCompile(`^Query Text: (.+)\s+(
 
 var parallelWorkerProcessTextRegexp = regexp.MustCompile(`^parallel worker for PID (\d+)`)
 
-func AnalyzeLogLines(logLinesIn []state.LogLine) (logLinesOut []state.LogLine, samples []state.PostgresQuerySample) {
-	// Split log lines by backend to ensure we have the right context
-	backendLogLines := make(map[int32][]state.LogLine)
+// groupLogLinesByBackend - Splits log lines by backend, and returns the backend
+// PIDs in the order they were first seen, so results are stable between runs
+func groupLogLinesByBackend(logLinesIn []state.LogLine) (backendPids []int32, backendLogLines map[int32][]state.LogLine) {
+	backendLogLines = make(map[int32][]state.LogLine)
 
 	for _, logLine := range logLinesIn {
+		if _, exists := backendLogLines[logLine.BackendPid]; !exists {
+			backendPids = append(backendPids, logLine.BackendPid)
+		}
 		backendLogLines[logLine.BackendPid] = append(backendLogLines[logLine.BackendPid], logLine)
 	}
 
-	for _, logLines := range backendLogLines {
+	return
+}
+
+// groupLogLineIdxsByBackend - Like groupLogLinesByBackend, but returns the
+// indices of the log lines in the passed in slice
+func groupLogLineIdxsByBackend(logLinesIn []state.LogLine) (backendPids []int32, backendLogLineIdxs map[int32][]int) {
+	backendLogLineIdxs = make(map[int32][]int)
+
+	for idx, logLine := range logLinesIn {
+		if _, exists := backendLogLineIdxs[logLine.BackendPid]; !exists {
+			backendPids = append(backendPids, logLine.BackendPid)
+		}
+		backendLogLineIdxs[logLine.BackendPid] = append(backendLogLineIdxs[logLine.BackendPid], idx)
+	}
+
+	return
+}
+
+func AnalyzeLogLines(logLinesIn []state.LogLine) (logLinesOut []state.LogLine, samples []state.PostgresQuerySample) {
+	// Split log lines by backend to ensure we have the right context
+	backendPids, backendLogLines := groupLogLinesByBackend(logLinesIn)
+
+	for _, backendPid := range backendPids {
+		logLines := backendLogLines[backendPid]
 		backendLogLinesOut, backendSamples := AnalyzeBackendLogLines(logLines)
 		for _, logLine := range backendLogLinesOut {
 			logLinesOut = append(logLinesOut, logLine)
@@ -1065,12 +1092,37 @@ func classifyAndSetDetails(logLine state.LogLine, detailLine state.LogLine, samp
 	return logLine, samples
 }
 
+// isFollowOnLogLevel - Whether lines with this level add context to the line
+// preceding them in the same backend, instead of being standalone events
+func isFollowOnLogLevel(logLevel pganalyze_collector.LogLineInformation_LogLevel) bool {
+	return logLevel == pganalyze_collector.LogLineInformation_STATEMENT || logLevel == pganalyze_collector.LogLineInformation_DETAIL ||
+		logLevel == pganalyze_collector.LogLineInformation_HINT || logLevel == pganalyze_collector.LogLineInformation_CONTEXT ||
+		logLevel == pganalyze_collector.LogLineInformation_QUERY
+}
+
 func AnalyzeBackendLogLines(logLines []state.LogLine) (logLinesOut []state.LogLine, samples []state.PostgresQuerySample) {
+	AnalyzeBackendLogLinesWithCallback(logLines, func(logLine state.LogLine) {
+		logLinesOut = append(logLinesOut, logLine)
+	}, func(sample state.PostgresQuerySample) {
+		samples = append(samples, sample)
+	})
+
+	return
+}
+
+// AnalyzeBackendLogLinesWithCallback - Analyzes the log lines of a single backend,
+// and passes each analyzed log line and query sample to the callbacks as soon as
+// they are available, instead of collecting them in memory
+func AnalyzeBackendLogLinesWithCallback(logLines []state.LogLine, logLineCallback func(state.LogLine), sampleCallback func(state.PostgresQuerySample)) {
 	additionalLines := 0
+	correlatedIdx := -1
 
 	for idx, logLine := range logLines {
+		// Ensure no other part of the system accidentally sends log line contents, as
+		// they should be considered opaque from here on
 		if additionalLines > 0 {
-			logLinesOut = append(logLinesOut, logLine)
+			logLine.Content = ""
+			logLineCallback(logLine)
 			additionalLines--
 			continue
 		}
@@ -1081,9 +1133,7 @@ func AnalyzeBackendLogLines(logLines []state.LogLine) (logLinesOut []state.LogLi
 		lowerBound := int(math.Min(float64(len(logLines)), float64(idx+1)))
 		upperBound := int(math.Min(float64(len(logLines)), float64(idx+5)))
 		for idx, futureLine := range logLines[lowerBound:upperBound] {
-			if futureLine.LogLevel == pganalyze_collector.LogLineInformation_STATEMENT || futureLine.LogLevel == pganalyze_collector.LogLineInformation_DETAIL ||
-				futureLine.LogLevel == pganalyze_collector.LogLineInformation_HINT || futureLine.LogLevel == pganalyze_collector.LogLineInformation_CONTEXT ||
-				futureLine.LogLevel == pganalyze_collector.LogLineInformation_QUERY {
+			if isFollowOnLogLevel(futureLine.LogLevel) {
 				if futureLine.LogLevel == pganalyze_collector.LogLineInformation_STATEMENT && !strings.HasSuffix(futureLine.Content, "[Your log message was truncated]") {
 					logLine.Query = futureLine.Content
 				}
@@ -1097,16 +1147,59 @@ func AnalyzeBackendLogLines(logLines []state.LogLine) (logLinesOut []state.LogLi
 			}
 		}
 
+		var samples []state.PostgresQuerySample
 		logLine, samples = classifyAndSetDetails(logLine, detailLine, samples)
 
-		logLinesOut = append(logLinesOut, logLine)
+		// The statement duration logged right after an auto_explain plan describes
+		// the same execution, so its sample is merged into the one with the plan
+		if idx == correlatedIdx {
+			samples = nil
+		}
+		for sampleIdx, sample := range samples {
+			if !sample.HasExplain || sample.ExplainSource != pganalyze_collector.QuerySample_AUTO_EXPLAIN_EXPLAIN_SOURCE {
+				continue
+			}
+			nextIdx := idx + 1 + additionalLines
+			if durationSample, ok := peekDurationSample(logLines, nextIdx); ok && durationSample.Query == sample.Query {
+				samples[sampleIdx].Parameters = durationSample.Parameters
+				correlatedIdx = nextIdx
+			}
+		}
+
+		for _, sample := range samples {
+			sampleCallback(sample)
+		}
+
+		logLine.Content = ""
+		logLineCallback(logLine)
 	}
+}
 
-	// Ensure no other part of the system accidentally sends log line contents, as
-	// they should be considered opaque from here on
-	for idx := range logLinesOut {
-		logLinesOut[idx].Content = ""
+// peekDurationSample - Returns the query sample of the statement duration line
+// at the given index (if any), without modifying the log lines
+func peekDurationSample(logLines []state.LogLine, idx int) (state.PostgresQuerySample, bool) {
+	if idx >= len(logLines) {
+		return state.PostgresQuerySample{}, false
+	}
+	logLine := logLines[idx]
+	if !strings.HasPrefix(logLine.Content, "duration: ") || ContentAutoExplainRegexp.MatchString(logLine.Content) {
+		return state.PostgresQuerySample{}, false
 	}
 
-	return
+	var detailLine state.LogLine
+	upperBound := int(math.Min(float64(len(logLines)), float64(idx+5)))
+	for _, futureLine := range logLines[idx+1 : upperBound] {
+		if !isFollowOnLogLevel(futureLine.LogLevel) {
+			break
+		}
+		if futureLine.LogLevel == pganalyze_collector.LogLineInformation_DETAIL {
+			detailLine = futureLine
+		}
+	}
+
+	_, samples := classifyAndSetDetails(logLine, detailLine, nil)
+	if len(samples) != 1 || samples[0].HasExplain {
+		return state.PostgresQuerySample{}, false
+	}
+	return samples[0], true
 }
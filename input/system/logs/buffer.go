@@ -0,0 +1,67 @@
+package logs
+
+import (
+	"sync/atomic"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// Policies for what happens once LogBufferMaxLines log lines are buffered
+// because they could not be sent yet (see LogBufferPolicy)
+const (
+	LogBufferPolicyDropOldest = "drop_oldest"
+	LogBufferPolicyBlock      = "block"
+)
+
+// LogBufferFull - Returns whether no further log lines should be received for
+// now, since the server uses the block policy and its buffer is full
+//
+// Callers receiving log lines stop reading from their source while this is
+// true, which leaves the lines with the source (e.g. in the log file) instead
+// of keeping them in memory.
+func LogBufferFull(server state.Server, bufferedLogLines []state.LogLine) bool {
+	return server.Config.LogBufferPolicy == LogBufferPolicyBlock &&
+		server.Config.LogBufferMaxLines > 0 && len(bufferedLogLines) >= server.Config.LogBufferMaxLines
+}
+
+// WithoutBlockingLogBuffer - Returns the server to use for log lines that are
+// pushed to the collector (e.g. over syslog), which uses the drop_oldest policy
+// instead of the block policy
+//
+// Pushed lines can't be left with their sender while the buffer is full, so
+// blocking would only move them into an unlimited buffer of the receiver.
+func WithoutBlockingLogBuffer(server state.Server) state.Server {
+	if server.Config.LogBufferPolicy == LogBufferPolicyBlock {
+		server.Config.LogBufferPolicy = LogBufferPolicyDropOldest
+	}
+	return server
+}
+
+// PausedLogBufferMaxLines - How many log lines are kept while collection is
+// paused, for servers that don't limit their buffer through LogBufferMaxLines
+var PausedLogBufferMaxLines = 100000
+
+// limitBufferedLogLines - Drops the oldest of the log lines that are kept for
+// the next attempt, if there are more than the server's buffer allows
+//
+// With the block policy the lines are kept, since LogBufferFull stops new lines
+// from being received. While collection is paused, an unlimited buffer is
+// limited to PausedLogBufferMaxLines, since a pause can last for hours.
+func limitBufferedLogLines(server state.Server, logLines []state.LogLine, paused bool, prefixedLogger *util.Logger) []state.LogLine {
+	maxLines := server.Config.LogBufferMaxLines
+	blocking := server.Config.LogBufferPolicy == LogBufferPolicyBlock
+	if maxLines <= 0 && paused {
+		maxLines = PausedLogBufferMaxLines
+		blocking = false
+	}
+	if maxLines <= 0 || len(logLines) <= maxLines || blocking {
+		return logLines
+	}
+
+	overflow := len(logLines) - maxLines
+	atomic.AddInt64(&logLinesOverflowed, int64(overflow))
+	prefixedLogger.PrintWarning("Dropping %d oldest log lines, since more than %d log lines are waiting to be sent", overflow, maxLines)
+
+	return append([]state.LogLine(nil), logLines[overflow:]...)
+}
@@ -0,0 +1,8 @@
+package logs
+
+import "time"
+
+// clock - Returns the current time wherever it decides how log lines get
+// handled (e.g. whether they are ready to be sent), replaced in tests to step
+// across these boundaries without having to sleep
+var clock = time.Now
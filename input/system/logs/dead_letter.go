@@ -0,0 +1,155 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/pganalyze/collector/output"
+	"github.com/pganalyze/collector/state"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Section names are user-defined, so anything unusual in them is replaced for the filename
+var deadLetterUnsafeRegexp = regexp.MustCompile(`[^\w.-]+`)
+
+// DeadLetterManifest - Describes a file that was written to the dead-letter
+// directory (a log file, or the query samples of the batch), so it can be
+// matched to its server and submitted manually later
+type DeadLetterManifest struct {
+	SectionName string `json:"section_name"`
+	SystemID    string `json:"system_id"`
+	SystemType  string `json:"system_type"`
+	SystemScope string `json:"system_scope"`
+
+	LogFileUUID  string `json:"log_file_uuid,omitempty"`
+	OriginalName string `json:"original_name,omitempty"`
+	ByteSize     int    `json:"byte_size"`
+	LogLines     int    `json:"log_lines"`
+	QuerySamples int    `json:"query_samples,omitempty"`
+
+	FirstOccurredAt time.Time `json:"first_occurred_at"`
+	LastOccurredAt  time.Time `json:"last_occurred_at"`
+
+	DroppedAt time.Time `json:"dropped_at"`
+	Failures  int       `json:"failures"`
+	LastError string    `json:"last_error"`
+
+	// Key envelope needed to decrypt the file (S3 client-side encryption
+	// metadata, with the data key encrypted by KMS), not set for plaintext files
+	Encryption map[string]string `json:"encryption,omitempty"`
+}
+
+// writeDeadLetter - Writes the log files and query samples of a batch that is
+// about to be dropped to the dead-letter directory, each next to a manifest
+// (".json") describing it
+//
+// The files are encrypted with the encryption key of the logs grant, just like
+// uploads. Without one (e.g. since getting the grant is what failed) they are
+// only written unencrypted if that was explicitly allowed, and made readable
+// for the collector's user only.
+func writeDeadLetter(server state.Server, grant state.GrantLogs, logState state.LogState, dir string, allowPlaintext bool, failures int, sendErr error, now time.Time) error {
+	encrypt := grant.EncryptionKey.CiphertextBlob != ""
+	if !encrypt && !allowPlaintext {
+		return fmt.Errorf("no log encryption key available, set log_dead_letter_plaintext to write dropped log lines without encryption")
+	}
+
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	baseManifest := DeadLetterManifest{
+		SectionName: server.Config.SectionName,
+		SystemID:    server.Config.SystemID,
+		SystemType:  server.Config.SystemType,
+		SystemScope: server.Config.SystemScope,
+		DroppedAt:   now,
+		Failures:    failures,
+	}
+	if sendErr != nil {
+		baseManifest.LastError = sendErr.Error()
+	}
+	basenamePrefix := filepath.Join(dir, fmt.Sprintf("%s-%s-", deadLetterUnsafeRegexp.ReplaceAllString(server.Config.SectionName, "_"), now.UTC().Format("20060102T150405Z")))
+
+	for _, logFile := range logState.LogFiles {
+		content, err := logFile.ReadContent()
+		if err != nil {
+			return fmt.Errorf("could not read log tempfile: %s", err)
+		}
+
+		manifest := baseManifest
+		manifest.LogFileUUID = logFile.UUID.String()
+		manifest.OriginalName = logFile.OriginalName
+		manifest.ByteSize = len(content)
+		manifest.LogLines = len(logFile.LogLines)
+		for _, logLine := range logFile.LogLines {
+			if manifest.FirstOccurredAt.IsZero() || logLine.OccurredAt.Before(manifest.FirstOccurredAt) {
+				manifest.FirstOccurredAt = logLine.OccurredAt
+			}
+			if logLine.OccurredAt.After(manifest.LastOccurredAt) {
+				manifest.LastOccurredAt = logLine.OccurredAt
+			}
+		}
+
+		err = writeDeadLetterFile(basenamePrefix+logFile.UUID.String(), ".log", content, manifest, grant, encrypt)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(logState.QuerySamples) > 0 {
+		content, err := json.Marshal(logState.QuerySamples)
+		if err != nil {
+			return err
+		}
+
+		manifest := baseManifest
+		manifest.ByteSize = len(content)
+		manifest.QuerySamples = len(logState.QuerySamples)
+		for _, sample := range logState.QuerySamples {
+			if manifest.FirstOccurredAt.IsZero() || sample.OccurredAt.Before(manifest.FirstOccurredAt) {
+				manifest.FirstOccurredAt = sample.OccurredAt
+			}
+			if sample.OccurredAt.After(manifest.LastOccurredAt) {
+				manifest.LastOccurredAt = sample.OccurredAt
+			}
+		}
+
+		err = writeDeadLetterFile(basenamePrefix+uuid.NewV4().String(), ".samples", content, manifest, grant, encrypt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeDeadLetterFile - Writes the content (encrypted, if requested) and its
+// manifest, with the manifest being written last so its presence means the
+// content is complete
+func writeDeadLetterFile(basename string, extension string, content []byte, manifest DeadLetterManifest, grant state.GrantLogs, encrypt bool) error {
+	var err error
+	if encrypt {
+		content, manifest.Encryption, err = output.EncryptLogContent(grant.EncryptionKey, content)
+		if err != nil {
+			return fmt.Errorf("could not encrypt dropped log lines: %s", err)
+		}
+		extension += ".enc"
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(basename+extension, content, 0600)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(basename+".json", manifestJSON, 0600)
+}
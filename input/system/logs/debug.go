@@ -28,6 +28,13 @@ func PrintDebugInfo(logFileContents string, logLines []state.LogLine, samples []
 		fmt.Printf("%d x %s\n", count, classification)
 	}
 
+	if len(samples) > 0 {
+		fmt.Printf("\nQuery samples (with fingerprints, as used by redact_query_parameters):\n")
+		for _, sample := range samples {
+			fmt.Printf("%s  %s\n", QuerySampleFingerprint(sample), sample.Query)
+		}
+	}
+
 	if len(unclassifiedLogLines) > 0 {
 		fmt.Printf("\nUnclassified log lines:\n")
 		for _, logLine := range unclassifiedLogLines {
@@ -0,0 +1,90 @@
+package logs
+
+import (
+	"sort"
+	"sync/atomic"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+type querySampleKey struct {
+	database    string
+	fingerprint [21]byte
+}
+
+// DeduplicateQuerySamples - Keeps at most maxPerFingerprint query samples for
+// each query fingerprint and database, preferring samples with EXPLAIN output,
+// and then the slowest ones
+//
+// Samples that are kept stay in their original order. A maxPerFingerprint of
+// zero disables deduplication.
+func DeduplicateQuerySamples(samples []state.PostgresQuerySample, maxPerFingerprint int) []state.PostgresQuerySample {
+	if maxPerFingerprint <= 0 || len(samples) <= maxPerFingerprint {
+		return samples
+	}
+
+	idxsByKey := make(map[querySampleKey][]int)
+	for idx, sample := range samples {
+		key := querySampleKey{database: sample.Database, fingerprint: util.FingerprintQuery(sample.Query)}
+		idxsByKey[key] = append(idxsByKey[key], idx)
+	}
+
+	keep := make([]bool, len(samples))
+	for _, idxs := range idxsByKey {
+		sort.SliceStable(idxs, func(i, j int) bool {
+			a, b := samples[idxs[i]], samples[idxs[j]]
+			if a.HasExplain != b.HasExplain {
+				return a.HasExplain
+			}
+			return a.RuntimeMs > b.RuntimeMs
+		})
+		if len(idxs) > maxPerFingerprint {
+			idxs = idxs[:maxPerFingerprint]
+		}
+		for _, idx := range idxs {
+			keep[idx] = true
+		}
+	}
+
+	var kept []state.PostgresQuerySample
+	for idx, sample := range samples {
+		if keep[idx] {
+			kept = append(kept, sample)
+		}
+	}
+	return kept
+}
+
+// LimitQuerySamples - Keeps at most max samples overall, preferring the slowest
+// ones, and counts the dropped samples in the collector statistics
+//
+// Samples that are kept stay in their original order. A max of zero disables
+// the limit.
+func LimitQuerySamples(samples []state.PostgresQuerySample, max int) []state.PostgresQuerySample {
+	if max <= 0 || len(samples) <= max {
+		return samples
+	}
+
+	idxs := make([]int, len(samples))
+	for idx := range samples {
+		idxs[idx] = idx
+	}
+	sort.SliceStable(idxs, func(i, j int) bool {
+		return samples[idxs[i]].RuntimeMs > samples[idxs[j]].RuntimeMs
+	})
+
+	keep := make([]bool, len(samples))
+	for _, idx := range idxs[:max] {
+		keep[idx] = true
+	}
+
+	kept := make([]state.PostgresQuerySample, 0, max)
+	for idx, sample := range samples {
+		if keep[idx] {
+			kept = append(kept, sample)
+		}
+	}
+	atomic.AddInt64(&querySamplesDropped, int64(len(samples)-max))
+	return kept
+}
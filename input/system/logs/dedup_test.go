@@ -0,0 +1,92 @@
+package logs_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/pganalyze/collector/input/system/logs"
+	"github.com/pganalyze/collector/state"
+)
+
+func TestDeduplicateQuerySamples(t *testing.T) {
+	var samples []state.PostgresQuerySample
+	for i := 0; i < 100; i++ {
+		samples = append(samples, state.PostgresQuerySample{Database: "mydb", Query: "SELECT * FROM items WHERE id = 1", RuntimeMs: float64(i)})
+	}
+	// Same query text on another database, and a different query with other literal values
+	samples = append(samples, state.PostgresQuerySample{Database: "otherdb", Query: "SELECT * FROM items WHERE id = 1", RuntimeMs: 1})
+	samples = append(samples, state.PostgresQuerySample{Database: "mydb", Query: "SELECT * FROM items WHERE id = 2", RuntimeMs: 1})
+	samples = append(samples, state.PostgresQuerySample{Database: "mydb", Query: "SELECT * FROM users", RuntimeMs: 1})
+	// A fast sample with EXPLAIN output is kept over slower ones without
+	samples[10].HasExplain = true
+
+	result := logs.DeduplicateQuerySamples(samples, 3)
+
+	var runtimes []float64
+	databases := make(map[string]int)
+	for _, sample := range result {
+		databases[sample.Database]++
+		if sample.Database == "mydb" && sample.Query != "SELECT * FROM users" {
+			runtimes = append(runtimes, sample.RuntimeMs)
+		}
+	}
+	expected := []float64{10, 98, 99}
+	if len(runtimes) != len(expected) {
+		t.Fatalf("Expected %d samples for the repeated query, got %d", len(expected), len(runtimes))
+	}
+	for idx := range expected {
+		if runtimes[idx] != expected[idx] {
+			t.Errorf("Expected runtimes %v to be kept (in original order), got %v", expected, runtimes)
+			break
+		}
+	}
+	if databases["otherdb"] != 1 {
+		t.Errorf("Expected the sample of the other database to be kept separately, got %d", databases["otherdb"])
+	}
+	if databases["mydb"] != 4 {
+		t.Errorf("Expected 4 samples for mydb (3 of the repeated query, 1 other query), got %d", databases["mydb"])
+	}
+
+	if len(logs.DeduplicateQuerySamples(samples, 0)) != len(samples) {
+		t.Errorf("Expected deduplication to be disabled with a limit of 0")
+	}
+}
+
+func TestLimitQuerySamples(t *testing.T) {
+	// 500 distinct queries with runtimes 0..499ms in random order
+	var samples []state.PostgresQuerySample
+	for _, runtime := range rand.New(rand.NewSource(1)).Perm(500) {
+		samples = append(samples, state.PostgresQuerySample{Database: "mydb", Query: fmt.Sprintf("SELECT * FROM items_%d", runtime), RuntimeMs: float64(runtime)})
+	}
+
+	droppedBefore := logs.GetDroppedQuerySamples()
+	result := logs.LimitQuerySamples(samples, 50)
+
+	if len(result) != 50 {
+		t.Fatalf("Expected 50 samples to be kept, got %d", len(result))
+	}
+	if dropped := logs.GetDroppedQuerySamples() - droppedBefore; dropped != 450 {
+		t.Errorf("Expected 450 dropped samples to be counted, got %d", dropped)
+	}
+	var prevIdx = -1
+	for _, sample := range result {
+		if sample.RuntimeMs < 450 {
+			t.Errorf("Expected only the 50 slowest samples to be kept, got one with %.0fms", sample.RuntimeMs)
+		}
+		idx := -1
+		for i := range samples {
+			if samples[i].Query == sample.Query {
+				idx = i
+			}
+		}
+		if idx < prevIdx {
+			t.Errorf("Expected samples to be kept in their original order")
+		}
+		prevIdx = idx
+	}
+
+	if len(logs.LimitQuerySamples(samples, 0)) != len(samples) {
+		t.Errorf("Expected limit to be disabled with a limit of 0")
+	}
+}
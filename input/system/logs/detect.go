@@ -0,0 +1,50 @@
+package logs
+
+import "strings"
+
+// DetectLogLinePrefix - Infers which of the supported log_line_prefix settings
+// the given raw log lines were written with
+//
+// Confidence is the share of non-empty lines that matched the detected prefix,
+// lines that are continuations of a previous line reduce it somewhat. A
+// confidence of 0 means that none of the supported prefixes matched. Lines
+// received through syslog are reported as LogPrefixEmpty, since the syslog
+// format already contains the timestamp and PID.
+func DetectLogLinePrefix(lines []string) (prefix string, confidence float64) {
+	matches := make(map[string]int)
+	total := 0
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		total++
+
+		linePrefix, rsyslog := matchLogLinePrefix(line)
+
+		// Custom 3 and 4 have an optional part (%q) that is left out for non-session
+		// lines, so they also match lines written with the simple prefix
+		if (linePrefix == LogPrefixCustom3 || linePrefix == LogPrefixCustom4) && LogPrefixSimpleRegexp.MatchString(line) {
+			linePrefix = LogPrefixSimple
+		}
+		if linePrefix != "" || rsyslog {
+			matches[linePrefix]++
+		}
+	}
+
+	if total == 0 {
+		return
+	}
+
+	// Iterate in the order of SupportedPrefixes so ties are resolved consistently
+	bestCount := 0
+	for _, supportedPrefix := range SupportedPrefixes {
+		if matches[supportedPrefix] > bestCount {
+			prefix = supportedPrefix
+			bestCount = matches[supportedPrefix]
+		}
+	}
+
+	confidence = float64(bestCount) / float64(total)
+	return
+}
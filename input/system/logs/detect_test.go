@@ -0,0 +1,65 @@
+package logs_test
+
+import (
+	"testing"
+
+	"github.com/pganalyze/collector/input/system/logs"
+)
+
+var detectTests = []struct {
+	lines              []string
+	expectedPrefix     string
+	expectedConfidence float64
+}{
+	{
+		[]string{
+			"2018-09-28 07:37:59 UTC [331]: [1-1] user=[unknown],db=[unknown] - PG-00000 LOG:  connection received: host=127.0.0.1 port=49738",
+			"2018-09-28 07:39:48 UTC [347]: [3-1] user=postgres,db=postgres - PG-57014 ERROR:  canceling statement due to user request",
+		},
+		logs.LogPrefixCustom5,
+		1.0,
+	},
+	{
+		[]string{
+			"2018-05-04 03:06:18.360 UTC [3184] LOG:  pganalyze-collector-identify: server1",
+			"\tcontinued line",
+			"",
+			"2018-08-22 16:00:04 UTC:127.0.0.1(36404):myuser@mydb:[18762]:LOG:  duration: 3668.685 ms  execute <unnamed>: SELECT 1",
+			"2018-05-04 03:06:19.360 UTC [3184] LOG:  duration: 1.0 ms",
+		},
+		logs.LogPrefixSimple,
+		0.5,
+	},
+	{
+		[]string{
+			"Feb  1 21:48:31 ip-172-31-14-41 postgres[9076]: [3-1] LOG:  database system is ready to accept connections",
+		},
+		logs.LogPrefixEmpty,
+		1.0,
+	},
+	{
+		[]string{
+			"LOG:  database system is ready to accept connections",
+			"postgres: some unrelated output",
+		},
+		logs.LogPrefixEmpty,
+		0,
+	},
+	{
+		nil,
+		logs.LogPrefixEmpty,
+		0,
+	},
+}
+
+func TestDetectLogLinePrefix(t *testing.T) {
+	for idx, test := range detectTests {
+		prefix, confidence := logs.DetectLogLinePrefix(test.lines)
+		if prefix != test.expectedPrefix {
+			t.Errorf("Test %d: expected prefix '%s', got '%s'", idx, test.expectedPrefix, prefix)
+		}
+		if confidence != test.expectedConfidence {
+			t.Errorf("Test %d: expected confidence %f, got %f", idx, test.expectedConfidence, confidence)
+		}
+	}
+}
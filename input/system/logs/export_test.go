@@ -0,0 +1,47 @@
+package logs
+
+import (
+	"context"
+	"time"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// SetSendFuncs - Replaces how log grants are retrieved and logs get sent,
+// returns a function that restores the original behaviour
+func SetSendFuncs(grantFunc func(context.Context, state.Server, state.CollectionOpts, *util.Logger) (state.GrantLogs, error), uploadFunc func(context.Context, state.Server, state.GrantLogs, state.CollectionOpts, *util.Logger, state.LogState) error) func() {
+	prevGrantFunc, prevUploadFunc := getLogsGrant, uploadAndSendLogs
+	getLogsGrant, uploadAndSendLogs = grantFunc, uploadFunc
+	return func() {
+		getLogsGrant, uploadAndSendLogs = prevGrantFunc, prevUploadFunc
+	}
+}
+
+// ReceiveSyslogFrame - Passes a frame to the source, as if it was received
+// over the network at the given time
+func (s *SyslogLogSource) ReceiveSyslogFrame(frame string, receivedAt time.Time) {
+	s.now = func() time.Time { return receivedAt }
+	s.receive(frame)
+}
+
+// SetSyslogNow - Replaces the clock used to decide whether messages are complete
+func (s *SyslogLogSource) SetSyslogNow(now time.Time) {
+	s.now = func() time.Time { return now }
+}
+
+// SetClock - Replaces the clock used to decide whether log lines are ready,
+// returns a function that restores the original behaviour
+func SetClock(now func() time.Time) func() {
+	prevClock := clock
+	clock = now
+	return func() {
+		clock = prevClock
+	}
+}
+
+// SendBackoff - Returns how long sending waits after consecutive failures
+var SendBackoff = sendBackoff
+
+// WriteDeadLetter - Writes a batch that is about to be dropped to the dead-letter directory
+var WriteDeadLetter = writeDeadLetter
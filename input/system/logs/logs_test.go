@@ -2710,6 +2710,87 @@ var tests = []testpair{
 				"          Index Cond: (pgbench_branches.bid = 59)",
 		}},
 	},
+	// auto_explain followed by the statement duration of the same execution
+	{
+		[]state.LogLine{{
+			Content: "duration: 1.052 ms  plan:\n" +
+				"	{\n" +
+				"	  \"Query Text\": \"SELECT * FROM x WHERE y = $1 LIMIT $2\",\n" +
+				"	  \"Plan\": {\n" +
+				"	    \"Node Type\": \"Limit\",\n" +
+				"	    \"Startup Cost\": 0.00,\n" +
+				"	    \"Total Cost\": 0.04,\n" +
+				"	    \"Plan Rows\": 1,\n" +
+				"	    \"Plan Width\": 4\n" +
+				"	  }\n" +
+				"	}\n",
+			LogLevel: pganalyze_collector.LogLineInformation_LOG,
+			UUID:     uuid.UUID{1},
+		}, {
+			Content:  "duration: 1.173 ms execute <unnamed>: SELECT * FROM x WHERE y = $1 LIMIT $2",
+			LogLevel: pganalyze_collector.LogLineInformation_LOG,
+			UUID:     uuid.UUID{2},
+		}, {
+			Content:  "parameters: $1 = 'long string', $2 = '1'",
+			LogLevel: pganalyze_collector.LogLineInformation_DETAIL,
+		}},
+		[]state.LogLine{{
+			Query:          "SELECT * FROM x WHERE y = $1 LIMIT $2",
+			Classification: pganalyze_collector.LogLineInformation_STATEMENT_AUTO_EXPLAIN,
+			LogLevel:       pganalyze_collector.LogLineInformation_LOG,
+			UUID:           uuid.UUID{1},
+			Details:        map[string]interface{}{"duration_ms": 1.052},
+		}, {
+			Query:          "SELECT * FROM x WHERE y = $1 LIMIT $2",
+			Classification: pganalyze_collector.LogLineInformation_STATEMENT_DURATION,
+			LogLevel:       pganalyze_collector.LogLineInformation_LOG,
+			UUID:           uuid.UUID{2},
+			Details:        map[string]interface{}{"duration_ms": 1.173},
+		}, {
+			LogLevel:   pganalyze_collector.LogLineInformation_DETAIL,
+			ParentUUID: uuid.UUID{2},
+		}},
+		[]state.PostgresQuerySample{{
+			Query:         "SELECT * FROM x WHERE y = $1 LIMIT $2",
+			LogLineUUID:   uuid.UUID{1},
+			RuntimeMs:     1.052,
+			Parameters:    []string{"long string", "1"},
+			HasExplain:    true,
+			ExplainSource: pganalyze_collector.QuerySample_AUTO_EXPLAIN_EXPLAIN_SOURCE,
+			ExplainFormat: pganalyze_collector.QuerySample_JSON_EXPLAIN_FORMAT,
+			ExplainOutput: "[{\"Plan\":{\"Node Type\":\"Limit\",\"Plan Rows\":1,\"Plan Width\":4,\"Startup Cost\":0,\"Total Cost\":0.04}}]",
+		}},
+	},
+	// auto_explain of a different statement than the following duration line
+	{
+		[]state.LogLine{{
+			Content: "duration: 1681.452 ms  plan:\n" +
+				"  Query Text: UPDATE pgbench_branches SET bbalance = bbalance + 2656 WHERE bid = 59;\n" +
+				"  Update on public.pgbench_branches  (cost=0.27..8.29 rows=1 width=370) (actual rows=0 loops=1)",
+		}, {
+			Content: "duration: 3205.800 ms execute a2: SELECT 1",
+		}},
+		[]state.LogLine{{
+			Query:          "UPDATE pgbench_branches SET bbalance = bbalance + 2656 WHERE bid = 59;",
+			Classification: pganalyze_collector.LogLineInformation_STATEMENT_AUTO_EXPLAIN,
+			Details:        map[string]interface{}{"duration_ms": 1681.452},
+		}, {
+			Query:          "SELECT 1",
+			Classification: pganalyze_collector.LogLineInformation_STATEMENT_DURATION,
+			Details:        map[string]interface{}{"duration_ms": 3205.8},
+		}},
+		[]state.PostgresQuerySample{{
+			Query:         "UPDATE pgbench_branches SET bbalance = bbalance + 2656 WHERE bid = 59;",
+			RuntimeMs:     1681.452,
+			HasExplain:    true,
+			ExplainSource: pganalyze_collector.QuerySample_AUTO_EXPLAIN_EXPLAIN_SOURCE,
+			ExplainFormat: pganalyze_collector.QuerySample_TEXT_EXPLAIN_FORMAT,
+			ExplainOutput: "Update on public.pgbench_branches  (cost=0.27..8.29 rows=1 width=370) (actual rows=0 loops=1)",
+		}, {
+			Query:     "SELECT 1",
+			RuntimeMs: 3205.8,
+		}},
+	},
 	// pganalyze-collector-identify
 	{
 		[]state.LogLine{{
@@ -2755,3 +2836,72 @@ func TestAnalyzeLogLines(t *testing.T) {
 		}
 	}
 }
+
+func makeBenchmarkLogLines(count int) []state.LogLine {
+	logLines := make([]state.LogLine, 0, count)
+	for len(logLines) < count {
+		logLines = append(logLines, state.LogLine{
+			UUID:     uuid.NewV4(),
+			LogLevel: pganalyze_collector.LogLineInformation_LOG,
+			Content:  "duration: 3205.800 ms execute a2: SELECT \"servers\".* FROM \"servers\" WHERE \"servers\".\"id\" = 1 LIMIT 2",
+		}, state.LogLine{
+			UUID:     uuid.NewV4(),
+			LogLevel: pganalyze_collector.LogLineInformation_ERROR,
+			Content:  "relation \"x\" does not exist at character 15",
+		}, state.LogLine{
+			UUID:     uuid.NewV4(),
+			LogLevel: pganalyze_collector.LogLineInformation_STATEMENT,
+			Content:  "SELECT * FROM x;",
+		})
+	}
+	return logLines[:count]
+}
+
+func BenchmarkAnalyzeBackendLogLines(b *testing.B) {
+	logLines := makeBenchmarkLogLines(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logs.AnalyzeBackendLogLines(logLines)
+	}
+}
+
+func BenchmarkAnalyzeBackendLogLinesWithCallback(b *testing.B) {
+	logLines := makeBenchmarkLogLines(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logs.AnalyzeBackendLogLinesWithCallback(logLines, func(logLine state.LogLine) {}, func(sample state.PostgresQuerySample) {})
+	}
+}
+
+func TestAnalyzeLogLinesBackendOrder(t *testing.T) {
+	var logLinesIn []state.LogLine
+	for i := 0; i < 5; i++ {
+		for _, pid := range []int32{42, 7, 1234} {
+			logLinesIn = append(logLinesIn, state.LogLine{
+				BackendPid: pid,
+				LogLevel:   pganalyze_collector.LogLineInformation_LOG,
+				Content:    "connection received: host=127.0.0.1 port=5432",
+			})
+		}
+	}
+
+	var expected []int32
+	for _, pid := range []int32{42, 7, 1234} {
+		for i := 0; i < 5; i++ {
+			expected = append(expected, pid)
+		}
+	}
+
+	for run := 0; run < 20; run++ {
+		logLinesOut, _ := logs.AnalyzeLogLines(logLinesIn)
+		var actual []int32
+		for _, logLine := range logLinesOut {
+			actual = append(actual, logLine.BackendPid)
+		}
+		if diff := pretty.Compare(expected, actual); diff != "" {
+			t.Fatalf("Run %d: backend order diff: (-want +got)\n%s", run, diff)
+		}
+	}
+}
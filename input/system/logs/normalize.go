@@ -0,0 +1,41 @@
+package logs
+
+import (
+	"sync"
+
+	"github.com/pganalyze/collector/state"
+)
+
+// QuerySampleNormalizer - Post-processes the query text of a query sample, e.g.
+// to strip or rewrite structured comments added by the application
+type QuerySampleNormalizer func(query string) string
+
+var querySampleNormalizer QuerySampleNormalizer
+var querySampleNormalizerMutex sync.RWMutex
+
+// SetQuerySampleNormalizer - Registers a function that is applied to the query
+// text of all query samples found in the logs, passing nil restores the default
+// of leaving the text as it is
+//
+// The normalizer runs before filter_query_sample and max_query_sample_length
+// are applied, so it can't undo their redaction or truncation.
+func SetQuerySampleNormalizer(normalizer QuerySampleNormalizer) {
+	querySampleNormalizerMutex.Lock()
+	defer querySampleNormalizerMutex.Unlock()
+	querySampleNormalizer = normalizer
+}
+
+// NormalizeQuerySamples - Applies the registered normalizer (if any) to the query samples
+func NormalizeQuerySamples(samples []state.PostgresQuerySample) []state.PostgresQuerySample {
+	querySampleNormalizerMutex.RLock()
+	normalizer := querySampleNormalizer
+	querySampleNormalizerMutex.RUnlock()
+
+	if normalizer == nil {
+		return samples
+	}
+	for idx := range samples {
+		samples[idx].Query = normalizer(samples[idx].Query)
+	}
+	return samples
+}
@@ -72,6 +72,31 @@ func IsSupportedPrefix(prefix string) bool {
 	return false
 }
 
+// matchLogLinePrefix - Returns the supported prefix that matches the line, or
+// whether it's an rsyslog line (which doesn't use a prefix)
+func matchLogLinePrefix(line string) (prefix string, rsyslog bool) {
+	if LogPrefixAmazonRdsRegxp.MatchString(line) {
+		prefix = LogPrefixAmazonRds
+	} else if LogPrefixCustom1Regexp.MatchString(line) {
+		prefix = LogPrefixCustom1
+	} else if LogPrefixCustom2Regexp.MatchString(line) {
+		prefix = LogPrefixCustom2
+	} else if LogPrefixCustom4Regexp.MatchString(line) { // 4 is more specific than 3, so needs to go first
+		prefix = LogPrefixCustom4
+	} else if LogPrefixCustom3Regexp.MatchString(line) {
+		prefix = LogPrefixCustom3
+	} else if LogPrefixCustom5Regexp.MatchString(line) {
+		prefix = LogPrefixCustom5
+	} else if LogPrefixCustom6Regexp.MatchString(line) {
+		prefix = LogPrefixCustom6
+	} else if LogPrefixSimpleRegexp.MatchString(line) {
+		prefix = LogPrefixSimple
+	} else if RsyslogRegexp.MatchString(line) {
+		rsyslog = true
+	}
+	return
+}
+
 func ParseLogLineWithPrefix(prefix string, line string) (logLine state.LogLine, ok bool) {
 	var timePart, userPart, dbPart, appPart, pidPart, levelPart, contentPart string
 
@@ -82,25 +107,7 @@ func ParseLogLineWithPrefix(prefix string, line string) (logLine state.LogLine,
 	rsyslog := false
 
 	if prefix == "" {
-		if LogPrefixAmazonRdsRegxp.MatchString(line) {
-			prefix = LogPrefixAmazonRds
-		} else if LogPrefixCustom1Regexp.MatchString(line) {
-			prefix = LogPrefixCustom1
-		} else if LogPrefixCustom2Regexp.MatchString(line) {
-			prefix = LogPrefixCustom2
-		} else if LogPrefixCustom4Regexp.MatchString(line) { // 4 is more specific than 3, so needs to go first
-			prefix = LogPrefixCustom4
-		} else if LogPrefixCustom3Regexp.MatchString(line) {
-			prefix = LogPrefixCustom3
-		} else if LogPrefixCustom5Regexp.MatchString(line) {
-			prefix = LogPrefixCustom5
-		} else if LogPrefixCustom6Regexp.MatchString(line) {
-			prefix = LogPrefixCustom6
-		} else if LogPrefixSimpleRegexp.MatchString(line) {
-			prefix = LogPrefixSimple
-		} else if RsyslogRegexp.MatchString(line) {
-			rsyslog = true
-		}
+		prefix, rsyslog = matchLogLinePrefix(line)
 	}
 
 	if rsyslog {
@@ -243,6 +250,7 @@ func ParseLogLineWithPrefix(prefix string, line string) (logLine state.LogLine,
 			return
 		}
 	}
+	logLine.OccurredAtPrecision = TimestampPrecision(timePart)
 
 	if userPart != "[unknown]" {
 		logLine.Username = userPart
@@ -269,6 +277,34 @@ func ParseLogLineWithPrefix(prefix string, line string) (logLine state.LogLine,
 	return
 }
 
+// TimestampPrecision - Returns the resolution of a logged timestamp, based on
+// the number of fractional second digits (none for %t, three for %m)
+func TimestampPrecision(timePart string) time.Duration {
+	precision := time.Second
+	dot := strings.IndexByte(timePart, '.')
+	if dot == -1 {
+		return precision
+	}
+	for _, c := range timePart[dot+1:] {
+		if c < '0' || c > '9' || precision == time.Nanosecond {
+			break
+		}
+		precision /= 10
+	}
+	return precision
+}
+
+// PrefixTimestampPrecision - Returns the timestamp resolution of lines logged
+// with the given log_line_prefix (zero if it has no timestamp)
+func PrefixTimestampPrecision(prefix string) time.Duration {
+	if strings.Contains(prefix, "%m") {
+		return time.Millisecond
+	} else if strings.Contains(prefix, "%t") {
+		return time.Second
+	}
+	return 0
+}
+
 func ParseAndAnalyzeBuffer(buffer string, initialByteStart int64, linesNewerThan time.Time) ([]state.LogLine, []state.PostgresQuerySample, int64) {
 	var logLines []state.LogLine
 	currentByteStart := initialByteStart
@@ -300,7 +336,7 @@ func ParseAndAnalyzeBuffer(buffer string, initialByteStart int64, linesNewerThan
 		}
 
 		// Ignore loglines which are outside our time window
-		if logLine.OccurredAt.Before(linesNewerThan) {
+		if logLine.OccurredBefore(linesNewerThan) {
 			continue
 		}
 
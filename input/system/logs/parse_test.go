@@ -23,10 +23,11 @@ var parseTests = []parseTestpair{
 		"",
 		"Feb  1 21:48:31 ip-172-31-14-41 postgres[9076]: [3-1] LOG:  database system is ready to accept connections",
 		state.LogLine{
-			OccurredAt: time.Date(time.Now().Year(), time.February, 1, 21, 48, 31, 0, time.UTC),
-			LogLevel:   pganalyze_collector.LogLineInformation_LOG,
-			BackendPid: 9076,
-			Content:    "database system is ready to accept connections",
+			OccurredAt:          time.Date(time.Now().Year(), time.February, 1, 21, 48, 31, 0, time.UTC),
+			OccurredAtPrecision: time.Second,
+			LogLevel:            pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:          9076,
+			Content:             "database system is ready to accept connections",
 		},
 		true,
 	},
@@ -34,10 +35,11 @@ var parseTests = []parseTestpair{
 		"",
 		"Feb  1 21:48:31 ip-172-31-14-41 postgres[9076]: [3-2] #011 something",
 		state.LogLine{
-			OccurredAt: time.Date(time.Now().Year(), time.February, 1, 21, 48, 31, 0, time.UTC),
-			LogLevel:   pganalyze_collector.LogLineInformation_UNKNOWN,
-			BackendPid: 9076,
-			Content:    "\t something",
+			OccurredAt:          time.Date(time.Now().Year(), time.February, 1, 21, 48, 31, 0, time.UTC),
+			OccurredAtPrecision: time.Second,
+			LogLevel:            pganalyze_collector.LogLineInformation_UNKNOWN,
+			BackendPid:          9076,
+			Content:             "\t something",
 		},
 		false,
 	},
@@ -45,12 +47,13 @@ var parseTests = []parseTestpair{
 		"",
 		"Feb  1 21:48:31 ip-172-31-14-41 postgres[123]: [8-1] [user=postgres,db=postgres,app=[unknown]] LOG: connection received: host=[local]",
 		state.LogLine{
-			OccurredAt: time.Date(time.Now().Year(), time.February, 1, 21, 48, 31, 0, time.UTC),
-			LogLevel:   pganalyze_collector.LogLineInformation_LOG,
-			BackendPid: 123,
-			Username:   "postgres",
-			Database:   "postgres",
-			Content:    "connection received: host=[local]",
+			OccurredAt:          time.Date(time.Now().Year(), time.February, 1, 21, 48, 31, 0, time.UTC),
+			OccurredAtPrecision: time.Second,
+			LogLevel:            pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:          123,
+			Username:            "postgres",
+			Database:            "postgres",
+			Content:             "connection received: host=[local]",
 		},
 		true,
 	},
@@ -59,12 +62,13 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-08-22 16:00:04 UTC:ec2-1-1-1-1.compute-1.amazonaws.com(48808):myuser@mydb:[18762]:LOG:  duration: 3668.685 ms  execute <unnamed>: SELECT 1",
 		state.LogLine{
-			OccurredAt: time.Date(2018, time.August, 22, 16, 0, 4, 0, time.UTC),
-			Username:   "myuser",
-			Database:   "mydb",
-			LogLevel:   pganalyze_collector.LogLineInformation_LOG,
-			BackendPid: 18762,
-			Content:    "duration: 3668.685 ms  execute <unnamed>: SELECT 1",
+			OccurredAt:          time.Date(2018, time.August, 22, 16, 0, 4, 0, time.UTC),
+			OccurredAtPrecision: time.Second,
+			Username:            "myuser",
+			Database:            "mydb",
+			LogLevel:            pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:          18762,
+			Content:             "duration: 3668.685 ms  execute <unnamed>: SELECT 1",
 		},
 		true,
 	},
@@ -72,12 +76,13 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-08-22 16:00:03 UTC:127.0.0.1(36404):myuser@mydb:[21495]:LOG:  duration: 1630.946 ms  execute 3: SELECT 1",
 		state.LogLine{
-			OccurredAt: time.Date(2018, time.August, 22, 16, 0, 3, 0, time.UTC),
-			Username:   "myuser",
-			Database:   "mydb",
-			LogLevel:   pganalyze_collector.LogLineInformation_LOG,
-			BackendPid: 21495,
-			Content:    "duration: 1630.946 ms  execute 3: SELECT 1",
+			OccurredAt:          time.Date(2018, time.August, 22, 16, 0, 3, 0, time.UTC),
+			OccurredAtPrecision: time.Second,
+			Username:            "myuser",
+			Database:            "mydb",
+			LogLevel:            pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:          21495,
+			Content:             "duration: 1630.946 ms  execute 3: SELECT 1",
 		},
 		true,
 	},
@@ -85,12 +90,13 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-08-22 16:00:03 UTC:[local]:myuser@mydb:[21495]:LOG:  duration: 1630.946 ms  execute 3: SELECT 1",
 		state.LogLine{
-			OccurredAt: time.Date(2018, time.August, 22, 16, 0, 3, 0, time.UTC),
-			Username:   "myuser",
-			Database:   "mydb",
-			LogLevel:   pganalyze_collector.LogLineInformation_LOG,
-			BackendPid: 21495,
-			Content:    "duration: 1630.946 ms  execute 3: SELECT 1",
+			OccurredAt:          time.Date(2018, time.August, 22, 16, 0, 3, 0, time.UTC),
+			OccurredAtPrecision: time.Second,
+			Username:            "myuser",
+			Database:            "mydb",
+			LogLevel:            pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:          21495,
+			Content:             "duration: 1630.946 ms  execute 3: SELECT 1",
 		},
 		true,
 	},
@@ -99,10 +105,11 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-09-27 06:57:01.030 UTC [20194] [user=[unknown],db=[unknown],app=[unknown]] LOG:  connection received: host=[local]",
 		state.LogLine{
-			OccurredAt: time.Date(2018, time.September, 27, 6, 57, 1, 30*1000*1000, time.UTC),
-			LogLevel:   pganalyze_collector.LogLineInformation_LOG,
-			BackendPid: 20194,
-			Content:    "connection received: host=[local]",
+			OccurredAt:          time.Date(2018, time.September, 27, 6, 57, 1, 30*1000*1000, time.UTC),
+			OccurredAtPrecision: time.Millisecond,
+			LogLevel:            pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:          20194,
+			Content:             "connection received: host=[local]",
 		},
 		true,
 	},
@@ -110,13 +117,14 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-09-27 06:57:02.779 UTC [20194] [user=postgres,db=postgres,app=psql] ERROR:  canceling statement due to user request",
 		state.LogLine{
-			OccurredAt:  time.Date(2018, time.September, 27, 6, 57, 2, 779*1000*1000, time.UTC),
-			Username:    "postgres",
-			Database:    "postgres",
-			Application: "psql",
-			LogLevel:    pganalyze_collector.LogLineInformation_ERROR,
-			BackendPid:  20194,
-			Content:     "canceling statement due to user request",
+			OccurredAt:          time.Date(2018, time.September, 27, 6, 57, 2, 779*1000*1000, time.UTC),
+			OccurredAtPrecision: time.Millisecond,
+			Username:            "postgres",
+			Database:            "postgres",
+			Application:         "psql",
+			LogLevel:            pganalyze_collector.LogLineInformation_ERROR,
+			BackendPid:          20194,
+			Content:             "canceling statement due to user request",
 		},
 		true,
 	},
@@ -125,10 +133,11 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-09-27 06:57:01.030 UTC [20194] [user=[unknown],db=[unknown],app=[unknown],host=[local]] LOG:  connection received: host=[local]",
 		state.LogLine{
-			OccurredAt: time.Date(2018, time.September, 27, 6, 57, 1, 30*1000*1000, time.UTC),
-			LogLevel:   pganalyze_collector.LogLineInformation_LOG,
-			BackendPid: 20194,
-			Content:    "connection received: host=[local]",
+			OccurredAt:          time.Date(2018, time.September, 27, 6, 57, 1, 30*1000*1000, time.UTC),
+			OccurredAtPrecision: time.Millisecond,
+			LogLevel:            pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:          20194,
+			Content:             "connection received: host=[local]",
 		},
 		true,
 	},
@@ -136,13 +145,14 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-09-27 06:57:02.779 UTC [20194] [user=postgres,db=postgres,app=psql,host=127.0.0.1] ERROR:  canceling statement due to user request",
 		state.LogLine{
-			OccurredAt:  time.Date(2018, time.September, 27, 6, 57, 2, 779*1000*1000, time.UTC),
-			Username:    "postgres",
-			Database:    "postgres",
-			Application: "psql",
-			LogLevel:    pganalyze_collector.LogLineInformation_ERROR,
-			BackendPid:  20194,
-			Content:     "canceling statement due to user request",
+			OccurredAt:          time.Date(2018, time.September, 27, 6, 57, 2, 779*1000*1000, time.UTC),
+			OccurredAtPrecision: time.Millisecond,
+			Username:            "postgres",
+			Database:            "postgres",
+			Application:         "psql",
+			LogLevel:            pganalyze_collector.LogLineInformation_ERROR,
+			BackendPid:          20194,
+			Content:             "canceling statement due to user request",
 		},
 		true,
 	},
@@ -151,10 +161,11 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-09-28 07:37:59 UTC [331]: [1-1] user=[unknown],db=[unknown] - PG-00000 LOG:  connection received: host=127.0.0.1 port=49738",
 		state.LogLine{
-			OccurredAt: time.Date(2018, time.September, 28, 7, 37, 59, 0, time.UTC),
-			LogLevel:   pganalyze_collector.LogLineInformation_LOG,
-			BackendPid: 331,
-			Content:    "connection received: host=127.0.0.1 port=49738",
+			OccurredAt:          time.Date(2018, time.September, 28, 7, 37, 59, 0, time.UTC),
+			OccurredAtPrecision: time.Second,
+			LogLevel:            pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:          331,
+			Content:             "connection received: host=127.0.0.1 port=49738",
 		},
 		true,
 	},
@@ -162,12 +173,13 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-09-28 07:39:48 UTC [347]: [3-1] user=postgres,db=postgres - PG-57014 ERROR:  canceling statement due to user request",
 		state.LogLine{
-			OccurredAt: time.Date(2018, time.September, 28, 7, 39, 48, 0, time.UTC),
-			Username:   "postgres",
-			Database:   "postgres",
-			LogLevel:   pganalyze_collector.LogLineInformation_ERROR,
-			BackendPid: 347,
-			Content:    "canceling statement due to user request",
+			OccurredAt:          time.Date(2018, time.September, 28, 7, 39, 48, 0, time.UTC),
+			OccurredAtPrecision: time.Second,
+			Username:            "postgres",
+			Database:            "postgres",
+			LogLevel:            pganalyze_collector.LogLineInformation_ERROR,
+			BackendPid:          347,
+			Content:             "canceling statement due to user request",
 		},
 		true,
 	},
@@ -176,10 +188,11 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-10-16 01:25:58 UTC [93897]: [4-1] user=,db=,app=,client= LOG:  database system is ready to accept connections",
 		state.LogLine{
-			OccurredAt: time.Date(2018, time.October, 16, 1, 25, 58, 0, time.UTC),
-			LogLevel:   pganalyze_collector.LogLineInformation_LOG,
-			BackendPid: 93897,
-			Content:    "database system is ready to accept connections",
+			OccurredAt:          time.Date(2018, time.October, 16, 1, 25, 58, 0, time.UTC),
+			OccurredAtPrecision: time.Second,
+			LogLevel:            pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:          93897,
+			Content:             "database system is ready to accept connections",
 		},
 		true,
 	},
@@ -187,10 +200,11 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-10-16 01:26:09 UTC [93907]: [1-1] user=[unknown],db=[unknown],app=[unknown],client=::1 LOG:  connection received: host=::1 port=61349",
 		state.LogLine{
-			OccurredAt: time.Date(2018, time.October, 16, 1, 26, 9, 0, time.UTC),
-			LogLevel:   pganalyze_collector.LogLineInformation_LOG,
-			BackendPid: 93907,
-			Content:    "connection received: host=::1 port=61349",
+			OccurredAt:          time.Date(2018, time.October, 16, 1, 26, 9, 0, time.UTC),
+			OccurredAtPrecision: time.Second,
+			LogLevel:            pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:          93907,
+			Content:             "connection received: host=::1 port=61349",
 		},
 		true,
 	},
@@ -198,12 +212,13 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-10-16 01:26:33 UTC [93911]: [3-1] user=postgres,db=postgres,app=psql,client=::1 ERROR:  canceling statement due to user request",
 		state.LogLine{
-			OccurredAt: time.Date(2018, time.October, 16, 1, 26, 33, 0, time.UTC),
-			Username:   "postgres",
-			Database:   "postgres",
-			LogLevel:   pganalyze_collector.LogLineInformation_ERROR,
-			BackendPid: 93911,
-			Content:    "canceling statement due to user request",
+			OccurredAt:          time.Date(2018, time.October, 16, 1, 26, 33, 0, time.UTC),
+			OccurredAtPrecision: time.Second,
+			Username:            "postgres",
+			Database:            "postgres",
+			LogLevel:            pganalyze_collector.LogLineInformation_ERROR,
+			BackendPid:          93911,
+			Content:             "canceling statement due to user request",
 		},
 		true,
 	},
@@ -212,10 +227,11 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-05-04 03:06:18.360 UTC [3184] LOG:  pganalyze-collector-identify: server1",
 		state.LogLine{
-			OccurredAt: time.Date(2018, time.May, 4, 3, 6, 18, 360*1000*1000, time.UTC),
-			LogLevel:   pganalyze_collector.LogLineInformation_LOG,
-			BackendPid: 3184,
-			Content:    "pganalyze-collector-identify: server1",
+			OccurredAt:          time.Date(2018, time.May, 4, 3, 6, 18, 360*1000*1000, time.UTC),
+			OccurredAtPrecision: time.Millisecond,
+			LogLevel:            pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:          3184,
+			Content:             "pganalyze-collector-identify: server1",
 		},
 		true,
 	},
@@ -223,10 +239,11 @@ var parseTests = []parseTestpair{
 		"",
 		"2018-05-04 03:06:18.360 +0100 [3184] LOG:  pganalyze-collector-identify: server1",
 		state.LogLine{
-			OccurredAt: time.Date(2018, time.May, 4, 3, 6, 18, 360*1000*1000, time.FixedZone("+0100", 3600)),
-			LogLevel:   pganalyze_collector.LogLineInformation_LOG,
-			BackendPid: 3184,
-			Content:    "pganalyze-collector-identify: server1",
+			OccurredAt:          time.Date(2018, time.May, 4, 3, 6, 18, 360*1000*1000, time.FixedZone("+0100", 3600)),
+			OccurredAtPrecision: time.Millisecond,
+			LogLevel:            pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:          3184,
+			Content:             "pganalyze-collector-identify: server1",
 		},
 		true,
 	},
@@ -248,3 +265,63 @@ func TestParseLogLineWithPrefix(t *testing.T) {
 		}
 	}
 }
+
+type timestampPrecisionTestpair struct {
+	prefixIn       string
+	lineIn         string
+	precisionOut   time.Duration
+	linesNewerThan time.Time
+	olderOut       bool
+}
+
+var timestampPrecisionTests = []timestampPrecisionTestpair{
+	// %t - the line could have been logged any time during 07:37:59
+	{
+		logs.LogPrefixCustom5,
+		"2018-09-28 07:37:59 UTC [331]: [1-1] user=[unknown],db=[unknown] - PG-00000 LOG:  connection received: host=127.0.0.1 port=49738",
+		time.Second,
+		time.Date(2018, time.September, 28, 7, 37, 59, 500*1000*1000, time.UTC),
+		false,
+	},
+	{
+		logs.LogPrefixCustom5,
+		"2018-09-28 07:37:59 UTC [331]: [1-1] user=[unknown],db=[unknown] - PG-00000 LOG:  connection received: host=127.0.0.1 port=49738",
+		time.Second,
+		time.Date(2018, time.September, 28, 7, 38, 0, 0, time.UTC),
+		true,
+	},
+	// %m
+	{
+		logs.LogPrefixCustom3,
+		"2018-09-27 06:57:01.030 UTC [20194] [user=[unknown],db=[unknown],app=[unknown]] LOG:  connection received: host=[local]",
+		time.Millisecond,
+		time.Date(2018, time.September, 27, 6, 57, 1, 30*1000*1000+500*1000, time.UTC),
+		false,
+	},
+	{
+		logs.LogPrefixCustom3,
+		"2018-09-27 06:57:01.030 UTC [20194] [user=[unknown],db=[unknown],app=[unknown]] LOG:  connection received: host=[local]",
+		time.Millisecond,
+		time.Date(2018, time.September, 27, 6, 57, 1, 500*1000*1000, time.UTC),
+		true,
+	},
+}
+
+func TestTimestampPrecision(t *testing.T) {
+	for _, pair := range timestampPrecisionTests {
+		if precision := logs.PrefixTimestampPrecision(pair.prefixIn); precision != pair.precisionOut {
+			t.Errorf("For prefix \"%v\": expected precision %s, got %s\n", pair.prefixIn, pair.precisionOut, precision)
+		}
+
+		l, ok := logs.ParseLogLineWithPrefix(pair.prefixIn, pair.lineIn)
+		if !ok {
+			t.Fatalf("For \"%v\": expected parsing to succeed\n", pair.lineIn)
+		}
+		if l.OccurredAtPrecision != pair.precisionOut {
+			t.Errorf("For \"%v\": expected precision %s, got %s\n", pair.lineIn, pair.precisionOut, l.OccurredAtPrecision)
+		}
+		if older := l.OccurredBefore(pair.linesNewerThan); older != pair.olderOut {
+			t.Errorf("For \"%v\": expected line to be older than %s? to be %v, but was %v\n", pair.lineIn, pair.linesNewerThan, pair.olderOut, older)
+		}
+	}
+}
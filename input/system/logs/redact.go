@@ -0,0 +1,123 @@
+package logs
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	pg_query "github.com/lfittl/pg_query_go"
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// Used in place of queries that can't be parsed, and therefore not be normalized
+const unparseableQuerySample = "<truncated query>"
+
+// Used in place of parameters that are redacted by redact_query_parameters
+const redactedQueryParameter = "<redacted>"
+
+// shouldRedactQuerySamples - Whether literal values need to be removed from query
+// samples, unknown settings are treated like "normalize" to err on the safe side
+func shouldRedactQuerySamples(filterQuerySample string) bool {
+	return filterQuerySample != "" && filterQuerySample != "none"
+}
+
+// RedactQuerySamples - Removes literal values and parameters from query samples,
+// according to the filter_query_sample setting
+//
+// Samples of queries whose fingerprint is listed in raw_query_sample_fingerprints
+// are kept as they are.
+func RedactQuerySamples(samples []state.PostgresQuerySample, serverConfig config.ServerConfig) []state.PostgresQuerySample {
+	if !shouldRedactQuerySamples(serverConfig.FilterQuerySample) {
+		return samples
+	}
+
+	for idx, sample := range samples {
+		if isRawQuerySample(sample, serverConfig) {
+			continue
+		}
+		normalizedQuery, err := pg_query.Normalize(sample.Query)
+		if err != nil {
+			normalizedQuery = unparseableQuerySample
+		}
+		sample.Query = normalizedQuery
+		sample.Parameters = nil
+		samples[idx] = sample
+	}
+
+	return samples
+}
+
+// RedactQueryParametersByPosition - Replaces the parameters that are listed for
+// the fingerprint of the sample's query in redact_query_parameters, independent
+// of the filter_query_sample setting
+func RedactQueryParametersByPosition(samples []state.PostgresQuerySample, serverConfig config.ServerConfig) []state.PostgresQuerySample {
+	if len(serverConfig.RedactQueryParameters) == 0 {
+		return samples
+	}
+
+	for idx, sample := range samples {
+		if len(sample.Parameters) == 0 {
+			continue
+		}
+		positions := serverConfig.QueryParametersToRedact(QuerySampleFingerprint(sample))
+		if len(positions) == 0 {
+			continue
+		}
+		parameters := make([]string, len(sample.Parameters))
+		copy(parameters, sample.Parameters)
+		for _, position := range positions {
+			if position <= len(parameters) {
+				parameters[position-1] = redactedQueryParameter
+			}
+		}
+		samples[idx].Parameters = parameters
+	}
+
+	return samples
+}
+
+// isRawQuerySample - Whether the sample's query is exempt from redaction by raw_query_sample_fingerprints
+func isRawQuerySample(sample state.PostgresQuerySample, serverConfig config.ServerConfig) bool {
+	return len(serverConfig.RawQuerySampleFingerprints) > 0 && serverConfig.IsRawQuerySampleAllowed(QuerySampleFingerprint(sample))
+}
+
+// QuerySampleFingerprint - Returns the fingerprint of the sample's query in hex,
+// as used by redact_query_parameters
+func QuerySampleFingerprint(sample state.PostgresQuerySample) string {
+	fingerprint := util.FingerprintQuery(sample.Query)
+	return hex.EncodeToString(fingerprint[:])
+}
+
+// ValidateQuerySampleRedaction - Returns a description for each query sample that
+// still contains raw literal values or parameters, even though the
+// filter_query_sample setting requires them to be removed (samples exempted by
+// raw_query_sample_fingerprints are not checked)
+//
+// This needs to run before TruncateQuerySamples, since truncated query texts
+// can't be parsed anymore.
+func ValidateQuerySampleRedaction(samples []state.PostgresQuerySample, serverConfig config.ServerConfig) (problems []string) {
+	if !shouldRedactQuerySamples(serverConfig.FilterQuerySample) {
+		return
+	}
+
+	for _, sample := range samples {
+		if isRawQuerySample(sample, serverConfig) {
+			continue
+		}
+		if len(sample.Parameters) > 0 {
+			problems = append(problems, fmt.Sprintf("query sample for log line %s contains %d parameter values", sample.LogLineUUID, len(sample.Parameters)))
+		}
+		if sample.Query == unparseableQuerySample {
+			continue
+		}
+		normalizedQuery, err := pg_query.Normalize(sample.Query)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("query sample for log line %s could not be checked for literal values: %s", sample.LogLineUUID, err))
+		} else if normalizedQuery != sample.Query {
+			problems = append(problems, fmt.Sprintf("query sample for log line %s contains literal values", sample.LogLineUUID))
+		}
+	}
+
+	return
+}
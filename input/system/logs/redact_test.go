@@ -0,0 +1,137 @@
+package logs_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/input/system/logs"
+	"github.com/pganalyze/collector/state"
+)
+
+var redactTests = []struct {
+	filterQuerySample string
+	samplesIn         []state.PostgresQuerySample
+	samplesOut        []state.PostgresQuerySample
+}{
+	{
+		"none",
+		[]state.PostgresQuerySample{{Query: "SELECT * FROM x WHERE y = 'secret'", Parameters: []string{"1"}}},
+		[]state.PostgresQuerySample{{Query: "SELECT * FROM x WHERE y = 'secret'", Parameters: []string{"1"}}},
+	},
+	{
+		"normalize",
+		[]state.PostgresQuerySample{
+			{Query: "SELECT * FROM x WHERE y = 'secret' AND z = 42"},
+			{Query: "SELECT * FROM x WHERE y = $1 LIMIT $2", Parameters: []string{"'long string'", "'1'"}},
+			{Query: "SELECT * FROM x WHERE y = 'trunc"},
+		},
+		[]state.PostgresQuerySample{
+			{Query: "SELECT * FROM x WHERE y = $1 AND z = $2"},
+			{Query: "SELECT * FROM x WHERE y = $1 LIMIT $2"},
+			{Query: "<truncated query>"},
+		},
+	},
+}
+
+func TestRedactQuerySamples(t *testing.T) {
+	for _, test := range redactTests {
+		samples := logs.RedactQuerySamples(test.samplesIn, config.ServerConfig{FilterQuerySample: test.filterQuerySample})
+
+		cfg := pretty.CompareConfig
+		cfg.SkipZeroFields = true
+
+		if diff := cfg.Compare(test.samplesOut, samples); diff != "" {
+			t.Errorf("For %s: query samples diff: (-want +got)\n%s", test.filterQuerySample, diff)
+		}
+		if problems := logs.ValidateQuerySampleRedaction(samples, config.ServerConfig{FilterQuerySample: test.filterQuerySample}); len(problems) != 0 {
+			t.Errorf("For %s: expected redacted samples to pass validation, got: %v", test.filterQuerySample, problems)
+		}
+	}
+}
+
+func TestValidateQuerySampleRedaction(t *testing.T) {
+	samples := []state.PostgresQuerySample{
+		{Query: "SELECT * FROM x WHERE y = $1"},
+		{Query: "SELECT * FROM x WHERE y = 'secret'"},
+		{Query: "SELECT * FROM x WHERE y = $1", Parameters: []string{"'secret'"}},
+	}
+
+	if problems := logs.ValidateQuerySampleRedaction(samples, config.ServerConfig{FilterQuerySample: "none"}); len(problems) != 0 {
+		t.Errorf("Expected no problems without filtering, got: %v", problems)
+	}
+	if problems := logs.ValidateQuerySampleRedaction(samples, config.ServerConfig{FilterQuerySample: "normalize"}); len(problems) != 2 {
+		t.Errorf("Expected 2 problems, got: %v", problems)
+	}
+}
+
+func TestRedactQuerySamplesRawFingerprints(t *testing.T) {
+	rawQuery := "SELECT * FROM feature_flags WHERE name = 'new_checkout'"
+	serverConfig := config.ServerConfig{
+		FilterQuerySample: "normalize",
+		// Fingerprints are matched case-insensitively
+		RawQuerySampleFingerprints: []string{strings.ToUpper(logs.QuerySampleFingerprint(state.PostgresQuerySample{Query: rawQuery}))},
+	}
+
+	samples := []state.PostgresQuerySample{
+		{Query: rawQuery},
+		// Same fingerprint, since literal values don't matter for it
+		{Query: "SELECT * FROM feature_flags WHERE name = $1", Parameters: []string{"'old_checkout'"}},
+		{Query: "SELECT * FROM users WHERE email = 'jane@example.com'"},
+	}
+	expected := []state.PostgresQuerySample{
+		{Query: rawQuery},
+		{Query: "SELECT * FROM feature_flags WHERE name = $1", Parameters: []string{"'old_checkout'"}},
+		{Query: "SELECT * FROM users WHERE email = $1"},
+	}
+
+	samples = logs.RedactQuerySamples(samples, serverConfig)
+	if diff := pretty.Compare(expected, samples); diff != "" {
+		t.Errorf("Query samples diff: (-want +got)\n%s", diff)
+	}
+	if problems := logs.ValidateQuerySampleRedaction(samples, serverConfig); len(problems) != 0 {
+		t.Errorf("Expected allowlisted samples to pass validation, got: %v", problems)
+	}
+
+	// Without the allow list, the raw samples are reported
+	serverConfig.RawQuerySampleFingerprints = nil
+	if problems := logs.ValidateQuerySampleRedaction(samples, serverConfig); len(problems) != 2 {
+		t.Errorf("Expected 2 problems without the allow list, got: %v", problems)
+	}
+}
+
+func TestRedactQueryParametersByPosition(t *testing.T) {
+	ssnQuery := "SELECT * FROM users WHERE name = $1 AND ssn = $2"
+	otherQuery := "SELECT * FROM users WHERE id = $1"
+	serverConfig := config.ServerConfig{
+		RedactQueryParameters: map[string][]int{
+			logs.QuerySampleFingerprint(state.PostgresQuerySample{Query: ssnQuery}): {2, 5},
+		},
+	}
+
+	samples := []state.PostgresQuerySample{
+		{Query: ssnQuery, Parameters: []string{"'jane'", "'123-45-6789'"}},
+		// Same fingerprint, since literal values don't matter for it
+		{Query: "SELECT * FROM users WHERE name = 'x' AND ssn = $2", Parameters: []string{"'joe'", "'987-65-4321'"}},
+		{Query: otherQuery, Parameters: []string{"42", "'unused'"}},
+	}
+	expected := []state.PostgresQuerySample{
+		{Query: ssnQuery, Parameters: []string{"'jane'", "<redacted>"}},
+		{Query: "SELECT * FROM users WHERE name = 'x' AND ssn = $2", Parameters: []string{"'joe'", "<redacted>"}},
+		{Query: otherQuery, Parameters: []string{"42", "'unused'"}},
+	}
+
+	samples = logs.RedactQueryParametersByPosition(samples, serverConfig)
+	if diff := pretty.Compare(expected, samples); diff != "" {
+		t.Errorf("Query samples diff: (-want +got)\n%s", diff)
+	}
+
+	// Position-based redaction comes first, and filter_query_sample still removes everything else
+	samples = logs.RedactQuerySamples(samples, config.ServerConfig{FilterQuerySample: "normalize"})
+	for _, sample := range samples {
+		if len(sample.Parameters) != 0 {
+			t.Errorf("Expected normalize to remove all parameters, got %v", sample.Parameters)
+		}
+	}
+}
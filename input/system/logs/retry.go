@@ -0,0 +1,73 @@
+package logs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pganalyze/collector/grant"
+	"github.com/pganalyze/collector/output"
+	"github.com/pganalyze/collector/state"
+)
+
+// Indirections for sending logs, so they can be replaced in tests
+var getLogsGrant = grant.GetCachedLogsGrant
+var uploadAndSendLogs = output.UploadAndSendLogs
+
+// sendRetryState - Consecutive failed attempts at sending log lines of a server,
+// which determine how long to wait before the next attempt
+//
+// The retry budget is tracked separately for each batch of log lines (see
+// state.LogLine.SendFailures), so that new lines don't inherit the failures
+// of lines that were dropped before.
+type sendRetryState struct {
+	failures    int
+	nextAttempt time.Time
+}
+
+var sendRetries = make(map[string]*sendRetryState)
+var sendRetriesMutex sync.Mutex
+
+// shouldWaitBeforeSend - Whether we are still backing off from a previously failed send
+func shouldWaitBeforeSend(server state.Server, now time.Time) bool {
+	sendRetriesMutex.Lock()
+	defer sendRetriesMutex.Unlock()
+
+	retry, exists := sendRetries[server.Config.SectionName]
+	return exists && now.Before(retry.nextAttempt)
+}
+
+// The wait time doubles with each consecutive failure, up to this many times
+// (i.e. at most 64 times the base delay, which also avoids overflows)
+const maxSendBackoffExponent = 6
+
+// recordSendFailure - Schedules the next attempt using exponential backoff
+func recordSendFailure(server state.Server, globalCollectionOpts state.CollectionOpts, now time.Time) {
+	sendRetriesMutex.Lock()
+	defer sendRetriesMutex.Unlock()
+
+	retry, exists := sendRetries[server.Config.SectionName]
+	if !exists {
+		retry = &sendRetryState{}
+		sendRetries[server.Config.SectionName] = retry
+	}
+	retry.failures++
+
+	retry.nextAttempt = now.Add(sendBackoff(globalCollectionOpts.LogUploadRetryBaseDelay, retry.failures))
+}
+
+// sendBackoff - Returns how long to wait after the given number of consecutive failures
+func sendBackoff(baseDelay time.Duration, failures int) time.Duration {
+	exponent := failures - 1
+	if exponent > maxSendBackoffExponent {
+		exponent = maxSendBackoffExponent
+	}
+	return baseDelay * time.Duration(1<<uint(exponent))
+}
+
+// recordSendSuccess - Resets the retry budget after log lines were sent successfully
+func recordSendSuccess(server state.Server) {
+	sendRetriesMutex.Lock()
+	defer sendRetriesMutex.Unlock()
+
+	delete(sendRetries, server.Config.SectionName)
+}
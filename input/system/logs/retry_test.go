@@ -0,0 +1,391 @@
+package logs_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/input/system/logs"
+	"github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+type fakeUploader struct {
+	failuresLeft int
+	calls        int
+}
+
+func (u *fakeUploader) getGrant(ctx context.Context, server state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (state.GrantLogs, error) {
+	return state.GrantLogs{Valid: true}, nil
+}
+
+func (u *fakeUploader) upload(ctx context.Context, server state.Server, grant state.GrantLogs, globalCollectionOpts state.CollectionOpts, logger *util.Logger, logState state.LogState) error {
+	u.calls++
+	if u.failuresLeft > 0 {
+		u.failuresLeft--
+		return errors.New("upload failed")
+	}
+	return nil
+}
+
+func retryTestLogLines() []state.LogLine {
+	return []state.LogLine{{
+		CollectedAt: time.Now().Add(-1 * time.Minute),
+		LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+		BackendPid:  1,
+		Content:     "connection received: host=127.0.0.1 port=5432\n",
+	}}
+}
+
+var retryTests = []struct {
+	failures        int
+	maxRetries      int
+	expectedPending []int // Number of pending log lines returned after each call
+}{
+	{0, 3, []int{0}},
+	{2, 3, []int{1, 1, 0}},
+	{3, 3, []int{1, 1, 1, 0}},
+	{5, 2, []int{1, 1, 0}}, // Dropped after the second retry failed
+}
+
+func TestAnalyzeInGroupsAndSendRetries(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	for testIdx, test := range retryTests {
+		uploader := &fakeUploader{failuresLeft: test.failures}
+		restore := logs.SetSendFuncs(uploader.getGrant, uploader.upload)
+
+		server := state.Server{Config: config.ServerConfig{SectionName: "retry-test"}}
+		opts := state.CollectionOpts{LogUploadMaxRetries: test.maxRetries}
+
+		logLines := retryTestLogLines()
+		for callIdx, expected := range test.expectedPending {
+			logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+			if len(logLines) != expected {
+				t.Errorf("Test %d, call %d: expected %d pending log lines, got %d", testIdx, callIdx, expected, len(logLines))
+			}
+		}
+		if uploader.calls != len(test.expectedPending) {
+			t.Errorf("Test %d: expected %d upload attempts, got %d", testIdx, len(test.expectedPending), uploader.calls)
+		}
+
+		restore()
+	}
+}
+
+func TestAnalyzeInGroupsAndSendDeadLetter(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &fakeUploader{failuresLeft: 5}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	dir, err := ioutil.TempDir("", "dead-letter-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	deadLetterDir := filepath.Join(dir, "dropped")
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "dead/letter test", SystemID: "my-db"}}
+	opts := state.CollectionOpts{LogUploadMaxRetries: 1, LogDeadLetterDir: deadLetterDir, LogDeadLetterPlaintext: true}
+
+	logLines := logs.AnalyzeInGroupsAndSend(context.Background(), server, retryTestLogLines(), opts, logger, nil)
+	files, _ := filepath.Glob(filepath.Join(deadLetterDir, "*"))
+	if len(logLines) != 1 || len(files) != 0 {
+		t.Fatalf("Expected log lines to be kept for retry without dead-letter files, got %d lines and files %v", len(logLines), files)
+	}
+
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+	if len(logLines) != 0 {
+		t.Fatalf("Expected log lines to be dropped, got %d pending", len(logLines))
+	}
+
+	manifests, _ := filepath.Glob(filepath.Join(deadLetterDir, "dead_letter_test-*.json"))
+	if len(manifests) != 1 {
+		t.Fatalf("Expected one dead-letter manifest, got %v", manifests)
+	}
+	manifestJSON, err := ioutil.ReadFile(manifests[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest logs.DeadLetterManifest
+	err = json.Unmarshal(manifestJSON, &manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.SectionName != "dead/letter test" || manifest.SystemID != "my-db" || manifest.LogLines != 1 || manifest.Failures != 2 || manifest.LastError != "upload failed" {
+		t.Errorf("Unexpected dead-letter manifest: %+v", manifest)
+	}
+
+	content, err := ioutil.ReadFile(strings.TrimSuffix(manifests[0], ".json") + ".log")
+	if err != nil {
+		t.Fatalf("Expected dead-letter log file next to the manifest: %s", err)
+	}
+	if string(content) != "connection received: host=127.0.0.1 port=5432\n" || manifest.ByteSize != len(content) {
+		t.Errorf("Unexpected dead-letter log file content: %q", content)
+	}
+}
+
+func TestWriteDeadLetterEncrypted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dead-letter-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	grant := state.GrantLogs{Valid: true, EncryptionKey: state.GrantLogsEncryptionKey{
+		Plaintext:      base64.StdEncoding.EncodeToString(key),
+		CiphertextBlob: base64.StdEncoding.EncodeToString([]byte("encrypted key")),
+		KeyId:          "my-key",
+	}}
+	content := "connection received: host=127.0.0.1 port=5432\n"
+	logState := state.LogState{
+		LogFiles:     []state.LogFile{{LogLines: []state.LogLine{{Content: content}}, Content: []byte(content)}},
+		QuerySamples: []state.PostgresQuerySample{{Query: "SELECT secret FROM users"}},
+	}
+	server := state.Server{Config: config.ServerConfig{SectionName: "test"}}
+
+	err = logs.WriteDeadLetter(server, grant, logState, dir, false, 2, errors.New("upload failed"), time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	var manifests int
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "127.0.0.1") || strings.Contains(string(data), "secret") {
+			t.Errorf("Expected %s to not contain plaintext log data, got %q", file, data)
+		}
+		if !strings.HasSuffix(file, ".json") {
+			if !strings.HasSuffix(file, ".log.enc") && !strings.HasSuffix(file, ".samples.enc") {
+				t.Errorf("Expected encrypted dead-letter files only, got %s", file)
+			}
+			continue
+		}
+		manifests++
+		var manifest logs.DeadLetterManifest
+		err = json.Unmarshal(data, &manifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if manifest.Encryption["x-amz-meta-x-amz-key-v2"] != grant.EncryptionKey.CiphertextBlob || manifest.Encryption["x-amz-meta-x-amz-iv"] == "" {
+			t.Errorf("Expected the key envelope in the manifest, got %v", manifest.Encryption)
+		}
+	}
+	if manifests != 2 {
+		t.Errorf("Expected manifests for the log file and the query samples, got files %v", files)
+	}
+}
+
+func TestWriteDeadLetterWithoutKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dead-letter-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	content := "connection received: host=127.0.0.1 port=5432\n"
+	logState := state.LogState{LogFiles: []state.LogFile{{LogLines: []state.LogLine{{Content: content}}, Content: []byte(content)}}}
+	server := state.Server{Config: config.ServerConfig{SectionName: "test"}}
+
+	// Getting the grant failed, so there is no key to encrypt with
+	err = logs.WriteDeadLetter(server, state.GrantLogs{}, logState, dir, false, 2, errors.New("could not get grant"), time.Now())
+	if err == nil {
+		t.Errorf("Expected an error without an encryption key")
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*")); len(files) != 0 {
+		t.Errorf("Expected no dead-letter files to be written, got %v", files)
+	}
+
+	err = logs.WriteDeadLetter(server, state.GrantLogs{}, logState, dir, true, 2, errors.New("could not get grant"), time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.log")); len(files) != 1 {
+		t.Errorf("Expected a plaintext dead-letter file when explicitly allowed, got %v", files)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendBackoff(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &fakeUploader{failuresLeft: 1}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "backoff-test"}}
+	opts := state.CollectionOpts{LogUploadMaxRetries: 3, LogUploadRetryBaseDelay: time.Hour}
+
+	logLines := logs.AnalyzeInGroupsAndSend(context.Background(), server, retryTestLogLines(), opts, logger, nil)
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+	if len(logLines) != 1 {
+		t.Errorf("Expected log lines to be kept during backoff, got %d", len(logLines))
+	}
+	if uploader.calls != 1 {
+		t.Errorf("Expected no upload attempt during backoff, got %d attempts", uploader.calls)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendRetryBudgetPerBatch(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &fakeUploader{failuresLeft: 3}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "budget-test"}}
+	opts := state.CollectionOpts{LogUploadMaxRetries: 1}
+
+	logLines := logs.AnalyzeInGroupsAndSend(context.Background(), server, retryTestLogLines(), opts, logger, nil)
+
+	// Lines that arrive later don't share the used up budget of the earlier ones
+	logLines = append(logLines, state.LogLine{
+		CollectedAt: time.Now().Add(-1 * time.Minute),
+		LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+		BackendPid:  2,
+		Content:     "disconnection: session time: 0:00:01.000\n",
+	})
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+	if len(logLines) != 1 || logLines[0].BackendPid != 2 || logLines[0].SendFailures != 0 {
+		t.Fatalf("Expected only the first batch to be dropped, got pending: %+v", logLines)
+	}
+
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+	if len(logLines) != 1 || logLines[0].SendFailures != 1 {
+		t.Fatalf("Expected second batch to be kept for its first retry, got pending: %+v", logLines)
+	}
+
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+	if len(logLines) != 0 {
+		t.Errorf("Expected second batch to be sent on its retry, got %d pending", len(logLines))
+	}
+	if uploader.calls != 4 {
+		t.Errorf("Expected 4 upload attempts, got %d", uploader.calls)
+	}
+}
+
+func TestSendBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{7, 640 * time.Second},
+		{8, 640 * time.Second},
+		{100, 640 * time.Second}, // Capped instead of overflowing
+	}
+	for _, test := range tests {
+		if backoff := logs.SendBackoff(10*time.Second, test.failures); backoff != test.expected {
+			t.Errorf("After %d failures: expected backoff of %s, got %s", test.failures, test.expected, backoff)
+		}
+	}
+}
+
+// slowUploader - Blocks each upload until the context gets cancelled
+type slowUploader struct {
+	uploadStarted chan bool
+}
+
+func (u *slowUploader) getGrant(ctx context.Context, server state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (state.GrantLogs, error) {
+	return state.GrantLogs{Valid: true}, nil
+}
+
+func (u *slowUploader) upload(ctx context.Context, server state.Server, grant state.GrantLogs, globalCollectionOpts state.CollectionOpts, logger *util.Logger, logState state.LogState) error {
+	u.uploadStarted <- true
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Minute):
+		return nil
+	}
+}
+
+func TestAnalyzeInGroupsAndSendCancelled(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &slowUploader{uploadStarted: make(chan bool, 1)}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "cancel-test"}}
+	opts := state.CollectionOpts{LogUploadMaxRetries: 0} // Any recorded failure drops the log lines
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-uploader.uploadStarted
+		cancel()
+	}()
+
+	start := time.Now()
+	logLines := logs.AnalyzeInGroupsAndSend(ctx, server, retryTestLogLines(), opts, logger, nil)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected prompt return after cancellation, took %s", elapsed)
+	}
+	if len(logLines) != 1 {
+		t.Errorf("Expected cancelled log lines to be kept for retry, got %d", len(logLines))
+	}
+
+	// A cancelled send doesn't use up the retry budget, nor causes a backoff
+	retryUploader := &fakeUploader{}
+	defer logs.SetSendFuncs(retryUploader.getGrant, retryUploader.upload)()
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+	if len(logLines) != 0 || retryUploader.calls != 1 {
+		t.Errorf("Expected log lines to be sent on the next call, got %d pending after %d attempts", len(logLines), retryUploader.calls)
+	}
+}
+
+type rejectingUploader struct {
+	err error
+}
+
+func (u *rejectingUploader) getGrant(ctx context.Context, server state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (state.GrantLogs, error) {
+	grant, _ := server.LogsGrantCache.Get(time.Now())
+	return grant, nil
+}
+
+func (u *rejectingUploader) upload(ctx context.Context, server state.Server, grant state.GrantLogs, globalCollectionOpts state.CollectionOpts, logger *util.Logger, logState state.LogState) error {
+	return u.err
+}
+
+func TestAnalyzeInGroupsAndSendInvalidatesGrant(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	tests := []struct {
+		err                 error
+		expectedInvalidated bool
+	}{
+		{util.S3UploadError{Status: "403 Forbidden", StatusCode: 403}, true},
+		{util.S3UploadError{Status: "500 Internal Server Error", StatusCode: 500}, false},
+		{errors.New("upload failed"), false},
+	}
+
+	policy := base64.StdEncoding.EncodeToString([]byte(`{"expiration": "` + time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"}`))
+	s3 := state.GrantS3{S3Fields: map[string]string{"key": "${filename}", "policy": policy}}
+
+	for _, test := range tests {
+		uploader := &rejectingUploader{err: test.err}
+		restore := logs.SetSendFuncs(uploader.getGrant, uploader.upload)
+
+		server := state.Server{Config: config.ServerConfig{SectionName: "invalidate-test"}, LogsGrantCache: &state.GrantLogsCache{}}
+		server.LogsGrantCache.Set(state.GrantLogs{Valid: true, Logdata: s3, Snapshot: s3})
+		opts := state.CollectionOpts{LogUploadMaxRetries: 0}
+
+		logs.AnalyzeInGroupsAndSend(context.Background(), server, retryTestLogLines(), opts, logger, nil)
+
+		_, cached := server.LogsGrantCache.Get(time.Now())
+		if cached == test.expectedInvalidated {
+			t.Errorf("For upload error %q: expected grant to be invalidated: %v, but it was cached: %v", test.err, test.expectedInvalidated, cached)
+		}
+
+		restore()
+	}
+}
@@ -1,11 +1,14 @@
 package logs
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/pganalyze/collector/grant"
-	"github.com/pganalyze/collector/output"
 	"github.com/pganalyze/collector/output/pganalyze_collector"
 	"github.com/pganalyze/collector/state"
 	"github.com/pganalyze/collector/util"
@@ -13,141 +16,748 @@ import (
 )
 
 // AnalyzeInGroupsAndSend - Sends all log lines that are ready, and returns the one that are not ready yet
-func AnalyzeInGroupsAndSend(server state.Server, logLines []state.LogLine, globalCollectionOpts state.CollectionOpts, prefixedLogger *util.Logger, logTestSucceeded chan<- bool) []state.LogLine {
+//
+// Ready lines are sent in chunks of at most LogSendChunkMaxLines. When the
+// context gets cancelled before all chunks were sent, the lines of the remaining
+// chunks are returned, so they can be sent again later (e.g. after a restart).
+//
+// The returned lines are limited to the server's LogBufferMaxLines, so that
+// they can't grow without bounds while sending fails or is slow (or to
+// PausedLogBufferMaxLines while collection is paused).
+//
+// When collecting once, all lines are sent right away, since there is no later
+// run that would send the ones that are too fresh.
+func AnalyzeInGroupsAndSend(ctx context.Context, server state.Server, logLines []state.LogLine, globalCollectionOpts state.CollectionOpts, prefixedLogger *util.Logger, logTestSucceeded chan<- bool) []state.LogLine {
+	logLines = analyzeInGroupsAndSend(ctx, server, logLines, globalCollectionOpts, prefixedLogger, logTestSucceeded, globalCollectionOpts.CollectOnce)
+	return limitBufferedLogLines(server, logLines, globalCollectionOpts.Pause.Paused(prefixedLogger), prefixedLogger)
+}
+
+// Servers (by section name) that were already warned about future-dated log
+// lines during this run of the collector, since the clock skew persists and
+// would otherwise cause a warning every time logs get sent
+var futureTimestampWarned sync.Map
+
+// FlushTimeout - How long FlushAndSend waits for the log lines to be sent
+var FlushTimeout = 10 * time.Second
+
+// FlushAndSend - Sends all log lines, including the ones that would otherwise be
+// considered too fresh, in one final attempt (e.g. when shutting down)
+//
+// This ignores any wait for retrying a failed send, and gives up after
+// FlushTimeout. Returns the log lines that could not be sent.
+func FlushAndSend(server state.Server, logLines []state.LogLine, globalCollectionOpts state.CollectionOpts, prefixedLogger *util.Logger) []state.LogLine {
+	if len(logLines) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), FlushTimeout)
+	defer cancel()
+
+	return analyzeInGroupsAndSend(ctx, server, logLines, globalCollectionOpts, prefixedLogger, nil, true)
+}
+
+func analyzeInGroupsAndSend(ctx context.Context, server state.Server, logLines []state.LogLine, globalCollectionOpts state.CollectionOpts, prefixedLogger *util.Logger, logTestSucceeded chan<- bool, flush bool) []state.LogLine {
 	var readyLogLines []state.LogLine
 	var tooFreshLogLines []state.LogLine
 	var stitchedLogLines []state.LogLine
 
-	// Submit all logLines that are older than 3 seconds
+	// Submit all logLines that are older than the configured threshold (3 seconds by default)
 	var now time.Time
-	now = time.Now()
+	now = clock()
+
+	// Avoid reprocessing the log lines while we're waiting to retry a failed send,
+	// and keep them while collection is paused (see limitBufferedLogLines)
+	if ctx.Err() != nil || (!flush && shouldWaitBeforeSend(server, now)) || globalCollectionOpts.Pause.Paused(prefixedLogger) {
+		return logLines
+	}
+
+	stitchingStart := time.Now()
+
+	// Lines that claim to be collected in the future (e.g. due to clock skew with
+	// a log shipper) would never become ready - count their waiting time from
+	// now instead, which is kept when they are returned as too fresh
+	var futureLines int
+	var maxSkew time.Duration
+	for idx, logLine := range logLines {
+		if skew := logLine.CollectedAt.Sub(now); skew > 0 {
+			futureLines++
+			if skew > maxSkew {
+				maxSkew = skew
+			}
+			logLines[idx].CollectedAt = now
+		}
+	}
+	if futureLines > 0 {
+		if _, warned := futureTimestampWarned.LoadOrStore(server.Config.SectionName, true); warned {
+			prefixedLogger.PrintVerbose("Received %d log lines timestamped up to %s in the future", futureLines, maxSkew)
+		} else {
+			prefixedLogger.PrintWarning("Received %d log lines timestamped up to %s in the future - check the clock of the system sending the logs (only warning once)", futureLines, maxSkew)
+		}
+	}
+
+	logLines = filterBelowMinLevel(server, logLines)
 
 	// Always stitch together log lines ahead of time that are missing level and PID
 	// - this is mostly to support the output of the Postgres logging collector to files
+	var stitched, dropped int64
+	var unparseable []state.LogLine
 	for _, logLine := range logLines {
 		if logLine.LogLevel != pganalyze_collector.LogLineInformation_UNKNOWN || logLine.BackendPid != 0 {
 			stitchedLogLines = append(stitchedLogLines, logLine)
 		} else if len(stitchedLogLines) > 0 {
 			stitchedLogLines[len(stitchedLogLines)-1].Content += " " + logLine.Content
+			stitched++
+		} else {
+			unparseable = append(unparseable, logLine)
+			dropped++
 		}
 	}
+	atomic.AddInt64(&logLinesStitched, stitched)
+	atomic.AddInt64(&logLinesDropped, dropped)
+	recordUnparseableLogLines(server, unparseable, globalCollectionOpts.TestRun)
+	if dropped > 0 && float64(dropped)/float64(len(logLines)) > droppedLogLinesWarningThreshold {
+		prefixedLogger.PrintWarning("Dropped %d of %d log lines that could not be associated with a previous line - check that your log_line_prefix is supported", dropped, len(logLines))
+	}
 
+	// Follow-on lines (e.g. STATEMENT, HINT, DETAIL) are sent together with the
+	// line they belong to, even if they arrived later and are still too fresh by
+	// themselves. In case they never arrive (e.g. because the backend crashed),
+	// the line gets sent on its own once its waiting time is over.
+	backendLastLineReady := make(map[int32]bool)
 	for _, logLine := range stitchedLogLines {
-		// TODO: The intent here is to wait 3 seconds so we get follow-on log lines
-		// (e.g. STATEMENT, HINT, DETAIL). This doesn't actually work, since we don't
-		// peek into newer messages for these additional lines
-		if now.Sub(logLine.CollectedAt) > 3*time.Second {
+		ready := flush || logLineReady(logLine, now, server.Config.LogLinesReadyAfter)
+		if isFollowOnLogLevel(logLine.LogLevel) && backendLastLineReady[logLine.BackendPid] {
+			ready = true
+		}
+		backendLastLineReady[logLine.BackendPid] = ready
+
+		if ready {
 			readyLogLines = append(readyLogLines, logLine)
 		} else {
 			tooFreshLogLines = append(tooFreshLogLines, logLine)
 		}
 	}
 
+	recordLogProcessingDuration(server, logPhaseStitching, time.Since(stitchingStart))
+
 	if len(readyLogLines) == 0 {
 		return tooFreshLogLines
 	}
 
-	// Setup temporary file that will be used for encryption
-	var logFile state.LogFile
-	var err error
-	logFile.UUID = uuid.NewV4()
-	logFile.TmpFile, err = ioutil.TempFile("", "")
-	if err != nil {
-		prefixedLogger.PrintError("Could not allocate tempfile for logs: %s", err)
-		return logLines
+	// Ready lines are analyzed and sent in chunks, so that the analyzed lines,
+	// tempfiles and query samples of a large batch are never all kept in memory
+	chunks := chunkLogLines(readyLogLines, LogSendChunkMaxLines)
+	for idx, chunk := range chunks {
+		switch analyzeAndSendChunk(ctx, server, chunk, globalCollectionOpts, prefixedLogger, logTestSucceeded) {
+		case chunkRetry:
+			return pendingLogLines(chunks[idx:], tooFreshLogLines)
+		case chunkDropped:
+			return pendingLogLines(chunks[idx+1:], tooFreshLogLines)
+		}
 	}
 
-	logState := state.LogState{CollectedAt: time.Now()}
+	return tooFreshLogLines
+}
+
+// LogSendChunkMaxLines - How many ready log lines are analyzed and sent together
+// at most (0 means no limit)
+var LogSendChunkMaxLines = 10000
 
-	currentByteStart := int64(0)
-	for idx, logLine := range readyLogLines {
-		_, err = logFile.TmpFile.WriteString(logLine.Content)
-		if err != nil {
-			prefixedLogger.PrintError("%s", err)
-			break
+// chunkOutcome - What happened to a chunk of log lines that was meant to be sent
+type chunkOutcome int
+
+const (
+	chunkDone    chunkOutcome = iota // Sent, or intentionally not sent (e.g. in a test run)
+	chunkRetry                       // Not sent, and needs to be retried later
+	chunkDropped                     // Sending failed too often, so the lines were given up on
+)
+
+// chunkLogLines - Splits the log lines into chunks of about maxLines each (0
+// means no limit), keeping the original order of the lines within a chunk
+//
+// The lines of a backend are only split before a line that starts a new
+// statement, since continuation and follow-on lines need to be analyzed
+// together with the line they belong to.
+//
+// Lines that failed to send a different number of times go into separate
+// chunks (the ones that failed most often first), so each chunk has a single
+// retry budget.
+func chunkLogLines(logLines []state.LogLine, maxLines int) [][]state.LogLine {
+	var chunkIdxs [][]int
+	var failureCounts []int
+	currentIdxs := make(map[int][]int)
+	backendPids, backendLogLineIdxs := groupLogLineIdxsByBackend(logLines)
+	for _, backendPid := range backendPids {
+		failures := 0
+		for idx, lineIdx := range backendLogLineIdxs[backendPid] {
+			logLevel := logLines[lineIdx].LogLevel
+			startsStatement := idx == 0 || (logLevel != pganalyze_collector.LogLineInformation_UNKNOWN && !isFollowOnLogLevel(logLevel))
+			if startsStatement {
+				failures = logLines[lineIdx].SendFailures
+			}
+			if _, exists := currentIdxs[failures]; !exists {
+				failureCounts = append(failureCounts, failures)
+			}
+			if maxLines > 0 && len(currentIdxs[failures]) >= maxLines && startsStatement {
+				chunkIdxs = append(chunkIdxs, currentIdxs[failures])
+				currentIdxs[failures] = nil
+			}
+			currentIdxs[failures] = append(currentIdxs[failures], lineIdx)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(failureCounts)))
+	for _, failures := range failureCounts {
+		if len(currentIdxs[failures]) > 0 {
+			chunkIdxs = append(chunkIdxs, currentIdxs[failures])
 		}
-		logLine.ByteStart = currentByteStart
-		logLine.ByteContentStart = currentByteStart
-		logLine.ByteEnd = currentByteStart + int64(len(logLine.Content)) - 1
-		readyLogLines[idx] = logLine
-		currentByteStart += int64(len(logLine.Content))
 	}
 
-	// Ensure that log lines that span multiple lines are already concated together before passing them to analyze
-	// Split log lines by backend to ensure we have the right context
-	backendLogLines := make(map[int32][]state.LogLine)
+	chunks := make([][]state.LogLine, len(chunkIdxs))
+	for chunkIdx, lineIdxs := range chunkIdxs {
+		sort.Ints(lineIdxs)
+		for _, lineIdx := range lineIdxs {
+			chunks[chunkIdx] = append(chunks[chunkIdx], logLines[lineIdx])
+		}
+	}
+	return chunks
+}
 
-	for _, logLine := range readyLogLines {
-		backendLogLines[logLine.BackendPid] = append(backendLogLines[logLine.BackendPid], logLine)
+// pendingLogLines - Returns the lines of the chunks that were not sent, followed
+// by the lines that are not ready yet
+func pendingLogLines(chunks [][]state.LogLine, tooFreshLogLines []state.LogLine) []state.LogLine {
+	var logLines []state.LogLine
+	for _, chunk := range chunks {
+		logLines = append(logLines, chunk...)
 	}
+	return append(logLines, tooFreshLogLines...)
+}
+
+// analyzeAndSendChunk - Analyzes the ready log lines, writes them to tempfiles
+// and sends them, together with the query samples found in them
+func analyzeAndSendChunk(ctx context.Context, server state.Server, readyLogLines []state.LogLine, globalCollectionOpts state.CollectionOpts, prefixedLogger *util.Logger, logTestSucceeded chan<- bool) chunkOutcome {
+	analysisStart := time.Now()
+	logState := state.LogState{CollectedAt: clock()}
+
+	// Lines are analyzed before they are written to the tempfiles, so that lines
+	// with a denied classification can be left out. For each analyzed line we keep
+	// the ready lines it was made of (the line itself, and its continuation lines).
+	var analyzedLogLines []state.LogLine
+	var analyzedReadyIdxs [][]int
+	deniedUUIDs := make(map[uuid.UUID]bool)
+
+	// Log tails keep the copy of the server they were started with, so the grant
+	// of the last full snapshot is taken from the shared one
+	snapshotGrant := server.CurrentGrant()
+
+	// Ensure that log lines that span multiple lines are already concated together before passing them to analyze
+	// Split log lines by backend to ensure we have the right context
+	backendPids, backendLogLineIdxs := groupLogLineIdxsByBackend(readyLogLines)
 
-	for _, logLines := range backendLogLines {
+	for _, backendPid := range backendPids {
 		var analyzableLogLines []state.LogLine
-		for _, logLine := range logLines {
+		var analyzableReadyIdxs [][]int
+		for _, lineIdx := range backendLogLineIdxs[backendPid] {
+			logLine := readyLogLines[lineIdx]
 			if logLine.LogLevel != pganalyze_collector.LogLineInformation_UNKNOWN {
 				analyzableLogLines = append(analyzableLogLines, logLine)
+				analyzableReadyIdxs = append(analyzableReadyIdxs, []int{lineIdx})
 			} else if len(analyzableLogLines) > 0 {
 				analyzableLogLines[len(analyzableLogLines)-1].Content += logLine.Content
-				analyzableLogLines[len(analyzableLogLines)-1].ByteEnd += int64(len(logLine.Content))
+				analyzableReadyIdxs[len(analyzableReadyIdxs)-1] = append(analyzableReadyIdxs[len(analyzableReadyIdxs)-1], lineIdx)
+				atomic.AddInt64(&logLinesStitched, 1)
+			} else {
+				atomic.AddInt64(&logLinesDropped, 1)
 			}
 		}
 
-		backendLogLinesOut, backendSamples := AnalyzeBackendLogLines(analyzableLogLines)
-		for _, logLine := range backendLogLinesOut {
-			logFile.LogLines = append(logFile.LogLines, logLine)
+		// Each analyzable line results in exactly one output line, in the same order,
+		// and the query samples found in a line are passed on right before it
+		outIdx := 0
+		var lineSamples []state.PostgresQuerySample
+		AnalyzeBackendLogLinesWithCallback(analyzableLogLines, func(logLine state.LogLine) {
+			readyIdxs := analyzableReadyIdxs[outIdx]
+			outIdx++
+
+			// Follow-on lines (e.g. DETAIL) are left out together with the line they belong to
+			if isClassificationDenied(server, snapshotGrant, logLine) || isApplicationNameDenied(server, logLine, globalCollectionOpts) ||
+				(logLine.ParentUUID != uuid.Nil && deniedUUIDs[logLine.ParentUUID]) {
+				if logLine.UUID != uuid.Nil {
+					deniedUUIDs[logLine.UUID] = true
+				}
+				atomic.AddInt64(&logLinesFiltered, 1)
+				lineSamples = nil
+				return
+			}
+
+			analyzedLogLines = append(analyzedLogLines, logLine)
+			analyzedReadyIdxs = append(analyzedReadyIdxs, readyIdxs)
+			lineSamples = RedactQueryParametersByPosition(lineSamples, server.Config)
+			logState.QuerySamples = append(logState.QuerySamples, NormalizeQuerySamples(lineSamples)...)
+			lineSamples = nil
+		}, func(sample state.PostgresQuerySample) {
+			if snapshotGrant.CollectQuerySamples() {
+				lineSamples = append(lineSamples, sample)
+			}
+		})
+	}
+
+	// The ready lines of each analyzed line are written right after each other
+	// (in the order the analyzed lines started), since lines of other backends
+	// can be in between them - this way the byte offsets of a stitched line
+	// cover exactly its content in the tempfile
+	analyzedOrder := make([]int, len(analyzedLogLines))
+	for idx := range analyzedOrder {
+		analyzedOrder[idx] = idx
+	}
+	sort.SliceStable(analyzedOrder, func(i, j int) bool {
+		return analyzedReadyIdxs[analyzedOrder[i]][0] < analyzedReadyIdxs[analyzedOrder[j]][0]
+	})
+
+	var writtenLogLines []state.LogLine
+	writtenFirstIdxs := make([]int, len(analyzedLogLines))
+	for _, analyzedIdx := range analyzedOrder {
+		writtenFirstIdxs[analyzedIdx] = len(writtenLogLines)
+		for _, readyIdx := range analyzedReadyIdxs[analyzedIdx] {
+			writtenLogLines = append(writtenLogLines, readyLogLines[readyIdx])
 		}
-		for _, sample := range backendSamples {
-			logState.QuerySamples = append(logState.QuerySamples, sample)
+	}
+
+	// Setup temporary files that will be used for encryption
+	logFiles, logLineFileIdxs, err := writeLogFiles(ctx, writtenLogLines, server.Config.LogTempfileMaxBytes, globalCollectionOpts.LogTempDir, prefixedLogger)
+
+	// Removes the tempfiles in all cases below, including when there is nothing to send
+	defer func() {
+		err := state.LogState{LogFiles: logFiles}.Cleanup()
+		if err != nil {
+			prefixedLogger.PrintError("Could not remove log tempfile: %s", err)
+		}
+	}()
+
+	if ctx.Err() != nil {
+		return chunkRetry
+	}
+	if err != nil {
+		prefixedLogger.PrintError("Could not write tempfile for logs: %s", err)
+		return chunkRetry
+	}
+
+	for _, analyzedIdx := range analyzedOrder {
+		logLine := analyzedLogLines[analyzedIdx]
+		firstIdx := writtenFirstIdxs[analyzedIdx]
+		lastIdx := firstIdx + len(analyzedReadyIdxs[analyzedIdx]) - 1
+		logLine.ByteStart = writtenLogLines[firstIdx].ByteStart
+		logLine.ByteContentStart = writtenLogLines[firstIdx].ByteContentStart
+		logLine.ByteEnd = writtenLogLines[lastIdx].ByteEnd
+		fileIdx := logLineFileIdxs[firstIdx]
+		logFiles[fileIdx].LogLines = append(logFiles[fileIdx].LogLines, logLine)
+	}
+
+	logState.QuerySamples = DeduplicateQuerySamples(logState.QuerySamples, server.Config.MaxQuerySamplesPerFingerprint)
+	logState.QuerySamples = LimitQuerySamples(logState.QuerySamples, server.Config.MaxQuerySamplesPerSnapshot)
+	logState.QuerySamples = RedactQuerySamples(logState.QuerySamples, server.Config)
+
+	// The redaction check needs the complete query texts, since truncated ones
+	// can't be parsed anymore
+	var redactionProblems []string
+	if globalCollectionOpts.TestRun {
+		redactionProblems = ValidateQuerySampleRedaction(logState.QuerySamples, server.Config)
+	}
+	logState.QuerySamples = TruncateQuerySamples(logState.QuerySamples, server.Config.MaxQuerySampleLength)
+
+	recordLogProcessingDuration(server, logPhaseAnalysis, time.Since(analysisStart))
+
+	for _, logFile := range logFiles {
+		if len(logFile.LogLines) > 0 {
+			logState.LogFiles = append(logState.LogFiles, logFile)
 		}
 	}
 
 	// Nothing to send, so just skip getting the grant and other work
-	if len(logFile.LogLines) == 0 && len(logState.QuerySamples) == 0 {
-		logState.Cleanup()
-		return tooFreshLogLines
+	if len(logState.LogFiles) == 0 && len(logState.QuerySamples) == 0 {
+		return chunkDone
 	}
 
-	logState.LogFiles = []state.LogFile{logFile}
+	// Also covers the debug and test output that replaces the upload in those modes
+	uploadStart := time.Now()
+	defer func() {
+		recordLogProcessingDuration(server, logPhaseUpload, time.Since(uploadStart))
+	}()
 
 	if globalCollectionOpts.DebugLogs {
 		prefixedLogger.PrintInfo("Would have sent log state:\n")
-		content, _ := ioutil.ReadFile(logFile.TmpFile.Name())
-		PrintDebugInfo(string(content), logFile.LogLines, logState.QuerySamples)
-		logState.Cleanup()
-		return tooFreshLogLines
+		for idx, logFile := range logState.LogFiles {
+			// Query samples are not associated with a particular file, so only print them once
+			var querySamples []state.PostgresQuerySample
+			if idx == 0 {
+				querySamples = logState.QuerySamples
+			}
+			content, _ := logFile.ReadContent()
+			PrintDebugInfo(string(content), logFile.LogLines, querySamples)
+		}
+		return chunkDone
 	}
 
 	if globalCollectionOpts.TestRun {
-		for _, logLine := range logFile.LogLines {
-			if logLine.Classification == pganalyze_collector.LogLineInformation_PGA_COLLECTOR_IDENTIFY &&
-				logLine.Details["config_section"] == server.Config.SectionName {
-				logTestSucceeded <- true
+		if len(redactionProblems) > 0 {
+			for _, problem := range redactionProblems {
+				prefixedLogger.PrintError("Query sample redaction check failed: %s", problem)
+			}
+			if logTestSucceeded != nil {
+				select {
+				case logTestSucceeded <- false:
+				default:
+				}
 			}
+			return chunkDone
 		}
-		logState.Cleanup()
-		return tooFreshLogLines
+		for _, logFile := range logState.LogFiles {
+			for _, logLine := range logFile.LogLines {
+				if logLine.Classification == pganalyze_collector.LogLineInformation_PGA_COLLECTOR_IDENTIFY &&
+					logLine.Details["config_section"] == server.Config.SectionName {
+					logTestSucceeded <- true
+				}
+			}
+		}
+		return chunkDone
 	}
 
-	grant, err := grant.GetLogsGrant(server, globalCollectionOpts, prefixedLogger)
+	grant, err := getLogsGrant(ctx, server, globalCollectionOpts, prefixedLogger)
+	if ctx.Err() != nil {
+		prefixedLogger.PrintVerbose("Log sending cancelled, keeping %d log lines", len(readyLogLines))
+		return chunkRetry
+	}
 	if err != nil {
 		prefixedLogger.PrintError("Could not get log grant: %s", err)
-		logState.Cleanup()
-		return logLines // Retry
+		return retryOrDropChunk(server, state.GrantLogs{}, readyLogLines, logState, err, globalCollectionOpts, prefixedLogger)
 	}
 
 	if !grant.Valid {
 		prefixedLogger.PrintVerbose("Log collection disabled from server, skipping")
-		logState.Cleanup()
-		return tooFreshLogLines
+		recordSendSuccess(server)
+		return chunkDone
 	}
 
-	err = output.UploadAndSendLogs(server, grant, globalCollectionOpts, prefixedLogger, logState)
+	err = uploadAndSendLogs(ctx, server, grant, globalCollectionOpts, prefixedLogger, logState)
+	if ctx.Err() != nil {
+		prefixedLogger.PrintVerbose("Log sending cancelled, keeping %d log lines", len(readyLogLines))
+		return chunkRetry
+	}
 	if err != nil {
 		prefixedLogger.PrintError("Failed to upload/send logs: %s", err)
-		logState.Cleanup()
-		return logLines // Retry
+		if util.IsUploadForbidden(err) {
+			server.LogsGrantCache.Invalidate()
+		}
+		return retryOrDropChunk(server, grant, readyLogLines, logState, err, globalCollectionOpts, prefixedLogger)
 	}
 
-	logState.Cleanup()
-	return tooFreshLogLines
+	recordSendSuccess(server)
+	return chunkDone
+}
+
+// minReadyCheckInterval - Keeps receivers from checking for ready lines in a
+// tight loop when lines are sent right away (log_lines_ready_after = 0)
+const minReadyCheckInterval = 100 * time.Millisecond
+
+// ReadyCheckInterval - How often receivers should pass the lines they hold on
+// to AnalyzeInGroupsAndSend again, so they get sent soon after becoming ready
+func ReadyCheckInterval(server state.Server) time.Duration {
+	if server.Config.LogLinesReadyAfter < minReadyCheckInterval {
+		return minReadyCheckInterval
+	}
+	return server.Config.LogLinesReadyAfter
+}
+
+// logLineReady - Whether a line has waited long enough for related lines to
+// arrive. OccurredAt is truncated to its precision, so a line logged with %t
+// may have occurred up to a second later than its timestamp - lines from a
+// second that hadn't ended yet when they were collected wait until it is over.
+// Without a waiting time lines are always sent right away.
+func logLineReady(logLine state.LogLine, now time.Time, readyAfter time.Duration) bool {
+	readySince := logLine.CollectedAt
+	if readyAfter > 0 && logLine.OccurredAtPrecision > 0 && !logLine.OccurredAt.IsZero() {
+		// Bounded by the precision, so clock skew between the database server
+		// and the collector can't hold back lines for longer than that
+		occurredBy := logLine.OccurredAt.Add(logLine.OccurredAtPrecision)
+		if occurredBy.After(readySince) {
+			readySince = occurredBy
+		}
+		if maxReadySince := logLine.CollectedAt.Add(logLine.OccurredAtPrecision); readySince.After(maxReadySince) {
+			readySince = maxReadySince
+		}
+	}
+	return now.Sub(readySince) > readyAfter
+}
+
+// filterBelowMinLevel - Leaves out log lines below the server's minimum level,
+// together with the follow-on and continuation lines that belong to them
+//
+// Continuation lines without a PID belong to the line right before them, all
+// others to the last line of their backend.
+func filterBelowMinLevel(server state.Server, logLines []state.LogLine) []state.LogLine {
+	if server.Config.LogMinLevel == 0 {
+		return logLines
+	}
+
+	var keptLogLines []state.LogLine
+	var filtered int64
+	backendLastLineFiltered := make(map[int32]bool)
+	prevLineFiltered := false
+	for _, logLine := range logLines {
+		var filter bool
+		if logLine.LogLevel == pganalyze_collector.LogLineInformation_UNKNOWN && logLine.BackendPid == 0 {
+			filter = prevLineFiltered
+		} else if logLine.LogLevel == pganalyze_collector.LogLineInformation_UNKNOWN || isFollowOnLogLevel(logLine.LogLevel) {
+			filter = backendLastLineFiltered[logLine.BackendPid]
+		} else {
+			filter = server.Config.IsBelowLogMinLevel(int32(logLine.LogLevel))
+			backendLastLineFiltered[logLine.BackendPid] = filter
+		}
+		prevLineFiltered = filter
+
+		if filter {
+			filtered++
+		} else {
+			keptLogLines = append(keptLogLines, logLine)
+		}
+	}
+	atomic.AddInt64(&logLinesFiltered, filtered)
+
+	return keptLogLines
+}
+
+// isClassificationDenied - Whether the line's classification may not be sent
+//
+// An allow list in the grant replaces the locally configured deny list, so that
+// pganalyze can control which lines get sent during rollouts. Follow-on lines
+// are sent if the line they belong to is, and the collector's identify lines
+// are always kept, since they are needed to verify that logs are received from
+// the right server.
+func isClassificationDenied(server state.Server, grant state.Grant, logLine state.LogLine) bool {
+	if len(grant.Config.Features.LogClassificationAllowList) > 0 {
+		if logLine.ParentUUID != uuid.Nil || logLine.Classification == pganalyze_collector.LogLineInformation_PGA_COLLECTOR_IDENTIFY {
+			return false
+		}
+		return !grant.IsLogClassificationAllowed(int32(logLine.Classification))
+	}
+	return server.Config.IsLogClassificationDenied(int32(logLine.Classification))
+}
+
+// isApplicationNameDenied - Whether the line was logged by a backend whose
+// application name is denied, which always includes the collector itself
+//
+// The collector's identify lines are kept, since they are needed to verify
+// that logs are received from the right server.
+func isApplicationNameDenied(server state.Server, logLine state.LogLine, globalCollectionOpts state.CollectionOpts) bool {
+	if logLine.Application == "" || logLine.Classification == pganalyze_collector.LogLineInformation_PGA_COLLECTOR_IDENTIFY {
+		return false
+	}
+	return logLine.Application == globalCollectionOpts.CollectorApplicationName || server.Config.IsLogApplicationNameDenied(logLine.Application)
+}
+
+// FilterDownloadedLogFiles - Leaves out the lines of downloaded log files that
+// are below the server's minimum level, or whose classification or application
+// name is denied, the same way as for log lines that are streamed
+//
+// The content of files with filtered lines is rewritten to only contain the
+// remaining lines, so that it doesn't get sent either. Query samples that were
+// found in filtered lines are left out as well.
+func FilterDownloadedLogFiles(server state.Server, logFiles []state.LogFile, querySamples []state.PostgresQuerySample, globalCollectionOpts state.CollectionOpts) ([]state.LogFile, []state.PostgresQuerySample, error) {
+	grant := server.CurrentGrant()
+	filteredUUIDs := make(map[uuid.UUID]bool)
+
+	for idx, logFile := range logFiles {
+		keptByLevel := make(map[uuid.UUID]bool)
+		for _, logLine := range filterBelowMinLevel(server, logFile.LogLines) {
+			keptByLevel[logLine.UUID] = true
+		}
+
+		// Follow-on lines (e.g. DETAIL) are left out together with the line they belong to
+		var keptLogLines []state.LogLine
+		for _, logLine := range logFile.LogLines {
+			if !keptByLevel[logLine.UUID] {
+				filteredUUIDs[logLine.UUID] = true
+				continue
+			}
+			if isClassificationDenied(server, grant, logLine) || isApplicationNameDenied(server, logLine, globalCollectionOpts) ||
+				(logLine.ParentUUID != uuid.Nil && filteredUUIDs[logLine.ParentUUID]) {
+				filteredUUIDs[logLine.UUID] = true
+				atomic.AddInt64(&logLinesFiltered, 1)
+				continue
+			}
+			keptLogLines = append(keptLogLines, logLine)
+		}
+		if len(keptLogLines) == len(logFile.LogLines) {
+			continue
+		}
+
+		var err error
+		logFiles[idx], err = rewriteLogFile(logFile, keptLogLines)
+		if err != nil {
+			return logFiles, querySamples, err
+		}
+	}
+
+	if len(filteredUUIDs) == 0 {
+		return logFiles, querySamples, nil
+	}
+	var keptQuerySamples []state.PostgresQuerySample
+	for _, sample := range querySamples {
+		if sample.LogLineUUID == uuid.Nil || !filteredUUIDs[sample.LogLineUUID] {
+			keptQuerySamples = append(keptQuerySamples, sample)
+		}
+	}
+	return logFiles, keptQuerySamples, nil
+}
+
+// rewriteLogFile - Replaces the content of the log file with the content of the
+// given lines (in the order they appeared in the file), and updates their offsets
+func rewriteLogFile(logFile state.LogFile, logLines []state.LogLine) (state.LogFile, error) {
+	content, err := logFile.ReadContent()
+	if err != nil {
+		return logFile, err
+	}
+
+	sort.SliceStable(logLines, func(i, j int) bool {
+		return logLines[i].ByteStart < logLines[j].ByteStart
+	})
+
+	var newContent []byte
+	for idx, logLine := range logLines {
+		if logLine.ByteStart < 0 || logLine.ByteEnd < logLine.ByteStart || logLine.ByteEnd >= int64(len(content)) {
+			return logFile, fmt.Errorf("log line offsets %d-%d are outside of the file (%d bytes)", logLine.ByteStart, logLine.ByteEnd, len(content))
+		}
+		byteStart := int64(len(newContent))
+		newContent = append(newContent, content[logLine.ByteStart:logLine.ByteEnd+1]...)
+		logLine.ByteContentStart = byteStart + (logLine.ByteContentStart - logLine.ByteStart)
+		logLine.ByteEnd = byteStart + (logLine.ByteEnd - logLine.ByteStart)
+		logLine.ByteStart = byteStart
+		logLines[idx] = logLine
+	}
+
+	if logFile.TmpFile != nil {
+		if err = logFile.TmpFile.Truncate(0); err != nil {
+			return logFile, err
+		}
+		if _, err = logFile.TmpFile.WriteAt(newContent, 0); err != nil {
+			return logFile, err
+		}
+	} else {
+		logFile.Content = newContent
+	}
+	logFile.LogLines = logLines
+	return logFile, nil
+}
+
+// retryOrDropChunk - Decides whether the lines of a chunk that could not be sent
+// get retried later, or dropped since the retry budget is used up
+//
+// Lines that get retried have their failure count updated in place. Dropped
+// lines are written to the dead-letter directory, if one is configured, and
+// encrypted with the key of the grant (if getting one succeeded).
+func retryOrDropChunk(server state.Server, grant state.GrantLogs, chunk []state.LogLine, logState state.LogState, sendErr error, globalCollectionOpts state.CollectionOpts, prefixedLogger *util.Logger) chunkOutcome {
+	now := clock()
+	recordSendFailure(server, globalCollectionOpts, now)
+
+	failures := 0
+	for _, logLine := range chunk {
+		if logLine.SendFailures > failures {
+			failures = logLine.SendFailures
+		}
+	}
+	failures++
+
+	if failures <= globalCollectionOpts.LogUploadMaxRetries {
+		for idx := range chunk {
+			chunk[idx].SendFailures = failures
+		}
+		return chunkRetry
+	}
+
+	prefixedLogger.PrintError("Dropping %d log lines after %d failed attempts to send them", len(chunk), failures)
+	if globalCollectionOpts.LogDeadLetterDir != "" {
+		err := writeDeadLetter(server, grant, logState, globalCollectionOpts.LogDeadLetterDir, globalCollectionOpts.LogDeadLetterPlaintext, failures, sendErr, now)
+		if err != nil {
+			prefixedLogger.PrintError("Could not write dropped log lines to dead-letter directory: %s", err)
+		} else {
+			prefixedLogger.PrintInfo("Wrote dropped log lines to dead-letter directory %s", globalCollectionOpts.LogDeadLetterDir)
+		}
+	}
+	return chunkDropped
+}
+
+// InMemoryLogFilesMaxBytes - How much log content may be kept in memory at
+// most for a single send, when no tempfiles can be created
+var InMemoryLogFilesMaxBytes int64 = 10 * 1024 * 1024
+
+// Whether log files are currently kept in memory, so the degraded mode only
+// gets logged once (and again once tempfiles can be created)
+var inMemoryLogFiles int32
+
+// writeLogFiles - Writes the content of the log lines to tempfiles, starting a
+// new file whenever maxFileSize would be exceeded (0 means no limit), and sets
+// the byte offsets of each line relative to the file it was written to
+//
+// Tempfiles are created in tempDir, or the OS temp directory if empty. If a
+// tempfile can't be created (e.g. because the directory is not writable), the
+// content is kept in memory instead, as long as all log lines together don't
+// exceed InMemoryLogFilesMaxBytes - otherwise the error is returned.
+//
+// Returns the index of the file each line was written to. Continuation lines
+// (without a log level) always stay in the file of the line they belong to.
+func writeLogFiles(ctx context.Context, logLines []state.LogLine, maxFileSize int64, tempDir string, logger *util.Logger) (logFiles []state.LogFile, logLineFileIdxs []int, err error) {
+	var logFile *state.LogFile
+	currentByteStart := int64(0)
+
+	for idx, logLine := range logLines {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		contentSize := int64(len(logLine.Content))
+		rollover := maxFileSize > 0 && currentByteStart > 0 && currentByteStart+contentSize > maxFileSize &&
+			logLine.LogLevel != pganalyze_collector.LogLineInformation_UNKNOWN
+		if logFile == nil || rollover {
+			newLogFile := state.LogFile{UUID: uuid.NewV4()}
+			newLogFile.TmpFile, err = ioutil.TempFile(tempDir, "")
+			if err != nil {
+				if totalLogLinesSize(logLines) > InMemoryLogFilesMaxBytes {
+					return
+				}
+				if atomic.CompareAndSwapInt32(&inMemoryLogFiles, 0, 1) {
+					logger.PrintWarning("Could not create tempfile for logs, keeping log files in memory instead: %s", err)
+				}
+				newLogFile.TmpFile = nil
+				err = nil
+			} else if atomic.CompareAndSwapInt32(&inMemoryLogFiles, 1, 0) {
+				logger.PrintInfo("Log tempfiles can be created again, no longer keeping log files in memory")
+			}
+			logFiles = append(logFiles, newLogFile)
+			logFile = &logFiles[len(logFiles)-1]
+			currentByteStart = 0
+		}
+
+		if logFile.TmpFile != nil {
+			_, err = logFile.TmpFile.WriteString(logLine.Content)
+			if err != nil {
+				return
+			}
+		} else {
+			logFile.Content = append(logFile.Content, logLine.Content...)
+		}
+		logLine.ByteStart = currentByteStart
+		logLine.ByteContentStart = currentByteStart
+		logLine.ByteEnd = currentByteStart + contentSize - 1
+		logLines[idx] = logLine
+		logLineFileIdxs = append(logLineFileIdxs, len(logFiles)-1)
+		currentByteStart += contentSize
+	}
+
+	return
+}
+
+func totalLogLinesSize(logLines []state.LogLine) (size int64) {
+	for _, logLine := range logLines {
+		size += int64(len(logLine.Content))
+	}
+	return
 }
@@ -0,0 +1,1305 @@
+package logs_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/input/system/logs"
+	"github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+	uuid "github.com/satori/go.uuid"
+)
+
+var readyAfterTests = []struct {
+	readyAfter       time.Duration
+	collectedAgo     []time.Duration
+	expectedTooFresh int
+}{
+	{
+		0,
+		[]time.Duration{5 * time.Second, 1 * time.Second, 10 * time.Millisecond},
+		0,
+	},
+	{
+		10 * time.Second,
+		[]time.Duration{15 * time.Second, 9 * time.Second, 1 * time.Second},
+		2,
+	},
+	{
+		10 * time.Second,
+		[]time.Duration{1 * time.Second, 2 * time.Second},
+		2,
+	},
+}
+
+func TestAnalyzeInGroupsAndSendReadyAfter(t *testing.T) {
+	logger := &util.Logger{}
+	opts := state.CollectionOpts{TestRun: true}
+
+	for _, test := range readyAfterTests {
+		server := state.Server{Config: config.ServerConfig{LogLinesReadyAfter: test.readyAfter}}
+
+		var logLines []state.LogLine
+		now := time.Now()
+		for idx, ago := range test.collectedAgo {
+			logLines = append(logLines, state.LogLine{
+				CollectedAt: now.Add(-ago),
+				LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+				BackendPid:  int32(idx + 1),
+				Content:     "connection received: host=127.0.0.1 port=5432\n",
+			})
+		}
+
+		tooFresh := logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+		if len(tooFresh) != test.expectedTooFresh {
+			t.Errorf("For ready after %s: expected %d too fresh log lines, got %d", test.readyAfter, test.expectedTooFresh, len(tooFresh))
+		}
+		for _, logLine := range tooFresh {
+			if now.Sub(logLine.CollectedAt) > test.readyAfter {
+				t.Errorf("For ready after %s: log line collected %s ago was incorrectly held back", test.readyAfter, now.Sub(logLine.CollectedAt))
+			}
+		}
+	}
+}
+
+type followOnTestLine struct {
+	collectedAgo time.Duration
+	backendPid   int32
+	logLevel     pganalyze_collector.LogLineInformation_LogLevel
+}
+
+var followOnTests = []struct {
+	logLines         []followOnTestLine
+	expectedTooFresh []int32
+}{
+	// STATEMENT arrived later than its ERROR line, and gets sent together with it
+	{
+		[]followOnTestLine{
+			{5 * time.Second, 1, pganalyze_collector.LogLineInformation_ERROR},
+			{2 * time.Second, 2, pganalyze_collector.LogLineInformation_LOG},
+			{1 * time.Second, 1, pganalyze_collector.LogLineInformation_STATEMENT},
+			{1 * time.Second, 1, pganalyze_collector.LogLineInformation_HINT},
+		},
+		[]int32{2},
+	},
+	// Follow-on lines of a line that is still too fresh are held back as well
+	{
+		[]followOnTestLine{
+			{5 * time.Second, 1, pganalyze_collector.LogLineInformation_LOG},
+			{2 * time.Second, 1, pganalyze_collector.LogLineInformation_ERROR},
+			{1 * time.Second, 1, pganalyze_collector.LogLineInformation_STATEMENT},
+		},
+		[]int32{1, 1},
+	},
+	// Follow-on lines never arrived (e.g. backend crashed), send the line by itself
+	{
+		[]followOnTestLine{
+			{5 * time.Second, 1, pganalyze_collector.LogLineInformation_ERROR},
+		},
+		[]int32{},
+	},
+}
+
+func TestAnalyzeInGroupsAndSendFollowOnLines(t *testing.T) {
+	logger := &util.Logger{}
+	opts := state.CollectionOpts{TestRun: true}
+	server := state.Server{Config: config.ServerConfig{LogLinesReadyAfter: 3 * time.Second}}
+
+	for testIdx, test := range followOnTests {
+		var logLines []state.LogLine
+		now := time.Now()
+		for _, line := range test.logLines {
+			logLines = append(logLines, state.LogLine{
+				CollectedAt: now.Add(-line.collectedAgo),
+				LogLevel:    line.logLevel,
+				BackendPid:  line.backendPid,
+				Content:     "content\n",
+			})
+		}
+
+		tooFresh := logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+		if len(tooFresh) != len(test.expectedTooFresh) {
+			t.Errorf("Test %d: expected %d too fresh log lines, got %d", testIdx, len(test.expectedTooFresh), len(tooFresh))
+			continue
+		}
+		for idx, logLine := range tooFresh {
+			if logLine.BackendPid != test.expectedTooFresh[idx] {
+				t.Errorf("Test %d: expected too fresh log line %d to be from PID %d, got %d", testIdx, idx, test.expectedTooFresh[idx], logLine.BackendPid)
+			}
+		}
+	}
+}
+
+func TestAnalyzeInGroupsAndSendDroppedLines(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	opts := state.CollectionOpts{TestRun: true}
+	server := state.Server{}
+
+	logLines := []state.LogLine{
+		{LogLevel: pganalyze_collector.LogLineInformation_UNKNOWN, Content: "leading line without a previous line\n"},
+		{LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 1, Content: "connection received: host=127.0.0.1 port=5432\n"},
+		{LogLevel: pganalyze_collector.LogLineInformation_UNKNOWN, Content: "continued\n"},
+	}
+
+	stitchedBefore, droppedBefore := logs.GetStitchingStats()
+	logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+	stitchedAfter, droppedAfter := logs.GetStitchingStats()
+
+	if droppedAfter-droppedBefore != 1 {
+		t.Errorf("Expected 1 dropped log line, got %d", droppedAfter-droppedBefore)
+	}
+	if stitchedAfter-stitchedBefore != 1 {
+		t.Errorf("Expected 1 stitched log line, got %d", stitchedAfter-stitchedBefore)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendUnparseableLines(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	server := state.Server{Config: config.ServerConfig{SectionName: "unparseable"}}
+
+	logLines := []state.LogLine{
+		{LogLevel: pganalyze_collector.LogLineInformation_UNKNOWN, Content: "\x00\x01garbage\n"},
+		{LogLevel: pganalyze_collector.LogLineInformation_UNKNOWN, Content: "user=app password=hunter2 name='Jane Doe'\n"},
+		{LogLevel: pganalyze_collector.LogLineInformation_UNKNOWN, Content: strings.Repeat("x", 500) + "\n"},
+		{LogLevel: pganalyze_collector.LogLineInformation_UNKNOWN, Content: strings.Repeat("y", 180) + " 'secret value that crosses the sample length'\n"},
+		{LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 1, Content: "connection received: host=127.0.0.1 port=5432\n"},
+		{LogLevel: pganalyze_collector.LogLineInformation_UNKNOWN, Content: "continued\n"},
+	}
+
+	// Samples are only kept during test runs
+	unparseableBefore := logs.GetUnparseableLogLines()
+	logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{DebugLogs: true}, logger, nil)
+	if count := logs.GetUnparseableLogLines() - unparseableBefore; count != 4 {
+		t.Errorf("Expected 4 unparseable log lines, got %d", count)
+	}
+	unparseable := logs.TakeUnparseableLogLines(server)
+	if unparseable.Count != 4 || len(unparseable.Samples) != 0 {
+		t.Errorf("Expected 4 unparseable lines without samples, got %+v", unparseable)
+	}
+
+	logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{TestRun: true}, logger, nil)
+	unparseable = logs.TakeUnparseableLogLines(server)
+	expected := logs.UnparseableLogLines{
+		Count: 4,
+		Samples: []string{
+			"\x00\x01garbage",
+			"user=app password=[redacted] name='<redacted>'",
+			strings.Repeat("x", 200) + "...",
+			strings.Repeat("y", 180) + " '<redacted>'",
+		},
+	}
+	if diff := pretty.Compare(unparseable, expected); diff != "" {
+		t.Errorf("Unexpected unparseable log lines (-got +want):\n%s", diff)
+	}
+
+	if unparseable = logs.TakeUnparseableLogLines(server); unparseable.Count != 0 {
+		t.Errorf("Expected unparseable log lines to be reset after taking them, got %+v", unparseable)
+	}
+}
+
+type capturingUploader struct {
+	calls           int
+	linesPerFile    []int
+	bytesPerFile    []int
+	samples         int
+	tmpFileNames    []string
+	queries         []string
+	runtimes        []float64
+	classifications []pganalyze_collector.LogLineInformation_LogClassification
+	snippets        []string // Content of the tempfile at the byte offsets of each line
+}
+
+func (u *capturingUploader) getGrant(ctx context.Context, server state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (state.GrantLogs, error) {
+	return state.GrantLogs{Valid: true}, nil
+}
+
+func (u *capturingUploader) upload(ctx context.Context, server state.Server, grant state.GrantLogs, globalCollectionOpts state.CollectionOpts, logger *util.Logger, logState state.LogState) error {
+	u.calls++
+	for _, logFile := range logState.LogFiles {
+		content, _ := logFile.ReadContent()
+		if logFile.TmpFile != nil {
+			u.tmpFileNames = append(u.tmpFileNames, logFile.TmpFile.Name())
+		}
+		u.linesPerFile = append(u.linesPerFile, len(logFile.LogLines))
+		u.bytesPerFile = append(u.bytesPerFile, len(content))
+		for _, logLine := range logFile.LogLines {
+			u.classifications = append(u.classifications, logLine.Classification)
+			u.snippets = append(u.snippets, string(content[logLine.ByteStart:logLine.ByteEnd+1]))
+		}
+	}
+	u.samples += len(logState.QuerySamples)
+	for _, sample := range logState.QuerySamples {
+		u.queries = append(u.queries, sample.Query)
+		u.runtimes = append(u.runtimes, sample.RuntimeMs)
+	}
+	return nil
+}
+
+var rolloverTests = []struct {
+	maxBytes             int64
+	levels               []pganalyze_collector.LogLineInformation_LogLevel
+	expectedLinesPerFile []int
+	expectedBytesPerFile []int
+}{
+	// No limit
+	{
+		0,
+		[]pganalyze_collector.LogLineInformation_LogLevel{pganalyze_collector.LogLineInformation_LOG, pganalyze_collector.LogLineInformation_LOG, pganalyze_collector.LogLineInformation_LOG},
+		[]int{3},
+		[]int{30},
+	},
+	// Exactly two lines fit into each file
+	{
+		20,
+		[]pganalyze_collector.LogLineInformation_LogLevel{pganalyze_collector.LogLineInformation_LOG, pganalyze_collector.LogLineInformation_LOG, pganalyze_collector.LogLineInformation_LOG, pganalyze_collector.LogLineInformation_LOG, pganalyze_collector.LogLineInformation_LOG},
+		[]int{2, 2, 1},
+		[]int{20, 20, 10},
+	},
+	// One byte less, and only one line fits
+	{
+		19,
+		[]pganalyze_collector.LogLineInformation_LogLevel{pganalyze_collector.LogLineInformation_LOG, pganalyze_collector.LogLineInformation_LOG, pganalyze_collector.LogLineInformation_LOG},
+		[]int{1, 1, 1},
+		[]int{10, 10, 10},
+	},
+	// Continuation lines stay with their line, even if that exceeds the limit
+	{
+		20,
+		[]pganalyze_collector.LogLineInformation_LogLevel{pganalyze_collector.LogLineInformation_LOG, pganalyze_collector.LogLineInformation_LOG, pganalyze_collector.LogLineInformation_UNKNOWN, pganalyze_collector.LogLineInformation_LOG},
+		[]int{2, 1},
+		[]int{30, 10},
+	},
+}
+
+func TestAnalyzeInGroupsAndSendRollover(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	for testIdx, test := range rolloverTests {
+		uploader := &capturingUploader{}
+		restore := logs.SetSendFuncs(uploader.getGrant, uploader.upload)
+
+		server := state.Server{Config: config.ServerConfig{SectionName: "rollover-test", LogTempfileMaxBytes: test.maxBytes}}
+
+		var logLines []state.LogLine
+		for _, level := range test.levels {
+			logLines = append(logLines, state.LogLine{
+				CollectedAt: time.Now().Add(-1 * time.Minute),
+				LogLevel:    level,
+				BackendPid:  1,
+				Content:     "123456789\n",
+			})
+		}
+
+		logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{}, logger, nil)
+		restore()
+
+		if uploader.calls != 1 {
+			t.Errorf("Test %d: expected all files to be sent in one upload, got %d uploads", testIdx, uploader.calls)
+		}
+		if diff := pretty.Compare(test.expectedLinesPerFile, uploader.linesPerFile); diff != "" {
+			t.Errorf("Test %d: lines per file diff: (-want +got)\n%s", testIdx, diff)
+		}
+		if diff := pretty.Compare(test.expectedBytesPerFile, uploader.bytesPerFile); diff != "" {
+			t.Errorf("Test %d: bytes per file diff: (-want +got)\n%s", testIdx, diff)
+		}
+	}
+}
+
+func TestAnalyzeInGroupsAndSendByteOffsets(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	// Continuation lines of two backends are interleaved with each other
+	collectedAt := time.Now().Add(-1 * time.Minute)
+	logLines := []state.LogLine{
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 1, Content: "first line of 1\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 2, Content: "first line of 2\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_UNKNOWN, BackendPid: 1, Content: "\tcontinued 1\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_UNKNOWN, BackendPid: 2, Content: "\tcontinued 2\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 1, Content: "second line of 1\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_UNKNOWN, BackendPid: 2, Content: "\tcontinued 2 again\n"},
+	}
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "byte-offsets-test"}}
+	logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{}, logger, nil)
+
+	expected := []string{
+		"first line of 1\n\tcontinued 1\n",
+		"first line of 2\n\tcontinued 2\n\tcontinued 2 again\n",
+		"second line of 1\n",
+	}
+	if diff := pretty.Compare(expected, uploader.snippets); diff != "" {
+		t.Errorf("Content at byte offsets diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendLogMinLevel(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	collectedAt := time.Now().Add(-1 * time.Minute)
+	logLines := []state.LogLine{
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_DEBUG, BackendPid: 1, Content: "debug message\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_UNKNOWN, Content: "\tcontinued debug message\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_WARNING, BackendPid: 2, Content: "warning message\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_DETAIL, BackendPid: 1, Content: "detail of debug message\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_DETAIL, BackendPid: 2, Content: "detail of warning message\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_INFO, BackendPid: 2, Content: "info message\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 1, Content: "log message\n"},
+	}
+
+	// Warning, as set by log_min_level = warning
+	server := state.Server{Config: config.ServerConfig{SectionName: "min-level-test", LogMinLevel: int32(pganalyze_collector.LogLineInformation_WARNING)}}
+
+	filteredBefore := logs.GetFilteredLogLines()
+	logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{}, logger, nil)
+
+	expected := []string{"warning message\n", "detail of warning message\n", "log message\n"}
+	if diff := pretty.Compare(expected, uploader.snippets); diff != "" {
+		t.Errorf("Sent lines diff: (-want +got)\n%s", diff)
+	}
+	if filtered := logs.GetFilteredLogLines() - filteredBefore; filtered != 4 {
+		t.Errorf("Expected 4 filtered lines, got %d", filtered)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendLogTempDir(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	opts := state.CollectionOpts{LogTempDir: tempDir}
+	if err = opts.Validate(); err != nil {
+		t.Fatalf("Expected log temp directory to be accepted, got: %s", err)
+	}
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "temp-dir-test"}}
+	logLines := []state.LogLine{{
+		CollectedAt: time.Now().Add(-1 * time.Minute),
+		LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+		BackendPid:  1,
+		Content:     "123456789\n",
+	}}
+	logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+
+	if len(uploader.tmpFileNames) != 1 {
+		t.Fatalf("Expected one log file to be uploaded, got %d", len(uploader.tmpFileNames))
+	}
+	if dir := filepath.Dir(uploader.tmpFileNames[0]); dir != tempDir {
+		t.Errorf("Expected log tempfile to be created in %s, got %s", tempDir, dir)
+	}
+	remaining, _ := ioutil.ReadDir(tempDir)
+	if len(remaining) != 0 {
+		t.Errorf("Expected log tempfiles to be removed after sending, found %d files", len(remaining))
+	}
+}
+
+func TestAnalyzeInGroupsAndSendInMemoryFallback(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Tempfiles can't be created in a directory that doesn't exist
+	opts := state.CollectionOpts{LogTempDir: filepath.Join(tempDir, "missing")}
+	server := state.Server{Config: config.ServerConfig{SectionName: "in-memory-test"}}
+	logLines := []state.LogLine{{
+		CollectedAt: time.Now().Add(-1 * time.Minute),
+		LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+		BackendPid:  1,
+		Content:     "123456789\n",
+	}, {
+		CollectedAt: time.Now().Add(-1 * time.Minute),
+		LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+		BackendPid:  2,
+		Content:     "abcdef\n",
+	}}
+	remaining := logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+
+	if len(remaining) != 0 {
+		t.Errorf("Expected all log lines to be sent, got %d remaining", len(remaining))
+	}
+	if uploader.calls != 1 {
+		t.Fatalf("Expected one upload, got %d", uploader.calls)
+	}
+	if len(uploader.tmpFileNames) != 0 {
+		t.Errorf("Expected no tempfiles to be used, got %v", uploader.tmpFileNames)
+	}
+	expectedSnippets := []string{"123456789\n", "abcdef\n"}
+	if diff := pretty.Compare(expectedSnippets, uploader.snippets); diff != "" {
+		t.Errorf("Sent lines diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendInMemoryFallbackTooLarge(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	maxBytes := logs.InMemoryLogFilesMaxBytes
+	logs.InMemoryLogFilesMaxBytes = 5
+	defer func() { logs.InMemoryLogFilesMaxBytes = maxBytes }()
+
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	opts := state.CollectionOpts{LogTempDir: filepath.Join(tempDir, "missing")}
+	server := state.Server{Config: config.ServerConfig{SectionName: "in-memory-too-large-test"}}
+	logLines := []state.LogLine{{
+		CollectedAt: time.Now().Add(-1 * time.Minute),
+		LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+		BackendPid:  1,
+		Content:     "123456789\n",
+	}}
+	remaining := logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+
+	if len(remaining) != 1 {
+		t.Errorf("Expected log line to be kept for retrying, got %d remaining", len(remaining))
+	}
+	if uploader.calls != 0 {
+		t.Errorf("Expected no upload, got %d", uploader.calls)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendFutureTimestamp(t *testing.T) {
+	var logOutput bytes.Buffer
+	logger := &util.Logger{Destination: log.New(&logOutput, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "future-test", LogLinesReadyAfter: 50 * time.Millisecond}}
+	logLines := []state.LogLine{{
+		CollectedAt: time.Now().Add(30 * time.Second),
+		LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+		BackendPid:  1,
+		Content:     "123456789\n",
+	}}
+
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{}, logger, nil)
+	if len(logLines) != 1 || uploader.calls != 0 {
+		t.Fatalf("Expected future-dated line to wait for the ready window, got %d pending after %d uploads", len(logLines), uploader.calls)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	logLines = append(logLines, state.LogLine{
+		CollectedAt: time.Now().Add(30 * time.Second),
+		LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+		BackendPid:  2,
+		Content:     "987654321\n",
+	})
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{}, logger, nil)
+	if len(logLines) != 1 || uploader.calls != 1 {
+		t.Errorf("Expected first future-dated line to be sent once the ready window passed, got %d pending after %d uploads", len(logLines), uploader.calls)
+	}
+
+	if warnings := strings.Count(logOutput.String(), "check the clock"); warnings != 1 {
+		t.Errorf("Expected clock skew to be warned about once per run, got %d warnings:\n%s", warnings, logOutput.String())
+	}
+}
+
+var querySamplesEnabled = true
+var querySamplesDisabled = false
+
+var querySamplesGrantTests = []struct {
+	grant           state.Grant
+	expectedSamples int
+}{
+	// No grant received yet
+	{state.Grant{}, 1},
+	// Grant from a server that doesn't send the feature
+	{state.Grant{Valid: true, Config: state.GrantConfig{Features: state.GrantFeatures{Logs: true}}}, 1},
+	{state.Grant{Valid: true, Config: state.GrantConfig{Features: state.GrantFeatures{Logs: true, QuerySamples: &querySamplesEnabled}}}, 1},
+	{state.Grant{Valid: true, Config: state.GrantConfig{Features: state.GrantFeatures{Logs: true, QuerySamples: &querySamplesDisabled}}}, 0},
+}
+
+func TestAnalyzeInGroupsAndSendQuerySamplesGrant(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	for testIdx, test := range querySamplesGrantTests {
+		uploader := &capturingUploader{}
+		restore := logs.SetSendFuncs(uploader.getGrant, uploader.upload)
+
+		server := state.Server{Config: config.ServerConfig{SectionName: "samples-test"}, Grant: test.grant}
+		logLines := []state.LogLine{{
+			CollectedAt: time.Now().Add(-1 * time.Minute),
+			LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:  1,
+			Content:     "duration: 3205.800 ms  statement: SELECT 1\n",
+		}}
+
+		logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{}, logger, nil)
+		restore()
+
+		if diff := pretty.Compare([]int{1}, uploader.linesPerFile); diff != "" {
+			t.Errorf("Test %d: lines per file diff: (-want +got)\n%s", testIdx, diff)
+		}
+		if uploader.samples != test.expectedSamples {
+			t.Errorf("Test %d: expected %d query samples, got %d", testIdx, test.expectedSamples, uploader.samples)
+		}
+	}
+}
+
+func TestAnalyzeInGroupsAndSendQuerySampleNormalizer(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	keywords := strings.NewReplacer("select", "SELECT", "from", "FROM", "where", "WHERE")
+	logs.SetQuerySampleNormalizer(keywords.Replace)
+	defer logs.SetQuerySampleNormalizer(nil)
+
+	grant := state.Grant{Valid: true, Config: state.GrantConfig{Features: state.GrantFeatures{Logs: true, QuerySamples: &querySamplesEnabled}}}
+	server := state.Server{Config: config.ServerConfig{SectionName: "normalizer-test"}, Grant: grant}
+	logLines := []state.LogLine{{
+		CollectedAt: time.Now().Add(-1 * time.Minute),
+		LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+		BackendPid:  1,
+		Content:     "duration: 3205.800 ms  statement: select id from items where id = 1\n",
+	}}
+
+	logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{}, logger, nil)
+
+	if diff := pretty.Compare([]string{"SELECT id FROM items WHERE id = 1"}, uploader.queries); diff != "" {
+		t.Errorf("Query samples diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendProcessingStats(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	tests := []struct {
+		description string
+		opts        state.CollectionOpts
+	}{
+		{"regular run", state.CollectionOpts{}},
+		{"test run", state.CollectionOpts{TestRun: true}},
+	}
+
+	for _, test := range tests {
+		uploader := &capturingUploader{}
+		restore := logs.SetSendFuncs(uploader.getGrant, uploader.upload)
+
+		server := state.Server{Config: config.ServerConfig{SectionName: "processing-stats-test"}}
+		logs.TakeLogProcessingStats(server) // Start over from earlier tests
+
+		logLines := []state.LogLine{
+			{
+				CollectedAt: time.Now().Add(-1 * time.Minute),
+				LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+				BackendPid:  1,
+				Content:     "duration: 3205.800 ms  statement: SELECT 1\n",
+			},
+			{
+				CollectedAt: time.Now(),
+				LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+				BackendPid:  2,
+				Content:     "still too fresh\n",
+			},
+		}
+		logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, test.opts, logger, nil)
+		logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, test.opts, logger, nil) // Nothing ready, only stitching
+		restore()
+
+		stats := logs.TakeLogProcessingStats(server)
+		counts := []int64{stats.Stitching.Count, stats.Analysis.Count, stats.Upload.Count}
+		if diff := pretty.Compare([]int64{2, 1, 1}, counts); diff != "" {
+			t.Errorf("%s: stitching/analysis/upload counts: (-want +got)\n%s", test.description, diff)
+		}
+		for _, durationStats := range []state.DurationStats{stats.Stitching, stats.Analysis, stats.Upload} {
+			if durationStats.Min > durationStats.Max || durationStats.Max > durationStats.Total || durationStats.Avg() > durationStats.Max {
+				t.Errorf("%s: inconsistent duration stats: %+v", test.description, durationStats)
+			}
+		}
+
+		if stats := logs.TakeLogProcessingStats(server); stats != (state.LogProcessingStats{}) {
+			t.Errorf("%s: expected stats to start over after being read, got %+v", test.description, stats)
+		}
+	}
+}
+
+func TestAnalyzeInGroupsAndSendClassificationDenyList(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	tests := []struct {
+		denyList                []int32
+		expectedClassifications []pganalyze_collector.LogLineInformation_LogClassification
+		expectedBytes           []int
+		expectedFiltered        int64
+	}{
+		{
+			nil,
+			[]pganalyze_collector.LogLineInformation_LogClassification{
+				pganalyze_collector.LogLineInformation_CHECKPOINT_STARTING,
+				pganalyze_collector.LogLineInformation_UNKNOWN_LOG_CLASSIFICATION,
+				pganalyze_collector.LogLineInformation_STATEMENT_DURATION,
+			},
+			[]int{113},
+			0,
+		},
+		{
+			// The DETAIL line and the continuation line are left out together with the checkpoint line
+			[]int32{int32(pganalyze_collector.LogLineInformation_CHECKPOINT_STARTING)},
+			[]pganalyze_collector.LogLineInformation_LogClassification{pganalyze_collector.LogLineInformation_STATEMENT_DURATION},
+			[]int{43},
+			2,
+		},
+	}
+
+	for testIdx, test := range tests {
+		uploader := &capturingUploader{}
+		restore := logs.SetSendFuncs(uploader.getGrant, uploader.upload)
+
+		server := state.Server{Config: config.ServerConfig{SectionName: "classification-test", LogClassificationDenyList: test.denyList}}
+		collectedAt := time.Now().Add(-1 * time.Minute)
+		logLines := []state.LogLine{
+			{CollectedAt: collectedAt, UUID: uuid.NewV4(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 1, Content: "checkpoint starting: time\n"},
+			{CollectedAt: collectedAt, UUID: uuid.NewV4(), BackendPid: 1, Content: "\tcontinued\n"},
+			{CollectedAt: collectedAt, UUID: uuid.NewV4(), LogLevel: pganalyze_collector.LogLineInformation_DETAIL, BackendPid: 1, Content: "some detail about the checkpoint\n"},
+			{CollectedAt: collectedAt, UUID: uuid.NewV4(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 2, Content: "duration: 3205.800 ms  statement: SELECT 1\n"},
+		}
+
+		filteredBefore := logs.GetFilteredLogLines()
+		logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{}, logger, nil)
+		restore()
+
+		if diff := pretty.Compare(test.expectedClassifications, uploader.classifications); diff != "" {
+			t.Errorf("Test %d: classifications diff: (-want +got)\n%s", testIdx, diff)
+		}
+		if diff := pretty.Compare(test.expectedBytes, uploader.bytesPerFile); diff != "" {
+			t.Errorf("Test %d: bytes per file diff: (-want +got)\n%s", testIdx, diff)
+		}
+		if filtered := logs.GetFilteredLogLines() - filteredBefore; filtered != test.expectedFiltered {
+			t.Errorf("Test %d: expected %d filtered log lines, got %d", testIdx, test.expectedFiltered, filtered)
+		}
+		if uploader.samples != 1 {
+			t.Errorf("Test %d: expected query sample of the remaining line to be kept, got %d samples", testIdx, uploader.samples)
+		}
+	}
+}
+
+func TestAnalyzeInGroupsAndSendClassificationAllowList(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	checkpointStarting := pganalyze_collector.LogLineInformation_CHECKPOINT_STARTING
+	statementDuration := pganalyze_collector.LogLineInformation_STATEMENT_DURATION
+
+	tests := []struct {
+		description             string
+		allowList               []int32
+		denyList                []int32
+		expectedClassifications []pganalyze_collector.LogLineInformation_LogClassification
+		expectedFiltered        int64
+		expectedSamples         int
+	}{
+		{
+			"empty allow list sends everything",
+			nil,
+			nil,
+			[]pganalyze_collector.LogLineInformation_LogClassification{checkpointStarting, pganalyze_collector.LogLineInformation_UNKNOWN_LOG_CLASSIFICATION, statementDuration, pganalyze_collector.LogLineInformation_PGA_COLLECTOR_IDENTIFY},
+			0,
+			1,
+		},
+		{
+			// The DETAIL line is left out together with the checkpoint line, the identify line is always kept
+			"restricted allow list",
+			[]int32{int32(statementDuration)},
+			nil,
+			[]pganalyze_collector.LogLineInformation_LogClassification{statementDuration, pganalyze_collector.LogLineInformation_PGA_COLLECTOR_IDENTIFY},
+			2,
+			1,
+		},
+		{
+			"server allow list takes precedence over local deny list",
+			[]int32{int32(checkpointStarting)},
+			[]int32{int32(checkpointStarting)},
+			[]pganalyze_collector.LogLineInformation_LogClassification{checkpointStarting, pganalyze_collector.LogLineInformation_UNKNOWN_LOG_CLASSIFICATION, pganalyze_collector.LogLineInformation_PGA_COLLECTOR_IDENTIFY},
+			1,
+			0,
+		},
+	}
+
+	for _, test := range tests {
+		uploader := &capturingUploader{}
+		restore := logs.SetSendFuncs(uploader.getGrant, uploader.upload)
+
+		// Log tails keep a copy of the server from before the first full snapshot,
+		// so the grant is only set on the shared one
+		server := state.Server{
+			Config:      config.ServerConfig{SectionName: "allow-list-test", LogClassificationDenyList: test.denyList},
+			SharedGrant: &state.SharedGrant{},
+		}
+		server.SharedGrant.Set(state.Grant{Valid: true, Config: state.GrantConfig{Features: state.GrantFeatures{QuerySamples: &querySamplesEnabled, LogClassificationAllowList: test.allowList}}})
+		collectedAt := time.Now().Add(-1 * time.Minute)
+		logLines := []state.LogLine{
+			{CollectedAt: collectedAt, UUID: uuid.NewV4(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 1, Content: "checkpoint starting: time\n"},
+			{CollectedAt: collectedAt, UUID: uuid.NewV4(), LogLevel: pganalyze_collector.LogLineInformation_DETAIL, BackendPid: 1, Content: "some detail about the checkpoint\n"},
+			{CollectedAt: collectedAt, UUID: uuid.NewV4(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 2, Content: "duration: 3205.800 ms  statement: SELECT 1\n"},
+			{CollectedAt: collectedAt, UUID: uuid.NewV4(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 3, Content: "pganalyze-collector-identify: allow-list-test\n"},
+		}
+
+		filteredBefore := logs.GetFilteredLogLines()
+		logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{}, logger, nil)
+		restore()
+
+		if diff := pretty.Compare(test.expectedClassifications, uploader.classifications); diff != "" {
+			t.Errorf("%s: classifications diff: (-want +got)\n%s", test.description, diff)
+		}
+		if filtered := logs.GetFilteredLogLines() - filteredBefore; filtered != test.expectedFiltered {
+			t.Errorf("%s: expected %d filtered log lines, got %d", test.description, test.expectedFiltered, filtered)
+		}
+		if uploader.samples != test.expectedSamples {
+			t.Errorf("%s: expected %d query samples, got %d", test.description, test.expectedSamples, uploader.samples)
+		}
+	}
+}
+
+func TestAnalyzeInGroupsAndSendApplicationNameDenyList(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "application-test", LogApplicationNameDenyList: []string{"pg_dump"}}}
+	opts := state.CollectionOpts{CollectorApplicationName: "pganalyze_collector"}
+	collectedAt := time.Now().Add(-1 * time.Minute)
+	logLines := []state.LogLine{
+		{CollectedAt: collectedAt, UUID: uuid.NewV4(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 1, Application: "pg_dump", Content: "duration: 5000.000 ms  statement: COPY public.items TO stdout;\n"},
+		{CollectedAt: collectedAt, UUID: uuid.NewV4(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 2, Application: "pganalyze_collector", Content: "duration: 1200.000 ms  statement: SELECT 1\n"},
+		{CollectedAt: collectedAt, UUID: uuid.NewV4(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 2, Application: "pganalyze_collector", Content: "pganalyze-collector-identify: application-test\n"},
+		{CollectedAt: collectedAt, UUID: uuid.NewV4(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 3, Application: "psql", Content: "duration: 3205.800 ms  statement: SELECT 2\n"},
+	}
+
+	filteredBefore := logs.GetFilteredLogLines()
+	logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+
+	expected := []pganalyze_collector.LogLineInformation_LogClassification{
+		pganalyze_collector.LogLineInformation_PGA_COLLECTOR_IDENTIFY,
+		pganalyze_collector.LogLineInformation_STATEMENT_DURATION,
+	}
+	if diff := pretty.Compare(expected, uploader.classifications); diff != "" {
+		t.Errorf("Classifications diff: (-want +got)\n%s", diff)
+	}
+	if diff := pretty.Compare([]string{"SELECT 2"}, uploader.queries); diff != "" {
+		t.Errorf("Query samples diff: (-want +got)\n%s", diff)
+	}
+	if filtered := logs.GetFilteredLogLines() - filteredBefore; filtered != 2 {
+		t.Errorf("Expected 2 filtered log lines, got %d", filtered)
+	}
+}
+
+func TestFilterDownloadedLogFiles(t *testing.T) {
+	prefix := "2018-09-27 06:57:01 UTC:127.0.0.1(45224):postgres@mydb:"
+	infoLines := prefix + "[1]:INFO:  info message\n" + prefix + "[1]:DETAIL:  detail of info message\n"
+	connectionLine := prefix + "[2]:LOG:  connection received: host=127.0.0.1 port=5432\n"
+	durationLine := prefix + "[3]:LOG:  duration: 3205.800 ms  statement: SELECT 2\n"
+	errorLines := prefix + "[4]:ERROR:  relation \"x\" does not exist at character 15\n" + prefix + "[4]:STATEMENT:  SELECT * FROM x\n"
+	buffer := infoLines + connectionLine + durationLine + errorLines
+
+	logLines, querySamples, _ := logs.ParseAndAnalyzeBuffer(buffer, 0, time.Time{})
+	if len(querySamples) != 1 {
+		t.Fatalf("Expected 1 query sample before filtering, got %d", len(querySamples))
+	}
+	logFiles := []state.LogFile{{UUID: uuid.NewV4(), LogLines: logLines, Content: []byte(buffer)}}
+
+	server := state.Server{Config: config.ServerConfig{
+		SectionName:               "download-filter-test",
+		LogMinLevel:               int32(pganalyze_collector.LogLineInformation_WARNING),
+		LogClassificationDenyList: []int32{int32(pganalyze_collector.LogLineInformation_STATEMENT_DURATION)},
+	}}
+
+	filteredBefore := logs.GetFilteredLogLines()
+	logFiles, querySamples, err := logs.FilterDownloadedLogFiles(server, logFiles, querySamples, state.CollectionOpts{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(querySamples) != 0 {
+		t.Errorf("Expected the query sample of the denied line to be left out, got %+v", querySamples)
+	}
+	if filtered := logs.GetFilteredLogLines() - filteredBefore; filtered != 3 {
+		t.Errorf("Expected 3 filtered lines, got %d", filtered)
+	}
+	content, _ := logFiles[0].ReadContent()
+	if diff := pretty.Compare(connectionLine+errorLines, string(content)); diff != "" {
+		t.Errorf("Content diff: (-want +got)\n%s", diff)
+	}
+	var contents []string
+	for _, logLine := range logFiles[0].LogLines {
+		contents = append(contents, string(content[logLine.ByteContentStart:logLine.ByteEnd+1]))
+	}
+	expected := []string{
+		"connection received: host=127.0.0.1 port=5432\n",
+		"relation \"x\" does not exist at character 15\n",
+		"SELECT * FROM x\n",
+	}
+	if diff := pretty.Compare(expected, contents); diff != "" {
+		t.Errorf("Line contents diff: (-want +got)\n%s", diff)
+	}
+}
+
+func bufferTestLogLines(contents ...string) []state.LogLine {
+	var logLines []state.LogLine
+	for idx, content := range contents {
+		logLines = append(logLines, state.LogLine{
+			CollectedAt: time.Now().Add(-time.Minute),
+			LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:  int32(idx + 1),
+			Content:     content + "\n",
+		})
+	}
+	return logLines
+}
+
+func TestAnalyzeInGroupsAndSendBufferDropOldest(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &fakeUploader{failuresLeft: 1}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "buffer-drop-test", LogBufferMaxLines: 3, LogBufferPolicy: logs.LogBufferPolicyDropOldest}}
+	opts := state.CollectionOpts{LogUploadMaxRetries: 10}
+
+	overflowedBefore := logs.GetOverflowedLogLines()
+	logLines := logs.AnalyzeInGroupsAndSend(context.Background(), server, bufferTestLogLines("a", "b", "c", "d", "e"), opts, logger, nil)
+
+	var contents []string
+	for _, logLine := range logLines {
+		contents = append(contents, logLine.Content)
+	}
+	if diff := pretty.Compare([]string{"c\n", "d\n", "e\n"}, contents); diff != "" {
+		t.Errorf("Buffered log lines diff: (-want +got)\n%s", diff)
+	}
+	if overflowed := logs.GetOverflowedLogLines() - overflowedBefore; overflowed != 2 {
+		t.Errorf("Expected 2 overflowed log lines, got %d", overflowed)
+	}
+
+	// Once sending works again, the remaining lines get sent
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+	if len(logLines) != 0 || uploader.calls != 2 {
+		t.Errorf("Expected buffered lines to be sent, got %d remaining after %d uploads", len(logLines), uploader.calls)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendPaused(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	pauseFile := filepath.Join(dir, "paused")
+	ioutil.WriteFile(pauseFile, nil, 0600)
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "paused-test", LogBufferMaxLines: 3, LogBufferPolicy: logs.LogBufferPolicyDropOldest}}
+	opts := state.CollectionOpts{Pause: &state.PauseControl{File: pauseFile}}
+
+	// While paused nothing gets sent, and the kept lines are limited like any other buffered lines
+	logLines := logs.AnalyzeInGroupsAndSend(context.Background(), server, bufferTestLogLines("a", "b"), opts, logger, nil)
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, append(logLines, bufferTestLogLines("c", "d", "e")...), opts, logger, nil)
+
+	var contents []string
+	for _, logLine := range logLines {
+		contents = append(contents, logLine.Content)
+	}
+	if diff := pretty.Compare([]string{"c\n", "d\n", "e\n"}, contents); diff != "" {
+		t.Errorf("Buffered log lines diff: (-want +got)\n%s", diff)
+	}
+	if uploader.calls != 0 {
+		t.Errorf("Expected no uploads while paused, got %d", uploader.calls)
+	}
+
+	// Once resumed, the kept lines get sent
+	os.Remove(pauseFile)
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+	if len(logLines) != 0 || uploader.calls != 1 {
+		t.Errorf("Expected buffered lines to be sent after resuming, got %d remaining after %d uploads", len(logLines), uploader.calls)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendPausedUnlimitedBuffer(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	prevMaxLines := logs.PausedLogBufferMaxLines
+	logs.PausedLogBufferMaxLines = 2
+	defer func() { logs.PausedLogBufferMaxLines = prevMaxLines }()
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	pauseFile := filepath.Join(dir, "paused")
+	ioutil.WriteFile(pauseFile, nil, 0600)
+
+	// Even with the block policy, lines are dropped while paused when the buffer isn't limited otherwise
+	server := state.Server{Config: config.ServerConfig{SectionName: "paused-unlimited-test", LogBufferPolicy: logs.LogBufferPolicyBlock}}
+	opts := state.CollectionOpts{Pause: &state.PauseControl{File: pauseFile}}
+
+	logLines := logs.AnalyzeInGroupsAndSend(context.Background(), server, bufferTestLogLines("a", "b", "c"), opts, logger, nil)
+
+	var contents []string
+	for _, logLine := range logLines {
+		contents = append(contents, logLine.Content)
+	}
+	if diff := pretty.Compare([]string{"b\n", "c\n"}, contents); diff != "" {
+		t.Errorf("Buffered log lines diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendPushedBufferBlock(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &fakeUploader{failuresLeft: 1}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	// Pushed lines can't be left with their sender, so the oldest ones get dropped instead
+	server := state.Server{Config: config.ServerConfig{SectionName: "pushed-block-test", LogBufferMaxLines: 2, LogBufferPolicy: logs.LogBufferPolicyBlock}}
+	server = logs.WithoutBlockingLogBuffer(server)
+	opts := state.CollectionOpts{LogUploadMaxRetries: 10}
+
+	if logs.LogBufferFull(server, bufferTestLogLines("a", "b", "c")) {
+		t.Errorf("Expected buffer of pushed lines to never be full")
+	}
+	logLines := logs.AnalyzeInGroupsAndSend(context.Background(), server, bufferTestLogLines("a", "b", "c"), opts, logger, nil)
+
+	var contents []string
+	for _, logLine := range logLines {
+		contents = append(contents, logLine.Content)
+	}
+	if diff := pretty.Compare([]string{"b\n", "c\n"}, contents); diff != "" {
+		t.Errorf("Buffered log lines diff: (-want +got)\n%s", diff)
+	}
+}
+
+type countingLogSource struct {
+	logLines []state.LogLine
+	reads    int
+}
+
+func (s *countingLogSource) GetLogLines(ctx context.Context) ([]state.LogLine, error) {
+	s.reads++
+	logLines := s.logLines
+	s.logLines = nil
+	return logLines, nil
+}
+
+func TestAnalyzeSourceInGroupsAndSendBufferBlock(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &fakeUploader{failuresLeft: 2}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "buffer-block-test", LogBufferMaxLines: 2, LogBufferPolicy: logs.LogBufferPolicyBlock}}
+	opts := state.CollectionOpts{LogUploadMaxRetries: 10}
+	source := &countingLogSource{logLines: bufferTestLogLines("a", "b", "c")}
+
+	// The source gets read, but sending fails, so all lines stay buffered
+	overflowedBefore := logs.GetOverflowedLogLines()
+	pending, _ := logs.AnalyzeSourceInGroupsAndSend(context.Background(), server, source, nil, opts, logger, nil)
+	if len(pending) != 3 || source.reads != 1 {
+		t.Fatalf("Expected 3 buffered lines after 1 read, got %d after %d reads", len(pending), source.reads)
+	}
+	if overflowed := logs.GetOverflowedLogLines() - overflowedBefore; overflowed != 0 {
+		t.Errorf("Expected no overflowed log lines with the block policy, got %d", overflowed)
+	}
+
+	// The buffer is full, so new lines are left with the source
+	source.logLines = bufferTestLogLines("d")
+	pending, _ = logs.AnalyzeSourceInGroupsAndSend(context.Background(), server, source, pending, opts, logger, nil)
+	if len(pending) != 3 || source.reads != 1 || len(source.logLines) != 1 {
+		t.Fatalf("Expected source not to be read while the buffer is full, got %d buffered lines after %d reads", len(pending), source.reads)
+	}
+
+	// Once sending works again, reading continues
+	pending, _ = logs.AnalyzeSourceInGroupsAndSend(context.Background(), server, source, pending, opts, logger, nil)
+	pending, _ = logs.AnalyzeSourceInGroupsAndSend(context.Background(), server, source, pending, opts, logger, nil)
+	if len(pending) != 0 || source.reads != 2 || len(source.logLines) != 0 {
+		t.Errorf("Expected all lines to be sent, got %d buffered lines after %d reads", len(pending), source.reads)
+	}
+}
+
+func TestFlushAndSend(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &fakeUploader{failuresLeft: 1}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "flush-test", LogLinesReadyAfter: time.Minute}}
+	opts := state.CollectionOpts{LogUploadMaxRetries: 10}
+
+	// A failed send makes the next attempt wait
+	logs.AnalyzeInGroupsAndSend(context.Background(), server, bufferTestLogLines("a"), opts, logger, nil)
+	if uploader.calls != 1 {
+		t.Fatalf("Expected one failed upload, got %d", uploader.calls)
+	}
+
+	logLines := []state.LogLine{
+		{CollectedAt: time.Now(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 1, Content: "just received\n"},
+		{CollectedAt: time.Now(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 2, Content: "just received as well\n"},
+	}
+
+	// Normally these lines are too fresh to be sent
+	remaining := logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, opts, logger, nil)
+	if uploader.calls != 1 || len(remaining) != 2 {
+		t.Fatalf("Expected lines to be kept without uploading, got %d uploads and %d remaining lines", uploader.calls, len(remaining))
+	}
+
+	// Flushing sends them right away, without waiting for the retry
+	capturing := &capturingUploader{}
+	logs.SetSendFuncs(capturing.getGrant, capturing.upload)
+	remaining = logs.FlushAndSend(server, remaining, opts, logger)
+	if len(remaining) != 0 {
+		t.Errorf("Expected all lines to be sent, got %d remaining", len(remaining))
+	}
+	if diff := pretty.Compare([]string{"just received\n", "just received as well\n"}, capturing.snippets); diff != "" {
+		t.Errorf("Flushed lines diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendTimestampPrecision(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	// Both lines were logged right when they got collected, just after the
+	// waiting time is over, but the line logged with %t still needs to wait
+	// out the second it may have been logged in
+	collectedAt := time.Now().Add(-3500 * time.Millisecond)
+	secondLine, _ := logs.ParseLogLineWithPrefix(logs.LogPrefixCustom2, "2018-09-28 07:37:59 UTC [331-1] LOG:  logged with %t\n")
+	secondLine.OccurredAt = collectedAt
+	secondLine.CollectedAt = collectedAt
+	millisecondLine, _ := logs.ParseLogLineWithPrefix(logs.LogPrefixSimple, "2018-09-28 07:37:59.123 UTC [332] LOG:  logged with %m\n")
+	millisecondLine.OccurredAt = collectedAt
+	millisecondLine.CollectedAt = collectedAt
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "precision-test", LogLinesReadyAfter: 3 * time.Second}}
+	remaining := logs.AnalyzeInGroupsAndSend(context.Background(), server, []state.LogLine{secondLine, millisecondLine}, state.CollectionOpts{}, logger, nil)
+
+	if diff := pretty.Compare([]string{"logged with %m\n"}, uploader.snippets); diff != "" {
+		t.Errorf("Sent lines diff: (-want +got)\n%s", diff)
+	}
+	if len(remaining) != 1 || remaining[0].BackendPid != 331 {
+		t.Errorf("Expected the line logged with %%t to remain, got %v", remaining)
+	}
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Step(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestAnalyzeInGroupsAndSendFreshnessBoundary(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	clock := &fakeClock{now: time.Date(2018, time.September, 28, 7, 37, 59, 0, time.UTC)}
+	defer logs.SetClock(clock.Now)()
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "clock-test", LogLinesReadyAfter: 3 * time.Second}}
+	logLines := []state.LogLine{{CollectedAt: clock.Now(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 1, Content: "log message\n"}}
+
+	// Exactly at the boundary the line is still too fresh
+	clock.Step(3 * time.Second)
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{}, logger, nil)
+	if len(logLines) != 1 || len(uploader.snippets) != 0 {
+		t.Fatalf("Expected line to be too fresh at 3s, got %d remaining and %d sent", len(logLines), len(uploader.snippets))
+	}
+
+	clock.Step(time.Millisecond)
+	logLines = logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{}, logger, nil)
+	if len(logLines) != 0 {
+		t.Errorf("Expected line to be sent after 3s, got %d remaining", len(logLines))
+	}
+	if diff := pretty.Compare([]string{"log message\n"}, uploader.snippets); diff != "" {
+		t.Errorf("Sent lines diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestChannelLogSourceUsesClock(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2018, time.September, 28, 7, 37, 59, 0, time.UTC)}
+	defer logs.SetClock(clock.Now)()
+
+	in := make(chan string, 1)
+	in <- "2018-09-28 07:37:59.123 UTC [1] LOG:  log message\n"
+	logLines, err := logs.NewChannelLogSource(in).GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(logLines) != 1 || !logLines[0].CollectedAt.Equal(clock.Now()) {
+		t.Errorf("Expected one line collected at %s, got %v", clock.Now(), logLines)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendCollectOnce(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	// Lines are never held back, since no later run would send them
+	server := state.Server{Config: config.ServerConfig{SectionName: "collect-once-test", LogLinesReadyAfter: 3 * time.Second}}
+	logLines := []state.LogLine{{CollectedAt: time.Now(), LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 1, Content: "log message\n"}}
+	remaining := logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{CollectOnce: true}, logger, nil)
+
+	if len(remaining) != 0 {
+		t.Errorf("Expected no lines to be held back, got %d", len(remaining))
+	}
+	if diff := pretty.Compare([]string{"log message\n"}, uploader.snippets); diff != "" {
+		t.Errorf("Sent lines diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendMaxQuerySamples(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	// 500 samples of 5 distinct queries, each query with runtimes 0..99ms
+	var logLines []state.LogLine
+	for i := 0; i < 500; i++ {
+		logLines = append(logLines, state.LogLine{
+			CollectedAt: time.Now().Add(-1 * time.Minute),
+			LogLevel:    pganalyze_collector.LogLineInformation_LOG,
+			BackendPid:  int32(i + 1),
+			Content:     fmt.Sprintf("duration: %d.000 ms  statement: SELECT * FROM items_%d\n", i/5, i%5),
+		})
+	}
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "max-samples-test", MaxQuerySamplesPerFingerprint: 10, MaxQuerySamplesPerSnapshot: 20}}
+	droppedBefore := logs.GetDroppedQuerySamples()
+	logs.AnalyzeInGroupsAndSend(context.Background(), server, logLines, state.CollectionOpts{}, logger, nil)
+
+	if uploader.samples != 20 {
+		t.Fatalf("Expected 20 query samples, got %d", uploader.samples)
+	}
+	// Deduplication keeps the 10 slowest of each query (90..99ms), and the cap
+	// then keeps the 20 slowest of those
+	for idx, runtime := range uploader.runtimes {
+		if runtime < 96 {
+			t.Errorf("Expected only samples of 96ms or slower to be kept, got %.0fms for %s", runtime, uploader.queries[idx])
+		}
+	}
+	if dropped := logs.GetDroppedQuerySamples() - droppedBefore; dropped != 30 {
+		t.Errorf("Expected 30 samples to be counted as dropped by the cap, got %d", dropped)
+	}
+}
+
+type chunkUploader struct {
+	capturingUploader
+	failFromCall int // Fail this and all later calls (0 means never)
+}
+
+func (u *chunkUploader) upload(ctx context.Context, server state.Server, grant state.GrantLogs, globalCollectionOpts state.CollectionOpts, logger *util.Logger, logState state.LogState) error {
+	if u.failFromCall > 0 && u.calls+1 >= u.failFromCall {
+		u.calls++
+		return fmt.Errorf("upload endpoint unavailable")
+	}
+	return u.capturingUploader.upload(ctx, server, grant, globalCollectionOpts, logger, logState)
+}
+
+func TestAnalyzeInGroupsAndSendChunks(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	prevChunkMaxLines := logs.LogSendChunkMaxLines
+	logs.LogSendChunkMaxLines = 2
+	defer func() { logs.LogSendChunkMaxLines = prevChunkMaxLines }()
+
+	collectedAt := time.Now().Add(-1 * time.Minute)
+	logLines := []state.LogLine{
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_ERROR, BackendPid: 1, Content: "error of 1\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 2, Content: "first line of 2\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_UNKNOWN, BackendPid: 1, Content: "\tcontinued 1\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_STATEMENT, BackendPid: 1, Content: "statement of 1\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 1, Content: "second line of 1\n"},
+		{CollectedAt: collectedAt, LogLevel: pganalyze_collector.LogLineInformation_LOG, BackendPid: 2, Content: "second line of 2\n"},
+	}
+
+	uploader := &chunkUploader{}
+	restore := logs.SetSendFuncs(uploader.getGrant, uploader.upload)
+	remaining := logs.AnalyzeInGroupsAndSend(context.Background(), state.Server{Config: config.ServerConfig{SectionName: "chunks-test"}}, logLines, state.CollectionOpts{}, logger, nil)
+	restore()
+
+	// Continuation and follow-on lines are never split from their line, even if
+	// that exceeds the chunk size
+	if len(remaining) != 0 || uploader.calls != 3 {
+		t.Errorf("Expected all lines to be sent in 3 chunks, got %d uploads and %d remaining lines", uploader.calls, len(remaining))
+	}
+	expected := []string{
+		"error of 1\n\tcontinued 1\n",
+		"statement of 1\n",
+		"first line of 2\n",
+		"second line of 1\n",
+		"second line of 2\n",
+	}
+	if diff := pretty.Compare(expected, uploader.snippets); diff != "" {
+		t.Errorf("Sent lines diff: (-want +got)\n%s", diff)
+	}
+
+	// When a chunk fails, only the lines of that chunk and the ones after it are kept
+	uploader = &chunkUploader{failFromCall: 2}
+	restore = logs.SetSendFuncs(uploader.getGrant, uploader.upload)
+	remaining = logs.AnalyzeInGroupsAndSend(context.Background(), state.Server{Config: config.ServerConfig{SectionName: "chunks-failure-test"}}, logLines, state.CollectionOpts{LogUploadMaxRetries: 10}, logger, nil)
+	restore()
+
+	var contents []string
+	for _, logLine := range remaining {
+		contents = append(contents, logLine.Content)
+	}
+	if diff := pretty.Compare([]string{"first line of 2\n", "second line of 1\n", "second line of 2\n"}, contents); diff != "" {
+		t.Errorf("Remaining lines diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestAnalyzeInGroupsAndSendTimestampPrecisionOlderLines(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	uploader := &capturingUploader{}
+	defer logs.SetSendFuncs(uploader.getGrant, uploader.upload)()
+
+	// Lines from a second that was over long before they got collected don't
+	// need to wait any longer than the regular waiting time
+	secondLine, _ := logs.ParseLogLineWithPrefix(logs.LogPrefixCustom2, "2018-09-28 07:37:59 UTC [331-1] LOG:  logged with %t\n")
+	secondLine.CollectedAt = time.Now().Add(-3500 * time.Millisecond)
+
+	server := state.Server{Config: config.ServerConfig{SectionName: "precision-test", LogLinesReadyAfter: 3 * time.Second}}
+	remaining := logs.AnalyzeInGroupsAndSend(context.Background(), server, []state.LogLine{secondLine}, state.CollectionOpts{}, logger, nil)
+
+	if diff := pretty.Compare([]string{"logged with %t\n"}, uploader.snippets); diff != "" {
+		t.Errorf("Sent lines diff: (-want +got)\n%s", diff)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("Expected no remaining lines, got %v", remaining)
+	}
+}
@@ -0,0 +1,233 @@
+package logs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+	uuid "github.com/satori/go.uuid"
+)
+
+// LogSource - Provides log lines that should be analyzed and sent, independent
+// of where they originally came from (e.g. a log file, or a process' stdout)
+type LogSource interface {
+	// GetLogLines - Returns all log lines that have been received since the last
+	// call, or io.EOF once the source is exhausted and won't return any more lines
+	GetLogLines(ctx context.Context) ([]state.LogLine, error)
+}
+
+// AnalyzeSourceInGroupsAndSend - Fetches new log lines from the source, and sends
+// them together with any previously pending lines once they are ready
+//
+// Returns the log lines that are not ready yet, which should be passed in as
+// pendingLogLines on the next call.
+//
+// No new log lines are fetched while the buffer is full (see LogBufferFull).
+func AnalyzeSourceInGroupsAndSend(ctx context.Context, server state.Server, source LogSource, pendingLogLines []state.LogLine, globalCollectionOpts state.CollectionOpts, prefixedLogger *util.Logger, logTestSucceeded chan<- bool) ([]state.LogLine, error) {
+	var newLogLines []state.LogLine
+	var err error
+	if !LogBufferFull(server, pendingLogLines) {
+		newLogLines, err = source.GetLogLines(ctx)
+	}
+	logLines := append(pendingLogLines, newLogLines...)
+	if len(logLines) > 0 {
+		logLines = AnalyzeInGroupsAndSend(ctx, server, logLines, globalCollectionOpts, prefixedLogger, logTestSucceeded)
+	}
+	return logLines, err
+}
+
+// FileLogSource - Reads log lines that were appended to a log file since the last read
+//
+// When the file gets rotated, the lines that were appended to it after the
+// last read are still read from the rotated file, including when it was
+// compressed in the meantime (e.g. by logrotate, as "postgresql.log.1.gz").
+type FileLogSource struct {
+	path   string
+	offset int64
+
+	file     os.FileInfo // The file that offset refers to, to detect rotation
+	lastLine string      // Last line that was read, to recognize the file after rotation
+}
+
+// NewFileLogSource - Sets up a log source for the given file, starting at its beginning
+func NewFileLogSource(path string) *FileLogSource {
+	return &FileLogSource{path: path}
+}
+
+// GetLogLines - Returns all complete lines written to the file since the last call
+func (s *FileLogSource) GetLogLines(ctx context.Context) ([]state.LogLine, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	var content string
+	if s.file != nil && !os.SameFile(s.file, info) {
+		content, err = s.readRotated()
+		if err != nil {
+			return nil, err
+		}
+		s.offset = 0
+		s.lastLine = ""
+	} else if info.Size() < s.offset {
+		// Truncated in place (e.g. logrotate's copytruncate), start over
+		s.offset = 0
+		s.lastLine = ""
+	}
+	s.file = info
+
+	_, err = file.Seek(s.offset, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only consume complete lines, a partially written line will be read again
+	// once it got finished. Line endings are dropped, to match how lines are
+	// received from other sources.
+	newContent := buf.String()
+	end := strings.LastIndexByte(newContent, '\n')
+	newContent = newContent[:end+1]
+	if newContent != "" {
+		s.offset += int64(len(newContent))
+		s.lastLine = newContent[strings.LastIndexByte(newContent[:len(newContent)-1], '\n')+1:]
+	}
+	content += newContent
+	if content == "" {
+		return nil, nil
+	}
+
+	var logLines []state.LogLine
+	collectedAt := clock()
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		logLines = append(logLines, logLineFromSourceLine(line, collectedAt))
+	}
+
+	return logLines, nil
+}
+
+// readRotated - Returns the lines that were appended to the previous file after
+// the last read, looking for it under the names used by logrotate
+//
+// If the previous file can't be found (e.g. because it was already removed),
+// its remaining lines are skipped.
+func (s *FileLogSource) readRotated() (string, error) {
+	for _, name := range []string{s.path + ".1", s.path + ".1.gz", s.path + ".gz"} {
+		content, found, err := s.readRotatedFile(name)
+		if err != nil || found {
+			return content, err
+		}
+	}
+	return "", nil
+}
+
+// readRotatedFile - Reads the rest of the given file after the previous offset,
+// if it is the file we were reading before (which is verified by checking that
+// it contains the last line we read right before the offset)
+func (s *FileLogSource) readRotatedFile(name string) (content string, found bool, err error) {
+	file, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(name, ".gz") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return "", false, nil
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	} else {
+		info, err := file.Stat()
+		if err != nil || !os.SameFile(s.file, info) {
+			return "", false, nil
+		}
+	}
+
+	_, err = io.CopyN(ioutil.Discard, reader, s.offset-int64(len(s.lastLine)))
+	if err != nil {
+		return "", false, nil
+	}
+	lastLine := make([]byte, len(s.lastLine))
+	if _, err = io.ReadFull(reader, lastLine); err != nil || string(lastLine) != s.lastLine {
+		return "", false, nil
+	}
+
+	rest, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", false, err
+	}
+	content = string(rest)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	return content, true, nil
+}
+
+// ChannelLogSource - Receives individual log lines as strings over a channel,
+// e.g. from a process' stdout, or a journald reader
+type ChannelLogSource struct {
+	in <-chan string
+}
+
+// NewChannelLogSource - Sets up a log source that reads from the given channel
+func NewChannelLogSource(in <-chan string) *ChannelLogSource {
+	return &ChannelLogSource{in: in}
+}
+
+// GetLogLines - Returns all lines that are currently waiting in the channel,
+// without blocking for new ones to arrive
+func (s *ChannelLogSource) GetLogLines(ctx context.Context) ([]state.LogLine, error) {
+	var logLines []state.LogLine
+	collectedAt := clock()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return logLines, ctx.Err()
+		case line, ok := <-s.in:
+			if !ok {
+				return logLines, io.EOF
+			}
+			logLines = append(logLines, logLineFromSourceLine(line, collectedAt))
+		default:
+			return logLines, nil
+		}
+	}
+}
+
+func logLineFromSourceLine(line string, collectedAt time.Time) state.LogLine {
+	// We ignore failures here since we want the per-backend stitching logic
+	// that runs later on (and any other parsing errors will just be ignored)
+	logLine, _ := ParseLogLineWithPrefix("", line)
+	logLine.CollectedAt = collectedAt
+	logLine.UUID = uuid.NewV4()
+	return logLine
+}
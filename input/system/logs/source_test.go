@@ -0,0 +1,276 @@
+package logs_test
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/input/system/logs"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+	uuid "github.com/satori/go.uuid"
+)
+
+var sourceTestLines = []string{
+	"2018-10-16 01:26:33 UTC [93911]: [3-1] user=postgres,db=postgres,app=psql,client=::1 ERROR:  relation \"x\" does not exist at character 15",
+	"2018-10-16 01:26:33 UTC [93911]: [4-1] user=postgres,db=postgres,app=psql,client=::1 STATEMENT:  SELECT * FROM x;",
+	"2018-10-16 01:26:34 UTC [93912]: [1-1] user=postgres,db=postgres,app=psql,client=::1 LOG:  pganalyze-collector-identify: server1",
+}
+
+func setupFileLogSource(t *testing.T, lines []string) (logs.LogSource, func()) {
+	tmpFile, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatalf("Could not create tempfile: %s", err)
+	}
+	for _, line := range lines {
+		tmpFile.WriteString(line + "\n")
+	}
+	tmpFile.Close()
+
+	return logs.NewFileLogSource(tmpFile.Name()), func() { os.Remove(tmpFile.Name()) }
+}
+
+func setupChannelLogSource(t *testing.T, lines []string) (logs.LogSource, func()) {
+	in := make(chan string, len(lines))
+	for _, line := range lines {
+		in <- line
+	}
+	return logs.NewChannelLogSource(in), func() {}
+}
+
+var logSourceSetups = map[string]func(*testing.T, []string) (logs.LogSource, func()){
+	"file":    setupFileLogSource,
+	"channel": setupChannelLogSource,
+}
+
+func TestLogSourcesAnalyzeTheSame(t *testing.T) {
+	var results []state.LogLine
+	for name, setup := range logSourceSetups {
+		source, cleanup := setup(t, sourceTestLines)
+		defer cleanup()
+
+		logLines, err := source.GetLogLines(context.Background())
+		if err != nil {
+			t.Fatalf("%s source: unexpected error: %s", name, err)
+		}
+		if len(logLines) != len(sourceTestLines) {
+			t.Fatalf("%s source: expected %d log lines, got %d", name, len(sourceTestLines), len(logLines))
+		}
+
+		logLines, _ = logs.AnalyzeBackendLogLines(logLines)
+		for idx := range logLines {
+			logLines[idx].UUID = uuid.UUID{}
+			logLines[idx].ParentUUID = uuid.UUID{}
+			logLines[idx].CollectedAt = time.Time{}
+		}
+
+		if results == nil {
+			results = logLines
+		} else if diff := pretty.Compare(results, logLines); diff != "" {
+			t.Errorf("%s source: analysis output differs from other source: (-want +got)\n%s", name, diff)
+		}
+	}
+}
+
+func TestAnalyzeSourceInGroupsAndSend(t *testing.T) {
+	logger := &util.Logger{}
+	opts := state.CollectionOpts{TestRun: true}
+	server := state.Server{Config: config.ServerConfig{SectionName: "server1"}}
+
+	for name, setup := range logSourceSetups {
+		source, cleanup := setup(t, sourceTestLines)
+		defer cleanup()
+
+		logTestSucceeded := make(chan bool, 1)
+		tooFresh, err := logs.AnalyzeSourceInGroupsAndSend(context.Background(), server, source, nil, opts, logger, logTestSucceeded)
+		if err != nil {
+			t.Errorf("%s source: unexpected error: %s", name, err)
+		}
+		if len(tooFresh) != 0 {
+			t.Errorf("%s source: expected no too fresh log lines, got %d", name, len(tooFresh))
+		}
+		select {
+		case <-logTestSucceeded:
+		default:
+			t.Errorf("%s source: expected identify log line to be recognized", name)
+		}
+	}
+}
+
+func TestFileLogSourcePartialLines(t *testing.T) {
+	tmpFile, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatalf("Could not create tempfile: %s", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	source := logs.NewFileLogSource(tmpFile.Name())
+
+	tmpFile.WriteString(sourceTestLines[0] + "\n" + sourceTestLines[1][:20])
+	logLines, err := source.GetLogLines(context.Background())
+	if err != nil || len(logLines) != 1 {
+		t.Fatalf("Expected 1 log line and no error, got %d lines (err: %v)", len(logLines), err)
+	}
+
+	tmpFile.WriteString(sourceTestLines[1][20:] + "\n")
+	logLines, err = source.GetLogLines(context.Background())
+	if err != nil || len(logLines) != 1 {
+		t.Fatalf("Expected 1 log line and no error, got %d lines (err: %v)", len(logLines), err)
+	}
+	if logLines[0].Content != "SELECT * FROM x;" {
+		t.Errorf("Expected partially written line to be read in full, got %q", logLines[0].Content)
+	}
+}
+
+// rotateLogFile - Rotates the log file like logrotate does with "compress",
+// leaving only the gzipped file and an empty new log file behind
+func rotateLogFile(t *testing.T, path string) {
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("Could not rename log file: %s", err)
+	}
+	if err := ioutil.WriteFile(path, []byte{}, 0600); err != nil {
+		t.Fatalf("Could not create new log file: %s", err)
+	}
+
+	content, err := ioutil.ReadFile(path + ".1")
+	if err != nil {
+		t.Fatalf("Could not read rotated log file: %s", err)
+	}
+	gzFile, err := os.Create(path + ".1.gz")
+	if err != nil {
+		t.Fatalf("Could not create compressed log file: %s", err)
+	}
+	gzipWriter := gzip.NewWriter(gzFile)
+	gzipWriter.Write(content)
+	gzipWriter.Close()
+	gzFile.Close()
+
+	if err = os.Remove(path + ".1"); err != nil {
+		t.Fatalf("Could not remove rotated log file: %s", err)
+	}
+}
+
+func logLineContents(logLines []state.LogLine) (contents []string) {
+	for _, logLine := range logLines {
+		contents = append(contents, logLine.Content)
+	}
+	return
+}
+
+func TestFileLogSourceRotatedToGzip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "postgresql.log")
+
+	lines := []string{
+		"2018-10-16 01:26:33 UTC [93911]: [1-1] user=postgres,db=postgres,app=psql,client=::1 LOG:  first",
+		"2018-10-16 01:26:34 UTC [93911]: [2-1] user=postgres,db=postgres,app=psql,client=::1 LOG:  second",
+		"2018-10-16 01:26:35 UTC [93911]: [3-1] user=postgres,db=postgres,app=psql,client=::1 LOG:  third",
+		"2018-10-16 01:26:36 UTC [93911]: [4-1] user=postgres,db=postgres,app=psql,client=::1 LOG:  fourth",
+	}
+	if err = ioutil.WriteFile(path, []byte(lines[0]+"\n"), 0600); err != nil {
+		t.Fatalf("Could not write log file: %s", err)
+	}
+
+	source := logs.NewFileLogSource(path)
+	logLines, err := source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if diff := pretty.Compare([]string{"first"}, logLineContents(logLines)); diff != "" {
+		t.Errorf("Before rotation: log lines diff: (-want +got)\n%s", diff)
+	}
+
+	// The second line is written right before the rotation, and not read yet
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	file.WriteString(lines[1] + "\n")
+	file.Close()
+	rotateLogFile(t, path)
+	file, _ = os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	file.WriteString(lines[2] + "\n")
+	file.Close()
+
+	logLines, err = source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if diff := pretty.Compare([]string{"second", "third"}, logLineContents(logLines)); diff != "" {
+		t.Errorf("After rotation: log lines diff: (-want +got)\n%s", diff)
+	}
+
+	// Reading continues in the new file, without returning any lines twice
+	file, _ = os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	file.WriteString(lines[3] + "\n")
+	file.Close()
+
+	logLines, err = source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if diff := pretty.Compare([]string{"fourth"}, logLineContents(logLines)); diff != "" {
+		t.Errorf("After reading the new file: log lines diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestFileLogSourceRotatedUnrelatedGzip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "postgresql.log")
+
+	if err = ioutil.WriteFile(path, []byte(sourceTestLines[0]+"\n"), 0600); err != nil {
+		t.Fatalf("Could not write log file: %s", err)
+	}
+	source := logs.NewFileLogSource(path)
+	if _, err = source.GetLogLines(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// A compressed file from an earlier rotation doesn't continue where we
+	// stopped reading, so none of its lines are returned
+	if err = ioutil.WriteFile(path, []byte(sourceTestLines[1]+"\n"+sourceTestLines[2]+"\n"), 0600); err != nil {
+		t.Fatalf("Could not write log file: %s", err)
+	}
+	rotateLogFile(t, path)
+	if err = ioutil.WriteFile(path+".tmp", []byte(sourceTestLines[2]+"\n"), 0600); err != nil {
+		t.Fatalf("Could not write log file: %s", err)
+	}
+	if err = os.Rename(path+".tmp", path); err != nil {
+		t.Fatalf("Could not replace log file: %s", err)
+	}
+
+	logLines, err := source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if diff := pretty.Compare([]string{"pganalyze-collector-identify: server1"}, logLineContents(logLines)); diff != "" {
+		t.Errorf("Log lines diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestChannelLogSourceClosed(t *testing.T) {
+	in := make(chan string, 1)
+	in <- sourceTestLines[0]
+	close(in)
+
+	logLines, err := logs.NewChannelLogSource(in).GetLogLines(context.Background())
+	if err != io.EOF {
+		t.Errorf("Expected io.EOF for closed channel, got %v", err)
+	}
+	if len(logLines) != 1 {
+		t.Errorf("Expected 1 log line, got %d", len(logLines))
+	}
+}
@@ -0,0 +1,94 @@
+package logs
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pganalyze/collector/state"
+)
+
+// Share of dropped log lines in a single run above which we warn, since this
+// typically indicates a misconfigured log_line_prefix
+const droppedLogLinesWarningThreshold = 0.1
+
+// Running totals since collector start, read through GetStitchingStats
+var (
+	logLinesStitched    int64 // Lines without level and PID that were concatenated onto the previous line
+	logLinesDropped     int64 // Lines without level and PID that were dropped, since there was no previous line
+	logLinesUnparseable int64 // Dropped lines that didn't follow any other line at all, a subset of logLinesDropped
+	logLinesFiltered    int64 // Lines that were not sent because of their level, classification or application name
+	logLinesOverflowed  int64 // Lines that were dropped since they exceeded LogBufferMaxLines
+
+	querySamplesDropped int64 // Query samples that were dropped since they exceeded MaxQuerySamplesPerSnapshot
+)
+
+// GetStitchingStats - Returns the number of log lines that were stitched onto
+// their previous line, and that were dropped without such a line, since startup
+func GetStitchingStats() (stitched int64, dropped int64) {
+	return atomic.LoadInt64(&logLinesStitched), atomic.LoadInt64(&logLinesDropped)
+}
+
+// GetUnparseableLogLines - Returns the number of log lines that had neither a
+// level nor a PID and didn't follow any other line, since startup
+func GetUnparseableLogLines() int64 {
+	return atomic.LoadInt64(&logLinesUnparseable)
+}
+
+// GetFilteredLogLines - Returns the number of log lines that were not sent
+// because their classification is denied, since startup
+func GetFilteredLogLines() int64 {
+	return atomic.LoadInt64(&logLinesFiltered)
+}
+
+// GetOverflowedLogLines - Returns the number of log lines that were dropped
+// because too many lines were waiting to be sent, since startup
+func GetOverflowedLogLines() int64 {
+	return atomic.LoadInt64(&logLinesOverflowed)
+}
+
+// GetDroppedQuerySamples - Returns the number of query samples that were dropped
+// because too many were collected in a single batch, since startup
+func GetDroppedQuerySamples() int64 {
+	return atomic.LoadInt64(&querySamplesDropped)
+}
+
+type logProcessingPhase int
+
+const (
+	logPhaseStitching logProcessingPhase = iota
+	logPhaseAnalysis
+	logPhaseUpload
+)
+
+// Timings of the phases of AnalyzeInGroupsAndSend by server, since they were
+// last read through TakeLogProcessingStats
+var logProcessingStats = make(map[string]state.LogProcessingStats)
+var logProcessingStatsMutex sync.Mutex
+
+func recordLogProcessingDuration(server state.Server, phase logProcessingPhase, d time.Duration) {
+	logProcessingStatsMutex.Lock()
+	defer logProcessingStatsMutex.Unlock()
+
+	stats := logProcessingStats[server.Config.SectionName]
+	switch phase {
+	case logPhaseStitching:
+		stats.Stitching.Add(d)
+	case logPhaseAnalysis:
+		stats.Analysis.Add(d)
+	case logPhaseUpload:
+		stats.Upload.Add(d)
+	}
+	logProcessingStats[server.Config.SectionName] = stats
+}
+
+// TakeLogProcessingStats - Returns the log processing timings of the server
+// since the previous call, and starts over
+func TakeLogProcessingStats(server state.Server) state.LogProcessingStats {
+	logProcessingStatsMutex.Lock()
+	defer logProcessingStatsMutex.Unlock()
+
+	stats := logProcessingStats[server.Config.SectionName]
+	delete(logProcessingStats, server.Config.SectionName)
+	return stats
+}
@@ -0,0 +1,321 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+	uuid "github.com/satori/go.uuid"
+)
+
+// How long we wait for further frames of a message, before treating it as
+// complete - Postgres sends all frames of a message at once
+const syslogReassemblyTimeout = time.Second
+
+// Largest frame we accept over TCP (UDP is limited by the datagram size)
+const syslogMaxFrameSize = 1024 * 1024
+
+// Postgres prefixes each frame with "[sequence-chunk] ", or only "[chunk] " if
+// syslog_sequence_numbers is disabled
+var syslogChunkRegexp = regexp.MustCompile(`^\[(?:(\d+)-)?(\d+)\] ?`)
+
+var syslogLevelAndContentRegexp = regexp.MustCompile(`^(\w+):\s+(.*)$`)
+
+// SyslogLogSource - Receives Postgres log messages sent over syslog (RFC 5424),
+// both over UDP and TCP (with octet counting or newline framing, see RFC 6587)
+//
+// Postgres splits messages into multiple syslog frames (one for each line, and
+// long lines into multiple chunks), which get reassembled into a single log
+// line for each message.
+type SyslogLogSource struct {
+	mutex     sync.Mutex
+	pending   map[string]*syslogPendingMessage // By hostname and PID of the sender
+	complete  []state.LogLine
+	listeners []io.Closer
+
+	now func() time.Time
+}
+
+type syslogFrame struct {
+	timestamp time.Time
+	hostname  string
+	procID    string
+	message   string
+}
+
+type syslogPendingMessage struct {
+	frame      syslogFrame // First frame of the message
+	sequence   string
+	content    string
+	receivedAt time.Time // When the first frame was received
+	updatedAt  time.Time // When the last frame was received
+}
+
+// NewSyslogLogSource - Sets up a log source that receives frames once Listen gets called
+func NewSyslogLogSource() *SyslogLogSource {
+	return &SyslogLogSource{pending: make(map[string]*syslogPendingMessage), now: func() time.Time { return clock() }}
+}
+
+// Listen - Starts receiving syslog frames on the given address, over both UDP and TCP
+func (s *SyslogLogSource) Listen(address string) error {
+	packetConn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return fmt.Errorf("could not listen for syslog over UDP: %s", err)
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		packetConn.Close()
+		return fmt.Errorf("could not listen for syslog over TCP: %s", err)
+	}
+
+	s.mutex.Lock()
+	s.listeners = append(s.listeners, packetConn, listener)
+	s.mutex.Unlock()
+
+	go s.receiveUDP(packetConn)
+	go s.acceptTCP(listener)
+
+	return nil
+}
+
+// Close - Stops receiving syslog frames
+func (s *SyslogLogSource) Close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, listener := range s.listeners {
+		listener.Close()
+	}
+	s.listeners = nil
+}
+
+// GetLogLines - Returns all messages that were completely received since the last call
+func (s *SyslogLogSource) GetLogLines(ctx context.Context) ([]state.LogLine, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	var timedOut []*syslogPendingMessage
+	for key, message := range s.pending {
+		if now.Sub(message.updatedAt) >= syslogReassemblyTimeout {
+			timedOut = append(timedOut, message)
+			delete(s.pending, key)
+		}
+	}
+	sort.Slice(timedOut, func(i, j int) bool {
+		return timedOut[i].receivedAt.Before(timedOut[j].receivedAt)
+	})
+	for _, message := range timedOut {
+		s.complete = append(s.complete, message.logLine())
+	}
+
+	logLines := s.complete
+	s.complete = nil
+	return logLines, ctx.Err()
+}
+
+func (s *SyslogLogSource) receiveUDP(conn net.PacketConn) {
+	buf := make([]byte, 65536)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		s.receive(strings.TrimRight(string(buf[:n]), "\r\n"))
+	}
+}
+
+func (s *SyslogLogSource) acceptTCP(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			readSyslogTCPFrames(bufio.NewReader(conn), s.receive)
+		}()
+	}
+}
+
+// readSyslogTCPFrames - Reads frames that are either prefixed by their length
+// ("octet counting"), or terminated by a newline ("non-transparent framing")
+func readSyslogTCPFrames(reader *bufio.Reader, receive func(string)) {
+	for {
+		first, err := reader.Peek(1)
+		if err != nil {
+			return
+		}
+
+		if first[0] >= '0' && first[0] <= '9' {
+			lengthStr, err := reader.ReadString(' ')
+			if err != nil {
+				return
+			}
+			length, err := strconv.Atoi(strings.TrimSuffix(lengthStr, " "))
+			if err != nil || length > syslogMaxFrameSize {
+				return
+			}
+			frame := make([]byte, length)
+			if _, err = io.ReadFull(reader, frame); err != nil {
+				return
+			}
+			receive(string(frame))
+		} else {
+			frame, err := reader.ReadString('\n')
+			if frame = strings.TrimRight(frame, "\r\n"); frame != "" {
+				receive(frame)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// receive - Adds a frame to the message it belongs to
+func (s *SyslogLogSource) receive(rawFrame string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+
+	frame, err := parseSyslogFrame(rawFrame)
+	if err != nil {
+		// Not RFC 5424, but maybe still a line we can parse (e.g. RFC 3164 from rsyslog)
+		s.complete = append(s.complete, logLineFromSourceLine(rawFrame, now))
+		return
+	}
+
+	key := frame.hostname + "/" + frame.procID
+	pending := s.pending[key]
+
+	chunk := syslogChunkRegexp.FindStringSubmatch(frame.message)
+	if chunk == nil {
+		if pending != nil {
+			s.complete = append(s.complete, pending.logLine())
+			delete(s.pending, key)
+		}
+		s.complete = append(s.complete, (&syslogPendingMessage{frame: frame, content: frame.message, receivedAt: now}).logLine())
+		return
+	}
+	sequence := chunk[1]
+	text := strings.Replace(frame.message[len(chunk[0]):], "#011", "\t", -1)
+
+	if pending != nil && (chunk[2] == "1" || sequence != pending.sequence) {
+		s.complete = append(s.complete, pending.logLine())
+		delete(s.pending, key)
+		pending = nil
+	}
+	if pending == nil {
+		s.pending[key] = &syslogPendingMessage{frame: frame, sequence: sequence, content: text, receivedAt: now, updatedAt: now}
+		return
+	}
+
+	// Frames for new lines start with a tab (Postgres indents continuation lines),
+	// otherwise it's a long line that got split into chunks at a space
+	if strings.HasPrefix(text, "\t") {
+		pending.content += "\n" + text
+	} else {
+		pending.content += text
+	}
+	pending.updatedAt = now
+}
+
+// parseSyslogFrame - Parses a frame in the RFC 5424 format:
+// "<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA [MSG]"
+func parseSyslogFrame(rawFrame string) (frame syslogFrame, err error) {
+	if !strings.HasPrefix(rawFrame, "<") {
+		return frame, fmt.Errorf("missing priority")
+	}
+	end := strings.Index(rawFrame, ">")
+	if end == -1 || !strings.HasPrefix(rawFrame[end+1:], "1 ") {
+		return frame, fmt.Errorf("not an RFC 5424 frame")
+	}
+
+	fields := strings.SplitN(rawFrame[end+3:], " ", 6)
+	if len(fields) != 6 {
+		return frame, fmt.Errorf("missing header fields")
+	}
+	if fields[0] != "-" {
+		frame.timestamp, err = time.Parse(time.RFC3339Nano, fields[0])
+		if err != nil {
+			return frame, err
+		}
+	}
+	frame.hostname = fields[1]
+	frame.procID = fields[3]
+
+	rest := fields[5]
+	if strings.HasPrefix(rest, "-") {
+		rest = rest[1:]
+	} else {
+		for strings.HasPrefix(rest, "[") {
+			idx := 1
+			for idx < len(rest) && rest[idx] != ']' {
+				if rest[idx] == '\\' {
+					idx++
+				}
+				idx++
+			}
+			if idx >= len(rest) {
+				return frame, fmt.Errorf("unterminated structured data")
+			}
+			rest = rest[idx+1:]
+		}
+	}
+	frame.message = strings.TrimPrefix(strings.TrimPrefix(rest, " "), "\xEF\xBB\xBF")
+
+	return frame, nil
+}
+
+// logLine - Parses the reassembled message, which contains the log_line_prefix
+// (if any), the log level and the content
+func (m *syslogPendingMessage) logLine() state.LogLine {
+	firstLine, rest := m.content, ""
+	if idx := strings.IndexByte(m.content, '\n'); idx != -1 {
+		firstLine, rest = m.content[:idx], m.content[idx:]
+	}
+
+	logLine, ok := ParseLogLineWithPrefix("", firstLine)
+	if !ok {
+		logLine = state.LogLine{Content: firstLine}
+		if parts := LogPrefixNoTimestampUserDatabaseAppRegexp.FindStringSubmatch(firstLine); len(parts) == 6 {
+			logLine.Username = parts[1]
+			logLine.Database = parts[2]
+			logLine.Application = parts[3]
+			logLine.LogLevel = parseLogLevel(parts[4])
+			logLine.Content = parts[5]
+		} else if parts := syslogLevelAndContentRegexp.FindStringSubmatch(firstLine); parts != nil && parseLogLevel(parts[1]) != pganalyze_collector.LogLineInformation_UNKNOWN {
+			logLine.LogLevel = parseLogLevel(parts[1])
+			logLine.Content = parts[2]
+		}
+	}
+	logLine.Content += rest
+
+	if logLine.OccurredAt.IsZero() {
+		logLine.OccurredAt = m.frame.timestamp
+	}
+	if logLine.BackendPid == 0 {
+		pid, _ := strconv.Atoi(m.frame.procID)
+		logLine.BackendPid = int32(pid)
+	}
+	logLine.CollectedAt = m.receivedAt
+	logLine.UUID = uuid.NewV4()
+
+	return logLine
+}
+
+func parseLogLevel(level string) pganalyze_collector.LogLineInformation_LogLevel {
+	return pganalyze_collector.LogLineInformation_LogLevel(pganalyze_collector.LogLineInformation_LogLevel_value[level])
+}
@@ -0,0 +1,125 @@
+package logs_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/input/system/logs"
+	"github.com/pganalyze/collector/output/pganalyze_collector"
+)
+
+type syslogLogLine struct {
+	OccurredAt time.Time
+	BackendPid int32
+	LogLevel   pganalyze_collector.LogLineInformation_LogLevel
+	Database   string
+	Content    string
+}
+
+func TestSyslogLogSource(t *testing.T) {
+	now := time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+	source := logs.NewSyslogLogSource()
+
+	frames := []string{
+		// A multi-line query, sent as one frame per line
+		`<134>1 2018-10-16T11:59:59.123Z db1 postgres 1234 - - [5-1] [user=app,db=mydb,app=web] LOG:  duration: 1.000 ms  statement: SELECT *`,
+		`<134>1 2018-10-16T11:59:59.123Z db1 postgres 1234 - - [5-2] #011FROM items`,
+		// Another backend sending at the same time, with structured data and a BOM
+		`<131>1 2018-10-16T11:59:59.200Z db1 postgres 4321 - [meta sequenceId="1\]"] ` + "\xEF\xBB\xBF" + `[2-1] ERROR:  relation "foo" does not exist`,
+		// A long line, split into chunks at a space
+		`<134>1 2018-10-16T11:59:59.300Z db1 postgres 1234 - - [6-1] LOG:  checkpoint complete: wrote 5 buffers`,
+		`<134>1 2018-10-16T11:59:59.300Z db1 postgres 1234 - - [6-2]  (0.1%); 0 WAL file(s) added`,
+		// Without sequence numbers
+		`<134>1 2018-10-16T11:59:59.400Z db1 postgres 1234 - - [1] LOG:  connection received: host=127.0.0.1 port=5432`,
+	}
+	for _, frame := range frames {
+		source.ReceiveSyslogFrame(frame, now)
+	}
+
+	// The last message of each backend could still be followed by more frames
+	source.SetSyslogNow(now)
+	logLines, err := source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var result []syslogLogLine
+	for _, logLine := range logLines {
+		result = append(result, syslogLogLine{logLine.OccurredAt.UTC(), logLine.BackendPid, logLine.LogLevel, logLine.Database, logLine.Content})
+	}
+	expected := []syslogLogLine{
+		{time.Date(2018, time.October, 16, 11, 59, 59, 123000000, time.UTC), 1234, pganalyze_collector.LogLineInformation_LOG, "mydb", "duration: 1.000 ms  statement: SELECT *\n\tFROM items"},
+		{time.Date(2018, time.October, 16, 11, 59, 59, 300000000, time.UTC), 1234, pganalyze_collector.LogLineInformation_LOG, "", "checkpoint complete: wrote 5 buffers (0.1%); 0 WAL file(s) added"},
+	}
+	if diff := pretty.Compare(expected, result); diff != "" {
+		t.Errorf("Reassembled messages diff: (-want +got)\n%s", diff)
+	}
+
+	// Once no more frames arrive, the last message of each backend is complete as well
+	source.SetSyslogNow(now.Add(2 * time.Second))
+	logLines, _ = source.GetLogLines(context.Background())
+	contents := make(map[int32]string)
+	for _, logLine := range logLines {
+		contents[logLine.BackendPid] = logLine.LogLevel.String() + ": " + logLine.Content
+	}
+	expectedContents := map[int32]string{
+		1234: "LOG: connection received: host=127.0.0.1 port=5432",
+		4321: `ERROR: relation "foo" does not exist`,
+	}
+	if diff := pretty.Compare(expectedContents, contents); diff != "" {
+		t.Errorf("Messages after timeout diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestSyslogLogSourceListen(t *testing.T) {
+	// Find a port that is free for TCP, and hope it's free for UDP as well
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Could not find free port: %s", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	source := logs.NewSyslogLogSource()
+	if err = source.Listen(address); err != nil {
+		t.Fatalf("Could not listen: %s", err)
+	}
+	defer source.Close()
+
+	frame1 := `<134>1 2018-10-16T11:59:59Z db1 postgres 1234 - - [1-1] LOG:  statement: SELECT 1`
+	frame2 := `<134>1 2018-10-16T11:59:59Z db1 postgres 1234 - - [1-2] #011FROM items`
+	frame3 := `<134>1 2018-10-16T11:59:59Z db1 postgres 99 - - [1-1] LOG:  statement: SELECT 2`
+
+	tcpConn, err := net.Dial("tcp", address)
+	if err != nil {
+		t.Fatalf("Could not connect over TCP: %s", err)
+	}
+	// Octet counting and newline framing can be mixed
+	fmt.Fprintf(tcpConn, "%d %s%s\n", len(frame1), frame1, frame2)
+	tcpConn.Close()
+
+	udpConn, err := net.Dial("udp", address)
+	if err != nil {
+		t.Fatalf("Could not connect over UDP: %s", err)
+	}
+	fmt.Fprint(udpConn, frame3)
+	udpConn.Close()
+
+	contents := make(map[int32]string)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(contents) < 2 && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+		logLines, _ := source.GetLogLines(context.Background())
+		for _, logLine := range logLines {
+			contents[logLine.BackendPid] = logLine.Content
+		}
+	}
+
+	expected := map[int32]string{1234: "statement: SELECT 1\n\tFROM items", 99: "statement: SELECT 2"}
+	if diff := pretty.Compare(expected, contents); diff != "" {
+		t.Errorf("Received messages diff: (-want +got)\n%s", diff)
+	}
+}
@@ -0,0 +1,40 @@
+package logs
+
+import (
+	"unicode/utf8"
+
+	"github.com/pganalyze/collector/state"
+)
+
+// Appended to query samples that were cut off because they exceeded max_query_sample_length
+const querySampleTruncationMarker = " /* truncated by collector */"
+
+// TruncateQuerySamples - Cuts off query texts longer than maxLength bytes
+// (including the marker that gets appended), keeping their leading portion
+//
+// This runs after RedactQuerySamples, so it only ever shortens text that was
+// already redacted. A maxLength of zero disables truncation.
+func TruncateQuerySamples(samples []state.PostgresQuerySample, maxLength int) []state.PostgresQuerySample {
+	if maxLength <= 0 {
+		return samples
+	}
+
+	for idx, sample := range samples {
+		if len(sample.Query) <= maxLength {
+			continue
+		}
+
+		keep := maxLength - len(querySampleTruncationMarker)
+		if keep < 0 {
+			keep = 0
+		}
+		// Don't split a multi-byte character
+		for keep > 0 && !utf8.RuneStart(sample.Query[keep]) {
+			keep--
+		}
+		sample.Query = sample.Query[:keep] + querySampleTruncationMarker
+		samples[idx] = sample
+	}
+
+	return samples
+}
@@ -0,0 +1,76 @@
+package logs_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/input/system/logs"
+	"github.com/pganalyze/collector/state"
+)
+
+func TestTruncateQuerySamples(t *testing.T) {
+	const maxLength = 1000
+
+	// A 1MB bulk INSERT
+	var query strings.Builder
+	query.WriteString("INSERT INTO x (y) VALUES ('a')")
+	for query.Len() < 1024*1024 {
+		query.WriteString(", ('ü')")
+	}
+
+	samples := []state.PostgresQuerySample{
+		{Query: query.String(), Parameters: []string{"1"}},
+		{Query: "SELECT 1"},
+	}
+	samples = logs.TruncateQuerySamples(samples, maxLength)
+
+	truncated := samples[0].Query
+	if len(truncated) > maxLength || len(truncated) < maxLength-utf8.UTFMax {
+		t.Errorf("Expected query to be truncated to %d bytes, got %d", maxLength, len(truncated))
+	}
+	if !strings.HasSuffix(truncated, " /* truncated by collector */") {
+		t.Errorf("Expected truncation marker at the end of the query, got: ...%s", truncated[len(truncated)-50:])
+	}
+	if !strings.HasPrefix(query.String(), strings.TrimSuffix(truncated, " /* truncated by collector */")) {
+		t.Errorf("Expected the beginning of the query to be kept")
+	}
+	if !utf8.ValidString(truncated) {
+		t.Errorf("Expected truncated query to be valid UTF-8")
+	}
+	if len(samples[0].Parameters) != 1 {
+		t.Errorf("Expected parameters to be left alone, got %v", samples[0].Parameters)
+	}
+	if samples[1].Query != "SELECT 1" {
+		t.Errorf("Expected short query to be kept as is, got %q", samples[1].Query)
+	}
+}
+
+func TestTruncateQuerySamplesAfterRedaction(t *testing.T) {
+	samples := []state.PostgresQuerySample{{Query: "SELECT * FROM x WHERE y IN ('a', 'b', 'c', 'd', 'e', 'f', 'g', 'h')", Parameters: []string{"'secret'"}}}
+
+	samples = logs.RedactQuerySamples(samples, config.ServerConfig{FilterQuerySample: "normalize"})
+	if problems := logs.ValidateQuerySampleRedaction(samples, config.ServerConfig{FilterQuerySample: "normalize"}); len(problems) > 0 {
+		t.Errorf("Expected redacted query sample to pass redaction check, got problems: %v", problems)
+	}
+	samples = logs.TruncateQuerySamples(samples, 50)
+
+	if strings.Contains(samples[0].Query, "'") || len(samples[0].Parameters) > 0 {
+		t.Errorf("Expected truncated query sample to stay redacted, got %q with parameters %v", samples[0].Query, samples[0].Parameters)
+	}
+}
+
+func TestValidateQuerySampleRedactionBeforeTruncation(t *testing.T) {
+	samples := []state.PostgresQuerySample{{Query: "SELECT * FROM x WHERE y = 'secret' AND z IN (1, 2, 3, 4, 5, 6, 7, 8)"}}
+
+	// Truncated texts can't be parsed anymore, so a literal left in them would
+	// be missed - which is why the check runs on the complete text
+	if problems := logs.ValidateQuerySampleRedaction(samples, config.ServerConfig{FilterQuerySample: "normalize"}); len(problems) != 1 {
+		t.Errorf("Expected 1 problem for the complete query sample, got: %v", problems)
+	}
+	samples = logs.TruncateQuerySamples(samples, 50)
+	if problems := logs.ValidateQuerySampleRedaction(samples, config.ServerConfig{FilterQuerySample: "normalize"}); len(problems) != 1 {
+		t.Errorf("Expected 1 problem for the truncated query sample, got: %v", problems)
+	}
+}
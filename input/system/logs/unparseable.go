@@ -0,0 +1,77 @@
+package logs
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// UnparseableLogLineSamplesMax - How many unparseable lines are kept per server
+// for the test output
+var UnparseableLogLineSamplesMax = 5
+
+// Samples are cut off after this many bytes, since they might be large garbage
+const unparseableLogLineSampleMaxLength = 200
+
+// String literals might contain sensitive data, and can't be told apart from
+// the rest of the line reliably without parsing it
+var unparseableLogLineLiteralRegexp = regexp.MustCompile(`'(?:[^']|'')*'`)
+
+// UnparseableLogLines - Log lines of a server that had neither a level nor a PID,
+// and didn't follow any other line they could belong to
+type UnparseableLogLines struct {
+	Count   int64
+	Samples []string // Redacted content of the first lines, only kept during test runs
+}
+
+// Unparseable lines by server, since they were last read through TakeUnparseableLogLines
+var unparseableLogLines = make(map[string]UnparseableLogLines)
+var unparseableLogLinesMutex sync.Mutex
+
+func recordUnparseableLogLines(server state.Server, logLines []state.LogLine, keepSamples bool) {
+	if len(logLines) == 0 {
+		return
+	}
+	atomic.AddInt64(&logLinesUnparseable, int64(len(logLines)))
+
+	unparseableLogLinesMutex.Lock()
+	defer unparseableLogLinesMutex.Unlock()
+
+	unparseable := unparseableLogLines[server.Config.SectionName]
+	unparseable.Count += int64(len(logLines))
+	if keepSamples {
+		for _, logLine := range logLines {
+			if len(unparseable.Samples) >= UnparseableLogLineSamplesMax {
+				break
+			}
+			unparseable.Samples = append(unparseable.Samples, redactUnparseableLogLine(logLine.Content))
+		}
+	}
+	unparseableLogLines[server.Config.SectionName] = unparseable
+}
+
+// TakeUnparseableLogLines - Returns the unparseable log lines of the server
+// since the previous call, and starts over
+func TakeUnparseableLogLines(server state.Server) UnparseableLogLines {
+	unparseableLogLinesMutex.Lock()
+	defer unparseableLogLinesMutex.Unlock()
+
+	unparseable := unparseableLogLines[server.Config.SectionName]
+	delete(unparseableLogLines, server.Config.SectionName)
+	return unparseable
+}
+
+func redactUnparseableLogLine(content string) string {
+	// Redact before truncating, since a literal that gets cut off no longer
+	// has its closing quote and would otherwise be kept as-is
+	content = unparseableLogLineLiteralRegexp.ReplaceAllString(strings.TrimSpace(content), "'<redacted>'")
+	content = util.RedactSecrets(content)
+	if len(content) > unparseableLogLineSampleMaxLength {
+		content = content[:unparseableLogLineSampleMaxLength] + "..."
+	}
+	return content
+}
@@ -0,0 +1,185 @@
+package rds
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/pganalyze/collector/input/system/logs"
+	"github.com/pganalyze/collector/state"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Upper bound for GetLogEvents calls per run, so a large backlog (e.g. after
+// the collector was stopped for a while) gets worked off over multiple runs
+const cloudWatchLogsMaxPagesPerRun = 20
+
+// How far back we start reading when we don't have a position for the log group yet
+const cloudWatchLogsInitialLookback = time.Minute
+
+// CloudWatchLogSource - Reads log events from a CloudWatch Logs log group, as
+// written by RDS when log exports are enabled
+//
+// RDS starts a new log stream in the log group from time to time, and we always
+// follow the stream that received events most recently. The position we left
+// off at is kept, so it can be persisted between runs (see Position).
+type CloudWatchLogSource struct {
+	client       cloudwatchlogsiface.CloudWatchLogsAPI
+	logGroupName string
+	position     state.CloudWatchLogsPosition
+
+	now func() time.Time
+}
+
+// NewCloudWatchLogSource - Sets up a log source for the given log group, continuing at the given position
+func NewCloudWatchLogSource(client cloudwatchlogsiface.CloudWatchLogsAPI, logGroupName string, position state.CloudWatchLogsPosition) *CloudWatchLogSource {
+	return &CloudWatchLogSource{client: client, logGroupName: logGroupName, position: position, now: time.Now}
+}
+
+// Position - Returns where the source left off, to be passed to NewCloudWatchLogSource on the next run
+func (s *CloudWatchLogSource) Position() state.CloudWatchLogsPosition {
+	return s.position
+}
+
+// GetLogLines - Returns all log lines that were written to the log group since the last call
+func (s *CloudWatchLogSource) GetLogLines(ctx context.Context) ([]state.LogLine, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	latestStreamName, err := s.findLatestLogStream()
+	if err != nil {
+		return nil, err
+	}
+	if latestStreamName == "" {
+		return nil, nil
+	}
+
+	if s.position.LogStreamName == "" {
+		s.position = state.CloudWatchLogsPosition{
+			LogStreamName:      latestStreamName,
+			LastEventTimestamp: timeToCloudWatchTimestamp(s.now().Add(-cloudWatchLogsInitialLookback)),
+		}
+	}
+
+	var logLines []state.LogLine
+	if s.position.LogStreamName != latestStreamName {
+		// The log group rotated to a new stream - read what's left in the old one
+		// first, unless it got removed in the meantime
+		logLines, err = s.readLogStream(ctx)
+		if err != nil && !isAwsErrorCode(err, cloudwatchlogs.ErrCodeResourceNotFoundException) {
+			return logLines, err
+		}
+		s.position.LogStreamName = latestStreamName
+		s.position.NextToken = ""
+	}
+
+	newLogLines, err := s.readLogStream(ctx)
+	return append(logLines, newLogLines...), err
+}
+
+func (s *CloudWatchLogSource) findLatestLogStream() (string, error) {
+	resp, err := s.client.DescribeLogStreams(&cloudwatchlogs.DescribeLogStreamsInput{
+		LogGroupName: aws.String(s.logGroupName),
+		OrderBy:      aws.String(cloudwatchlogs.OrderByLastEventTime),
+		Descending:   aws.Bool(true),
+		Limit:        aws.Int64(1),
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.LogStreams) == 0 {
+		return "", nil
+	}
+	return aws.StringValue(resp.LogStreams[0].LogStreamName), nil
+}
+
+// readLogStream - Reads new events from the current log stream, and advances the position
+func (s *CloudWatchLogSource) readLogStream(ctx context.Context) ([]state.LogLine, error) {
+	var logLines []state.LogLine
+	collectedAt := s.now()
+
+	for page := 0; page < cloudWatchLogsMaxPagesPerRun; page++ {
+		if err := ctx.Err(); err != nil {
+			return logLines, err
+		}
+
+		input := &cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(s.logGroupName),
+			LogStreamName: aws.String(s.position.LogStreamName),
+			StartFromHead: aws.Bool(true),
+		}
+		if s.position.NextToken != "" {
+			input.NextToken = aws.String(s.position.NextToken)
+		} else {
+			input.StartTime = aws.Int64(s.position.LastEventTimestamp + 1)
+		}
+
+		resp, err := s.client.GetLogEvents(input)
+		if err != nil {
+			if input.NextToken != nil && isAwsErrorCode(err, cloudwatchlogs.ErrCodeInvalidParameterException) {
+				// Tokens expire after a while (e.g. when the collector wasn't running),
+				// continue after the last event we've seen instead
+				s.position.NextToken = ""
+				continue
+			}
+			return logLines, err
+		}
+
+		for _, event := range resp.Events {
+			logLines = append(logLines, logLineFromCloudWatchEvent(event, collectedAt))
+			if timestamp := aws.Int64Value(event.Timestamp); timestamp > s.position.LastEventTimestamp {
+				s.position.LastEventTimestamp = timestamp
+			}
+		}
+
+		// The forward token stays the same once we've reached the end of the stream
+		nextToken := aws.StringValue(resp.NextForwardToken)
+		if nextToken == "" || nextToken == aws.StringValue(input.NextToken) {
+			return logLines, nil
+		}
+		s.position.NextToken = nextToken
+	}
+
+	return logLines, nil
+}
+
+func logLineFromCloudWatchEvent(event *cloudwatchlogs.OutputLogEvent, collectedAt time.Time) state.LogLine {
+	// Each event is a complete log entry, with any continuation lines (e.g. of a
+	// multi-line query) included in the message
+	lines := strings.Split(strings.TrimSuffix(aws.StringValue(event.Message), "\n"), "\n")
+
+	// We ignore failures here since we want the per-backend stitching logic
+	// that runs later on (and any other parsing errors will just be ignored)
+	logLine, _ := logs.ParseLogLineWithPrefix("", lines[0])
+	if logLine.Content == "" {
+		logLine.Content = lines[0]
+	}
+	for _, line := range lines[1:] {
+		logLine.Content += "\n" + line
+	}
+	if logLine.OccurredAt.IsZero() {
+		logLine.OccurredAt = cloudWatchTimestampToTime(aws.Int64Value(event.Timestamp))
+	}
+	logLine.CollectedAt = collectedAt
+	logLine.UUID = uuid.NewV4()
+	return logLine
+}
+
+func isAwsErrorCode(err error, code string) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == code
+}
+
+// CloudWatch timestamps are milliseconds since the epoch
+func timeToCloudWatchTimestamp(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func cloudWatchTimestampToTime(timestamp int64) time.Time {
+	return time.Unix(0, timestamp*int64(time.Millisecond))
+}
@@ -0,0 +1,154 @@
+package rds
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+)
+
+// fakeCloudWatchLogs - Serves events from in-memory log streams, using the
+// index of the next event as the forward token
+type fakeCloudWatchLogs struct {
+	cloudwatchlogsiface.CloudWatchLogsAPI
+
+	streamNames  []string // Ordered from oldest to newest
+	streams      map[string][]*cloudwatchlogs.OutputLogEvent
+	expiredToken string
+	pageSize     int
+}
+
+func (f *fakeCloudWatchLogs) DescribeLogStreams(input *cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	if len(f.streamNames) == 0 {
+		return &cloudwatchlogs.DescribeLogStreamsOutput{}, nil
+	}
+	latest := f.streamNames[len(f.streamNames)-1]
+	return &cloudwatchlogs.DescribeLogStreamsOutput{LogStreams: []*cloudwatchlogs.LogStream{{LogStreamName: aws.String(latest)}}}, nil
+}
+
+func (f *fakeCloudWatchLogs) GetLogEvents(input *cloudwatchlogs.GetLogEventsInput) (*cloudwatchlogs.GetLogEventsOutput, error) {
+	events, ok := f.streams[aws.StringValue(input.LogStreamName)]
+	if !ok {
+		return nil, awserr.New(cloudwatchlogs.ErrCodeResourceNotFoundException, "stream not found", nil)
+	}
+
+	start := 0
+	if input.NextToken != nil {
+		if aws.StringValue(input.NextToken) == f.expiredToken {
+			return nil, awserr.New(cloudwatchlogs.ErrCodeInvalidParameterException, "token expired", nil)
+		}
+		fmt.Sscanf(aws.StringValue(input.NextToken), "f/%d", &start)
+	} else {
+		for start < len(events) && aws.Int64Value(events[start].Timestamp) < aws.Int64Value(input.StartTime) {
+			start++
+		}
+	}
+
+	end := start + f.pageSize
+	if end > len(events) {
+		end = len(events)
+	}
+	return &cloudwatchlogs.GetLogEventsOutput{
+		Events:           events[start:end],
+		NextForwardToken: aws.String(fmt.Sprintf("f/%d", end)),
+	}, nil
+}
+
+func (f *fakeCloudWatchLogs) addEvent(streamName string, timestamp time.Time, message string) {
+	if _, ok := f.streams[streamName]; !ok {
+		f.streamNames = append(f.streamNames, streamName)
+	}
+	f.streams[streamName] = append(f.streams[streamName], &cloudwatchlogs.OutputLogEvent{
+		Timestamp: aws.Int64(timeToCloudWatchTimestamp(timestamp)),
+		Message:   aws.String(message),
+	})
+}
+
+type cloudWatchLogLine struct {
+	OccurredAt time.Time
+	Content    string
+}
+
+func summarizeCloudWatchLogLines(logLines []state.LogLine) (result []cloudWatchLogLine) {
+	for _, logLine := range logLines {
+		result = append(result, cloudWatchLogLine{logLine.OccurredAt.UTC(), logLine.Content})
+	}
+	return
+}
+
+func TestCloudWatchLogSource(t *testing.T) {
+	now := time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+	client := &fakeCloudWatchLogs{streams: make(map[string][]*cloudwatchlogs.OutputLogEvent), pageSize: 2}
+
+	client.addEvent("db.0", now.Add(-5*time.Minute), "2018-10-16 11:55:00 UTC:127.0.0.1(1234):postgres@mydb:[100]:LOG:  too old")
+	client.addEvent("db.0", now.Add(-30*time.Second), "2018-10-16 11:59:30 UTC:127.0.0.1(1234):postgres@mydb:[100]:LOG:  first")
+	client.addEvent("db.0", now.Add(-20*time.Second), "2018-10-16 11:59:40 UTC:127.0.0.1(1234):postgres@mydb:[100]:LOG:  duration: 1.0 ms  statement: SELECT 1\n\tFROM foo")
+	client.addEvent("db.0", now.Add(-10*time.Second), "no prefix")
+
+	source := NewCloudWatchLogSource(client, "/aws/rds/instance/db/postgresql", state.CloudWatchLogsPosition{})
+	source.now = func() time.Time { return now }
+
+	logLines, err := source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := []cloudWatchLogLine{
+		{now.Add(-30 * time.Second), "first"},
+		{now.Add(-20 * time.Second), "duration: 1.0 ms  statement: SELECT 1\n\tFROM foo"},
+		{now.Add(-10 * time.Second), "no prefix"},
+	}
+	if diff := pretty.Compare(expected, summarizeCloudWatchLogLines(logLines)); diff != "" {
+		t.Errorf("First read: (-want +got)\n%s", diff)
+	}
+	for _, logLine := range logLines {
+		if !logLine.CollectedAt.Equal(now) {
+			t.Errorf("Expected CollectedAt to be %s, got %s", now, logLine.CollectedAt)
+		}
+	}
+
+	// Continuing with the token only returns new events
+	client.addEvent("db.0", now.Add(5*time.Second), "2018-10-16 12:00:05 UTC:127.0.0.1(1234):postgres@mydb:[100]:LOG:  second")
+	logLines, err = source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected = []cloudWatchLogLine{{now.Add(5 * time.Second), "second"}}
+	if diff := pretty.Compare(expected, summarizeCloudWatchLogLines(logLines)); diff != "" {
+		t.Errorf("Continued read: (-want +got)\n%s", diff)
+	}
+
+	// When the log group rotates, the rest of the old stream is read before the new one
+	client.addEvent("db.0", now.Add(6*time.Second), "2018-10-16 12:00:06 UTC:127.0.0.1(1234):postgres@mydb:[100]:LOG:  third")
+	client.addEvent("db.1", now.Add(7*time.Second), "2018-10-16 12:00:07 UTC:127.0.0.1(1234):postgres@mydb:[100]:LOG:  fourth")
+	logLines, err = source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected = []cloudWatchLogLine{{now.Add(6 * time.Second), "third"}, {now.Add(7 * time.Second), "fourth"}}
+	if diff := pretty.Compare(expected, summarizeCloudWatchLogLines(logLines)); diff != "" {
+		t.Errorf("Rotated read: (-want +got)\n%s", diff)
+	}
+	if source.Position().LogStreamName != "db.1" {
+		t.Errorf("Expected position to have moved to the new log stream, got %s", source.Position().LogStreamName)
+	}
+
+	// A position restored from the state file whose token expired continues after the last seen event
+	client.addEvent("db.1", now.Add(8*time.Second), "2018-10-16 12:00:08 UTC:127.0.0.1(1234):postgres@mydb:[100]:LOG:  fifth")
+	client.expiredToken = source.Position().NextToken
+	source = NewCloudWatchLogSource(client, "/aws/rds/instance/db/postgresql", source.Position())
+	logLines, err = source.GetLogLines(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected = []cloudWatchLogLine{{now.Add(8 * time.Second), "fifth"}}
+	if diff := pretty.Compare(expected, summarizeCloudWatchLogLines(logLines)); diff != "" {
+		t.Errorf("Read with expired token: (-want +got)\n%s", diff)
+	}
+}
@@ -15,7 +15,12 @@ import (
 )
 
 // DownloadLogFiles - Gets log files for an Amazon RDS instance
-func DownloadLogFiles(config config.ServerConfig, logger *util.Logger) (result []state.LogFile, samples []state.PostgresQuerySample) {
+//
+// Each log file is read from where the previous run left off (based on the
+// markers in the position), and the new position is returned.
+func DownloadLogFiles(config config.ServerConfig, position state.RdsLogsPosition, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (result []state.LogFile, samples []state.PostgresQuerySample, newPosition state.RdsLogsPosition) {
+	newPosition = position
+
 	sess := awsutil.GetAwsSession(config)
 
 	rdsSvc := rds.New(sess)
@@ -26,9 +31,14 @@ func DownloadLogFiles(config config.ServerConfig, logger *util.Logger) (result [
 		return
 	}
 
-	// Retrieve all possibly matching logfiles in the last two minutes, assuming
-	// a scheduler that runs more frequently than that
-	linesNewerThan := time.Now().Add(-2 * time.Minute)
+	// Retrieve all log files written since the last download, or in the last
+	// two minutes if there is none, assuming a scheduler that runs more
+	// frequently than that
+	now := time.Now()
+	linesNewerThan := now.Add(-2 * time.Minute)
+	if !position.LastDownloadedAt.IsZero() {
+		linesNewerThan = position.LastDownloadedAt
+	}
 	lastWritten := linesNewerThan.Unix() * 1000
 
 	params := &rds.DescribeDBLogFilesInput{
@@ -42,28 +52,36 @@ func DownloadLogFiles(config config.ServerConfig, logger *util.Logger) (result [
 		return
 	}
 
+	nextPosition := state.RdsLogsPosition{LastDownloadedAt: now, Markers: make(map[string]string)}
+	incomplete := false
+files:
 	for _, rdsLogFile := range resp.DescribeDBLogFiles {
 		var lastMarker *string
+		fileLinesNewerThan := linesNewerThan
+
+		// With a marker we continue right after the lines we read before, instead
+		// of only getting the most recent data (and skipping lines)
+		if marker, ok := position.Markers[*rdsLogFile.LogFileName]; ok {
+			lastMarker = aws.String(marker)
+			fileLinesNewerThan = time.Time{}
+		}
 
 		var logFile state.LogFile
 		logFile.UUID = uuid.NewV4()
-		logFile.TmpFile, err = ioutil.TempFile("", "")
+		logFile.TmpFile, err = ioutil.TempFile(globalCollectionOpts.LogTempDir, "")
 		if err != nil {
 			logger.PrintError("Could not allocate tempfile for logs: %s", err)
+			incomplete = true
 			break
 		}
 		logFile.OriginalName = *rdsLogFile.LogFileName
 		currentByteStart := int64(0)
 
-		// Some day this logic needs to be changed so we remember the marker in the
-		// collector state - then we can still pass no marker for the initial call
-		// (as we do now), but then afterwards keep tracking a position in the file
-		// instead of only getting the most recent data (and skipping lines)
 		for {
 			resp, err := rdsSvc.DownloadDBLogFilePortion(&rds.DownloadDBLogFilePortionInput{
 				DBInstanceIdentifier: instance.DBInstanceIdentifier,
 				LogFileName:          rdsLogFile.LogFileName,
-				Marker:               lastMarker,     // This is not set for the initial call, so we only get the most recent lines
+				Marker:               lastMarker,     // This is not set for a new file, so we only get the most recent lines
 				NumberOfLines:        aws.Int64(500), // This is the effective maximum lines retrieved per run
 			})
 
@@ -72,8 +90,12 @@ func DownloadLogFiles(config config.ServerConfig, logger *util.Logger) (result [
 				// Error: AccessDenied: User: arn:aws:iam::XXX:user/pganalyze_collector is not authorized to perform: rds:DownloadDBLogFilePortion on resource: arn:aws:rds:us-east-1:XXX:db:XXX
 				// status code: 403, request id: XXX
 				logger.PrintError("%s", err)
-				logFile.Cleanup()
-				return
+				err = logFile.Cleanup()
+				if err != nil {
+					logger.PrintError("Could not remove log tempfile: %s", err)
+				}
+				incomplete = true
+				break files
 			}
 
 			if resp.LogFileData == nil {
@@ -89,7 +111,7 @@ func DownloadLogFiles(config config.ServerConfig, logger *util.Logger) (result [
 
 			var newLogLines []state.LogLine
 			var newSamples []state.PostgresQuerySample
-			newLogLines, newSamples, currentByteStart = logs.ParseAndAnalyzeBuffer(*resp.LogFileData, currentByteStart, linesNewerThan)
+			newLogLines, newSamples, currentByteStart = logs.ParseAndAnalyzeBuffer(*resp.LogFileData, currentByteStart, fileLinesNewerThan)
 			logFile.LogLines = append(logFile.LogLines, newLogLines...)
 			samples = append(samples, newSamples...)
 
@@ -103,8 +125,25 @@ func DownloadLogFiles(config config.ServerConfig, logger *util.Logger) (result [
 			}
 		}
 
+		if lastMarker != nil {
+			nextPosition.Markers[*rdsLogFile.LogFileName] = *lastMarker
+		}
+
 		result = append(result, logFile)
 	}
 
+	// Files that were not read are read again from their previous position on
+	// the next run
+	if incomplete {
+		nextPosition.LastDownloadedAt = position.LastDownloadedAt
+		for name, marker := range position.Markers {
+			if _, ok := nextPosition.Markers[name]; !ok {
+				nextPosition.Markers[name] = marker
+			}
+		}
+	}
+
+	newPosition = nextPosition
+
 	return
 }
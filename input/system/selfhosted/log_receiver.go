@@ -2,6 +2,7 @@ package selfhosted
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -12,6 +13,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
@@ -51,7 +53,7 @@ func DiscoverLogLocation(servers []state.Server, globalCollectionOpts state.Coll
 	for _, server := range servers {
 		prefixedLogger := logger.WithPrefix(server.Config.SectionName)
 
-		if server.Config.DbHost != "localhost" && server.Config.DbHost != "127.0.0.1" {
+		if server.Config.DbSocketDir == "" && server.Config.DbHost != "localhost" && server.Config.DbHost != "127.0.0.1" {
 			prefixedLogger.PrintError("ERROR - Detected remote server - Log Insights requires the collector to run on the database server directly for self-hosted systems")
 			continue
 		}
@@ -118,8 +120,27 @@ func DiscoverLogLocation(servers []state.Server, globalCollectionOpts state.Coll
 
 // SetupLogTails - Sets up continuously running log tails for all servers with a
 // local log directory or file specified
-func SetupLogTails(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) chan bool {
+//
+// Sending on the returned channel stops all log tails, and cancels any log
+// sending that is still in progress. Log lines that were not sent yet then get
+// flushed in a final attempt, which wg (if given) waits for.
+func SetupLogTails(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger, wg *sync.WaitGroup) chan bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopRequested := make(chan bool)
 	stop := make(chan bool)
+	var receivers sync.WaitGroup
+	if wg != nil {
+		wg.Add(1)
+	}
+	go func() {
+		<-stopRequested
+		cancel()
+		close(stop)
+		receivers.Wait()
+		if wg != nil {
+			wg.Done()
+		}
+	}()
 
 	for _, server := range servers {
 		prefixedLogger := logger.WithPrefix(server.Config.SectionName)
@@ -129,7 +150,7 @@ func SetupLogTails(servers []state.Server, globalCollectionOpts state.Collection
 				prefixedLogger.PrintInfo("Setting up log tail for %s", server.Config.LogLocation)
 			}
 
-			logStream := logReceiver(server, globalCollectionOpts, prefixedLogger, nil, stop)
+			logStream := logReceiver(ctx, server, globalCollectionOpts, prefixedLogger, nil, stop, &receivers)
 			err := setupLogLocationTail(server.Config.LogLocation, logStream, prefixedLogger, stop)
 			if err != nil {
 				prefixedLogger.PrintError("ERROR - %s", err)
@@ -139,14 +160,50 @@ func SetupLogTails(servers []state.Server, globalCollectionOpts state.Collection
 				prefixedLogger.PrintInfo("Setting up docker logs tail for %s", server.Config.LogDockerTail)
 			}
 
-			logStream := logReceiver(server, globalCollectionOpts, prefixedLogger, nil, stop)
+			logStream := logReceiver(ctx, server, globalCollectionOpts, prefixedLogger, nil, stop, &receivers)
 			err := setupDockerTail(server.Config.LogDockerTail, logStream, prefixedLogger, stop)
 			if err != nil {
 				prefixedLogger.PrintError("ERROR - %s", err)
 			}
+		} else if server.Config.LogSyslogServer != "" {
+			if globalCollectionOpts.DebugLogs || globalCollectionOpts.TestRun {
+				prefixedLogger.PrintInfo("Setting up syslog server on %s", server.Config.LogSyslogServer)
+			}
+
+			source := logs.NewSyslogLogSource()
+			err := source.Listen(server.Config.LogSyslogServer)
+			if err != nil {
+				prefixedLogger.PrintError("ERROR - %s", err)
+				continue
+			}
+			receivers.Add(1)
+			go receiveSyslog(ctx, server, source, globalCollectionOpts, prefixedLogger, stop, &receivers)
+		}
+	}
+	return stopRequested
+}
+
+// receiveSyslog - Periodically analyzes and sends the messages received by the
+// syslog source, until stopped
+func receiveSyslog(ctx context.Context, server state.Server, source *logs.SyslogLogSource, globalCollectionOpts state.CollectionOpts, prefixedLogger *util.Logger, stop <-chan bool, receivers *sync.WaitGroup) {
+	defer receivers.Done()
+	defer source.Close()
+
+	server = logs.WithoutBlockingLogBuffer(server)
+
+	ticker := time.NewTicker(logs.ReadyCheckInterval(server))
+	defer ticker.Stop()
+
+	var pendingLogLines []state.LogLine
+	for {
+		select {
+		case <-ticker.C:
+			pendingLogLines, _ = logs.AnalyzeSourceInGroupsAndSend(ctx, server, source, pendingLogLines, globalCollectionOpts, prefixedLogger, nil)
+		case <-stop:
+			flushLogLines(server, pendingLogLines, globalCollectionOpts, prefixedLogger)
+			return
 		}
 	}
-	return stop
 }
 
 // TestLogTail - Tests the tailing of a log file (without watching it continuously)
@@ -163,8 +220,27 @@ func TestLogTail(server state.Server, globalCollectionOpts state.CollectionOpts,
 
 	logTestSucceeded := make(chan bool, 1)
 
-	logStream := logReceiver(server, globalCollectionOpts, prefixedLogger, logTestSucceeded, stop)
-	err = setupLogLocationTail(server.Config.LogLocation, logStream, prefixedLogger, stop)
+	// Keep a sample of the raw lines, so we can detect the log_line_prefix that
+	// is actually used in case the test fails
+	sampleLines := make(chan string, logPrefixSampleSize)
+	logStream := logReceiver(context.Background(), server, globalCollectionOpts, prefixedLogger, logTestSucceeded, stop, nil)
+	sampledLogStream := make(chan string)
+	go func() {
+		for {
+			select {
+			case line := <-sampledLogStream:
+				select {
+				case sampleLines <- line:
+				default:
+				}
+				logStream <- line
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	err = setupLogLocationTail(server.Config.LogLocation, sampledLogStream, prefixedLogger, stop)
 	if err != nil {
 		return err
 	}
@@ -176,13 +252,37 @@ func TestLogTail(server state.Server, globalCollectionOpts state.CollectionOpts,
 	}
 
 	select {
-	case <-logTestSucceeded:
+	case succeeded := <-logTestSucceeded:
+		if !succeeded {
+			return fmt.Errorf("Log lines were received, but query samples were not redacted according to the filter_query_sample setting")
+		}
 		return nil
 	case <-time.After(10 * time.Second):
+		printDetectedLogLinePrefix(sampleLines, prefixedLogger)
 		return fmt.Errorf("Timeout")
 	}
 }
 
+const logPrefixSampleSize = 100
+
+func printDetectedLogLinePrefix(sampleLines chan string, prefixedLogger *util.Logger) {
+	var lines []string
+	for len(sampleLines) > 0 {
+		lines = append(lines, <-sampleLines)
+	}
+	if len(lines) == 0 {
+		prefixedLogger.PrintInfo("No log lines were received during the test, can't detect log_line_prefix")
+		return
+	}
+
+	prefix, confidence := logs.DetectLogLinePrefix(lines)
+	if confidence == 0 {
+		prefixedLogger.PrintInfo("None of the %d received log lines match a supported log_line_prefix - please change the setting to one of the supported ones", len(lines))
+		return
+	}
+	prefixedLogger.PrintInfo("Detected log_line_prefix '%s' in %.0f%% of %d received log lines", prefix, confidence*100, len(lines))
+}
+
 func tailFile(path string, out chan<- string, prefixedLogger *util.Logger) (chan bool, error) {
 	prefixedLogger.PrintVerbose("Tailing log file %s", path)
 
@@ -381,10 +481,17 @@ func setupDockerTail(containerName string, out chan<- string, prefixedLogger *ut
 	return nil
 }
 
-func logReceiver(server state.Server, globalCollectionOpts state.CollectionOpts, prefixedLogger *util.Logger, logTestSucceeded chan<- bool, stop <-chan bool) chan<- string {
+func logReceiver(ctx context.Context, server state.Server, globalCollectionOpts state.CollectionOpts, prefixedLogger *util.Logger, logTestSucceeded chan<- bool, stop <-chan bool, receivers *sync.WaitGroup) chan<- string {
 	logStream := make(chan string)
 
+	if receivers != nil {
+		receivers.Add(1)
+	}
 	go func() {
+		if receivers != nil {
+			defer receivers.Done()
+		}
+
 		var logLines []state.LogLine
 
 		// Only ingest log lines that were written in the last minute before startup,
@@ -393,16 +500,22 @@ func logReceiver(server state.Server, globalCollectionOpts state.CollectionOpts,
 		linesNewerThan := time.Now().Add(-1 * time.Minute)
 
 		// Use a timeout to clear out loglines that don't have any follow-on lines
-		// (the threshold used in AnalyzeInGroupsAndSend is 3 seconds)
+		// (see log_lines_ready_after)
 		timeout := make(chan bool, 1)
 		go func() {
-			time.Sleep(3 * time.Second)
+			time.Sleep(logs.ReadyCheckInterval(server))
 			timeout <- true
 		}()
 
 		for {
+			// Leave further lines with the tail while the buffer is full
+			var receivedLines <-chan string = logStream
+			if logs.LogBufferFull(server, logLines) {
+				receivedLines = nil
+			}
+
 			select {
-			case line, ok := <-logStream:
+			case line, ok := <-receivedLines:
 				if !ok {
 					return
 				}
@@ -415,21 +528,26 @@ func logReceiver(server state.Server, globalCollectionOpts state.CollectionOpts,
 
 				// Ignore loglines which are outside our time window
 				nullTime := time.Time{}
-				if logLine.OccurredAt != nullTime && logLine.OccurredAt.Before(linesNewerThan) {
+				if logLine.OccurredAt != nullTime && logLine.OccurredBefore(linesNewerThan) {
 					continue
 				}
 
 				logLines = append(logLines, logLine)
-				logLines = logs.AnalyzeInGroupsAndSend(server, logLines, globalCollectionOpts, prefixedLogger, logTestSucceeded)
+				logLines = logs.AnalyzeInGroupsAndSend(ctx, server, logLines, globalCollectionOpts, prefixedLogger, logTestSucceeded)
 			case <-timeout:
 				if len(logLines) > 0 {
-					logLines = logs.AnalyzeInGroupsAndSend(server, logLines, globalCollectionOpts, prefixedLogger, logTestSucceeded)
+					logLines = logs.AnalyzeInGroupsAndSend(ctx, server, logLines, globalCollectionOpts, prefixedLogger, logTestSucceeded)
 				}
 				go func() {
-					time.Sleep(3 * time.Second)
+					time.Sleep(logs.ReadyCheckInterval(server))
 					timeout <- true
 				}()
 			case <-stop:
+				// Only log tails set up by SetupLogTails send their remaining lines, test
+				// runs just check that lines are received
+				if receivers != nil {
+					flushLogLines(server, logLines, globalCollectionOpts, prefixedLogger)
+				}
 				return
 			}
 		}
@@ -437,3 +555,16 @@ func logReceiver(server state.Server, globalCollectionOpts state.CollectionOpts,
 
 	return logStream
 }
+
+// flushLogLines - Sends the log lines that are still buffered when stopping
+func flushLogLines(server state.Server, logLines []state.LogLine, globalCollectionOpts state.CollectionOpts, prefixedLogger *util.Logger) {
+	if len(logLines) == 0 {
+		return
+	}
+
+	prefixedLogger.PrintVerbose("Sending %d remaining log lines before stopping", len(logLines))
+	unsent := logs.FlushAndSend(server, logLines, globalCollectionOpts, prefixedLogger)
+	if len(unsent) > 0 {
+		prefixedLogger.PrintWarning("Could not send %d log lines before stopping", len(unsent))
+	}
+}
@@ -94,6 +94,8 @@ func GetSystemState(config config.ServerConfig, logger *util.Logger) (system sta
 	} else {
 		system.Memory.SwapUsedBytes = swap.Used
 		system.Memory.SwapTotalBytes = swap.Total
+		system.Memory.SwapInBytes = swap.Sin
+		system.Memory.SwapOutBytes = swap.Sout
 	}
 
 	// TODO: Read the stats below from /proc/meminfo (or patch gopsutil to do so)
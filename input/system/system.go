@@ -11,9 +11,10 @@ import (
 )
 
 // DownloadLogFiles - Downloads all new log files for the remote system and returns them
-func DownloadLogFiles(config config.ServerConfig, logger *util.Logger) (files []state.LogFile, querySamples []state.PostgresQuerySample) {
+func DownloadLogFiles(config config.ServerConfig, rdsPosition state.RdsLogsPosition, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (files []state.LogFile, querySamples []state.PostgresQuerySample, newRdsPosition state.RdsLogsPosition) {
+	newRdsPosition = rdsPosition
 	if config.SystemType == "amazon_rds" {
-		files, querySamples = rds.DownloadLogFiles(config, logger)
+		files, querySamples, newRdsPosition = rds.DownloadLogFiles(config, rdsPosition, globalCollectionOpts, logger)
 	}
 
 	return
@@ -0,0 +1,60 @@
+package input
+
+import (
+	"fmt"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+)
+
+// throttleReason - Returns why the expensive parts of a full snapshot (bloat
+// estimates, as well as table, index and function statistics) should be
+// skipped this run, based on the load seen in the previous run, or an empty
+// string if they should be collected as usual
+func throttleReason(serverConfig config.ServerConfig, prevState state.PersistedState) string {
+	if serverConfig.ThrottleMaxActiveBackends > 0 {
+		active := prevState.BackendCounts.CountByState()["active"]
+		if int(active) > serverConfig.ThrottleMaxActiveBackends {
+			return fmt.Sprintf("%d connections were active in the last run (throttle_max_active_backends = %d)", active, serverConfig.ThrottleMaxActiveBackends)
+		}
+	}
+
+	if serverConfig.ThrottleMaxReplicationLagBytes > 0 {
+		lag := maxReplicationLagBytes(prevState.Replication)
+		if lag > serverConfig.ThrottleMaxReplicationLagBytes {
+			return fmt.Sprintf("replication lag was %d bytes in the last run (throttle_max_replication_lag_bytes = %d)", lag, serverConfig.ThrottleMaxReplicationLagBytes)
+		}
+	}
+
+	return ""
+}
+
+// maxReplicationLagBytes - Returns the apply lag of a standby, or the largest
+// lag of the standbys of a primary
+func maxReplicationLagBytes(replication state.PostgresReplication) int64 {
+	var lag int64
+	if replication.ApplyByteLag.Valid {
+		lag = replication.ApplyByteLag.Int64
+	}
+	for _, standby := range replication.Standbys {
+		if standby.ByteLag.Valid && standby.ByteLag.Int64 > lag {
+			lag = standby.ByteLag.Int64
+		}
+	}
+	return lag
+}
+
+// carryOverSchemaData - Keeps the tables, indexes and functions (including
+// their statistics) of the previous run, when they are skipped in this run
+//
+// Their statistics are not diffed this run, and the next run's diff covers the
+// time since they were actually collected (see PersistedState.CategoryCollectedAt).
+// The databases are not marked as having their local catalog collected, so the
+// carried over tables don't replace what was sent before.
+func carryOverSchemaData(prevState state.PersistedState, ps state.PersistedState) state.PersistedState {
+	ps.Relations = prevState.Relations
+	ps.RelationStats = prevState.RelationStats
+	ps.IndexStats = prevState.IndexStats
+	ps.Functions = prevState.Functions
+	return ps
+}
@@ -0,0 +1,103 @@
+package input
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/guregu/null"
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+var throttleTests = []struct {
+	serverConfig config.ServerConfig
+	prevState    state.PersistedState
+	expected     string
+}{
+	// Throttling is disabled by default
+	{
+		config.ServerConfig{},
+		state.PersistedState{BackendCounts: state.PostgresBackendCounts{{State: "active", Count: 500}}},
+		"",
+	},
+	{
+		config.ServerConfig{ThrottleMaxActiveBackends: 50},
+		state.PersistedState{BackendCounts: state.PostgresBackendCounts{{State: "active", Count: 30}, {State: "active", Count: 20}, {State: "idle", Count: 100}}},
+		"",
+	},
+	{
+		config.ServerConfig{ThrottleMaxActiveBackends: 50},
+		state.PersistedState{BackendCounts: state.PostgresBackendCounts{{State: "active", Count: 30}, {State: "active", Count: 21}}},
+		"51 connections were active in the last run (throttle_max_active_backends = 50)",
+	},
+	// Lag of this standby
+	{
+		config.ServerConfig{ThrottleMaxReplicationLagBytes: 1024},
+		state.PersistedState{Replication: state.PostgresReplication{InRecovery: true, ApplyByteLag: null.IntFrom(2048)}},
+		"replication lag was 2048 bytes in the last run (throttle_max_replication_lag_bytes = 1024)",
+	},
+	// Largest lag of the standbys of this primary
+	{
+		config.ServerConfig{ThrottleMaxReplicationLagBytes: 1024},
+		state.PersistedState{Replication: state.PostgresReplication{Standbys: []state.PostgresReplicationStandby{{ByteLag: null.IntFrom(100)}, {ByteLag: null.IntFrom(4096)}}}},
+		"replication lag was 4096 bytes in the last run (throttle_max_replication_lag_bytes = 1024)",
+	},
+	{
+		config.ServerConfig{ThrottleMaxReplicationLagBytes: 1024},
+		state.PersistedState{Replication: state.PostgresReplication{Standbys: []state.PostgresReplicationStandby{{ByteLag: null.IntFrom(1024)}, {}}}},
+		"",
+	},
+}
+
+func TestThrottleReason(t *testing.T) {
+	for idx, test := range throttleTests {
+		actual := throttleReason(test.serverConfig, test.prevState)
+		if actual != test.expected {
+			t.Errorf("Test %d: expected %q, got %q", idx, test.expected, actual)
+		}
+	}
+}
+
+func TestCollectFullThrottled(t *testing.T) {
+	for _, throttled := range []bool{false, true} {
+		connection, fakeServer := openFakePostgres(t.Name(), []fakePostgresResponse{
+			{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL 10.5 on x86_64-pc-linux-gnu"}}},
+			{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{"100005"}}},
+			{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{"10.5"}}},
+			{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+		})
+
+		var output bytes.Buffer
+		logger := &util.Logger{Destination: log.New(&output, "", 0)}
+		prevState := state.PersistedState{
+			Relations:     []state.PostgresRelation{{Oid: 1, RelationName: "users"}},
+			RelationStats: state.PostgresRelationStatsMap{1: {SeqScan: 10}},
+			BackendCounts: state.PostgresBackendCounts{{State: "active", Count: 10}},
+		}
+		if throttled {
+			prevState.BackendCounts = state.PostgresBackendCounts{{State: "active", Count: 100}}
+		}
+		server := state.Server{Config: config.ServerConfig{SectionName: "test", DbAllNames: true, ThrottleMaxActiveBackends: 50}, PrevState: prevState}
+
+		ps, _, err := CollectFull(server, connection, state.CollectionOpts{CollectPostgresBloat: true}, logger)
+		connection.Close()
+		if err != nil {
+			t.Fatalf("Throttled %v: expected collection to succeed, got error: %s", throttled, err)
+		}
+
+		if ran := fakeServer.ranQuery("pg_stats"); ran == throttled {
+			t.Errorf("Throttled %v: expected bloat estimates to be collected: %v, but they were: %v", throttled, !throttled, ran)
+		}
+		if warned := strings.Contains(output.String(), "Skipping bloat, table, index and function statistics this run, since 100 connections were active"); warned != throttled {
+			t.Errorf("Throttled %v: expected warning: %v, got output:\n%s", throttled, throttled, output.String())
+		}
+		// Skipped statistics are carried over, so the next diff stays correct
+		if carried := len(ps.Relations) == 1 && ps.RelationStats[1].SeqScan == 10; carried != throttled {
+			t.Errorf("Throttled %v: expected previous relations to be carried over: %v, got %v and %v", throttled, throttled, ps.Relations, ps.RelationStats)
+		}
+	}
+}
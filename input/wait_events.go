@@ -0,0 +1,27 @@
+package input
+
+import (
+	"database/sql"
+
+	"github.com/pganalyze/collector/input/postgres"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// SampleWaitEvents - Takes one sample of the wait events in pg_stat_activity,
+// and adds it to the server's sampler, to be sent with the next full snapshot
+func SampleWaitEvents(server state.Server, connection *sql.DB, logger *util.Logger) error {
+	version, err := postgres.GetPostgresVersion(logger, connection)
+	if err != nil {
+		return err
+	}
+
+	counts, err := postgres.GetWaitEventCounts(logger, connection, version)
+	if err != nil {
+		return err
+	}
+
+	server.WaitEventSampler.Add(counts)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package input
+
+import (
+	"database/sql/driver"
+	"io/ioutil"
+	"log"
+	"strconv"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+var sampleWaitEventsTests = []struct {
+	version    string
+	versionNum string
+	responses  []fakePostgresResponse
+	expected   state.PostgresWaitEventSummary
+}{
+	{
+		"10.5",
+		"100005",
+		[]fakePostgresResponse{
+			{pattern: "wait_event IS NOT NULL", columns: []string{"wait_event_type", "wait_event", "count"}, rows: [][]driver.Value{
+				{"Lock", "transactionid", int64(3)},
+				{"LWLock", "WALWriteLock", int64(1)},
+			}},
+		},
+		state.PostgresWaitEventSummary{SampleCount: 2, Counts: state.PostgresWaitEventCounts{
+			{WaitEventType: "Lock", WaitEvent: "transactionid"}:  6,
+			{WaitEventType: "LWLock", WaitEvent: "WALWriteLock"}: 2,
+		}},
+	},
+	// Before Postgres 9.6 only waiting on locks is known
+	{
+		"9.5.14",
+		"90514",
+		[]fakePostgresResponse{
+			{pattern: "AND waiting", columns: []string{"wait_event_type", "wait_event", "count"}, rows: [][]driver.Value{
+				{"Lock", "", int64(2)},
+			}},
+		},
+		state.PostgresWaitEventSummary{SampleCount: 2, Counts: state.PostgresWaitEventCounts{
+			{WaitEventType: "Lock", WaitEvent: ""}: 4,
+		}},
+	},
+	// Nothing waiting still counts as a sample
+	{
+		"10.5",
+		"100005",
+		[]fakePostgresResponse{},
+		state.PostgresWaitEventSummary{SampleCount: 2, Counts: state.PostgresWaitEventCounts{}},
+	},
+}
+
+func TestSampleWaitEvents(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	for idx, test := range sampleWaitEventsTests {
+		responses := append([]fakePostgresResponse{
+			{pattern: "SELECT version()", columns: []string{"version"}, rows: [][]driver.Value{{"PostgreSQL " + test.version + " on x86_64-pc-linux-gnu"}}},
+			{pattern: "SHOW server_version_num", columns: []string{"server_version_num"}, rows: [][]driver.Value{{test.versionNum}}},
+			{pattern: "SHOW server_version", columns: []string{"server_version"}, rows: [][]driver.Value{{test.version}}},
+			{pattern: "rds.extensions", columns: []string{"?column?"}, rows: [][]driver.Value{{false}}},
+		}, test.responses...)
+		connection, _ := openFakePostgres(t.Name()+strconv.Itoa(idx), responses)
+		server := state.Server{WaitEventSampler: &state.WaitEventSampler{}}
+
+		for i := 0; i < 2; i++ {
+			err := SampleWaitEvents(server, connection, logger)
+			if err != nil {
+				t.Fatalf("Test %d: unexpected error: %s", idx, err)
+			}
+		}
+		connection.Close()
+
+		if diff := pretty.Compare(test.expected, server.WaitEventSampler.Take()); diff != "" {
+			t.Errorf("Test %d: result diff: (-want +got)\n%s", idx, diff)
+		}
+		if diff := pretty.Compare(state.PostgresWaitEventSummary{}, server.WaitEventSampler.Take()); diff != "" {
+			t.Errorf("Test %d: expected samples to start over after Take, diff: (-want +got)\n%s", idx, diff)
+		}
+	}
+}
@@ -20,9 +20,12 @@ import (
 	flag "github.com/ogier/pflag"
 
 	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/health"
+	"github.com/pganalyze/collector/input/postgres"
 	"github.com/pganalyze/collector/input/system/heroku"
 	"github.com/pganalyze/collector/input/system/logs"
 	"github.com/pganalyze/collector/input/system/selfhosted"
+	"github.com/pganalyze/collector/metrics"
 	"github.com/pganalyze/collector/runner"
 	"github.com/pganalyze/collector/scheduler"
 	"github.com/pganalyze/collector/state"
@@ -31,30 +34,37 @@ import (
 	_ "github.com/lib/pq" // Enable database package to use Postgres
 )
 
-func run(wg *sync.WaitGroup, globalCollectionOpts state.CollectionOpts, logger *util.Logger, configFilename string) (bool, chan<- bool, chan<- bool, chan<- bool, chan<- bool, chan<- bool, chan<- bool) {
+// Kept across config reloads, since the health check server keeps running
+var healthChecker = health.NewChecker()
+
+func run(wg *sync.WaitGroup, globalCollectionOpts state.CollectionOpts, logger *util.Logger, configFilename string) (bool, chan<- bool, chan<- bool, chan<- bool, chan<- bool, chan<- bool, chan<- bool, chan<- bool, chan<- bool) {
 	var servers []state.Server
 
-	schedulerGroups, err := scheduler.GetSchedulerGroups()
+	conf, err := config.Read(logger, configFilename)
 	if err != nil {
-		logger.PrintError("Error: Could not get scheduler groups")
-		return false, nil, nil, nil, nil, nil, nil
+		logger.PrintError("Config Error: %s", err)
+		if globalCollectionOpts.CollectOnce {
+			os.Exit(1)
+		}
+		return !globalCollectionOpts.TestRun, nil, nil, nil, nil, nil, nil, nil, nil
 	}
 
-	conf, err := config.Read(logger, configFilename)
+	schedulerGroups, err := scheduler.GetSchedulerGroups(conf.ScheduleStartupJitter, conf.ScheduleJitter)
 	if err != nil {
-		logger.PrintError("Config Error: %s", err)
-		return !globalCollectionOpts.TestRun, nil, nil, nil, nil, nil, nil
+		logger.PrintError("Error: Could not get scheduler groups")
+		return false, nil, nil, nil, nil, nil, nil, nil, nil
 	}
 
 	// Avoid even running the scheduler when we already know its not needed
 	hasAnyLogsEnabled := false
 	hasAnyReportsEnabled := false
 	hasAnyActivityEnabled := false
+	hasAnyWaitEventsEnabled := false
 
 	serverConfigs := conf.Servers
 	for _, config := range serverConfigs {
-		servers = append(servers, state.Server{Config: config, StateMutex: &sync.Mutex{}})
-		if config.EnableLogs || config.LogLocation != "" || config.LogDockerTail != "" {
+		servers = append(servers, newServer(config))
+		if config.EnableLogs || config.LogLocation != "" || config.LogDockerTail != "" || config.LogSyslogServer != "" || config.AwsCloudWatchLogGroup != "" || config.AzureLogAnalyticsWorkspaceID != "" {
 			hasAnyLogsEnabled = true
 		}
 		if config.EnableReports {
@@ -63,10 +73,23 @@ func run(wg *sync.WaitGroup, globalCollectionOpts state.CollectionOpts, logger *
 		if config.EnableActivity {
 			hasAnyActivityEnabled = true
 		}
+		if config.CollectWaitEvents {
+			hasAnyWaitEventsEnabled = true
+		}
+		for _, setting := range config.IneffectiveCollectionIntervals() {
+			logger.WithPrefix(config.SectionName).PrintWarning("Ignoring %s, since full snapshots already run every 10 minutes", setting)
+		}
+		if len(config.RawQuerySampleFingerprints) > 0 {
+			logger.WithPrefix(config.SectionName).PrintWarning("WARNING - Raw query text capture is enabled: query samples of %d fingerprints are sent with their literal values, since they are listed in raw_query_sample_fingerprints", len(config.RawQuerySampleFingerprints))
+		}
 	}
 
 	runner.ReadStateFile(servers, globalCollectionOpts, logger)
 
+	if globalCollectionOpts.CollectOnce {
+		os.Exit(runner.CollectOnce(servers, globalCollectionOpts, logger))
+	}
+
 	// We intentionally don't do a test-run in the normal mode, since we're fine with
 	// a later SIGHUP that fixes the config (or a temporarily unreachable server at start)
 	if globalCollectionOpts.TestRun {
@@ -74,9 +97,15 @@ func run(wg *sync.WaitGroup, globalCollectionOpts state.CollectionOpts, logger *
 			runner.RunTestReport(servers, globalCollectionOpts, logger)
 		} else if globalCollectionOpts.TestRunLogs {
 			runner.TestLogsForAllServers(servers, globalCollectionOpts, logger)
+		} else if globalCollectionOpts.TestExplain {
+			runner.TestExplainForAllServers(servers, globalCollectionOpts, logger)
+		} else if globalCollectionOpts.CheckPermissions {
+			if !runner.CheckPermissionsForAllServers(servers, globalCollectionOpts, logger) {
+				os.Exit(1)
+			}
 		} else {
 			runner.CollectAllServers(servers, globalCollectionOpts, logger)
-			if hasAnyLogsEnabled {
+			if hasAnyLogsEnabled && !globalCollectionOpts.DebugSnapshot {
 				// Initial test
 				hasSuccessfulLocalServers := runner.TestLogsForAllServers(servers, globalCollectionOpts, logger)
 
@@ -121,19 +150,34 @@ func run(wg *sync.WaitGroup, globalCollectionOpts state.CollectionOpts, logger *
 				}
 			}
 		}
-		return false, nil, nil, nil, nil, nil, nil
+		return false, nil, nil, nil, nil, nil, nil, nil, nil
 	}
 
 	if globalCollectionOpts.DebugLogs {
-		selfhosted.SetupLogTails(servers, globalCollectionOpts, logger)
+		selfhosted.SetupLogTails(servers, globalCollectionOpts, logger, nil)
 
 		// Keep running but only running log processing
-		return true, nil, nil, nil, nil, nil, nil
+		return true, nil, nil, nil, nil, nil, nil, nil, nil
 	}
 
 	if globalCollectionOpts.DiscoverLogLocation {
 		selfhosted.DiscoverLogLocation(servers, globalCollectionOpts, logger)
-		return false, nil, nil, nil, nil, nil, nil
+		return false, nil, nil, nil, nil, nil, nil, nil, nil
+	}
+
+	if conf.PauseFile != "" {
+		globalCollectionOpts.Pause = &state.PauseControl{File: conf.PauseFile}
+	}
+	globalCollectionOpts.LogDeadLetterDir = conf.LogDeadLetterDir
+	globalCollectionOpts.LogDeadLetterPlaintext = conf.LogDeadLetterPlaintext
+
+	if conf.HealthCheckAddress != "" {
+		healthChecker.Update(servers, conf.HealthCheckReadyWithin)
+		healthChecker.ListenAndServe(conf.HealthCheckAddress, logger)
+	}
+
+	if conf.MetricsAddress != "" {
+		metrics.DefaultExporter.ListenAndServe(conf.MetricsAddress, logger)
 	}
 
 	statsStop := schedulerGroups["stats"].Schedule(func() {
@@ -158,9 +202,9 @@ func run(wg *sync.WaitGroup, globalCollectionOpts state.CollectionOpts, logger *
 		var hasAnyLogTails bool
 
 		for _, server := range servers {
-			if server.Config.LogLocation != "" || server.Config.LogDockerTail != "" {
+			if server.Config.LogLocation != "" || server.Config.LogDockerTail != "" || server.Config.LogSyslogServer != "" {
 				hasAnyLogTails = true
-			} else if server.Config.EnableLogs && conf.HerokuLogStream == nil {
+			} else if (server.Config.EnableLogs || server.Config.AwsCloudWatchLogGroup != "" || server.Config.AzureLogAnalyticsWorkspaceID != "") && conf.HerokuLogStream == nil {
 				hasAnyLogDownloads = true
 			}
 		}
@@ -170,7 +214,7 @@ func run(wg *sync.WaitGroup, globalCollectionOpts state.CollectionOpts, logger *
 		}
 
 		if hasAnyLogTails {
-			logsTailStop = selfhosted.SetupLogTails(servers, globalCollectionOpts, logger)
+			logsTailStop = selfhosted.SetupLogTails(servers, globalCollectionOpts, logger, wg)
 		}
 
 		if hasAnyLogDownloads {
@@ -198,7 +242,23 @@ func run(wg *sync.WaitGroup, globalCollectionOpts state.CollectionOpts, logger *
 		wg.Done()
 	}, logger, "high frequency query statistics of all servers", schedulerGroups["stats"])
 
-	return true, statsStop, reportsStop, logsTailStop, logsDownloadStop, activityStop, queriesStop
+	var waitEventsStop chan<- bool
+	if hasAnyWaitEventsEnabled {
+		waitEventsStop = schedulerGroups["wait_events"].Schedule(func() {
+			wg.Add(1)
+			runner.SampleWaitEventsFromAllServers(servers, globalCollectionOpts, logger)
+			wg.Done()
+		}, logger, "wait event sampling of all servers")
+	}
+
+	// Allow resending the last snapshots for debugging, with "kill -USR1 <pid>"
+	resendStop := runner.SetupSnapshotResend(servers, globalCollectionOpts, logger, wg)
+
+	return true, statsStop, reportsStop, logsTailStop, logsDownloadStop, activityStop, queriesStop, waitEventsStop, resendStop
+}
+
+func newServer(config config.ServerConfig) state.Server {
+	return state.Server{Config: config, StateMutex: &sync.Mutex{}, SharedGrant: &state.SharedGrant{}, LogsGrantCache: &state.GrantLogsCache{}, SubmissionTimes: &state.SubmissionTimes{}, WaitEventSampler: &state.WaitEventSampler{}, LastSentSnapshots: &state.LastSentSnapshots{}}
 }
 
 const defaultConfigFile = "/etc/pganalyze-collector.conf"
@@ -210,20 +270,26 @@ func main() {
 	var dryRunLogs bool
 	var analyzeLogfile string
 	var debugLogs bool
+	var debugSnapshot bool
 	var discoverLogLocation bool
 	var testRun bool
 	var testReport string
 	var testRunLogs bool
+	var testExplain bool
+	var checkPermissions bool
 	var forceStateUpdate bool
 	var configFilename string
 	var stateFilename string
 	var pidFilename string
+	var logTempDir string
 	var noPostgresSettings, noPostgresLocks, noPostgresFunctions, noPostgresBloat, noPostgresViews bool
-	var noPostgresRelations, noLogs, noExplain, noSystemInformation, diffStatements bool
+	var noPostgresRelations, noLogs, noLogCompression, noExplain, noSystemInformation, diffStatements bool
 	var writeHeapProfile bool
 	var testRunAndTrace bool
+	var collectOnce bool
 	var logToSyslog bool
 	var logNoTimestamps bool
+	var logJSON bool
 	var reloadRun bool
 
 	logFlags := log.LstdFlags
@@ -233,23 +299,30 @@ func main() {
 	flag.BoolVarP(&testRun, "test", "t", false, "Tests whether we can successfully collect statistics (including log data if configured), submits it to the server, and exits afterwards")
 	flag.StringVar(&testReport, "test-report", "", "Tests a particular report and returns its output as JSON")
 	flag.BoolVar(&testRunLogs, "test-logs", false, "Tests whether log collection works (does not test privilege dropping for local log collection, use --test for that)")
+	flag.BoolVar(&testExplain, "test-explain", false, "Tests whether EXPLAIN plans can be collected (checks that auto_explain is loaded, and runs EXPLAIN on a test query)")
+	flag.BoolVar(&checkPermissions, "check-permissions", false, "Checks whether the monitoring role can access all statistics views and functions, and prints the GRANT statements for anything that's missing")
+	flag.BoolVar(&collectOnce, "collect-once", false, "Collects statistics (and downloads logs, if configured) once, submits them to the server, and exits afterwards with a non-zero exit code on failure (use this to run the collector from cron)")
 	flag.BoolVar(&reloadRun, "reload", false, "Reloads the collector daemon thats running on the host")
 	flag.BoolVarP(&logger.Verbose, "verbose", "v", false, "Outputs additional debugging information, use this if you're encoutering errors or other problems")
 	flag.BoolVar(&logToSyslog, "syslog", false, "Write all log output to syslog instead of stderr (disabled by default)")
 	flag.BoolVar(&logNoTimestamps, "no-log-timestamps", false, "Disable timestamps in the log output (automatically done when syslog is enabled)")
+	flag.BoolVar(&logJSON, "log-json", false, "Write log output as one JSON object per line, with the timestamp, level, server and message as separate fields")
 	flag.BoolVar(&dryRun, "dry-run", false, "Print JSON data that would get sent to web service (without actually sending) and exit afterwards")
 	flag.BoolVar(&dryRunLogs, "dry-run-logs", false, "Print JSON data for log snapshot (without actually sending) and exit afterwards")
 	flag.StringVar(&analyzeLogfile, "analyze-logfile", "", "Analyzes the content of the given log file and returns debug output about it")
 	flag.BoolVar(&debugLogs, "debug-logs", false, "Outputs all log analysis that would be sent, doesn't send any other data (use for debugging only)")
+	flag.BoolVar(&debugSnapshot, "debug-snapshot", false, "Outputs the full snapshot that would be sent as JSON (with secrets redacted), doesn't send any data and exits afterwards (use for debugging only)")
 	flag.BoolVar(&discoverLogLocation, "discover-log-location", false, "Tries to automatically discover the location of the Postgres log directory, to support configuring the 'db_log_location' setting")
 	flag.BoolVar(&forceStateUpdate, "force-state-update", false, "Updates the state file even if other options would have prevented it (intended to be used together with --dry-run for debugging)")
 	flag.BoolVar(&noPostgresRelations, "no-postgres-relations", false, "Don't collect any Postgres relation information (not recommended)")
 	flag.BoolVar(&noPostgresSettings, "no-postgres-settings", false, "Don't collect Postgres configuration settings")
-	flag.BoolVar(&noPostgresLocks, "no-postgres-locks", false, "Don't collect Postgres lock information (NOTE: This is always enabled right now, i.e. no lock data is gathered)")
+	flag.BoolVar(&noPostgresLocks, "no-postgres-locks", false, "Don't collect Postgres lock waits and the blocking relationships between backends")
 	flag.BoolVar(&noPostgresFunctions, "no-postgres-functions", false, "Don't collect Postgres function/procedure information")
 	flag.BoolVar(&noPostgresBloat, "no-postgres-bloat", false, "Don't collect Postgres table/index bloat statistics")
 	flag.BoolVar(&noPostgresViews, "no-postgres-views", false, "Don't collect Postgres view/materialized view information (NOTE: This is not implemented right now - views are always collected)")
 	flag.BoolVar(&noLogs, "no-logs", false, "Don't collect log data")
+	flag.StringVar(&logTempDir, "log-temp-dir", "", "Directory for temporary files that hold log data before it is uploaded (defaults to the OS temp directory, e.g. use this when /tmp is small)")
+	flag.BoolVar(&noLogCompression, "no-log-compression", false, "Don't compress log data before uploading it")
 	flag.BoolVar(&noExplain, "no-explain", false, "Don't automatically EXPLAIN slow queries logged in the logfile")
 	flag.BoolVar(&noSystemInformation, "no-system-information", false, "Don't collect OS level performance data")
 	flag.BoolVar(&diffStatements, "diff-statements", false, "Send a diff of the pg_stat_statements statistics, instead of counter values")
@@ -265,9 +338,11 @@ func main() {
 		return
 	}
 
-	if logNoTimestamps || logToSyslog {
+	// JSON log lines carry their own timestamp
+	if logNoTimestamps || logToSyslog || logJSON {
 		logFlags = 0
 	}
+	logger.JSON = logJSON
 
 	if logToSyslog {
 		var err error
@@ -290,7 +365,7 @@ func main() {
 		}
 	}
 
-	if testReport != "" || testRunLogs || testRunAndTrace {
+	if testReport != "" || testRunLogs || testExplain || checkPermissions || testRunAndTrace {
 		testRun = true
 	}
 
@@ -299,8 +374,12 @@ func main() {
 		TestRun:                  testRun,
 		TestReport:               testReport,
 		TestRunLogs:              testRunLogs || dryRunLogs,
+		TestExplain:              testExplain,
+		CheckPermissions:         checkPermissions,
 		DebugLogs:                debugLogs,
+		DebugSnapshot:            debugSnapshot,
 		DiscoverLogLocation:      discoverLogLocation,
+		CollectOnce:              collectOnce,
 		CollectPostgresRelations: !noPostgresRelations,
 		CollectPostgresSettings:  !noPostgresSettings,
 		CollectPostgresLocks:     !noPostgresLocks,
@@ -308,12 +387,14 @@ func main() {
 		CollectPostgresBloat:     !noPostgresBloat,
 		CollectPostgresViews:     !noPostgresViews,
 		CollectLogs:              !noLogs,
+		CompressLogs:             !noLogCompression,
+		LogTempDir:               logTempDir,
 		CollectExplain:           !noExplain,
 		CollectSystemInformation: !noSystemInformation,
 		DiffStatements:           diffStatements,
 		StateFilename:            stateFilename,
-		WriteStateUpdate:         (!dryRun && !dryRunLogs && !testRun) || forceStateUpdate,
-		ForceEmptyGrant:          dryRun || dryRunLogs,
+		WriteStateUpdate:         (!dryRun && !dryRunLogs && !debugSnapshot && !testRun) || forceStateUpdate,
+		ForceEmptyGrant:          dryRun || dryRunLogs || debugSnapshot,
 	}
 
 	if reloadRun {
@@ -321,19 +402,25 @@ func main() {
 		return
 	}
 
-	if dryRun || dryRunLogs {
+	if dryRun || dryRunLogs || debugSnapshot {
 		globalCollectionOpts.SubmitCollectedData = false
 		globalCollectionOpts.TestRun = true
 	}
 
 	if globalCollectionOpts.TestRun || globalCollectionOpts.TestReport != "" ||
-		globalCollectionOpts.TestRunLogs || globalCollectionOpts.DebugLogs ||
-		globalCollectionOpts.DiscoverLogLocation {
+		globalCollectionOpts.TestRunLogs || globalCollectionOpts.TestExplain || globalCollectionOpts.CheckPermissions || globalCollectionOpts.DebugLogs ||
+		globalCollectionOpts.DebugSnapshot || globalCollectionOpts.DiscoverLogLocation {
 		globalCollectionOpts.CollectorApplicationName = "pganalyze_test_run"
 	} else {
 		globalCollectionOpts.CollectorApplicationName = "pganalyze_collector"
 	}
 
+	err := globalCollectionOpts.Validate()
+	if err != nil {
+		logger.PrintError("Invalid combination of options: %s", err)
+		os.Exit(1)
+	}
+
 	if analyzeLogfile != "" {
 		content, err := ioutil.ReadFile(analyzeLogfile)
 		if err != nil {
@@ -377,7 +464,7 @@ func main() {
 	wg := sync.WaitGroup{}
 
 ReadConfigAndRun:
-	keepRunning, statsStop, reportsStop, logsTailStop, logsDownloadStop, activityStop, queriesStop := run(&wg, globalCollectionOpts, logger, configFilename)
+	keepRunning, statsStop, reportsStop, logsTailStop, logsDownloadStop, activityStop, queriesStop, waitEventsStop, resendStop := run(&wg, globalCollectionOpts, logger, configFilename)
 	if !keepRunning {
 		return
 	}
@@ -404,6 +491,12 @@ ReadConfigAndRun:
 	if queriesStop != nil {
 		queriesStop <- true
 	}
+	if waitEventsStop != nil {
+		waitEventsStop <- true
+	}
+	if resendStop != nil {
+		resendStop <- true
+	}
 
 	if s == syscall.SIGHUP {
 		if writeHeapProfile {
@@ -420,6 +513,7 @@ ReadConfigAndRun:
 		}
 		logger.PrintInfo("Reloading configuration...")
 		wg.Wait()
+		postgres.CloseReusedConnections()
 		goto ReadConfigAndRun
 	}
 
@@ -427,4 +521,5 @@ ReadConfigAndRun:
 
 	logger.PrintInfo("Exiting...")
 	wg.Wait()
+	postgres.CloseReusedConnections()
 }
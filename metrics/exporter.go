@@ -0,0 +1,251 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// Exporter - Re-exports the statistics of the latest full snapshot of each
+// server on /metrics, in the Prometheus text format
+//
+// Counters are exported as per-second rates over the last collection interval,
+// since we only keep the diffs between two snapshots, not the raw counters.
+type Exporter struct {
+	mutex   sync.Mutex
+	samples map[string][]sample // Latest samples, by server section name
+	address string
+}
+
+// DefaultExporter - Gets updated after every full snapshot, once it serves metrics
+var DefaultExporter = NewExporter()
+
+// NewExporter - Sets up an exporter without any samples
+func NewExporter() *Exporter {
+	return &Exporter{samples: make(map[string][]sample)}
+}
+
+type label struct {
+	name  string
+	value string
+}
+
+type sample struct {
+	metric string
+	labels []label
+	value  float64
+}
+
+type metricDefinition struct {
+	name string
+	help string
+}
+
+// Metrics in the order they get written out - treat names and labels as stable,
+// since users build dashboards and alerts on them
+var metricDefinitions = []metricDefinition{
+	{"pganalyze_statement_calls_per_second", "Query calls per second, summed up by database"},
+	{"pganalyze_statement_time_seconds_per_second", "Query execution time per second, summed up by database"},
+	{"pganalyze_relation_size_bytes", "Size of the table, including TOAST, excluding indexes"},
+	{"pganalyze_relation_live_tuples", "Estimated number of live rows"},
+	{"pganalyze_relation_dead_tuples", "Estimated number of dead rows"},
+	{"pganalyze_relation_seq_scans_per_second", "Sequential scans on the table per second"},
+	{"pganalyze_relation_index_scans_per_second", "Index scans on the table per second"},
+	{"pganalyze_replication_apply_lag_bytes", "Bytes received by this standby that are not yet applied"},
+	{"pganalyze_replication_standby_lag_bytes", "Bytes not yet replayed by the standby, as seen from the primary"},
+	{"pganalyze_system_cpu_percent", "Share of CPU time spent in each mode"},
+	{"pganalyze_system_memory_total_bytes", "Total memory of the system"},
+	{"pganalyze_system_memory_available_bytes", "Memory available for starting new applications, without swapping"},
+	{"pganalyze_system_network_receive_bytes_per_second", "Bytes received per second"},
+	{"pganalyze_system_network_transmit_bytes_per_second", "Bytes transmitted per second"},
+	{"pganalyze_system_disk_partition_used_bytes", "Used space on the partition"},
+	{"pganalyze_system_disk_partition_total_bytes", "Total space on the partition"},
+}
+
+// Enabled - Whether the exporter serves metrics, and should therefore be updated
+func (e *Exporter) Enabled() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.address != ""
+}
+
+// ListenAndServe - Starts serving metrics on the given address in the
+// background, unless that already happened (changing the address requires a
+// restart of the collector)
+func (e *Exporter) ListenAndServe(address string, logger *util.Logger) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.address != "" {
+		if e.address != address {
+			logger.PrintWarning("Metrics server keeps running on %s, restart the collector to use %s instead", e.address, address)
+		}
+		return
+	}
+	e.address = address
+
+	go func() {
+		err := http.ListenAndServe(address, e)
+		logger.PrintError("Metrics server stopped: %s", err)
+	}()
+	logger.PrintVerbose("Serving metrics on %s", address)
+}
+
+// Update - Replaces the exported samples of the server with those of a new snapshot
+func (e *Exporter) Update(sectionName string, newState state.PersistedState, diffState state.DiffState, transientState state.TransientState, collectedIntervalSecs uint32) {
+	samples := samplesFromSnapshot(sectionName, newState, diffState, transientState, collectedIntervalSecs)
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.samples[sectionName] = samples
+}
+
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/metrics" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprint(w, e.text())
+}
+
+// text - Formats all samples in the Prometheus text exposition format
+func (e *Exporter) text() string {
+	e.mutex.Lock()
+	byMetric := make(map[string][]string)
+	for _, samples := range e.samples {
+		for _, s := range samples {
+			byMetric[s.metric] = append(byMetric[s.metric], s.metric+formatLabels(s.labels)+" "+strconv.FormatFloat(s.value, 'g', -1, 64))
+		}
+	}
+	e.mutex.Unlock()
+
+	var out strings.Builder
+	for _, definition := range metricDefinitions {
+		lines := byMetric[definition.name]
+		if len(lines) == 0 {
+			continue
+		}
+		sort.Strings(lines)
+		fmt.Fprintf(&out, "# HELP %s %s\n# TYPE %s gauge\n", definition.name, definition.help, definition.name)
+		for _, line := range lines {
+			out.WriteString(line + "\n")
+		}
+	}
+	return out.String()
+}
+
+func formatLabels(labels []label) string {
+	var parts []string
+	for _, l := range labels {
+		parts = append(parts, l.name+"=\""+escapeLabelValue(l.value)+"\"")
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func escapeLabelValue(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, "\n", `\n`, -1)
+	return strings.Replace(value, `"`, `\"`, -1)
+}
+
+func samplesFromSnapshot(sectionName string, newState state.PersistedState, diffState state.DiffState, transientState state.TransientState, collectedIntervalSecs uint32) (samples []sample) {
+	interval := float64(collectedIntervalSecs)
+	if interval == 0 {
+		interval = 1
+	}
+	serverLabel := label{"server", sectionName}
+
+	databaseNames := make(map[state.Oid]string)
+	for _, database := range transientState.Databases {
+		databaseNames[database.Oid] = database.Name
+	}
+
+	// Statements
+	statementInterval := interval
+	if diffState.StatementStatsIntervalSecs > 0 {
+		statementInterval = float64(diffState.StatementStatsIntervalSecs)
+	}
+	calls := make(map[state.Oid]float64)
+	times := make(map[state.Oid]float64)
+	for key, stats := range diffState.StatementStats {
+		calls[key.DatabaseOid] += float64(stats.Calls)
+		times[key.DatabaseOid] += stats.TotalTime / 1000
+	}
+	for databaseOid := range calls {
+		labels := []label{serverLabel, {"database", databaseNames[databaseOid]}}
+		samples = append(samples,
+			sample{"pganalyze_statement_calls_per_second", labels, calls[databaseOid] / statementInterval},
+			sample{"pganalyze_statement_time_seconds_per_second", labels, times[databaseOid] / statementInterval},
+		)
+	}
+
+	// Relations
+	relationInterval := interval
+	if diffState.RelationStatsIntervalSecs > 0 {
+		relationInterval = float64(diffState.RelationStatsIntervalSecs)
+	}
+	for _, relation := range newState.Relations {
+		stats, exists := diffState.RelationStats[relation.Oid]
+		if !exists {
+			continue
+		}
+		labels := []label{serverLabel, {"database", databaseNames[relation.DatabaseOid]}, {"schema", relation.SchemaName}, {"relation", relation.RelationName}}
+		samples = append(samples,
+			sample{"pganalyze_relation_size_bytes", labels, float64(stats.SizeBytes)},
+			sample{"pganalyze_relation_live_tuples", labels, float64(stats.NLiveTup)},
+			sample{"pganalyze_relation_dead_tuples", labels, float64(stats.NDeadTup)},
+			sample{"pganalyze_relation_seq_scans_per_second", labels, float64(stats.SeqScan) / relationInterval},
+			sample{"pganalyze_relation_index_scans_per_second", labels, float64(stats.IdxScan) / relationInterval},
+		)
+	}
+
+	// Replication
+	if newState.Replication.ApplyByteLag.Valid {
+		samples = append(samples, sample{"pganalyze_replication_apply_lag_bytes", []label{serverLabel}, float64(newState.Replication.ApplyByteLag.Int64)})
+	}
+	for _, standby := range newState.Replication.Standbys {
+		if standby.ByteLag.Valid {
+			labels := []label{serverLabel, {"application_name", standby.ApplicationName}, {"client_addr", standby.ClientAddr}}
+			samples = append(samples, sample{"pganalyze_replication_standby_lag_bytes", labels, float64(standby.ByteLag.Int64)})
+		}
+	}
+
+	// System
+	for cpu, stats := range diffState.SystemCPUStats {
+		for _, mode := range []struct {
+			name    string
+			percent float64
+		}{{"user", stats.UserPercent}, {"system", stats.SystemPercent}, {"iowait", stats.IowaitPercent}, {"steal", stats.StealPercent}, {"idle", stats.IdlePercent}} {
+			samples = append(samples, sample{"pganalyze_system_cpu_percent", []label{serverLabel, {"cpu", cpu}, {"mode", mode.name}}, mode.percent})
+		}
+	}
+	if newState.System.Memory.TotalBytes > 0 {
+		samples = append(samples,
+			sample{"pganalyze_system_memory_total_bytes", []label{serverLabel}, float64(newState.System.Memory.TotalBytes)},
+			sample{"pganalyze_system_memory_available_bytes", []label{serverLabel}, float64(newState.System.Memory.AvailableBytes)},
+		)
+	}
+	for name, stats := range diffState.SystemNetworkStats {
+		labels := []label{serverLabel, {"interface", name}}
+		samples = append(samples,
+			sample{"pganalyze_system_network_receive_bytes_per_second", labels, float64(stats.ReceiveThroughputBytesPerSecond)},
+			sample{"pganalyze_system_network_transmit_bytes_per_second", labels, float64(stats.TransmitThroughputBytesPerSecond)},
+		)
+	}
+	for mountpoint, partition := range newState.System.DiskPartitions {
+		labels := []label{serverLabel, {"mountpoint", mountpoint}}
+		samples = append(samples,
+			sample{"pganalyze_system_disk_partition_used_bytes", labels, float64(partition.UsedBytes)},
+			sample{"pganalyze_system_disk_partition_total_bytes", labels, float64(partition.TotalBytes)},
+		)
+	}
+
+	return
+}
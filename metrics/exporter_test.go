@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pganalyze/collector/state"
+)
+
+func TestExporter(t *testing.T) {
+	newState := state.PersistedState{
+		Relations: []state.PostgresRelation{
+			{Oid: 100, DatabaseOid: 1, SchemaName: "public", RelationName: "items"},
+			{Oid: 101, DatabaseOid: 1, SchemaName: "public", RelationName: "dropped"},
+		},
+	}
+	newState.System.Memory.TotalBytes = 1024
+	newState.System.Memory.AvailableBytes = 512
+	diffState := state.DiffState{
+		StatementStats: state.DiffedPostgresStatementStatsMap{
+			{DatabaseOid: 1, QueryID: 1}: {Calls: 60, TotalTime: 3000},
+			{DatabaseOid: 1, QueryID: 2}: {Calls: 60, TotalTime: 3000},
+		},
+		RelationStats: state.DiffedPostgresRelationStatsMap{
+			100: {SizeBytes: 8192, NLiveTup: 10, NDeadTup: 2, SeqScan: 120},
+		},
+	}
+	transientState := state.TransientState{
+		Databases: []state.PostgresDatabase{{Oid: 1, Name: "my\"db"}},
+	}
+
+	exporter := NewExporter()
+	exporter.Update("primary", newState, diffState, transientState, 60)
+
+	server := httptest.NewServer(exporter)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("Scrape failed: %s", err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	expectedLines := []string{
+		"# TYPE pganalyze_statement_calls_per_second gauge",
+		`pganalyze_statement_calls_per_second{server="primary",database="my\"db"} 2`,
+		`pganalyze_statement_time_seconds_per_second{server="primary",database="my\"db"} 0.1`,
+		`pganalyze_relation_size_bytes{server="primary",database="my\"db",schema="public",relation="items"} 8192`,
+		`pganalyze_relation_dead_tuples{server="primary",database="my\"db",schema="public",relation="items"} 2`,
+		`pganalyze_relation_seq_scans_per_second{server="primary",database="my\"db",schema="public",relation="items"} 2`,
+		`pganalyze_system_memory_available_bytes{server="primary"} 512`,
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+	if strings.Contains(string(body), `relation="dropped"`) {
+		t.Errorf("Expected relations without statistics to be skipped, got:\n%s", body)
+	}
+
+	resp, err = http.Get(server.URL + "/other")
+	if err != nil {
+		t.Fatalf("Request failed: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown path, got %d", resp.StatusCode)
+	}
+}
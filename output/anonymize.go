@@ -0,0 +1,257 @@
+package output
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+)
+
+// Number of hex characters of the HMAC that are kept for each pseudonym
+const pseudonymLength = 16
+
+// anonymizer - Replaces object names with pseudonyms derived from a secret,
+// so the same name always maps to the same pseudonym for a given secret
+type anonymizer struct {
+	secret []byte
+	cache  map[string]string
+}
+
+func newAnonymizer(secret string) *anonymizer {
+	return &anonymizer{secret: []byte(secret), cache: make(map[string]string)}
+}
+
+// pseudonym - Returns the pseudonym for a name of the given kind (e.g. "db"),
+// the kind is part of both the HMAC input and the pseudonym itself
+func (a *anonymizer) pseudonym(kind string, name string) string {
+	if name == "" {
+		return ""
+	}
+	key := kind + "\x00" + name
+	if p, ok := a.cache[key]; ok {
+		return p
+	}
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(key))
+	p := kind + "_" + hex.EncodeToString(mac.Sum(nil))[:pseudonymLength]
+	a.cache[key] = p
+	return p
+}
+
+func (a *anonymizer) roles(refs []*snapshot.RoleReference) {
+	for _, ref := range refs {
+		ref.Name = a.pseudonym("role", ref.Name)
+	}
+}
+
+func (a *anonymizer) databases(refs []*snapshot.DatabaseReference) {
+	for _, ref := range refs {
+		ref.Name = a.pseudonym("db", ref.Name)
+	}
+}
+
+// Tables and indexes share the same namespace in Postgres, and are therefore
+// both anonymized as relations
+func (a *anonymizer) relations(refs []*snapshot.RelationReference) {
+	for _, ref := range refs {
+		ref.SchemaName = a.pseudonym("schema", ref.SchemaName)
+		ref.RelationName = a.pseudonym("rel", ref.RelationName)
+	}
+}
+
+func (a *anonymizer) indices(refs []*snapshot.IndexReference) {
+	for _, ref := range refs {
+		ref.SchemaName = a.pseudonym("schema", ref.SchemaName)
+		ref.IndexName = a.pseudonym("rel", ref.IndexName)
+	}
+}
+
+// Function arguments include their names, and can refer to types by name
+func (a *anonymizer) functions(refs []*snapshot.FunctionReference) {
+	for _, ref := range refs {
+		ref.SchemaName = a.pseudonym("schema", ref.SchemaName)
+		ref.FunctionName = a.pseudonym("func", ref.FunctionName)
+		ref.Arguments = a.pseudonym("args", ref.Arguments)
+	}
+}
+
+// queries - Drops the query texts, since they contain names as well as values
+func (a *anonymizer) queries(infos []*snapshot.QueryInformation) {
+	for _, info := range infos {
+		info.NormalizedQuery = ""
+	}
+}
+
+// settingValue - Keeps numeric and boolean setting values, and drops all others,
+// since string settings (e.g. search_path or archive_command) can contain names,
+// and are not told apart from enum settings in the snapshot
+func settingValue(value string) string {
+	if value == "on" || value == "off" {
+		return value
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	return ""
+}
+
+func nullSettingValue(value *snapshot.NullString) *snapshot.NullString {
+	if value == nil || settingValue(value.Value) == "" {
+		return nil
+	}
+	return value
+}
+
+// anonymizeFullSnapshot - Replaces database, role, schema, relation, index,
+// column, function, tablespace, replication slot, standby and host names in the
+// snapshot with pseudonyms
+//
+// Query texts, explain plans and definitions (views, indexes, constraints,
+// column defaults and function bodies) refer to objects by their real names,
+// and are left out, as are collector errors, per-role and per-function settings
+// and the values of string settings.
+func anonymizeFullSnapshot(s *snapshot.FullSnapshot, secret string) {
+	a := newAnonymizer(secret)
+	a.roles(s.RoleReferences)
+	a.databases(s.DatabaseReferences)
+	a.relations(s.RelationReferences)
+	a.indices(s.IndexReferences)
+	a.functions(s.FunctionReferences)
+	a.queries(s.QueryInformations)
+	s.QueryExplains = nil
+	s.CollectorErrors = nil
+
+	for _, info := range s.RoleInformations {
+		info.Config = nil
+	}
+	for _, ref := range s.TablespaceReferences {
+		ref.Name = a.pseudonym("tablespace", ref.Name)
+	}
+	for _, setting := range s.Settings {
+		setting.CurrentValue = settingValue(setting.CurrentValue)
+		setting.BootValue = nullSettingValue(setting.BootValue)
+		setting.ResetValue = nullSettingValue(setting.ResetValue)
+		setting.SourceFile = nil
+	}
+	a.replication(s.Replication)
+	a.system(s.System)
+
+	for _, info := range s.RelationInformations {
+		info.ViewDefinition = nil
+		for _, column := range info.Columns {
+			column.Name = a.pseudonym("col", column.Name)
+			column.DefaultValue = nil
+		}
+		for _, constraint := range info.Constraints {
+			constraint.Name = a.pseudonym("constraint", constraint.Name)
+			constraint.ConstraintDef = ""
+		}
+	}
+	for _, info := range s.IndexInformations {
+		info.IndexDef = ""
+		info.ConstraintDef = nil
+	}
+	for _, info := range s.FunctionInformations {
+		info.Source = ""
+		info.SourceBin = ""
+		info.Config = nil
+		// Functions returning a table include the names of its columns
+		info.Result = ""
+	}
+	for _, change := range s.FunctionChanges {
+		change.SchemaName = a.pseudonym("schema", change.SchemaName)
+		change.FunctionName = a.pseudonym("func", change.FunctionName)
+		change.Arguments = a.pseudonym("args", change.Arguments)
+	}
+	for _, vacuum := range s.VacuumProgress {
+		vacuum.SchemaName = a.pseudonym("schema", vacuum.SchemaName)
+		vacuum.RelationName = a.pseudonym("rel", vacuum.RelationName)
+	}
+	if s.Wraparound != nil {
+		for _, table := range s.Wraparound.Tables {
+			table.SchemaName = a.pseudonym("schema", table.SchemaName)
+			table.RelationName = a.pseudonym("rel", table.RelationName)
+		}
+	}
+	a.blockingTrees(s.BlockingTrees)
+}
+
+// replication - Replaces standby application and host names, client addresses
+// and replication slot names with pseudonyms
+func (a *anonymizer) replication(replication *snapshot.Replication) {
+	if replication == nil {
+		return
+	}
+	for _, ref := range replication.StandbyReferences {
+		ref.ClientAddr = a.pseudonym("addr", ref.ClientAddr)
+	}
+	for _, info := range replication.StandbyInformations {
+		info.ApplicationName = a.pseudonym("app", info.ApplicationName)
+		info.ClientHostname = a.pseudonym("host", info.ClientHostname)
+	}
+	for _, standby := range replication.DisconnectedStandbys {
+		standby.ApplicationName = a.pseudonym("app", standby.ApplicationName)
+		standby.ClientAddr = a.pseudonym("addr", standby.ClientAddr)
+	}
+	for _, slot := range replication.LogicalSlots {
+		slot.SlotName = a.pseudonym("slot", slot.SlotName)
+	}
+}
+
+// system - Replaces the host name, and the initial database and user of RDS
+// instances with pseudonyms (the same ones as in the database and role references)
+func (a *anonymizer) system(system *snapshot.System) {
+	info := system.GetSystemInformation()
+	if selfHosted := info.GetSelfHosted(); selfHosted != nil {
+		selfHosted.Hostname = a.pseudonym("host", selfHosted.Hostname)
+	}
+	if amazonRds := info.GetAmazonRds(); amazonRds != nil {
+		amazonRds.InitialDbName = a.pseudonym("db", amazonRds.InitialDbName)
+		amazonRds.MasterUsername = a.pseudonym("role", amazonRds.MasterUsername)
+	}
+}
+
+// blockingTrees - Drops the query texts of the backends in the blocking trees
+func (a *anonymizer) blockingTrees(nodes []*snapshot.BlockingBackend) {
+	for _, node := range nodes {
+		node.QueryText = ""
+		a.blockingTrees(node.Blocked)
+	}
+}
+
+// anonymizeCompactSnapshot - Replaces database, role, schema and relation
+// names in the compact snapshot references with pseudonyms
+//
+// Query texts are left out, as well as log line details, query samples and the
+// references to the uploaded log files (see UploadAndSendLogs, which doesn't
+// upload the log contents in the first place).
+func anonymizeCompactSnapshot(s *snapshot.CompactSnapshot, secret string) {
+	if logSnapshot := s.GetLogSnapshot(); logSnapshot != nil {
+		logSnapshot.LogFileReferences = nil
+		logSnapshot.QuerySamples = nil
+		logSnapshot.QueryTextObjects = nil
+		for _, info := range logSnapshot.LogLineInformations {
+			info.LogFileIdx = 0
+			info.ByteStart = 0
+			info.ByteContentStart = 0
+			info.ByteEnd = 0
+			info.DetailsJson = ""
+		}
+	}
+	if activitySnapshot := s.GetActivitySnapshot(); activitySnapshot != nil {
+		for _, backend := range activitySnapshot.Backends {
+			backend.QueryText = ""
+		}
+	}
+
+	if s.BaseRefs == nil {
+		return
+	}
+	a := newAnonymizer(secret)
+	a.roles(s.BaseRefs.RoleReferences)
+	a.databases(s.BaseRefs.DatabaseReferences)
+	a.relations(s.BaseRefs.RelationReferences)
+	a.queries(s.BaseRefs.QueryInformations)
+}
@@ -0,0 +1,284 @@
+package output
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+)
+
+func buildAnonymizeTestSnapshot() snapshot.FullSnapshot {
+	return snapshot.FullSnapshot{
+		RoleReferences:     []*snapshot.RoleReference{{Name: "billing_owner"}},
+		DatabaseReferences: []*snapshot.DatabaseReference{{Name: "customer_billing"}},
+		RelationReferences: []*snapshot.RelationReference{{SchemaName: "invoicing", RelationName: "credit_cards"}},
+		IndexReferences:    []*snapshot.IndexReference{{SchemaName: "invoicing", IndexName: "credit_cards_pkey"}},
+		FunctionReferences: []*snapshot.FunctionReference{{SchemaName: "invoicing", FunctionName: "charge_customer", Arguments: "card invoicing.card_number"}},
+		QueryInformations:  []*snapshot.QueryInformation{{NormalizedQuery: "SELECT * FROM invoicing.credit_cards WHERE card_number = $1"}},
+		QueryExplains:      []*snapshot.QueryExplainInformation{{ExplainOutput: "Seq Scan on credit_cards"}},
+		RelationInformations: []*snapshot.RelationInformation{{
+			ViewDefinition: &snapshot.NullString{Valid: true, Value: "SELECT card_number FROM invoicing.credit_cards"},
+			Columns:        []*snapshot.RelationInformation_Column{{Name: "card_number", DataType: "text", DefaultValue: &snapshot.NullString{Valid: true, Value: "invoicing.next_card_number()"}}},
+			Constraints:    []*snapshot.RelationInformation_Constraint{{Name: "credit_cards_card_number_check", ConstraintDef: "CHECK (length(card_number) = 16)"}},
+		}},
+		IndexInformations: []*snapshot.IndexInformation{{
+			IndexDef:      "CREATE UNIQUE INDEX credit_cards_pkey ON invoicing.credit_cards USING btree (card_number)",
+			ConstraintDef: &snapshot.NullString{Valid: true, Value: "PRIMARY KEY (card_number)"},
+		}},
+		FunctionInformations: []*snapshot.FunctionInformation{{Source: "UPDATE invoicing.credit_cards SET charged = true", SourceBin: "charge_customer"}},
+		FunctionChanges:      []*snapshot.FunctionChange{{SchemaName: "invoicing", FunctionName: "charge_customer", Arguments: "card invoicing.card_number"}},
+		VacuumProgress:       []*snapshot.VacuumProgress{{SchemaName: "invoicing", RelationName: "credit_cards"}},
+		Wraparound:           &snapshot.Wraparound{Tables: []*snapshot.TableWraparound{{SchemaName: "invoicing", RelationName: "credit_cards"}}},
+		BlockingTrees: []*snapshot.BlockingBackend{{
+			Pid:       100,
+			QueryText: "LOCK TABLE invoicing.credit_cards",
+			Blocked:   []*snapshot.BlockingBackend{{Pid: 200, QueryText: "UPDATE invoicing.credit_cards SET card_number = NULL", HasLockWaitIdx: true}},
+		}},
+	}
+}
+
+func TestAnonymizeFullSnapshot(t *testing.T) {
+	s1 := buildAnonymizeTestSnapshot()
+	s2 := buildAnonymizeTestSnapshot()
+	anonymizeFullSnapshot(&s1, "secret")
+	anonymizeFullSnapshot(&s2, "secret")
+
+	if !proto.Equal(&s1, &s2) {
+		t.Errorf("Expected the same pseudonyms for the same secret, got %v and %v", s1, s2)
+	}
+	if s1.RelationReferences[0].SchemaName != s1.IndexReferences[0].SchemaName || s1.RelationReferences[0].SchemaName != s1.FunctionReferences[0].SchemaName {
+		t.Errorf("Expected the same pseudonym for the same schema, got %v", s1)
+	}
+	if s1.DatabaseReferences[0].Name == s1.RoleReferences[0].Name {
+		t.Errorf("Expected different pseudonyms for different names, got %v", s1)
+	}
+	if s1.FunctionReferences[0].Arguments != s1.FunctionChanges[0].Arguments {
+		t.Errorf("Expected the same pseudonym for the same function arguments, got %v", s1)
+	}
+
+	s3 := buildAnonymizeTestSnapshot()
+	anonymizeFullSnapshot(&s3, "other secret")
+	if s3.DatabaseReferences[0].Name == s1.DatabaseReferences[0].Name {
+		t.Errorf("Expected different pseudonyms for a different secret, got %s", s3.DatabaseReferences[0].Name)
+	}
+
+	data, err := proto.Marshal(&s1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, name := range []string{"billing_owner", "customer_billing", "invoicing", "credit_cards", "credit_cards_pkey", "charge_customer", "card_number", "credit_cards_card_number_check", "Seq Scan"} {
+		if bytes.Contains(data, []byte(name)) {
+			t.Errorf("Expected %q to not be contained in the snapshot", name)
+		}
+	}
+}
+
+func TestAnonymizeCompactSnapshot(t *testing.T) {
+	full := buildAnonymizeTestSnapshot()
+	anonymizeFullSnapshot(&full, "secret")
+
+	s := snapshot.CompactSnapshot{BaseRefs: &snapshot.CompactSnapshot_BaseRefs{
+		RoleReferences:     []*snapshot.RoleReference{{Name: "billing_owner"}},
+		DatabaseReferences: []*snapshot.DatabaseReference{{Name: "customer_billing"}, {Name: ""}},
+		RelationReferences: []*snapshot.RelationReference{{SchemaName: "invoicing", RelationName: "credit_cards"}},
+	}}
+	anonymizeCompactSnapshot(&s, "secret")
+
+	if s.BaseRefs.RoleReferences[0].Name != full.RoleReferences[0].Name {
+		t.Errorf("Expected role pseudonym %s, got %s", full.RoleReferences[0].Name, s.BaseRefs.RoleReferences[0].Name)
+	}
+	if s.BaseRefs.DatabaseReferences[0].Name != full.DatabaseReferences[0].Name {
+		t.Errorf("Expected database pseudonym %s, got %s", full.DatabaseReferences[0].Name, s.BaseRefs.DatabaseReferences[0].Name)
+	}
+	if s.BaseRefs.DatabaseReferences[1].Name != "" {
+		t.Errorf("Expected empty database name to stay empty, got %s", s.BaseRefs.DatabaseReferences[1].Name)
+	}
+	if !proto.Equal(s.BaseRefs.RelationReferences[0], full.RelationReferences[0]) {
+		t.Errorf("Expected relation pseudonym %v, got %v", full.RelationReferences[0], s.BaseRefs.RelationReferences[0])
+	}
+
+	anonymizeCompactSnapshot(&snapshot.CompactSnapshot{}, "secret")
+}
+
+func TestAnonymizeCompactSnapshotContents(t *testing.T) {
+	logSnapshot := snapshot.CompactSnapshot{
+		BaseRefs: &snapshot.CompactSnapshot_BaseRefs{
+			QueryInformations: []*snapshot.QueryInformation{{NormalizedQuery: "SELECT * FROM invoicing.credit_cards"}},
+		},
+		Data: &snapshot.CompactSnapshot_LogSnapshot{LogSnapshot: &snapshot.CompactLogSnapshot{
+			LogFileReferences:   []*snapshot.LogFileReference{{S3Location: "s3://bucket/credit_cards.log", OriginalName: "credit_cards.log"}},
+			LogLineInformations: []*snapshot.LogLineInformation{{ByteStart: 10, ByteEnd: 100, DetailsJson: `{"relation":"invoicing.credit_cards"}`}},
+			QuerySamples:        []*snapshot.QuerySample{{QueryText: "SELECT * FROM invoicing.credit_cards", Parameters: []string{"4111111111111111"}}},
+		}},
+	}
+	anonymizeCompactSnapshot(&logSnapshot, "secret")
+
+	activitySnapshot := snapshot.CompactSnapshot{
+		Data: &snapshot.CompactSnapshot_ActivitySnapshot{ActivitySnapshot: &snapshot.CompactActivitySnapshot{
+			Backends: []*snapshot.Backend{{QueryText: "SELECT * FROM invoicing.credit_cards"}},
+		}},
+	}
+	anonymizeCompactSnapshot(&activitySnapshot, "secret")
+
+	for _, s := range []snapshot.CompactSnapshot{logSnapshot, activitySnapshot} {
+		data, err := proto.Marshal(&s)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		for _, name := range []string{"credit_cards", "4111111111111111"} {
+			if bytes.Contains(data, []byte(name)) {
+				t.Errorf("Expected %q to not be contained in the snapshot", name)
+			}
+		}
+	}
+	if lines := logSnapshot.GetLogSnapshot().LogLineInformations; len(lines) != 1 || lines[0].ByteEnd != 0 {
+		t.Errorf("Expected log line to be kept without its location in the log file, got %v", lines)
+	}
+}
+
+// Marker for real names that fillStrings puts into every string field
+const anonymizeTestName = "secret_customer_name"
+
+// Fields that can't contain object names or other customer data, and are
+// therefore kept as they are (by message type and Go field name)
+var anonymizeSafeStringFields = map[string]bool{
+	// Identify the system to pganalyze, which also receives them with every request
+	"System.SystemId": true, "System.SystemScope": true, "SystemInformationAmazonRDS.InstanceId": true,
+	"FullSnapshot.SnapshotUuid": true,
+
+	// Configured in the collector, for use in pganalyze
+	"CustomMetric.Name": true, "Tag.Key": true, "Tag.Value": true,
+
+	// Versions, hardware and operating system
+	"FullSnapshot.CollectorVersion": true, "CollectorStatistic.GoVersion": true, "PostgresVersion.Full": true, "PostgresVersion.Short": true,
+	"CPUInformation.Model": true, "CPUReference.CoreId": true, "NetworkReference.InterfaceName": true,
+	"DiskInformation.DiskType": true, "DiskInformation.Scheduler": true, "DiskReference.DeviceName": true,
+	"DiskPartitionInformation.FilesystemOpts": true, "DiskPartitionInformation.FilesystemType": true,
+	"DiskPartitionInformation.PartitionName": true, "DiskPartitionReference.Mountpoint": true,
+	"SystemInformationSelfHosted.Architecture": true, "SystemInformationSelfHosted.DatabaseSystemIdentifier": true,
+	"SystemInformationSelfHosted.KernelVersion": true, "SystemInformationSelfHosted.OperatingSystem": true,
+	"SystemInformationSelfHosted.Platform": true, "SystemInformationSelfHosted.PlatformFamily": true,
+	"SystemInformationSelfHosted.PlatformVersion": true, "SystemInformationSelfHosted.VirtualizationSystem": true,
+	"SystemInformationAmazonRDS.AvailabilityZone": true, "SystemInformationAmazonRDS.CaCertificate": true,
+	"SystemInformationAmazonRDS.InstanceClass": true, "SystemInformationAmazonRDS.ParameterApplyStatus": true,
+	"SystemInformationAmazonRDS.PreferredBackupWindow": true, "SystemInformationAmazonRDS.PreferredMaintenanceWindow": true,
+	"SystemInformationAmazonRDS.Region": true, "SystemInformationAmazonRDS.SecondaryAvailabilityZone": true,
+	"SystemInformationAmazonRDS.Status": true,
+
+	// Values defined by Postgres (types, kinds, states, phases, WAL locations and storage options)
+	"DatabaseInformation.CType": true, "DatabaseInformation.Collate": true, "DatabaseInformation.Encoding": true,
+	"RelationInformation.Options": true, "RelationInformation.PersistenceType": true, "RelationInformation.RelationType": true,
+	"RelationInformation_Column.DataType": true, "RelationInformation_Constraint.Type": true,
+	"RelationInformation_Constraint.ForeignDeleteType": true, "RelationInformation_Constraint.ForeignMatchType": true,
+	"RelationInformation_Constraint.ForeignUpdateType": true, "IndexInformation.IndexType": true,
+	"FunctionInformation.DefinitionHash": true, "FunctionInformation.Language": true, "FunctionInformation.Volatile": true,
+	"TablespaceInformation.Config": true, "Setting.Name": true, "Setting.Source": true, "Setting.SourceLine": true, "Setting.Unit": true,
+	"Replication.CurrentXlogLocation": true, "Replication.ReceiveLocation": true, "Replication.ReplayLocation": true,
+	"StandbyInformation.SyncState": true, "StandbyStatistic.State": true, "StandbyStatistic.SentLocation": true,
+	"StandbyStatistic.WriteLocation": true, "StandbyStatistic.FlushLocation": true, "StandbyStatistic.ReplayLocation": true,
+	"LogicalReplicationSlot.Plugin": true, "LogicalReplicationSlot.RestartLsn": true, "LogicalReplicationSlot.ConfirmedFlushLsn": true,
+	"IOStatistic.BackendType": true, "WaitEventStatistic.WaitEvent": true, "WaitEventStatistic.WaitEventType": true,
+	"LockWait.LockType": true, "LockWait.Mode": true, "BlockingBackend.State": true,
+	"VacuumProgress.Phase": true, "CreateIndexProgress.Command": true, "CreateIndexProgress.Phase": true, "BaseBackupProgress.Phase": true,
+}
+
+// fillStrings - Sets every string field of the message (and the messages it
+// contains, down to a few levels for recursive ones) to a real name, using the
+// given variant of each oneof
+func fillStrings(v reflect.Value, depth int, variant int) {
+	if depth > 3 {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		fillStrings(v.Elem(), depth+1, variant)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" || strings.HasPrefix(field.Name, "XXX_") {
+				continue
+			}
+			if _, ok := field.Tag.Lookup("protobuf_oneof"); ok {
+				oneofFuncs := v.Addr().MethodByName("XXX_OneofFuncs").Call(nil)
+				wrappers := oneofFuncs[3].Interface().([]interface{})
+				wrapper := reflect.New(reflect.TypeOf(wrappers[variant%len(wrappers)]).Elem())
+				fillStrings(wrapper.Elem(), depth, variant)
+				v.Field(i).Set(wrapper)
+				continue
+			}
+			fillStrings(v.Field(i), depth, variant)
+		}
+	case reflect.String:
+		v.SetString(anonymizeTestName)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillStrings(v.Index(0), depth, variant)
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String && v.Type().Elem().Kind() == reflect.String {
+			v.Set(reflect.ValueOf(map[string]string{anonymizeTestName: anonymizeTestName}).Convert(v.Type()))
+		}
+	}
+}
+
+// leakedStrings - Returns the fields (as message type and Go field name) whose
+// string values still contain the real name
+func leakedStrings(v reflect.Value, path string, leaks map[string]bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			leakedStrings(v.Elem(), path, leaks)
+		}
+	case reflect.Struct:
+		// Values of nullable strings belong to the field containing them
+		if v.Type() == reflect.TypeOf(snapshot.NullString{}) {
+			leakedStrings(v.FieldByName("Value"), path, leaks)
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				leakedStrings(v.Field(i), v.Type().Name()+"."+v.Type().Field(i).Name, leaks)
+			}
+		}
+	case reflect.String:
+		if strings.Contains(v.String(), anonymizeTestName) && !anonymizeSafeStringFields[path] {
+			leaks[path] = true
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			leakedStrings(v.Index(i), path, leaks)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			leakedStrings(key, path, leaks)
+			leakedStrings(v.MapIndex(key), path, leaks)
+		}
+	}
+}
+
+func TestAnonymizeFullSnapshotAllStrings(t *testing.T) {
+	leaks := make(map[string]bool)
+	for variant := 0; variant < 2; variant++ {
+		s := snapshot.FullSnapshot{}
+		fillStrings(reflect.ValueOf(&s).Elem(), 0, variant)
+		anonymizeFullSnapshot(&s, "secret")
+		leakedStrings(reflect.ValueOf(s), "FullSnapshot", leaks)
+	}
+	var fields []string
+	for field := range leaks {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		t.Errorf("Expected %s to be anonymized", field)
+	}
+}
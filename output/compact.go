@@ -3,6 +3,7 @@ package output
 import (
 	"bytes"
 	"compress/zlib"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,7 +22,7 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
-func uploadAndSubmitCompactSnapshot(s pganalyze_collector.CompactSnapshot, s3 state.GrantS3, server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, collectedAt time.Time, quiet bool, kind string) error {
+func uploadAndSubmitCompactSnapshot(ctx context.Context, s pganalyze_collector.CompactSnapshot, s3 state.GrantS3, apiBaseURL string, server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, collectedAt time.Time, quiet bool, kind string) error {
 	var err error
 	var data []byte
 
@@ -32,6 +33,10 @@ func uploadAndSubmitCompactSnapshot(s pganalyze_collector.CompactSnapshot, s3 st
 	s.CollectorVersion = util.CollectorNameAndVersion
 	s.SnapshotUuid = snapshotUUID.String()
 	s.CollectedAt, _ = ptypes.TimestampProto(collectedAt)
+	s.Tags = transformTags(server.Config.Tags)
+	if server.Config.AnonymizeNamesSecret != "" {
+		anonymizeCompactSnapshot(&s, server.Config.AnonymizeNamesSecret)
+	}
 
 	data, err = proto.Marshal(&s)
 	if err != nil {
@@ -49,13 +54,28 @@ func uploadAndSubmitCompactSnapshot(s pganalyze_collector.CompactSnapshot, s3 st
 		return nil
 	}
 
-	s3Location, err := uploadCompactSnapshot(s3, logger, compressedData, snapshotUUID.String())
+	err = checkSnapshotSize(server.Config, len(data), compressedData.Len())
+	if err != nil {
+		logger.PrintError("Error uploading snapshot: %s", err)
+		return err
+	}
+
+	s3Location, err := uploadCompactSnapshot(ctx, s3, logger, compressedData, snapshotUUID.String())
 	if err != nil {
 		logger.PrintError("Error uploading to S3: %s", err)
 		return err
 	}
 
-	return submitCompactSnapshot(server, collectionOpts, logger, s3Location, collectedAt, quiet, kind)
+	err = submitCompactSnapshot(ctx, server, apiBaseURL, collectionOpts, logger, s3Location, collectedAt, quiet, kind)
+	if err != nil {
+		return err
+	}
+
+	if kind == "logs" {
+		server.LastSentSnapshots.RecordLogs(state.SentSnapshot{UUID: snapshotUUID.String(), CollectedAt: collectedAt, CompressedData: compressedData.Bytes()})
+	}
+
+	return nil
 }
 
 func debugCompactOutputAsJSON(logger *util.Logger, compressedData bytes.Buffer) {
@@ -89,8 +109,8 @@ func debugCompactOutputAsJSON(logger *util.Logger, compressedData bytes.Buffer)
 	fmt.Printf("%s\n", out.String())
 }
 
-func submitCompactSnapshot(server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, s3Location string, collectedAt time.Time, quiet bool, kind string) error {
-	requestURL := server.Config.APIBaseURL + "/v2/snapshots/compact"
+func submitCompactSnapshot(ctx context.Context, server state.Server, apiBaseURL string, collectionOpts state.CollectionOpts, logger *util.Logger, s3Location string, collectedAt time.Time, quiet bool, kind string) error {
+	requestURL := submitURL(server, apiBaseURL, "/v2/snapshots/compact")
 
 	data := url.Values{
 		"s3_location":  {s3Location},
@@ -101,6 +121,7 @@ func submitCompactSnapshot(server state.Server, collectionOpts state.CollectionO
 	if err != nil {
 		return err
 	}
+	req = req.WithContext(ctx)
 
 	req.Header.Set("Pganalyze-Api-Key", server.Config.APIKey)
 	req.Header.Set("Pganalyze-System-Id", server.Config.SystemID)
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"context"
 	"github.com/pganalyze/collector/output/pganalyze_collector"
 	"github.com/pganalyze/collector/output/transform"
 	"github.com/pganalyze/collector/state"
@@ -14,5 +15,5 @@ func SubmitCompactActivitySnapshot(server state.Server, grant state.Grant, colle
 		BaseRefs: &r,
 		Data:     &pganalyze_collector.CompactSnapshot_ActivitySnapshot{ActivitySnapshot: &as},
 	}
-	return uploadAndSubmitCompactSnapshot(s, grant.S3(), server, collectionOpts, logger, activityState.CollectedAt, false, "activity")
+	return uploadAndSubmitCompactSnapshot(context.Background(), s, grant.S3(), grant.APIBaseURL, server, collectionOpts, logger, activityState.CollectedAt, false, "activity")
 }
@@ -1,15 +1,40 @@
 package output
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/pganalyze/collector/output/pganalyze_collector"
 	"github.com/pganalyze/collector/output/transform"
 	"github.com/pganalyze/collector/state"
 	"github.com/pganalyze/collector/util"
 )
 
-func UploadAndSendLogs(server state.Server, grant state.GrantLogs, collectionOpts state.CollectionOpts, logger *util.Logger, logState state.LogState) error {
-	if collectionOpts.SubmitCollectedData && grant.EncryptionKey.CiphertextBlob != "" {
-		logState.LogFiles = EncryptAndUploadLogfiles(grant.Logdata, grant.EncryptionKey, logger, logState.LogFiles)
+// UploadAndSendLogs - Uploads the log files and submits the log snapshot that
+// references them, returning the context's error if it got cancelled midway
+func UploadAndSendLogs(ctx context.Context, server state.Server, grant state.GrantLogs, collectionOpts state.CollectionOpts, logger *util.Logger, logState state.LogState) error {
+	// Never fall back to uploading without the requested server-side encryption
+	if server.Config.LogEncryptionKeyID != "" {
+		err := validateKMSKeyID(server.Config.LogEncryptionKeyID)
+		if err != nil {
+			return fmt.Errorf("Invalid log_encryption_key_id setting: %s", err)
+		}
+		if grant.Logdata.S3URL != "" && !grantUsesKMSKey(grant.Logdata, server.Config.LogEncryptionKeyID) {
+			return fmt.Errorf("Log upload grant does not use the KMS key of the log_encryption_key_id setting, refusing to upload logs without it")
+		}
+	}
+
+	// Log contents can't be anonymized, and are therefore not uploaded at all
+	// when names get replaced by pseudonyms
+	if collectionOpts.SubmitCollectedData && grant.EncryptionKey.CiphertextBlob != "" && server.Config.AnonymizeNamesSecret == "" {
+		logState.LogFiles = EncryptAndUploadLogfiles(ctx, grant.Logdata, grant.EncryptionKey, collectionOpts.CompressLogs, server.Config.Tags, logger, logState.LogFiles)
+		logState.QuerySamples = uploadSeparateQuerySamples(ctx, server, grant, collectionOpts, logger, logState.QuerySamples)
+	}
+
+	// Don't submit a snapshot that is missing some of its uploaded files
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
 	ls, r := transform.LogStateToLogSnapshot(logState)
@@ -18,5 +43,9 @@ func UploadAndSendLogs(server state.Server, grant state.GrantLogs, collectionOpt
 		Data:     &pganalyze_collector.CompactSnapshot_LogSnapshot{LogSnapshot: &ls},
 	}
 
-	return uploadAndSubmitCompactSnapshot(s, grant.Snapshot, server, collectionOpts, logger, logState.CollectedAt, false, "logs")
+	err := uploadAndSubmitCompactSnapshot(ctx, s, grant.Snapshot, grant.APIBaseURL, server, collectionOpts, logger, logState.CollectedAt, false, "logs")
+	if err == nil && collectionOpts.SubmitCollectedData {
+		server.SubmissionTimes.RecordLogs(time.Now())
+	}
+	return err
 }
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"context"
 	"time"
 
 	"github.com/pganalyze/collector/output/pganalyze_collector"
@@ -15,5 +16,5 @@ func SubmitCompactSystemSnapshot(server state.Server, grant state.Grant, collect
 	s := pganalyze_collector.CompactSnapshot{
 		Data: &pganalyze_collector.CompactSnapshot_SystemSnapshot{SystemSnapshot: &ss},
 	}
-	return uploadAndSubmitCompactSnapshot(s, grant.S3(), server, collectionOpts, logger, collectedAt, false, "system")
+	return uploadAndSubmitCompactSnapshot(context.Background(), s, grant.S3(), grant.APIBaseURL, server, collectionOpts, logger, collectedAt, false, "system")
 }
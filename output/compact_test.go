@@ -0,0 +1,55 @@
+package output
+
+import (
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+func TestSubmitCompactSnapshotGrantEndpoint(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	var configuredSubmitted, grantSubmitted int
+	configured := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		configuredSubmitted++
+	}))
+	defer configured.Close()
+	grantEndpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		grantSubmitted++
+	}))
+	defer grantEndpoint.Close()
+
+	localDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	server := state.Server{Config: config.ServerConfig{APIBaseURL: configured.URL}}
+	grant := state.Grant{Valid: true, LocalDir: localDir, APIBaseURL: grantEndpoint.URL}
+	opts := state.CollectionOpts{SubmitCollectedData: true}
+
+	_, snapshotBytesBefore := state.GetUploadedBytes()
+	err = SubmitCompactSystemSnapshot(server, grant, opts, logger, state.SystemState{}, time.Now())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	_, snapshotBytesAfter := state.GetUploadedBytes()
+
+	// The uploaded bytes are counted as written, i.e. compressed
+	files, _ := ioutil.ReadDir(localDir)
+	if len(files) != 1 || snapshotBytesAfter-snapshotBytesBefore != files[0].Size() {
+		t.Errorf("Expected uploaded snapshot bytes to match the uploaded file, got %d bytes for %d files", snapshotBytesAfter-snapshotBytesBefore, len(files))
+	}
+	if grantSubmitted != 1 || configuredSubmitted != 0 {
+		t.Errorf("Expected submission to the endpoint of the grant, got %d there and %d to the configured endpoint", grantSubmitted, configuredSubmitted)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 
@@ -24,11 +25,22 @@ import (
 )
 
 func SendFull(server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, newState state.PersistedState, diffState state.DiffState, transientState state.TransientState, collectedIntervalSecs uint32) error {
+	s := BuildFull(logger, newState, diffState, transientState, collectedIntervalSecs)
+	return submitFull(s, server, collectionOpts, logger, newState.CollectedAt, false)
+}
+
+// BuildFull - Transforms collected state into the full snapshot that SendFull
+// would submit, without sending it anywhere
+func BuildFull(logger *util.Logger, newState state.PersistedState, diffState state.DiffState, transientState state.TransientState, collectedIntervalSecs uint32) snapshot.FullSnapshot {
 	s := transform.StateToSnapshot(newState, diffState, transientState)
 	s.CollectedIntervalSecs = collectedIntervalSecs
 	s.CollectorErrors = logger.ErrorMessages
+	return s
+}
 
-	return submitFull(s, server, collectionOpts, logger, newState.CollectedAt, false)
+// SubmitFull - Submits a full snapshot previously built using BuildFull
+func SubmitFull(s snapshot.FullSnapshot, server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, collectedAt time.Time) error {
+	return submitFull(s, server, collectionOpts, logger, collectedAt, false)
 }
 
 func SendFailedFull(server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger) error {
@@ -36,6 +48,17 @@ func SendFailedFull(server state.Server, collectionOpts state.CollectionOpts, lo
 	return submitFull(s, server, collectionOpts, logger, time.Now(), true)
 }
 
+// transformTags - Returns the configured server tags, sorted by key so that
+// snapshots with the same tags are identical
+func transformTags(tags map[string]string) []*snapshot.Tag {
+	var result []*snapshot.Tag
+	for key, value := range tags {
+		result = append(result, &snapshot.Tag{Key: key, Value: value})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
 func submitFull(s snapshot.FullSnapshot, server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, collectedAt time.Time, quiet bool) error {
 	var err error
 	var data []byte
@@ -47,6 +70,10 @@ func submitFull(s snapshot.FullSnapshot, server state.Server, collectionOpts sta
 	s.CollectorVersion = util.CollectorNameAndVersion
 	s.SnapshotUuid = snapshotUUID.String()
 	s.CollectedAt, _ = ptypes.TimestampProto(collectedAt)
+	s.Tags = transformTags(server.Config.Tags)
+	if server.Config.AnonymizeNamesSecret != "" {
+		anonymizeFullSnapshot(&s, server.Config.AnonymizeNamesSecret)
+	}
 
 	data, err = proto.Marshal(&s)
 	if err != nil {
@@ -59,18 +86,31 @@ func submitFull(s snapshot.FullSnapshot, server state.Server, collectionOpts sta
 	w.Write(data)
 	w.Close()
 
-	if !collectionOpts.SubmitCollectedData {
+	if collectionOpts.DebugSnapshot || !collectionOpts.SubmitCollectedData {
 		debugOutputAsJSON(logger, compressedData)
 		return nil
 	}
 
+	err = checkSnapshotSize(server.Config, len(data), compressedData.Len())
+	if err != nil {
+		logger.PrintError("Error uploading snapshot: %s", err)
+		return err
+	}
+
 	s3Location, err := uploadSnapshot(server.Grant, logger, compressedData, snapshotUUID.String())
 	if err != nil {
 		logger.PrintError("Error uploading to S3: %s", err)
 		return err
 	}
 
-	return submitSnapshot(server, collectionOpts, logger, s3Location, collectedAt, quiet)
+	err = submitSnapshot(server, collectionOpts, logger, s3Location, collectedAt, quiet)
+	if err != nil {
+		return err
+	}
+
+	server.LastSentSnapshots.RecordFull(state.SentSnapshot{UUID: snapshotUUID.String(), CollectedAt: collectedAt, CompressedData: compressedData.Bytes()})
+
+	return nil
 }
 
 func debugOutputAsJSON(logger *util.Logger, compressedData bytes.Buffer) {
@@ -92,6 +132,8 @@ func debugOutputAsJSON(logger *util.Logger, compressedData bytes.Buffer) {
 		return
 	}
 
+	redactSnapshotSecrets(s)
+
 	var out bytes.Buffer
 	var marshaler jsonpb.Marshaler
 	dataJSON, err := marshaler.MarshalToString(s)
@@ -105,10 +147,10 @@ func debugOutputAsJSON(logger *util.Logger, compressedData bytes.Buffer) {
 }
 
 func submitSnapshot(server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger, s3Location string, collectedAt time.Time, quiet bool) error {
-	requestURL := server.Config.APIBaseURL + "/v2/snapshots"
+	requestURL := submitURL(server, server.Grant.APIBaseURL, "/v2/snapshots")
 
 	if collectionOpts.TestRun {
-		requestURL = server.Config.APIBaseURL + "/v2/snapshots/test"
+		requestURL = submitURL(server, server.Grant.APIBaseURL, "/v2/snapshots/test")
 	}
 
 	data := url.Values{
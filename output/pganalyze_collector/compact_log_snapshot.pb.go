@@ -77,7 +77,7 @@ func (x LogLineInformation_LogLevel) String() string {
 	return proto.EnumName(LogLineInformation_LogLevel_name, int32(x))
 }
 func (LogLineInformation_LogLevel) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_compact_log_snapshot_797bc57ce4428036, []int{2, 0}
+	return fileDescriptor_compact_log_snapshot_25f0d59811c1ef52, []int{2, 0}
 }
 
 type LogLineInformation_LogClassification int32
@@ -399,7 +399,7 @@ func (x LogLineInformation_LogClassification) String() string {
 	return proto.EnumName(LogLineInformation_LogClassification_name, int32(x))
 }
 func (LogLineInformation_LogClassification) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_compact_log_snapshot_797bc57ce4428036, []int{2, 1}
+	return fileDescriptor_compact_log_snapshot_25f0d59811c1ef52, []int{2, 1}
 }
 
 type QuerySample_ExplainFormat int32
@@ -422,7 +422,7 @@ func (x QuerySample_ExplainFormat) String() string {
 	return proto.EnumName(QuerySample_ExplainFormat_name, int32(x))
 }
 func (QuerySample_ExplainFormat) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_compact_log_snapshot_797bc57ce4428036, []int{3, 0}
+	return fileDescriptor_compact_log_snapshot_25f0d59811c1ef52, []int{3, 0}
 }
 
 type QuerySample_ExplainSource int32
@@ -451,23 +451,24 @@ func (x QuerySample_ExplainSource) String() string {
 	return proto.EnumName(QuerySample_ExplainSource_name, int32(x))
 }
 func (QuerySample_ExplainSource) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_compact_log_snapshot_797bc57ce4428036, []int{3, 1}
+	return fileDescriptor_compact_log_snapshot_25f0d59811c1ef52, []int{3, 1}
 }
 
 type CompactLogSnapshot struct {
-	LogFileReferences    []*LogFileReference   `protobuf:"bytes,1,rep,name=log_file_references,json=logFileReferences,proto3" json:"log_file_references,omitempty"`
-	LogLineInformations  []*LogLineInformation `protobuf:"bytes,2,rep,name=log_line_informations,json=logLineInformations,proto3" json:"log_line_informations,omitempty"`
-	QuerySamples         []*QuerySample        `protobuf:"bytes,3,rep,name=query_samples,json=querySamples,proto3" json:"query_samples,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	LogFileReferences    []*LogFileReference         `protobuf:"bytes,1,rep,name=log_file_references,json=logFileReferences,proto3" json:"log_file_references,omitempty"`
+	LogLineInformations  []*LogLineInformation       `protobuf:"bytes,2,rep,name=log_line_informations,json=logLineInformations,proto3" json:"log_line_informations,omitempty"`
+	QuerySamples         []*QuerySample              `protobuf:"bytes,3,rep,name=query_samples,json=querySamples,proto3" json:"query_samples,omitempty"`
+	QueryTextObjects     []*QueryTextObjectReference `protobuf:"bytes,4,rep,name=query_text_objects,json=queryTextObjects,proto3" json:"query_text_objects,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
 }
 
 func (m *CompactLogSnapshot) Reset()         { *m = CompactLogSnapshot{} }
 func (m *CompactLogSnapshot) String() string { return proto.CompactTextString(m) }
 func (*CompactLogSnapshot) ProtoMessage()    {}
 func (*CompactLogSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_compact_log_snapshot_797bc57ce4428036, []int{0}
+	return fileDescriptor_compact_log_snapshot_25f0d59811c1ef52, []int{0}
 }
 func (m *CompactLogSnapshot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CompactLogSnapshot.Unmarshal(m, b)
@@ -508,13 +509,22 @@ func (m *CompactLogSnapshot) GetQuerySamples() []*QuerySample {
 	return nil
 }
 
+func (m *CompactLogSnapshot) GetQueryTextObjects() []*QueryTextObjectReference {
+	if m != nil {
+		return m.QueryTextObjects
+	}
+	return nil
+}
+
 type LogFileReference struct {
-	Uuid                 string   `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	S3Location           string   `protobuf:"bytes,2,opt,name=s3_location,json=s3Location,proto3" json:"s3_location,omitempty"`
-	S3CekAlgo            string   `protobuf:"bytes,3,opt,name=s3_cek_algo,json=s3CekAlgo,proto3" json:"s3_cek_algo,omitempty"`
-	S3CmkKeyId           string   `protobuf:"bytes,4,opt,name=s3_cmk_key_id,json=s3CmkKeyId,proto3" json:"s3_cmk_key_id,omitempty"`
-	ByteSize             int64    `protobuf:"varint,5,opt,name=byte_size,json=byteSize,proto3" json:"byte_size,omitempty"`
-	OriginalName         string   `protobuf:"bytes,6,opt,name=original_name,json=originalName,proto3" json:"original_name,omitempty"`
+	Uuid         string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	S3Location   string `protobuf:"bytes,2,opt,name=s3_location,json=s3Location,proto3" json:"s3_location,omitempty"`
+	S3CekAlgo    string `protobuf:"bytes,3,opt,name=s3_cek_algo,json=s3CekAlgo,proto3" json:"s3_cek_algo,omitempty"`
+	S3CmkKeyId   string `protobuf:"bytes,4,opt,name=s3_cmk_key_id,json=s3CmkKeyId,proto3" json:"s3_cmk_key_id,omitempty"`
+	ByteSize     int64  `protobuf:"varint,5,opt,name=byte_size,json=byteSize,proto3" json:"byte_size,omitempty"`
+	OriginalName string `protobuf:"bytes,6,opt,name=original_name,json=originalName,proto3" json:"original_name,omitempty"`
+	// Whether the file content was compressed with gzip before it got encrypted
+	Compressed           bool     `protobuf:"varint,7,opt,name=compressed,proto3" json:"compressed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -524,7 +534,7 @@ func (m *LogFileReference) Reset()         { *m = LogFileReference{} }
 func (m *LogFileReference) String() string { return proto.CompactTextString(m) }
 func (*LogFileReference) ProtoMessage()    {}
 func (*LogFileReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_compact_log_snapshot_797bc57ce4428036, []int{1}
+	return fileDescriptor_compact_log_snapshot_25f0d59811c1ef52, []int{1}
 }
 func (m *LogFileReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogFileReference.Unmarshal(m, b)
@@ -586,6 +596,13 @@ func (m *LogFileReference) GetOriginalName() string {
 	return ""
 }
 
+func (m *LogFileReference) GetCompressed() bool {
+	if m != nil {
+		return m.Compressed
+	}
+	return false
+}
+
 type LogLineInformation struct {
 	LogFileIdx           int32                                `protobuf:"varint,1,opt,name=log_file_idx,json=logFileIdx,proto3" json:"log_file_idx,omitempty"`
 	Uuid                 string                               `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
@@ -616,7 +633,7 @@ func (m *LogLineInformation) Reset()         { *m = LogLineInformation{} }
 func (m *LogLineInformation) String() string { return proto.CompactTextString(m) }
 func (*LogLineInformation) ProtoMessage()    {}
 func (*LogLineInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_compact_log_snapshot_797bc57ce4428036, []int{2}
+	return fileDescriptor_compact_log_snapshot_25f0d59811c1ef52, []int{2}
 }
 func (m *LogLineInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLineInformation.Unmarshal(m, b)
@@ -784,21 +801,25 @@ type QuerySample struct {
 	Parameters  []string             `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
 	LogLineUuid string               `protobuf:"bytes,10,opt,name=log_line_uuid,json=logLineUuid,proto3" json:"log_line_uuid,omitempty"`
 	// Note: For historic reasons this contains an inline version of QueryExplainInformation
-	HasExplain           bool                      `protobuf:"varint,20,opt,name=has_explain,json=hasExplain,proto3" json:"has_explain,omitempty"`
-	ExplainOutput        string                    `protobuf:"bytes,21,opt,name=explain_output,json=explainOutput,proto3" json:"explain_output,omitempty"`
-	ExplainError         string                    `protobuf:"bytes,22,opt,name=explain_error,json=explainError,proto3" json:"explain_error,omitempty"`
-	ExplainFormat        QuerySample_ExplainFormat `protobuf:"varint,23,opt,name=explain_format,json=explainFormat,proto3,enum=pganalyze.collector.QuerySample_ExplainFormat" json:"explain_format,omitempty"`
-	ExplainSource        QuerySample_ExplainSource `protobuf:"varint,24,opt,name=explain_source,json=explainSource,proto3,enum=pganalyze.collector.QuerySample_ExplainSource" json:"explain_source,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	HasExplain    bool                      `protobuf:"varint,20,opt,name=has_explain,json=hasExplain,proto3" json:"has_explain,omitempty"`
+	ExplainOutput string                    `protobuf:"bytes,21,opt,name=explain_output,json=explainOutput,proto3" json:"explain_output,omitempty"`
+	ExplainError  string                    `protobuf:"bytes,22,opt,name=explain_error,json=explainError,proto3" json:"explain_error,omitempty"`
+	ExplainFormat QuerySample_ExplainFormat `protobuf:"varint,23,opt,name=explain_format,json=explainFormat,proto3,enum=pganalyze.collector.QuerySample_ExplainFormat" json:"explain_format,omitempty"`
+	ExplainSource QuerySample_ExplainSource `protobuf:"varint,24,opt,name=explain_source,json=explainSource,proto3,enum=pganalyze.collector.QuerySample_ExplainSource" json:"explain_source,omitempty"`
+	// Full query text (only valid if has_query_text_object is set, query_text then
+	// only contains its beginning)
+	QueryTextObjectIdx   int32    `protobuf:"varint,25,opt,name=query_text_object_idx,json=queryTextObjectIdx,proto3" json:"query_text_object_idx,omitempty"`
+	HasQueryTextObject   bool     `protobuf:"varint,26,opt,name=has_query_text_object,json=hasQueryTextObject,proto3" json:"has_query_text_object,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *QuerySample) Reset()         { *m = QuerySample{} }
 func (m *QuerySample) String() string { return proto.CompactTextString(m) }
 func (*QuerySample) ProtoMessage()    {}
 func (*QuerySample) Descriptor() ([]byte, []int) {
-	return fileDescriptor_compact_log_snapshot_797bc57ce4428036, []int{3}
+	return fileDescriptor_compact_log_snapshot_25f0d59811c1ef52, []int{3}
 }
 func (m *QuerySample) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QuerySample.Unmarshal(m, b)
@@ -895,11 +916,107 @@ func (m *QuerySample) GetExplainSource() QuerySample_ExplainSource {
 	return QuerySample_STATEMENT_LOG_EXPLAIN_SOURCE
 }
 
+func (m *QuerySample) GetQueryTextObjectIdx() int32 {
+	if m != nil {
+		return m.QueryTextObjectIdx
+	}
+	return 0
+}
+
+func (m *QuerySample) GetHasQueryTextObject() bool {
+	if m != nil {
+		return m.HasQueryTextObject
+	}
+	return false
+}
+
+// Full query text of a query sample, uploaded as a separate encrypted object
+type QueryTextObjectReference struct {
+	Uuid       string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	S3Location string `protobuf:"bytes,2,opt,name=s3_location,json=s3Location,proto3" json:"s3_location,omitempty"`
+	S3CekAlgo  string `protobuf:"bytes,3,opt,name=s3_cek_algo,json=s3CekAlgo,proto3" json:"s3_cek_algo,omitempty"`
+	S3CmkKeyId string `protobuf:"bytes,4,opt,name=s3_cmk_key_id,json=s3CmkKeyId,proto3" json:"s3_cmk_key_id,omitempty"`
+	// Size of the query text (before compression)
+	ByteSize int64 `protobuf:"varint,5,opt,name=byte_size,json=byteSize,proto3" json:"byte_size,omitempty"`
+	// Whether the query text was compressed with gzip before it got encrypted
+	Compressed           bool     `protobuf:"varint,6,opt,name=compressed,proto3" json:"compressed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryTextObjectReference) Reset()         { *m = QueryTextObjectReference{} }
+func (m *QueryTextObjectReference) String() string { return proto.CompactTextString(m) }
+func (*QueryTextObjectReference) ProtoMessage()    {}
+func (*QueryTextObjectReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_compact_log_snapshot_25f0d59811c1ef52, []int{4}
+}
+func (m *QueryTextObjectReference) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryTextObjectReference.Unmarshal(m, b)
+}
+func (m *QueryTextObjectReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryTextObjectReference.Marshal(b, m, deterministic)
+}
+func (dst *QueryTextObjectReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryTextObjectReference.Merge(dst, src)
+}
+func (m *QueryTextObjectReference) XXX_Size() int {
+	return xxx_messageInfo_QueryTextObjectReference.Size(m)
+}
+func (m *QueryTextObjectReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryTextObjectReference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryTextObjectReference proto.InternalMessageInfo
+
+func (m *QueryTextObjectReference) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+func (m *QueryTextObjectReference) GetS3Location() string {
+	if m != nil {
+		return m.S3Location
+	}
+	return ""
+}
+
+func (m *QueryTextObjectReference) GetS3CekAlgo() string {
+	if m != nil {
+		return m.S3CekAlgo
+	}
+	return ""
+}
+
+func (m *QueryTextObjectReference) GetS3CmkKeyId() string {
+	if m != nil {
+		return m.S3CmkKeyId
+	}
+	return ""
+}
+
+func (m *QueryTextObjectReference) GetByteSize() int64 {
+	if m != nil {
+		return m.ByteSize
+	}
+	return 0
+}
+
+func (m *QueryTextObjectReference) GetCompressed() bool {
+	if m != nil {
+		return m.Compressed
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*CompactLogSnapshot)(nil), "pganalyze.collector.CompactLogSnapshot")
 	proto.RegisterType((*LogFileReference)(nil), "pganalyze.collector.LogFileReference")
 	proto.RegisterType((*LogLineInformation)(nil), "pganalyze.collector.LogLineInformation")
 	proto.RegisterType((*QuerySample)(nil), "pganalyze.collector.QuerySample")
+	proto.RegisterType((*QueryTextObjectReference)(nil), "pganalyze.collector.QueryTextObjectReference")
 	proto.RegisterEnum("pganalyze.collector.LogLineInformation_LogLevel", LogLineInformation_LogLevel_name, LogLineInformation_LogLevel_value)
 	proto.RegisterEnum("pganalyze.collector.LogLineInformation_LogClassification", LogLineInformation_LogClassification_name, LogLineInformation_LogClassification_value)
 	proto.RegisterEnum("pganalyze.collector.QuerySample_ExplainFormat", QuerySample_ExplainFormat_name, QuerySample_ExplainFormat_value)
@@ -907,163 +1024,170 @@ func init() {
 }
 
 func init() {
-	proto.RegisterFile("compact_log_snapshot.proto", fileDescriptor_compact_log_snapshot_797bc57ce4428036)
-}
-
-var fileDescriptor_compact_log_snapshot_797bc57ce4428036 = []byte{
-	// 2464 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0x5b, 0x7f, 0x1b, 0x39,
-	0x15, 0x5f, 0x27, 0x4d, 0x93, 0x28, 0x49, 0x57, 0x55, 0x9b, 0xc4, 0x4d, 0xdb, 0xd4, 0x4d, 0x29,
-	0x1b, 0x60, 0xc9, 0xf2, 0x6b, 0xe1, 0x81, 0x1f, 0x57, 0x65, 0xe6, 0xd8, 0x51, 0x33, 0x96, 0x26,
-	0x1a, 0x4d, 0x2e, 0x5d, 0x40, 0xb8, 0xf1, 0x34, 0x35, 0x75, 0x3c, 0xa9, 0xc7, 0x59, 0xda, 0x72,
-	0x5d, 0xee, 0xb0, 0x0f, 0x3c, 0xf1, 0x2d, 0xf8, 0x28, 0xbc, 0xf2, 0x1d, 0xf8, 0x18, 0xfc, 0x8e,
-	0x66, 0xc6, 0x76, 0xdc, 0x70, 0xd9, 0x37, 0xcf, 0xf9, 0x1f, 0xfd, 0x75, 0xee, 0x92, 0x4c, 0xd6,
-	0x8e, 0xd3, 0xd3, 0xb3, 0xd6, 0xf1, 0xc0, 0x76, 0xd3, 0x13, 0x9b, 0xf5, 0x5a, 0x67, 0xd9, 0x8b,
-	0x74, 0xb0, 0x75, 0xd6, 0x4f, 0x07, 0x29, 0xbb, 0x71, 0x76, 0xd2, 0xea, 0xb5, 0xba, 0x6f, 0xde,
-	0x26, 0x5b, 0xc7, 0x69, 0xb7, 0x9b, 0x1c, 0x0f, 0xd2, 0xfe, 0xda, 0xbd, 0x93, 0x34, 0x3d, 0xe9,
-	0x26, 0x1f, 0x39, 0x95, 0x67, 0xe7, 0xcf, 0x3f, 0x1a, 0x74, 0x4e, 0x93, 0x6c, 0xd0, 0x3a, 0x3d,
-	0xcb, 0x57, 0x6d, 0xfc, 0x75, 0x8a, 0x30, 0x2f, 0x27, 0x0d, 0xd2, 0x93, 0xa8, 0xa0, 0x64, 0x31,
-	0xb9, 0x81, 0x5b, 0x3c, 0xef, 0x74, 0x13, 0xdb, 0x4f, 0x9e, 0x27, 0xfd, 0xa4, 0x77, 0x9c, 0x64,
-	0xd5, 0x4a, 0x6d, 0x7a, 0x73, 0xe1, 0xd1, 0xc3, 0xad, 0x4b, 0xb6, 0xda, 0x0a, 0xd2, 0x93, 0x7a,
-	0xa7, 0x9b, 0xe8, 0x52, 0x5b, 0x5f, 0xef, 0x4e, 0x48, 0x32, 0xf6, 0x31, 0x59, 0x46, 0xda, 0x6e,
-	0xa7, 0x97, 0xd8, 0x4e, 0xef, 0x79, 0xda, 0x3f, 0x6d, 0x0d, 0x3a, 0x69, 0x2f, 0xab, 0x4e, 0x39,
-	0xe2, 0x0f, 0xfe, 0x13, 0x71, 0xd0, 0xe9, 0x25, 0x62, 0xa4, 0xaf, 0xd1, 0xb8, 0x09, 0x59, 0xc6,
-	0x80, 0x2c, 0xbd, 0x3a, 0x4f, 0xfa, 0x6f, 0x6c, 0xd6, 0x3a, 0x3d, 0xeb, 0x26, 0x59, 0x75, 0xda,
-	0x91, 0xd6, 0x2e, 0x25, 0xdd, 0x43, 0xcd, 0xc8, 0x29, 0xea, 0xc5, 0x57, 0xa3, 0x8f, 0x6c, 0xe3,
-	0x1f, 0x15, 0x42, 0x27, 0x7d, 0x61, 0x8c, 0x5c, 0x39, 0x3f, 0xef, 0xb4, 0xab, 0x95, 0x5a, 0x65,
-	0x73, 0x5e, 0xbb, 0xdf, 0xec, 0x1e, 0x59, 0xc8, 0x1e, 0xdb, 0x6e, 0x7a, 0xec, 0xf6, 0xaf, 0x4e,
-	0x39, 0x88, 0x64, 0x8f, 0x83, 0x42, 0xc2, 0xd6, 0x9d, 0xc2, 0x71, 0xf2, 0xd2, 0xb6, 0xba, 0x27,
-	0x69, 0x75, 0xda, 0x29, 0xcc, 0x67, 0x8f, 0xbd, 0xe4, 0x25, 0xef, 0x9e, 0xa4, 0xec, 0x3e, 0x59,
-	0x42, 0xfc, 0xf4, 0xa5, 0x7d, 0x99, 0xbc, 0xb1, 0x9d, 0x76, 0xf5, 0x4a, 0x49, 0xe1, 0x9d, 0xbe,
-	0xdc, 0x4d, 0xde, 0x88, 0x36, 0xbb, 0x4d, 0xe6, 0x9f, 0xbd, 0x19, 0x24, 0x36, 0xeb, 0xbc, 0x4d,
-	0xaa, 0x33, 0xb5, 0xca, 0xe6, 0xb4, 0x9e, 0x43, 0x41, 0xd4, 0x79, 0x9b, 0xb0, 0x07, 0x64, 0x29,
-	0xed, 0x77, 0x4e, 0x3a, 0xbd, 0x56, 0xd7, 0xf6, 0x5a, 0xa7, 0x49, 0xf5, 0xaa, 0x5b, 0xbf, 0x58,
-	0x0a, 0x65, 0xeb, 0x34, 0xd9, 0xf8, 0x74, 0x9d, 0xb0, 0x77, 0x23, 0xc8, 0x6a, 0x64, 0x71, 0x98,
-	0xe0, 0x4e, 0xfb, 0xb5, 0x73, 0x6c, 0x46, 0x93, 0x22, 0x65, 0xa2, 0xfd, 0x7a, 0xe8, 0xf2, 0xd4,
-	0x45, 0x97, 0xcf, 0x5a, 0xfd, 0xa4, 0x37, 0xb0, 0x0e, 0xca, 0x3d, 0x22, 0xb9, 0x28, 0x46, 0x85,
-	0xbb, 0x84, 0xe4, 0xf6, 0x0e, 0x5a, 0xfd, 0x81, 0xf3, 0x67, 0x5a, 0x3b, 0x0f, 0x22, 0x14, 0xb0,
-	0x0f, 0x09, 0x73, 0xf0, 0x71, 0xda, 0x1b, 0x20, 0x4b, 0xae, 0x96, 0xfb, 0x45, 0x11, 0xf1, 0x72,
-	0x20, 0xd7, 0xbe, 0x45, 0x9c, 0xaf, 0x36, 0xe9, 0xb5, 0x9d, 0x6b, 0xd3, 0x7a, 0x16, 0xbf, 0xa1,
-	0xd7, 0x46, 0xf3, 0x5f, 0xb4, 0x32, 0xdb, 0x4f, 0x0b, 0xf3, 0x67, 0x6b, 0x95, 0xcd, 0x39, 0x4d,
-	0x5e, 0xb4, 0x32, 0x9d, 0xe6, 0xe6, 0xdf, 0x22, 0x73, 0x43, 0x74, 0xce, 0x39, 0x37, 0xdb, 0x2f,
-	0xa0, 0x4d, 0x42, 0x71, 0x71, 0xbb, 0x35, 0x68, 0x3d, 0x6b, 0x65, 0xb9, 0xca, 0xbc, 0x23, 0xb8,
-	0xf6, 0xa2, 0x95, 0xf9, 0x85, 0x18, 0x35, 0xef, 0x93, 0xc5, 0x0b, 0x5a, 0xc4, 0x11, 0x2d, 0xb4,
-	0xc7, 0x54, 0x36, 0xc8, 0x12, 0x92, 0xe5, 0x95, 0x87, 0x3a, 0x0b, 0x8e, 0x69, 0xe1, 0x45, 0x2b,
-	0x73, 0x35, 0x86, 0x3a, 0xb7, 0xc9, 0xfc, 0x08, 0x5f, 0x74, 0x1c, 0x73, 0xaf, 0x4a, 0xf0, 0x5b,
-	0x64, 0x21, 0x3d, 0x3e, 0x3e, 0xef, 0xf7, 0x93, 0xb6, 0x6d, 0x0d, 0xaa, 0x4b, 0xb5, 0xca, 0xe6,
-	0xc2, 0xa3, 0xb5, 0xad, 0xbc, 0x71, 0xb7, 0xca, 0xc6, 0xdd, 0x32, 0x65, 0xe3, 0x6a, 0x52, 0xaa,
-	0xf3, 0x01, 0x26, 0xe4, 0x59, 0xeb, 0xf8, 0x65, 0xd2, 0x6b, 0xdb, 0xb3, 0x4e, 0xbb, 0x7a, 0x2d,
-	0xcf, 0x62, 0x21, 0x0a, 0x3b, 0x6d, 0x56, 0x27, 0x33, 0xdd, 0xe4, 0x93, 0xa4, 0x5b, 0x7d, 0xbf,
-	0x56, 0xd9, 0xbc, 0xf6, 0xe8, 0x6b, 0xff, 0x67, 0x87, 0x39, 0x11, 0xae, 0xd3, 0xf9, 0x72, 0xd6,
-	0x22, 0xd7, 0x8e, 0xbb, 0xad, 0x2c, 0xeb, 0x3c, 0xef, 0x14, 0xf5, 0x4e, 0x1d, 0xe1, 0x37, 0x3f,
-	0x07, 0xa1, 0x77, 0x81, 0x40, 0x4f, 0x10, 0xba, 0x60, 0x27, 0x83, 0x56, 0xa7, 0x9b, 0xd9, 0x9f,
-	0x64, 0x69, 0xaf, 0x7a, 0xdd, 0x55, 0xd7, 0x42, 0x21, 0x7b, 0x92, 0xa5, 0xbd, 0x32, 0x73, 0xfd,
-	0xa4, 0xeb, 0x96, 0xb8, 0x78, 0xb2, 0x61, 0xe6, 0x74, 0x21, 0x2e, 0x32, 0x77, 0x41, 0xeb, 0x46,
-	0x9e, 0xb9, 0xfe, 0x25, 0x2a, 0x89, 0x8b, 0x5d, 0x56, 0xbd, 0x59, 0x9b, 0x1e, 0xaa, 0x24, 0x18,
-	0xbc, 0x6c, 0xe3, 0xef, 0x15, 0x32, 0x57, 0x46, 0x82, 0x2d, 0x90, 0xd9, 0x58, 0xee, 0x4a, 0x75,
-	0x20, 0xe9, 0x7b, 0x6c, 0x9e, 0xcc, 0xf8, 0xb0, 0x1d, 0x37, 0x68, 0x85, 0xcd, 0x91, 0x2b, 0x42,
-	0xd6, 0x15, 0x9d, 0x62, 0x84, 0x5c, 0x95, 0xca, 0x08, 0x0f, 0xe8, 0x34, 0x6a, 0x1f, 0x70, 0x2d,
-	0x85, 0x6c, 0xd0, 0x2b, 0xa8, 0x0d, 0x5a, 0x2b, 0x4d, 0x67, 0xd8, 0x2c, 0x99, 0x0e, 0x54, 0x83,
-	0x5e, 0x45, 0x59, 0x9d, 0x1b, 0x1e, 0xd0, 0x59, 0xfc, 0x19, 0x72, 0x29, 0x3c, 0x3a, 0x87, 0x14,
-	0x3e, 0x18, 0x2e, 0x02, 0x3a, 0x8f, 0xc4, 0x3b, 0x42, 0x1a, 0x4a, 0x90, 0xcc, 0x53, 0xd2, 0xc0,
-	0xa1, 0xa1, 0x0b, 0x6c, 0x89, 0xcc, 0x47, 0x86, 0x1b, 0x68, 0x82, 0x34, 0x74, 0x11, 0x17, 0xef,
-	0xc5, 0xa0, 0x8f, 0xe8, 0xd2, 0xc6, 0xdf, 0x56, 0xc8, 0xf5, 0x77, 0xe2, 0xcc, 0xd6, 0xc9, 0x5a,
-	0x61, 0xb7, 0x0d, 0x54, 0xc3, 0x7a, 0x01, 0x8f, 0x22, 0x51, 0x17, 0x1e, 0x37, 0x42, 0xa1, 0x2b,
-	0x8c, 0x5c, 0x8b, 0x40, 0xef, 0x83, 0xb6, 0x9e, 0xe6, 0xd1, 0x0e, 0xf8, 0xb4, 0xc2, 0x28, 0x59,
-	0x2c, 0x64, 0x91, 0xe1, 0xda, 0xd0, 0x29, 0x76, 0x9b, 0xac, 0x8e, 0x4b, 0xac, 0x06, 0x4f, 0xed,
-	0x83, 0x46, 0xff, 0xa6, 0xd9, 0x0d, 0xf2, 0x7e, 0x09, 0xee, 0xc4, 0xc6, 0xc7, 0x10, 0x5d, 0x61,
-	0x55, 0x72, 0xb3, 0x10, 0xaa, 0xd8, 0x58, 0x55, 0xb7, 0x4d, 0x68, 0x2a, 0x7d, 0x44, 0x67, 0xc6,
-	0xb8, 0x84, 0xdc, 0xe7, 0x81, 0xf0, 0xad, 0xb7, 0x03, 0xde, 0x6e, 0x14, 0x37, 0xe9, 0x55, 0x76,
-	0x87, 0x54, 0x0b, 0xd0, 0x40, 0x33, 0xb4, 0x75, 0x11, 0x80, 0xf5, 0x34, 0x70, 0x03, 0x3e, 0x9d,
-	0x65, 0xef, 0x93, 0x85, 0x02, 0x6d, 0x8a, 0x08, 0x03, 0x76, 0x9d, 0x2c, 0x15, 0x02, 0x0d, 0x81,
-	0xe2, 0x3e, 0x9d, 0x67, 0xb7, 0xc8, 0x72, 0x21, 0x0a, 0xb5, 0xf2, 0x20, 0x8a, 0x2c, 0x1c, 0x0a,
-	0x5c, 0x4e, 0xd8, 0x06, 0x59, 0x1f, 0x79, 0x61, 0x22, 0xeb, 0xa9, 0x20, 0x00, 0xcf, 0x28, 0x6d,
-	0x8d, 0x68, 0x82, 0x8a, 0x31, 0xbe, 0xab, 0xe4, 0x86, 0xa7, 0xa4, 0x04, 0x0f, 0xe3, 0x83, 0x7e,
-	0x82, 0xd8, 0x07, 0x9f, 0xde, 0x44, 0xde, 0x31, 0x80, 0xc7, 0x66, 0x47, 0x69, 0xf1, 0x14, 0x7c,
-	0xba, 0xfc, 0xce, 0x9a, 0x27, 0xe0, 0xe1, 0x86, 0x2b, 0xe8, 0xea, 0x18, 0xe0, 0x8b, 0xa8, 0xf8,
-	0x02, 0x9f, 0xae, 0xb2, 0x0f, 0xc8, 0x83, 0x31, 0xd0, 0x0b, 0x04, 0x48, 0x63, 0xeb, 0x5c, 0x04,
-	0xe0, 0x5b, 0xa3, 0x6c, 0x81, 0xd1, 0x2a, 0xc6, 0x77, 0x4c, 0x31, 0x50, 0x91, 0xa1, 0xb7, 0x26,
-	0xa8, 0x51, 0x68, 0x55, 0x08, 0xd2, 0x9a, 0x43, 0xba, 0x36, 0x61, 0xab, 0x01, 0xdd, 0x14, 0xd2,
-	0x85, 0xf0, 0x36, 0x5b, 0x21, 0xac, 0x48, 0xc8, 0x48, 0x23, 0xa2, 0x77, 0xd8, 0x5d, 0x72, 0xcb,
-	0x28, 0x65, 0x9b, 0x5c, 0x1e, 0x8d, 0x23, 0x56, 0xab, 0x00, 0xe8, 0x5d, 0xf6, 0x80, 0xdc, 0xf3,
-	0x54, 0x1c, 0xf8, 0x56, 0x2a, 0x63, 0xb9, 0xe7, 0x41, 0x68, 0x6c, 0x14, 0x05, 0x63, 0xaa, 0x74,
-	0x9d, 0x7d, 0x91, 0x6c, 0x84, 0x5a, 0x19, 0xe5, 0xa9, 0xc0, 0xba, 0x8a, 0xb7, 0xb1, 0x8c, 0xe2,
-	0x30, 0x54, 0xda, 0x80, 0x6f, 0xf7, 0x41, 0x47, 0xa8, 0x77, 0x8f, 0x3d, 0x24, 0xf7, 0x27, 0xf4,
-	0x84, 0xf4, 0x54, 0x33, 0x0c, 0xc0, 0x80, 0x6d, 0x42, 0x14, 0xf1, 0x06, 0xd0, 0x1a, 0xbb, 0x4f,
-	0xee, 0x5e, 0x6a, 0x92, 0xcf, 0x0d, 0xdf, 0xe6, 0x11, 0xd0, 0xfb, 0x2e, 0xf2, 0x58, 0x3c, 0xa1,
-	0x12, 0xd2, 0xe4, 0xb5, 0x89, 0x35, 0xb9, 0x39, 0x01, 0x94, 0xe4, 0xf4, 0x4b, 0x2e, 0x6e, 0x23,
-	0x00, 0xf9, 0xeb, 0x1a, 0xf6, 0x62, 0xec, 0xa6, 0x2f, 0x63, 0xdc, 0x34, 0x38, 0x96, 0x09, 0xc2,
-	0xaf, 0xbc, 0x03, 0x0d, 0x29, 0x3f, 0xc4, 0xfc, 0x5c, 0x80, 0xb8, 0xa1, 0x5f, 0xc5, 0x78, 0x1e,
-	0xf0, 0x60, 0x58, 0xe2, 0xd8, 0x30, 0xda, 0xb7, 0x01, 0xc8, 0x86, 0xd9, 0xa1, 0x8f, 0xd8, 0x22,
-	0x99, 0x43, 0x58, 0x83, 0xaf, 0xe8, 0x63, 0x6c, 0x52, 0xfc, 0xe2, 0xda, 0xdb, 0x11, 0xfb, 0x80,
-	0xdc, 0x4d, 0x2e, 0xfd, 0xa2, 0x18, 0xe8, 0xd7, 0xb1, 0x2b, 0x10, 0x47, 0xa7, 0xed, 0x36, 0xf7,
-	0x76, 0xe3, 0x70, 0xb4, 0xff, 0x37, 0xd8, 0x32, 0xb9, 0xce, 0x63, 0xa3, 0xf6, 0xb9, 0x17, 0xc7,
-	0x4d, 0xeb, 0x71, 0xe9, 0x41, 0x40, 0xbf, 0x8d, 0x9e, 0x9a, 0x43, 0xe1, 0xdb, 0x03, 0xcd, 0x43,
-	0xae, 0x55, 0x2c, 0x7d, 0x5b, 0xce, 0xa4, 0xef, 0xa0, 0x3b, 0x93, 0x60, 0x3e, 0xa3, 0xbe, 0xcb,
-	0xee, 0x91, 0xdb, 0x63, 0x74, 0x01, 0x8f, 0xa5, 0xb7, 0x53, 0x36, 0x3e, 0xf8, 0xf4, 0x7b, 0x98,
-	0xbe, 0x4b, 0x15, 0x76, 0x62, 0x83, 0xc1, 0xb2, 0x6e, 0x02, 0x7c, 0x1f, 0x27, 0xc0, 0xb8, 0x59,
-	0x85, 0xbd, 0x3e, 0xe5, 0xb8, 0x39, 0x22, 0x5c, 0xf2, 0xe0, 0xe8, 0x29, 0x8c, 0x41, 0xdb, 0x58,
-	0x42, 0xd1, 0xae, 0x08, 0x43, 0xe4, 0x29, 0x37, 0x50, 0xde, 0x6e, 0x5e, 0x76, 0xfb, 0x5c, 0x04,
-	0x7c, 0x3b, 0x00, 0xea, 0x61, 0xf3, 0x0c, 0xf5, 0x4a, 0x9e, 0x4b, 0x14, 0x7d, 0x9c, 0x10, 0x4e,
-	0xce, 0xbd, 0xbd, 0x58, 0x68, 0xf0, 0x69, 0x1d, 0xc7, 0x9b, 0x13, 0x1d, 0x70, 0xe1, 0x92, 0xdb,
-	0x18, 0x4a, 0xca, 0x31, 0xb0, 0xc3, 0xd6, 0xc8, 0x8a, 0x93, 0xf8, 0xc0, 0xfd, 0xe2, 0x87, 0xc9,
-	0x1b, 0x57, 0xa0, 0xf9, 0x17, 0x31, 0xbe, 0xaf, 0x84, 0x0f, 0x3e, 0x7d, 0x82, 0xdd, 0x35, 0x9c,
-	0xce, 0xd6, 0x8f, 0x75, 0x3e, 0x65, 0x43, 0x4c, 0xf0, 0x48, 0x9e, 0x67, 0x08, 0xbb, 0xbc, 0xd8,
-	0x6e, 0xcf, 0xcd, 0xc4, 0x77, 0xf1, 0x38, 0x02, 0x4d, 0xb5, 0x1b, 0x72, 0x43, 0x10, 0x8f, 0x8f,
-	0x08, 0xcd, 0x1b, 0x89, 0x30, 0x96, 0x16, 0x0e, 0xc3, 0x80, 0x0b, 0x49, 0x0d, 0xa6, 0x27, 0x32,
-	0x5c, 0xfa, 0xdb, 0x47, 0x16, 0xcb, 0x52, 0x69, 0xc0, 0xc4, 0x07, 0xb6, 0xae, 0x55, 0xb3, 0x2c,
-	0x31, 0xfa, 0x14, 0x0b, 0xb4, 0x54, 0x2b, 0x52, 0x6b, 0x23, 0xa3, 0x81, 0x37, 0x31, 0x24, 0x1f,
-	0x63, 0xf3, 0x8d, 0xe0, 0x42, 0x6c, 0x85, 0x34, 0xa0, 0x75, 0x1c, 0x62, 0x1c, 0x7e, 0x70, 0x91,
-	0x41, 0x85, 0xe1, 0x05, 0x86, 0x1f, 0x8e, 0xdb, 0xe1, 0x29, 0x19, 0x89, 0xc8, 0xa0, 0xb1, 0xc5,
-	0xc9, 0xe1, 0x36, 0x35, 0x40, 0x7f, 0x54, 0x84, 0xa6, 0xb4, 0x63, 0x22, 0x04, 0xd4, 0xba, 0x13,
-	0xa1, 0xc0, 0xcb, 0x66, 0xc2, 0xb8, 0x05, 0x42, 0x02, 0xfd, 0x31, 0x16, 0x6b, 0x2c, 0xc5, 0x5e,
-	0x0c, 0x6e, 0x0f, 0xa3, 0x39, 0x36, 0xe0, 0xbe, 0x50, 0x41, 0x1e, 0xf9, 0x36, 0xfb, 0x02, 0xa9,
-	0xd5, 0x95, 0x06, 0xd1, 0x90, 0x76, 0x17, 0x8e, 0x2e, 0xd7, 0x4a, 0xd0, 0x5b, 0x2c, 0x1c, 0x19,
-	0x07, 0xc1, 0xe5, 0x2a, 0xcf, 0xd1, 0x4e, 0x37, 0x38, 0x2e, 0xc7, 0x4f, 0xf0, 0x70, 0x81, 0x43,
-	0x2f, 0x88, 0x23, 0x37, 0xcd, 0x2f, 0xd3, 0x79, 0xe1, 0x0e, 0xd6, 0x23, 0x69, 0xf8, 0x61, 0xd1,
-	0x6c, 0x3d, 0x6c, 0x92, 0xd2, 0x2b, 0x21, 0xc3, 0xd8, 0xd8, 0x1c, 0xa7, 0x29, 0x96, 0xc4, 0x3e,
-	0x0f, 0x62, 0x70, 0x33, 0x2a, 0x50, 0xb2, 0x61, 0xeb, 0x78, 0x50, 0x1d, 0x85, 0x40, 0xcf, 0xb0,
-	0x24, 0xca, 0x65, 0x4e, 0x89, 0xbe, 0x42, 0xfd, 0x26, 0x0f, 0xea, 0x4a, 0x37, 0xc1, 0xb7, 0x5c,
-	0x6b, 0x7e, 0x64, 0x03, 0x61, 0x40, 0xf3, 0x80, 0xf6, 0x5d, 0xbd, 0xc4, 0xdb, 0xee, 0xa6, 0x80,
-	0x47, 0x67, 0xe4, 0xda, 0x26, 0x10, 0x3c, 0xa2, 0x19, 0xfa, 0x2e, 0x64, 0x04, 0xda, 0x58, 0xc3,
-	0x75, 0x03, 0x70, 0xb4, 0x05, 0x71, 0x53, 0xa2, 0x5e, 0x93, 0x1b, 0x6f, 0x87, 0x0e, 0x70, 0x39,
-	0x0e, 0x61, 0x1e, 0xe0, 0xc4, 0x72, 0x7d, 0x14, 0xe5, 0x5b, 0xd0, 0x73, 0x56, 0x23, 0x77, 0x46,
-	0x0b, 0x1c, 0xb1, 0x2b, 0xb4, 0x86, 0x56, 0x71, 0x68, 0xb7, 0x8f, 0xe8, 0x27, 0x68, 0x99, 0x86,
-	0x3c, 0x06, 0xd6, 0x57, 0x10, 0xb9, 0x1e, 0x85, 0x43, 0x11, 0x19, 0xfa, 0xd3, 0xfc, 0xa8, 0x72,
-	0xcb, 0x27, 0x20, 0xbc, 0x38, 0xaf, 0xaa, 0x10, 0x34, 0xc7, 0x03, 0x7a, 0x02, 0x7c, 0xe3, 0xd2,
-	0x91, 0xaf, 0xd3, 0x50, 0x07, 0x0d, 0xd2, 0x03, 0xcb, 0x9b, 0xdb, 0xa2, 0x11, 0xab, 0x38, 0xa2,
-	0x6f, 0x71, 0x28, 0x86, 0x78, 0xee, 0x45, 0x2e, 0x1f, 0x3e, 0x48, 0x01, 0x3e, 0xfd, 0x19, 0x7a,
-	0x62, 0x34, 0x97, 0x11, 0xcf, 0x8f, 0x46, 0x11, 0x59, 0xbe, 0xed, 0x8e, 0x27, 0xfa, 0x73, 0x3c,
-	0xe3, 0xf2, 0xd4, 0xd5, 0x03, 0xe1, 0x19, 0x2b, 0xd5, 0x78, 0x1a, 0xf3, 0x50, 0xfc, 0x02, 0xd3,
-	0x3c, 0xae, 0xa4, 0xd5, 0x81, 0xe5, 0xf5, 0xba, 0x1b, 0x0d, 0xd6, 0x1c, 0xe0, 0xed, 0xef, 0x97,
-	0x63, 0x3e, 0x79, 0x5c, 0xa2, 0xd1, 0xdb, 0x60, 0x3d, 0x1e, 0x19, 0xfa, 0x2b, 0xb6, 0x4c, 0xa8,
-	0x2f, 0xf6, 0x85, 0x33, 0x6a, 0xfb, 0xc8, 0x3e, 0x05, 0xad, 0xe8, 0xaf, 0xf1, 0xc6, 0xb5, 0x50,
-	0xa8, 0xfa, 0x5a, 0x85, 0xf4, 0xd3, 0x0a, 0xbb, 0x85, 0x85, 0x61, 0xa0, 0x31, 0xba, 0x40, 0x69,
-	0x2e, 0x1b, 0x40, 0x7f, 0x53, 0x61, 0x37, 0xc8, 0xb5, 0xd1, 0xb1, 0xd2, 0x80, 0xc3, 0x90, 0xfe,
-	0xb6, 0xc2, 0x18, 0x59, 0x0a, 0xb9, 0xe6, 0xcd, 0x32, 0x0b, 0xf4, 0x77, 0x15, 0x76, 0x87, 0xac,
-	0xd6, 0x63, 0xe9, 0x5d, 0x16, 0xf8, 0xdf, 0x57, 0xd8, 0x0a, 0xb9, 0x2e, 0x95, 0x8d, 0x62, 0x6f,
-	0xc7, 0x46, 0x7c, 0x1f, 0xdc, 0xd9, 0x45, 0xff, 0x50, 0x61, 0xf7, 0xf0, 0xc6, 0x38, 0xba, 0x33,
-	0xd8, 0xbd, 0x58, 0x15, 0xc3, 0x01, 0x69, 0xff, 0x58, 0x61, 0x0f, 0xc8, 0xfa, 0x65, 0x0a, 0xc2,
-	0x07, 0x69, 0x44, 0x5d, 0x80, 0xa6, 0x7f, 0xaa, 0xb0, 0x35, 0xb2, 0x5c, 0x1a, 0xb9, 0x7d, 0x64,
-	0xc0, 0x46, 0xee, 0x90, 0xf5, 0x80, 0xfe, 0xb9, 0xc2, 0x36, 0xf1, 0xe6, 0x53, 0x5e, 0x26, 0x22,
-	0xd0, 0x82, 0x07, 0xe2, 0x29, 0x58, 0x0d, 0x21, 0xe0, 0xd1, 0x1e, 0xe0, 0x4f, 0xee, 0xd3, 0xbf,
-	0x54, 0xd8, 0x43, 0x52, 0xbb, 0x4c, 0xb3, 0xfc, 0xe5, 0x66, 0xfc, 0x67, 0x15, 0x76, 0x9b, 0xac,
-	0x84, 0x0d, 0x3e, 0x76, 0x9f, 0x2b, 0x6c, 0x39, 0xa2, 0xff, 0x9a, 0xdd, 0xf8, 0xe7, 0x0c, 0x59,
-	0x18, 0x7b, 0xf0, 0x5f, 0x7c, 0x8f, 0x55, 0xfe, 0xfb, 0x7b, 0x6c, 0xea, 0x73, 0xbd, 0xc7, 0xee,
-	0x12, 0xd2, 0x3f, 0xef, 0x0d, 0x3a, 0xa7, 0x89, 0x3d, 0xcd, 0xdc, 0xfb, 0xb8, 0xa2, 0xe7, 0x0b,
-	0x49, 0x33, 0x43, 0x38, 0xdf, 0x78, 0x90, 0xbc, 0x1e, 0x14, 0xcf, 0xfd, 0xdc, 0x14, 0x93, 0xbc,
-	0x1e, 0xb0, 0x75, 0x82, 0x6f, 0xe9, 0xd6, 0x69, 0x32, 0x48, 0xfa, 0x59, 0x75, 0xa6, 0x36, 0x5d,
-	0xbc, 0xae, 0x0b, 0x09, 0xbe, 0x35, 0x87, 0x7f, 0x9f, 0xb8, 0x07, 0x38, 0xc9, 0x9f, 0x48, 0xc5,
-	0xbf, 0x21, 0x71, 0xf1, 0x44, 0xc7, 0x27, 0x52, 0xf2, 0xfa, 0xac, 0xdb, 0xea, 0xf4, 0xaa, 0x37,
-	0x87, 0x0f, 0x63, 0xc8, 0x25, 0xec, 0x21, 0xb9, 0x56, 0x80, 0x36, 0x3d, 0x1f, 0x9c, 0x9d, 0x0f,
-	0xaa, 0xcb, 0x8e, 0x65, 0xa9, 0x90, 0x2a, 0x27, 0x64, 0x0f, 0x48, 0x29, 0xb0, 0x49, 0xbf, 0x9f,
-	0xf6, 0xab, 0x2b, 0xf9, 0x9f, 0x0b, 0x85, 0x10, 0x50, 0xc6, 0xe2, 0x11, 0x57, 0xfe, 0xd2, 0xab,
-	0xae, 0xba, 0x57, 0xe1, 0xd6, 0xff, 0xfa, 0xcf, 0x65, 0xab, 0xb0, 0xa6, 0xee, 0x56, 0x0d, 0xf7,
-	0xce, 0x3f, 0xc7, 0x69, 0xb3, 0xf4, 0xbc, 0x7f, 0x9c, 0x54, 0xab, 0x9f, 0x8f, 0x36, 0x72, 0xab,
-	0x86, 0xb4, 0xf9, 0xe7, 0x06, 0x27, 0x4b, 0x17, 0xb6, 0xc5, 0x2b, 0x22, 0xbe, 0xa9, 0xca, 0x93,
-	0x13, 0xc7, 0x6b, 0x93, 0x1b, 0xfa, 0x1e, 0x02, 0x4f, 0x22, 0x25, 0x27, 0x81, 0xca, 0xc6, 0x67,
-	0x95, 0x21, 0x47, 0x4e, 0x8a, 0xc3, 0xef, 0xc2, 0xd1, 0x3c, 0x5c, 0x13, 0xa9, 0x58, 0x7b, 0x40,
-	0xdf, 0x2b, 0x6f, 0x53, 0x43, 0x60, 0x42, 0x01, 0x6b, 0x77, 0x15, 0x0e, 0x0d, 0x68, 0xc9, 0x83,
-	0x49, 0x70, 0x0a, 0xc7, 0x55, 0x03, 0x24, 0x68, 0xe1, 0x4d, 0x62, 0xd3, 0xcf, 0xae, 0xba, 0x6a,
-	0x7c, 0xfc, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x29, 0xab, 0x67, 0xeb, 0x17, 0x14, 0x00, 0x00,
+	proto.RegisterFile("compact_log_snapshot.proto", fileDescriptor_compact_log_snapshot_25f0d59811c1ef52)
+}
+
+var fileDescriptor_compact_log_snapshot_25f0d59811c1ef52 = []byte{
+	// 2565 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xdb, 0x7a, 0x1b, 0xb7,
+	0xb5, 0x0e, 0x25, 0xeb, 0x04, 0x1d, 0x02, 0x43, 0x96, 0x34, 0x92, 0x6d, 0x99, 0x96, 0xb7, 0x77,
+	0xb4, 0xf7, 0x4e, 0x94, 0x1d, 0xbb, 0xbd, 0xe8, 0xd7, 0x23, 0x34, 0x03, 0x52, 0xb0, 0x86, 0xc0,
+	0x08, 0x83, 0xd1, 0xc1, 0x69, 0x8b, 0x8e, 0xc9, 0xb1, 0xcc, 0x98, 0xe2, 0xc8, 0x1c, 0x2a, 0xb5,
+	0xdd, 0x63, 0x7a, 0x6e, 0x73, 0xdd, 0xb7, 0xe8, 0x1b, 0xf4, 0x21, 0xfa, 0x1a, 0xbd, 0xc8, 0x43,
+	0xf4, 0x5b, 0x98, 0x19, 0x92, 0xa2, 0x94, 0xb6, 0xb9, 0xeb, 0x1d, 0xb9, 0xfe, 0x1f, 0xff, 0x60,
+	0x1d, 0xb0, 0x70, 0x40, 0x1b, 0xcd, 0xf4, 0xec, 0x3c, 0x6e, 0xf6, 0x4d, 0x27, 0x3d, 0x35, 0x59,
+	0x37, 0x3e, 0xcf, 0x5e, 0xa4, 0xfd, 0x9d, 0xf3, 0x5e, 0xda, 0x4f, 0xc9, 0xf2, 0xf9, 0x69, 0xdc,
+	0x8d, 0x3b, 0x6f, 0xde, 0x26, 0x3b, 0xcd, 0xb4, 0xd3, 0x49, 0x9a, 0xfd, 0xb4, 0xb7, 0x71, 0xef,
+	0x34, 0x4d, 0x4f, 0x3b, 0xc9, 0x87, 0x96, 0xf2, 0xec, 0xe2, 0xf9, 0x87, 0xfd, 0xf6, 0x59, 0x92,
+	0xf5, 0xe3, 0xb3, 0xf3, 0x7c, 0xd4, 0xd6, 0x17, 0x13, 0x88, 0xb8, 0xb9, 0xa8, 0x9f, 0x9e, 0x86,
+	0x85, 0x24, 0x89, 0xd0, 0x32, 0x7c, 0xe2, 0x79, 0xbb, 0x93, 0x98, 0x5e, 0xf2, 0x3c, 0xe9, 0x25,
+	0xdd, 0x66, 0x92, 0x39, 0x95, 0xea, 0xe4, 0xf6, 0xfc, 0xa3, 0x87, 0x3b, 0xd7, 0x7c, 0x6a, 0xc7,
+	0x4f, 0x4f, 0x6b, 0xed, 0x4e, 0xa2, 0x4a, 0xb6, 0xba, 0xd9, 0x19, 0xb3, 0x64, 0xe4, 0x63, 0xb4,
+	0x02, 0xb2, 0x9d, 0x76, 0x37, 0x31, 0xed, 0xee, 0xf3, 0xb4, 0x77, 0x16, 0xf7, 0xdb, 0x69, 0x37,
+	0x73, 0x26, 0xac, 0xf0, 0x7b, 0x5f, 0x26, 0xec, 0xb7, 0xbb, 0x09, 0x1f, 0xf2, 0xd5, 0x72, 0xe7,
+	0x8a, 0x2d, 0x23, 0x0c, 0x2d, 0xbe, 0xba, 0x48, 0x7a, 0x6f, 0x4c, 0x16, 0x9f, 0x9d, 0x77, 0x92,
+	0xcc, 0x99, 0xb4, 0xa2, 0xd5, 0x6b, 0x45, 0x0f, 0x80, 0x19, 0x5a, 0xa2, 0x5a, 0x78, 0x35, 0xfc,
+	0x03, 0x73, 0x24, 0xb9, 0x4c, 0x3f, 0x79, 0xdd, 0x37, 0xe9, 0xb3, 0x4f, 0x92, 0x66, 0x3f, 0x73,
+	0x6e, 0x58, 0xad, 0x0f, 0xbe, 0x5c, 0x4b, 0x27, 0xaf, 0xfb, 0xd2, 0x92, 0x87, 0x11, 0xc0, 0xaf,
+	0x2e, 0x23, 0xd9, 0xd6, 0x17, 0x15, 0x84, 0xc7, 0x03, 0x45, 0x08, 0xba, 0x71, 0x71, 0xd1, 0x6e,
+	0x39, 0x95, 0x6a, 0x65, 0x7b, 0x4e, 0xd9, 0xdf, 0xe4, 0x1e, 0x9a, 0xcf, 0x1e, 0x9b, 0x4e, 0xda,
+	0xb4, 0xce, 0x39, 0x13, 0x16, 0x42, 0xd9, 0x63, 0xbf, 0xb0, 0x90, 0x4d, 0x4b, 0x68, 0x26, 0x2f,
+	0x4d, 0xdc, 0x39, 0x4d, 0x9d, 0x49, 0x4b, 0x98, 0xcb, 0x1e, 0xbb, 0xc9, 0x4b, 0xda, 0x39, 0x4d,
+	0xc9, 0x7d, 0xb4, 0x08, 0xf8, 0xd9, 0x4b, 0xf3, 0x32, 0x79, 0x63, 0xda, 0x2d, 0xe7, 0x46, 0x29,
+	0xe1, 0x9e, 0xbd, 0xdc, 0x4f, 0xde, 0xf0, 0x16, 0xb9, 0x8d, 0xe6, 0x9e, 0xbd, 0xe9, 0x27, 0x26,
+	0x6b, 0xbf, 0x4d, 0x9c, 0xa9, 0x6a, 0x65, 0x7b, 0x52, 0xcd, 0x82, 0x21, 0x6c, 0xbf, 0x4d, 0xc8,
+	0x03, 0xb4, 0x98, 0xf6, 0xda, 0xa7, 0xed, 0x6e, 0xdc, 0x31, 0xdd, 0xf8, 0x2c, 0x71, 0xa6, 0xed,
+	0xf8, 0x85, 0xd2, 0x28, 0xe2, 0xb3, 0x84, 0x6c, 0x22, 0x04, 0x15, 0xd9, 0x4b, 0xb2, 0x2c, 0x69,
+	0x39, 0x33, 0xd5, 0xca, 0xf6, 0xac, 0x1a, 0xb1, 0x6c, 0x7d, 0xb6, 0x89, 0xc8, 0xd5, 0xf4, 0x91,
+	0x2a, 0x5a, 0x18, 0x54, 0x57, 0xbb, 0xf5, 0xda, 0x3a, 0x3e, 0xa5, 0x50, 0x51, 0x2f, 0xbc, 0xf5,
+	0x7a, 0x10, 0x92, 0x89, 0xcb, 0x21, 0x39, 0x8f, 0x7b, 0x49, 0xb7, 0x6f, 0x2c, 0x94, 0x7b, 0x8c,
+	0x72, 0x53, 0x04, 0x84, 0xbb, 0x08, 0xe5, 0xfe, 0xf4, 0xe3, 0x5e, 0xdf, 0xfa, 0x3b, 0xa9, 0xac,
+	0x87, 0x21, 0x18, 0xc8, 0xfb, 0x88, 0x58, 0xb8, 0x99, 0x76, 0xfb, 0xa0, 0x92, 0xd3, 0x72, 0xbf,
+	0x31, 0x20, 0x6e, 0x0e, 0xe4, 0xec, 0x75, 0x64, 0x63, 0x61, 0x92, 0x6e, 0xcb, 0xba, 0x3e, 0xa9,
+	0x66, 0xe0, 0x3f, 0xeb, 0xb6, 0x60, 0xfa, 0x2f, 0xe2, 0xcc, 0xf4, 0xd2, 0x62, 0xfa, 0x85, 0xdf,
+	0x2f, 0xe2, 0x4c, 0xa5, 0xf9, 0xf4, 0xd7, 0xd1, 0xec, 0x00, 0x9d, 0xb5, 0xce, 0xcd, 0xf4, 0x0a,
+	0x68, 0x1b, 0x61, 0x18, 0xdc, 0x8a, 0xfb, 0xf1, 0xb3, 0x38, 0xcb, 0x29, 0x73, 0x56, 0x60, 0xe9,
+	0x45, 0x9c, 0x79, 0x85, 0x19, 0x98, 0xf7, 0xd1, 0xc2, 0x25, 0x16, 0xb2, 0x42, 0xf3, 0xad, 0x11,
+	0xca, 0x16, 0x5a, 0x04, 0xb1, 0xbc, 0x5e, 0x81, 0x33, 0x6f, 0x95, 0xe6, 0x5f, 0xc4, 0x99, 0x2d,
+	0x4a, 0xe0, 0xdc, 0x46, 0x73, 0x43, 0x7c, 0xc1, 0x6a, 0xcc, 0xbe, 0x2a, 0xc1, 0x6f, 0xa2, 0xf9,
+	0xb4, 0xd9, 0xbc, 0xe8, 0xf5, 0x92, 0x96, 0x89, 0xfb, 0xce, 0x62, 0xb5, 0xb2, 0x3d, 0xff, 0x68,
+	0x63, 0x27, 0xef, 0x1a, 0x3b, 0x65, 0xd7, 0xd8, 0xd1, 0x65, 0xd7, 0x50, 0xa8, 0xa4, 0xd3, 0x3e,
+	0x24, 0xe4, 0x59, 0xdc, 0x7c, 0x99, 0x74, 0x5b, 0xe6, 0xbc, 0xdd, 0x72, 0x96, 0xf2, 0x2c, 0x16,
+	0xa6, 0xa0, 0xdd, 0x22, 0x35, 0x34, 0xd5, 0x49, 0x3e, 0x4d, 0x3a, 0xce, 0xbb, 0xd5, 0xca, 0xf6,
+	0xd2, 0xa3, 0xff, 0xff, 0x37, 0x97, 0xb7, 0x35, 0xc1, 0x38, 0x95, 0x0f, 0x27, 0x31, 0x5a, 0x6a,
+	0x76, 0xe2, 0x2c, 0x6b, 0x3f, 0x6f, 0x17, 0xeb, 0x01, 0x5b, 0xc1, 0x6f, 0x7c, 0x05, 0x41, 0xf7,
+	0x92, 0x80, 0x1a, 0x13, 0xb4, 0xc1, 0x4e, 0xfa, 0x71, 0xbb, 0x93, 0x99, 0x4f, 0xb2, 0xb4, 0xeb,
+	0xdc, 0xb4, 0xd5, 0x35, 0x5f, 0xd8, 0x9e, 0x64, 0x69, 0xb7, 0xcc, 0x5c, 0x2f, 0xe9, 0xd8, 0x21,
+	0x36, 0x9e, 0x64, 0x90, 0x39, 0x55, 0x98, 0x8b, 0xcc, 0x5d, 0x62, 0x2d, 0xe7, 0x99, 0xeb, 0x5d,
+	0x43, 0x49, 0x6c, 0xec, 0x32, 0xe7, 0x56, 0x75, 0x72, 0x40, 0x49, 0x20, 0x78, 0xd9, 0xd6, 0x5f,
+	0x2a, 0x68, 0xb6, 0x8c, 0x04, 0x99, 0x47, 0x33, 0x91, 0xd8, 0x17, 0xf2, 0x48, 0xe0, 0x77, 0xc8,
+	0x1c, 0x9a, 0xf2, 0xd8, 0x6e, 0x54, 0xc7, 0x15, 0x32, 0x8b, 0x6e, 0x70, 0x51, 0x93, 0x78, 0x82,
+	0x20, 0x34, 0x2d, 0xa4, 0xe6, 0x2e, 0xc3, 0x93, 0xc0, 0x3e, 0xa2, 0x4a, 0x70, 0x51, 0xc7, 0x37,
+	0x80, 0xcd, 0x94, 0x92, 0x0a, 0x4f, 0x91, 0x19, 0x34, 0xe9, 0xcb, 0x3a, 0x9e, 0x06, 0x5b, 0x8d,
+	0x6a, 0xea, 0xe3, 0x19, 0xf8, 0x19, 0x50, 0xc1, 0x5d, 0x3c, 0x0b, 0x12, 0x1e, 0xd3, 0x94, 0xfb,
+	0x78, 0x0e, 0x84, 0xf7, 0xb8, 0xd0, 0x18, 0x81, 0x98, 0x2b, 0x85, 0x66, 0xc7, 0x1a, 0xcf, 0x93,
+	0x45, 0x34, 0x17, 0x6a, 0xaa, 0x59, 0x83, 0x09, 0x8d, 0x17, 0x60, 0xf0, 0x41, 0xc4, 0xd4, 0x09,
+	0x5e, 0xdc, 0xfa, 0xf3, 0x2a, 0xba, 0x79, 0x25, 0xce, 0x64, 0x13, 0x6d, 0x14, 0xf3, 0x36, 0xbe,
+	0xac, 0x1b, 0xd7, 0xa7, 0x61, 0xc8, 0x6b, 0xdc, 0xa5, 0x9a, 0x4b, 0x70, 0x85, 0xa0, 0xa5, 0x90,
+	0xa9, 0x43, 0xa6, 0x8c, 0xab, 0x68, 0xb8, 0xc7, 0x3c, 0x5c, 0x21, 0x18, 0x2d, 0x14, 0xb6, 0x50,
+	0x53, 0xa5, 0xf1, 0x04, 0xb9, 0x8d, 0xd6, 0x46, 0x2d, 0x46, 0x31, 0x57, 0x1e, 0x32, 0x05, 0xfe,
+	0x4d, 0x92, 0x65, 0xf4, 0x6e, 0x09, 0xee, 0x45, 0xda, 0x83, 0x10, 0xdd, 0x20, 0x0e, 0xba, 0x55,
+	0x18, 0x65, 0xa4, 0x8d, 0xac, 0x99, 0x06, 0x6b, 0x48, 0x75, 0x82, 0xa7, 0x46, 0xb4, 0xb8, 0x38,
+	0xa4, 0x3e, 0xf7, 0x8c, 0xbb, 0xc7, 0xdc, 0xfd, 0x30, 0x6a, 0xe0, 0x69, 0x72, 0x07, 0x39, 0x05,
+	0xa8, 0x59, 0x23, 0x30, 0x35, 0xee, 0x33, 0xe3, 0x2a, 0x46, 0x35, 0xf3, 0xf0, 0x0c, 0x79, 0x17,
+	0xcd, 0x17, 0x68, 0x83, 0x87, 0x10, 0xb0, 0x9b, 0x68, 0xb1, 0x30, 0x28, 0xe6, 0x4b, 0xea, 0xe1,
+	0x39, 0xb2, 0x8e, 0x56, 0x0a, 0x53, 0xa0, 0xa4, 0xcb, 0xc2, 0xd0, 0xb0, 0x63, 0x0e, 0xc3, 0x11,
+	0xd9, 0x42, 0x9b, 0x43, 0x2f, 0x74, 0x68, 0x5c, 0xe9, 0xfb, 0xcc, 0xd5, 0x52, 0x19, 0xcd, 0x1b,
+	0x4c, 0x46, 0x10, 0xdf, 0x35, 0xb4, 0xec, 0x4a, 0x21, 0x98, 0x0b, 0xf1, 0x01, 0x3f, 0x19, 0x3f,
+	0x64, 0x1e, 0xbe, 0x05, 0xba, 0x23, 0x00, 0x8d, 0xf4, 0x9e, 0x54, 0xfc, 0x29, 0xf3, 0xf0, 0xca,
+	0x95, 0x31, 0x4f, 0x98, 0x0b, 0x1f, 0x5c, 0x05, 0x57, 0x47, 0x00, 0x8f, 0x87, 0xc5, 0x3f, 0xe6,
+	0xe1, 0x35, 0xf2, 0x1e, 0x7a, 0x30, 0x02, 0xba, 0x3e, 0x67, 0x42, 0x9b, 0x1a, 0xe5, 0x3e, 0xf3,
+	0x8c, 0x96, 0xa6, 0xc0, 0xb0, 0x03, 0xf1, 0x1d, 0x21, 0xfa, 0x32, 0xd4, 0x78, 0x7d, 0x4c, 0x1a,
+	0x8c, 0x46, 0x06, 0x4c, 0x18, 0x7d, 0x8c, 0x37, 0xc6, 0xe6, 0xaa, 0x99, 0x6a, 0x70, 0x61, 0x43,
+	0x78, 0x9b, 0xac, 0x22, 0x52, 0x24, 0x64, 0xc8, 0x08, 0xf1, 0x1d, 0x72, 0x17, 0xad, 0x6b, 0x29,
+	0x4d, 0x83, 0x8a, 0x93, 0x51, 0xc4, 0x28, 0xe9, 0x33, 0x7c, 0x97, 0x3c, 0x40, 0xf7, 0x5c, 0x19,
+	0xf9, 0x9e, 0x11, 0x52, 0x1b, 0xea, 0xba, 0x2c, 0xd0, 0x26, 0x0c, 0xfd, 0x11, 0x2a, 0xde, 0x24,
+	0xff, 0x8d, 0xb6, 0x02, 0x25, 0xb5, 0x74, 0xa5, 0x6f, 0x6c, 0xc5, 0x9b, 0x48, 0x84, 0x51, 0x10,
+	0x48, 0xa5, 0x99, 0x67, 0x0e, 0x99, 0x0a, 0x81, 0x77, 0x8f, 0x3c, 0x44, 0xf7, 0xc7, 0x78, 0x5c,
+	0xb8, 0xb2, 0x11, 0xf8, 0x4c, 0x33, 0xd3, 0x60, 0x61, 0x48, 0xeb, 0x0c, 0x57, 0xc9, 0x7d, 0x74,
+	0xf7, 0xda, 0x29, 0x79, 0x54, 0xd3, 0x5d, 0x1a, 0x32, 0x7c, 0xdf, 0x46, 0x1e, 0x8a, 0x27, 0x90,
+	0x5c, 0xe8, 0xbc, 0x36, 0xa1, 0x26, 0xb7, 0xc7, 0x80, 0x52, 0x1c, 0xff, 0x8f, 0x8d, 0xdb, 0x10,
+	0x00, 0xfd, 0x9a, 0x62, 0x07, 0x11, 0xac, 0xa6, 0xff, 0x85, 0xb8, 0x29, 0x66, 0x55, 0xc6, 0x04,
+	0xff, 0xef, 0x0a, 0x34, 0x90, 0x7c, 0x1f, 0xf2, 0x73, 0x09, 0xa2, 0x1a, 0x7f, 0x00, 0xf1, 0x3c,
+	0xa2, 0xfe, 0xa0, 0xc4, 0x61, 0xc1, 0x28, 0xcf, 0xf8, 0x4c, 0xd4, 0xf5, 0x1e, 0x7e, 0x44, 0x16,
+	0xd0, 0x2c, 0xc0, 0x8a, 0x79, 0x12, 0x3f, 0x86, 0x45, 0x0a, 0xff, 0xa8, 0x72, 0xf7, 0xf8, 0x21,
+	0x03, 0xed, 0x06, 0x15, 0x5e, 0x51, 0x0c, 0xf8, 0x6b, 0xb0, 0x2a, 0x00, 0x07, 0xa7, 0xcd, 0x2e,
+	0x75, 0xf7, 0xa3, 0x60, 0xf8, 0xfd, 0xaf, 0x93, 0x15, 0x74, 0x93, 0x46, 0x5a, 0x1e, 0x52, 0x37,
+	0x8a, 0x1a, 0xc6, 0xa5, 0xc2, 0x65, 0x3e, 0xfe, 0x16, 0x78, 0xaa, 0x8f, 0xb9, 0x67, 0x8e, 0x14,
+	0x0d, 0xa8, 0x92, 0x91, 0xf0, 0x4c, 0xd9, 0x93, 0xbe, 0x0d, 0xee, 0x8c, 0x83, 0x79, 0x8f, 0xfa,
+	0x0e, 0xb9, 0x87, 0x6e, 0x8f, 0xc8, 0xf9, 0x34, 0x12, 0xee, 0x5e, 0xb9, 0xf0, 0x99, 0x87, 0xbf,
+	0x0b, 0xe9, 0xbb, 0x96, 0xb0, 0x17, 0x69, 0x08, 0x96, 0xb1, 0x1d, 0xe0, 0x7b, 0xd0, 0x01, 0x46,
+	0xa7, 0x55, 0xcc, 0xd7, 0xc3, 0x14, 0x3e, 0x0e, 0x08, 0x15, 0xd4, 0x3f, 0x79, 0xca, 0x46, 0xa0,
+	0x5d, 0x28, 0xa1, 0x70, 0x9f, 0x07, 0x01, 0xe8, 0x94, 0x1f, 0x90, 0xee, 0x7e, 0x5e, 0x76, 0x87,
+	0x94, 0xfb, 0x74, 0xd7, 0x67, 0xd8, 0x85, 0xc5, 0x33, 0xe0, 0x95, 0x3a, 0xd7, 0x10, 0x3d, 0xe8,
+	0x10, 0xd6, 0x4e, 0xdd, 0x83, 0x88, 0x2b, 0xe6, 0xe1, 0x1a, 0xb4, 0x37, 0x6b, 0x3a, 0xa2, 0xdc,
+	0x26, 0xb7, 0x3e, 0xb0, 0x94, 0x6d, 0x60, 0x8f, 0x6c, 0xa0, 0x55, 0x6b, 0xf1, 0x18, 0xf5, 0x8a,
+	0x1f, 0x3a, 0x5f, 0xb8, 0x1c, 0xa6, 0x7f, 0x19, 0xa3, 0x87, 0x92, 0x7b, 0xcc, 0xc3, 0x4f, 0x60,
+	0x75, 0x0d, 0xba, 0xb3, 0xf1, 0x22, 0x95, 0x77, 0xd9, 0x00, 0x12, 0x3c, 0xb4, 0xe7, 0x19, 0x62,
+	0xde, 0xe0, 0x73, 0x07, 0xb6, 0x27, 0x5e, 0xc5, 0xa3, 0x90, 0x29, 0xac, 0x6c, 0x93, 0x1b, 0x80,
+	0xb0, 0x7d, 0x84, 0x30, 0xbd, 0xa1, 0x09, 0x62, 0x69, 0xd8, 0x71, 0xe0, 0x53, 0x2e, 0xb0, 0x86,
+	0xf4, 0x84, 0x9a, 0x0a, 0x6f, 0xf7, 0xc4, 0x40, 0x59, 0x4a, 0xc5, 0x20, 0xf1, 0xbe, 0xa9, 0x29,
+	0xd9, 0x28, 0x4b, 0x0c, 0x3f, 0x85, 0x02, 0x2d, 0x69, 0x45, 0x6a, 0x4d, 0xa8, 0x15, 0xa3, 0x0d,
+	0x08, 0xc9, 0xc7, 0xb0, 0xf8, 0x86, 0x70, 0x61, 0x36, 0x5c, 0x68, 0xa6, 0x54, 0x14, 0x40, 0x1c,
+	0xbe, 0x7f, 0x59, 0x41, 0x06, 0xc1, 0x25, 0x85, 0x1f, 0x8c, 0xce, 0xc3, 0x95, 0x22, 0xe4, 0xa1,
+	0x86, 0xc9, 0x16, 0x3b, 0x87, 0xfd, 0xa8, 0x66, 0xf8, 0x87, 0x45, 0x68, 0xca, 0x79, 0x8c, 0x85,
+	0x00, 0x1b, 0xbb, 0x23, 0x14, 0x78, 0xb9, 0x98, 0x20, 0x6e, 0x3e, 0x17, 0x0c, 0xff, 0x08, 0x8a,
+	0x35, 0x12, 0xfc, 0x20, 0x62, 0xf6, 0x1b, 0x5a, 0x51, 0x58, 0x80, 0x87, 0x5c, 0xfa, 0x79, 0xe4,
+	0x5b, 0xe4, 0xbf, 0x50, 0xb5, 0x26, 0x15, 0xe3, 0x75, 0x61, 0xf6, 0xd9, 0xc9, 0xf5, 0xac, 0x04,
+	0xbc, 0x85, 0xc2, 0x11, 0x91, 0xef, 0x5f, 0x4f, 0x79, 0x0e, 0xf3, 0xb4, 0x8d, 0xe3, 0x7a, 0xfc,
+	0x14, 0x36, 0x17, 0x76, 0xec, 0xfa, 0x51, 0x68, 0xbb, 0xf9, 0x75, 0x9c, 0x17, 0x76, 0x63, 0x3d,
+	0x11, 0x9a, 0x1e, 0x17, 0x8b, 0xad, 0x0b, 0x8b, 0xa4, 0xf4, 0x8a, 0x8b, 0x20, 0xd2, 0x26, 0xc7,
+	0x71, 0x0a, 0x25, 0x71, 0x48, 0xfd, 0x88, 0xd9, 0x1e, 0xe5, 0x4b, 0x51, 0x37, 0x35, 0xd8, 0xa8,
+	0x4e, 0x02, 0x86, 0xcf, 0xa1, 0x24, 0xca, 0x61, 0x96, 0x84, 0x5f, 0x01, 0xbf, 0x41, 0xfd, 0x9a,
+	0x54, 0x0d, 0xe6, 0x19, 0xaa, 0x14, 0x3d, 0x31, 0x3e, 0xd7, 0x4c, 0x51, 0x1f, 0xf7, 0x6c, 0xbd,
+	0x44, 0xbb, 0xf6, 0xa4, 0x00, 0x5b, 0x67, 0x68, 0x97, 0x8d, 0xcf, 0x69, 0x88, 0x33, 0xf0, 0x9d,
+	0x8b, 0x90, 0x29, 0x6d, 0x34, 0x55, 0x75, 0x06, 0xad, 0xcd, 0x8f, 0x1a, 0x02, 0x78, 0x0d, 0xaa,
+	0xdd, 0x3d, 0xdc, 0x87, 0xe1, 0xd0, 0x84, 0xa9, 0x0f, 0x1d, 0xcb, 0xae, 0xa3, 0x30, 0xff, 0x04,
+	0xbe, 0x20, 0x55, 0x74, 0x67, 0x38, 0xc0, 0x0a, 0xdb, 0x42, 0xab, 0x2b, 0x19, 0x05, 0x66, 0xf7,
+	0x04, 0x7f, 0x0a, 0x33, 0x53, 0x2c, 0x8f, 0x81, 0xf1, 0x24, 0x0b, 0xed, 0x1a, 0x65, 0xc7, 0x3c,
+	0xd4, 0xf8, 0xc7, 0xf9, 0x56, 0x65, 0x87, 0x8f, 0x41, 0x70, 0x70, 0x5e, 0x93, 0x01, 0x53, 0x14,
+	0x36, 0xe8, 0x31, 0xf0, 0x8d, 0x4d, 0x47, 0x3e, 0x4e, 0xb1, 0x1a, 0x53, 0x4c, 0xb8, 0xcc, 0xd0,
+	0xc6, 0x2e, 0xaf, 0x47, 0x32, 0x0a, 0xf1, 0x5b, 0x68, 0x8a, 0x01, 0xec, 0x7b, 0xa1, 0xcd, 0x87,
+	0xc7, 0x04, 0x67, 0x1e, 0xfe, 0x09, 0x78, 0xa2, 0x15, 0x15, 0x21, 0xcd, 0xb7, 0x46, 0x1e, 0x1a,
+	0xba, 0x6b, 0xb7, 0x27, 0xfc, 0x53, 0xd8, 0xe3, 0xf2, 0xd4, 0xd5, 0x7c, 0xee, 0x6a, 0x23, 0xe4,
+	0x68, 0x1a, 0xf3, 0x50, 0xfc, 0x0c, 0xd2, 0x3c, 0x4a, 0x52, 0xf2, 0xc8, 0xd0, 0x5a, 0xcd, 0xb6,
+	0x06, 0xa3, 0x8f, 0xe0, 0xf4, 0xf7, 0xf3, 0x11, 0x9f, 0x5c, 0x2a, 0x60, 0xd2, 0xbb, 0xcc, 0xb8,
+	0x34, 0xd4, 0xf8, 0x17, 0x64, 0x05, 0x61, 0x8f, 0x1f, 0x72, 0x3b, 0xa9, 0xdd, 0x13, 0xf3, 0x94,
+	0x29, 0x89, 0x7f, 0x09, 0x27, 0xae, 0xf9, 0x82, 0xea, 0x29, 0x19, 0xe0, 0xcf, 0x2a, 0x64, 0x1d,
+	0x0a, 0x43, 0xb3, 0xfa, 0xf0, 0x00, 0xa5, 0xa8, 0xa8, 0x33, 0xfc, 0xab, 0x0a, 0x59, 0x46, 0x4b,
+	0xc3, 0x6d, 0xa5, 0xce, 0x8e, 0x03, 0xfc, 0xeb, 0x0a, 0x21, 0x68, 0x31, 0xa0, 0x8a, 0x36, 0xca,
+	0x2c, 0xe0, 0xdf, 0x54, 0xc8, 0x1d, 0xb4, 0x56, 0x8b, 0x84, 0x7b, 0x5d, 0xe0, 0x7f, 0x5b, 0x21,
+	0xab, 0xe8, 0xa6, 0x90, 0x26, 0x8c, 0xdc, 0x3d, 0x13, 0xd2, 0x43, 0x66, 0xf7, 0x2e, 0xfc, 0xbb,
+	0x0a, 0xb9, 0x07, 0x27, 0xc6, 0xe1, 0x99, 0xc1, 0x1c, 0x44, 0xb2, 0x68, 0x0e, 0x20, 0xfb, 0xfb,
+	0x0a, 0x79, 0x80, 0x36, 0xaf, 0x23, 0x70, 0x8f, 0x09, 0xcd, 0x6b, 0x9c, 0x29, 0xfc, 0x87, 0x0a,
+	0xd9, 0x40, 0x2b, 0xe5, 0x24, 0x77, 0x4f, 0x34, 0x33, 0xa1, 0xdd, 0x64, 0x5d, 0x86, 0xff, 0x58,
+	0x21, 0xdb, 0xe8, 0xc1, 0xf0, 0x30, 0x11, 0x32, 0xc5, 0xa9, 0xcf, 0x9f, 0x32, 0xa3, 0x58, 0xc0,
+	0x60, 0x6b, 0xf7, 0xe1, 0x27, 0xf5, 0xf0, 0x9f, 0x2a, 0xe4, 0x21, 0xaa, 0x5e, 0xc7, 0x2c, 0x7f,
+	0x01, 0x17, 0x7f, 0x5e, 0x21, 0xb7, 0xd1, 0x6a, 0x50, 0xa7, 0x23, 0xe7, 0xb9, 0x62, 0x2e, 0x27,
+	0xf8, 0xef, 0x33, 0x5b, 0x7f, 0x9d, 0x46, 0xf3, 0x23, 0xaf, 0x0d, 0x97, 0xef, 0x63, 0x95, 0x7f,
+	0x7e, 0x1f, 0x9b, 0xf8, 0x4a, 0xf7, 0xb1, 0xbb, 0x08, 0xf5, 0x2e, 0xba, 0xf0, 0xc2, 0x63, 0xce,
+	0x32, 0x7b, 0x3f, 0xae, 0xa8, 0xb9, 0xc2, 0xd2, 0xc8, 0x00, 0x1e, 0x3e, 0x6c, 0x14, 0xcf, 0x01,
+	0x73, 0x83, 0x17, 0x0a, 0xb8, 0xcb, 0x9f, 0xc7, 0xbd, 0xf8, 0x2c, 0xe9, 0x27, 0xbd, 0xcc, 0x99,
+	0xaa, 0x4e, 0x16, 0xb7, 0xeb, 0xc2, 0x02, 0x77, 0xcd, 0xc1, 0xdb, 0x8d, 0xbd, 0x80, 0xa3, 0xfc,
+	0x8a, 0x54, 0x3c, 0xc5, 0x44, 0xc5, 0x15, 0x1d, 0xae, 0x48, 0xc9, 0xeb, 0xf3, 0x4e, 0xdc, 0xee,
+	0x3a, 0xb7, 0x06, 0x17, 0x63, 0x96, 0x5b, 0xc8, 0x43, 0xb4, 0x54, 0x80, 0x26, 0xbd, 0xe8, 0x9f,
+	0x5f, 0xf4, 0x9d, 0x15, 0xab, 0xb2, 0x58, 0x58, 0xa5, 0x35, 0xc2, 0xe3, 0x43, 0x49, 0x4b, 0x7a,
+	0xbd, 0xb4, 0xe7, 0xac, 0xe6, 0x8f, 0x0f, 0x85, 0x91, 0x81, 0x8d, 0x44, 0x43, 0xad, 0xfc, 0xa6,
+	0xe7, 0xac, 0xd9, 0x5b, 0xe1, 0xce, 0xbf, 0x7a, 0xf0, 0xd9, 0x29, 0x66, 0x53, 0xb3, 0xa3, 0x06,
+	0xdf, 0xce, 0xff, 0x8e, 0xca, 0x66, 0xe9, 0x45, 0xaf, 0x99, 0x38, 0xce, 0x57, 0x93, 0x0d, 0xed,
+	0xa8, 0x81, 0x6c, 0xfe, 0x97, 0x7c, 0x84, 0x56, 0xae, 0x3c, 0x2b, 0xd9, 0x12, 0x58, 0xb7, 0x25,
+	0x40, 0xc6, 0x9e, 0x8a, 0xa0, 0x18, 0x3e, 0x42, 0x2b, 0xc3, 0xdb, 0xfd, 0xc8, 0x30, 0x67, 0xc3,
+	0xc6, 0x95, 0x94, 0xb7, 0xfc, 0xe1, 0xa8, 0x2d, 0x8a, 0x16, 0x2f, 0x39, 0x07, 0x07, 0x51, 0xb8,
+	0xb9, 0x95, 0xfb, 0x33, 0x34, 0xf1, 0x06, 0xd5, 0xf8, 0x1d, 0x00, 0x9e, 0x84, 0x52, 0x8c, 0x03,
+	0x95, 0xad, 0xcf, 0x2b, 0x03, 0x8d, 0x62, 0xea, 0x55, 0x74, 0xe7, 0xd2, 0x01, 0x60, 0x30, 0x26,
+	0x94, 0x91, 0x72, 0x19, 0x7e, 0xa7, 0x3c, 0xb3, 0x0d, 0x80, 0x31, 0x02, 0xac, 0x90, 0x35, 0x76,
+	0xac, 0x99, 0x12, 0xd4, 0x1f, 0x07, 0x27, 0xa0, 0x29, 0xd6, 0x99, 0x60, 0x8a, 0xbb, 0xe3, 0xd8,
+	0xe4, 0xd6, 0xdf, 0x2a, 0xc8, 0xf9, 0xb2, 0xf7, 0xb5, 0xff, 0xcc, 0x87, 0xb3, 0xcb, 0x6f, 0x62,
+	0xd3, 0xe3, 0x6f, 0x62, 0xcf, 0xa6, 0xed, 0x2a, 0x7e, 0xfc, 0x8f, 0x01, 0x00, 0x97, 0x54, 0x2f,
+	0x98, 0xcc, 0x15, 0x00, 0x00,
 }
@@ -31,17 +31,19 @@ type CompactSnapshot struct {
 	//	*CompactSnapshot_LogSnapshot
 	//	*CompactSnapshot_SystemSnapshot
 	//	*CompactSnapshot_ActivitySnapshot
-	Data                 isCompactSnapshot_Data `protobuf_oneof:"data"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Data isCompactSnapshot_Data `protobuf_oneof:"data"`
+	// Custom labels configured for the server (sorted by key)
+	Tags                 []*Tag   `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CompactSnapshot) Reset()         { *m = CompactSnapshot{} }
 func (m *CompactSnapshot) String() string { return proto.CompactTextString(m) }
 func (*CompactSnapshot) ProtoMessage()    {}
 func (*CompactSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_compact_snapshot_fc01413db2a69e2a, []int{0}
+	return fileDescriptor_compact_snapshot_8e00d8073c9c7386, []int{0}
 }
 func (m *CompactSnapshot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CompactSnapshot.Unmarshal(m, b)
@@ -149,6 +151,13 @@ func (m *CompactSnapshot) GetActivitySnapshot() *CompactActivitySnapshot {
 	return nil
 }
 
+func (m *CompactSnapshot) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*CompactSnapshot) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _CompactSnapshot_OneofMarshaler, _CompactSnapshot_OneofUnmarshaler, _CompactSnapshot_OneofSizer, []interface{}{
@@ -257,7 +266,7 @@ func (m *CompactSnapshot_BaseRefs) Reset()         { *m = CompactSnapshot_BaseRe
 func (m *CompactSnapshot_BaseRefs) String() string { return proto.CompactTextString(m) }
 func (*CompactSnapshot_BaseRefs) ProtoMessage()    {}
 func (*CompactSnapshot_BaseRefs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_compact_snapshot_fc01413db2a69e2a, []int{0, 0}
+	return fileDescriptor_compact_snapshot_8e00d8073c9c7386, []int{0, 0}
 }
 func (m *CompactSnapshot_BaseRefs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CompactSnapshot_BaseRefs.Unmarshal(m, b)
@@ -318,42 +327,42 @@ func init() {
 }
 
 func init() {
-	proto.RegisterFile("compact_snapshot.proto", fileDescriptor_compact_snapshot_fc01413db2a69e2a)
+	proto.RegisterFile("compact_snapshot.proto", fileDescriptor_compact_snapshot_8e00d8073c9c7386)
 }
 
-var fileDescriptor_compact_snapshot_fc01413db2a69e2a = []byte{
-	// 513 bytes of a gzipped FileDescriptorProto
+var fileDescriptor_compact_snapshot_8e00d8073c9c7386 = []byte{
+	// 524 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xdf, 0x6e, 0xd3, 0x30,
-	0x14, 0xc6, 0xc9, 0xba, 0x56, 0x9d, 0x13, 0xd6, 0xd6, 0x43, 0x93, 0x15, 0x09, 0xad, 0xda, 0x04,
-	0x54, 0xfc, 0xc9, 0xa4, 0xc1, 0x2d, 0x17, 0x1b, 0x5c, 0xf0, 0x67, 0x20, 0x61, 0x36, 0xb8, 0xe0,
-	0x22, 0x72, 0x13, 0x37, 0x0b, 0x72, 0xe2, 0xd4, 0x76, 0x26, 0x95, 0x07, 0xe1, 0x35, 0x78, 0x45,
-	0x54, 0x27, 0x4e, 0x33, 0x13, 0x7a, 0xd7, 0x73, 0xce, 0xf7, 0xfd, 0x6c, 0x7f, 0xa7, 0x01, 0x87,
-	0x11, 0xcf, 0x0a, 0x12, 0xa9, 0x50, 0xe6, 0xa4, 0x90, 0x37, 0x5c, 0x05, 0x85, 0xe0, 0x8a, 0xc3,
-	0x83, 0x22, 0x21, 0x39, 0x61, 0xab, 0x5f, 0x34, 0x88, 0x38, 0x63, 0x34, 0x52, 0x5c, 0xf8, 0x47,
-	0x09, 0xe7, 0x09, 0xa3, 0xa7, 0x5a, 0x32, 0x2f, 0x17, 0xa7, 0x2a, 0xcd, 0xa8, 0x54, 0x24, 0x2b,
-	0x2a, 0x97, 0x7f, 0x64, 0x68, 0x24, 0x52, 0xe9, 0x6d, 0xaa, 0x56, 0x16, 0xd6, 0xf7, 0x8d, 0x80,
-	0xf1, 0xc4, 0x9e, 0x3d, 0x6c, 0xae, 0xb2, 0x92, 0x8a, 0x66, 0xf6, 0xd8, 0x93, 0x37, 0x44, 0xd0,
-	0xb8, 0xaa, 0x8e, 0x7f, 0x0f, 0xc1, 0xe8, 0x4d, 0xa5, 0xff, 0x5a, 0xeb, 0xe0, 0x2b, 0x70, 0x68,
-	0x3c, 0xe1, 0x2d, 0x15, 0x32, 0xe5, 0x79, 0x98, 0x91, 0x9f, 0x5c, 0x20, 0x67, 0xea, 0xcc, 0xfa,
-	0xf8, 0x81, 0x99, 0x7e, 0xab, 0x86, 0x9f, 0xd6, 0xb3, 0x6e, 0x57, 0x9a, 0x73, 0x81, 0x76, 0xba,
-	0x5d, 0xeb, 0x19, 0x7c, 0x06, 0x26, 0x4d, 0x2e, 0xc6, 0x86, 0x7a, 0x53, 0x67, 0xb6, 0x87, 0xc7,
-	0xcd, 0xa0, 0x76, 0xc0, 0x13, 0x70, 0xbf, 0x39, 0xa2, 0x2c, 0xd3, 0x18, 0xed, 0x6a, 0xa1, 0x67,
-	0x9a, 0xd7, 0x65, 0x1a, 0xc3, 0xd7, 0xc0, 0xab, 0x8d, 0x34, 0x0e, 0x89, 0x42, 0xfd, 0xa9, 0x33,
-	0x73, 0xcf, 0xfc, 0xa0, 0xca, 0x3c, 0x30, 0x99, 0x07, 0x57, 0x26, 0x73, 0xec, 0x36, 0xfa, 0x73,
-	0x05, 0x3f, 0x80, 0xbd, 0x39, 0x91, 0x34, 0x14, 0x74, 0x21, 0xd1, 0x40, 0x7b, 0x5f, 0x04, 0x1d,
-	0x4b, 0x0c, 0xac, 0xd4, 0x82, 0x0b, 0x22, 0x29, 0xa6, 0x0b, 0x89, 0x87, 0xf3, 0xfa, 0x17, 0xbc,
-	0x04, 0x5e, 0x7b, 0x3f, 0x08, 0x68, 0xdc, 0x93, 0x6d, 0xb8, 0x4b, 0x9e, 0x18, 0xe2, 0xbb, 0x7b,
-	0xd8, 0x65, 0x9b, 0x12, 0x5e, 0x83, 0x91, 0xb5, 0x51, 0xe4, 0x6a, 0xe0, 0xd3, 0xad, 0xf7, 0xd3,
-	0x96, 0x16, 0x73, 0x5f, 0xde, 0xe9, 0xc0, 0x1f, 0x60, 0xf2, 0xcf, 0xbf, 0x0c, 0x79, 0x1a, 0xfc,
-	0x7c, 0x1b, 0xf8, 0xbc, 0x36, 0xb5, 0xd0, 0x63, 0x62, 0xf5, 0xfc, 0x3f, 0x3d, 0x30, 0x34, 0xc1,
-	0xc0, 0x8f, 0x60, 0x24, 0x38, 0xd3, 0xd1, 0x52, 0x41, 0xf3, 0x88, 0x4a, 0xe4, 0x4c, 0x7b, 0x33,
-	0xf7, 0xec, 0xb8, 0xf3, 0x1c, 0xcc, 0xd9, 0xda, 0x57, 0x49, 0xf1, 0xbe, 0x68, 0x97, 0x12, 0x7e,
-	0x07, 0x07, 0x31, 0x51, 0xc4, 0xec, 0xca, 0x00, 0x77, 0x34, 0xf0, 0x71, 0x27, 0xf0, 0x6d, 0xad,
-	0xdf, 0x40, 0x61, 0x6c, 0xb7, 0x24, 0xfc, 0x0c, 0xc6, 0xcb, 0x92, 0x8a, 0x55, 0x9b, 0xda, 0xd3,
-	0xd4, 0x93, 0x4e, 0xea, 0x97, 0xb5, 0x78, 0x83, 0x1c, 0x2d, 0xef, 0xd4, 0x12, 0x5e, 0x01, 0x58,
-	0xf1, 0xd2, 0x7c, 0xc1, 0x45, 0x46, 0x54, 0xca, 0x73, 0x89, 0x76, 0x35, 0xf1, 0xd1, 0xff, 0x89,
-	0xef, 0x37, 0x6a, 0x3c, 0x59, 0x5a, 0x1d, 0xfd, 0x7c, 0x41, 0x99, 0x2e, 0xda, 0x17, 0xed, 0x6f,
-	0x79, 0x3e, 0xae, 0xf5, 0xad, 0xe7, 0x0b, 0xbb, 0x25, 0x2f, 0x06, 0x60, 0x57, 0x87, 0x32, 0xd0,
-	0x1f, 0xca, 0xcb, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x47, 0xfd, 0x62, 0xd1, 0xd9, 0x04, 0x00,
-	0x00,
+	0x14, 0xc6, 0xc9, 0xfa, 0x87, 0xce, 0x09, 0x6b, 0xeb, 0xa1, 0xc9, 0x8a, 0x84, 0x56, 0x6d, 0x02,
+	0x2a, 0x18, 0x99, 0x34, 0xb8, 0xe5, 0x62, 0x83, 0x0b, 0xfe, 0x0c, 0x24, 0x4c, 0x07, 0x17, 0x5c,
+	0x44, 0x6e, 0xe2, 0x66, 0x41, 0x49, 0x9c, 0xda, 0xce, 0xa4, 0xf2, 0x52, 0xbc, 0x04, 0x0f, 0x86,
+	0xea, 0xc4, 0x69, 0x6a, 0x42, 0xef, 0x72, 0xce, 0xf9, 0xbe, 0x5f, 0xec, 0xf3, 0x25, 0xe0, 0x28,
+	0x60, 0x69, 0x4e, 0x02, 0xe9, 0x8b, 0x8c, 0xe4, 0xe2, 0x96, 0x49, 0x2f, 0xe7, 0x4c, 0x32, 0x78,
+	0x98, 0x47, 0x24, 0x23, 0xc9, 0xea, 0x17, 0xf5, 0x02, 0x96, 0x24, 0x34, 0x90, 0x8c, 0xbb, 0xc7,
+	0x11, 0x63, 0x51, 0x42, 0xcf, 0x95, 0x64, 0x5e, 0x2c, 0xce, 0x65, 0x9c, 0x52, 0x21, 0x49, 0x9a,
+	0x97, 0x2e, 0xf7, 0x58, 0xd3, 0x48, 0x20, 0xe3, 0xbb, 0x58, 0xae, 0x0c, 0xac, 0xeb, 0x6a, 0x41,
+	0xc2, 0x22, 0x73, 0xf6, 0xa8, 0x3e, 0xca, 0x4a, 0x48, 0x9a, 0x9a, 0x63, 0x47, 0xdc, 0x12, 0x4e,
+	0xc3, 0xb6, 0xea, 0xe4, 0xcf, 0x00, 0x0c, 0xdf, 0x94, 0xee, 0xaf, 0x95, 0x0b, 0xbe, 0x02, 0x47,
+	0x9a, 0xe0, 0xdf, 0x51, 0x2e, 0x62, 0x96, 0xf9, 0x29, 0xf9, 0xc9, 0x38, 0xb2, 0x26, 0xd6, 0xb4,
+	0x87, 0x1f, 0xea, 0xe9, 0xb7, 0x72, 0xf8, 0x69, 0x3d, 0x6b, 0x77, 0xc5, 0x19, 0xe3, 0x68, 0xaf,
+	0xdd, 0xb5, 0x9e, 0xc1, 0xe7, 0x60, 0x5c, 0x6f, 0x49, 0xdb, 0x50, 0x67, 0x62, 0x4d, 0xf7, 0xf1,
+	0xa8, 0x1e, 0x54, 0x0e, 0x78, 0x0a, 0x1e, 0xd4, 0xaf, 0x28, 0x8a, 0x38, 0x44, 0x5d, 0x25, 0x74,
+	0x74, 0xf3, 0xa6, 0x88, 0x43, 0xf8, 0x1a, 0x38, 0x95, 0x91, 0x86, 0x3e, 0x91, 0xa8, 0x37, 0xb1,
+	0xa6, 0xf6, 0x85, 0xeb, 0x95, 0x09, 0x78, 0x3a, 0x01, 0x6f, 0xa6, 0x13, 0xc0, 0x76, 0xad, 0xbf,
+	0x94, 0xf0, 0x03, 0xd8, 0x9f, 0x13, 0x41, 0x7d, 0x4e, 0x17, 0x02, 0xf5, 0x95, 0xf7, 0x85, 0xd7,
+	0x12, 0xa9, 0x67, 0x6c, 0xcd, 0xbb, 0x22, 0x82, 0x62, 0xba, 0x10, 0x78, 0x30, 0xaf, 0x9e, 0xe0,
+	0x35, 0x70, 0x9a, 0x69, 0x21, 0xa0, 0x70, 0x4f, 0x77, 0xe1, 0xae, 0x59, 0xa4, 0x89, 0xef, 0xee,
+	0x61, 0x3b, 0xd9, 0x94, 0xf0, 0x06, 0x0c, 0x8d, 0x7c, 0x91, 0xad, 0x80, 0xcf, 0x76, 0x9e, 0x4f,
+	0x59, 0x1a, 0xcc, 0x03, 0xb1, 0xd5, 0x81, 0x3f, 0xc0, 0xf8, 0x9f, 0x6f, 0x0e, 0x39, 0x0a, 0x7c,
+	0xb6, 0x0b, 0x7c, 0x59, 0x99, 0x1a, 0xe8, 0x11, 0x31, 0x7a, 0xf0, 0x0c, 0x74, 0x25, 0x89, 0x04,
+	0xba, 0x3f, 0xe9, 0x4c, 0xed, 0x0b, 0xd4, 0xca, 0x9b, 0x91, 0x08, 0x2b, 0x95, 0xfb, 0xbb, 0x03,
+	0x06, 0x7a, 0x8d, 0xf0, 0x23, 0x18, 0x72, 0x96, 0xa8, 0x20, 0x28, 0xa7, 0x59, 0x40, 0x05, 0xb2,
+	0x14, 0xe5, 0xa4, 0x95, 0x82, 0x59, 0x42, 0xb1, 0x96, 0xe2, 0x03, 0xde, 0x2c, 0x05, 0xfc, 0x0e,
+	0x0e, 0x43, 0x22, 0x89, 0x4e, 0x56, 0x03, 0xf7, 0x14, 0xf0, 0x49, 0x2b, 0xf0, 0x6d, 0xa5, 0xdf,
+	0x40, 0x61, 0x68, 0xb6, 0x04, 0xfc, 0x0c, 0x46, 0xcb, 0x82, 0xf2, 0x55, 0x93, 0xda, 0x51, 0xd4,
+	0xd3, 0x56, 0xea, 0x97, 0xb5, 0x78, 0x83, 0x1c, 0x2e, 0xb7, 0x6a, 0x01, 0x67, 0x00, 0x96, 0xbc,
+	0x38, 0x5b, 0x30, 0x9e, 0x12, 0x19, 0xb3, 0x4c, 0xa0, 0xae, 0x22, 0x3e, 0xfe, 0x3f, 0xf1, 0xfd,
+	0x46, 0x8d, 0xc7, 0x4b, 0xa3, 0xa3, 0xae, 0xcf, 0x69, 0xa2, 0x8a, 0xe6, 0x41, 0x7b, 0x3b, 0xae,
+	0x8f, 0x2b, 0x7d, 0xe3, 0xfa, 0xdc, 0x6c, 0x89, 0xab, 0x3e, 0xe8, 0xaa, 0xa5, 0xf4, 0xd5, 0x6f,
+	0xf5, 0xf2, 0xef, 0x00, 0x9b, 0x13, 0x0b, 0x41, 0x15, 0x05, 0x00, 0x00,
 }
@@ -19,6 +19,35 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type WraparoundRisk int32
+
+const (
+	WraparoundRisk_WRAPAROUND_RISK_LOW      WraparoundRisk = 0
+	WraparoundRisk_WRAPAROUND_RISK_ELEVATED WraparoundRisk = 1
+	WraparoundRisk_WRAPAROUND_RISK_HIGH     WraparoundRisk = 2
+	WraparoundRisk_WRAPAROUND_RISK_CRITICAL WraparoundRisk = 3
+)
+
+var WraparoundRisk_name = map[int32]string{
+	0: "WRAPAROUND_RISK_LOW",
+	1: "WRAPAROUND_RISK_ELEVATED",
+	2: "WRAPAROUND_RISK_HIGH",
+	3: "WRAPAROUND_RISK_CRITICAL",
+}
+var WraparoundRisk_value = map[string]int32{
+	"WRAPAROUND_RISK_LOW":      0,
+	"WRAPAROUND_RISK_ELEVATED": 1,
+	"WRAPAROUND_RISK_HIGH":     2,
+	"WRAPAROUND_RISK_CRITICAL": 3,
+}
+
+func (x WraparoundRisk) String() string {
+	return proto.EnumName(WraparoundRisk_name, int32(x))
+}
+func (WraparoundRisk) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{0}
+}
+
 type BackendCountStatistic_BackendState int32
 
 const (
@@ -54,7 +83,7 @@ func (x BackendCountStatistic_BackendState) String() string {
 	return proto.EnumName(BackendCountStatistic_BackendState_name, int32(x))
 }
 func (BackendCountStatistic_BackendState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{9, 0}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{9, 0}
 }
 
 type BackendCountStatistic_BackendType int32
@@ -104,7 +133,7 @@ func (x BackendCountStatistic_BackendType) String() string {
 	return proto.EnumName(BackendCountStatistic_BackendType_name, int32(x))
 }
 func (BackendCountStatistic_BackendType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{9, 1}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{9, 1}
 }
 
 type RelationEvent_EventType int32
@@ -133,7 +162,33 @@ func (x RelationEvent_EventType) String() string {
 	return proto.EnumName(RelationEvent_EventType_name, int32(x))
 }
 func (RelationEvent_EventType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{16, 0}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{16, 0}
+}
+
+type FunctionChange_ChangeType int32
+
+const (
+	FunctionChange_ADDED    FunctionChange_ChangeType = 0
+	FunctionChange_REMOVED  FunctionChange_ChangeType = 1
+	FunctionChange_MODIFIED FunctionChange_ChangeType = 2
+)
+
+var FunctionChange_ChangeType_name = map[int32]string{
+	0: "ADDED",
+	1: "REMOVED",
+	2: "MODIFIED",
+}
+var FunctionChange_ChangeType_value = map[string]int32{
+	"ADDED":    0,
+	"REMOVED":  1,
+	"MODIFIED": 2,
+}
+
+func (x FunctionChange_ChangeType) String() string {
+	return proto.EnumName(FunctionChange_ChangeType_name, int32(x))
+}
+func (FunctionChange_ChangeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{25, 0}
 }
 
 type FullSnapshot struct {
@@ -175,16 +230,54 @@ type FullSnapshot struct {
 	IndexStatistics         []*IndexStatistic          `protobuf:"bytes,225,rep,name=index_statistics,json=indexStatistics,proto3" json:"index_statistics,omitempty"`
 	FunctionInformations    []*FunctionInformation     `protobuf:"bytes,227,rep,name=function_informations,json=functionInformations,proto3" json:"function_informations,omitempty"`
 	FunctionStatistics      []*FunctionStatistic       `protobuf:"bytes,228,rep,name=function_statistics,json=functionStatistics,proto3" json:"function_statistics,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{}                   `json:"-"`
-	XXX_unrecognized        []byte                     `json:"-"`
-	XXX_sizecache           int32                      `json:"-"`
+	// When the bloat estimates in the relation and index statistics were collected
+	// (they are only collected periodically, and repeated in the snapshots in between)
+	BloatCollectedAt *timestamp.Timestamp `protobuf:"bytes,13,opt,name=bloat_collected_at,json=bloatCollectedAt,proto3" json:"bloat_collected_at,omitempty"`
+	IoStatistics     []*IOStatistic       `protobuf:"bytes,125,rep,name=io_statistics,json=ioStatistics,proto3" json:"io_statistics,omitempty"`
+	// Time covered by the relation, index and function statistics - this is longer than
+	// collected_interval_secs when they were skipped in the previous run (e.g. due to throttling)
+	RelationStatisticsIntervalSecs uint32 `protobuf:"varint,14,opt,name=relation_statistics_interval_secs,json=relationStatisticsIntervalSecs,proto3" json:"relation_statistics_interval_secs,omitempty"`
+	// Whether pg_stat_statements was reset outside of the collector since the last run - affected
+	// query statistics contain the values since the reset, instead of the difference to the last run
+	QueryStatisticsReset bool                  `protobuf:"varint,215,opt,name=query_statistics_reset,json=queryStatisticsReset,proto3" json:"query_statistics_reset,omitempty"`
+	WaitEventStatistics  []*WaitEventStatistic `protobuf:"bytes,126,rep,name=wait_event_statistics,json=waitEventStatistics,proto3" json:"wait_event_statistics,omitempty"`
+	// Number of pg_stat_activity samples the wait event statistics were summed up from
+	WaitEventSampleCount int32 `protobuf:"varint,127,opt,name=wait_event_sample_count,json=waitEventSampleCount,proto3" json:"wait_event_sample_count,omitempty"`
+	// Functions that were added, removed or replaced with a different definition since the last run
+	FunctionChanges []*FunctionChange `protobuf:"bytes,229,rep,name=function_changes,json=functionChanges,proto3" json:"function_changes,omitempty"`
+	// Time covered by the query statistics - this is longer than collected_interval_secs when
+	// they were not collected in the previous run (see statement_collection_interval)
+	QueryStatisticsIntervalSecs uint32 `protobuf:"varint,216,opt,name=query_statistics_interval_secs,json=queryStatisticsIntervalSecs,proto3" json:"query_statistics_interval_secs,omitempty"`
+	// Custom labels configured for the server (sorted by key)
+	Tags []*Tag `protobuf:"bytes,22,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Long-running operations in progress at the time of the snapshot
+	VacuumProgress      []*VacuumProgress      `protobuf:"bytes,230,rep,name=vacuum_progress,json=vacuumProgress,proto3" json:"vacuum_progress,omitempty"`
+	CreateIndexProgress []*CreateIndexProgress `protobuf:"bytes,231,rep,name=create_index_progress,json=createIndexProgress,proto3" json:"create_index_progress,omitempty"`
+	BaseBackupProgress  []*BaseBackupProgress  `protobuf:"bytes,232,rep,name=base_backup_progress,json=baseBackupProgress,proto3" json:"base_backup_progress,omitempty"`
+	// Not set on the first snapshot after the collector started
+	BgwriterStatistic *BgwriterStatistic `protobuf:"bytes,128,opt,name=bgwriter_statistic,json=bgwriterStatistic,proto3" json:"bgwriter_statistic,omitempty"`
+	// Custom metrics that could be collected in this run (failed queries are left out)
+	CustomMetrics []*CustomMetric `protobuf:"bytes,129,rep,name=custom_metrics,json=customMetrics,proto3" json:"custom_metrics,omitempty"`
+	// Whether settings only contains the settings that changed since the last run (see settings_changed_only),
+	// instead of all settings
+	SettingsChangedOnly bool `protobuf:"varint,132,opt,name=settings_changed_only,json=settingsChangedOnly,proto3" json:"settings_changed_only,omitempty"`
+	// Locks that backends were waiting for at the time of the snapshot, and the blocking
+	// relationships between backends derived from them (rooted at backends that don't wait themselves)
+	LockWaits     []*LockWait        `protobuf:"bytes,133,rep,name=lock_waits,json=lockWaits,proto3" json:"lock_waits,omitempty"`
+	BlockingTrees []*BlockingBackend `protobuf:"bytes,134,rep,name=blocking_trees,json=blockingTrees,proto3" json:"blocking_trees,omitempty"`
+	Wraparound    *Wraparound        `protobuf:"bytes,135,opt,name=wraparound,proto3" json:"wraparound,omitempty"`
+	// Not set on the first snapshot, since there is nothing to compare against
+	BackendCountChanges  []*BackendCountChange `protobuf:"bytes,136,rep,name=backend_count_changes,json=backendCountChanges,proto3" json:"backend_count_changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *FullSnapshot) Reset()         { *m = FullSnapshot{} }
 func (m *FullSnapshot) String() string { return proto.CompactTextString(m) }
 func (*FullSnapshot) ProtoMessage()    {}
 func (*FullSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{0}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{0}
 }
 func (m *FullSnapshot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FullSnapshot.Unmarshal(m, b)
@@ -449,6 +542,139 @@ func (m *FullSnapshot) GetFunctionStatistics() []*FunctionStatistic {
 	return nil
 }
 
+func (m *FullSnapshot) GetBloatCollectedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.BloatCollectedAt
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetIoStatistics() []*IOStatistic {
+	if m != nil {
+		return m.IoStatistics
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetRelationStatisticsIntervalSecs() uint32 {
+	if m != nil {
+		return m.RelationStatisticsIntervalSecs
+	}
+	return 0
+}
+
+func (m *FullSnapshot) GetQueryStatisticsReset() bool {
+	if m != nil {
+		return m.QueryStatisticsReset
+	}
+	return false
+}
+
+func (m *FullSnapshot) GetWaitEventStatistics() []*WaitEventStatistic {
+	if m != nil {
+		return m.WaitEventStatistics
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetWaitEventSampleCount() int32 {
+	if m != nil {
+		return m.WaitEventSampleCount
+	}
+	return 0
+}
+
+func (m *FullSnapshot) GetFunctionChanges() []*FunctionChange {
+	if m != nil {
+		return m.FunctionChanges
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetQueryStatisticsIntervalSecs() uint32 {
+	if m != nil {
+		return m.QueryStatisticsIntervalSecs
+	}
+	return 0
+}
+
+func (m *FullSnapshot) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetVacuumProgress() []*VacuumProgress {
+	if m != nil {
+		return m.VacuumProgress
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetCreateIndexProgress() []*CreateIndexProgress {
+	if m != nil {
+		return m.CreateIndexProgress
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetBaseBackupProgress() []*BaseBackupProgress {
+	if m != nil {
+		return m.BaseBackupProgress
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetBgwriterStatistic() *BgwriterStatistic {
+	if m != nil {
+		return m.BgwriterStatistic
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetCustomMetrics() []*CustomMetric {
+	if m != nil {
+		return m.CustomMetrics
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetSettingsChangedOnly() bool {
+	if m != nil {
+		return m.SettingsChangedOnly
+	}
+	return false
+}
+
+func (m *FullSnapshot) GetLockWaits() []*LockWait {
+	if m != nil {
+		return m.LockWaits
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetBlockingTrees() []*BlockingBackend {
+	if m != nil {
+		return m.BlockingTrees
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetWraparound() *Wraparound {
+	if m != nil {
+		return m.Wraparound
+	}
+	return nil
+}
+
+func (m *FullSnapshot) GetBackendCountChanges() []*BackendCountChange {
+	if m != nil {
+		return m.BackendCountChanges
+	}
+	return nil
+}
+
 type CollectorStatistic struct {
 	GoVersion string `protobuf:"bytes,10,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
 	// Statistics from after the collection input step
@@ -458,17 +684,31 @@ type CollectorStatistic struct {
 	MemoryRssBytes           uint64 `protobuf:"varint,16,opt,name=memory_rss_bytes,json=memoryRssBytes,proto3" json:"memory_rss_bytes,omitempty"`
 	ActiveGoroutines         int32  `protobuf:"varint,20,opt,name=active_goroutines,json=activeGoroutines,proto3" json:"active_goroutines,omitempty"`
 	// Diff-ed statistics between two runs
-	CgoCalls             int64    `protobuf:"varint,30,opt,name=cgo_calls,json=cgoCalls,proto3" json:"cgo_calls,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	CgoCalls int64 `protobuf:"varint,30,opt,name=cgo_calls,json=cgoCalls,proto3" json:"cgo_calls,omitempty"`
+	// Log processing since the last full snapshot
+	LogLinesStitched    int64 `protobuf:"varint,40,opt,name=log_lines_stitched,json=logLinesStitched,proto3" json:"log_lines_stitched,omitempty"`
+	LogLinesDropped     int64 `protobuf:"varint,41,opt,name=log_lines_dropped,json=logLinesDropped,proto3" json:"log_lines_dropped,omitempty"`
+	LogLinesFiltered    int64 `protobuf:"varint,42,opt,name=log_lines_filtered,json=logLinesFiltered,proto3" json:"log_lines_filtered,omitempty"`
+	LogLinesUnparseable int64 `protobuf:"varint,43,opt,name=log_lines_unparseable,json=logLinesUnparseable,proto3" json:"log_lines_unparseable,omitempty"`
+	LogLinesOverflowed  int64 `protobuf:"varint,44,opt,name=log_lines_overflowed,json=logLinesOverflowed,proto3" json:"log_lines_overflowed,omitempty"`
+	QuerySamplesDropped int64 `protobuf:"varint,45,opt,name=query_samples_dropped,json=querySamplesDropped,proto3" json:"query_samples_dropped,omitempty"`
+	// Bytes uploaded since the last full snapshot, after compression (and encryption for logs)
+	UploadedLogBytes      int64 `protobuf:"varint,50,opt,name=uploaded_log_bytes,json=uploadedLogBytes,proto3" json:"uploaded_log_bytes,omitempty"`
+	UploadedSnapshotBytes int64 `protobuf:"varint,51,opt,name=uploaded_snapshot_bytes,json=uploadedSnapshotBytes,proto3" json:"uploaded_snapshot_bytes,omitempty"`
+	// Time spent in the phases of log processing since the last full snapshot
+	LogStitchingDuration *DurationStatistic `protobuf:"bytes,60,opt,name=log_stitching_duration,json=logStitchingDuration,proto3" json:"log_stitching_duration,omitempty"`
+	LogAnalysisDuration  *DurationStatistic `protobuf:"bytes,61,opt,name=log_analysis_duration,json=logAnalysisDuration,proto3" json:"log_analysis_duration,omitempty"`
+	LogUploadDuration    *DurationStatistic `protobuf:"bytes,62,opt,name=log_upload_duration,json=logUploadDuration,proto3" json:"log_upload_duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *CollectorStatistic) Reset()         { *m = CollectorStatistic{} }
 func (m *CollectorStatistic) String() string { return proto.CompactTextString(m) }
 func (*CollectorStatistic) ProtoMessage()    {}
 func (*CollectorStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{1}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{1}
 }
 func (m *CollectorStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CollectorStatistic.Unmarshal(m, b)
@@ -537,6 +777,83 @@ func (m *CollectorStatistic) GetCgoCalls() int64 {
 	return 0
 }
 
+func (m *CollectorStatistic) GetLogLinesStitched() int64 {
+	if m != nil {
+		return m.LogLinesStitched
+	}
+	return 0
+}
+
+func (m *CollectorStatistic) GetLogLinesDropped() int64 {
+	if m != nil {
+		return m.LogLinesDropped
+	}
+	return 0
+}
+
+func (m *CollectorStatistic) GetLogLinesFiltered() int64 {
+	if m != nil {
+		return m.LogLinesFiltered
+	}
+	return 0
+}
+
+func (m *CollectorStatistic) GetLogLinesUnparseable() int64 {
+	if m != nil {
+		return m.LogLinesUnparseable
+	}
+	return 0
+}
+
+func (m *CollectorStatistic) GetLogLinesOverflowed() int64 {
+	if m != nil {
+		return m.LogLinesOverflowed
+	}
+	return 0
+}
+
+func (m *CollectorStatistic) GetQuerySamplesDropped() int64 {
+	if m != nil {
+		return m.QuerySamplesDropped
+	}
+	return 0
+}
+
+func (m *CollectorStatistic) GetUploadedLogBytes() int64 {
+	if m != nil {
+		return m.UploadedLogBytes
+	}
+	return 0
+}
+
+func (m *CollectorStatistic) GetUploadedSnapshotBytes() int64 {
+	if m != nil {
+		return m.UploadedSnapshotBytes
+	}
+	return 0
+}
+
+func (m *CollectorStatistic) GetLogStitchingDuration() *DurationStatistic {
+	if m != nil {
+		return m.LogStitchingDuration
+	}
+	return nil
+}
+
+func (m *CollectorStatistic) GetLogAnalysisDuration() *DurationStatistic {
+	if m != nil {
+		return m.LogAnalysisDuration
+	}
+	return nil
+}
+
+func (m *CollectorStatistic) GetLogUploadDuration() *DurationStatistic {
+	if m != nil {
+		return m.LogUploadDuration
+	}
+	return nil
+}
+
 type RoleInformation struct {
 	RoleIdx              int32          `protobuf:"varint,1,opt,name=role_idx,json=roleIdx,proto3" json:"role_idx,omitempty"`
 	Inherit              bool           `protobuf:"varint,2,opt,name=inherit,proto3" json:"inherit,omitempty"`
@@ -559,7 +876,7 @@ func (m *RoleInformation) Reset()         { *m = RoleInformation{} }
 func (m *RoleInformation) String() string { return proto.CompactTextString(m) }
 func (*RoleInformation) ProtoMessage()    {}
 func (*RoleInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{2}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{2}
 }
 func (m *RoleInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RoleInformation.Unmarshal(m, b)
@@ -691,7 +1008,7 @@ func (m *DatabaseInformation) Reset()         { *m = DatabaseInformation{} }
 func (m *DatabaseInformation) String() string { return proto.CompactTextString(m) }
 func (*DatabaseInformation) ProtoMessage()    {}
 func (*DatabaseInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{3}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{3}
 }
 func (m *DatabaseInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DatabaseInformation.Unmarshal(m, b)
@@ -789,24 +1106,28 @@ func (m *DatabaseInformation) GetCollectedLocalCatalogData() bool {
 }
 
 type Setting struct {
-	Name                 string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	CurrentValue         string      `protobuf:"bytes,2,opt,name=current_value,json=currentValue,proto3" json:"current_value,omitempty"`
-	Unit                 *NullString `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
-	BootValue            *NullString `protobuf:"bytes,4,opt,name=boot_value,json=bootValue,proto3" json:"boot_value,omitempty"`
-	ResetValue           *NullString `protobuf:"bytes,5,opt,name=reset_value,json=resetValue,proto3" json:"reset_value,omitempty"`
-	Source               *NullString `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
-	SourceFile           *NullString `protobuf:"bytes,7,opt,name=source_file,json=sourceFile,proto3" json:"source_file,omitempty"`
-	SourceLine           *NullString `protobuf:"bytes,8,opt,name=source_line,json=sourceLine,proto3" json:"source_line,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Name         string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CurrentValue string      `protobuf:"bytes,2,opt,name=current_value,json=currentValue,proto3" json:"current_value,omitempty"`
+	Unit         *NullString `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	BootValue    *NullString `protobuf:"bytes,4,opt,name=boot_value,json=bootValue,proto3" json:"boot_value,omitempty"`
+	ResetValue   *NullString `protobuf:"bytes,5,opt,name=reset_value,json=resetValue,proto3" json:"reset_value,omitempty"`
+	Source       *NullString `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	SourceFile   *NullString `protobuf:"bytes,7,opt,name=source_file,json=sourceFile,proto3" json:"source_file,omitempty"`
+	SourceLine   *NullString `protobuf:"bytes,8,opt,name=source_line,json=sourceLine,proto3" json:"source_line,omitempty"`
+	// Whether the setting was changed, but only gets applied once the server is restarted
+	PendingRestart bool `protobuf:"varint,9,opt,name=pending_restart,json=pendingRestart,proto3" json:"pending_restart,omitempty"`
+	// Whether the setting started waiting for a restart since the last snapshot
+	NewlyPendingRestart  bool     `protobuf:"varint,10,opt,name=newly_pending_restart,json=newlyPendingRestart,proto3" json:"newly_pending_restart,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Setting) Reset()         { *m = Setting{} }
 func (m *Setting) String() string { return proto.CompactTextString(m) }
 func (*Setting) ProtoMessage()    {}
 func (*Setting) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{4}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{4}
 }
 func (m *Setting) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Setting.Unmarshal(m, b)
@@ -882,6 +1203,20 @@ func (m *Setting) GetSourceLine() *NullString {
 	return nil
 }
 
+func (m *Setting) GetPendingRestart() bool {
+	if m != nil {
+		return m.PendingRestart
+	}
+	return false
+}
+
+func (m *Setting) GetNewlyPendingRestart() bool {
+	if m != nil {
+		return m.NewlyPendingRestart
+	}
+	return false
+}
+
 type Replication struct {
 	// Are we the primary, or a standby?
 	InRecovery bool `protobuf:"varint,1,opt,name=in_recovery,json=inRecovery,proto3" json:"in_recovery,omitempty"`
@@ -891,22 +1226,31 @@ type Replication struct {
 	StandbyInformations []*StandbyInformation `protobuf:"bytes,12,rep,name=standby_informations,json=standbyInformations,proto3" json:"standby_informations,omitempty"`
 	StandbyStatistics   []*StandbyStatistic   `protobuf:"bytes,13,rep,name=standby_statistics,json=standbyStatistics,proto3" json:"standby_statistics,omitempty"`
 	// Standby information
-	IsStreaming          bool                 `protobuf:"varint,20,opt,name=is_streaming,json=isStreaming,proto3" json:"is_streaming,omitempty"`
-	ReceiveLocation      string               `protobuf:"bytes,21,opt,name=receive_location,json=receiveLocation,proto3" json:"receive_location,omitempty"`
-	ReplayLocation       string               `protobuf:"bytes,22,opt,name=replay_location,json=replayLocation,proto3" json:"replay_location,omitempty"`
-	ApplyByteLag         int64                `protobuf:"varint,23,opt,name=apply_byte_lag,json=applyByteLag,proto3" json:"apply_byte_lag,omitempty"`
-	ReplayTimestamp      *timestamp.Timestamp `protobuf:"bytes,24,opt,name=replay_timestamp,json=replayTimestamp,proto3" json:"replay_timestamp,omitempty"`
-	ReplayTimestampAge   int64                `protobuf:"varint,25,opt,name=replay_timestamp_age,json=replayTimestampAge,proto3" json:"replay_timestamp_age,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	IsStreaming        bool                 `protobuf:"varint,20,opt,name=is_streaming,json=isStreaming,proto3" json:"is_streaming,omitempty"`
+	ReceiveLocation    string               `protobuf:"bytes,21,opt,name=receive_location,json=receiveLocation,proto3" json:"receive_location,omitempty"`
+	ReplayLocation     string               `protobuf:"bytes,22,opt,name=replay_location,json=replayLocation,proto3" json:"replay_location,omitempty"`
+	ApplyByteLag       int64                `protobuf:"varint,23,opt,name=apply_byte_lag,json=applyByteLag,proto3" json:"apply_byte_lag,omitempty"`
+	ReplayTimestamp    *timestamp.Timestamp `protobuf:"bytes,24,opt,name=replay_timestamp,json=replayTimestamp,proto3" json:"replay_timestamp,omitempty"`
+	ReplayTimestampAge int64                `protobuf:"varint,25,opt,name=replay_timestamp_age,json=replayTimestampAge,proto3" json:"replay_timestamp_age,omitempty"`
+	// Whether the server switched between primary and standby since the last snapshot
+	// (no changes are sent in that case, since the data is not comparable)
+	RoleChanged bool `protobuf:"varint,2,opt,name=role_changed,json=roleChanged,proto3" json:"role_changed,omitempty"`
+	// Standbys that were connected at the last snapshot, but aren't anymore
+	DisconnectedStandbys []*DisconnectedStandby `protobuf:"bytes,14,rep,name=disconnected_standbys,json=disconnectedStandbys,proto3" json:"disconnected_standbys,omitempty"`
+	// Change in apply_byte_lag since the last snapshot
+	ApplyByteLagDelta    int64                     `protobuf:"varint,26,opt,name=apply_byte_lag_delta,json=applyByteLagDelta,proto3" json:"apply_byte_lag_delta,omitempty"`
+	HasApplyByteLagDelta bool                      `protobuf:"varint,27,opt,name=has_apply_byte_lag_delta,json=hasApplyByteLagDelta,proto3" json:"has_apply_byte_lag_delta,omitempty"`
+	LogicalSlots         []*LogicalReplicationSlot `protobuf:"bytes,15,rep,name=logical_slots,json=logicalSlots,proto3" json:"logical_slots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
 func (m *Replication) Reset()         { *m = Replication{} }
 func (m *Replication) String() string { return proto.CompactTextString(m) }
 func (*Replication) ProtoMessage()    {}
 func (*Replication) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{5}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{5}
 }
 func (m *Replication) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Replication.Unmarshal(m, b)
@@ -1003,6 +1347,41 @@ func (m *Replication) GetReplayTimestampAge() int64 {
 	return 0
 }
 
+func (m *Replication) GetRoleChanged() bool {
+	if m != nil {
+		return m.RoleChanged
+	}
+	return false
+}
+
+func (m *Replication) GetDisconnectedStandbys() []*DisconnectedStandby {
+	if m != nil {
+		return m.DisconnectedStandbys
+	}
+	return nil
+}
+
+func (m *Replication) GetApplyByteLagDelta() int64 {
+	if m != nil {
+		return m.ApplyByteLagDelta
+	}
+	return 0
+}
+
+func (m *Replication) GetHasApplyByteLagDelta() bool {
+	if m != nil {
+		return m.HasApplyByteLagDelta
+	}
+	return false
+}
+
+func (m *Replication) GetLogicalSlots() []*LogicalReplicationSlot {
+	if m != nil {
+		return m.LogicalSlots
+	}
+	return nil
+}
+
 type StandbyReference struct {
 	ClientAddr           string   `protobuf:"bytes,1,opt,name=client_addr,json=clientAddr,proto3" json:"client_addr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1014,7 +1393,7 @@ func (m *StandbyReference) Reset()         { *m = StandbyReference{} }
 func (m *StandbyReference) String() string { return proto.CompactTextString(m) }
 func (*StandbyReference) ProtoMessage()    {}
 func (*StandbyReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{6}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{6}
 }
 func (m *StandbyReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StandbyReference.Unmarshal(m, b)
@@ -1060,7 +1439,7 @@ func (m *StandbyInformation) Reset()         { *m = StandbyInformation{} }
 func (m *StandbyInformation) String() string { return proto.CompactTextString(m) }
 func (*StandbyInformation) ProtoMessage()    {}
 func (*StandbyInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{7}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{7}
 }
 func (m *StandbyInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StandbyInformation.Unmarshal(m, b)
@@ -1144,13 +1523,18 @@ func (m *StandbyInformation) GetSyncState() string {
 }
 
 type StandbyStatistic struct {
-	StandbyIdx           int32    `protobuf:"varint,1,opt,name=standby_idx,json=standbyIdx,proto3" json:"standby_idx,omitempty"`
-	State                string   `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
-	SentLocation         string   `protobuf:"bytes,3,opt,name=sent_location,json=sentLocation,proto3" json:"sent_location,omitempty"`
-	WriteLocation        string   `protobuf:"bytes,4,opt,name=write_location,json=writeLocation,proto3" json:"write_location,omitempty"`
-	FlushLocation        string   `protobuf:"bytes,5,opt,name=flush_location,json=flushLocation,proto3" json:"flush_location,omitempty"`
-	ReplayLocation       string   `protobuf:"bytes,6,opt,name=replay_location,json=replayLocation,proto3" json:"replay_location,omitempty"`
-	ByteLag              int64    `protobuf:"varint,7,opt,name=byte_lag,json=byteLag,proto3" json:"byte_lag,omitempty"`
+	StandbyIdx     int32  `protobuf:"varint,1,opt,name=standby_idx,json=standbyIdx,proto3" json:"standby_idx,omitempty"`
+	State          string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	SentLocation   string `protobuf:"bytes,3,opt,name=sent_location,json=sentLocation,proto3" json:"sent_location,omitempty"`
+	WriteLocation  string `protobuf:"bytes,4,opt,name=write_location,json=writeLocation,proto3" json:"write_location,omitempty"`
+	FlushLocation  string `protobuf:"bytes,5,opt,name=flush_location,json=flushLocation,proto3" json:"flush_location,omitempty"`
+	ReplayLocation string `protobuf:"bytes,6,opt,name=replay_location,json=replayLocation,proto3" json:"replay_location,omitempty"`
+	ByteLag        int64  `protobuf:"varint,7,opt,name=byte_lag,json=byteLag,proto3" json:"byte_lag,omitempty"`
+	// Change in byte_lag since the last snapshot
+	ByteLagDelta    int64 `protobuf:"varint,8,opt,name=byte_lag_delta,json=byteLagDelta,proto3" json:"byte_lag_delta,omitempty"`
+	HasByteLagDelta bool  `protobuf:"varint,9,opt,name=has_byte_lag_delta,json=hasByteLagDelta,proto3" json:"has_byte_lag_delta,omitempty"`
+	// Whether the standby connected since the last snapshot
+	NewlyConnected       bool     `protobuf:"varint,10,opt,name=newly_connected,json=newlyConnected,proto3" json:"newly_connected,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1160,7 +1544,7 @@ func (m *StandbyStatistic) Reset()         { *m = StandbyStatistic{} }
 func (m *StandbyStatistic) String() string { return proto.CompactTextString(m) }
 func (*StandbyStatistic) ProtoMessage()    {}
 func (*StandbyStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{8}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{8}
 }
 func (m *StandbyStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StandbyStatistic.Unmarshal(m, b)
@@ -1229,6 +1613,27 @@ func (m *StandbyStatistic) GetByteLag() int64 {
 	return 0
 }
 
+func (m *StandbyStatistic) GetByteLagDelta() int64 {
+	if m != nil {
+		return m.ByteLagDelta
+	}
+	return 0
+}
+
+func (m *StandbyStatistic) GetHasByteLagDelta() bool {
+	if m != nil {
+		return m.HasByteLagDelta
+	}
+	return false
+}
+
+func (m *StandbyStatistic) GetNewlyConnected() bool {
+	if m != nil {
+		return m.NewlyConnected
+	}
+	return false
+}
+
 type BackendCountStatistic struct {
 	HasRoleIdx           bool                               `protobuf:"varint,1,opt,name=has_role_idx,json=hasRoleIdx,proto3" json:"has_role_idx,omitempty"`
 	RoleIdx              int32                              `protobuf:"varint,2,opt,name=role_idx,json=roleIdx,proto3" json:"role_idx,omitempty"`
@@ -1247,7 +1652,7 @@ func (m *BackendCountStatistic) Reset()         { *m = BackendCountStatistic{} }
 func (m *BackendCountStatistic) String() string { return proto.CompactTextString(m) }
 func (*BackendCountStatistic) ProtoMessage()    {}
 func (*BackendCountStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{9}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{9}
 }
 func (m *BackendCountStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BackendCountStatistic.Unmarshal(m, b)
@@ -1334,7 +1739,7 @@ func (m *TablespaceReference) Reset()         { *m = TablespaceReference{} }
 func (m *TablespaceReference) String() string { return proto.CompactTextString(m) }
 func (*TablespaceReference) ProtoMessage()    {}
 func (*TablespaceReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{10}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{10}
 }
 func (m *TablespaceReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TablespaceReference.Unmarshal(m, b)
@@ -1375,7 +1780,7 @@ func (m *TablespaceInformation) Reset()         { *m = TablespaceInformation{} }
 func (m *TablespaceInformation) String() string { return proto.CompactTextString(m) }
 func (*TablespaceInformation) ProtoMessage()    {}
 func (*TablespaceInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{11}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{11}
 }
 func (m *TablespaceInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TablespaceInformation.Unmarshal(m, b)
@@ -1449,7 +1854,7 @@ func (m *QueryStatistic) Reset()         { *m = QueryStatistic{} }
 func (m *QueryStatistic) String() string { return proto.CompactTextString(m) }
 func (*QueryStatistic) ProtoMessage()    {}
 func (*QueryStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{12}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{12}
 }
 func (m *QueryStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStatistic.Unmarshal(m, b)
@@ -1594,7 +1999,7 @@ func (m *HistoricQueryStatistics) Reset()         { *m = HistoricQueryStatistics
 func (m *HistoricQueryStatistics) String() string { return proto.CompactTextString(m) }
 func (*HistoricQueryStatistics) ProtoMessage()    {}
 func (*HistoricQueryStatistics) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{13}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{13}
 }
 func (m *HistoricQueryStatistics) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HistoricQueryStatistics.Unmarshal(m, b)
@@ -1650,18 +2055,23 @@ type RelationInformation struct {
 	MinimumMultixactXid    uint32                            `protobuf:"varint,12,opt,name=minimum_multixact_xid,json=minimumMultixactXid,proto3" json:"minimum_multixact_xid,omitempty"`
 	// True if another process is currently holding an AccessExclusiveLock on this
 	// relation, this also means we won't have columns/index/constraints information
-	ExclusivelyLocked    bool              `protobuf:"varint,13,opt,name=exclusively_locked,json=exclusivelyLocked,proto3" json:"exclusively_locked,omitempty"`
-	Options              map[string]string `protobuf:"bytes,14,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	ExclusivelyLocked bool              `protobuf:"varint,13,opt,name=exclusively_locked,json=exclusivelyLocked,proto3" json:"exclusively_locked,omitempty"`
+	Options           map[string]string `protobuf:"bytes,14,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Whether this is a partition of a declaratively partitioned table (its statistics are also included in the parent's)
+	IsPartition bool `protobuf:"varint,15,opt,name=is_partition,json=isPartition,proto3" json:"is_partition,omitempty"`
+	// Partitioned table this is a partition of (not set if the parent is not collected, e.g. due to the schema filter)
+	ParentRelationIdx    int32    `protobuf:"varint,16,opt,name=parent_relation_idx,json=parentRelationIdx,proto3" json:"parent_relation_idx,omitempty"`
+	HasParentRelationIdx bool     `protobuf:"varint,17,opt,name=has_parent_relation_idx,json=hasParentRelationIdx,proto3" json:"has_parent_relation_idx,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *RelationInformation) Reset()         { *m = RelationInformation{} }
 func (m *RelationInformation) String() string { return proto.CompactTextString(m) }
 func (*RelationInformation) ProtoMessage()    {}
 func (*RelationInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{14}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{14}
 }
 func (m *RelationInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RelationInformation.Unmarshal(m, b)
@@ -1779,6 +2189,27 @@ func (m *RelationInformation) GetOptions() map[string]string {
 	return nil
 }
 
+func (m *RelationInformation) GetIsPartition() bool {
+	if m != nil {
+		return m.IsPartition
+	}
+	return false
+}
+
+func (m *RelationInformation) GetParentRelationIdx() int32 {
+	if m != nil {
+		return m.ParentRelationIdx
+	}
+	return 0
+}
+
+func (m *RelationInformation) GetHasParentRelationIdx() bool {
+	if m != nil {
+		return m.HasParentRelationIdx
+	}
+	return false
+}
+
 type RelationInformation_Column struct {
 	Name                 string      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	DataType             string      `protobuf:"bytes,3,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
@@ -1794,7 +2225,7 @@ func (m *RelationInformation_Column) Reset()         { *m = RelationInformation_
 func (m *RelationInformation_Column) String() string { return proto.CompactTextString(m) }
 func (*RelationInformation_Column) ProtoMessage()    {}
 func (*RelationInformation_Column) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{14, 1}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{14, 1}
 }
 func (m *RelationInformation_Column) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RelationInformation_Column.Unmarshal(m, b)
@@ -1868,7 +2299,7 @@ func (m *RelationInformation_Constraint) Reset()         { *m = RelationInformat
 func (m *RelationInformation_Constraint) String() string { return proto.CompactTextString(m) }
 func (*RelationInformation_Constraint) ProtoMessage()    {}
 func (*RelationInformation_Constraint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{14, 2}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{14, 2}
 }
 func (m *RelationInformation_Constraint) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RelationInformation_Constraint.Unmarshal(m, b)
@@ -1952,27 +2383,44 @@ func (m *RelationInformation_Constraint) GetForeignMatchType() string {
 }
 
 type RelationStatistic struct {
-	RelationIdx          int32    `protobuf:"varint,1,opt,name=relation_idx,json=relationIdx,proto3" json:"relation_idx,omitempty"`
-	SizeBytes            int64    `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
-	SeqScan              int64    `protobuf:"varint,3,opt,name=seq_scan,json=seqScan,proto3" json:"seq_scan,omitempty"`
-	SeqTupRead           int64    `protobuf:"varint,4,opt,name=seq_tup_read,json=seqTupRead,proto3" json:"seq_tup_read,omitempty"`
-	IdxScan              int64    `protobuf:"varint,5,opt,name=idx_scan,json=idxScan,proto3" json:"idx_scan,omitempty"`
-	IdxTupFetch          int64    `protobuf:"varint,6,opt,name=idx_tup_fetch,json=idxTupFetch,proto3" json:"idx_tup_fetch,omitempty"`
-	NTupIns              int64    `protobuf:"varint,7,opt,name=n_tup_ins,json=nTupIns,proto3" json:"n_tup_ins,omitempty"`
-	NTupUpd              int64    `protobuf:"varint,8,opt,name=n_tup_upd,json=nTupUpd,proto3" json:"n_tup_upd,omitempty"`
-	NTupDel              int64    `protobuf:"varint,9,opt,name=n_tup_del,json=nTupDel,proto3" json:"n_tup_del,omitempty"`
-	NTupHotUpd           int64    `protobuf:"varint,10,opt,name=n_tup_hot_upd,json=nTupHotUpd,proto3" json:"n_tup_hot_upd,omitempty"`
-	NLiveTup             int64    `protobuf:"varint,11,opt,name=n_live_tup,json=nLiveTup,proto3" json:"n_live_tup,omitempty"`
-	NDeadTup             int64    `protobuf:"varint,12,opt,name=n_dead_tup,json=nDeadTup,proto3" json:"n_dead_tup,omitempty"`
-	NModSinceAnalyze     int64    `protobuf:"varint,13,opt,name=n_mod_since_analyze,json=nModSinceAnalyze,proto3" json:"n_mod_since_analyze,omitempty"`
-	HeapBlksRead         int64    `protobuf:"varint,18,opt,name=heap_blks_read,json=heapBlksRead,proto3" json:"heap_blks_read,omitempty"`
-	HeapBlksHit          int64    `protobuf:"varint,19,opt,name=heap_blks_hit,json=heapBlksHit,proto3" json:"heap_blks_hit,omitempty"`
-	IdxBlksRead          int64    `protobuf:"varint,20,opt,name=idx_blks_read,json=idxBlksRead,proto3" json:"idx_blks_read,omitempty"`
-	IdxBlksHit           int64    `protobuf:"varint,21,opt,name=idx_blks_hit,json=idxBlksHit,proto3" json:"idx_blks_hit,omitempty"`
-	ToastBlksRead        int64    `protobuf:"varint,22,opt,name=toast_blks_read,json=toastBlksRead,proto3" json:"toast_blks_read,omitempty"`
-	ToastBlksHit         int64    `protobuf:"varint,23,opt,name=toast_blks_hit,json=toastBlksHit,proto3" json:"toast_blks_hit,omitempty"`
-	TidxBlksRead         int64    `protobuf:"varint,24,opt,name=tidx_blks_read,json=tidxBlksRead,proto3" json:"tidx_blks_read,omitempty"`
-	TidxBlksHit          int64    `protobuf:"varint,25,opt,name=tidx_blks_hit,json=tidxBlksHit,proto3" json:"tidx_blks_hit,omitempty"`
+	RelationIdx      int32 `protobuf:"varint,1,opt,name=relation_idx,json=relationIdx,proto3" json:"relation_idx,omitempty"`
+	SizeBytes        int64 `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	SeqScan          int64 `protobuf:"varint,3,opt,name=seq_scan,json=seqScan,proto3" json:"seq_scan,omitempty"`
+	SeqTupRead       int64 `protobuf:"varint,4,opt,name=seq_tup_read,json=seqTupRead,proto3" json:"seq_tup_read,omitempty"`
+	IdxScan          int64 `protobuf:"varint,5,opt,name=idx_scan,json=idxScan,proto3" json:"idx_scan,omitempty"`
+	IdxTupFetch      int64 `protobuf:"varint,6,opt,name=idx_tup_fetch,json=idxTupFetch,proto3" json:"idx_tup_fetch,omitempty"`
+	NTupIns          int64 `protobuf:"varint,7,opt,name=n_tup_ins,json=nTupIns,proto3" json:"n_tup_ins,omitempty"`
+	NTupUpd          int64 `protobuf:"varint,8,opt,name=n_tup_upd,json=nTupUpd,proto3" json:"n_tup_upd,omitempty"`
+	NTupDel          int64 `protobuf:"varint,9,opt,name=n_tup_del,json=nTupDel,proto3" json:"n_tup_del,omitempty"`
+	NTupHotUpd       int64 `protobuf:"varint,10,opt,name=n_tup_hot_upd,json=nTupHotUpd,proto3" json:"n_tup_hot_upd,omitempty"`
+	NLiveTup         int64 `protobuf:"varint,11,opt,name=n_live_tup,json=nLiveTup,proto3" json:"n_live_tup,omitempty"`
+	NDeadTup         int64 `protobuf:"varint,12,opt,name=n_dead_tup,json=nDeadTup,proto3" json:"n_dead_tup,omitempty"`
+	NModSinceAnalyze int64 `protobuf:"varint,13,opt,name=n_mod_since_analyze,json=nModSinceAnalyze,proto3" json:"n_mod_since_analyze,omitempty"`
+	HeapBlksRead     int64 `protobuf:"varint,18,opt,name=heap_blks_read,json=heapBlksRead,proto3" json:"heap_blks_read,omitempty"`
+	HeapBlksHit      int64 `protobuf:"varint,19,opt,name=heap_blks_hit,json=heapBlksHit,proto3" json:"heap_blks_hit,omitempty"`
+	IdxBlksRead      int64 `protobuf:"varint,20,opt,name=idx_blks_read,json=idxBlksRead,proto3" json:"idx_blks_read,omitempty"`
+	IdxBlksHit       int64 `protobuf:"varint,21,opt,name=idx_blks_hit,json=idxBlksHit,proto3" json:"idx_blks_hit,omitempty"`
+	ToastBlksRead    int64 `protobuf:"varint,22,opt,name=toast_blks_read,json=toastBlksRead,proto3" json:"toast_blks_read,omitempty"`
+	ToastBlksHit     int64 `protobuf:"varint,23,opt,name=toast_blks_hit,json=toastBlksHit,proto3" json:"toast_blks_hit,omitempty"`
+	TidxBlksRead     int64 `protobuf:"varint,24,opt,name=tidx_blks_read,json=tidxBlksRead,proto3" json:"tidx_blks_read,omitempty"`
+	TidxBlksHit      int64 `protobuf:"varint,25,opt,name=tidx_blks_hit,json=tidxBlksHit,proto3" json:"tidx_blks_hit,omitempty"`
+	// Estimated bloat of the table (only valid if has_bloat_estimate is set)
+	BloatBytes       int64 `protobuf:"varint,26,opt,name=bloat_bytes,json=bloatBytes,proto3" json:"bloat_bytes,omitempty"`
+	HasBloatEstimate bool  `protobuf:"varint,27,opt,name=has_bloat_estimate,json=hasBloatEstimate,proto3" json:"has_bloat_estimate,omitempty"`
+	// Size of the main fork, i.e. without TOAST, free space map and visibility map
+	MainSizeBytes int64 `protobuf:"varint,28,opt,name=main_size_bytes,json=mainSizeBytes,proto3" json:"main_size_bytes,omitempty"`
+	// Size of the TOAST table including its index (if any)
+	ToastSizeBytes int64 `protobuf:"varint,29,opt,name=toast_size_bytes,json=toastSizeBytes,proto3" json:"toast_size_bytes,omitempty"`
+	// Total size of all indexes on the table
+	IndexSizeBytes int64 `protobuf:"varint,30,opt,name=index_size_bytes,json=indexSizeBytes,proto3" json:"index_size_bytes,omitempty"`
+	// Not valid if the table was never vacuumed by autovacuum
+	LastAutovacuum *NullTimestamp `protobuf:"bytes,31,opt,name=last_autovacuum,json=lastAutovacuum,proto3" json:"last_autovacuum,omitempty"`
+	// Not valid if the table was never analyzed by autovacuum
+	LastAutoanalyze *NullTimestamp `protobuf:"bytes,32,opt,name=last_autoanalyze,json=lastAutoanalyze,proto3" json:"last_autoanalyze,omitempty"`
+	// Share of dead rows among all rows of the table (0 if the table has no rows)
+	DeadTupleRatio float64 `protobuf:"fixed64,33,opt,name=dead_tuple_ratio,json=deadTupleRatio,proto3" json:"dead_tuple_ratio,omitempty"`
+	// Whether the table has many dead rows, and was not vacuumed within the configured time
+	VacuumOverdue        bool     `protobuf:"varint,34,opt,name=vacuum_overdue,json=vacuumOverdue,proto3" json:"vacuum_overdue,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1982,7 +2430,7 @@ func (m *RelationStatistic) Reset()         { *m = RelationStatistic{} }
 func (m *RelationStatistic) String() string { return proto.CompactTextString(m) }
 func (*RelationStatistic) ProtoMessage()    {}
 func (*RelationStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{15}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{15}
 }
 func (m *RelationStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RelationStatistic.Unmarshal(m, b)
@@ -2149,12 +2597,75 @@ func (m *RelationStatistic) GetTidxBlksHit() int64 {
 	return 0
 }
 
-type RelationEvent struct {
-	RelationIdx           int32                   `protobuf:"varint,1,opt,name=relation_idx,json=relationIdx,proto3" json:"relation_idx,omitempty"`
-	Type                  RelationEvent_EventType `protobuf:"varint,2,opt,name=type,proto3,enum=pganalyze.collector.RelationEvent_EventType" json:"type,omitempty"`
-	OccurredAt            *timestamp.Timestamp    `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
-	ApproximateOccurredAt bool                    `protobuf:"varint,4,opt,name=approximate_occurred_at,json=approximateOccurredAt,proto3" json:"approximate_occurred_at,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{}                `json:"-"`
+func (m *RelationStatistic) GetBloatBytes() int64 {
+	if m != nil {
+		return m.BloatBytes
+	}
+	return 0
+}
+
+func (m *RelationStatistic) GetHasBloatEstimate() bool {
+	if m != nil {
+		return m.HasBloatEstimate
+	}
+	return false
+}
+
+func (m *RelationStatistic) GetMainSizeBytes() int64 {
+	if m != nil {
+		return m.MainSizeBytes
+	}
+	return 0
+}
+
+func (m *RelationStatistic) GetToastSizeBytes() int64 {
+	if m != nil {
+		return m.ToastSizeBytes
+	}
+	return 0
+}
+
+func (m *RelationStatistic) GetIndexSizeBytes() int64 {
+	if m != nil {
+		return m.IndexSizeBytes
+	}
+	return 0
+}
+
+func (m *RelationStatistic) GetLastAutovacuum() *NullTimestamp {
+	if m != nil {
+		return m.LastAutovacuum
+	}
+	return nil
+}
+
+func (m *RelationStatistic) GetLastAutoanalyze() *NullTimestamp {
+	if m != nil {
+		return m.LastAutoanalyze
+	}
+	return nil
+}
+
+func (m *RelationStatistic) GetDeadTupleRatio() float64 {
+	if m != nil {
+		return m.DeadTupleRatio
+	}
+	return 0
+}
+
+func (m *RelationStatistic) GetVacuumOverdue() bool {
+	if m != nil {
+		return m.VacuumOverdue
+	}
+	return false
+}
+
+type RelationEvent struct {
+	RelationIdx           int32                   `protobuf:"varint,1,opt,name=relation_idx,json=relationIdx,proto3" json:"relation_idx,omitempty"`
+	Type                  RelationEvent_EventType `protobuf:"varint,2,opt,name=type,proto3,enum=pganalyze.collector.RelationEvent_EventType" json:"type,omitempty"`
+	OccurredAt            *timestamp.Timestamp    `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	ApproximateOccurredAt bool                    `protobuf:"varint,4,opt,name=approximate_occurred_at,json=approximateOccurredAt,proto3" json:"approximate_occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}                `json:"-"`
 	XXX_unrecognized      []byte                  `json:"-"`
 	XXX_sizecache         int32                   `json:"-"`
 }
@@ -2163,7 +2674,7 @@ func (m *RelationEvent) Reset()         { *m = RelationEvent{} }
 func (m *RelationEvent) String() string { return proto.CompactTextString(m) }
 func (*RelationEvent) ProtoMessage()    {}
 func (*RelationEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{16}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{16}
 }
 func (m *RelationEvent) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RelationEvent.Unmarshal(m, b)
@@ -2231,7 +2742,7 @@ func (m *IndexInformation) Reset()         { *m = IndexInformation{} }
 func (m *IndexInformation) String() string { return proto.CompactTextString(m) }
 func (*IndexInformation) ProtoMessage()    {}
 func (*IndexInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{17}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{17}
 }
 func (m *IndexInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IndexInformation.Unmarshal(m, b)
@@ -2322,23 +2833,28 @@ func (m *IndexInformation) GetIndexType() string {
 }
 
 type IndexStatistic struct {
-	IndexIdx             int32    `protobuf:"varint,1,opt,name=index_idx,json=indexIdx,proto3" json:"index_idx,omitempty"`
-	SizeBytes            int64    `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
-	IdxScan              int64    `protobuf:"varint,3,opt,name=idx_scan,json=idxScan,proto3" json:"idx_scan,omitempty"`
-	IdxTupRead           int64    `protobuf:"varint,4,opt,name=idx_tup_read,json=idxTupRead,proto3" json:"idx_tup_read,omitempty"`
-	IdxTupFetch          int64    `protobuf:"varint,6,opt,name=idx_tup_fetch,json=idxTupFetch,proto3" json:"idx_tup_fetch,omitempty"`
-	IdxBlksRead          int64    `protobuf:"varint,7,opt,name=idx_blks_read,json=idxBlksRead,proto3" json:"idx_blks_read,omitempty"`
-	IdxBlksHit           int64    `protobuf:"varint,8,opt,name=idx_blks_hit,json=idxBlksHit,proto3" json:"idx_blks_hit,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	IndexIdx    int32 `protobuf:"varint,1,opt,name=index_idx,json=indexIdx,proto3" json:"index_idx,omitempty"`
+	SizeBytes   int64 `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	IdxScan     int64 `protobuf:"varint,3,opt,name=idx_scan,json=idxScan,proto3" json:"idx_scan,omitempty"`
+	IdxTupRead  int64 `protobuf:"varint,4,opt,name=idx_tup_read,json=idxTupRead,proto3" json:"idx_tup_read,omitempty"`
+	IdxTupFetch int64 `protobuf:"varint,6,opt,name=idx_tup_fetch,json=idxTupFetch,proto3" json:"idx_tup_fetch,omitempty"`
+	IdxBlksRead int64 `protobuf:"varint,7,opt,name=idx_blks_read,json=idxBlksRead,proto3" json:"idx_blks_read,omitempty"`
+	IdxBlksHit  int64 `protobuf:"varint,8,opt,name=idx_blks_hit,json=idxBlksHit,proto3" json:"idx_blks_hit,omitempty"`
+	// Estimated bloat of the index (only valid if has_bloat_estimate is set)
+	BloatBytes       int64 `protobuf:"varint,9,opt,name=bloat_bytes,json=bloatBytes,proto3" json:"bloat_bytes,omitempty"`
+	HasBloatEstimate bool  `protobuf:"varint,10,opt,name=has_bloat_estimate,json=hasBloatEstimate,proto3" json:"has_bloat_estimate,omitempty"`
+	// Since when the index has had no scans at all (not set if it was scanned)
+	UnusedSince          *timestamp.Timestamp `protobuf:"bytes,11,opt,name=unused_since,json=unusedSince,proto3" json:"unused_since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *IndexStatistic) Reset()         { *m = IndexStatistic{} }
 func (m *IndexStatistic) String() string { return proto.CompactTextString(m) }
 func (*IndexStatistic) ProtoMessage()    {}
 func (*IndexStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{18}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{18}
 }
 func (m *IndexStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IndexStatistic.Unmarshal(m, b)
@@ -2407,20 +2923,43 @@ func (m *IndexStatistic) GetIdxBlksHit() int64 {
 	return 0
 }
 
+func (m *IndexStatistic) GetBloatBytes() int64 {
+	if m != nil {
+		return m.BloatBytes
+	}
+	return 0
+}
+
+func (m *IndexStatistic) GetHasBloatEstimate() bool {
+	if m != nil {
+		return m.HasBloatEstimate
+	}
+	return false
+}
+
+func (m *IndexStatistic) GetUnusedSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.UnusedSince
+	}
+	return nil
+}
+
 type FunctionInformation struct {
-	FunctionIdx          int32    `protobuf:"varint,1,opt,name=function_idx,json=functionIdx,proto3" json:"function_idx,omitempty"`
-	Language             string   `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
-	Source               string   `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
-	SourceBin            string   `protobuf:"bytes,5,opt,name=source_bin,json=sourceBin,proto3" json:"source_bin,omitempty"`
-	Config               []string `protobuf:"bytes,6,rep,name=config,proto3" json:"config,omitempty"`
-	Result               string   `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"`
-	Aggregate            bool     `protobuf:"varint,9,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
-	Window               bool     `protobuf:"varint,10,opt,name=window,proto3" json:"window,omitempty"`
-	SecurityDefiner      bool     `protobuf:"varint,11,opt,name=security_definer,json=securityDefiner,proto3" json:"security_definer,omitempty"`
-	Leakproof            bool     `protobuf:"varint,12,opt,name=leakproof,proto3" json:"leakproof,omitempty"`
-	Strict               bool     `protobuf:"varint,13,opt,name=strict,proto3" json:"strict,omitempty"`
-	ReturnsSet           bool     `protobuf:"varint,14,opt,name=returns_set,json=returnsSet,proto3" json:"returns_set,omitempty"`
-	Volatile             string   `protobuf:"bytes,15,opt,name=volatile,proto3" json:"volatile,omitempty"`
+	FunctionIdx     int32    `protobuf:"varint,1,opt,name=function_idx,json=functionIdx,proto3" json:"function_idx,omitempty"`
+	Language        string   `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	Source          string   `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	SourceBin       string   `protobuf:"bytes,5,opt,name=source_bin,json=sourceBin,proto3" json:"source_bin,omitempty"`
+	Config          []string `protobuf:"bytes,6,rep,name=config,proto3" json:"config,omitempty"`
+	Result          string   `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"`
+	Aggregate       bool     `protobuf:"varint,9,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	Window          bool     `protobuf:"varint,10,opt,name=window,proto3" json:"window,omitempty"`
+	SecurityDefiner bool     `protobuf:"varint,11,opt,name=security_definer,json=securityDefiner,proto3" json:"security_definer,omitempty"`
+	Leakproof       bool     `protobuf:"varint,12,opt,name=leakproof,proto3" json:"leakproof,omitempty"`
+	Strict          bool     `protobuf:"varint,13,opt,name=strict,proto3" json:"strict,omitempty"`
+	ReturnsSet      bool     `protobuf:"varint,14,opt,name=returns_set,json=returnsSet,proto3" json:"returns_set,omitempty"`
+	Volatile        string   `protobuf:"bytes,15,opt,name=volatile,proto3" json:"volatile,omitempty"`
+	// Hash of the function definition (source, signature and settings), which changes when the function is replaced
+	DefinitionHash       string   `protobuf:"bytes,16,opt,name=definition_hash,json=definitionHash,proto3" json:"definition_hash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2430,7 +2969,7 @@ func (m *FunctionInformation) Reset()         { *m = FunctionInformation{} }
 func (m *FunctionInformation) String() string { return proto.CompactTextString(m) }
 func (*FunctionInformation) ProtoMessage()    {}
 func (*FunctionInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{19}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{19}
 }
 func (m *FunctionInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FunctionInformation.Unmarshal(m, b)
@@ -2541,6 +3080,13 @@ func (m *FunctionInformation) GetVolatile() string {
 	return ""
 }
 
+func (m *FunctionInformation) GetDefinitionHash() string {
+	if m != nil {
+		return m.DefinitionHash
+	}
+	return ""
+}
+
 type FunctionStatistic struct {
 	FunctionIdx          int32    `protobuf:"varint,1,opt,name=function_idx,json=functionIdx,proto3" json:"function_idx,omitempty"`
 	Calls                int64    `protobuf:"varint,2,opt,name=calls,proto3" json:"calls,omitempty"`
@@ -2555,7 +3101,7 @@ func (m *FunctionStatistic) Reset()         { *m = FunctionStatistic{} }
 func (m *FunctionStatistic) String() string { return proto.CompactTextString(m) }
 func (*FunctionStatistic) ProtoMessage()    {}
 func (*FunctionStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_full_snapshot_ed217f5871782d8c, []int{20}
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{20}
 }
 func (m *FunctionStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FunctionStatistic.Unmarshal(m, b)
@@ -2603,292 +3149,2017 @@ func (m *FunctionStatistic) GetSelfTime() float64 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*FullSnapshot)(nil), "pganalyze.collector.FullSnapshot")
-	proto.RegisterType((*CollectorStatistic)(nil), "pganalyze.collector.CollectorStatistic")
-	proto.RegisterType((*RoleInformation)(nil), "pganalyze.collector.RoleInformation")
-	proto.RegisterType((*DatabaseInformation)(nil), "pganalyze.collector.DatabaseInformation")
-	proto.RegisterType((*Setting)(nil), "pganalyze.collector.Setting")
-	proto.RegisterType((*Replication)(nil), "pganalyze.collector.Replication")
-	proto.RegisterType((*StandbyReference)(nil), "pganalyze.collector.StandbyReference")
-	proto.RegisterType((*StandbyInformation)(nil), "pganalyze.collector.StandbyInformation")
-	proto.RegisterType((*StandbyStatistic)(nil), "pganalyze.collector.StandbyStatistic")
-	proto.RegisterType((*BackendCountStatistic)(nil), "pganalyze.collector.BackendCountStatistic")
-	proto.RegisterType((*TablespaceReference)(nil), "pganalyze.collector.TablespaceReference")
-	proto.RegisterType((*TablespaceInformation)(nil), "pganalyze.collector.TablespaceInformation")
-	proto.RegisterType((*QueryStatistic)(nil), "pganalyze.collector.QueryStatistic")
-	proto.RegisterType((*HistoricQueryStatistics)(nil), "pganalyze.collector.HistoricQueryStatistics")
-	proto.RegisterType((*RelationInformation)(nil), "pganalyze.collector.RelationInformation")
-	proto.RegisterMapType((map[string]string)(nil), "pganalyze.collector.RelationInformation.OptionsEntry")
-	proto.RegisterType((*RelationInformation_Column)(nil), "pganalyze.collector.RelationInformation.Column")
-	proto.RegisterType((*RelationInformation_Constraint)(nil), "pganalyze.collector.RelationInformation.Constraint")
-	proto.RegisterType((*RelationStatistic)(nil), "pganalyze.collector.RelationStatistic")
-	proto.RegisterType((*RelationEvent)(nil), "pganalyze.collector.RelationEvent")
-	proto.RegisterType((*IndexInformation)(nil), "pganalyze.collector.IndexInformation")
-	proto.RegisterType((*IndexStatistic)(nil), "pganalyze.collector.IndexStatistic")
-	proto.RegisterType((*FunctionInformation)(nil), "pganalyze.collector.FunctionInformation")
-	proto.RegisterType((*FunctionStatistic)(nil), "pganalyze.collector.FunctionStatistic")
-	proto.RegisterEnum("pganalyze.collector.BackendCountStatistic_BackendState", BackendCountStatistic_BackendState_name, BackendCountStatistic_BackendState_value)
-	proto.RegisterEnum("pganalyze.collector.BackendCountStatistic_BackendType", BackendCountStatistic_BackendType_name, BackendCountStatistic_BackendType_value)
-	proto.RegisterEnum("pganalyze.collector.RelationEvent_EventType", RelationEvent_EventType_name, RelationEvent_EventType_value)
+type DisconnectedStandby struct {
+	ClientAddr           string   `protobuf:"bytes,1,opt,name=client_addr,json=clientAddr,proto3" json:"client_addr,omitempty"`
+	ApplicationName      string   `protobuf:"bytes,2,opt,name=application_name,json=applicationName,proto3" json:"application_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisconnectedStandby) Reset()         { *m = DisconnectedStandby{} }
+func (m *DisconnectedStandby) String() string { return proto.CompactTextString(m) }
+func (*DisconnectedStandby) ProtoMessage()    {}
+func (*DisconnectedStandby) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{21}
+}
+func (m *DisconnectedStandby) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisconnectedStandby.Unmarshal(m, b)
+}
+func (m *DisconnectedStandby) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisconnectedStandby.Marshal(b, m, deterministic)
+}
+func (dst *DisconnectedStandby) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisconnectedStandby.Merge(dst, src)
+}
+func (m *DisconnectedStandby) XXX_Size() int {
+	return xxx_messageInfo_DisconnectedStandby.Size(m)
+}
+func (m *DisconnectedStandby) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisconnectedStandby.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisconnectedStandby proto.InternalMessageInfo
+
+func (m *DisconnectedStandby) GetClientAddr() string {
+	if m != nil {
+		return m.ClientAddr
+	}
+	return ""
+}
+
+func (m *DisconnectedStandby) GetApplicationName() string {
+	if m != nil {
+		return m.ApplicationName
+	}
+	return ""
+}
+
+type DurationStatistic struct {
+	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	TotalMs              float64  `protobuf:"fixed64,2,opt,name=total_ms,json=totalMs,proto3" json:"total_ms,omitempty"`
+	MinMs                float64  `protobuf:"fixed64,3,opt,name=min_ms,json=minMs,proto3" json:"min_ms,omitempty"`
+	MaxMs                float64  `protobuf:"fixed64,4,opt,name=max_ms,json=maxMs,proto3" json:"max_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DurationStatistic) Reset()         { *m = DurationStatistic{} }
+func (m *DurationStatistic) String() string { return proto.CompactTextString(m) }
+func (*DurationStatistic) ProtoMessage()    {}
+func (*DurationStatistic) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{22}
+}
+func (m *DurationStatistic) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DurationStatistic.Unmarshal(m, b)
+}
+func (m *DurationStatistic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DurationStatistic.Marshal(b, m, deterministic)
+}
+func (dst *DurationStatistic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DurationStatistic.Merge(dst, src)
+}
+func (m *DurationStatistic) XXX_Size() int {
+	return xxx_messageInfo_DurationStatistic.Size(m)
+}
+func (m *DurationStatistic) XXX_DiscardUnknown() {
+	xxx_messageInfo_DurationStatistic.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DurationStatistic proto.InternalMessageInfo
+
+func (m *DurationStatistic) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *DurationStatistic) GetTotalMs() float64 {
+	if m != nil {
+		return m.TotalMs
+	}
+	return 0
+}
+
+func (m *DurationStatistic) GetMinMs() float64 {
+	if m != nil {
+		return m.MinMs
+	}
+	return 0
+}
+
+func (m *DurationStatistic) GetMaxMs() float64 {
+	if m != nil {
+		return m.MaxMs
+	}
+	return 0
+}
+
+// I/O activity since the last run, by backend type (from pg_stat_io, Postgres 16+)
+type IOStatistic struct {
+	BackendType      string  `protobuf:"bytes,1,opt,name=backend_type,json=backendType,proto3" json:"backend_type,omitempty"`
+	ReadsPerSecond   float64 `protobuf:"fixed64,2,opt,name=reads_per_second,json=readsPerSecond,proto3" json:"reads_per_second,omitempty"`
+	WritesPerSecond  float64 `protobuf:"fixed64,3,opt,name=writes_per_second,json=writesPerSecond,proto3" json:"writes_per_second,omitempty"`
+	ExtendsPerSecond float64 `protobuf:"fixed64,4,opt,name=extends_per_second,json=extendsPerSecond,proto3" json:"extends_per_second,omitempty"`
+	HitsPerSecond    float64 `protobuf:"fixed64,5,opt,name=hits_per_second,json=hitsPerSecond,proto3" json:"hits_per_second,omitempty"`
+	// Time spent in read operations since the last run, in milliseconds (zero unless track_io_timing is enabled)
+	ReadTime float64 `protobuf:"fixed64,6,opt,name=read_time,json=readTime,proto3" json:"read_time,omitempty"`
+	// Time spent in write operations since the last run, in milliseconds (zero unless track_io_timing is enabled)
+	WriteTime float64 `protobuf:"fixed64,7,opt,name=write_time,json=writeTime,proto3" json:"write_time,omitempty"`
+	// Time spent in extend operations since the last run, in milliseconds (zero unless track_io_timing is enabled)
+	ExtendTime           float64  `protobuf:"fixed64,8,opt,name=extend_time,json=extendTime,proto3" json:"extend_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IOStatistic) Reset()         { *m = IOStatistic{} }
+func (m *IOStatistic) String() string { return proto.CompactTextString(m) }
+func (*IOStatistic) ProtoMessage()    {}
+func (*IOStatistic) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{23}
+}
+func (m *IOStatistic) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IOStatistic.Unmarshal(m, b)
+}
+func (m *IOStatistic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IOStatistic.Marshal(b, m, deterministic)
+}
+func (dst *IOStatistic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IOStatistic.Merge(dst, src)
+}
+func (m *IOStatistic) XXX_Size() int {
+	return xxx_messageInfo_IOStatistic.Size(m)
+}
+func (m *IOStatistic) XXX_DiscardUnknown() {
+	xxx_messageInfo_IOStatistic.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IOStatistic proto.InternalMessageInfo
+
+func (m *IOStatistic) GetBackendType() string {
+	if m != nil {
+		return m.BackendType
+	}
+	return ""
+}
+
+func (m *IOStatistic) GetReadsPerSecond() float64 {
+	if m != nil {
+		return m.ReadsPerSecond
+	}
+	return 0
+}
+
+func (m *IOStatistic) GetWritesPerSecond() float64 {
+	if m != nil {
+		return m.WritesPerSecond
+	}
+	return 0
+}
+
+func (m *IOStatistic) GetExtendsPerSecond() float64 {
+	if m != nil {
+		return m.ExtendsPerSecond
+	}
+	return 0
+}
+
+func (m *IOStatistic) GetHitsPerSecond() float64 {
+	if m != nil {
+		return m.HitsPerSecond
+	}
+	return 0
+}
+
+func (m *IOStatistic) GetReadTime() float64 {
+	if m != nil {
+		return m.ReadTime
+	}
+	return 0
+}
+
+func (m *IOStatistic) GetWriteTime() float64 {
+	if m != nil {
+		return m.WriteTime
+	}
+	return 0
+}
+
+func (m *IOStatistic) GetExtendTime() float64 {
+	if m != nil {
+		return m.ExtendTime
+	}
+	return 0
+}
+
+// Backends waiting on a wait event, summed up across all samples since the last full snapshot
+type WaitEventStatistic struct {
+	// Before Postgres 9.6 only lock waits are known, which have the type "Lock" and no wait event
+	WaitEventType        string   `protobuf:"bytes,1,opt,name=wait_event_type,json=waitEventType,proto3" json:"wait_event_type,omitempty"`
+	WaitEvent            string   `protobuf:"bytes,2,opt,name=wait_event,json=waitEvent,proto3" json:"wait_event,omitempty"`
+	Count                int64    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WaitEventStatistic) Reset()         { *m = WaitEventStatistic{} }
+func (m *WaitEventStatistic) String() string { return proto.CompactTextString(m) }
+func (*WaitEventStatistic) ProtoMessage()    {}
+func (*WaitEventStatistic) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{24}
+}
+func (m *WaitEventStatistic) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WaitEventStatistic.Unmarshal(m, b)
+}
+func (m *WaitEventStatistic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WaitEventStatistic.Marshal(b, m, deterministic)
+}
+func (dst *WaitEventStatistic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WaitEventStatistic.Merge(dst, src)
+}
+func (m *WaitEventStatistic) XXX_Size() int {
+	return xxx_messageInfo_WaitEventStatistic.Size(m)
+}
+func (m *WaitEventStatistic) XXX_DiscardUnknown() {
+	xxx_messageInfo_WaitEventStatistic.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WaitEventStatistic proto.InternalMessageInfo
+
+func (m *WaitEventStatistic) GetWaitEventType() string {
+	if m != nil {
+		return m.WaitEventType
+	}
+	return ""
+}
+
+func (m *WaitEventStatistic) GetWaitEvent() string {
+	if m != nil {
+		return m.WaitEvent
+	}
+	return ""
+}
+
+func (m *WaitEventStatistic) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type FunctionChange struct {
+	DatabaseIdx          int32                     `protobuf:"varint,1,opt,name=database_idx,json=databaseIdx,proto3" json:"database_idx,omitempty"`
+	SchemaName           string                    `protobuf:"bytes,2,opt,name=schema_name,json=schemaName,proto3" json:"schema_name,omitempty"`
+	FunctionName         string                    `protobuf:"bytes,3,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"`
+	Arguments            string                    `protobuf:"bytes,4,opt,name=arguments,proto3" json:"arguments,omitempty"`
+	ChangeType           FunctionChange_ChangeType `protobuf:"varint,5,opt,name=change_type,json=changeType,proto3,enum=pganalyze.collector.FunctionChange_ChangeType" json:"change_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *FunctionChange) Reset()         { *m = FunctionChange{} }
+func (m *FunctionChange) String() string { return proto.CompactTextString(m) }
+func (*FunctionChange) ProtoMessage()    {}
+func (*FunctionChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{25}
+}
+func (m *FunctionChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FunctionChange.Unmarshal(m, b)
+}
+func (m *FunctionChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FunctionChange.Marshal(b, m, deterministic)
+}
+func (dst *FunctionChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FunctionChange.Merge(dst, src)
+}
+func (m *FunctionChange) XXX_Size() int {
+	return xxx_messageInfo_FunctionChange.Size(m)
+}
+func (m *FunctionChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_FunctionChange.DiscardUnknown(m)
 }
 
-func init() { proto.RegisterFile("full_snapshot.proto", fileDescriptor_full_snapshot_ed217f5871782d8c) }
-
-var fileDescriptor_full_snapshot_ed217f5871782d8c = []byte{
-	// 4051 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x7a, 0x4b, 0x73, 0x1c, 0xc9,
-	0x71, 0xbf, 0x06, 0x83, 0xc7, 0x4c, 0xce, 0x13, 0x05, 0x80, 0x1c, 0x82, 0xbb, 0x5a, 0xec, 0x88,
-	0x5a, 0x42, 0x12, 0x85, 0xfd, 0x07, 0xf9, 0xb7, 0xb4, 0x21, 0x87, 0x2c, 0x0d, 0x81, 0xa1, 0x88,
-	0x25, 0x08, 0x50, 0x8d, 0x01, 0xb9, 0xab, 0x08, 0xbb, 0xa3, 0xa7, 0xbb, 0x66, 0x50, 0x42, 0x4f,
-	0x77, 0xb3, 0xaa, 0x1a, 0xc4, 0xd0, 0x3e, 0x28, 0xec, 0x8b, 0x23, 0x7c, 0xf0, 0x07, 0xf0, 0xc1,
-	0x1f, 0xc1, 0x3e, 0x38, 0x14, 0x3e, 0xfa, 0xe4, 0xf0, 0xe3, 0x66, 0x87, 0x7c, 0xb1, 0xac, 0xb5,
-	0x2d, 0x47, 0xf8, 0xe6, 0x8b, 0xbf, 0x80, 0x23, 0xab, 0xaa, 0x5f, 0x83, 0x21, 0x80, 0x75, 0xf8,
-	0x82, 0x98, 0xca, 0xfc, 0x65, 0x56, 0x76, 0x65, 0x56, 0x56, 0x56, 0x16, 0x60, 0x6d, 0x14, 0xfb,
-	0xbe, 0x2d, 0x02, 0x27, 0x12, 0xa7, 0xa1, 0xdc, 0x89, 0x78, 0x28, 0x43, 0xb2, 0x16, 0x8d, 0x9d,
-	0xc0, 0xf1, 0xa7, 0x6f, 0xe9, 0x8e, 0x1b, 0xfa, 0x3e, 0x75, 0x65, 0xc8, 0x37, 0x3f, 0x18, 0x87,
-	0xe1, 0xd8, 0xa7, 0x1f, 0x2b, 0xc8, 0x30, 0x1e, 0x7d, 0x2c, 0xd9, 0x84, 0x0a, 0xe9, 0x4c, 0x22,
-	0x2d, 0xb5, 0x59, 0x17, 0xa7, 0x0e, 0xa7, 0x9e, 0x1e, 0x75, 0xff, 0x62, 0x03, 0xea, 0x4f, 0x62,
-	0xdf, 0x3f, 0x36, 0xaa, 0xc9, 0xff, 0x87, 0x5b, 0xc9, 0x34, 0xf6, 0x39, 0xe5, 0x82, 0x85, 0x81,
-	0x3d, 0x71, 0x7e, 0x1a, 0xf2, 0x4e, 0x69, 0xab, 0xb4, 0xbd, 0x64, 0xad, 0x27, 0xdc, 0x97, 0x9a,
-	0xf9, 0x1c, 0x79, 0xf3, 0xa5, 0x58, 0x10, 0xf2, 0xce, 0xc2, 0x7c, 0x29, 0xe4, 0x91, 0x6f, 0xc1,
-	0x6a, 0x6a, 0x78, 0x22, 0xd6, 0x29, 0x6f, 0x95, 0xb6, 0xab, 0x56, 0x3b, 0x65, 0x18, 0x09, 0xf2,
-	0x3e, 0xc0, 0xc8, 0x61, 0x3e, 0xf5, 0x6c, 0x1e, 0x07, 0x9d, 0xc5, 0xad, 0xd2, 0x76, 0xc5, 0xaa,
-	0x6a, 0x8a, 0x15, 0x07, 0xe4, 0x6b, 0xd0, 0x48, 0x2d, 0x88, 0x63, 0xe6, 0x75, 0x40, 0xe9, 0xa9,
-	0x27, 0xc4, 0x93, 0x98, 0x79, 0xe4, 0xfb, 0x50, 0x37, 0x7a, 0xa9, 0x67, 0x3b, 0xb2, 0x53, 0xdb,
-	0x2a, 0x6d, 0xd7, 0x1e, 0x6e, 0xee, 0xe8, 0x35, 0xdb, 0x49, 0xd6, 0x6c, 0x67, 0x90, 0xac, 0x99,
-	0x55, 0x4b, 0xf1, 0x3d, 0x49, 0xbe, 0x03, 0xb7, 0x33, 0x71, 0x16, 0x48, 0xca, 0xcf, 0x1d, 0xdf,
-	0x16, 0xd4, 0x15, 0x9d, 0xfa, 0x56, 0x69, 0xbb, 0x61, 0x6d, 0xa4, 0xec, 0x7d, 0xc3, 0x3d, 0xa6,
-	0xae, 0x20, 0x9f, 0xc1, 0x5a, 0xf6, 0x9d, 0x42, 0x3a, 0x92, 0x09, 0xc9, 0xdc, 0xce, 0xba, 0x9a,
-	0xfd, 0xfe, 0xce, 0x1c, 0x37, 0xee, 0xec, 0x26, 0xbf, 0x8e, 0x13, 0xb8, 0x45, 0xdc, 0x4b, 0x34,
-	0xf2, 0x0d, 0xc8, 0x16, 0xca, 0xa6, 0x9c, 0x87, 0x5c, 0x74, 0x36, 0xb6, 0xca, 0xdb, 0x55, 0xab,
-	0x95, 0xd2, 0xfb, 0x8a, 0x4c, 0x1e, 0xc1, 0xb2, 0x98, 0x0a, 0x49, 0x27, 0x1d, 0x4f, 0xcd, 0x7b,
-	0x77, 0xee, 0xbc, 0xc7, 0x0a, 0x62, 0x19, 0x28, 0x39, 0x82, 0x76, 0x14, 0x0a, 0x39, 0xe6, 0x54,
-	0xa4, 0x0e, 0xa2, 0x4a, 0xfc, 0xde, 0x5c, 0xf1, 0x17, 0x06, 0x6c, 0x9c, 0x66, 0xb5, 0xa2, 0x22,
-	0x81, 0x3c, 0x83, 0x16, 0x0f, 0x7d, 0x6a, 0x73, 0x3a, 0xa2, 0x9c, 0x06, 0x2e, 0x15, 0x9d, 0xd1,
-	0x56, 0x79, 0xbb, 0xf6, 0xb0, 0x3b, 0x57, 0x9f, 0x15, 0xfa, 0xd4, 0x4a, 0xa0, 0x56, 0x93, 0xe7,
-	0x87, 0x82, 0xbc, 0x82, 0x35, 0xcf, 0x91, 0xce, 0xd0, 0x11, 0x05, 0x85, 0x63, 0xa5, 0xf0, 0xa3,
-	0xb9, 0x0a, 0xf7, 0x0c, 0x3e, 0x53, 0x4a, 0xbc, 0x59, 0x92, 0x20, 0x3f, 0x86, 0x55, 0x65, 0x25,
-	0x0b, 0x46, 0x21, 0x9f, 0x38, 0x92, 0x85, 0x81, 0xe8, 0x04, 0x4a, 0xed, 0xbd, 0x77, 0xda, 0xb9,
-	0x9f, 0x81, 0xad, 0x36, 0x2f, 0x12, 0x04, 0xf9, 0x6d, 0xd8, 0x48, 0x6d, 0x2d, 0xa8, 0x0d, 0x95,
-	0xda, 0xed, 0x2b, 0xad, 0xcd, 0xab, 0x5e, 0xf7, 0x2e, 0x13, 0x05, 0xf9, 0x04, 0x2a, 0x82, 0x4a,
-	0xc9, 0x82, 0xb1, 0xe8, 0xbc, 0x55, 0x1a, 0xdf, 0x9b, 0xef, 0x5f, 0x0d, 0xb2, 0x52, 0x34, 0x79,
-	0x0c, 0x35, 0x4e, 0x23, 0x9f, 0xb9, 0x4a, 0x53, 0xe7, 0x77, 0x95, 0x77, 0xb7, 0xe6, 0x7f, 0x65,
-	0x86, 0xb3, 0xf2, 0x42, 0xc4, 0x83, 0xce, 0xd0, 0x71, 0xcf, 0x68, 0xe0, 0xd9, 0x6e, 0x18, 0x07,
-	0x32, 0x0b, 0x72, 0xd1, 0xf9, 0x3d, 0x65, 0xcd, 0x37, 0xe7, 0x2a, 0x7c, 0xac, 0x85, 0x76, 0x51,
-	0x26, 0x0b, 0xf4, 0x5b, 0xc3, 0x79, 0x64, 0x41, 0x7e, 0x07, 0x36, 0xa4, 0x33, 0xf4, 0xa9, 0x88,
-	0x1c, 0xb7, 0xe0, 0xf0, 0xdf, 0x2f, 0x5d, 0xb1, 0x86, 0x83, 0x54, 0x24, 0xf3, 0xf9, 0xba, 0xbc,
-	0x4c, 0x14, 0xc4, 0x83, 0xdb, 0x39, 0xfd, 0x05, 0x27, 0xfd, 0x41, 0xe9, 0x8a, 0xaf, 0xc8, 0x66,
-	0xc8, 0xfb, 0xe9, 0x96, 0x9c, 0x47, 0x16, 0xb8, 0xa5, 0x5e, 0xc7, 0x94, 0x4f, 0xf3, 0x1f, 0xf0,
-	0x37, 0x5a, 0xfd, 0xd7, 0xe6, 0xaa, 0xff, 0x31, 0xa2, 0x33, 0xdb, 0x5b, 0xaf, 0x0b, 0x63, 0x95,
-	0x5d, 0x38, 0xf5, 0x95, 0xf6, 0xbc, 0xce, 0xbf, 0x2d, 0x5d, 0xb1, 0x0d, 0x2c, 0x23, 0x90, 0xdb,
-	0x06, 0x7c, 0x96, 0xa4, 0x4c, 0x65, 0x81, 0x47, 0x2f, 0xf2, 0x6a, 0xff, 0xee, 0x2a, 0x53, 0xf7,
-	0x11, 0x9d, 0x33, 0x95, 0x15, 0xc6, 0xca, 0xd4, 0x51, 0x1c, 0xb8, 0xb3, 0xa6, 0xfe, 0xfd, 0x55,
-	0xa6, 0x3e, 0x31, 0x02, 0x39, 0x53, 0x47, 0xb3, 0x24, 0x41, 0x4e, 0x80, 0xe8, 0x55, 0x2d, 0xb8,
-	0xed, 0x1f, 0xb4, 0xe2, 0xaf, 0xbf, 0x7b, 0x5d, 0xf3, 0x1e, 0x5b, 0x7d, 0x3d, 0x43, 0xc9, 0x39,
-	0x2b, 0x17, 0xd0, 0xff, 0x78, 0xad, 0xb3, 0xb2, 0x50, 0xd6, 0xce, 0xca, 0xc5, 0x30, 0x83, 0x3b,
-	0xa7, 0x4c, 0xc8, 0x90, 0x33, 0xd7, 0xbe, 0xa4, 0xf9, 0x17, 0x5a, 0xf3, 0x83, 0xb9, 0x9a, 0x9f,
-	0x1a, 0xb1, 0xe2, 0x0c, 0xc2, 0xba, 0x7d, 0x3a, 0x9f, 0x41, 0x06, 0xd0, 0xd4, 0x33, 0xd0, 0x8b,
-	0xc8, 0x77, 0x58, 0x20, 0x3a, 0xff, 0x74, 0x95, 0x7e, 0x25, 0xde, 0xd7, 0xd0, 0xfc, 0xaa, 0x34,
-	0x5e, 0xe7, 0x18, 0x6a, 0x13, 0xa6, 0xd1, 0x56, 0x58, 0xeb, 0x5f, 0x5e, 0xb5, 0x09, 0x93, 0x78,
-	0x2b, 0x24, 0x32, 0x7e, 0x99, 0x58, 0x8c, 0xe6, 0xdc, 0xd2, 0xfc, 0xcb, 0x4d, 0xa2, 0x39, 0x77,
-	0x56, 0xf2, 0x59, 0x92, 0x20, 0x07, 0xd0, 0x4a, 0x35, 0xd3, 0x73, 0x1a, 0x48, 0xd1, 0xf9, 0xa2,
-	0x74, 0xd5, 0xd9, 0x63, 0xc0, 0x7d, 0xc4, 0x5a, 0x4d, 0x9e, 0x1f, 0xaa, 0x80, 0xd3, 0x7b, 0xa3,
-	0xb0, 0x08, 0xff, 0x7a, 0x55, 0xc0, 0xa9, 0xdd, 0x51, 0x08, 0x38, 0x36, 0x43, 0xc9, 0x6d, 0xb9,
-	0xdc, 0xb7, 0xff, 0xdb, 0xb5, 0x5b, 0x2e, 0x17, 0x70, 0xac, 0x30, 0x56, 0xfe, 0x4a, 0xb7, 0x5c,
-	0xc1, 0xd4, 0x5f, 0x5f, 0xe5, 0xaf, 0x64, 0xd3, 0x15, 0xfc, 0x35, 0xba, 0x4c, 0x2c, 0x6e, 0xe9,
-	0x9c, 0xcd, 0xff, 0x71, 0x93, 0x2d, 0x9d, 0xf3, 0xd7, 0x68, 0x96, 0x24, 0x3e, 0x5d, 0xac, 0x5c,
-	0xb4, 0xa7, 0x9f, 0x2e, 0x56, 0xa6, 0xed, 0xb7, 0x9f, 0x2e, 0x57, 0x7e, 0x55, 0x6a, 0x7f, 0x51,
-	0xfa, 0x74, 0xb9, 0xf2, 0xef, 0xa5, 0xf6, 0xaf, 0x4b, 0xdd, 0xbf, 0x5e, 0x00, 0x72, 0xb9, 0x44,
-	0xc2, 0x1a, 0x71, 0x1c, 0xa6, 0x85, 0x8a, 0xae, 0x00, 0xab, 0xe3, 0x30, 0x29, 0x3e, 0xbe, 0x0f,
-	0x77, 0x27, 0x74, 0x12, 0xf2, 0xa9, 0x7d, 0x4a, 0x9d, 0xc8, 0x76, 0x7c, 0x3f, 0x74, 0x1d, 0xac,
-	0xe5, 0x86, 0x53, 0x49, 0x45, 0xa7, 0xb1, 0x55, 0xda, 0x5e, 0xb4, 0x3a, 0x1a, 0xf2, 0x94, 0x3a,
-	0x51, 0x2f, 0x01, 0x3c, 0x46, 0x3e, 0xd9, 0x81, 0xb5, 0xbc, 0x78, 0x38, 0xfc, 0x29, 0x75, 0xa5,
-	0xe8, 0x34, 0x95, 0xd8, 0x6a, 0x26, 0x76, 0xa4, 0x19, 0x39, 0xbc, 0xae, 0xa6, 0xcc, 0x34, 0xad,
-	0x3c, 0x5e, 0xd7, 0x5b, 0x5a, 0xff, 0x36, 0xb4, 0x0d, 0x9e, 0x0b, 0x61, 0xc0, 0x6d, 0x05, 0x6e,
-	0x6a, 0xba, 0x25, 0x84, 0x46, 0x7e, 0x0b, 0x56, 0x1d, 0x57, 0xb2, 0x73, 0x6a, 0x8f, 0x43, 0x1e,
-	0xc6, 0x92, 0x05, 0x54, 0xa8, 0x72, 0x72, 0xc9, 0x6a, 0x6b, 0xc6, 0x8f, 0x52, 0x3a, 0xb9, 0x0b,
-	0x55, 0x77, 0x1c, 0xda, 0xae, 0xe3, 0xfb, 0xa2, 0xf3, 0xd5, 0xad, 0xd2, 0x76, 0xd9, 0xaa, 0xb8,
-	0xe3, 0x70, 0x17, 0xc7, 0xdd, 0x3f, 0x2f, 0x43, 0x6b, 0xa6, 0x78, 0x21, 0x77, 0xa0, 0xa2, 0xab,
-	0x1f, 0xef, 0xc2, 0x14, 0xfd, 0x2b, 0xaa, 0x9c, 0xf1, 0x2e, 0x48, 0x07, 0x56, 0x58, 0x70, 0x4a,
-	0x39, 0x93, 0xaa, 0xb0, 0xaf, 0x58, 0xc9, 0x90, 0xac, 0xc3, 0x92, 0x1f, 0x8e, 0x99, 0xae, 0xdf,
-	0x2b, 0x96, 0x1e, 0xa8, 0xb9, 0x39, 0x75, 0x24, 0xb5, 0xbd, 0xa1, 0xa9, 0xd9, 0x2b, 0x9a, 0xb0,
-	0x37, 0x24, 0x1f, 0x40, 0xcd, 0x30, 0x51, 0x7d, 0x67, 0x49, 0xb1, 0x41, 0x93, 0xd0, 0x26, 0x74,
-	0xa7, 0x88, 0x23, 0xca, 0xed, 0x58, 0x50, 0xde, 0x59, 0xd6, 0x25, 0xbf, 0xa2, 0x9c, 0x08, 0xca,
-	0xc9, 0x56, 0xb1, 0x72, 0x59, 0x51, 0xfc, 0x42, 0x5d, 0xf2, 0x3e, 0xc0, 0x70, 0x1a, 0x39, 0x42,
-	0xd8, 0xdc, 0x17, 0x9d, 0x8a, 0x56, 0xa0, 0x29, 0x96, 0x2f, 0x74, 0xf5, 0x1c, 0x04, 0x54, 0x47,
-	0xaf, 0xcf, 0x26, 0x4c, 0x76, 0xaa, 0xea, 0x83, 0x5b, 0x19, 0xfd, 0x00, 0xc9, 0x64, 0x00, 0xeb,
-	0x28, 0xf5, 0x26, 0xe4, 0x9e, 0x7d, 0xee, 0xf8, 0xcc, 0xb3, 0xe3, 0x40, 0x32, 0x5f, 0xc5, 0xd8,
-	0xbb, 0x12, 0xc8, 0x61, 0xec, 0xfb, 0xd9, 0x4d, 0x82, 0x24, 0xf2, 0x2f, 0x51, 0xfc, 0x04, 0xa5,
-	0xc9, 0x2d, 0x58, 0x76, 0xc3, 0x60, 0xc4, 0xc6, 0x9d, 0x9a, 0x2a, 0xda, 0xcd, 0x08, 0x97, 0x6d,
-	0x42, 0x27, 0x43, 0xca, 0xed, 0x70, 0xd4, 0xa9, 0x6f, 0x95, 0xb7, 0x97, 0xac, 0x8a, 0x26, 0x1c,
-	0x8d, 0xba, 0x7f, 0x59, 0x86, 0xb5, 0x39, 0x85, 0x21, 0xf9, 0x10, 0xea, 0x59, 0x85, 0x99, 0xba,
-	0xae, 0x96, 0x96, 0x8b, 0xde, 0x05, 0xb9, 0x07, 0xcd, 0xf0, 0x4d, 0x40, 0xb9, 0x9d, 0xfa, 0x57,
-	0x5f, 0xcf, 0xea, 0x8a, 0x6a, 0x19, 0x27, 0x6f, 0x42, 0x85, 0x06, 0x6e, 0xe8, 0xb1, 0x60, 0x6c,
-	0x6e, 0x63, 0xe9, 0x18, 0x03, 0x00, 0x3f, 0xd0, 0x91, 0x54, 0xb9, 0xb3, 0x6a, 0x25, 0x43, 0xb2,
-	0x01, 0xcb, 0xae, 0x2d, 0xa7, 0x91, 0x76, 0x64, 0xd5, 0x5a, 0x72, 0x07, 0xd3, 0x88, 0xa2, 0x93,
-	0x99, 0xb0, 0x25, 0x9d, 0x44, 0x4a, 0x48, 0x3b, 0x11, 0x98, 0x18, 0x18, 0x8a, 0x8a, 0x65, 0xdf,
-	0x0f, 0xdf, 0xd8, 0xd9, 0x92, 0x0b, 0xe3, 0xcb, 0xb6, 0x62, 0xec, 0x66, 0xf4, 0xb9, 0x1e, 0xab,
-	0xcc, 0xf7, 0x18, 0xde, 0x17, 0x79, 0xf8, 0x96, 0x06, 0xf6, 0x05, 0xf3, 0x94, 0x5b, 0x1b, 0x56,
-	0x55, 0x53, 0x3e, 0x63, 0x1e, 0x79, 0x08, 0x1b, 0x13, 0x16, 0xb0, 0x49, 0x3c, 0xb1, 0x27, 0xb1,
-	0x2f, 0xd9, 0x85, 0xe3, 0x4a, 0x85, 0x04, 0x85, 0x5c, 0x33, 0xcc, 0xe7, 0x09, 0x0f, 0x65, 0x7e,
-	0x00, 0xef, 0x65, 0xf7, 0x3f, 0x4c, 0x0d, 0xbe, 0xed, 0x3a, 0xd2, 0xf1, 0xc3, 0xb1, 0x8d, 0xab,
-	0xac, 0xae, 0x93, 0x15, 0xeb, 0x4e, 0x8a, 0x39, 0x40, 0xc8, 0xae, 0x46, 0xa0, 0xc7, 0xba, 0x3f,
-	0x2f, 0xc3, 0x8a, 0xa9, 0xc0, 0x09, 0x81, 0xc5, 0xc0, 0x99, 0x50, 0xe5, 0xa6, 0xaa, 0xa5, 0x7e,
-	0xe3, 0x25, 0xd6, 0x8d, 0x39, 0xa7, 0x81, 0xc4, 0x20, 0x8b, 0xa9, 0x72, 0x4f, 0xd5, 0xaa, 0x1b,
-	0xe2, 0x4b, 0xa4, 0x91, 0x47, 0xb0, 0x18, 0x07, 0x4c, 0x2a, 0xd7, 0xd4, 0x1e, 0x7e, 0xf0, 0xce,
-	0xd0, 0x3b, 0x96, 0x1c, 0x2b, 0x7d, 0x05, 0x26, 0xbf, 0x05, 0x30, 0x0c, 0xc3, 0x44, 0xed, 0xe2,
-	0xcd, 0x44, 0xab, 0x28, 0xa2, 0x27, 0xfd, 0x21, 0xee, 0x35, 0x41, 0x13, 0x05, 0x4b, 0x37, 0x53,
-	0x00, 0x4a, 0x46, 0x6b, 0xf8, 0x2e, 0x2c, 0x8b, 0x30, 0xe6, 0xae, 0x8e, 0x81, 0x1b, 0x08, 0x1b,
-	0x38, 0x4e, 0xad, 0x7f, 0xd9, 0x23, 0xe6, 0x53, 0x15, 0x1a, 0x37, 0x99, 0x5a, 0xcb, 0x3c, 0x61,
-	0x7e, 0x5e, 0x83, 0xcf, 0x02, 0xaa, 0x02, 0xe6, 0xe6, 0x1a, 0x0e, 0x58, 0x40, 0xbb, 0x3f, 0x5b,
-	0x82, 0x5a, 0xee, 0xf6, 0xa3, 0xa2, 0x1a, 0x4b, 0x58, 0x37, 0x3c, 0xa7, 0x7c, 0xaa, 0x7c, 0x88,
-	0x51, 0x1d, 0x58, 0x86, 0x82, 0xe1, 0x95, 0x78, 0xf2, 0x02, 0xe3, 0x43, 0x1d, 0x24, 0xd9, 0xa1,
-	0xb4, 0x66, 0x98, 0x9f, 0xf9, 0xe1, 0xf8, 0xc0, 0xb0, 0xc8, 0x00, 0x88, 0x90, 0x4e, 0xe0, 0x0d,
-	0x0b, 0x77, 0x83, 0xda, 0x15, 0x15, 0xc5, 0xb1, 0x86, 0x67, 0xa5, 0xf1, 0xaa, 0x98, 0xa1, 0x08,
-	0xf2, 0x13, 0x58, 0x4f, 0xb4, 0x16, 0xce, 0xff, 0xba, 0xd2, 0x7b, 0xff, 0x2a, 0xbd, 0xf9, 0xd3,
-	0x7f, 0x4d, 0x5c, 0xa2, 0x89, 0xbc, 0xc5, 0xb9, 0xb3, 0xbf, 0x71, 0xbd, 0xc5, 0xd9, 0xc9, 0x9f,
-	0x58, 0x9c, 0x2b, 0x59, 0x3e, 0x84, 0x3a, 0x13, 0xb6, 0x90, 0x9c, 0x3a, 0x13, 0xcc, 0x41, 0xeb,
-	0x3a, 0xb1, 0x33, 0x71, 0x9c, 0x90, 0x30, 0x0f, 0x70, 0xea, 0x52, 0x3c, 0x01, 0xd3, 0x95, 0xdd,
-	0x50, 0x2b, 0xdb, 0x32, 0xf4, 0x74, 0x55, 0xef, 0x63, 0xd9, 0x17, 0xf9, 0xce, 0x34, 0x43, 0xde,
-	0x52, 0xc8, 0xa6, 0x26, 0xa7, 0xc0, 0x7b, 0xd0, 0x74, 0xa2, 0xc8, 0x9f, 0xaa, 0x93, 0xd7, 0xf6,
-	0x9d, 0x71, 0xe7, 0xb6, 0x3a, 0x2c, 0xeb, 0x8a, 0x8a, 0x07, 0xef, 0x81, 0x33, 0x26, 0x7d, 0x9c,
-	0x59, 0xa9, 0x4b, 0x1b, 0x6b, 0x9d, 0xce, 0xb5, 0x6d, 0x24, 0x63, 0x42, 0x4a, 0x20, 0xff, 0x0f,
-	0xd6, 0x67, 0xd5, 0xd8, 0xce, 0x98, 0x76, 0xee, 0xa8, 0x29, 0xc9, 0x0c, 0xbc, 0x37, 0xa6, 0xdd,
-	0x47, 0xd0, 0x9e, 0x75, 0xb7, 0x3a, 0x41, 0x7d, 0x86, 0x41, 0xe6, 0x78, 0x1e, 0x37, 0xa9, 0x04,
-	0x34, 0xa9, 0xe7, 0x79, 0xbc, 0xfb, 0xcb, 0x05, 0x20, 0x97, 0x9d, 0x89, 0x72, 0x69, 0x4c, 0xa4,
-	0x27, 0x05, 0x24, 0x1e, 0xf6, 0x2e, 0x0a, 0x25, 0xc0, 0x42, 0xb1, 0x04, 0x68, 0x43, 0x39, 0x62,
-	0x9e, 0xca, 0x3e, 0x65, 0x0b, 0x7f, 0xa2, 0x33, 0x70, 0x89, 0xcc, 0xde, 0xb0, 0x55, 0x56, 0xd3,
-	0x87, 0x43, 0x2b, 0x47, 0x3f, 0xc4, 0x04, 0x77, 0x1f, 0x5a, 0xc6, 0xe0, 0xd3, 0x50, 0x48, 0x85,
-	0xd4, 0xa7, 0x45, 0x53, 0x93, 0x9f, 0x1a, 0x6a, 0xee, 0xcb, 0xa2, 0x90, 0x4b, 0x95, 0x32, 0x96,
-	0x92, 0x2f, 0x7b, 0x11, 0x72, 0x49, 0x7e, 0x00, 0x8d, 0xa4, 0xe5, 0x20, 0xa4, 0xc3, 0xa5, 0xc9,
-	0x0b, 0x57, 0x39, 0xa1, 0x6e, 0x04, 0x8e, 0x11, 0xaf, 0x1a, 0x86, 0xd3, 0xc0, 0xb5, 0x23, 0xce,
-	0x42, 0xce, 0xe4, 0xd4, 0x9c, 0x23, 0x75, 0x24, 0xbe, 0x30, 0x34, 0x55, 0x81, 0x20, 0x08, 0xa3,
-	0x9b, 0xaa, 0x43, 0xa4, 0x6a, 0x55, 0x91, 0x82, 0xe1, 0x4a, 0xbb, 0x3f, 0x5b, 0x48, 0x9d, 0x92,
-	0x15, 0xa1, 0xd7, 0x2e, 0xee, 0x3a, 0x2c, 0x69, 0x7d, 0x3a, 0xbb, 0xeb, 0x81, 0xb2, 0x07, 0xbf,
-	0x37, 0x8d, 0xd2, 0xb2, 0x69, 0x60, 0xd2, 0x40, 0xa6, 0x31, 0xfa, 0x75, 0x68, 0xbe, 0xe1, 0x4c,
-	0xe6, 0xa2, 0x5e, 0x2f, 0x74, 0x43, 0x51, 0xf3, 0xb0, 0x91, 0x1f, 0x8b, 0xd3, 0x0c, 0xa6, 0x57,
-	0xb9, 0xa1, 0xa8, 0x57, 0x6d, 0x8d, 0xe5, 0xb9, 0x5b, 0xe3, 0x0e, 0x54, 0xd2, 0x4d, 0xb1, 0xa2,
-	0x1c, 0xbf, 0x32, 0xd4, 0xfb, 0xa1, 0xfb, 0x47, 0xcb, 0xb0, 0x31, 0xb7, 0x8d, 0x43, 0xb6, 0xa0,
-	0x7e, 0xea, 0x08, 0xbb, 0x50, 0x4a, 0x56, 0x2c, 0x38, 0x75, 0x44, 0x52, 0x68, 0x5c, 0x11, 0x65,
-	0xdb, 0xd0, 0x46, 0xe1, 0x42, 0x41, 0xa3, 0x2b, 0xcb, 0xe6, 0xa9, 0x23, 0xf6, 0x72, 0x35, 0xcd,
-	0x6c, 0xd9, 0xb3, 0x78, 0xb9, 0xec, 0x79, 0x9e, 0x2c, 0x38, 0xae, 0x42, 0xf3, 0xe1, 0x77, 0x6f,
-	0xde, 0x8b, 0x4a, 0xa8, 0xca, 0xdd, 0x89, 0xa7, 0x3e, 0x87, 0x24, 0x92, 0x74, 0xbd, 0xb3, 0xac,
-	0xb4, 0x7e, 0xe7, 0xcb, 0x6b, 0xc5, 0x02, 0xc9, 0xaa, 0x0d, 0xb3, 0x01, 0x7e, 0xf6, 0x1b, 0x87,
-	0x61, 0x7d, 0x60, 0x8f, 0x42, 0x8e, 0x6e, 0x39, 0x33, 0xb5, 0x50, 0xd3, 0xd0, 0x9f, 0x84, 0xfc,
-	0x20, 0x74, 0xcf, 0x30, 0x88, 0x54, 0xab, 0xcd, 0x84, 0xad, 0x1e, 0x74, 0xff, 0xa4, 0x04, 0xf5,
-	0xbc, 0xc9, 0x64, 0x15, 0x1a, 0x27, 0x87, 0xcf, 0x0e, 0x8f, 0x5e, 0x1d, 0xda, 0xc7, 0x83, 0xde,
-	0xa0, 0xdf, 0xfe, 0x0a, 0x01, 0x58, 0xee, 0xed, 0x0e, 0xf6, 0x5f, 0xf6, 0xdb, 0x25, 0x52, 0x81,
-	0xc5, 0xfd, 0xbd, 0x83, 0x7e, 0x7b, 0x81, 0xdc, 0x86, 0x35, 0xfc, 0x65, 0xef, 0x1f, 0xda, 0x03,
-	0xab, 0x77, 0x78, 0x8c, 0x90, 0xa3, 0xc3, 0x76, 0x99, 0x7c, 0x00, 0x77, 0xe7, 0x30, 0xec, 0xde,
-	0xe3, 0x23, 0x6b, 0xd0, 0xdf, 0x6b, 0x2f, 0x92, 0x4d, 0xb8, 0xf5, 0xa4, 0x77, 0x3c, 0x78, 0xd1,
-	0x1b, 0x3c, 0xb5, 0x9f, 0x9c, 0x1c, 0x6a, 0xf6, 0x6e, 0xef, 0xe0, 0xa0, 0xbd, 0x44, 0xea, 0x50,
-	0xd9, 0xdb, 0x3f, 0xee, 0x3d, 0x3e, 0xe8, 0xef, 0xb5, 0x97, 0xbb, 0x5f, 0x94, 0xa0, 0x96, 0xfb,
-	0x74, 0xd2, 0x86, 0x7a, 0x62, 0xdc, 0xe0, 0xf3, 0x17, 0x68, 0xdb, 0x6d, 0x58, 0xeb, 0x9d, 0x0c,
-	0x8e, 0x5e, 0xf6, 0x76, 0x4f, 0x4e, 0x9e, 0xdb, 0x07, 0xbd, 0x93, 0xc3, 0xdd, 0xa7, 0x7d, 0xab,
-	0x5d, 0x22, 0x1b, 0xb0, 0x9a, 0x63, 0xbc, 0x3a, 0xb2, 0x9e, 0xf5, 0xad, 0xf6, 0x02, 0x92, 0x1f,
-	0xf7, 0x76, 0x9f, 0xfd, 0xc8, 0x3a, 0x3a, 0x39, 0xdc, 0x4b, 0xc8, 0xe5, 0x59, 0xb2, 0xb5, 0x3f,
-	0xe8, 0x5b, 0xed, 0x45, 0x42, 0xa0, 0xb9, 0x7b, 0xb0, 0xdf, 0x3f, 0x1c, 0xd8, 0xc8, 0xed, 0x1f,
-	0xee, 0xb5, 0x97, 0xd0, 0x86, 0xdd, 0xa7, 0xfd, 0xdd, 0x67, 0x2f, 0x8e, 0xf6, 0x0f, 0x11, 0xb5,
-	0x4c, 0x6a, 0xb0, 0x72, 0x3c, 0xe8, 0x59, 0x83, 0x93, 0x17, 0xed, 0x15, 0xd2, 0x82, 0xda, 0xab,
-	0xde, 0x81, 0xd5, 0xdf, 0xed, 0xef, 0xbf, 0xec, 0x5b, 0xed, 0x0a, 0x69, 0x40, 0xf5, 0x55, 0xef,
-	0xe0, 0xb8, 0x7f, 0xb8, 0xd7, 0xb7, 0xda, 0x55, 0x33, 0x34, 0x33, 0x40, 0xf7, 0x1b, 0xb0, 0x36,
-	0xa7, 0xdf, 0x38, 0xaf, 0xd6, 0xeb, 0xfe, 0x69, 0x09, 0x36, 0xe6, 0x76, 0x0e, 0x71, 0xf7, 0xe6,
-	0xfb, 0x90, 0x69, 0x0e, 0x69, 0xe4, 0x3a, 0x8a, 0xde, 0x05, 0x79, 0x00, 0xc4, 0x63, 0xe2, 0xcc,
-	0x8e, 0x1c, 0x2e, 0x99, 0xbe, 0xdf, 0xa7, 0xfb, 0xa8, 0x8d, 0x9c, 0x17, 0x09, 0x63, 0x76, 0xaf,
-	0x95, 0x8b, 0x7b, 0x2d, 0xbb, 0x85, 0x2c, 0xe6, 0x6f, 0x21, 0xdd, 0xff, 0x5a, 0x84, 0x66, 0xb1,
-	0xa9, 0x84, 0x17, 0x13, 0xd3, 0x66, 0x4b, 0xad, 0xaa, 0xe8, 0xae, 0x99, 0xce, 0x6b, 0xfa, 0x92,
-	0xb9, 0xa0, 0x52, 0x84, 0x1e, 0x60, 0x0a, 0x95, 0xa1, 0x74, 0x7c, 0x75, 0xd0, 0xa9, 0xa9, 0x4b,
-	0x56, 0x55, 0x51, 0x30, 0x33, 0xe3, 0xd2, 0xf0, 0xf0, 0x8d, 0x50, 0xdb, 0xb6, 0x6c, 0xa9, 0xdf,
-	0xe4, 0x23, 0x68, 0xe9, 0x47, 0x2a, 0x7b, 0xe8, 0x9f, 0x09, 0xfb, 0x94, 0x49, 0xb5, 0x73, 0xcb,
-	0x56, 0x43, 0x93, 0x1f, 0xfb, 0x67, 0xe2, 0x29, 0x93, 0xb8, 0x5b, 0xf2, 0x38, 0x4e, 0x1d, 0x4f,
-	0x6d, 0xc6, 0xb2, 0xd5, 0xcc, 0x80, 0x16, 0x75, 0x3c, 0xbc, 0x8a, 0xe7, 0x91, 0x1e, 0xe3, 0x92,
-	0x51, 0xcf, 0xe4, 0xb2, 0xd5, 0x0c, 0xbc, 0xa7, 0x19, 0xb3, 0x78, 0xcc, 0xae, 0x92, 0x06, 0x6a,
-	0xaf, 0x15, 0xf0, 0xaf, 0x34, 0x03, 0x6b, 0x07, 0x7d, 0x1f, 0x48, 0x0d, 0xae, 0xea, 0xda, 0x41,
-	0x51, 0x13, 0x7b, 0x3f, 0x82, 0x56, 0x0e, 0xa5, 0xcc, 0x05, 0xfd, 0x5d, 0x29, 0x4c, 0x59, 0xfb,
-	0x00, 0x48, 0x0e, 0x97, 0x18, 0x5b, 0x53, 0xd0, 0x76, 0x0a, 0x4d, 0x6c, 0x2d, 0xa2, 0x13, 0x53,
-	0xeb, 0x33, 0xe8, 0x9c, 0xa5, 0x78, 0x19, 0xcb, 0x99, 0xd0, 0xd0, 0x96, 0x22, 0x35, 0xb5, 0xe0,
-	0x9b, 0xb0, 0x9a, 0xa1, 0x12, 0x95, 0x4d, 0x05, 0x6c, 0x25, 0xc0, 0x44, 0x63, 0x17, 0x1a, 0x43,
-	0xff, 0x4c, 0xe9, 0xd2, 0x3e, 0x6e, 0x29, 0x1f, 0xd7, 0x86, 0xfe, 0x19, 0xea, 0x52, 0x5e, 0xbe,
-	0x07, 0x4d, 0xc4, 0xe8, 0xb3, 0x4b, 0x81, 0xda, 0x0a, 0x54, 0x1f, 0xfa, 0x67, 0xa8, 0x87, 0x22,
-	0xaa, 0xfb, 0x8b, 0x12, 0xdc, 0x7e, 0x47, 0x9b, 0xf3, 0xd2, 0xd3, 0x5d, 0xe9, 0xff, 0xec, 0xe9,
-	0x6e, 0xe1, 0xaa, 0xa7, 0xbb, 0x5d, 0x80, 0x5c, 0x65, 0x5b, 0xbe, 0x79, 0xe7, 0x37, 0x27, 0xd6,
-	0xfd, 0x33, 0x80, 0xb5, 0x39, 0x1d, 0x50, 0x3c, 0xba, 0xb2, 0x5e, 0x6a, 0x76, 0x63, 0x4f, 0xfb,
-	0xa2, 0xde, 0x05, 0x56, 0x05, 0x29, 0x44, 0x1d, 0x36, 0xe6, 0x46, 0x98, 0x10, 0x55, 0x1e, 0x7d,
-	0x0a, 0xad, 0x73, 0x46, 0xdf, 0xd8, 0x1e, 0x1d, 0xb1, 0x80, 0xa5, 0xc5, 0xc3, 0x0d, 0xee, 0x38,
-	0x4d, 0x94, 0xdb, 0x4b, 0xc5, 0xc8, 0xbe, 0xba, 0xde, 0xc7, 0x93, 0x40, 0xa8, 0x5c, 0x50, 0x7b,
-	0xf8, 0xf1, 0x4d, 0xdb, 0xb9, 0x3b, 0xbb, 0x4a, 0xce, 0x4a, 0xe4, 0xc9, 0x09, 0xd4, 0xdc, 0x30,
-	0x10, 0x92, 0x3b, 0x2c, 0x90, 0xa2, 0xb3, 0xa4, 0xd4, 0x3d, 0xfa, 0x12, 0xea, 0x12, 0x59, 0x2b,
-	0xaf, 0x07, 0x8b, 0xcd, 0x88, 0x72, 0xc1, 0x84, 0xc4, 0xcc, 0x9a, 0x1d, 0xc0, 0x55, 0xab, 0x95,
-	0xa3, 0xab, 0x65, 0xf9, 0x2a, 0xc0, 0x88, 0xf9, 0xfe, 0xc8, 0xc1, 0x49, 0xd4, 0x5e, 0x5f, 0xb2,
-	0x72, 0x14, 0x4c, 0x89, 0x58, 0x63, 0x84, 0xcc, 0x4b, 0x7a, 0x43, 0x2b, 0xa7, 0x8e, 0x38, 0x62,
-	0x9e, 0x20, 0x9f, 0x40, 0x07, 0x59, 0xa6, 0xb9, 0xe5, 0xe0, 0x4c, 0xee, 0x29, 0xf3, 0x3d, 0x4e,
-	0x03, 0xb5, 0xb3, 0x2b, 0xd6, 0xad, 0x53, 0x47, 0xec, 0x67, 0xec, 0x5d, 0xc3, 0xc5, 0x0c, 0x89,
-	0x92, 0x32, 0x74, 0x84, 0x54, 0xbb, 0xbb, 0x62, 0xe1, 0x2c, 0x03, 0x1c, 0xcf, 0xf4, 0x24, 0x6a,
-	0x37, 0xee, 0x49, 0xd4, 0xdf, 0xdd, 0x93, 0xf8, 0x36, 0x10, 0x7a, 0xe1, 0xfa, 0xb1, 0x60, 0xe7,
-	0xd4, 0x57, 0x85, 0xdc, 0x19, 0xd5, 0x7b, 0xba, 0x62, 0xad, 0xe6, 0x38, 0x07, 0x8a, 0x41, 0x8e,
-	0x60, 0x25, 0x8c, 0xf4, 0x05, 0xb0, 0xa9, 0x3c, 0xf2, 0x1b, 0x37, 0xf6, 0xc8, 0x91, 0x96, 0xeb,
-	0x07, 0x92, 0x4f, 0xad, 0x44, 0xcb, 0xe6, 0xf7, 0xa0, 0x9e, 0x67, 0xe0, 0xf5, 0xe0, 0x8c, 0x4e,
-	0xcd, 0x49, 0x87, 0x3f, 0xf1, 0x58, 0xc8, 0x37, 0x33, 0xf4, 0xe0, 0x7b, 0x0b, 0x9f, 0x94, 0x36,
-	0x7f, 0x5e, 0x82, 0x65, 0x1d, 0x36, 0xe9, 0x09, 0xb9, 0x90, 0xeb, 0x86, 0xdc, 0x85, 0x2a, 0x56,
-	0x71, 0xda, 0xc7, 0xa6, 0x11, 0x85, 0x04, 0xe5, 0xdc, 0x3d, 0x68, 0x78, 0x74, 0xe4, 0xc4, 0xfe,
-	0x97, 0xec, 0x69, 0xd4, 0x8d, 0x94, 0x6e, 0x4a, 0xdc, 0x81, 0x4a, 0x10, 0x4a, 0x3b, 0x88, 0x7d,
-	0xdf, 0xf4, 0x1f, 0x57, 0x82, 0x50, 0x22, 0x9c, 0x6c, 0x42, 0x25, 0x0a, 0x05, 0x4b, 0xab, 0xe2,
-	0x25, 0x2b, 0x1d, 0x6f, 0xfe, 0x6a, 0x01, 0x20, 0x0b, 0x50, 0xbc, 0xcc, 0x8d, 0x42, 0x4e, 0xd9,
-	0x38, 0xb0, 0xe7, 0xec, 0x67, 0x62, 0x78, 0x56, 0x6e, 0x5b, 0xcf, 0xfb, 0x5c, 0x02, 0x8b, 0xb9,
-	0x2f, 0x55, 0xbf, 0xb1, 0x14, 0xc8, 0x82, 0x1f, 0xf7, 0x77, 0x52, 0xef, 0x67, 0xd4, 0x3d, 0x3a,
-	0x32, 0x5d, 0x39, 0xb5, 0x6d, 0x97, 0x54, 0xb7, 0x30, 0xdd, 0x85, 0xf7, 0xa1, 0x95, 0x98, 0x96,
-	0x20, 0x96, 0x15, 0xa2, 0x69, 0xc8, 0xbb, 0x06, 0xb8, 0x03, 0x6b, 0x09, 0x30, 0x8e, 0x3c, 0x47,
-	0x9a, 0xad, 0xb5, 0xa2, 0xa6, 0x5b, 0x35, 0xac, 0x13, 0xc5, 0x51, 0xeb, 0x9f, 0xc3, 0x7b, 0xd4,
-	0xa7, 0x09, 0xbe, 0x52, 0xc0, 0xef, 0x29, 0x8e, 0xc2, 0x3f, 0x80, 0x64, 0x1d, 0xec, 0x89, 0x23,
-	0xdd, 0x53, 0x0d, 0xd7, 0x37, 0xaa, 0xb6, 0xe1, 0x3c, 0x47, 0x06, 0xa2, 0xbb, 0xff, 0xbc, 0x04,
-	0xab, 0x97, 0x5e, 0x75, 0x6e, 0x92, 0x2f, 0xf1, 0xc2, 0xc6, 0xde, 0x52, 0xd3, 0x3d, 0xd7, 0x85,
-	0x48, 0x15, 0x29, 0xba, 0x71, 0x7e, 0x07, 0x2a, 0x82, 0xbe, 0xb6, 0x85, 0xeb, 0x04, 0xe6, 0x06,
-	0xbb, 0x22, 0xe8, 0xeb, 0x63, 0xd7, 0x09, 0xf0, 0xba, 0x82, 0x2c, 0x19, 0x47, 0xfa, 0x58, 0xd4,
-	0x05, 0x09, 0x08, 0xfa, 0x7a, 0x10, 0x47, 0xea, 0x50, 0xbc, 0x03, 0x15, 0xe6, 0x5d, 0x68, 0x61,
-	0x5d, 0x8f, 0xac, 0x30, 0xef, 0x42, 0x09, 0x77, 0xa1, 0x81, 0x2c, 0x14, 0x1e, 0x51, 0xe9, 0x9e,
-	0x9a, 0x32, 0xa4, 0xc6, 0xbc, 0x8b, 0x41, 0x1c, 0x3d, 0x41, 0x12, 0xd9, 0x84, 0x6a, 0xa0, 0x10,
-	0xcc, 0x34, 0x38, 0xcb, 0xd6, 0x4a, 0x30, 0x88, 0xa3, 0xfd, 0x40, 0x64, 0xbc, 0x38, 0xf2, 0x4c,
-	0x95, 0xa1, 0x78, 0x27, 0x91, 0x97, 0xf1, 0x3c, 0xea, 0x9b, 0xb2, 0x42, 0xf1, 0xf6, 0xa8, 0x4f,
-	0x3e, 0x84, 0x86, 0xe6, 0xa9, 0x7f, 0x7b, 0x89, 0x92, 0x7a, 0x02, 0x90, 0xff, 0x34, 0x94, 0x28,
-	0xfe, 0x1e, 0x40, 0x60, 0xfb, 0xec, 0x9c, 0x22, 0xce, 0x14, 0x11, 0x95, 0xe0, 0x80, 0x9d, 0xd3,
-	0x41, 0x1c, 0x69, 0xae, 0xa7, 0x8e, 0xee, 0x38, 0x32, 0x45, 0x43, 0x25, 0xd8, 0xc3, 0x73, 0x3b,
-	0x8e, 0xc8, 0xb7, 0x61, 0x2d, 0xb0, 0x27, 0xa1, 0x67, 0x0b, 0x86, 0x29, 0xd0, 0x6c, 0x2c, 0x53,
-	0x31, 0xb4, 0x83, 0xe7, 0xa1, 0x77, 0x8c, 0x8c, 0x9e, 0xa6, 0xe3, 0x29, 0xaf, 0x5e, 0x46, 0xb2,
-	0xda, 0x82, 0xe8, 0xda, 0x02, 0xa9, 0x69, 0x6d, 0xd1, 0x85, 0x46, 0x86, 0xc2, 0x52, 0x69, 0x4d,
-	0xaf, 0x55, 0x02, 0xc2, 0x4a, 0xc9, 0xac, 0x67, 0xa6, 0x68, 0x3d, 0x5d, 0xcf, 0x54, 0xcf, 0x16,
-	0xd4, 0x53, 0x0c, 0xaa, 0xd9, 0xd0, 0x9f, 0x6e, 0x20, 0xa6, 0xde, 0x52, 0x79, 0x38, 0xa7, 0xe7,
-	0x96, 0xae, 0xb7, 0x14, 0x39, 0xd5, 0x84, 0x35, 0x51, 0x86, 0x43, 0x5d, 0xa6, 0xf3, 0x93, 0xc2,
-	0x50, 0x1b, 0xa2, 0x8a, 0x46, 0x75, 0x0c, 0x2a, 0x6f, 0x55, 0x17, 0x1a, 0xb2, 0x60, 0x96, 0xee,
-	0xe8, 0xd4, 0x64, 0x66, 0x57, 0xf7, 0xaf, 0x16, 0xa0, 0x51, 0x78, 0x5d, 0xbc, 0x49, 0x64, 0xff,
-	0xd0, 0xa4, 0x87, 0x05, 0x75, 0xdb, 0x7c, 0x70, 0xfd, 0x93, 0xe5, 0x8e, 0xfa, 0xab, 0xee, 0x98,
-	0x3a, 0x99, 0xfc, 0x26, 0xd4, 0x42, 0x57, 0x35, 0x1e, 0x55, 0x05, 0x55, 0xbe, 0xb6, 0x82, 0x82,
-	0x04, 0xae, 0x0b, 0x28, 0x27, 0x8a, 0x78, 0x78, 0xc1, 0x26, 0x98, 0x1c, 0xf2, 0x8a, 0xf4, 0xbb,
-	0xce, 0x46, 0x8e, 0x7d, 0x94, 0xca, 0x75, 0x4f, 0xa0, 0x9a, 0xda, 0x81, 0xb7, 0xd1, 0xe7, 0xbd,
-	0xc3, 0x93, 0xde, 0x81, 0xad, 0x2f, 0x72, 0xed, 0xaf, 0xe0, 0x05, 0x0b, 0x2f, 0x76, 0x09, 0xa1,
-	0x84, 0x97, 0x34, 0x83, 0xe9, 0x1d, 0xf6, 0x0e, 0x3e, 0xff, 0x09, 0x5e, 0x4e, 0xdb, 0x50, 0x57,
-	0xa0, 0x84, 0x52, 0xee, 0xfe, 0xe7, 0x02, 0xb4, 0x67, 0xdf, 0x53, 0xf1, 0xc0, 0x30, 0x6f, 0xb2,
-	0xd9, 0xed, 0x44, 0x3f, 0xb1, 0xea, 0x3e, 0x41, 0x61, 0x89, 0x17, 0x2e, 0x2f, 0x71, 0x2e, 0x8d,
-	0x96, 0x8b, 0x69, 0x34, 0xd5, 0x9c, 0xa5, 0x60, 0xad, 0x19, 0xb3, 0xef, 0x93, 0x4b, 0x49, 0xfa,
-	0x86, 0xed, 0xf1, 0x99, 0x2c, 0xfe, 0x3e, 0x00, 0x13, 0x76, 0xc4, 0xd9, 0xc4, 0xe1, 0xd3, 0xe4,
-	0xb9, 0x8b, 0x89, 0x17, 0x9a, 0xa0, 0x6c, 0x10, 0x76, 0x1c, 0xb0, 0xd7, 0x31, 0x35, 0x4d, 0x81,
-	0x0a, 0x13, 0x27, 0x6a, 0xac, 0x72, 0x93, 0xd0, 0x2f, 0x53, 0x49, 0x2d, 0xc3, 0x84, 0x7a, 0x69,
-	0x9a, 0x29, 0x83, 0xaa, 0x97, 0xca, 0x20, 0x9c, 0x56, 0x7d, 0x9b, 0x0a, 0x2f, 0xf3, 0x68, 0xaa,
-	0x28, 0x2a, 0x15, 0xff, 0x77, 0x09, 0x9a, 0xc5, 0x47, 0xe6, 0xab, 0xd7, 0xf9, 0xfa, 0x0c, 0x9c,
-	0x26, 0xd1, 0x72, 0x31, 0x89, 0x9a, 0x0d, 0x3d, 0x9b, 0x81, 0x75, 0x0e, 0x4d, 0x36, 0xd7, 0xb5,
-	0x69, 0xf6, 0x52, 0xea, 0x58, 0xb9, 0x3e, 0x75, 0x54, 0x66, 0x53, 0x47, 0xf7, 0x8f, 0xcb, 0xb0,
-	0x36, 0xe7, 0x11, 0x1c, 0xa3, 0x28, 0x7b, 0x4e, 0xcf, 0x36, 0x6a, 0xfa, 0x34, 0xae, 0x9f, 0xcf,
-	0x7c, 0x27, 0x18, 0xc7, 0xce, 0x38, 0xad, 0x5a, 0x92, 0x31, 0x5e, 0xb5, 0xcd, 0x23, 0x88, 0x0e,
-	0xa2, 0xe4, 0x8d, 0x03, 0x17, 0x4d, 0xbf, 0x50, 0x0c, 0x59, 0xd2, 0xac, 0xab, 0x6a, 0xca, 0x63,
-	0x16, 0xe4, 0x6e, 0xe8, 0xcb, 0x85, 0x77, 0xc2, 0x5b, 0xb0, 0xcc, 0xa9, 0x88, 0x7d, 0x69, 0xce,
-	0x5d, 0x33, 0x22, 0xef, 0x41, 0xd5, 0x19, 0x8f, 0x39, 0x1d, 0x27, 0x5d, 0xcb, 0x8a, 0x95, 0x11,
-	0x50, 0xea, 0x0d, 0x0b, 0xbc, 0xf0, 0x8d, 0xa9, 0x4f, 0xcd, 0x08, 0x4b, 0x6b, 0x41, 0xdd, 0x98,
-	0x33, 0x39, 0xd5, 0x57, 0x09, 0xca, 0xcd, 0x93, 0x56, 0x2b, 0xa1, 0xef, 0x69, 0x32, 0x4e, 0xe0,
-	0x53, 0xe7, 0x2c, 0xe2, 0xa1, 0x7a, 0xa0, 0x54, 0x13, 0xa4, 0x04, 0xf5, 0x95, 0x92, 0x33, 0x57,
-	0x9a, 0x3a, 0xd4, 0x8c, 0xc8, 0x07, 0x50, 0xe3, 0x54, 0xc6, 0x3c, 0x10, 0xb6, 0xa0, 0x52, 0xdd,
-	0x27, 0x2b, 0x16, 0x18, 0xd2, 0x31, 0x95, 0xb8, 0x74, 0xe7, 0x21, 0xee, 0x47, 0x5f, 0xdf, 0x22,
-	0xab, 0x56, 0x3a, 0xee, 0xfe, 0x61, 0x09, 0x56, 0x2f, 0xfd, 0xe3, 0xc0, 0x4d, 0xfc, 0xf1, 0xbf,
-	0x6a, 0x4b, 0xdc, 0x85, 0xaa, 0xa0, 0xfe, 0x48, 0x73, 0x17, 0x15, 0xb7, 0x82, 0x04, 0x64, 0x0e,
-	0x97, 0x55, 0xae, 0x7c, 0xf4, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd7, 0x23, 0x45, 0x43, 0x96,
-	0x2b, 0x00, 0x00,
+var xxx_messageInfo_FunctionChange proto.InternalMessageInfo
+
+func (m *FunctionChange) GetDatabaseIdx() int32 {
+	if m != nil {
+		return m.DatabaseIdx
+	}
+	return 0
+}
+
+func (m *FunctionChange) GetSchemaName() string {
+	if m != nil {
+		return m.SchemaName
+	}
+	return ""
+}
+
+func (m *FunctionChange) GetFunctionName() string {
+	if m != nil {
+		return m.FunctionName
+	}
+	return ""
+}
+
+func (m *FunctionChange) GetArguments() string {
+	if m != nil {
+		return m.Arguments
+	}
+	return ""
+}
+
+func (m *FunctionChange) GetChangeType() FunctionChange_ChangeType {
+	if m != nil {
+		return m.ChangeType
+	}
+	return FunctionChange_ADDED
+}
+
+// VACUUM that was running at the time of the snapshot (pg_stat_progress_vacuum)
+type VacuumProgress struct {
+	// Combination of vacuum start time and PID, identifies the vacuum over time
+	VacuumIdentity  uint64 `protobuf:"varint,1,opt,name=vacuum_identity,json=vacuumIdentity,proto3" json:"vacuum_identity,omitempty"`
+	BackendIdentity uint64 `protobuf:"varint,2,opt,name=backend_identity,json=backendIdentity,proto3" json:"backend_identity,omitempty"`
+	DatabaseIdx     int32  `protobuf:"varint,3,opt,name=database_idx,json=databaseIdx,proto3" json:"database_idx,omitempty"`
+	HasDatabaseIdx  bool   `protobuf:"varint,4,opt,name=has_database_idx,json=hasDatabaseIdx,proto3" json:"has_database_idx,omitempty"`
+	// Only set for relations of databases whose local catalog was collected
+	RelationIdx          int32                `protobuf:"varint,5,opt,name=relation_idx,json=relationIdx,proto3" json:"relation_idx,omitempty"`
+	HasRelationIdx       bool                 `protobuf:"varint,6,opt,name=has_relation_idx,json=hasRelationIdx,proto3" json:"has_relation_idx,omitempty"`
+	RoleIdx              int32                `protobuf:"varint,7,opt,name=role_idx,json=roleIdx,proto3" json:"role_idx,omitempty"`
+	HasRoleIdx           bool                 `protobuf:"varint,8,opt,name=has_role_idx,json=hasRoleIdx,proto3" json:"has_role_idx,omitempty"`
+	SchemaName           string               `protobuf:"bytes,9,opt,name=schema_name,json=schemaName,proto3" json:"schema_name,omitempty"`
+	RelationName         string               `protobuf:"bytes,10,opt,name=relation_name,json=relationName,proto3" json:"relation_name,omitempty"`
+	StartedAt            *timestamp.Timestamp `protobuf:"bytes,11,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Autovacuum           bool                 `protobuf:"varint,12,opt,name=autovacuum,proto3" json:"autovacuum,omitempty"`
+	Phase                string               `protobuf:"bytes,13,opt,name=phase,proto3" json:"phase,omitempty"`
+	HeapBlksTotal        int64                `protobuf:"varint,14,opt,name=heap_blks_total,json=heapBlksTotal,proto3" json:"heap_blks_total,omitempty"`
+	HeapBlksScanned      int64                `protobuf:"varint,15,opt,name=heap_blks_scanned,json=heapBlksScanned,proto3" json:"heap_blks_scanned,omitempty"`
+	HeapBlksVacuumed     int64                `protobuf:"varint,16,opt,name=heap_blks_vacuumed,json=heapBlksVacuumed,proto3" json:"heap_blks_vacuumed,omitempty"`
+	IndexVacuumCount     int64                `protobuf:"varint,17,opt,name=index_vacuum_count,json=indexVacuumCount,proto3" json:"index_vacuum_count,omitempty"`
+	MaxDeadTuples        int64                `protobuf:"varint,18,opt,name=max_dead_tuples,json=maxDeadTuples,proto3" json:"max_dead_tuples,omitempty"`
+	NumDeadTuples        int64                `protobuf:"varint,19,opt,name=num_dead_tuples,json=numDeadTuples,proto3" json:"num_dead_tuples,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *VacuumProgress) Reset()         { *m = VacuumProgress{} }
+func (m *VacuumProgress) String() string { return proto.CompactTextString(m) }
+func (*VacuumProgress) ProtoMessage()    {}
+func (*VacuumProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{26}
+}
+func (m *VacuumProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VacuumProgress.Unmarshal(m, b)
+}
+func (m *VacuumProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VacuumProgress.Marshal(b, m, deterministic)
+}
+func (dst *VacuumProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VacuumProgress.Merge(dst, src)
+}
+func (m *VacuumProgress) XXX_Size() int {
+	return xxx_messageInfo_VacuumProgress.Size(m)
+}
+func (m *VacuumProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_VacuumProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VacuumProgress proto.InternalMessageInfo
+
+func (m *VacuumProgress) GetVacuumIdentity() uint64 {
+	if m != nil {
+		return m.VacuumIdentity
+	}
+	return 0
+}
+
+func (m *VacuumProgress) GetBackendIdentity() uint64 {
+	if m != nil {
+		return m.BackendIdentity
+	}
+	return 0
+}
+
+func (m *VacuumProgress) GetDatabaseIdx() int32 {
+	if m != nil {
+		return m.DatabaseIdx
+	}
+	return 0
+}
+
+func (m *VacuumProgress) GetHasDatabaseIdx() bool {
+	if m != nil {
+		return m.HasDatabaseIdx
+	}
+	return false
+}
+
+func (m *VacuumProgress) GetRelationIdx() int32 {
+	if m != nil {
+		return m.RelationIdx
+	}
+	return 0
+}
+
+func (m *VacuumProgress) GetHasRelationIdx() bool {
+	if m != nil {
+		return m.HasRelationIdx
+	}
+	return false
+}
+
+func (m *VacuumProgress) GetRoleIdx() int32 {
+	if m != nil {
+		return m.RoleIdx
+	}
+	return 0
+}
+
+func (m *VacuumProgress) GetHasRoleIdx() bool {
+	if m != nil {
+		return m.HasRoleIdx
+	}
+	return false
+}
+
+func (m *VacuumProgress) GetSchemaName() string {
+	if m != nil {
+		return m.SchemaName
+	}
+	return ""
+}
+
+func (m *VacuumProgress) GetRelationName() string {
+	if m != nil {
+		return m.RelationName
+	}
+	return ""
+}
+
+func (m *VacuumProgress) GetStartedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *VacuumProgress) GetAutovacuum() bool {
+	if m != nil {
+		return m.Autovacuum
+	}
+	return false
+}
+
+func (m *VacuumProgress) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *VacuumProgress) GetHeapBlksTotal() int64 {
+	if m != nil {
+		return m.HeapBlksTotal
+	}
+	return 0
+}
+
+func (m *VacuumProgress) GetHeapBlksScanned() int64 {
+	if m != nil {
+		return m.HeapBlksScanned
+	}
+	return 0
+}
+
+func (m *VacuumProgress) GetHeapBlksVacuumed() int64 {
+	if m != nil {
+		return m.HeapBlksVacuumed
+	}
+	return 0
+}
+
+func (m *VacuumProgress) GetIndexVacuumCount() int64 {
+	if m != nil {
+		return m.IndexVacuumCount
+	}
+	return 0
+}
+
+func (m *VacuumProgress) GetMaxDeadTuples() int64 {
+	if m != nil {
+		return m.MaxDeadTuples
+	}
+	return 0
+}
+
+func (m *VacuumProgress) GetNumDeadTuples() int64 {
+	if m != nil {
+		return m.NumDeadTuples
+	}
+	return 0
+}
+
+// CREATE INDEX or REINDEX that was running at the time of the snapshot (pg_stat_progress_create_index, Postgres 12+)
+type CreateIndexProgress struct {
+	Pid            int32 `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	DatabaseIdx    int32 `protobuf:"varint,2,opt,name=database_idx,json=databaseIdx,proto3" json:"database_idx,omitempty"`
+	HasDatabaseIdx bool  `protobuf:"varint,3,opt,name=has_database_idx,json=hasDatabaseIdx,proto3" json:"has_database_idx,omitempty"`
+	// Table the index is built on (only set for databases whose local catalog was collected)
+	RelationIdx    int32 `protobuf:"varint,4,opt,name=relation_idx,json=relationIdx,proto3" json:"relation_idx,omitempty"`
+	HasRelationIdx bool  `protobuf:"varint,5,opt,name=has_relation_idx,json=hasRelationIdx,proto3" json:"has_relation_idx,omitempty"`
+	// Not set while the index doesn't exist yet in the collected catalog
+	IndexIdx             int32    `protobuf:"varint,6,opt,name=index_idx,json=indexIdx,proto3" json:"index_idx,omitempty"`
+	HasIndexIdx          bool     `protobuf:"varint,7,opt,name=has_index_idx,json=hasIndexIdx,proto3" json:"has_index_idx,omitempty"`
+	Command              string   `protobuf:"bytes,8,opt,name=command,proto3" json:"command,omitempty"`
+	Phase                string   `protobuf:"bytes,9,opt,name=phase,proto3" json:"phase,omitempty"`
+	LockersTotal         int64    `protobuf:"varint,10,opt,name=lockers_total,json=lockersTotal,proto3" json:"lockers_total,omitempty"`
+	LockersDone          int64    `protobuf:"varint,11,opt,name=lockers_done,json=lockersDone,proto3" json:"lockers_done,omitempty"`
+	BlocksTotal          int64    `protobuf:"varint,12,opt,name=blocks_total,json=blocksTotal,proto3" json:"blocks_total,omitempty"`
+	BlocksDone           int64    `protobuf:"varint,13,opt,name=blocks_done,json=blocksDone,proto3" json:"blocks_done,omitempty"`
+	TuplesTotal          int64    `protobuf:"varint,14,opt,name=tuples_total,json=tuplesTotal,proto3" json:"tuples_total,omitempty"`
+	TuplesDone           int64    `protobuf:"varint,15,opt,name=tuples_done,json=tuplesDone,proto3" json:"tuples_done,omitempty"`
+	PartitionsTotal      int64    `protobuf:"varint,16,opt,name=partitions_total,json=partitionsTotal,proto3" json:"partitions_total,omitempty"`
+	PartitionsDone       int64    `protobuf:"varint,17,opt,name=partitions_done,json=partitionsDone,proto3" json:"partitions_done,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateIndexProgress) Reset()         { *m = CreateIndexProgress{} }
+func (m *CreateIndexProgress) String() string { return proto.CompactTextString(m) }
+func (*CreateIndexProgress) ProtoMessage()    {}
+func (*CreateIndexProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{27}
+}
+func (m *CreateIndexProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateIndexProgress.Unmarshal(m, b)
+}
+func (m *CreateIndexProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateIndexProgress.Marshal(b, m, deterministic)
+}
+func (dst *CreateIndexProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateIndexProgress.Merge(dst, src)
+}
+func (m *CreateIndexProgress) XXX_Size() int {
+	return xxx_messageInfo_CreateIndexProgress.Size(m)
+}
+func (m *CreateIndexProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateIndexProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateIndexProgress proto.InternalMessageInfo
+
+func (m *CreateIndexProgress) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *CreateIndexProgress) GetDatabaseIdx() int32 {
+	if m != nil {
+		return m.DatabaseIdx
+	}
+	return 0
+}
+
+func (m *CreateIndexProgress) GetHasDatabaseIdx() bool {
+	if m != nil {
+		return m.HasDatabaseIdx
+	}
+	return false
+}
+
+func (m *CreateIndexProgress) GetRelationIdx() int32 {
+	if m != nil {
+		return m.RelationIdx
+	}
+	return 0
+}
+
+func (m *CreateIndexProgress) GetHasRelationIdx() bool {
+	if m != nil {
+		return m.HasRelationIdx
+	}
+	return false
+}
+
+func (m *CreateIndexProgress) GetIndexIdx() int32 {
+	if m != nil {
+		return m.IndexIdx
+	}
+	return 0
+}
+
+func (m *CreateIndexProgress) GetHasIndexIdx() bool {
+	if m != nil {
+		return m.HasIndexIdx
+	}
+	return false
+}
+
+func (m *CreateIndexProgress) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *CreateIndexProgress) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *CreateIndexProgress) GetLockersTotal() int64 {
+	if m != nil {
+		return m.LockersTotal
+	}
+	return 0
+}
+
+func (m *CreateIndexProgress) GetLockersDone() int64 {
+	if m != nil {
+		return m.LockersDone
+	}
+	return 0
+}
+
+func (m *CreateIndexProgress) GetBlocksTotal() int64 {
+	if m != nil {
+		return m.BlocksTotal
+	}
+	return 0
+}
+
+func (m *CreateIndexProgress) GetBlocksDone() int64 {
+	if m != nil {
+		return m.BlocksDone
+	}
+	return 0
+}
+
+func (m *CreateIndexProgress) GetTuplesTotal() int64 {
+	if m != nil {
+		return m.TuplesTotal
+	}
+	return 0
+}
+
+func (m *CreateIndexProgress) GetTuplesDone() int64 {
+	if m != nil {
+		return m.TuplesDone
+	}
+	return 0
+}
+
+func (m *CreateIndexProgress) GetPartitionsTotal() int64 {
+	if m != nil {
+		return m.PartitionsTotal
+	}
+	return 0
+}
+
+func (m *CreateIndexProgress) GetPartitionsDone() int64 {
+	if m != nil {
+		return m.PartitionsDone
+	}
+	return 0
+}
+
+// Base backup that was being streamed at the time of the snapshot (pg_stat_progress_basebackup, Postgres 13+)
+type BaseBackupProgress struct {
+	Pid   int32  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Phase string `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	// Estimated total bytes (only valid if has_backup_total is set)
+	BackupTotal          int64    `protobuf:"varint,3,opt,name=backup_total,json=backupTotal,proto3" json:"backup_total,omitempty"`
+	HasBackupTotal       bool     `protobuf:"varint,4,opt,name=has_backup_total,json=hasBackupTotal,proto3" json:"has_backup_total,omitempty"`
+	BackupStreamed       int64    `protobuf:"varint,5,opt,name=backup_streamed,json=backupStreamed,proto3" json:"backup_streamed,omitempty"`
+	TablespacesTotal     int64    `protobuf:"varint,6,opt,name=tablespaces_total,json=tablespacesTotal,proto3" json:"tablespaces_total,omitempty"`
+	TablespacesStreamed  int64    `protobuf:"varint,7,opt,name=tablespaces_streamed,json=tablespacesStreamed,proto3" json:"tablespaces_streamed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BaseBackupProgress) Reset()         { *m = BaseBackupProgress{} }
+func (m *BaseBackupProgress) String() string { return proto.CompactTextString(m) }
+func (*BaseBackupProgress) ProtoMessage()    {}
+func (*BaseBackupProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{28}
+}
+func (m *BaseBackupProgress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BaseBackupProgress.Unmarshal(m, b)
+}
+func (m *BaseBackupProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BaseBackupProgress.Marshal(b, m, deterministic)
+}
+func (dst *BaseBackupProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BaseBackupProgress.Merge(dst, src)
+}
+func (m *BaseBackupProgress) XXX_Size() int {
+	return xxx_messageInfo_BaseBackupProgress.Size(m)
+}
+func (m *BaseBackupProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_BaseBackupProgress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BaseBackupProgress proto.InternalMessageInfo
+
+func (m *BaseBackupProgress) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *BaseBackupProgress) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *BaseBackupProgress) GetBackupTotal() int64 {
+	if m != nil {
+		return m.BackupTotal
+	}
+	return 0
+}
+
+func (m *BaseBackupProgress) GetHasBackupTotal() bool {
+	if m != nil {
+		return m.HasBackupTotal
+	}
+	return false
+}
+
+func (m *BaseBackupProgress) GetBackupStreamed() int64 {
+	if m != nil {
+		return m.BackupStreamed
+	}
+	return 0
+}
+
+func (m *BaseBackupProgress) GetTablespacesTotal() int64 {
+	if m != nil {
+		return m.TablespacesTotal
+	}
+	return 0
+}
+
+func (m *BaseBackupProgress) GetTablespacesStreamed() int64 {
+	if m != nil {
+		return m.TablespacesStreamed
+	}
+	return 0
+}
+
+// Background writer and checkpointer activity since the last snapshot
+type BgwriterStatistic struct {
+	CheckpointsTimed     int64 `protobuf:"varint,1,opt,name=checkpoints_timed,json=checkpointsTimed,proto3" json:"checkpoints_timed,omitempty"`
+	CheckpointsRequested int64 `protobuf:"varint,2,opt,name=checkpoints_requested,json=checkpointsRequested,proto3" json:"checkpoints_requested,omitempty"`
+	// In milliseconds
+	CheckpointWriteTime float64 `protobuf:"fixed64,3,opt,name=checkpoint_write_time,json=checkpointWriteTime,proto3" json:"checkpoint_write_time,omitempty"`
+	// In milliseconds
+	CheckpointSyncTime float64 `protobuf:"fixed64,4,opt,name=checkpoint_sync_time,json=checkpointSyncTime,proto3" json:"checkpoint_sync_time,omitempty"`
+	MaxwrittenClean    int64   `protobuf:"varint,5,opt,name=maxwritten_clean,json=maxwrittenClean,proto3" json:"maxwritten_clean,omitempty"`
+	// Always zero on Postgres 17+
+	BuffersBackendFsync        int64   `protobuf:"varint,6,opt,name=buffers_backend_fsync,json=buffersBackendFsync,proto3" json:"buffers_backend_fsync,omitempty"`
+	BuffersCheckpointPerSecond float64 `protobuf:"fixed64,7,opt,name=buffers_checkpoint_per_second,json=buffersCheckpointPerSecond,proto3" json:"buffers_checkpoint_per_second,omitempty"`
+	BuffersCleanPerSecond      float64 `protobuf:"fixed64,8,opt,name=buffers_clean_per_second,json=buffersCleanPerSecond,proto3" json:"buffers_clean_per_second,omitempty"`
+	// Always zero on Postgres 17+ (see io_statistics instead)
+	BuffersBackendPerSecond float64  `protobuf:"fixed64,9,opt,name=buffers_backend_per_second,json=buffersBackendPerSecond,proto3" json:"buffers_backend_per_second,omitempty"`
+	BuffersAllocPerSecond   float64  `protobuf:"fixed64,10,opt,name=buffers_alloc_per_second,json=buffersAllocPerSecond,proto3" json:"buffers_alloc_per_second,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
+}
+
+func (m *BgwriterStatistic) Reset()         { *m = BgwriterStatistic{} }
+func (m *BgwriterStatistic) String() string { return proto.CompactTextString(m) }
+func (*BgwriterStatistic) ProtoMessage()    {}
+func (*BgwriterStatistic) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{29}
+}
+func (m *BgwriterStatistic) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BgwriterStatistic.Unmarshal(m, b)
+}
+func (m *BgwriterStatistic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BgwriterStatistic.Marshal(b, m, deterministic)
+}
+func (dst *BgwriterStatistic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BgwriterStatistic.Merge(dst, src)
+}
+func (m *BgwriterStatistic) XXX_Size() int {
+	return xxx_messageInfo_BgwriterStatistic.Size(m)
+}
+func (m *BgwriterStatistic) XXX_DiscardUnknown() {
+	xxx_messageInfo_BgwriterStatistic.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BgwriterStatistic proto.InternalMessageInfo
+
+func (m *BgwriterStatistic) GetCheckpointsTimed() int64 {
+	if m != nil {
+		return m.CheckpointsTimed
+	}
+	return 0
+}
+
+func (m *BgwriterStatistic) GetCheckpointsRequested() int64 {
+	if m != nil {
+		return m.CheckpointsRequested
+	}
+	return 0
+}
+
+func (m *BgwriterStatistic) GetCheckpointWriteTime() float64 {
+	if m != nil {
+		return m.CheckpointWriteTime
+	}
+	return 0
+}
+
+func (m *BgwriterStatistic) GetCheckpointSyncTime() float64 {
+	if m != nil {
+		return m.CheckpointSyncTime
+	}
+	return 0
+}
+
+func (m *BgwriterStatistic) GetMaxwrittenClean() int64 {
+	if m != nil {
+		return m.MaxwrittenClean
+	}
+	return 0
+}
+
+func (m *BgwriterStatistic) GetBuffersBackendFsync() int64 {
+	if m != nil {
+		return m.BuffersBackendFsync
+	}
+	return 0
+}
+
+func (m *BgwriterStatistic) GetBuffersCheckpointPerSecond() float64 {
+	if m != nil {
+		return m.BuffersCheckpointPerSecond
+	}
+	return 0
+}
+
+func (m *BgwriterStatistic) GetBuffersCleanPerSecond() float64 {
+	if m != nil {
+		return m.BuffersCleanPerSecond
+	}
+	return 0
+}
+
+func (m *BgwriterStatistic) GetBuffersBackendPerSecond() float64 {
+	if m != nil {
+		return m.BuffersBackendPerSecond
+	}
+	return 0
+}
+
+func (m *BgwriterStatistic) GetBuffersAllocPerSecond() float64 {
+	if m != nil {
+		return m.BuffersAllocPerSecond
+	}
+	return 0
+}
+
+// Logical replication slot on the primary (Postgres 9.4+)
+type LogicalReplicationSlot struct {
+	SlotName       string `protobuf:"bytes,1,opt,name=slot_name,json=slotName,proto3" json:"slot_name,omitempty"`
+	Plugin         string `protobuf:"bytes,2,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	DatabaseIdx    int32  `protobuf:"varint,3,opt,name=database_idx,json=databaseIdx,proto3" json:"database_idx,omitempty"`
+	HasDatabaseIdx bool   `protobuf:"varint,4,opt,name=has_database_idx,json=hasDatabaseIdx,proto3" json:"has_database_idx,omitempty"`
+	Active         bool   `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"`
+	// Empty if not known
+	RestartLsn string `protobuf:"bytes,6,opt,name=restart_lsn,json=restartLsn,proto3" json:"restart_lsn,omitempty"`
+	// Empty if not known (always empty before Postgres 9.6)
+	ConfirmedFlushLsn string `protobuf:"bytes,7,opt,name=confirmed_flush_lsn,json=confirmedFlushLsn,proto3" json:"confirmed_flush_lsn,omitempty"`
+	// WAL retained for this slot, in bytes behind the current WAL position
+	RestartByteLag    int64 `protobuf:"varint,8,opt,name=restart_byte_lag,json=restartByteLag,proto3" json:"restart_byte_lag,omitempty"`
+	HasRestartByteLag bool  `protobuf:"varint,9,opt,name=has_restart_byte_lag,json=hasRestartByteLag,proto3" json:"has_restart_byte_lag,omitempty"`
+	// WAL not yet confirmed by the consumer, in bytes behind the current WAL position
+	ConfirmedFlushByteLag    int64 `protobuf:"varint,10,opt,name=confirmed_flush_byte_lag,json=confirmedFlushByteLag,proto3" json:"confirmed_flush_byte_lag,omitempty"`
+	HasConfirmedFlushByteLag bool  `protobuf:"varint,11,opt,name=has_confirmed_flush_byte_lag,json=hasConfirmedFlushByteLag,proto3" json:"has_confirmed_flush_byte_lag,omitempty"`
+	// Change in confirmed flush lag since the last snapshot
+	ConfirmedFlushByteLagDelta    int64 `protobuf:"varint,12,opt,name=confirmed_flush_byte_lag_delta,json=confirmedFlushByteLagDelta,proto3" json:"confirmed_flush_byte_lag_delta,omitempty"`
+	HasConfirmedFlushByteLagDelta bool  `protobuf:"varint,13,opt,name=has_confirmed_flush_byte_lag_delta,json=hasConfirmedFlushByteLagDelta,proto3" json:"has_confirmed_flush_byte_lag_delta,omitempty"`
+	// Whether the slot did not advance since the last snapshot, while the server wrote more WAL
+	Stalled              bool     `protobuf:"varint,14,opt,name=stalled,proto3" json:"stalled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogicalReplicationSlot) Reset()         { *m = LogicalReplicationSlot{} }
+func (m *LogicalReplicationSlot) String() string { return proto.CompactTextString(m) }
+func (*LogicalReplicationSlot) ProtoMessage()    {}
+func (*LogicalReplicationSlot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{30}
+}
+func (m *LogicalReplicationSlot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogicalReplicationSlot.Unmarshal(m, b)
+}
+func (m *LogicalReplicationSlot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogicalReplicationSlot.Marshal(b, m, deterministic)
+}
+func (dst *LogicalReplicationSlot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogicalReplicationSlot.Merge(dst, src)
+}
+func (m *LogicalReplicationSlot) XXX_Size() int {
+	return xxx_messageInfo_LogicalReplicationSlot.Size(m)
+}
+func (m *LogicalReplicationSlot) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogicalReplicationSlot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogicalReplicationSlot proto.InternalMessageInfo
+
+func (m *LogicalReplicationSlot) GetSlotName() string {
+	if m != nil {
+		return m.SlotName
+	}
+	return ""
+}
+
+func (m *LogicalReplicationSlot) GetPlugin() string {
+	if m != nil {
+		return m.Plugin
+	}
+	return ""
+}
+
+func (m *LogicalReplicationSlot) GetDatabaseIdx() int32 {
+	if m != nil {
+		return m.DatabaseIdx
+	}
+	return 0
+}
+
+func (m *LogicalReplicationSlot) GetHasDatabaseIdx() bool {
+	if m != nil {
+		return m.HasDatabaseIdx
+	}
+	return false
+}
+
+func (m *LogicalReplicationSlot) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *LogicalReplicationSlot) GetRestartLsn() string {
+	if m != nil {
+		return m.RestartLsn
+	}
+	return ""
+}
+
+func (m *LogicalReplicationSlot) GetConfirmedFlushLsn() string {
+	if m != nil {
+		return m.ConfirmedFlushLsn
+	}
+	return ""
+}
+
+func (m *LogicalReplicationSlot) GetRestartByteLag() int64 {
+	if m != nil {
+		return m.RestartByteLag
+	}
+	return 0
+}
+
+func (m *LogicalReplicationSlot) GetHasRestartByteLag() bool {
+	if m != nil {
+		return m.HasRestartByteLag
+	}
+	return false
+}
+
+func (m *LogicalReplicationSlot) GetConfirmedFlushByteLag() int64 {
+	if m != nil {
+		return m.ConfirmedFlushByteLag
+	}
+	return 0
+}
+
+func (m *LogicalReplicationSlot) GetHasConfirmedFlushByteLag() bool {
+	if m != nil {
+		return m.HasConfirmedFlushByteLag
+	}
+	return false
+}
+
+func (m *LogicalReplicationSlot) GetConfirmedFlushByteLagDelta() int64 {
+	if m != nil {
+		return m.ConfirmedFlushByteLagDelta
+	}
+	return 0
+}
+
+func (m *LogicalReplicationSlot) GetHasConfirmedFlushByteLagDelta() bool {
+	if m != nil {
+		return m.HasConfirmedFlushByteLagDelta
+	}
+	return false
+}
+
+func (m *LogicalReplicationSlot) GetStalled() bool {
+	if m != nil {
+		return m.Stalled
+	}
+	return false
+}
+
+// Value returned by one of the custom metric queries configured for the server
+type CustomMetric struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                float64  `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CustomMetric) Reset()         { *m = CustomMetric{} }
+func (m *CustomMetric) String() string { return proto.CompactTextString(m) }
+func (*CustomMetric) ProtoMessage()    {}
+func (*CustomMetric) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{31}
+}
+func (m *CustomMetric) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CustomMetric.Unmarshal(m, b)
+}
+func (m *CustomMetric) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CustomMetric.Marshal(b, m, deterministic)
+}
+func (dst *CustomMetric) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomMetric.Merge(dst, src)
+}
+func (m *CustomMetric) XXX_Size() int {
+	return xxx_messageInfo_CustomMetric.Size(m)
+}
+func (m *CustomMetric) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomMetric.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CustomMetric proto.InternalMessageInfo
+
+func (m *CustomMetric) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CustomMetric) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+// Lock that a backend was waiting for at the time of the snapshot
+type LockWait struct {
+	Pid      int32  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	LockType string `protobuf:"bytes,2,opt,name=lock_type,json=lockType,proto3" json:"lock_type,omitempty"`
+	Mode     string `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Not set for locks on shared objects and those not related to a database
+	DatabaseIdx    int32 `protobuf:"varint,4,opt,name=database_idx,json=databaseIdx,proto3" json:"database_idx,omitempty"`
+	HasDatabaseIdx bool  `protobuf:"varint,5,opt,name=has_database_idx,json=hasDatabaseIdx,proto3" json:"has_database_idx,omitempty"`
+	// Only set for locks on relations of the monitored database
+	RelationIdx    int32 `protobuf:"varint,6,opt,name=relation_idx,json=relationIdx,proto3" json:"relation_idx,omitempty"`
+	HasRelationIdx bool  `protobuf:"varint,7,opt,name=has_relation_idx,json=hasRelationIdx,proto3" json:"has_relation_idx,omitempty"`
+	// Backends that block this one from getting the lock (as reported by pg_blocking_pids)
+	BlockingPids         []int32  `protobuf:"varint,8,rep,packed,name=blocking_pids,json=blockingPids,proto3" json:"blocking_pids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockWait) Reset()         { *m = LockWait{} }
+func (m *LockWait) String() string { return proto.CompactTextString(m) }
+func (*LockWait) ProtoMessage()    {}
+func (*LockWait) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{32}
+}
+func (m *LockWait) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockWait.Unmarshal(m, b)
+}
+func (m *LockWait) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockWait.Marshal(b, m, deterministic)
+}
+func (dst *LockWait) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockWait.Merge(dst, src)
+}
+func (m *LockWait) XXX_Size() int {
+	return xxx_messageInfo_LockWait.Size(m)
+}
+func (m *LockWait) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockWait.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockWait proto.InternalMessageInfo
+
+func (m *LockWait) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *LockWait) GetLockType() string {
+	if m != nil {
+		return m.LockType
+	}
+	return ""
+}
+
+func (m *LockWait) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *LockWait) GetDatabaseIdx() int32 {
+	if m != nil {
+		return m.DatabaseIdx
+	}
+	return 0
+}
+
+func (m *LockWait) GetHasDatabaseIdx() bool {
+	if m != nil {
+		return m.HasDatabaseIdx
+	}
+	return false
+}
+
+func (m *LockWait) GetRelationIdx() int32 {
+	if m != nil {
+		return m.RelationIdx
+	}
+	return 0
+}
+
+func (m *LockWait) GetHasRelationIdx() bool {
+	if m != nil {
+		return m.HasRelationIdx
+	}
+	return false
+}
+
+func (m *LockWait) GetBlockingPids() []int32 {
+	if m != nil {
+		return m.BlockingPids
+	}
+	return nil
+}
+
+// Backend in a blocking tree, together with the backends that are waiting for it
+type BlockingBackend struct {
+	Pid   int32  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	State string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	// Empty when the monitoring user lacks permissions
+	QueryText string `protobuf:"bytes,3,opt,name=query_text,json=queryText,proto3" json:"query_text,omitempty"`
+	// Lock this backend is waiting for (not set for the root of a tree)
+	LockWaitIdx    int32 `protobuf:"varint,4,opt,name=lock_wait_idx,json=lockWaitIdx,proto3" json:"lock_wait_idx,omitempty"`
+	HasLockWaitIdx bool  `protobuf:"varint,5,opt,name=has_lock_wait_idx,json=hasLockWaitIdx,proto3" json:"has_lock_wait_idx,omitempty"`
+	// Whether this backend already appears further up in the tree, i.e. the backends form a cycle
+	// (the backends it blocks are not repeated in that case)
+	Cycle                bool               `protobuf:"varint,6,opt,name=cycle,proto3" json:"cycle,omitempty"`
+	Blocked              []*BlockingBackend `protobuf:"bytes,7,rep,name=blocked,proto3" json:"blocked,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *BlockingBackend) Reset()         { *m = BlockingBackend{} }
+func (m *BlockingBackend) String() string { return proto.CompactTextString(m) }
+func (*BlockingBackend) ProtoMessage()    {}
+func (*BlockingBackend) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{33}
+}
+func (m *BlockingBackend) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockingBackend.Unmarshal(m, b)
+}
+func (m *BlockingBackend) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockingBackend.Marshal(b, m, deterministic)
+}
+func (dst *BlockingBackend) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockingBackend.Merge(dst, src)
+}
+func (m *BlockingBackend) XXX_Size() int {
+	return xxx_messageInfo_BlockingBackend.Size(m)
+}
+func (m *BlockingBackend) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockingBackend.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockingBackend proto.InternalMessageInfo
+
+func (m *BlockingBackend) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *BlockingBackend) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *BlockingBackend) GetQueryText() string {
+	if m != nil {
+		return m.QueryText
+	}
+	return ""
+}
+
+func (m *BlockingBackend) GetLockWaitIdx() int32 {
+	if m != nil {
+		return m.LockWaitIdx
+	}
+	return 0
+}
+
+func (m *BlockingBackend) GetHasLockWaitIdx() bool {
+	if m != nil {
+		return m.HasLockWaitIdx
+	}
+	return false
+}
+
+func (m *BlockingBackend) GetCycle() bool {
+	if m != nil {
+		return m.Cycle
+	}
+	return false
+}
+
+func (m *BlockingBackend) GetBlocked() []*BlockingBackend {
+	if m != nil {
+		return m.Blocked
+	}
+	return nil
+}
+
+// How close the databases and tables are to transaction ID wraparound, at the time of the snapshot
+type Wraparound struct {
+	// Value of autovacuum_freeze_max_age
+	FreezeMaxAge int64 `protobuf:"varint,1,opt,name=freeze_max_age,json=freezeMaxAge,proto3" json:"freeze_max_age,omitempty"`
+	// Ordered by how close they are to wraparound, oldest first
+	Databases []*DatabaseWraparound `protobuf:"bytes,2,rep,name=databases,proto3" json:"databases,omitempty"`
+	// Tables of the monitored database with the oldest unfrozen transaction IDs, oldest first
+	Tables               []*TableWraparound `protobuf:"bytes,3,rep,name=tables,proto3" json:"tables,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Wraparound) Reset()         { *m = Wraparound{} }
+func (m *Wraparound) String() string { return proto.CompactTextString(m) }
+func (*Wraparound) ProtoMessage()    {}
+func (*Wraparound) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{34}
+}
+func (m *Wraparound) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Wraparound.Unmarshal(m, b)
+}
+func (m *Wraparound) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Wraparound.Marshal(b, m, deterministic)
+}
+func (dst *Wraparound) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Wraparound.Merge(dst, src)
+}
+func (m *Wraparound) XXX_Size() int {
+	return xxx_messageInfo_Wraparound.Size(m)
+}
+func (m *Wraparound) XXX_DiscardUnknown() {
+	xxx_messageInfo_Wraparound.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Wraparound proto.InternalMessageInfo
+
+func (m *Wraparound) GetFreezeMaxAge() int64 {
+	if m != nil {
+		return m.FreezeMaxAge
+	}
+	return 0
+}
+
+func (m *Wraparound) GetDatabases() []*DatabaseWraparound {
+	if m != nil {
+		return m.Databases
+	}
+	return nil
+}
+
+func (m *Wraparound) GetTables() []*TableWraparound {
+	if m != nil {
+		return m.Tables
+	}
+	return nil
+}
+
+// Age of datfrozenxid for a database
+type DatabaseWraparound struct {
+	DatabaseIdx    int32 `protobuf:"varint,1,opt,name=database_idx,json=databaseIdx,proto3" json:"database_idx,omitempty"`
+	HasDatabaseIdx bool  `protobuf:"varint,2,opt,name=has_database_idx,json=hasDatabaseIdx,proto3" json:"has_database_idx,omitempty"`
+	XidAge         int64 `protobuf:"varint,3,opt,name=xid_age,json=xidAge,proto3" json:"xid_age,omitempty"`
+	// xid_age as a percentage of autovacuum_freeze_max_age
+	FreezeMaxAgePercent  float64        `protobuf:"fixed64,4,opt,name=freeze_max_age_percent,json=freezeMaxAgePercent,proto3" json:"freeze_max_age_percent,omitempty"`
+	Risk                 WraparoundRisk `protobuf:"varint,5,opt,name=risk,proto3,enum=pganalyze.collector.WraparoundRisk" json:"risk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *DatabaseWraparound) Reset()         { *m = DatabaseWraparound{} }
+func (m *DatabaseWraparound) String() string { return proto.CompactTextString(m) }
+func (*DatabaseWraparound) ProtoMessage()    {}
+func (*DatabaseWraparound) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{35}
+}
+func (m *DatabaseWraparound) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DatabaseWraparound.Unmarshal(m, b)
+}
+func (m *DatabaseWraparound) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DatabaseWraparound.Marshal(b, m, deterministic)
+}
+func (dst *DatabaseWraparound) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatabaseWraparound.Merge(dst, src)
+}
+func (m *DatabaseWraparound) XXX_Size() int {
+	return xxx_messageInfo_DatabaseWraparound.Size(m)
+}
+func (m *DatabaseWraparound) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatabaseWraparound.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatabaseWraparound proto.InternalMessageInfo
+
+func (m *DatabaseWraparound) GetDatabaseIdx() int32 {
+	if m != nil {
+		return m.DatabaseIdx
+	}
+	return 0
+}
+
+func (m *DatabaseWraparound) GetHasDatabaseIdx() bool {
+	if m != nil {
+		return m.HasDatabaseIdx
+	}
+	return false
+}
+
+func (m *DatabaseWraparound) GetXidAge() int64 {
+	if m != nil {
+		return m.XidAge
+	}
+	return 0
+}
+
+func (m *DatabaseWraparound) GetFreezeMaxAgePercent() float64 {
+	if m != nil {
+		return m.FreezeMaxAgePercent
+	}
+	return 0
+}
+
+func (m *DatabaseWraparound) GetRisk() WraparoundRisk {
+	if m != nil {
+		return m.Risk
+	}
+	return WraparoundRisk_WRAPAROUND_RISK_LOW
+}
+
+// Age of relfrozenxid for a table (including materialized views and TOAST tables)
+type TableWraparound struct {
+	SchemaName   string `protobuf:"bytes,1,opt,name=schema_name,json=schemaName,proto3" json:"schema_name,omitempty"`
+	RelationName string `protobuf:"bytes,2,opt,name=relation_name,json=relationName,proto3" json:"relation_name,omitempty"`
+	// Not set for TOAST tables and tables that are not collected otherwise
+	RelationIdx    int32 `protobuf:"varint,3,opt,name=relation_idx,json=relationIdx,proto3" json:"relation_idx,omitempty"`
+	HasRelationIdx bool  `protobuf:"varint,4,opt,name=has_relation_idx,json=hasRelationIdx,proto3" json:"has_relation_idx,omitempty"`
+	XidAge         int64 `protobuf:"varint,5,opt,name=xid_age,json=xidAge,proto3" json:"xid_age,omitempty"`
+	// xid_age as a percentage of autovacuum_freeze_max_age
+	FreezeMaxAgePercent  float64        `protobuf:"fixed64,6,opt,name=freeze_max_age_percent,json=freezeMaxAgePercent,proto3" json:"freeze_max_age_percent,omitempty"`
+	Risk                 WraparoundRisk `protobuf:"varint,7,opt,name=risk,proto3,enum=pganalyze.collector.WraparoundRisk" json:"risk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *TableWraparound) Reset()         { *m = TableWraparound{} }
+func (m *TableWraparound) String() string { return proto.CompactTextString(m) }
+func (*TableWraparound) ProtoMessage()    {}
+func (*TableWraparound) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{36}
+}
+func (m *TableWraparound) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TableWraparound.Unmarshal(m, b)
+}
+func (m *TableWraparound) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TableWraparound.Marshal(b, m, deterministic)
+}
+func (dst *TableWraparound) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TableWraparound.Merge(dst, src)
+}
+func (m *TableWraparound) XXX_Size() int {
+	return xxx_messageInfo_TableWraparound.Size(m)
+}
+func (m *TableWraparound) XXX_DiscardUnknown() {
+	xxx_messageInfo_TableWraparound.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TableWraparound proto.InternalMessageInfo
+
+func (m *TableWraparound) GetSchemaName() string {
+	if m != nil {
+		return m.SchemaName
+	}
+	return ""
+}
+
+func (m *TableWraparound) GetRelationName() string {
+	if m != nil {
+		return m.RelationName
+	}
+	return ""
+}
+
+func (m *TableWraparound) GetRelationIdx() int32 {
+	if m != nil {
+		return m.RelationIdx
+	}
+	return 0
+}
+
+func (m *TableWraparound) GetHasRelationIdx() bool {
+	if m != nil {
+		return m.HasRelationIdx
+	}
+	return false
+}
+
+func (m *TableWraparound) GetXidAge() int64 {
+	if m != nil {
+		return m.XidAge
+	}
+	return 0
+}
+
+func (m *TableWraparound) GetFreezeMaxAgePercent() float64 {
+	if m != nil {
+		return m.FreezeMaxAgePercent
+	}
+	return 0
+}
+
+func (m *TableWraparound) GetRisk() WraparoundRisk {
+	if m != nil {
+		return m.Risk
+	}
+	return WraparoundRisk_WRAPAROUND_RISK_LOW
+}
+
+// Change in the number of backends in a state since the last snapshot
+type BackendCountChange struct {
+	State                BackendCountStatistic_BackendState `protobuf:"varint,1,opt,name=state,proto3,enum=pganalyze.collector.BackendCountStatistic_BackendState" json:"state,omitempty"`
+	Change               int32                              `protobuf:"varint,2,opt,name=change,proto3" json:"change,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *BackendCountChange) Reset()         { *m = BackendCountChange{} }
+func (m *BackendCountChange) String() string { return proto.CompactTextString(m) }
+func (*BackendCountChange) ProtoMessage()    {}
+func (*BackendCountChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_full_snapshot_93c8c7110fd985f6, []int{37}
+}
+func (m *BackendCountChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackendCountChange.Unmarshal(m, b)
+}
+func (m *BackendCountChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackendCountChange.Marshal(b, m, deterministic)
+}
+func (dst *BackendCountChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackendCountChange.Merge(dst, src)
+}
+func (m *BackendCountChange) XXX_Size() int {
+	return xxx_messageInfo_BackendCountChange.Size(m)
+}
+func (m *BackendCountChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackendCountChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackendCountChange proto.InternalMessageInfo
+
+func (m *BackendCountChange) GetState() BackendCountStatistic_BackendState {
+	if m != nil {
+		return m.State
+	}
+	return BackendCountStatistic_UNKNOWN_STATE
+}
+
+func (m *BackendCountChange) GetChange() int32 {
+	if m != nil {
+		return m.Change
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*FullSnapshot)(nil), "pganalyze.collector.FullSnapshot")
+	proto.RegisterType((*CollectorStatistic)(nil), "pganalyze.collector.CollectorStatistic")
+	proto.RegisterType((*RoleInformation)(nil), "pganalyze.collector.RoleInformation")
+	proto.RegisterType((*DatabaseInformation)(nil), "pganalyze.collector.DatabaseInformation")
+	proto.RegisterType((*Setting)(nil), "pganalyze.collector.Setting")
+	proto.RegisterType((*Replication)(nil), "pganalyze.collector.Replication")
+	proto.RegisterType((*StandbyReference)(nil), "pganalyze.collector.StandbyReference")
+	proto.RegisterType((*StandbyInformation)(nil), "pganalyze.collector.StandbyInformation")
+	proto.RegisterType((*StandbyStatistic)(nil), "pganalyze.collector.StandbyStatistic")
+	proto.RegisterType((*BackendCountStatistic)(nil), "pganalyze.collector.BackendCountStatistic")
+	proto.RegisterType((*TablespaceReference)(nil), "pganalyze.collector.TablespaceReference")
+	proto.RegisterType((*TablespaceInformation)(nil), "pganalyze.collector.TablespaceInformation")
+	proto.RegisterType((*QueryStatistic)(nil), "pganalyze.collector.QueryStatistic")
+	proto.RegisterType((*HistoricQueryStatistics)(nil), "pganalyze.collector.HistoricQueryStatistics")
+	proto.RegisterType((*RelationInformation)(nil), "pganalyze.collector.RelationInformation")
+	proto.RegisterMapType((map[string]string)(nil), "pganalyze.collector.RelationInformation.OptionsEntry")
+	proto.RegisterType((*RelationInformation_Column)(nil), "pganalyze.collector.RelationInformation.Column")
+	proto.RegisterType((*RelationInformation_Constraint)(nil), "pganalyze.collector.RelationInformation.Constraint")
+	proto.RegisterType((*RelationStatistic)(nil), "pganalyze.collector.RelationStatistic")
+	proto.RegisterType((*RelationEvent)(nil), "pganalyze.collector.RelationEvent")
+	proto.RegisterType((*IndexInformation)(nil), "pganalyze.collector.IndexInformation")
+	proto.RegisterType((*IndexStatistic)(nil), "pganalyze.collector.IndexStatistic")
+	proto.RegisterType((*FunctionInformation)(nil), "pganalyze.collector.FunctionInformation")
+	proto.RegisterType((*FunctionStatistic)(nil), "pganalyze.collector.FunctionStatistic")
+	proto.RegisterType((*DisconnectedStandby)(nil), "pganalyze.collector.DisconnectedStandby")
+	proto.RegisterType((*DurationStatistic)(nil), "pganalyze.collector.DurationStatistic")
+	proto.RegisterType((*IOStatistic)(nil), "pganalyze.collector.IOStatistic")
+	proto.RegisterType((*WaitEventStatistic)(nil), "pganalyze.collector.WaitEventStatistic")
+	proto.RegisterType((*FunctionChange)(nil), "pganalyze.collector.FunctionChange")
+	proto.RegisterType((*VacuumProgress)(nil), "pganalyze.collector.VacuumProgress")
+	proto.RegisterType((*CreateIndexProgress)(nil), "pganalyze.collector.CreateIndexProgress")
+	proto.RegisterType((*BaseBackupProgress)(nil), "pganalyze.collector.BaseBackupProgress")
+	proto.RegisterType((*BgwriterStatistic)(nil), "pganalyze.collector.BgwriterStatistic")
+	proto.RegisterType((*LogicalReplicationSlot)(nil), "pganalyze.collector.LogicalReplicationSlot")
+	proto.RegisterType((*CustomMetric)(nil), "pganalyze.collector.CustomMetric")
+	proto.RegisterType((*LockWait)(nil), "pganalyze.collector.LockWait")
+	proto.RegisterType((*BlockingBackend)(nil), "pganalyze.collector.BlockingBackend")
+	proto.RegisterType((*Wraparound)(nil), "pganalyze.collector.Wraparound")
+	proto.RegisterType((*DatabaseWraparound)(nil), "pganalyze.collector.DatabaseWraparound")
+	proto.RegisterType((*TableWraparound)(nil), "pganalyze.collector.TableWraparound")
+	proto.RegisterType((*BackendCountChange)(nil), "pganalyze.collector.BackendCountChange")
+	proto.RegisterEnum("pganalyze.collector.WraparoundRisk", WraparoundRisk_name, WraparoundRisk_value)
+	proto.RegisterEnum("pganalyze.collector.BackendCountStatistic_BackendState", BackendCountStatistic_BackendState_name, BackendCountStatistic_BackendState_value)
+	proto.RegisterEnum("pganalyze.collector.BackendCountStatistic_BackendType", BackendCountStatistic_BackendType_name, BackendCountStatistic_BackendType_value)
+	proto.RegisterEnum("pganalyze.collector.RelationEvent_EventType", RelationEvent_EventType_name, RelationEvent_EventType_value)
+	proto.RegisterEnum("pganalyze.collector.FunctionChange_ChangeType", FunctionChange_ChangeType_name, FunctionChange_ChangeType_value)
+}
+
+func init() { proto.RegisterFile("full_snapshot.proto", fileDescriptor_full_snapshot_93c8c7110fd985f6) }
+
+var fileDescriptor_full_snapshot_93c8c7110fd985f6 = []byte{
+	// 6705 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x7b, 0xcb, 0x73, 0x24, 0xc7,
+	0x71, 0x37, 0x07, 0x83, 0xc7, 0x4c, 0xce, 0x13, 0x8d, 0xc7, 0xf6, 0xee, 0x72, 0x49, 0x70, 0x48,
+	0x91, 0x4b, 0x72, 0xb5, 0xfc, 0xbe, 0xa5, 0x45, 0xca, 0x92, 0x48, 0x69, 0x16, 0x33, 0xab, 0x05,
+	0x89, 0x05, 0xa0, 0xc6, 0x60, 0x57, 0x92, 0x65, 0x77, 0xf4, 0x74, 0xd7, 0x0c, 0x5a, 0xe8, 0xe9,
+	0x9e, 0xed, 0xea, 0xde, 0x05, 0x28, 0xdb, 0x92, 0xfc, 0x54, 0x84, 0x7d, 0xb2, 0x8e, 0x76, 0x84,
+	0xff, 0x00, 0x3b, 0xc2, 0x3e, 0xd9, 0xd6, 0xc1, 0x11, 0x3e, 0xfa, 0x71, 0xb3, 0x43, 0xb2, 0x0f,
+	0xb2, 0x24, 0x5b, 0xb6, 0xa5, 0x93, 0x0f, 0x3e, 0xdb, 0x11, 0x8e, 0xcc, 0xaa, 0xea, 0xc7, 0xcc,
+	0x00, 0x98, 0x75, 0xe8, 0x02, 0x4c, 0x65, 0xfe, 0x32, 0xbb, 0xba, 0x2a, 0x2b, 0x2b, 0x33, 0xab,
+	0x1a, 0xd6, 0x06, 0xb1, 0xe7, 0x99, 0xdc, 0xb7, 0xc6, 0xfc, 0x38, 0x88, 0x6e, 0x8f, 0xc3, 0x20,
+	0x0a, 0xb4, 0xb5, 0xf1, 0xd0, 0xf2, 0x2d, 0xef, 0xec, 0x23, 0x76, 0xdb, 0x0e, 0x3c, 0x8f, 0xd9,
+	0x51, 0x10, 0x5e, 0x7b, 0x71, 0x18, 0x04, 0x43, 0x8f, 0xbd, 0x45, 0x90, 0x7e, 0x3c, 0x78, 0x2b,
+	0x72, 0x47, 0x8c, 0x47, 0xd6, 0x68, 0x2c, 0xa4, 0xae, 0x55, 0xf9, 0xb1, 0x15, 0x32, 0x47, 0xb4,
+	0x5a, 0x7f, 0xb2, 0x05, 0xd5, 0x7b, 0xb1, 0xe7, 0x1d, 0x4a, 0xd5, 0xda, 0xcf, 0xc1, 0xa6, 0x7a,
+	0x8c, 0xf9, 0x84, 0x85, 0xdc, 0x0d, 0x7c, 0x73, 0x64, 0x7d, 0x35, 0x08, 0xf5, 0xc2, 0x56, 0xe1,
+	0xe6, 0x92, 0xb1, 0xae, 0xb8, 0x0f, 0x05, 0xf3, 0x01, 0xf2, 0x66, 0x4b, 0xb9, 0x7e, 0x10, 0xea,
+	0x0b, 0xb3, 0xa5, 0x90, 0xa7, 0xbd, 0x09, 0xab, 0x49, 0xc7, 0x95, 0x98, 0x5e, 0xdc, 0x2a, 0xdc,
+	0x2c, 0x1b, 0xcd, 0x84, 0x21, 0x25, 0xb4, 0x1b, 0x00, 0x03, 0xcb, 0xf5, 0x98, 0x63, 0x86, 0xb1,
+	0xaf, 0x2f, 0x6e, 0x15, 0x6e, 0x96, 0x8c, 0xb2, 0xa0, 0x18, 0xb1, 0xaf, 0xbd, 0x0c, 0xb5, 0xa4,
+	0x07, 0x71, 0xec, 0x3a, 0x3a, 0x90, 0x9e, 0xaa, 0x22, 0x1e, 0xc5, 0xae, 0xa3, 0xbd, 0x07, 0x55,
+	0xa9, 0x97, 0x39, 0xa6, 0x15, 0xe9, 0x95, 0xad, 0xc2, 0xcd, 0xca, 0x9d, 0x6b, 0xb7, 0xc5, 0x98,
+	0xdd, 0x56, 0x63, 0x76, 0xbb, 0xa7, 0xc6, 0xcc, 0xa8, 0x24, 0xf8, 0x76, 0xa4, 0xbd, 0x03, 0x57,
+	0x52, 0x71, 0xd7, 0x8f, 0x58, 0xf8, 0xc4, 0xf2, 0x4c, 0xce, 0x6c, 0xae, 0x57, 0xb7, 0x0a, 0x37,
+	0x6b, 0xc6, 0x46, 0xc2, 0xde, 0x91, 0xdc, 0x43, 0x66, 0x73, 0xed, 0x8b, 0xb0, 0x96, 0xbe, 0x27,
+	0x8f, 0xac, 0xc8, 0xe5, 0x91, 0x6b, 0xeb, 0xeb, 0xf4, 0xf4, 0xd7, 0x6e, 0xcf, 0x98, 0xc6, 0xdb,
+	0xdb, 0xea, 0xd7, 0xa1, 0x82, 0x1b, 0x9a, 0x3d, 0x45, 0xd3, 0x5e, 0x87, 0x74, 0xa0, 0x4c, 0x16,
+	0x86, 0x41, 0xc8, 0xf5, 0x8d, 0xad, 0xe2, 0xcd, 0xb2, 0xd1, 0x48, 0xe8, 0x5d, 0x22, 0x6b, 0x6f,
+	0xc3, 0x32, 0x3f, 0xe3, 0x11, 0x1b, 0xe9, 0x0e, 0x3d, 0xf7, 0xfa, 0xcc, 0xe7, 0x1e, 0x12, 0xc4,
+	0x90, 0x50, 0x6d, 0x1f, 0x9a, 0xe3, 0x80, 0x47, 0xc3, 0x90, 0xf1, 0x64, 0x82, 0x18, 0x89, 0xbf,
+	0x32, 0x53, 0xfc, 0x40, 0x82, 0xe5, 0xa4, 0x19, 0x8d, 0x71, 0x9e, 0xa0, 0x7d, 0x08, 0x8d, 0x30,
+	0xf0, 0x98, 0x19, 0xb2, 0x01, 0x0b, 0x99, 0x6f, 0x33, 0xae, 0x0f, 0xb6, 0x8a, 0x37, 0x2b, 0x77,
+	0x5a, 0x33, 0xf5, 0x19, 0x81, 0xc7, 0x0c, 0x05, 0x35, 0xea, 0x61, 0xb6, 0xc9, 0xb5, 0x47, 0xb0,
+	0xe6, 0x58, 0x91, 0xd5, 0xb7, 0x78, 0x4e, 0xe1, 0x90, 0x14, 0xbe, 0x3a, 0x53, 0x61, 0x47, 0xe2,
+	0x53, 0xa5, 0x9a, 0x33, 0x49, 0xe2, 0xda, 0x17, 0x60, 0x95, 0x7a, 0xe9, 0xfa, 0x83, 0x20, 0x1c,
+	0x59, 0x91, 0x1b, 0xf8, 0x5c, 0xf7, 0xb7, 0x8a, 0xe7, 0xbe, 0x37, 0xf6, 0x73, 0x27, 0x05, 0x1b,
+	0xcd, 0x30, 0x4f, 0xe0, 0xda, 0x2f, 0xc2, 0x46, 0xd2, 0xd7, 0x9c, 0xda, 0x80, 0xd4, 0xde, 0xbc,
+	0xb0, 0xb7, 0x59, 0xd5, 0xeb, 0xce, 0x34, 0x91, 0x6b, 0x9f, 0x84, 0x12, 0x67, 0x51, 0xe4, 0xfa,
+	0x43, 0xae, 0x7f, 0x44, 0x1a, 0x9f, 0x9f, 0x3d, 0xbf, 0x02, 0x64, 0x24, 0x68, 0xed, 0x2e, 0x54,
+	0x42, 0x36, 0xf6, 0x5c, 0x9b, 0x34, 0xe9, 0x5f, 0xa3, 0xd9, 0xdd, 0x9a, 0xfd, 0x96, 0x29, 0xce,
+	0xc8, 0x0a, 0x69, 0x0e, 0xe8, 0x7d, 0xcb, 0x3e, 0x61, 0xbe, 0x63, 0xda, 0x41, 0xec, 0x47, 0xa9,
+	0x91, 0x73, 0xfd, 0x97, 0xa9, 0x37, 0x6f, 0xcc, 0x54, 0x78, 0x57, 0x08, 0x6d, 0xa3, 0x4c, 0x6a,
+	0xe8, 0x9b, 0xfd, 0x59, 0x64, 0xae, 0xfd, 0x12, 0x6c, 0x44, 0x56, 0xdf, 0x63, 0x7c, 0x6c, 0xd9,
+	0xb9, 0x09, 0xff, 0xb5, 0xc2, 0x05, 0x63, 0xd8, 0x4b, 0x44, 0xd2, 0x39, 0x5f, 0x8f, 0xa6, 0x89,
+	0x5c, 0x73, 0xe0, 0x4a, 0x46, 0x7f, 0x6e, 0x92, 0x7e, 0xbd, 0x70, 0xc1, 0x5b, 0xa4, 0x4f, 0xc8,
+	0xce, 0xd3, 0x66, 0x34, 0x8b, 0xcc, 0x71, 0x49, 0x3d, 0x8e, 0x59, 0x78, 0x96, 0x7d, 0x81, 0xbf,
+	0x16, 0xea, 0x5f, 0x9e, 0xa9, 0xfe, 0x0b, 0x88, 0x4e, 0xfb, 0xde, 0x78, 0x9c, 0x6b, 0x93, 0x77,
+	0x09, 0x99, 0x47, 0xda, 0xb3, 0x3a, 0xff, 0xa6, 0x70, 0xc1, 0x32, 0x30, 0xa4, 0x40, 0x66, 0x19,
+	0x84, 0x93, 0x24, 0xea, 0xaa, 0xeb, 0x3b, 0xec, 0x34, 0xab, 0xf6, 0x6f, 0x2f, 0xea, 0xea, 0x0e,
+	0xa2, 0x33, 0x5d, 0x75, 0x73, 0x6d, 0xea, 0xea, 0x20, 0xf6, 0xed, 0xc9, 0xae, 0xfe, 0xdd, 0x45,
+	0x5d, 0xbd, 0x27, 0x05, 0x32, 0x5d, 0x1d, 0x4c, 0x92, 0xb8, 0x76, 0x04, 0x9a, 0x18, 0xd5, 0xdc,
+	0xb4, 0xfd, 0xbd, 0x50, 0xfc, 0xb1, 0xf3, 0xc7, 0x35, 0x3b, 0x63, 0xab, 0x8f, 0x27, 0x28, 0x99,
+	0xc9, 0xca, 0x18, 0xf4, 0x3f, 0x5c, 0x3a, 0x59, 0xa9, 0x29, 0x37, 0x1e, 0xe7, 0xda, 0x5c, 0x73,
+	0xe1, 0xea, 0xb1, 0xcb, 0xa3, 0x20, 0x74, 0x6d, 0x73, 0x4a, 0xf3, 0x77, 0x85, 0xe6, 0x5b, 0x33,
+	0x35, 0xdf, 0x97, 0x62, 0xf9, 0x27, 0x70, 0xe3, 0xca, 0xf1, 0x6c, 0x86, 0xd6, 0x83, 0xba, 0x78,
+	0x02, 0x3b, 0x1d, 0x7b, 0x96, 0xeb, 0x73, 0xfd, 0x7b, 0x17, 0xe9, 0x27, 0xf1, 0xae, 0x80, 0x66,
+	0x47, 0xa5, 0xf6, 0x38, 0xc3, 0xa0, 0x45, 0x98, 0x58, 0x5b, 0x6e, 0xac, 0xbf, 0x7f, 0xd1, 0x22,
+	0x54, 0xf6, 0x96, 0x73, 0x64, 0xe1, 0x34, 0x31, 0x6f, 0xcd, 0x99, 0xa1, 0xf9, 0xe7, 0x79, 0xac,
+	0x39, 0xb3, 0x57, 0x86, 0x93, 0x24, 0xae, 0xed, 0x42, 0x23, 0xd1, 0xcc, 0x9e, 0x30, 0x3f, 0xe2,
+	0xfa, 0x0f, 0x0b, 0x17, 0xed, 0x3d, 0x12, 0xdc, 0x45, 0xac, 0x51, 0x0f, 0xb3, 0x4d, 0x32, 0x38,
+	0xb1, 0x36, 0x72, 0x83, 0xf0, 0xa3, 0x8b, 0x0c, 0x8e, 0x56, 0x47, 0xce, 0xe0, 0xdc, 0x09, 0x4a,
+	0x66, 0xc9, 0x65, 0xde, 0xfd, 0x5f, 0x2e, 0x5d, 0x72, 0x19, 0x83, 0x73, 0x73, 0x6d, 0x9a, 0xaf,
+	0x64, 0xc9, 0xe5, 0xba, 0xfa, 0xe3, 0x8b, 0xe6, 0x4b, 0x2d, 0xba, 0xdc, 0x7c, 0x0d, 0xa6, 0x89,
+	0xf9, 0x25, 0x9d, 0xe9, 0xf3, 0xbf, 0xcd, 0xb3, 0xa4, 0x33, 0xf3, 0x35, 0x98, 0x24, 0x71, 0xed,
+	0x3e, 0x68, 0x7d, 0x2f, 0xb0, 0x22, 0x33, 0x17, 0xb2, 0xd5, 0x2e, 0x0d, 0xd9, 0x9a, 0x24, 0xb5,
+	0x9d, 0x89, 0xdb, 0xba, 0x50, 0x73, 0x83, 0x6c, 0xef, 0x7e, 0x65, 0xab, 0x78, 0xee, 0x26, 0xb7,
+	0xb3, 0x9f, 0x76, 0xab, 0xea, 0x06, 0x99, 0x0e, 0xed, 0xc0, 0x4b, 0x33, 0x4c, 0x73, 0x22, 0x10,
+	0xac, 0x53, 0x20, 0xf8, 0xc2, 0xb4, 0xfd, 0xe5, 0x22, 0xc2, 0x4f, 0xc0, 0xe6, 0xe4, 0xea, 0x37,
+	0x43, 0xc6, 0x59, 0xa4, 0xff, 0x63, 0x81, 0x22, 0xdb, 0xf5, 0x09, 0xc7, 0x61, 0x20, 0x53, 0xfb,
+	0x05, 0xd8, 0x78, 0x6a, 0xb9, 0x91, 0x30, 0xdf, 0xec, 0x0b, 0xfd, 0xea, 0x56, 0xf1, 0xdc, 0x50,
+	0xf2, 0x91, 0xe5, 0x46, 0x64, 0xb4, 0xe9, 0x7b, 0xad, 0x3d, 0x9d, 0xa2, 0x61, 0x9f, 0xae, 0x64,
+	0x95, 0x5b, 0xa3, 0xb1, 0xc7, 0xc4, 0x76, 0xae, 0x7f, 0x5d, 0x04, 0xf1, 0xa9, 0x14, 0x31, 0x69,
+	0x7f, 0x46, 0x8b, 0x4d, 0x0c, 0xc0, 0x3e, 0xb6, 0xfc, 0x21, 0xe3, 0xfa, 0xbf, 0x5f, 0x64, 0xb1,
+	0x6a, 0xf6, 0xb7, 0x09, 0x6c, 0x34, 0x06, 0xb9, 0x36, 0xd7, 0x3a, 0xf0, 0xc2, 0xd4, 0xd8, 0xe4,
+	0xc7, 0xf8, 0x9f, 0x0a, 0x34, 0xc8, 0xd7, 0x27, 0xc6, 0x28, 0x37, 0xc2, 0xb7, 0x60, 0x31, 0xb2,
+	0x86, 0x5c, 0xdf, 0xa4, 0x9e, 0xe8, 0xe7, 0x6c, 0xdc, 0x43, 0x83, 0x50, 0xda, 0x03, 0x68, 0x3c,
+	0xb1, 0xec, 0x38, 0x1e, 0x99, 0xe3, 0x30, 0xc0, 0x78, 0x95, 0xeb, 0xff, 0x71, 0xd1, 0x3b, 0x3c,
+	0x24, 0xf0, 0x81, 0xc4, 0x1a, 0xf5, 0x27, 0xb9, 0x36, 0x06, 0x7b, 0x76, 0xc8, 0xac, 0x88, 0x99,
+	0x62, 0x31, 0x27, 0x4a, 0x7f, 0x72, 0xd1, 0xa2, 0xdb, 0x26, 0x11, 0x5a, 0xd0, 0x89, 0xe6, 0x35,
+	0x7b, 0x9a, 0xa8, 0x7d, 0x19, 0xd6, 0x29, 0x8e, 0xc4, 0x38, 0x29, 0x1e, 0xa7, 0xda, 0x7f, 0x5a,
+	0xb8, 0xc0, 0x0c, 0xee, 0x5a, 0x9c, 0xdd, 0x25, 0x81, 0x44, 0xb9, 0xd6, 0x9f, 0xa2, 0x69, 0x0f,
+	0x41, 0xeb, 0x0f, 0x9f, 0x86, 0x6e, 0xc4, 0xb2, 0xa9, 0xca, 0x37, 0x0a, 0x5b, 0x85, 0x73, 0x97,
+	0xf3, 0x5d, 0x89, 0x4f, 0xed, 0x6b, 0xb5, 0x3f, 0x49, 0xd2, 0x76, 0xa0, 0x6e, 0xc7, 0x3c, 0x0a,
+	0x46, 0xe6, 0x88, 0x45, 0x21, 0xda, 0xec, 0x37, 0x45, 0x6f, 0x5f, 0x9a, 0x3d, 0x16, 0x84, 0x7d,
+	0x40, 0x50, 0xa3, 0x66, 0x67, 0x5a, 0x98, 0xc9, 0x6c, 0xa8, 0xe8, 0x55, 0x5a, 0x9c, 0x63, 0x06,
+	0xbe, 0x77, 0xa6, 0xff, 0x86, 0x58, 0x3b, 0x6b, 0x8a, 0x2b, 0x2c, 0xca, 0xd9, 0xf7, 0xbd, 0x33,
+	0xed, 0x3d, 0x00, 0x2f, 0xb0, 0x4f, 0x4c, 0xb4, 0x61, 0xae, 0xff, 0xa6, 0x78, 0xf6, 0x8d, 0x99,
+	0xcf, 0xde, 0x0d, 0xec, 0x13, 0x5c, 0x34, 0x46, 0xd9, 0x93, 0xbf, 0x70, 0xf3, 0xa8, 0xf7, 0xb1,
+	0xe5, 0xfa, 0x43, 0x33, 0x0a, 0x19, 0xe3, 0xfa, 0x6f, 0x15, 0x2e, 0xc8, 0x07, 0xee, 0x4a, 0xac,
+	0x0c, 0x70, 0x8d, 0x9a, 0x12, 0xee, 0xa1, 0xac, 0xf6, 0x39, 0x80, 0xa7, 0xa1, 0x35, 0xb6, 0xc2,
+	0x20, 0xf6, 0x1d, 0xfd, 0xb7, 0xc5, 0xe0, 0xbe, 0x38, 0x7b, 0xf5, 0x26, 0x38, 0x23, 0x23, 0xa3,
+	0x7d, 0x05, 0x36, 0xf2, 0x11, 0xb7, 0x5a, 0x7a, 0xdf, 0xba, 0xd8, 0x06, 0xd2, 0xc0, 0x5a, 0x2e,
+	0xbf, 0xb5, 0xfe, 0x14, 0x8d, 0x7f, 0xb0, 0x58, 0x3a, 0x6d, 0x9e, 0x7d, 0xb0, 0x58, 0x3a, 0x6b,
+	0x7e, 0xf4, 0xc1, 0x72, 0xe9, 0x07, 0x85, 0xe6, 0x0f, 0x0b, 0x1f, 0x2c, 0x97, 0xfe, 0xb5, 0xd0,
+	0xfc, 0x71, 0xa1, 0xf5, 0xa3, 0x15, 0xd0, 0xa6, 0xb3, 0x53, 0x4c, 0xcf, 0x87, 0x41, 0x92, 0x23,
+	0x8a, 0xe4, 0xbb, 0x3c, 0x0c, 0x54, 0xde, 0xf7, 0x1e, 0x5c, 0x1f, 0xb1, 0x51, 0x10, 0x9e, 0x99,
+	0xc7, 0xcc, 0x1a, 0x9b, 0x96, 0xe7, 0x05, 0xb6, 0x85, 0x2e, 0xbd, 0x7f, 0x16, 0x31, 0x4e, 0x5e,
+	0x7d, 0xd1, 0xd0, 0x05, 0xe4, 0x3e, 0xb3, 0xc6, 0x6d, 0x05, 0xb8, 0x8b, 0x7c, 0xed, 0x36, 0xac,
+	0x65, 0xc5, 0x83, 0xfe, 0x57, 0x99, 0x1d, 0x09, 0x67, 0xbb, 0x68, 0xac, 0xa6, 0x62, 0xfb, 0x82,
+	0x91, 0xc1, 0x8b, 0x44, 0x56, 0x3e, 0xa6, 0x91, 0xc5, 0x8b, 0x54, 0x57, 0xe8, 0xbf, 0x09, 0x4d,
+	0x89, 0x0f, 0x39, 0x97, 0xe0, 0x26, 0x81, 0xeb, 0x82, 0x6e, 0x70, 0x2e, 0x90, 0x6f, 0xc2, 0xaa,
+	0x65, 0x47, 0xee, 0x13, 0x66, 0x0e, 0x83, 0x30, 0x88, 0x23, 0xd7, 0x67, 0x9c, 0x32, 0xf9, 0x25,
+	0xa3, 0x29, 0x18, 0x9f, 0x4f, 0xe8, 0xda, 0x75, 0x28, 0xdb, 0xc3, 0xc0, 0xb4, 0x2d, 0xcf, 0xe3,
+	0xfa, 0x0b, 0x5b, 0x85, 0x9b, 0x45, 0xa3, 0x64, 0x0f, 0x83, 0x6d, 0x6c, 0x6b, 0xb7, 0x40, 0xf3,
+	0x82, 0xa1, 0xe9, 0x21, 0xd2, 0xe4, 0x91, 0x1b, 0xd9, 0xc7, 0xcc, 0xd1, 0x6f, 0x12, 0xaa, 0xe9,
+	0x05, 0xc3, 0x5d, 0x64, 0x1c, 0x4a, 0xba, 0xf6, 0x06, 0xac, 0xa6, 0x68, 0x27, 0x0c, 0xc6, 0x63,
+	0xe6, 0xe8, 0xaf, 0x13, 0xb8, 0xa1, 0xc0, 0x1d, 0x41, 0xce, 0x6b, 0x1e, 0xb8, 0x5e, 0xc4, 0x42,
+	0xe6, 0xe8, 0x6f, 0xe4, 0x35, 0xdf, 0x93, 0x74, 0xed, 0x0e, 0x6c, 0xa4, 0xe8, 0xd8, 0x1f, 0x5b,
+	0x21, 0x67, 0x98, 0xba, 0xe8, 0x6f, 0x92, 0xc0, 0x9a, 0x12, 0x38, 0x4a, 0x59, 0xda, 0xff, 0x83,
+	0xf5, 0x54, 0x26, 0x78, 0xc2, 0xc2, 0x81, 0x17, 0x3c, 0x65, 0x8e, 0x7e, 0x8b, 0x44, 0x34, 0x25,
+	0xb2, 0x9f, 0x70, 0xf0, 0x29, 0xd2, 0xab, 0xd3, 0xde, 0x91, 0xbe, 0xc3, 0xc7, 0xc5, 0x53, 0x84,
+	0x2f, 0x17, 0xbc, 0xcc, 0x7b, 0xc4, 0x63, 0x2f, 0xb0, 0x1c, 0xe6, 0x98, 0xf8, 0x38, 0x31, 0x2f,
+	0x77, 0xc4, 0x7b, 0x28, 0xce, 0x6e, 0x30, 0x14, 0x33, 0xf3, 0x0e, 0x5c, 0x49, 0xd0, 0x49, 0x29,
+	0x48, 0x88, 0xbc, 0x4d, 0x22, 0x1b, 0x8a, 0xad, 0x8a, 0x5d, 0x42, 0xee, 0x2b, 0xb0, 0x89, 0xca,
+	0xc5, 0x0c, 0xe0, 0xfa, 0x76, 0xe2, 0x50, 0xe4, 0xc2, 0x9f, 0xb9, 0xc0, 0xe9, 0x75, 0x24, 0x28,
+	0x75, 0x7a, 0x38, 0x22, 0x87, 0x4a, 0x89, 0x62, 0x6b, 0x5f, 0x16, 0xa3, 0x4b, 0x0a, 0xb8, 0xcb,
+	0x53, 0xe5, 0xef, 0x3d, 0x93, 0x72, 0x9c, 0x85, 0xb6, 0xd4, 0x91, 0xe8, 0x7e, 0x08, 0x48, 0x36,
+	0xc5, 0x6b, 0xa5, 0x9a, 0xdf, 0x7f, 0x26, 0xcd, 0x68, 0x56, 0x47, 0xa4, 0x41, 0xf1, 0x5a, 0x7f,
+	0x5a, 0x84, 0xc6, 0x44, 0x45, 0x43, 0xbb, 0x0a, 0x25, 0x51, 0x12, 0x71, 0x4e, 0x65, 0x25, 0x70,
+	0x05, 0xdb, 0x3b, 0xce, 0xa9, 0xa6, 0xc3, 0x8a, 0xeb, 0x1f, 0xb3, 0xd0, 0x8d, 0xa8, 0xda, 0x57,
+	0x32, 0x54, 0x53, 0x5b, 0x87, 0x25, 0x2f, 0x18, 0xba, 0xa2, 0xa8, 0x57, 0x32, 0x44, 0x83, 0x56,
+	0x85, 0xd8, 0x1d, 0x9d, 0xbe, 0x2c, 0xe4, 0x95, 0x04, 0xa1, 0xd3, 0xd7, 0x5e, 0x84, 0x8a, 0x64,
+	0xa2, 0x7a, 0x7d, 0x89, 0xd8, 0x20, 0x48, 0xd8, 0x27, 0x74, 0x34, 0x3c, 0x1e, 0xb3, 0xd0, 0x8c,
+	0x39, 0x0b, 0xf5, 0x65, 0xe2, 0x97, 0x89, 0x72, 0xc4, 0x59, 0xa8, 0x6d, 0xe5, 0xcb, 0x19, 0x2b,
+	0xc4, 0xcf, 0x92, 0x50, 0x41, 0xff, 0x6c, 0x6c, 0x71, 0x6e, 0x86, 0x1e, 0xd7, 0x4b, 0x42, 0x81,
+	0xa0, 0x18, 0x1e, 0x17, 0x25, 0x35, 0xdf, 0x67, 0x22, 0xa2, 0xf1, 0xdc, 0x91, 0x1b, 0xe9, 0x65,
+	0x7a, 0xe1, 0x46, 0x4a, 0xdf, 0x45, 0xb2, 0xd6, 0x83, 0x75, 0x94, 0x7a, 0x1a, 0x84, 0x8e, 0xf9,
+	0xc4, 0xf2, 0x5c, 0xc7, 0x8c, 0xfd, 0xc8, 0xf5, 0xc8, 0xfb, 0x9d, 0x97, 0x55, 0xec, 0xc5, 0x9e,
+	0x97, 0xc6, 0xaa, 0x9a, 0x92, 0x7f, 0x88, 0xe2, 0x47, 0x28, 0xad, 0x6d, 0xc2, 0xb2, 0x1d, 0xf8,
+	0x03, 0x77, 0xa8, 0x57, 0xa8, 0x92, 0x27, 0x5b, 0x38, 0x6c, 0x23, 0x36, 0xea, 0xb3, 0xd0, 0x0c,
+	0x06, 0x7a, 0x75, 0xab, 0x78, 0x73, 0xc9, 0x28, 0x09, 0xc2, 0xfe, 0xa0, 0xf5, 0x17, 0x45, 0x58,
+	0x9b, 0x51, 0x2d, 0xd2, 0x5e, 0x82, 0x6a, 0x5a, 0x76, 0x4a, 0xa6, 0xae, 0xa2, 0x68, 0x38, 0x7d,
+	0xaf, 0x40, 0x3d, 0x78, 0xea, 0xb3, 0xd0, 0x4c, 0xe6, 0x57, 0xd4, 0x6c, 0xab, 0x44, 0x35, 0xe4,
+	0x24, 0x5f, 0x83, 0x12, 0xf3, 0xed, 0xc0, 0x71, 0xfd, 0xa1, 0x2c, 0xd1, 0x26, 0x6d, 0x34, 0x00,
+	0x7c, 0x41, 0x2b, 0x62, 0x34, 0x9d, 0x65, 0x43, 0x35, 0xb5, 0x0d, 0x58, 0xb6, 0xcd, 0xe8, 0x6c,
+	0x2c, 0x26, 0xb2, 0x6c, 0x2c, 0xd9, 0xbd, 0xb3, 0x31, 0xc3, 0x49, 0x76, 0xb9, 0x19, 0xb1, 0xd1,
+	0x98, 0x84, 0xc4, 0x24, 0x82, 0xcb, 0x7b, 0x92, 0x42, 0x5e, 0xd6, 0xf3, 0x82, 0xa7, 0x66, 0x3a,
+	0xe4, 0x5c, 0xce, 0x65, 0x93, 0x18, 0xdb, 0x29, 0x7d, 0xe6, 0x8c, 0x95, 0x66, 0xcf, 0x18, 0x16,
+	0x91, 0xc3, 0xe0, 0x23, 0xe6, 0x9b, 0xa7, 0xae, 0x43, 0xd3, 0x5a, 0x33, 0xca, 0x82, 0xf2, 0x45,
+	0x97, 0x9c, 0xd4, 0xc8, 0xf5, 0xdd, 0x51, 0x3c, 0x32, 0x47, 0xb1, 0x17, 0xb9, 0xa7, 0x96, 0x1d,
+	0x11, 0x12, 0x08, 0xb9, 0x26, 0x99, 0x0f, 0x14, 0x0f, 0x65, 0x3e, 0x0b, 0xcf, 0xa7, 0x09, 0x0a,
+	0x6e, 0x5a, 0x9e, 0x69, 0x5b, 0x91, 0x85, 0x0b, 0x13, 0x47, 0x99, 0x6a, 0xcc, 0x25, 0xe3, 0x6a,
+	0x82, 0xd9, 0x45, 0xc8, 0xb6, 0x40, 0xe0, 0x8c, 0xb5, 0xbe, 0xbd, 0x08, 0x2b, 0xb2, 0x2c, 0xa7,
+	0x69, 0xb0, 0xe8, 0x5b, 0x23, 0x46, 0xd3, 0x54, 0x36, 0xe8, 0x37, 0x56, 0xb6, 0xed, 0x38, 0x0c,
+	0x31, 0x28, 0x7f, 0x62, 0x79, 0x31, 0xa3, 0xe9, 0x29, 0x1b, 0x55, 0x49, 0x7c, 0x88, 0x34, 0xed,
+	0x6d, 0x58, 0x8c, 0x7d, 0x37, 0xd2, 0x8b, 0x17, 0x84, 0x12, 0x68, 0x7a, 0x87, 0x51, 0x88, 0xe5,
+	0x3f, 0x02, 0x6b, 0xef, 0x03, 0xf4, 0x83, 0x40, 0xa9, 0x5d, 0x9c, 0x4f, 0xb4, 0x8c, 0x22, 0xe2,
+	0xa1, 0x9f, 0xc3, 0xb5, 0xc6, 0x99, 0x52, 0xb0, 0x34, 0x9f, 0x02, 0x20, 0x19, 0xa1, 0xe1, 0x5d,
+	0x58, 0xe6, 0x41, 0x1c, 0xda, 0xc2, 0x06, 0xe6, 0x10, 0x96, 0x70, 0x7c, 0xb4, 0xf8, 0x85, 0xfb,
+	0x1b, 0xd3, 0x57, 0xe6, 0x93, 0x06, 0x21, 0x73, 0xcf, 0xf5, 0xb2, 0x1a, 0x70, 0x17, 0xd3, 0x4b,
+	0xcf, 0xa4, 0x01, 0x77, 0x37, 0xed, 0x35, 0x68, 0x8c, 0x99, 0x8f, 0x2b, 0x00, 0x73, 0xb7, 0xc8,
+	0x0a, 0x85, 0xa3, 0x28, 0x19, 0x75, 0x49, 0x36, 0x04, 0x15, 0xcd, 0xca, 0x67, 0x4f, 0xbd, 0x33,
+	0x73, 0x12, 0x0e, 0x22, 0x5e, 0x25, 0xe6, 0x41, 0x4e, 0xa6, 0xf5, 0x97, 0x2b, 0x50, 0xc9, 0xd4,
+	0x5b, 0x69, 0xc9, 0x60, 0xd1, 0xcc, 0xc6, 0xdd, 0xf6, 0x4c, 0x2f, 0xc8, 0x25, 0xe3, 0x1b, 0x92,
+	0x82, 0x0f, 0x51, 0x66, 0x72, 0x4a, 0x7b, 0x73, 0x20, 0x5d, 0xa0, 0x88, 0xc5, 0xd6, 0x24, 0xf3,
+	0x8b, 0xb8, 0x37, 0x4b, 0x96, 0xd6, 0x03, 0x8d, 0x47, 0x96, 0xef, 0xf4, 0x73, 0xd5, 0xc8, 0xca,
+	0x05, 0x35, 0x8c, 0x43, 0x01, 0x4f, 0x8b, 0x71, 0xab, 0x7c, 0x82, 0x42, 0xe9, 0x89, 0xd2, 0x9a,
+	0xab, 0x38, 0x54, 0x2f, 0x88, 0x4c, 0xa5, 0xde, 0x6c, 0xbd, 0x61, 0x8d, 0x4f, 0xd1, 0x78, 0xb6,
+	0xc7, 0x99, 0xf4, 0xb7, 0x76, 0x79, 0x8f, 0x33, 0x1b, 0x1e, 0x9f, 0xa0, 0x70, 0xf4, 0x92, 0x2e,
+	0xc6, 0x60, 0x21, 0xb3, 0x46, 0xe8, 0xe0, 0xd6, 0xc5, 0xae, 0xe1, 0xf2, 0x43, 0x45, 0x42, 0x27,
+	0x13, 0x32, 0x9b, 0x61, 0xe0, 0x97, 0x8c, 0xec, 0x06, 0x8d, 0x6c, 0x43, 0xd2, 0x93, 0x51, 0x7d,
+	0x0d, 0x0b, 0x4d, 0x63, 0xcf, 0x3a, 0x4b, 0x91, 0x9b, 0x84, 0xac, 0x0b, 0x72, 0x02, 0x7c, 0x05,
+	0xea, 0xd6, 0x78, 0xec, 0x9d, 0x51, 0x94, 0x62, 0x7a, 0xd6, 0x50, 0xbf, 0x42, 0x81, 0x4a, 0x95,
+	0xa8, 0x18, 0x9d, 0xec, 0x5a, 0x43, 0xad, 0x0b, 0x4d, 0x21, 0x67, 0x26, 0x47, 0x79, 0xba, 0x7e,
+	0x69, 0x15, 0x44, 0x76, 0x21, 0x21, 0x60, 0xc8, 0x36, 0xa9, 0xc6, 0xb4, 0x86, 0x4c, 0xbf, 0x4a,
+	0x8f, 0xd4, 0x26, 0xe0, 0xed, 0x21, 0xc3, 0x51, 0xa1, 0x2d, 0x41, 0xe6, 0x58, 0x72, 0x73, 0xaf,
+	0x20, 0x4d, 0x66, 0x56, 0x74, 0xaa, 0xe1, 0x72, 0xe9, 0x65, 0x31, 0xee, 0x12, 0x43, 0x8b, 0x91,
+	0xf9, 0x05, 0xa7, 0x1a, 0x19, 0x09, 0x65, 0x4f, 0xeb, 0xce, 0x34, 0x91, 0x6b, 0x6f, 0xc1, 0x7a,
+	0x7e, 0x80, 0x4c, 0x87, 0x79, 0x91, 0xa5, 0x5f, 0xa3, 0x3e, 0xaf, 0x66, 0x87, 0xa9, 0x83, 0x0c,
+	0xed, 0x1d, 0xd0, 0x8f, 0x2d, 0x6e, 0xce, 0x14, 0xba, 0x2e, 0x0a, 0x2b, 0xc7, 0x16, 0x6f, 0x4f,
+	0xc9, 0x1d, 0x40, 0x0d, 0x63, 0x13, 0x74, 0xde, 0xdc, 0x0b, 0x22, 0xcc, 0x14, 0xb0, 0xff, 0x6f,
+	0x9e, 0x93, 0x1f, 0x12, 0x32, 0xb3, 0x3a, 0x0f, 0xbd, 0x20, 0x32, 0xaa, 0x52, 0x03, 0x36, 0x78,
+	0xeb, 0x6d, 0x68, 0x4e, 0xae, 0x15, 0x8a, 0x6d, 0x3c, 0x17, 0x57, 0xa8, 0xe5, 0x38, 0xa1, 0x74,
+	0xf2, 0x20, 0x48, 0x6d, 0xc7, 0x09, 0x5b, 0xdf, 0x5f, 0x00, 0x6d, 0x7a, 0x25, 0xa0, 0x5c, 0xb2,
+	0xa0, 0x92, 0x3d, 0x1c, 0xd4, 0xf2, 0x70, 0x4e, 0x73, 0xc1, 0xd9, 0x42, 0x3e, 0x38, 0x6b, 0x42,
+	0x71, 0xec, 0x3a, 0xb4, 0x2f, 0x14, 0x0d, 0xfc, 0x89, 0x96, 0x6c, 0x8d, 0x93, 0xae, 0x9b, 0xb4,
+	0xdf, 0x88, 0x6d, 0xbb, 0x91, 0xa1, 0xef, 0xe1, 0xd6, 0xf3, 0x1a, 0x34, 0x64, 0x87, 0x8f, 0x03,
+	0x1e, 0x11, 0x52, 0xec, 0xe3, 0x75, 0x41, 0xbe, 0x2f, 0xa9, 0x99, 0x37, 0x1b, 0x07, 0x61, 0x44,
+	0xce, 0x7c, 0x49, 0xbd, 0xd9, 0x41, 0x10, 0x46, 0xda, 0x67, 0xa1, 0xa6, 0xf2, 0x55, 0xe1, 0xfa,
+	0x56, 0x2e, 0xb5, 0xe0, 0xaa, 0x14, 0x38, 0x44, 0x3c, 0x9d, 0xef, 0x9e, 0xf9, 0xb6, 0x39, 0x0e,
+	0xdd, 0x20, 0x74, 0xa3, 0x33, 0xb9, 0xc3, 0x57, 0x91, 0x78, 0x20, 0x69, 0x14, 0x1b, 0x22, 0x08,
+	0x5d, 0x03, 0x23, 0x67, 0x5c, 0x36, 0xca, 0x48, 0xc1, 0xb5, 0xce, 0x5a, 0xff, 0xbd, 0x90, 0x4c,
+	0x4a, 0x9a, 0xb8, 0x5e, 0x3a, 0xb8, 0xeb, 0xb0, 0x24, 0xf4, 0x89, 0x7d, 0x57, 0x34, 0xa8, 0x3f,
+	0xf8, 0xbe, 0xc9, 0x12, 0x2f, 0xca, 0xf3, 0x66, 0xe6, 0x47, 0xc9, 0x02, 0xff, 0x18, 0xd4, 0xa9,
+	0x0e, 0x92, 0xa2, 0xc4, 0x40, 0xd7, 0x88, 0x9a, 0x85, 0x0d, 0xbc, 0x98, 0x1f, 0xa7, 0x30, 0x31,
+	0xca, 0x35, 0xa2, 0x5e, 0xe4, 0x57, 0x96, 0x67, 0xfa, 0x95, 0xab, 0x50, 0x4a, 0x3c, 0xca, 0x0a,
+	0x4d, 0xfc, 0x4a, 0x5f, 0x3a, 0x93, 0x57, 0xa0, 0x3e, 0xb1, 0x2c, 0x4a, 0xc2, 0xe5, 0xf4, 0xb3,
+	0xcb, 0xe1, 0x4d, 0xd0, 0x70, 0x19, 0x4d, 0x20, 0xc5, 0xe6, 0xd6, 0x38, 0xb6, 0x78, 0x6e, 0xed,
+	0xbc, 0x06, 0x0d, 0xb1, 0xbb, 0x25, 0xeb, 0x57, 0xee, 0x6b, 0x75, 0x22, 0x6f, 0x2b, 0x6a, 0xeb,
+	0x77, 0x96, 0x61, 0x63, 0xe6, 0x89, 0x9f, 0xb6, 0x05, 0x55, 0x7c, 0x5e, 0x2e, 0xc1, 0x28, 0x19,
+	0x70, 0x6c, 0x71, 0x15, 0x7e, 0x5e, 0x60, 0xe1, 0x37, 0xa1, 0x89, 0xc2, 0xb9, 0x30, 0x57, 0xe4,
+	0x1b, 0xf5, 0x63, 0x8b, 0x77, 0x32, 0x91, 0xee, 0x64, 0x30, 0xbc, 0x38, 0x1d, 0x0c, 0x3f, 0x50,
+	0x93, 0x8d, 0x33, 0x50, 0xbf, 0xf3, 0xee, 0xfc, 0xc7, 0x96, 0x8a, 0x8a, 0x04, 0xa6, 0xac, 0xe4,
+	0x4b, 0xa0, 0xac, 0x58, 0x44, 0xc1, 0xcb, 0xa4, 0xf5, 0x9d, 0x67, 0xd7, 0x8a, 0x61, 0xb3, 0x51,
+	0xe9, 0xa7, 0x0d, 0x7c, 0x6d, 0xac, 0x65, 0x61, 0x38, 0x31, 0x08, 0x42, 0x34, 0x89, 0x13, 0x19,
+	0x21, 0xd7, 0x25, 0xfd, 0x5e, 0x10, 0x62, 0x29, 0x0b, 0x0d, 0x58, 0x94, 0x71, 0xc5, 0x92, 0x11,
+	0x8d, 0xd6, 0xef, 0x17, 0xa0, 0x9a, 0xed, 0xb2, 0xb6, 0x0a, 0xb5, 0xa3, 0xbd, 0x0f, 0xf7, 0xf6,
+	0x1f, 0xed, 0x99, 0x87, 0xbd, 0x76, 0xaf, 0xdb, 0x7c, 0x4e, 0x03, 0x58, 0x6e, 0x6f, 0xf7, 0x76,
+	0x1e, 0x76, 0x9b, 0x05, 0xad, 0x04, 0x8b, 0x3b, 0x9d, 0xdd, 0x6e, 0x73, 0x41, 0xbb, 0x02, 0x6b,
+	0xf8, 0xcb, 0xdc, 0xd9, 0x33, 0x7b, 0x46, 0x7b, 0xef, 0x10, 0x21, 0xfb, 0x7b, 0xcd, 0xa2, 0xf6,
+	0x22, 0x5c, 0x9f, 0xc1, 0x30, 0xdb, 0x77, 0xf7, 0x8d, 0x5e, 0xb7, 0xd3, 0x5c, 0xd4, 0xae, 0xc1,
+	0xe6, 0xbd, 0xf6, 0x61, 0xef, 0xa0, 0xdd, 0xbb, 0x6f, 0xde, 0x3b, 0xda, 0x13, 0xec, 0xed, 0xf6,
+	0xee, 0x6e, 0x73, 0x49, 0xab, 0x42, 0xa9, 0xb3, 0x73, 0xd8, 0xbe, 0xbb, 0xdb, 0xed, 0x34, 0x97,
+	0x5b, 0x3f, 0x2c, 0x40, 0x25, 0xf3, 0xea, 0x5a, 0x13, 0xaa, 0xaa, 0x73, 0xbd, 0x2f, 0x1d, 0x60,
+	0xdf, 0xae, 0xc0, 0x5a, 0xfb, 0xa8, 0xb7, 0xff, 0xb0, 0xbd, 0x7d, 0x74, 0xf4, 0xc0, 0xdc, 0x6d,
+	0x1f, 0xed, 0x6d, 0xdf, 0xef, 0x1a, 0xcd, 0x82, 0xb6, 0x01, 0xab, 0x19, 0xc6, 0xa3, 0x7d, 0xe3,
+	0xc3, 0xae, 0xd1, 0x5c, 0x40, 0xf2, 0xdd, 0xf6, 0xf6, 0x87, 0x9f, 0x37, 0xf6, 0x8f, 0xf6, 0x3a,
+	0x8a, 0x5c, 0x9c, 0x24, 0x1b, 0x3b, 0xbd, 0xae, 0xd1, 0x5c, 0xd4, 0x34, 0xa8, 0x6f, 0xef, 0xee,
+	0x74, 0xf7, 0x7a, 0x26, 0x72, 0xbb, 0x7b, 0x9d, 0xe6, 0x12, 0xf6, 0x61, 0xfb, 0x7e, 0x77, 0xfb,
+	0xc3, 0x83, 0xfd, 0x9d, 0x3d, 0x44, 0x2d, 0x6b, 0x15, 0x58, 0x39, 0xec, 0xb5, 0x8d, 0xde, 0xd1,
+	0x41, 0x73, 0x45, 0x6b, 0x40, 0xe5, 0x51, 0x7b, 0xd7, 0xe8, 0x6e, 0x77, 0x77, 0x1e, 0x76, 0x8d,
+	0x66, 0x49, 0xab, 0x41, 0xf9, 0x51, 0x7b, 0xf7, 0xb0, 0xbb, 0xd7, 0xe9, 0x1a, 0xcd, 0xb2, 0x6c,
+	0xca, 0x27, 0x40, 0xeb, 0x75, 0x58, 0x9b, 0x71, 0x34, 0x3d, 0x2b, 0x03, 0x68, 0xfd, 0x61, 0x01,
+	0x36, 0x66, 0x1e, 0x32, 0xa3, 0xe7, 0xc8, 0x1e, 0x59, 0x27, 0xfe, 0xab, 0x96, 0x52, 0xd1, 0xaa,
+	0x6f, 0x81, 0xe6, 0xb8, 0xfc, 0xc4, 0x1c, 0x5b, 0x61, 0xe4, 0x8a, 0xa3, 0xa0, 0x64, 0x1d, 0x35,
+	0x91, 0x73, 0xa0, 0x18, 0x93, 0x6b, 0xad, 0x98, 0x5f, 0x6b, 0x69, 0x6e, 0xba, 0x98, 0xcd, 0x4d,
+	0x5b, 0xff, 0xb9, 0x08, 0xf5, 0xfc, 0xf9, 0x23, 0xa6, 0xab, 0xf2, 0x44, 0x36, 0xe9, 0x55, 0x89,
+	0x08, 0xd2, 0xa7, 0x8a, 0xa2, 0xd8, 0x02, 0x79, 0x1f, 0xd1, 0x40, 0xf7, 0x1d, 0x05, 0x91, 0xe5,
+	0x51, 0x84, 0x42, 0x8f, 0x2e, 0x18, 0x65, 0xa2, 0xe0, 0xae, 0x80, 0x43, 0x13, 0x06, 0x4f, 0x39,
+	0x2d, 0xdb, 0xa2, 0x41, 0xbf, 0xb5, 0x57, 0xa1, 0x21, 0xee, 0x33, 0x99, 0x7d, 0xef, 0x84, 0x9b,
+	0xc7, 0x6e, 0x44, 0x2b, 0xb7, 0x68, 0xd4, 0x04, 0xf9, 0xae, 0x77, 0xc2, 0xef, 0xbb, 0x11, 0xae,
+	0x96, 0x2c, 0x2e, 0x64, 0x96, 0x43, 0x8b, 0xb1, 0x68, 0xd4, 0x53, 0xa0, 0xc1, 0x2c, 0x07, 0x4b,
+	0x87, 0x59, 0xa4, 0xe3, 0x86, 0x91, 0xcb, 0x1c, 0xe9, 0x47, 0x57, 0x53, 0x70, 0x47, 0x30, 0x26,
+	0xf1, 0xe8, 0xd9, 0x23, 0xe6, 0xeb, 0xa5, 0x49, 0xfc, 0x23, 0xc1, 0x40, 0x0f, 0x2c, 0xb2, 0xc4,
+	0xa4, 0xc3, 0x65, 0xe1, 0x81, 0x89, 0xaa, 0xfa, 0xfb, 0x2a, 0x34, 0x32, 0x28, 0xea, 0x2e, 0x88,
+	0xf7, 0x4a, 0x60, 0xd4, 0x5b, 0x2a, 0xf5, 0x25, 0x38, 0xd5, 0xd9, 0x8a, 0x2a, 0xf5, 0x49, 0xa8,
+	0xea, 0x6b, 0x1e, 0xad, 0xba, 0x5a, 0x9d, 0x40, 0x67, 0x7a, 0x8a, 0x29, 0x7a, 0xa6, 0x0b, 0x35,
+	0xd1, 0x53, 0xa4, 0x26, 0x3d, 0x78, 0x03, 0x56, 0x53, 0x94, 0x52, 0x59, 0x17, 0x85, 0x49, 0x05,
+	0x54, 0x1a, 0x5b, 0x50, 0xeb, 0x7b, 0x27, 0xa4, 0x4b, 0xcc, 0x71, 0x83, 0xe6, 0xb8, 0xd2, 0xf7,
+	0x4e, 0x50, 0x17, 0xcd, 0x32, 0xee, 0x50, 0xde, 0x89, 0x29, 0xf6, 0x4d, 0x02, 0x35, 0x09, 0x54,
+	0xed, 0x7b, 0x27, 0xa8, 0x87, 0x21, 0xaa, 0xf5, 0xdd, 0x02, 0x5c, 0x39, 0xe7, 0x44, 0x7c, 0xea,
+	0x96, 0x57, 0xe1, 0x67, 0x76, 0xcb, 0x6b, 0xe1, 0xa2, 0x5b, 0x5e, 0xdb, 0x00, 0x99, 0x94, 0xa4,
+	0x38, 0xff, 0x25, 0x81, 0x8c, 0x58, 0xeb, 0x3b, 0x15, 0x58, 0x9b, 0x71, 0x58, 0x4e, 0xb1, 0x78,
+	0x72, 0xec, 0x9e, 0xd6, 0x71, 0x14, 0x0d, 0xd7, 0xd4, 0xcb, 0x50, 0x4b, 0x20, 0xb4, 0xd9, 0xc8,
+	0x3a, 0x81, 0x22, 0x92, 0x1f, 0xbd, 0x0f, 0x8d, 0x27, 0x2e, 0x7b, 0x6a, 0x3a, 0x6c, 0xe0, 0xfa,
+	0x6e, 0x12, 0xb8, 0xcc, 0x91, 0xf9, 0xd6, 0x51, 0xae, 0x93, 0x88, 0x69, 0x3b, 0x54, 0xf4, 0x89,
+	0x47, 0x3e, 0x27, 0x5f, 0x50, 0xb9, 0xf3, 0xd6, 0xbc, 0x27, 0xff, 0x78, 0xb9, 0x2d, 0x1e, 0xf9,
+	0x86, 0x92, 0xd7, 0x8e, 0xa0, 0x62, 0x07, 0x3e, 0x8f, 0x42, 0xcb, 0xc5, 0x53, 0xf9, 0x25, 0x52,
+	0xf7, 0xf6, 0x33, 0xa8, 0x53, 0xb2, 0x46, 0x56, 0x0f, 0x06, 0xba, 0x63, 0x3c, 0x7e, 0xe0, 0x11,
+	0x7a, 0xd6, 0x74, 0x03, 0x2e, 0x1b, 0x8d, 0x0c, 0x9d, 0x86, 0xe5, 0x05, 0x80, 0x81, 0xeb, 0x79,
+	0x03, 0x0b, 0x1f, 0x42, 0x6b, 0x7d, 0xc9, 0xc8, 0x50, 0xd0, 0x25, 0x62, 0x8c, 0x11, 0xb8, 0x8e,
+	0xaa, 0x18, 0xae, 0x1c, 0x5b, 0x7c, 0xdf, 0x75, 0xf0, 0xe6, 0x15, 0xa5, 0x1c, 0xb2, 0xe4, 0x69,
+	0xe1, 0x93, 0xec, 0x63, 0xd7, 0x73, 0x42, 0xe6, 0xcb, 0x88, 0x69, 0xf3, 0xd8, 0xe2, 0x3b, 0x29,
+	0x7b, 0x5b, 0x72, 0xd1, 0x43, 0xa2, 0x64, 0x14, 0x58, 0x5c, 0x95, 0x02, 0xf0, 0x29, 0x3d, 0x6c,
+	0x4f, 0x54, 0xaa, 0x2a, 0x73, 0x57, 0xaa, 0xaa, 0xe7, 0x57, 0xaa, 0x3e, 0x0e, 0x1a, 0x3b, 0xb5,
+	0xbd, 0x98, 0xbb, 0x4f, 0x98, 0x47, 0x41, 0xe4, 0x09, 0x13, 0x6b, 0xba, 0x64, 0xac, 0x66, 0x38,
+	0xbb, 0xc4, 0xd0, 0xf6, 0x61, 0x25, 0x18, 0x8b, 0xcc, 0x5d, 0x64, 0x73, 0x9f, 0x98, 0x7b, 0x46,
+	0xf6, 0x85, 0x5c, 0xd7, 0x8f, 0xc2, 0x33, 0x43, 0x69, 0x91, 0x59, 0x76, 0xb2, 0x07, 0xe9, 0x0d,
+	0x95, 0x65, 0x27, 0xbb, 0x0f, 0x3a, 0xd3, 0xb1, 0x45, 0x35, 0x8c, 0x9c, 0xb5, 0x37, 0x69, 0x42,
+	0x56, 0x05, 0xcb, 0xc8, 0xd8, 0xfc, 0x27, 0xe0, 0x0a, 0x0e, 0xe1, 0x2c, 0x99, 0xd5, 0x24, 0xdd,
+	0x3b, 0x98, 0x14, 0xbb, 0xf6, 0x29, 0xa8, 0x66, 0xbb, 0x88, 0x49, 0xd2, 0x09, 0x3b, 0x93, 0x7b,
+	0x2e, 0xfe, 0xc4, 0x0d, 0x2a, 0x5b, 0x6c, 0x13, 0x8d, 0x4f, 0x2d, 0x7c, 0xb2, 0x70, 0xed, 0xcf,
+	0x0a, 0xb0, 0x2c, 0x0c, 0x38, 0xd9, 0xab, 0x17, 0x32, 0xd5, 0xba, 0xeb, 0x50, 0x76, 0xac, 0xc8,
+	0x12, 0xd6, 0x26, 0x0b, 0xa5, 0x48, 0x20, 0x33, 0xeb, 0x40, 0xcd, 0x61, 0x03, 0x2b, 0xf6, 0x9e,
+	0xb1, 0xe6, 0x56, 0x95, 0x52, 0xa2, 0x68, 0x76, 0x15, 0x4a, 0x7e, 0x10, 0x99, 0x7e, 0xec, 0x79,
+	0xb2, 0x3e, 0xbe, 0xe2, 0x07, 0x11, 0xc2, 0xb1, 0x4a, 0x3b, 0x0e, 0xb8, 0x9b, 0xe4, 0x06, 0x4b,
+	0x46, 0xd2, 0xbe, 0xf6, 0x83, 0x05, 0x80, 0x74, 0xa9, 0x60, 0x3d, 0x60, 0x10, 0x84, 0xcc, 0x1d,
+	0xfa, 0xe6, 0x0c, 0xcf, 0xa2, 0x49, 0x5e, 0x76, 0xb0, 0x67, 0xbd, 0xae, 0x06, 0x8b, 0x99, 0x37,
+	0xa5, 0xdf, 0x18, 0x94, 0xa4, 0xcb, 0x10, 0x3d, 0x8d, 0xca, 0x7a, 0x52, 0x6a, 0x87, 0x0d, 0x64,
+	0xd5, 0x98, 0x1c, 0xc8, 0x12, 0x55, 0xb3, 0x55, 0x13, 0x33, 0x0a, 0xd5, 0x35, 0x85, 0x58, 0x26,
+	0x44, 0x5d, 0x92, 0xb7, 0x25, 0xf0, 0x36, 0xac, 0x29, 0x60, 0x3c, 0x76, 0xac, 0x48, 0x2e, 0xf2,
+	0x15, 0x7a, 0xdc, 0xaa, 0x64, 0x1d, 0x11, 0x87, 0xc6, 0x3f, 0x83, 0x77, 0x98, 0xc7, 0x14, 0xbe,
+	0x94, 0xc3, 0x77, 0x88, 0x43, 0xf8, 0x5b, 0xa0, 0xc6, 0xc1, 0x1c, 0x59, 0x91, 0x7d, 0x2c, 0xe0,
+	0x22, 0xaf, 0x6c, 0x4a, 0xce, 0x03, 0x64, 0x20, 0xba, 0xf5, 0xed, 0x32, 0xac, 0x4e, 0x5d, 0x45,
+	0x9a, 0xc7, 0x73, 0x63, 0xda, 0xea, 0x7e, 0xc4, 0xe4, 0x61, 0x95, 0x08, 0x89, 0xca, 0x48, 0x11,
+	0x07, 0x54, 0x57, 0xf1, 0x6e, 0xe7, 0x63, 0x93, 0xdb, 0x96, 0x2f, 0xf3, 0xf8, 0x15, 0xce, 0x1e,
+	0x1f, 0xda, 0x96, 0x8f, 0x89, 0x13, 0xb2, 0xa2, 0x78, 0x2c, 0x36, 0x68, 0x11, 0x1a, 0x01, 0x67,
+	0x8f, 0x7b, 0xf1, 0x98, 0xb6, 0xe7, 0xab, 0x50, 0x72, 0x9d, 0x53, 0x21, 0x2c, 0x22, 0xa3, 0x15,
+	0xd7, 0x39, 0x25, 0xe1, 0x16, 0xd4, 0x90, 0x85, 0xc2, 0x03, 0x16, 0xd9, 0xc7, 0x32, 0x20, 0xaa,
+	0xb8, 0xce, 0x69, 0x2f, 0x1e, 0xdf, 0x43, 0x92, 0x76, 0x0d, 0xca, 0x3e, 0x21, 0x5c, 0x59, 0x80,
+	0x2f, 0x1a, 0x2b, 0x7e, 0x2f, 0x1e, 0xef, 0xf8, 0x3c, 0xe5, 0xc5, 0x63, 0x47, 0x2f, 0xa5, 0xbc,
+	0xa3, 0xb1, 0x93, 0xf2, 0x1c, 0xe6, 0xe9, 0xe5, 0x94, 0xd7, 0x61, 0x9e, 0xf6, 0x12, 0xd4, 0x04,
+	0x8f, 0xee, 0x6a, 0x8f, 0x55, 0x64, 0x03, 0xc8, 0xbf, 0x1f, 0x44, 0x28, 0xfe, 0x3c, 0x00, 0x56,
+	0xf2, 0x9f, 0x30, 0xc4, 0xc9, 0x70, 0xa6, 0xe4, 0xef, 0xba, 0x4f, 0x58, 0x2f, 0x1e, 0x0b, 0xae,
+	0x43, 0x41, 0x44, 0x3c, 0x96, 0xe1, 0x4b, 0xc9, 0xef, 0x60, 0x04, 0x11, 0x8f, 0xb5, 0x8f, 0xc3,
+	0x9a, 0x6f, 0x8e, 0x02, 0xc7, 0xe4, 0x2e, 0x3a, 0x63, 0xb9, 0xb0, 0x64, 0xec, 0xd2, 0xf4, 0x1f,
+	0x04, 0xce, 0x21, 0x32, 0xda, 0x82, 0x8e, 0xf1, 0x06, 0x9d, 0x29, 0xa7, 0x51, 0x8e, 0x26, 0xa2,
+	0x1c, 0xa4, 0x26, 0x51, 0x4e, 0x0b, 0x6a, 0x29, 0x0a, 0x83, 0xb6, 0x35, 0x31, 0x56, 0x0a, 0x84,
+	0x31, 0x9b, 0x1c, 0xcf, 0x54, 0xd1, 0x7a, 0x32, 0x9e, 0x89, 0x9e, 0x2d, 0xa8, 0x26, 0x18, 0x54,
+	0xb3, 0x21, 0x5e, 0x5d, 0x42, 0x64, 0xe4, 0x47, 0x3b, 0x42, 0x46, 0xcf, 0xa6, 0x88, 0xfc, 0x88,
+	0x9c, 0x68, 0xc2, 0xe8, 0x2c, 0xc5, 0xa1, 0x2e, 0x59, 0x3c, 0x4c, 0x60, 0xa8, 0x0d, 0x51, 0xf9,
+	0x4e, 0xe9, 0x12, 0x95, 0xed, 0x55, 0x0b, 0x6a, 0x51, 0xae, 0x5b, 0xa2, 0x28, 0x58, 0x89, 0x32,
+	0xfd, 0x7a, 0x11, 0x2a, 0xe2, 0x3a, 0x96, 0xb0, 0x52, 0x51, 0x82, 0x03, 0x22, 0x09, 0x33, 0xbd,
+	0x25, 0x8b, 0x06, 0x04, 0x62, 0x3c, 0x72, 0x47, 0x98, 0x47, 0x8b, 0xaa, 0x1b, 0x66, 0xe8, 0x77,
+	0x91, 0xd1, 0x95, 0x74, 0x7c, 0xcd, 0x91, 0xe5, 0xfa, 0x66, 0xc6, 0xf0, 0x9f, 0x17, 0xaf, 0x89,
+	0xe4, 0xc3, 0xc4, 0xf8, 0x6f, 0x42, 0x53, 0xbc, 0x66, 0x06, 0x78, 0x43, 0x04, 0xee, 0x44, 0xcf,
+	0x21, 0xe5, 0xd5, 0xb9, 0x14, 0x29, 0xce, 0xdc, 0xeb, 0x44, 0x4f, 0x91, 0x1f, 0x42, 0xc3, 0x43,
+	0x95, 0x56, 0x1c, 0x05, 0xe2, 0xe6, 0x8e, 0xfe, 0xe2, 0xdc, 0x47, 0x76, 0x75, 0x14, 0x6d, 0x27,
+	0x92, 0xda, 0x03, 0x68, 0x26, 0xca, 0x94, 0xad, 0x6d, 0xcd, 0xad, 0xad, 0xa1, 0xb4, 0x49, 0x20,
+	0xbe, 0x85, 0xb2, 0x6c, 0x8f, 0x99, 0x74, 0x20, 0xab, 0xbf, 0x44, 0x01, 0x70, 0xdd, 0x11, 0x06,
+	0xee, 0x31, 0x03, 0xa9, 0xe8, 0x66, 0x45, 0x17, 0xe8, 0x00, 0xde, 0x89, 0x99, 0xde, 0xa2, 0xb1,
+	0xae, 0x09, 0xea, 0xbe, 0x20, 0xb6, 0xfe, 0x6a, 0x01, 0x6a, 0xb9, 0xab, 0x8c, 0xf3, 0x78, 0xa4,
+	0xcf, 0x49, 0xb7, 0xbe, 0x40, 0xf5, 0x8a, 0x5b, 0x97, 0xdf, 0x8f, 0xbc, 0x4d, 0x7f, 0xa9, 0x4a,
+	0x41, 0x92, 0xda, 0xa7, 0xa1, 0x12, 0xd8, 0x74, 0xe6, 0x40, 0x31, 0x78, 0xf1, 0xd2, 0x18, 0x1c,
+	0x14, 0x5c, 0x84, 0xe0, 0xd6, 0x78, 0x1c, 0x06, 0xa7, 0x64, 0x2b, 0x66, 0x56, 0x91, 0x38, 0x2f,
+	0xde, 0xc8, 0xb0, 0xf7, 0x13, 0xb9, 0xd6, 0x11, 0x94, 0x93, 0x7e, 0x60, 0x3d, 0xe3, 0x41, 0x7b,
+	0xef, 0xa8, 0xbd, 0x6b, 0x8a, 0x52, 0x40, 0xf3, 0x39, 0x4c, 0xd1, 0xb1, 0x34, 0xa0, 0x08, 0x05,
+	0x4c, 0xf3, 0x25, 0xa6, 0xbd, 0xd7, 0xde, 0xfd, 0xd2, 0x97, 0xb1, 0xbc, 0xd1, 0x84, 0x2a, 0x81,
+	0x14, 0xa5, 0xd8, 0xfa, 0xc9, 0x02, 0x34, 0x27, 0x2f, 0x6f, 0xe2, 0x46, 0x2f, 0x2f, 0x80, 0xa6,
+	0xf9, 0x2d, 0x11, 0x64, 0xa5, 0x29, 0x37, 0xc4, 0x0b, 0xd3, 0x43, 0x9c, 0xd9, 0xfe, 0x8a, 0xf9,
+	0xed, 0x2f, 0xd1, 0x9c, 0x6e, 0x9d, 0x42, 0x33, 0xee, 0x9a, 0xf7, 0xa6, 0x36, 0xd7, 0x39, 0x8f,
+	0xdd, 0x26, 0x76, 0xdf, 0x1b, 0x00, 0x18, 0x8c, 0x85, 0xee, 0xc8, 0x0a, 0xcf, 0xd4, 0x31, 0xba,
+	0xcb, 0x0f, 0x04, 0x81, 0xfa, 0x80, 0xb7, 0x41, 0xdc, 0xc7, 0x31, 0x93, 0x65, 0xa5, 0x92, 0xcb,
+	0x8f, 0xa8, 0x4d, 0x7b, 0x0a, 0x17, 0x27, 0xde, 0x2a, 0x1a, 0x76, 0x39, 0x9d, 0x60, 0x4f, 0x04,
+	0xd2, 0xe5, 0xa9, 0x40, 0x1a, 0x1f, 0x4b, 0xef, 0x46, 0xe6, 0x25, 0xaf, 0x09, 0x11, 0x85, 0xb6,
+	0xd0, 0x6f, 0x16, 0xa1, 0x9e, 0xbf, 0xd1, 0x7a, 0xf1, 0x38, 0x5f, 0xbe, 0x73, 0x26, 0x9b, 0x5f,
+	0x31, 0xbf, 0xf9, 0x49, 0x47, 0x3c, 0xb9, 0x73, 0x8a, 0xbd, 0x4f, 0x39, 0xc5, 0x4b, 0xb7, 0xc7,
+	0x29, 0x97, 0xbf, 0x72, 0xb9, 0xcb, 0x2f, 0x4d, 0xb9, 0xfc, 0x09, 0xd7, 0x5a, 0x9e, 0xd3, 0xb5,
+	0xc2, 0x39, 0xae, 0xf5, 0x3d, 0xa8, 0xc6, 0x7e, 0xcc, 0x99, 0xdc, 0x01, 0xe7, 0xf9, 0xca, 0x49,
+	0xe0, 0x69, 0x5f, 0xa4, 0xab, 0x04, 0x33, 0xee, 0xff, 0xa2, 0x4d, 0xa7, 0x37, 0x89, 0x53, 0xb7,
+	0xa1, 0x68, 0xf2, 0x92, 0x80, 0x67, 0xf9, 0xc3, 0x18, 0xcf, 0x95, 0x64, 0xec, 0xab, 0xda, 0x58,
+	0x3a, 0x92, 0x47, 0xbd, 0xc2, 0xa4, 0x65, 0x8b, 0xa6, 0x90, 0x7e, 0x99, 0x7d, 0x57, 0x15, 0xbe,
+	0xcb, 0x82, 0x72, 0xd7, 0xf5, 0x33, 0x15, 0xa7, 0xe5, 0xdc, 0x6d, 0x88, 0x4d, 0x58, 0x0e, 0x19,
+	0x8f, 0xbd, 0x48, 0x46, 0x6f, 0xb2, 0xa5, 0x3d, 0x0f, 0x65, 0x6b, 0x38, 0x0c, 0xd9, 0x50, 0x9d,
+	0x00, 0x94, 0x8c, 0x94, 0x80, 0x52, 0x4f, 0x5d, 0xdf, 0x09, 0x9e, 0xca, 0xc1, 0x93, 0x2d, 0x4c,
+	0x15, 0x39, 0xb3, 0x63, 0x3c, 0x44, 0x10, 0xa9, 0x31, 0x0b, 0xe5, 0xc1, 0x7d, 0x43, 0xd1, 0x3b,
+	0x82, 0x8c, 0x0f, 0xf0, 0x98, 0x75, 0x32, 0x0e, 0x03, 0xba, 0x86, 0x41, 0x0f, 0x48, 0x08, 0xf4,
+	0x96, 0x51, 0xe8, 0xda, 0x91, 0xcc, 0xab, 0x64, 0x0b, 0xa7, 0x38, 0x64, 0x51, 0x1c, 0xfa, 0xdc,
+	0xe4, 0x2c, 0xa2, 0xfa, 0x48, 0xc9, 0x00, 0x49, 0x3a, 0x64, 0x11, 0x0e, 0xdd, 0x93, 0x00, 0xbd,
+	0x83, 0x27, 0xaa, 0x22, 0x65, 0x23, 0x69, 0x63, 0x3c, 0x9c, 0xe6, 0xeb, 0xe6, 0xb1, 0xc5, 0x8f,
+	0x29, 0x23, 0x2a, 0x1b, 0xf5, 0x94, 0x7c, 0xdf, 0xe2, 0xc7, 0xad, 0x6f, 0x15, 0x60, 0x75, 0xea,
+	0x72, 0xf5, 0x3c, 0x13, 0xf7, 0x7f, 0xaa, 0xc7, 0x5d, 0x87, 0x32, 0x67, 0xde, 0x40, 0x70, 0x17,
+	0x89, 0x5b, 0x42, 0x02, 0x32, 0x5b, 0x16, 0xac, 0xcd, 0x38, 0xe7, 0xbb, 0xf4, 0x08, 0x6c, 0xe6,
+	0xe9, 0xd4, 0xc2, 0xcc, 0xd3, 0xa9, 0x56, 0x08, 0xab, 0x53, 0xd7, 0x99, 0xd2, 0x62, 0x77, 0x41,
+	0xbe, 0x09, 0x36, 0xd0, 0x11, 0x88, 0x37, 0x19, 0x89, 0x57, 0x2c, 0x18, 0x2b, 0xd4, 0x7e, 0xc0,
+	0xf1, 0x8a, 0xca, 0xc8, 0xf5, 0x91, 0x21, 0x5e, 0x70, 0x69, 0xe4, 0xfa, 0x92, 0x6c, 0x9d, 0x22,
+	0x79, 0x51, 0x92, 0xad, 0xd3, 0x07, 0xbc, 0xf5, 0x9d, 0x05, 0xa8, 0xec, 0xec, 0xe7, 0xc6, 0x36,
+	0x57, 0xe0, 0x17, 0x2f, 0x34, 0x59, 0xa8, 0x47, 0xd7, 0xc0, 0x4d, 0xbc, 0xb4, 0xc4, 0x99, 0x1d,
+	0xf8, 0x8e, 0xec, 0x43, 0x9d, 0xe8, 0x07, 0x2c, 0x3c, 0x24, 0x2a, 0x96, 0xd2, 0xa8, 0xec, 0x95,
+	0x83, 0x8a, 0x5e, 0x35, 0x04, 0x23, 0xc5, 0xde, 0xc2, 0x64, 0x3e, 0x62, 0x7e, 0x5e, 0xaf, 0xe8,
+	0x6b, 0x53, 0x72, 0x52, 0xf4, 0xab, 0xd0, 0x38, 0x76, 0xa3, 0x1c, 0x74, 0x89, 0xa0, 0x35, 0x24,
+	0xa7, 0xb8, 0xeb, 0x50, 0x4e, 0x8b, 0x73, 0xcb, 0x62, 0x4a, 0x43, 0x55, 0x99, 0xbb, 0x01, 0x90,
+	0xa9, 0xca, 0xad, 0x08, 0x73, 0x78, 0xaa, 0x4a, 0x72, 0x38, 0xb5, 0xe2, 0xb9, 0x82, 0x5f, 0x22,
+	0x3e, 0x08, 0x12, 0x99, 0xc4, 0x63, 0xd0, 0xa6, 0xef, 0xa2, 0x63, 0xd7, 0x32, 0xd7, 0xce, 0x33,
+	0x83, 0x58, 0x4b, 0xae, 0x9b, 0xd3, 0x30, 0xe2, 0xd3, 0x13, 0x9c, 0x34, 0x89, 0x72, 0x02, 0x49,
+	0xe7, 0xbd, 0x98, 0x99, 0xf7, 0xd6, 0x1f, 0x2c, 0x40, 0x3d, 0x7f, 0xdf, 0x7c, 0x9e, 0x1b, 0x51,
+	0x78, 0x24, 0x68, 0x1f, 0xb3, 0x91, 0x95, 0x35, 0x3f, 0x10, 0xa4, 0x3d, 0x79, 0x25, 0x27, 0x59,
+	0x51, 0x04, 0x91, 0x87, 0x7f, 0x8a, 0x48, 0x20, 0xf4, 0x44, 0xe1, 0x30, 0x1e, 0xd1, 0x97, 0x26,
+	0xc2, 0xe7, 0xa5, 0x04, 0x6d, 0x1f, 0x2a, 0xe2, 0x5c, 0x3d, 0xbd, 0x1e, 0x55, 0xbf, 0x73, 0x7b,
+	0x8e, 0x0b, 0xf3, 0xb7, 0xc5, 0x3f, 0x0a, 0xb5, 0xc0, 0x4e, 0x7e, 0xb7, 0xee, 0x00, 0xa4, 0x1c,
+	0xad, 0x0c, 0x4b, 0xed, 0x4e, 0xa7, 0xdb, 0x69, 0x3e, 0x87, 0x87, 0x14, 0x46, 0xf7, 0xc1, 0xfe,
+	0xc3, 0x6e, 0xa7, 0x59, 0xc0, 0x53, 0x96, 0x07, 0xfb, 0x9d, 0x9d, 0x7b, 0x3b, 0xdd, 0x4e, 0x73,
+	0xa1, 0xf5, 0x5f, 0x4b, 0x50, 0xcf, 0x5f, 0x65, 0x47, 0x5f, 0x23, 0xa3, 0x4a, 0xd7, 0x61, 0x7e,
+	0x84, 0x27, 0xad, 0x05, 0x71, 0x11, 0x56, 0x90, 0x77, 0x24, 0x15, 0x17, 0xaa, 0xb2, 0xfc, 0x04,
+	0xb9, 0x40, 0xc8, 0x86, 0xa4, 0x27, 0xd0, 0xc9, 0x21, 0x2f, 0x4e, 0x0f, 0xf9, 0xac, 0x43, 0xbc,
+	0xc5, 0xf3, 0x0e, 0xf1, 0x72, 0xa1, 0xd5, 0xd2, 0x74, 0x68, 0x25, 0x95, 0xe5, 0x60, 0xcb, 0x89,
+	0xb2, 0x6c, 0x49, 0x23, 0x7b, 0xd4, 0xb1, 0x92, 0x3f, 0xea, 0x98, 0x3c, 0x93, 0x2c, 0x4d, 0x9d,
+	0x49, 0x4e, 0x98, 0x49, 0x79, 0x96, 0x99, 0x24, 0x7d, 0x20, 0x08, 0xe4, 0x2b, 0xb2, 0x04, 0xfa,
+	0x79, 0x2a, 0x1b, 0x87, 0x73, 0x7f, 0x91, 0x5c, 0x96, 0xe8, 0x76, 0x84, 0xc1, 0x56, 0x26, 0x85,
+	0x11, 0x7b, 0x51, 0x86, 0x82, 0x6b, 0x62, 0x7c, 0x6c, 0x71, 0x91, 0xfb, 0x96, 0x0d, 0xd1, 0x20,
+	0x5f, 0x90, 0xa4, 0xb2, 0xe4, 0x05, 0x65, 0xb9, 0xbe, 0xa6, 0x92, 0xd9, 0x1e, 0x12, 0xd1, 0x1b,
+	0xa5, 0x38, 0x0c, 0xa1, 0x7c, 0xe6, 0xd0, 0xd6, 0x54, 0x34, 0x1a, 0x0a, 0x79, 0x28, 0xc8, 0x14,
+	0xa0, 0x24, 0x58, 0xf1, 0x74, 0xe6, 0xd0, 0x26, 0x55, 0x34, 0x9a, 0x0a, 0xfc, 0x50, 0xd2, 0x11,
+	0x2d, 0x42, 0x3a, 0x69, 0x69, 0x62, 0xe1, 0xae, 0x0a, 0x34, 0x71, 0x04, 0x54, 0x7c, 0x60, 0x42,
+	0x99, 0xe2, 0xa9, 0x99, 0x66, 0x45, 0x5c, 0x66, 0xe8, 0xb5, 0x91, 0x75, 0xda, 0x51, 0x39, 0x11,
+	0x1d, 0x05, 0xf9, 0xf1, 0x28, 0x87, 0x13, 0x49, 0x7a, 0xcd, 0x8f, 0x47, 0x29, 0xae, 0xf5, 0xbd,
+	0x45, 0x58, 0x9b, 0xf1, 0xa9, 0x85, 0xba, 0x29, 0x21, 0xfc, 0x01, 0xfe, 0x9c, 0xb2, 0xdb, 0x85,
+	0xf9, 0xec, 0xb6, 0x38, 0x97, 0xdd, 0x2e, 0xce, 0x67, 0xb7, 0x4b, 0x33, 0xed, 0x36, 0x17, 0x14,
+	0x2f, 0x4f, 0x04, 0xc5, 0x58, 0xab, 0xa0, 0x8a, 0xb4, 0x02, 0xc8, 0x4b, 0xb0, 0x54, 0x86, 0x96,
+	0x18, 0xca, 0x3e, 0x46, 0x23, 0xcb, 0x77, 0x64, 0xfc, 0xa4, 0x9a, 0xa9, 0xd1, 0x94, 0xb3, 0x46,
+	0xf3, 0x32, 0x5e, 0x90, 0xb1, 0x4f, 0x58, 0xa8, 0x4c, 0x06, 0x92, 0x43, 0x2b, 0x24, 0x0a, 0x8b,
+	0x79, 0x09, 0x54, 0xdb, 0x74, 0x02, 0x9f, 0xc9, 0xba, 0x4d, 0x45, 0xd2, 0x3a, 0x81, 0x4f, 0xde,
+	0x97, 0x3e, 0x85, 0x50, 0x6a, 0x44, 0xf1, 0xa6, 0x22, 0x68, 0x42, 0x8b, 0x88, 0x86, 0xed, 0x13,
+	0xa9, 0xa4, 0x96, 0x44, 0xc3, 0xf6, 0x49, 0xa2, 0x43, 0xcc, 0x6f, 0xce, 0x7a, 0x2b, 0x82, 0x96,
+	0xe8, 0x90, 0x10, 0xd2, 0x21, 0xac, 0x16, 0x04, 0x89, 0x74, 0xe0, 0xd9, 0x80, 0xaa, 0x3a, 0x2b,
+	0x3d, 0xc2, 0x5c, 0x1b, 0x29, 0x5d, 0xe8, 0x7a, 0x0d, 0x32, 0x24, 0xa1, 0x4f, 0x98, 0x6a, 0x3d,
+	0x25, 0xa3, 0xce, 0xd6, 0xef, 0x2d, 0x80, 0x36, 0xfd, 0x95, 0xcd, 0x0c, 0xbb, 0x4a, 0x86, 0x78,
+	0x21, 0x3b, 0xc4, 0x32, 0x94, 0x88, 0xc7, 0xb2, 0x3b, 0x45, 0x39, 0x34, 0x44, 0x13, 0x5d, 0x91,
+	0x06, 0x92, 0x83, 0xa5, 0x5e, 0xf2, 0x6e, 0x06, 0xf9, 0x1a, 0x34, 0x24, 0x4a, 0xdc, 0x6a, 0x63,
+	0x8e, 0xac, 0xfe, 0xd5, 0x05, 0xf9, 0x50, 0x52, 0xf1, 0xa6, 0x6d, 0x7a, 0x56, 0xac, 0x46, 0x42,
+	0x64, 0x3a, 0xcd, 0x0c, 0x43, 0x68, 0xfd, 0xff, 0xb0, 0x9e, 0x05, 0x27, 0xaa, 0x45, 0xd6, 0xb3,
+	0x96, 0xe1, 0x29, 0xfd, 0xad, 0x3f, 0x5e, 0x84, 0xd5, 0xa9, 0x0f, 0x84, 0xf0, 0xa9, 0xf6, 0x31,
+	0xb3, 0x4f, 0xc6, 0x01, 0x9e, 0xd4, 0x50, 0xc0, 0xe0, 0xc8, 0x88, 0xad, 0x99, 0x61, 0xa0, 0xd7,
+	0x73, 0xf0, 0x7b, 0x9f, 0x2c, 0x38, 0x64, 0x8f, 0x63, 0xc6, 0x23, 0x79, 0x21, 0xad, 0x68, 0xac,
+	0x67, 0x98, 0x86, 0xe2, 0xd1, 0x75, 0xc8, 0x84, 0x9e, 0x3d, 0x4d, 0x14, 0xf1, 0xd4, 0x5a, 0xca,
+	0x4c, 0x0e, 0x15, 0xb1, 0x24, 0x9e, 0x91, 0xa1, 0x9b, 0x44, 0x99, 0xd8, 0x56, 0x4b, 0x79, 0x87,
+	0x67, 0xbe, 0x4d, 0x12, 0xaf, 0x43, 0x73, 0x64, 0x9d, 0xca, 0x53, 0x4f, 0xd3, 0xf6, 0x58, 0x52,
+	0x65, 0x6d, 0xa4, 0xf4, 0x6d, 0x24, 0x63, 0x87, 0xfa, 0xf1, 0x60, 0x80, 0x8b, 0x43, 0xed, 0x9b,
+	0x03, 0x7c, 0x84, 0x1c, 0xec, 0x35, 0xc9, 0x94, 0x97, 0x1e, 0xee, 0x21, 0x4b, 0x6b, 0xc3, 0x0d,
+	0x25, 0x93, 0xe9, 0x58, 0x26, 0x88, 0x13, 0x41, 0xd8, 0x35, 0x09, 0xda, 0x4e, 0x30, 0x69, 0x44,
+	0xf7, 0x2e, 0xe8, 0x89, 0x0a, 0xec, 0x47, 0x56, 0x5a, 0x84, 0x68, 0xaa, 0x5b, 0xd4, 0xcd, 0x54,
+	0xf0, 0xd3, 0x70, 0x6d, 0xb2, 0xbf, 0x19, 0xd1, 0x32, 0x89, 0x5e, 0xc9, 0x77, 0x7a, 0xe6, 0x53,
+	0xe9, 0x53, 0x9f, 0xac, 0x28, 0xe4, 0x9e, 0x4a, 0x1f, 0xfa, 0x24, 0x82, 0xad, 0x6f, 0x2c, 0xc1,
+	0xe6, 0xec, 0xfb, 0x75, 0x94, 0x6e, 0x78, 0x41, 0x64, 0x66, 0xae, 0x47, 0x94, 0x90, 0x40, 0xbb,
+	0xe8, 0x26, 0x2c, 0x8f, 0xbd, 0x18, 0x3f, 0x35, 0x10, 0x6b, 0x4a, 0xb6, 0x7e, 0xb6, 0xa1, 0xc7,
+	0x26, 0x2c, 0x8b, 0x4f, 0x7c, 0xa4, 0x57, 0x96, 0x2d, 0x91, 0xdc, 0xd1, 0xb6, 0x6c, 0x7a, 0x5c,
+	0x5d, 0xca, 0x02, 0x49, 0xda, 0xe5, 0x74, 0xac, 0x45, 0x69, 0x6b, 0x38, 0x62, 0x8e, 0x29, 0xaf,
+	0x7a, 0x71, 0x5f, 0x9d, 0x53, 0x24, 0xac, 0x7b, 0xc8, 0x41, 0x3c, 0xa5, 0x0c, 0x42, 0x61, 0x72,
+	0x91, 0x4b, 0x94, 0x0d, 0xea, 0x92, 0xae, 0x2e, 0x87, 0xbe, 0x05, 0xeb, 0x62, 0xcb, 0x98, 0x40,
+	0x8b, 0xcc, 0x77, 0x95, 0xb6, 0x8d, 0x9c, 0xc0, 0xbb, 0xa0, 0x4f, 0x76, 0x25, 0x11, 0x12, 0x3e,
+	0x7d, 0x23, 0xdf, 0x1f, 0x25, 0xf8, 0x3e, 0x3c, 0x8f, 0x4f, 0x3a, 0x57, 0x58, 0xa4, 0xcb, 0x78,
+	0x16, 0xba, 0x3d, 0x53, 0xfe, 0x2e, 0xbc, 0x70, 0x9e, 0xac, 0xbc, 0x5f, 0x26, 0xf6, 0x82, 0x6b,
+	0x33, 0x1f, 0x2f, 0xae, 0x9a, 0xed, 0x40, 0xeb, 0xa2, 0x3e, 0x48, 0x3d, 0x22, 0xf3, 0xbe, 0x71,
+	0x5e, 0x4f, 0x84, 0x2a, 0x1d, 0x56, 0x78, 0x64, 0x79, 0x1e, 0x73, 0x64, 0x32, 0xae, 0x9a, 0xad,
+	0x4f, 0x42, 0x35, 0xfb, 0xf5, 0xe1, 0xcc, 0x3b, 0xf9, 0xb9, 0xe3, 0xc1, 0x82, 0x3c, 0x1e, 0x6c,
+	0xfd, 0xee, 0x02, 0x94, 0xd4, 0xc7, 0x83, 0x33, 0xdc, 0xfe, 0x75, 0xa0, 0x0f, 0x0a, 0xb3, 0x87,
+	0xf3, 0x25, 0x24, 0x50, 0xc0, 0xae, 0xc1, 0xe2, 0x28, 0x70, 0x92, 0x83, 0x34, 0xfc, 0x3d, 0xcf,
+	0x7d, 0xb5, 0x59, 0xc6, 0xbb, 0x34, 0x57, 0xfc, 0xb1, 0x3c, 0x5f, 0xfc, 0xb1, 0x32, 0x33, 0xfe,
+	0x78, 0x19, 0x92, 0x2f, 0x1a, 0xcd, 0xb1, 0x38, 0x14, 0xc7, 0x12, 0x66, 0x55, 0x11, 0x0f, 0x5c,
+	0x87, 0xb7, 0xfe, 0xa7, 0x00, 0x8d, 0x89, 0x0f, 0x21, 0x67, 0x6f, 0x86, 0x33, 0xae, 0x57, 0xde,
+	0x00, 0x10, 0xb7, 0x87, 0x22, 0x76, 0x1a, 0xc9, 0x41, 0x11, 0xf7, 0x89, 0x7a, 0xec, 0x94, 0x8e,
+	0x5a, 0x92, 0xaf, 0x39, 0xb3, 0x43, 0xa3, 0x3e, 0xd8, 0xc4, 0x3e, 0xbe, 0x0e, 0x68, 0xfe, 0x66,
+	0x1e, 0x97, 0x8e, 0xcd, 0x6e, 0x06, 0x8a, 0xc9, 0xe3, 0x99, 0xed, 0xa9, 0x2f, 0x51, 0x44, 0x43,
+	0x7b, 0x1f, 0x56, 0xfa, 0xf2, 0x90, 0x7c, 0xe5, 0x19, 0xbe, 0xf5, 0x54, 0x42, 0xad, 0x3f, 0x2f,
+	0x00, 0xa4, 0x9f, 0x6f, 0xe2, 0x51, 0xcc, 0x20, 0x64, 0xec, 0x23, 0x66, 0x62, 0x38, 0x8b, 0x25,
+	0x32, 0xb1, 0xe1, 0x55, 0x05, 0xf5, 0x81, 0x75, 0x8a, 0x97, 0xae, 0xbb, 0xe2, 0xfc, 0x18, 0x67,
+	0x0d, 0x4b, 0x15, 0xe7, 0xdf, 0x98, 0x57, 0x73, 0x9b, 0x3e, 0xc1, 0x48, 0x25, 0xb5, 0xcf, 0xc0,
+	0xb2, 0xd8, 0x8d, 0xf5, 0xe2, 0x05, 0x5d, 0xa7, 0x4b, 0x65, 0x19, 0x05, 0x52, 0xa6, 0xf5, 0xd3,
+	0x02, 0x68, 0xd3, 0xfa, 0xe7, 0x49, 0x9d, 0x67, 0xd9, 0xe3, 0xc2, 0x4c, 0x7b, 0xbc, 0x02, 0x2b,
+	0xa7, 0xae, 0x63, 0xaa, 0x52, 0x61, 0xd1, 0x58, 0x3e, 0x75, 0x1d, 0x1c, 0x81, 0xb7, 0x61, 0x33,
+	0x3f, 0x4e, 0xb8, 0x79, 0xd8, 0x98, 0xf4, 0x8b, 0x7d, 0x78, 0x2d, 0x3b, 0x5e, 0x07, 0x82, 0xa5,
+	0xbd, 0x0b, 0x8b, 0xa1, 0xcb, 0x4f, 0x64, 0x1e, 0xfd, 0xf2, 0x65, 0x9f, 0xd2, 0xba, 0xfc, 0xc4,
+	0x20, 0x81, 0xd6, 0x1f, 0x2d, 0x40, 0x63, 0x62, 0x18, 0x26, 0x13, 0xbb, 0xc2, 0xe5, 0x89, 0xdd,
+	0xc2, 0x8c, 0xc4, 0x6e, 0x72, 0xc1, 0x15, 0xe7, 0x5b, 0x70, 0x8b, 0x33, 0x17, 0x5c, 0x66, 0xb4,
+	0x96, 0xe6, 0x1c, 0xad, 0xe5, 0xcb, 0x47, 0x6b, 0xe5, 0x59, 0x47, 0xeb, 0x6b, 0xa0, 0x49, 0x33,
+	0xcf, 0x7c, 0x2f, 0x9c, 0x5e, 0x9a, 0x2d, 0xfc, 0x4c, 0x2e, 0xcd, 0x62, 0xc9, 0x97, 0x14, 0xcb,
+	0x84, 0x4b, 0xb6, 0xde, 0xf8, 0x3a, 0xd4, 0xf3, 0x9d, 0xc2, 0x3b, 0xa0, 0x8f, 0x8c, 0xf6, 0x41,
+	0x5b, 0x5c, 0xde, 0x34, 0x76, 0x0e, 0x3f, 0x34, 0x77, 0xf7, 0x1f, 0x35, 0x9f, 0xd3, 0x9e, 0x07,
+	0x7d, 0x92, 0xd1, 0xdd, 0xed, 0x3e, 0x6c, 0xf7, 0xa8, 0x08, 0xa2, 0xc3, 0xfa, 0x24, 0xf7, 0xfe,
+	0xce, 0xe7, 0xef, 0x37, 0x17, 0x66, 0xc9, 0x6d, 0x1b, 0x3b, 0xbd, 0x9d, 0xed, 0xf6, 0x6e, 0xb3,
+	0xd8, 0x5f, 0xa6, 0x74, 0xfc, 0xed, 0xff, 0x1d, 0x00, 0xdb, 0x4f, 0x98, 0x46, 0x8e, 0x4d, 0x00,
+	0x00,
 }
@@ -39,7 +39,7 @@ func (x QueryExplainInformation_ExplainFormat) String() string {
 	return proto.EnumName(QueryExplainInformation_ExplainFormat_name, int32(x))
 }
 func (QueryExplainInformation_ExplainFormat) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{10, 0}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{10, 0}
 }
 
 type QueryExplainInformation_ExplainSource int32
@@ -68,7 +68,7 @@ func (x QueryExplainInformation_ExplainSource) String() string {
 	return proto.EnumName(QueryExplainInformation_ExplainSource_name, int32(x))
 }
 func (QueryExplainInformation_ExplainSource) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{10, 1}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{10, 1}
 }
 
 type SystemInformation_SystemType int32
@@ -94,7 +94,7 @@ func (x SystemInformation_SystemType) String() string {
 	return proto.EnumName(SystemInformation_SystemType_name, int32(x))
 }
 func (SystemInformation_SystemType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{12, 0}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{12, 0}
 }
 
 type NullString struct {
@@ -109,7 +109,7 @@ func (m *NullString) Reset()         { *m = NullString{} }
 func (m *NullString) String() string { return proto.CompactTextString(m) }
 func (*NullString) ProtoMessage()    {}
 func (*NullString) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{0}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{0}
 }
 func (m *NullString) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NullString.Unmarshal(m, b)
@@ -155,7 +155,7 @@ func (m *NullTimestamp) Reset()         { *m = NullTimestamp{} }
 func (m *NullTimestamp) String() string { return proto.CompactTextString(m) }
 func (*NullTimestamp) ProtoMessage()    {}
 func (*NullTimestamp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{1}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{1}
 }
 func (m *NullTimestamp) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NullTimestamp.Unmarshal(m, b)
@@ -202,7 +202,7 @@ func (m *PostgresVersion) Reset()         { *m = PostgresVersion{} }
 func (m *PostgresVersion) String() string { return proto.CompactTextString(m) }
 func (*PostgresVersion) ProtoMessage()    {}
 func (*PostgresVersion) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{2}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{2}
 }
 func (m *PostgresVersion) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PostgresVersion.Unmarshal(m, b)
@@ -254,7 +254,7 @@ func (m *RoleReference) Reset()         { *m = RoleReference{} }
 func (m *RoleReference) String() string { return proto.CompactTextString(m) }
 func (*RoleReference) ProtoMessage()    {}
 func (*RoleReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{3}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{3}
 }
 func (m *RoleReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RoleReference.Unmarshal(m, b)
@@ -292,7 +292,7 @@ func (m *DatabaseReference) Reset()         { *m = DatabaseReference{} }
 func (m *DatabaseReference) String() string { return proto.CompactTextString(m) }
 func (*DatabaseReference) ProtoMessage()    {}
 func (*DatabaseReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{4}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{4}
 }
 func (m *DatabaseReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DatabaseReference.Unmarshal(m, b)
@@ -332,7 +332,7 @@ func (m *RelationReference) Reset()         { *m = RelationReference{} }
 func (m *RelationReference) String() string { return proto.CompactTextString(m) }
 func (*RelationReference) ProtoMessage()    {}
 func (*RelationReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{5}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{5}
 }
 func (m *RelationReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RelationReference.Unmarshal(m, b)
@@ -386,7 +386,7 @@ func (m *IndexReference) Reset()         { *m = IndexReference{} }
 func (m *IndexReference) String() string { return proto.CompactTextString(m) }
 func (*IndexReference) ProtoMessage()    {}
 func (*IndexReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{6}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{6}
 }
 func (m *IndexReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IndexReference.Unmarshal(m, b)
@@ -441,7 +441,7 @@ func (m *FunctionReference) Reset()         { *m = FunctionReference{} }
 func (m *FunctionReference) String() string { return proto.CompactTextString(m) }
 func (*FunctionReference) ProtoMessage()    {}
 func (*FunctionReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{7}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{7}
 }
 func (m *FunctionReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FunctionReference.Unmarshal(m, b)
@@ -502,7 +502,7 @@ func (m *QueryReference) Reset()         { *m = QueryReference{} }
 func (m *QueryReference) String() string { return proto.CompactTextString(m) }
 func (*QueryReference) ProtoMessage()    {}
 func (*QueryReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{8}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{8}
 }
 func (m *QueryReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryReference.Unmarshal(m, b)
@@ -556,7 +556,7 @@ func (m *QueryInformation) Reset()         { *m = QueryInformation{} }
 func (m *QueryInformation) String() string { return proto.CompactTextString(m) }
 func (*QueryInformation) ProtoMessage()    {}
 func (*QueryInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{9}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{9}
 }
 func (m *QueryInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryInformation.Unmarshal(m, b)
@@ -612,7 +612,7 @@ func (m *QueryExplainInformation) Reset()         { *m = QueryExplainInformation
 func (m *QueryExplainInformation) String() string { return proto.CompactTextString(m) }
 func (*QueryExplainInformation) ProtoMessage()    {}
 func (*QueryExplainInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{10}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{10}
 }
 func (m *QueryExplainInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryExplainInformation.Unmarshal(m, b)
@@ -696,7 +696,7 @@ func (m *System) Reset()         { *m = System{} }
 func (m *System) String() string { return proto.CompactTextString(m) }
 func (*System) ProtoMessage()    {}
 func (*System) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{11}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{11}
 }
 func (m *System) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_System.Unmarshal(m, b)
@@ -865,7 +865,7 @@ func (m *SystemInformation) Reset()         { *m = SystemInformation{} }
 func (m *SystemInformation) String() string { return proto.CompactTextString(m) }
 func (*SystemInformation) ProtoMessage()    {}
 func (*SystemInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{12}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{12}
 }
 func (m *SystemInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SystemInformation.Unmarshal(m, b)
@@ -1027,7 +1027,7 @@ func (m *SystemInformationSelfHosted) Reset()         { *m = SystemInformationSe
 func (m *SystemInformationSelfHosted) String() string { return proto.CompactTextString(m) }
 func (*SystemInformationSelfHosted) ProtoMessage()    {}
 func (*SystemInformationSelfHosted) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{13}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{13}
 }
 func (m *SystemInformationSelfHosted) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SystemInformationSelfHosted.Unmarshal(m, b)
@@ -1142,7 +1142,7 @@ func (m *SystemInformationAmazonRDS) Reset()         { *m = SystemInformationAma
 func (m *SystemInformationAmazonRDS) String() string { return proto.CompactTextString(m) }
 func (*SystemInformationAmazonRDS) ProtoMessage()    {}
 func (*SystemInformationAmazonRDS) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{14}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{14}
 }
 func (m *SystemInformationAmazonRDS) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SystemInformationAmazonRDS.Unmarshal(m, b)
@@ -1329,7 +1329,7 @@ func (m *SchedulerStatistic) Reset()         { *m = SchedulerStatistic{} }
 func (m *SchedulerStatistic) String() string { return proto.CompactTextString(m) }
 func (*SchedulerStatistic) ProtoMessage()    {}
 func (*SchedulerStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{15}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{15}
 }
 func (m *SchedulerStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SchedulerStatistic.Unmarshal(m, b)
@@ -1371,26 +1371,37 @@ func (m *SchedulerStatistic) GetLoadAverage_15Min() float64 {
 }
 
 type MemoryStatistic struct {
-	TotalBytes           uint64   `protobuf:"varint,1,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
-	CachedBytes          uint64   `protobuf:"varint,2,opt,name=cached_bytes,json=cachedBytes,proto3" json:"cached_bytes,omitempty"`
-	BuffersBytes         uint64   `protobuf:"varint,3,opt,name=buffers_bytes,json=buffersBytes,proto3" json:"buffers_bytes,omitempty"`
-	FreeBytes            uint64   `protobuf:"varint,4,opt,name=free_bytes,json=freeBytes,proto3" json:"free_bytes,omitempty"`
-	WritebackBytes       uint64   `protobuf:"varint,5,opt,name=writeback_bytes,json=writebackBytes,proto3" json:"writeback_bytes,omitempty"`
-	DirtyBytes           uint64   `protobuf:"varint,6,opt,name=dirty_bytes,json=dirtyBytes,proto3" json:"dirty_bytes,omitempty"`
-	SlabBytes            uint64   `protobuf:"varint,7,opt,name=slab_bytes,json=slabBytes,proto3" json:"slab_bytes,omitempty"`
-	MappedBytes          uint64   `protobuf:"varint,8,opt,name=mapped_bytes,json=mappedBytes,proto3" json:"mapped_bytes,omitempty"`
-	PageTablesBytes      uint64   `protobuf:"varint,9,opt,name=page_tables_bytes,json=pageTablesBytes,proto3" json:"page_tables_bytes,omitempty"`
-	ActiveBytes          uint64   `protobuf:"varint,10,opt,name=active_bytes,json=activeBytes,proto3" json:"active_bytes,omitempty"`
-	InactiveBytes        uint64   `protobuf:"varint,11,opt,name=inactive_bytes,json=inactiveBytes,proto3" json:"inactive_bytes,omitempty"`
-	AvailableBytes       uint64   `protobuf:"varint,12,opt,name=available_bytes,json=availableBytes,proto3" json:"available_bytes,omitempty"`
-	SwapUsedBytes        uint64   `protobuf:"varint,13,opt,name=swap_used_bytes,json=swapUsedBytes,proto3" json:"swap_used_bytes,omitempty"`
-	SwapTotalBytes       uint64   `protobuf:"varint,14,opt,name=swap_total_bytes,json=swapTotalBytes,proto3" json:"swap_total_bytes,omitempty"`
-	HugePagesSizeBytes   uint64   `protobuf:"varint,20,opt,name=huge_pages_size_bytes,json=hugePagesSizeBytes,proto3" json:"huge_pages_size_bytes,omitempty"`
-	HugePagesFree        uint64   `protobuf:"varint,21,opt,name=huge_pages_free,json=hugePagesFree,proto3" json:"huge_pages_free,omitempty"`
-	HugePagesTotal       uint64   `protobuf:"varint,22,opt,name=huge_pages_total,json=hugePagesTotal,proto3" json:"huge_pages_total,omitempty"`
-	HugePagesReserved    uint64   `protobuf:"varint,23,opt,name=huge_pages_reserved,json=hugePagesReserved,proto3" json:"huge_pages_reserved,omitempty"`
-	HugePagesSurplus     uint64   `protobuf:"varint,24,opt,name=huge_pages_surplus,json=hugePagesSurplus,proto3" json:"huge_pages_surplus,omitempty"`
-	ApplicationBytes     uint64   `protobuf:"varint,30,opt,name=application_bytes,json=applicationBytes,proto3" json:"application_bytes,omitempty"`
+	TotalBytes         uint64 `protobuf:"varint,1,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	CachedBytes        uint64 `protobuf:"varint,2,opt,name=cached_bytes,json=cachedBytes,proto3" json:"cached_bytes,omitempty"`
+	BuffersBytes       uint64 `protobuf:"varint,3,opt,name=buffers_bytes,json=buffersBytes,proto3" json:"buffers_bytes,omitempty"`
+	FreeBytes          uint64 `protobuf:"varint,4,opt,name=free_bytes,json=freeBytes,proto3" json:"free_bytes,omitempty"`
+	WritebackBytes     uint64 `protobuf:"varint,5,opt,name=writeback_bytes,json=writebackBytes,proto3" json:"writeback_bytes,omitempty"`
+	DirtyBytes         uint64 `protobuf:"varint,6,opt,name=dirty_bytes,json=dirtyBytes,proto3" json:"dirty_bytes,omitempty"`
+	SlabBytes          uint64 `protobuf:"varint,7,opt,name=slab_bytes,json=slabBytes,proto3" json:"slab_bytes,omitempty"`
+	MappedBytes        uint64 `protobuf:"varint,8,opt,name=mapped_bytes,json=mappedBytes,proto3" json:"mapped_bytes,omitempty"`
+	PageTablesBytes    uint64 `protobuf:"varint,9,opt,name=page_tables_bytes,json=pageTablesBytes,proto3" json:"page_tables_bytes,omitempty"`
+	ActiveBytes        uint64 `protobuf:"varint,10,opt,name=active_bytes,json=activeBytes,proto3" json:"active_bytes,omitempty"`
+	InactiveBytes      uint64 `protobuf:"varint,11,opt,name=inactive_bytes,json=inactiveBytes,proto3" json:"inactive_bytes,omitempty"`
+	AvailableBytes     uint64 `protobuf:"varint,12,opt,name=available_bytes,json=availableBytes,proto3" json:"available_bytes,omitempty"`
+	SwapUsedBytes      uint64 `protobuf:"varint,13,opt,name=swap_used_bytes,json=swapUsedBytes,proto3" json:"swap_used_bytes,omitempty"`
+	SwapTotalBytes     uint64 `protobuf:"varint,14,opt,name=swap_total_bytes,json=swapTotalBytes,proto3" json:"swap_total_bytes,omitempty"`
+	HugePagesSizeBytes uint64 `protobuf:"varint,20,opt,name=huge_pages_size_bytes,json=hugePagesSizeBytes,proto3" json:"huge_pages_size_bytes,omitempty"`
+	HugePagesFree      uint64 `protobuf:"varint,21,opt,name=huge_pages_free,json=hugePagesFree,proto3" json:"huge_pages_free,omitempty"`
+	HugePagesTotal     uint64 `protobuf:"varint,22,opt,name=huge_pages_total,json=hugePagesTotal,proto3" json:"huge_pages_total,omitempty"`
+	HugePagesReserved  uint64 `protobuf:"varint,23,opt,name=huge_pages_reserved,json=hugePagesReserved,proto3" json:"huge_pages_reserved,omitempty"`
+	HugePagesSurplus   uint64 `protobuf:"varint,24,opt,name=huge_pages_surplus,json=hugePagesSurplus,proto3" json:"huge_pages_surplus,omitempty"`
+	ApplicationBytes   uint64 `protobuf:"varint,30,opt,name=application_bytes,json=applicationBytes,proto3" json:"application_bytes,omitempty"`
+	// Memory swapped in since the last snapshot
+	SwapInBytes uint64 `protobuf:"varint,15,opt,name=swap_in_bytes,json=swapInBytes,proto3" json:"swap_in_bytes,omitempty"`
+	// Memory swapped out since the last snapshot
+	SwapOutBytes uint64 `protobuf:"varint,16,opt,name=swap_out_bytes,json=swapOutBytes,proto3" json:"swap_out_bytes,omitempty"`
+	// Changes since the last snapshot (zero on the first snapshot)
+	AvailableBytesDelta int64 `protobuf:"varint,40,opt,name=available_bytes_delta,json=availableBytesDelta,proto3" json:"available_bytes_delta,omitempty"`
+	FreeBytesDelta      int64 `protobuf:"varint,41,opt,name=free_bytes_delta,json=freeBytesDelta,proto3" json:"free_bytes_delta,omitempty"`
+	SwapUsedBytesDelta  int64 `protobuf:"varint,42,opt,name=swap_used_bytes_delta,json=swapUsedBytesDelta,proto3" json:"swap_used_bytes_delta,omitempty"`
+	// Whether available memory dropped sharply or swap activity spiked since the last
+	// snapshot, which puts Postgres backends at risk of getting terminated by the OOM killer
+	LikelyMemoryPressure bool     `protobuf:"varint,43,opt,name=likely_memory_pressure,json=likelyMemoryPressure,proto3" json:"likely_memory_pressure,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1400,7 +1411,7 @@ func (m *MemoryStatistic) Reset()         { *m = MemoryStatistic{} }
 func (m *MemoryStatistic) String() string { return proto.CompactTextString(m) }
 func (*MemoryStatistic) ProtoMessage()    {}
 func (*MemoryStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{16}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{16}
 }
 func (m *MemoryStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MemoryStatistic.Unmarshal(m, b)
@@ -1560,6 +1571,48 @@ func (m *MemoryStatistic) GetApplicationBytes() uint64 {
 	return 0
 }
 
+func (m *MemoryStatistic) GetSwapInBytes() uint64 {
+	if m != nil {
+		return m.SwapInBytes
+	}
+	return 0
+}
+
+func (m *MemoryStatistic) GetSwapOutBytes() uint64 {
+	if m != nil {
+		return m.SwapOutBytes
+	}
+	return 0
+}
+
+func (m *MemoryStatistic) GetAvailableBytesDelta() int64 {
+	if m != nil {
+		return m.AvailableBytesDelta
+	}
+	return 0
+}
+
+func (m *MemoryStatistic) GetFreeBytesDelta() int64 {
+	if m != nil {
+		return m.FreeBytesDelta
+	}
+	return 0
+}
+
+func (m *MemoryStatistic) GetSwapUsedBytesDelta() int64 {
+	if m != nil {
+		return m.SwapUsedBytesDelta
+	}
+	return 0
+}
+
+func (m *MemoryStatistic) GetLikelyMemoryPressure() bool {
+	if m != nil {
+		return m.LikelyMemoryPressure
+	}
+	return false
+}
+
 type CPUInformation struct {
 	Model                string   `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
 	CacheSizeBytes       int32    `protobuf:"varint,2,opt,name=cache_size_bytes,json=cacheSizeBytes,proto3" json:"cache_size_bytes,omitempty"`
@@ -1576,7 +1629,7 @@ func (m *CPUInformation) Reset()         { *m = CPUInformation{} }
 func (m *CPUInformation) String() string { return proto.CompactTextString(m) }
 func (*CPUInformation) ProtoMessage()    {}
 func (*CPUInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{17}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{17}
 }
 func (m *CPUInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CPUInformation.Unmarshal(m, b)
@@ -1649,7 +1702,7 @@ func (m *CPUReference) Reset()         { *m = CPUReference{} }
 func (m *CPUReference) String() string { return proto.CompactTextString(m) }
 func (*CPUReference) ProtoMessage()    {}
 func (*CPUReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{18}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{18}
 }
 func (m *CPUReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CPUReference.Unmarshal(m, b)
@@ -1697,7 +1750,7 @@ func (m *CPUStatistic) Reset()         { *m = CPUStatistic{} }
 func (m *CPUStatistic) String() string { return proto.CompactTextString(m) }
 func (*CPUStatistic) ProtoMessage()    {}
 func (*CPUStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{19}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{19}
 }
 func (m *CPUStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CPUStatistic.Unmarshal(m, b)
@@ -1805,7 +1858,7 @@ func (m *NetworkReference) Reset()         { *m = NetworkReference{} }
 func (m *NetworkReference) String() string { return proto.CompactTextString(m) }
 func (*NetworkReference) ProtoMessage()    {}
 func (*NetworkReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{20}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{20}
 }
 func (m *NetworkReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkReference.Unmarshal(m, b)
@@ -1845,7 +1898,7 @@ func (m *NetworkStatistic) Reset()         { *m = NetworkStatistic{} }
 func (m *NetworkStatistic) String() string { return proto.CompactTextString(m) }
 func (*NetworkStatistic) ProtoMessage()    {}
 func (*NetworkStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{21}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{21}
 }
 func (m *NetworkStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetworkStatistic.Unmarshal(m, b)
@@ -1897,7 +1950,7 @@ func (m *DiskReference) Reset()         { *m = DiskReference{} }
 func (m *DiskReference) String() string { return proto.CompactTextString(m) }
 func (*DiskReference) ProtoMessage()    {}
 func (*DiskReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{22}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{22}
 }
 func (m *DiskReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiskReference.Unmarshal(m, b)
@@ -1939,7 +1992,7 @@ func (m *DiskInformation) Reset()         { *m = DiskInformation{} }
 func (m *DiskInformation) String() string { return proto.CompactTextString(m) }
 func (*DiskInformation) ProtoMessage()    {}
 func (*DiskInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{23}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{23}
 }
 func (m *DiskInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiskInformation.Unmarshal(m, b)
@@ -2015,7 +2068,7 @@ func (m *DiskStatistic) Reset()         { *m = DiskStatistic{} }
 func (m *DiskStatistic) String() string { return proto.CompactTextString(m) }
 func (*DiskStatistic) ProtoMessage()    {}
 func (*DiskStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{24}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{24}
 }
 func (m *DiskStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiskStatistic.Unmarshal(m, b)
@@ -2123,7 +2176,7 @@ func (m *DiskPartitionReference) Reset()         { *m = DiskPartitionReference{}
 func (m *DiskPartitionReference) String() string { return proto.CompactTextString(m) }
 func (*DiskPartitionReference) ProtoMessage()    {}
 func (*DiskPartitionReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{25}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{25}
 }
 func (m *DiskPartitionReference) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiskPartitionReference.Unmarshal(m, b)
@@ -2165,7 +2218,7 @@ func (m *DiskPartitionInformation) Reset()         { *m = DiskPartitionInformati
 func (m *DiskPartitionInformation) String() string { return proto.CompactTextString(m) }
 func (*DiskPartitionInformation) ProtoMessage()    {}
 func (*DiskPartitionInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{26}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{26}
 }
 func (m *DiskPartitionInformation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiskPartitionInformation.Unmarshal(m, b)
@@ -2233,7 +2286,7 @@ func (m *DiskPartitionStatistic) Reset()         { *m = DiskPartitionStatistic{}
 func (m *DiskPartitionStatistic) String() string { return proto.CompactTextString(m) }
 func (*DiskPartitionStatistic) ProtoMessage()    {}
 func (*DiskPartitionStatistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shared_3c5ff1423a6309ee, []int{27}
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{27}
 }
 func (m *DiskPartitionStatistic) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiskPartitionStatistic.Unmarshal(m, b)
@@ -2274,6 +2327,53 @@ func (m *DiskPartitionStatistic) GetTotalBytes() uint64 {
 	return 0
 }
 
+// Custom key/value label configured for the server
+type Tag struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Tag) Reset()         { *m = Tag{} }
+func (m *Tag) String() string { return proto.CompactTextString(m) }
+func (*Tag) ProtoMessage()    {}
+func (*Tag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shared_c23f4d9a246b2a27, []int{28}
+}
+func (m *Tag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tag.Unmarshal(m, b)
+}
+func (m *Tag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tag.Marshal(b, m, deterministic)
+}
+func (dst *Tag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tag.Merge(dst, src)
+}
+func (m *Tag) XXX_Size() int {
+	return xxx_messageInfo_Tag.Size(m)
+}
+func (m *Tag) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tag proto.InternalMessageInfo
+
+func (m *Tag) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Tag) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*NullString)(nil), "pganalyze.collector.NullString")
 	proto.RegisterType((*NullTimestamp)(nil), "pganalyze.collector.NullTimestamp")
@@ -2303,202 +2403,210 @@ func init() {
 	proto.RegisterType((*DiskPartitionReference)(nil), "pganalyze.collector.DiskPartitionReference")
 	proto.RegisterType((*DiskPartitionInformation)(nil), "pganalyze.collector.DiskPartitionInformation")
 	proto.RegisterType((*DiskPartitionStatistic)(nil), "pganalyze.collector.DiskPartitionStatistic")
+	proto.RegisterType((*Tag)(nil), "pganalyze.collector.Tag")
 	proto.RegisterEnum("pganalyze.collector.QueryExplainInformation_ExplainFormat", QueryExplainInformation_ExplainFormat_name, QueryExplainInformation_ExplainFormat_value)
 	proto.RegisterEnum("pganalyze.collector.QueryExplainInformation_ExplainSource", QueryExplainInformation_ExplainSource_name, QueryExplainInformation_ExplainSource_value)
 	proto.RegisterEnum("pganalyze.collector.SystemInformation_SystemType", SystemInformation_SystemType_name, SystemInformation_SystemType_value)
 }
 
-func init() { proto.RegisterFile("shared.proto", fileDescriptor_shared_3c5ff1423a6309ee) }
-
-var fileDescriptor_shared_3c5ff1423a6309ee = []byte{
-	// 3010 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0x4b, 0x77, 0x1b, 0x37,
-	0x96, 0x36, 0xad, 0x27, 0x2f, 0xc5, 0x17, 0xac, 0x07, 0x2d, 0xd9, 0xb1, 0x4c, 0xdb, 0xb1, 0xf2,
-	0x18, 0x39, 0x76, 0x26, 0x93, 0x64, 0x32, 0x2f, 0xc6, 0xa2, 0x63, 0x4d, 0xac, 0x47, 0x8a, 0xd4,
-	0xc4, 0x93, 0x4d, 0x1d, 0xa8, 0x0a, 0xa4, 0x30, 0x2e, 0x56, 0x95, 0x01, 0x94, 0x2c, 0xea, 0xcc,
-	0x7a, 0x36, 0xb3, 0x9b, 0xdd, 0x64, 0xd7, 0x9b, 0xde, 0xa6, 0xff, 0x4a, 0xf7, 0xba, 0x7f, 0x43,
-	0xff, 0x86, 0x3e, 0xb8, 0x28, 0x54, 0x15, 0x29, 0xc9, 0x76, 0xfa, 0x74, 0xef, 0x88, 0xef, 0x7e,
-	0xf7, 0xc3, 0xbd, 0x40, 0x01, 0xb8, 0x00, 0x61, 0x49, 0x9e, 0x50, 0xc1, 0xfc, 0xed, 0x58, 0x44,
-	0x2a, 0x22, 0x37, 0xe2, 0x21, 0x0d, 0x69, 0x30, 0x3e, 0x67, 0xdb, 0x5e, 0x14, 0x04, 0xcc, 0x53,
-	0x91, 0x58, 0xbf, 0x33, 0x8c, 0xa2, 0x61, 0xc0, 0x1e, 0x21, 0xe5, 0x38, 0x19, 0x3c, 0x52, 0x7c,
-	0xc4, 0xa4, 0xa2, 0xa3, 0xd8, 0x78, 0xb5, 0xbf, 0x02, 0xd8, 0x4f, 0x82, 0xa0, 0xa7, 0x04, 0x0f,
-	0x87, 0x64, 0x19, 0xe6, 0x4e, 0x69, 0xc0, 0xfd, 0x56, 0x69, 0xb3, 0xb4, 0xb5, 0xe8, 0x98, 0x46,
-	0x8a, 0x26, 0xac, 0x75, 0x7d, 0xb3, 0xb4, 0x55, 0x76, 0x4c, 0xa3, 0xfd, 0x23, 0x54, 0xb5, 0x67,
-	0xdf, 0x0a, 0x5e, 0xe1, 0xfc, 0x59, 0xd1, 0xb9, 0xf2, 0x64, 0x7d, 0xdb, 0x44, 0xb4, 0x6d, 0x23,
-	0xda, 0xce, 0x04, 0xac, 0xf0, 0x11, 0xd4, 0x0f, 0x23, 0xa9, 0x86, 0x82, 0xc9, 0xff, 0x60, 0x42,
-	0xf2, 0x28, 0x24, 0x04, 0x66, 0x07, 0x49, 0x10, 0xa0, 0x72, 0xd9, 0xc1, 0xdf, 0xba, 0x3b, 0x79,
-	0x12, 0x09, 0x65, 0xa3, 0xc2, 0x06, 0x69, 0xc1, 0x42, 0x98, 0x8c, 0x98, 0xe0, 0x5e, 0x6b, 0x66,
-	0xb3, 0xb4, 0x35, 0xe3, 0xd8, 0x66, 0xfb, 0x1e, 0x54, 0x9d, 0x28, 0x60, 0x0e, 0x1b, 0x30, 0xc1,
-	0x42, 0x8f, 0x69, 0xd1, 0x90, 0x8e, 0x98, 0x15, 0xd5, 0xbf, 0xdb, 0x0f, 0xa1, 0xb9, 0x43, 0x15,
-	0x3d, 0xa6, 0xf2, 0x1d, 0xc4, 0xff, 0x86, 0xa6, 0xc3, 0x02, 0xaa, 0x78, 0x14, 0xe6, 0xc4, 0xbb,
-	0xb0, 0xe4, 0xa7, 0xde, 0x2e, 0xf7, 0xcf, 0xd0, 0x61, 0xce, 0xa9, 0x58, 0x6c, 0xd7, 0x3f, 0x23,
-	0x77, 0xa0, 0x22, 0xbd, 0x13, 0x36, 0xa2, 0x2e, 0x4a, 0x9a, 0xd8, 0xc1, 0x40, 0xfb, 0x74, 0xc4,
-	0xc8, 0x3d, 0xa8, 0x8a, 0x54, 0xd8, 0x50, 0x66, 0x90, 0xb2, 0x64, 0x41, 0x4d, 0x6a, 0x4b, 0xa8,
-	0xed, 0x86, 0x3e, 0x3b, 0xfb, 0xeb, 0x76, 0x7d, 0x1b, 0x80, 0x6b, 0xd5, 0x62, 0xbf, 0x65, 0x44,
-	0xb0, 0xd3, 0x9f, 0x4b, 0xd0, 0x7c, 0x96, 0x84, 0xde, 0xdf, 0x24, 0xe7, 0x41, 0x2a, 0x3c, 0x91,
-	0xb3, 0x05, 0x91, 0x74, 0x0b, 0xca, 0x54, 0x0c, 0x93, 0x11, 0x0b, 0x95, 0x6c, 0xcd, 0x9a, 0xe0,
-	0x32, 0xa0, 0x1d, 0x43, 0xed, 0x87, 0x84, 0x89, 0xf1, 0xaf, 0x0a, 0xec, 0x26, 0x2c, 0x8a, 0x28,
-	0x30, 0xe6, 0xeb, 0x68, 0x5e, 0xd0, 0x6d, 0x6d, 0xda, 0x84, 0xca, 0x80, 0x87, 0x43, 0x26, 0x62,
-	0xc1, 0x43, 0x85, 0x01, 0x2d, 0x39, 0x45, 0xa8, 0xfd, 0x06, 0x1a, 0xd8, 0xe3, 0x6e, 0x38, 0x88,
-	0xc4, 0x08, 0xe7, 0x86, 0x6c, 0x40, 0xf9, 0xb5, 0xc6, 0x0a, 0x1d, 0x2e, 0x22, 0xa0, 0x25, 0x3f,
-	0x82, 0x46, 0xa8, 0x99, 0x01, 0x3f, 0x67, 0xbe, 0x8b, 0x70, 0x3a, 0x16, 0xf5, 0x1c, 0x47, 0xc9,
-	0xa2, 0x8e, 0x6c, 0xcd, 0x6c, 0xce, 0x6c, 0xcd, 0x64, 0x3a, 0xb2, 0xfd, 0xff, 0xb3, 0xb0, 0x86,
-	0xb4, 0xee, 0x59, 0x1c, 0x50, 0x1e, 0xbe, 0x77, 0x00, 0x0f, 0xa0, 0xc6, 0x8c, 0x8b, 0x1b, 0x25,
-	0x2a, 0x4e, 0xec, 0xd2, 0xa9, 0xa6, 0xe8, 0x01, 0x82, 0x7a, 0x36, 0x2c, 0x8d, 0x09, 0x11, 0x09,
-	0x3b, 0x1b, 0x29, 0xd8, 0xd5, 0x18, 0xa1, 0xb9, 0x96, 0xe9, 0x1d, 0xa7, 0xa4, 0xf6, 0xe4, 0x1f,
-	0xb7, 0x2f, 0xd9, 0x86, 0xb6, 0xaf, 0x08, 0x77, 0x3b, 0x85, 0x9e, 0x21, 0x90, 0xc5, 0x61, 0x9a,
-	0xc5, 0x2e, 0x64, 0x94, 0x08, 0x8f, 0xb5, 0xe6, 0xfe, 0xf2, 0x2e, 0x7a, 0xa8, 0x90, 0x75, 0x61,
-	0x9a, 0xed, 0x0e, 0x54, 0x27, 0x42, 0x20, 0x6b, 0x70, 0xa3, 0xdf, 0x7d, 0xd9, 0x77, 0xbb, 0x2f,
-	0x0f, 0x5f, 0x74, 0x76, 0xf7, 0xdd, 0x67, 0x07, 0xce, 0x5e, 0xa7, 0xdf, 0xb8, 0xa6, 0x0d, 0xff,
-	0xde, 0x3b, 0xd8, 0x9f, 0x36, 0x94, 0xda, 0xff, 0x5b, 0xca, 0x34, 0x8c, 0x28, 0xd9, 0x84, 0x5b,
-	0xbd, 0x7e, 0xa7, 0xdf, 0xdd, 0xeb, 0xee, 0xf7, 0xdd, 0x17, 0x07, 0xdf, 0x65, 0x3e, 0xbd, 0x83,
-	0x23, 0xe7, 0x69, 0xb7, 0x71, 0x8d, 0xdc, 0x81, 0x8d, 0xce, 0x51, 0xff, 0x20, 0x33, 0x4c, 0x11,
-	0x4a, 0x64, 0x03, 0xd6, 0xba, 0x2f, 0xfb, 0x5d, 0x67, 0xbf, 0xf3, 0x62, 0xda, 0x78, 0x9d, 0xac,
-	0xc3, 0xea, 0x77, 0xdd, 0xfd, 0xae, 0xb3, 0xfb, 0x74, 0xda, 0x36, 0xd3, 0xfe, 0xa5, 0x02, 0xf3,
-	0xbd, 0xb1, 0x54, 0x6c, 0x44, 0x8e, 0x80, 0x48, 0xfc, 0xe5, 0xf2, 0x7c, 0x38, 0xf0, 0x9b, 0xa8,
-	0x3c, 0xf9, 0xf0, 0xd2, 0x21, 0x34, 0x8e, 0x85, 0xc1, 0x73, 0x9a, 0x72, 0x1a, 0xd2, 0x5f, 0x98,
-	0x95, 0xf5, 0xd3, 0xef, 0x67, 0x31, 0x65, 0xf9, 0x7a, 0xcd, 0xa5, 0x46, 0xe9, 0x45, 0xb1, 0x5d,
-	0xc7, 0x15, 0x83, 0xf5, 0x34, 0x44, 0x5e, 0xc2, 0x0d, 0xbd, 0xf2, 0xfd, 0x24, 0x60, 0xc2, 0x95,
-	0x8a, 0x2a, 0x2e, 0x15, 0xf7, 0x5a, 0x80, 0x71, 0x3d, 0xbc, 0x3c, 0x2e, 0xcb, 0xef, 0x59, 0xba,
-	0x43, 0xe4, 0x05, 0x8c, 0x1c, 0x40, 0x63, 0xc4, 0x46, 0x91, 0x18, 0x17, 0x64, 0x2b, 0x28, 0x7b,
-	0xff, 0x52, 0xd9, 0x3d, 0x24, 0xe7, 0x9a, 0xf5, 0xd1, 0x24, 0x40, 0x5e, 0x40, 0xdd, 0x8b, 0x93,
-	0x89, 0xe1, 0x5b, 0x42, 0xbd, 0x7b, 0x97, 0xea, 0x3d, 0x3d, 0x3c, 0x2a, 0x8e, 0x5d, 0xcd, 0x8b,
-	0x93, 0xe2, 0xc0, 0x3d, 0x07, 0x8d, 0xb8, 0xc2, 0x6e, 0x50, 0xb2, 0x55, 0xdd, 0x9c, 0xd9, 0xaa,
-	0x3c, 0xb9, 0x7b, 0x95, 0x58, 0xb6, 0x95, 0x39, 0x55, 0x2f, 0x4e, 0xb2, 0x96, 0xb4, 0x4a, 0x59,
-	0x96, 0xb2, 0x55, 0x7b, 0xbb, 0x52, 0x9e, 0xa3, 0x56, 0xca, 0x5a, 0x92, 0xf4, 0x81, 0x84, 0x4c,
-	0xbd, 0x89, 0xc4, 0xab, 0x62, 0x5c, 0x75, 0x54, 0x7b, 0x70, 0xa9, 0xda, 0xbe, 0xa1, 0xe7, 0xb1,
-	0x35, 0xc3, 0x29, 0x64, 0x42, 0xb5, 0x10, 0x63, 0xe3, 0xdd, 0xaa, 0x79, 0x9c, 0x56, 0xb5, 0x10,
-	0xeb, 0xf7, 0x50, 0xf7, 0xb9, 0x9c, 0x08, 0xb4, 0x89, 0x92, 0xed, 0x4b, 0x25, 0x77, 0xb8, 0x2c,
-	0x44, 0x59, 0xf3, 0x8b, 0x4d, 0x49, 0x7e, 0x80, 0x26, 0x8a, 0x15, 0xe6, 0x56, 0xb6, 0x08, 0xca,
-	0xdd, 0xbf, 0x52, 0xae, 0x38, 0xbb, 0x0d, 0x7f, 0x12, 0xc8, 0xe3, 0x2b, 0xa4, 0x7c, 0xe3, 0x1d,
-	0xf1, 0xe5, 0xf9, 0x62, 0x7c, 0x85, 0x64, 0x87, 0x70, 0x13, 0xc5, 0x62, 0x2a, 0x14, 0xc7, 0x73,
-	0xb1, 0x90, 0xf6, 0x32, 0xca, 0x7e, 0x72, 0xa5, 0xec, 0xa1, 0x75, 0xca, 0xf3, 0x5f, 0xf3, 0x2f,
-	0xc5, 0x25, 0x19, 0xc1, 0xc6, 0x54, 0x47, 0x13, 0x43, 0xb2, 0x82, 0x5d, 0xfd, 0xdd, 0xbb, 0xbb,
-	0x2a, 0x8e, 0xcd, 0x4d, 0xff, 0x0a, 0xcb, 0x65, 0x79, 0x15, 0x86, 0x6b, 0xf5, 0x7d, 0xf3, 0xca,
-	0xc7, 0x6d, 0x32, 0xaf, 0xc2, 0x00, 0x3e, 0x87, 0xbb, 0xfa, 0xa4, 0x77, 0x7d, 0x2e, 0x50, 0x60,
-	0xec, 0x4e, 0xa7, 0xe9, 0x9f, 0xb5, 0x3e, 0xc0, 0x03, 0xf2, 0xb6, 0x26, 0xee, 0x58, 0xde, 0x64,
-	0x56, 0xfe, 0x19, 0xf9, 0x02, 0xd6, 0xce, 0x82, 0x68, 0x78, 0x99, 0xff, 0x1d, 0xf4, 0x5f, 0xd6,
-	0xe6, 0x0b, 0x6e, 0x1f, 0x42, 0x1d, 0xdd, 0x12, 0xc9, 0x7c, 0xf7, 0x78, 0xac, 0x98, 0x6c, 0x6d,
-	0x6e, 0x96, 0xb6, 0x66, 0x9d, 0xaa, 0x86, 0x8f, 0x24, 0xf3, 0xbf, 0xd5, 0x60, 0xfb, 0xff, 0x66,
-	0xa0, 0x79, 0x61, 0xe3, 0x25, 0x5d, 0x98, 0x55, 0xe3, 0xd8, 0x94, 0x9c, 0xb5, 0x27, 0x8f, 0xdf,
-	0x6f, 0xbb, 0x4e, 0x91, 0xfe, 0x38, 0x66, 0x0e, 0xba, 0x93, 0x1e, 0x54, 0x24, 0x0b, 0x06, 0xee,
-	0x49, 0x24, 0x15, 0xf3, 0xd3, 0x12, 0xfc, 0xb3, 0xf7, 0x53, 0xeb, 0xb1, 0x60, 0xf0, 0x1c, 0xfd,
-	0x9e, 0x5f, 0x73, 0x40, 0x66, 0x2d, 0x72, 0x08, 0x40, 0x47, 0xf4, 0x5c, 0x7f, 0x93, 0x58, 0x9d,
-	0x68, 0xcd, 0x47, 0xef, 0xa7, 0xd9, 0x41, 0x3f, 0x67, 0xa7, 0xf7, 0xfc, 0x9a, 0x53, 0x36, 0x22,
-	0x8e, 0x2f, 0xc9, 0x97, 0x50, 0x3e, 0x8e, 0x22, 0xe5, 0xea, 0xcb, 0x49, 0x7a, 0x12, 0xbc, 0xed,
-	0x9e, 0xb0, 0xa8, 0xc9, 0xba, 0xd9, 0xde, 0x07, 0xc8, 0x73, 0x26, 0xab, 0x40, 0x7a, 0xdd, 0x17,
-	0xcf, 0xdc, 0xe7, 0x07, 0xbd, 0x7e, 0x77, 0xc7, 0xed, 0xfd, 0x67, 0xaf, 0xdf, 0xdd, 0x6b, 0x5c,
-	0x23, 0x2b, 0xd0, 0xec, 0xec, 0x75, 0x7e, 0x3a, 0xd8, 0x77, 0x9d, 0x9d, 0x9e, 0x85, 0x4b, 0xa4,
-	0x09, 0xd5, 0xe7, 0x5d, 0xe7, 0xe0, 0xfb, 0x23, 0x0b, 0x5d, 0xff, 0x76, 0x1e, 0x66, 0xf5, 0xe7,
-	0xaf, 0x27, 0x65, 0xe3, 0x2d, 0x03, 0x42, 0xd6, 0x61, 0x51, 0x0f, 0x69, 0xe1, 0x56, 0x90, 0xb5,
-	0x49, 0x1b, 0x96, 0xa8, 0xf0, 0x4e, 0xb8, 0x62, 0x9e, 0x4a, 0x84, 0x2d, 0x77, 0x27, 0x30, 0x5d,
-	0x0a, 0x46, 0x31, 0x13, 0x54, 0xf1, 0x70, 0xe8, 0x9a, 0xd3, 0x31, 0x3d, 0x2b, 0xeb, 0x19, 0x9e,
-	0x1e, 0xe3, 0xeb, 0xb0, 0x18, 0x07, 0x54, 0xe9, 0x28, 0xd2, 0xaa, 0x37, 0x6b, 0x93, 0x87, 0x50,
-	0xb7, 0xbf, 0xdd, 0x01, 0x1d, 0xf1, 0x60, 0x8c, 0x25, 0x52, 0xd9, 0xa9, 0x59, 0xf8, 0x19, 0xa2,
-	0xba, 0xbf, 0x8c, 0x78, 0x6a, 0xee, 0x54, 0xad, 0x79, 0xd3, 0x9f, 0xc5, 0xed, 0x55, 0xeb, 0x73,
-	0x58, 0x39, 0xe5, 0x42, 0x25, 0xba, 0x1c, 0x35, 0xb7, 0x90, 0x34, 0xbe, 0x05, 0xe4, 0x2f, 0x4f,
-	0x1a, 0xd3, 0x20, 0x1f, 0x40, 0xed, 0x15, 0x13, 0x21, 0x0b, 0x32, 0xf5, 0x45, 0x53, 0x59, 0x1a,
-	0xd4, 0x6a, 0xff, 0x13, 0xac, 0x67, 0x25, 0x79, 0x56, 0x44, 0xb0, 0x50, 0xf1, 0x01, 0x67, 0xa2,
-	0x55, 0x46, 0x97, 0x96, 0x65, 0xa4, 0xe3, 0x9f, 0xd9, 0xdb, 0x7f, 0x58, 0x84, 0xf5, 0xab, 0xbf,
-	0x28, 0xb2, 0x0a, 0xf3, 0x82, 0x0d, 0x6d, 0x8d, 0x53, 0x76, 0xd2, 0x96, 0x8e, 0x8d, 0x87, 0x52,
-	0xd1, 0xd0, 0x63, 0xae, 0x17, 0x50, 0x29, 0x6d, 0xd5, 0x6b, 0xd1, 0xa7, 0x1a, 0xd4, 0x97, 0x94,
-	0x8c, 0xc6, 0xfd, 0x74, 0x36, 0xc0, 0x42, 0xbb, 0xbe, 0xd6, 0xd7, 0x7b, 0x55, 0x62, 0x2f, 0x1f,
-	0x69, 0x8b, 0x7c, 0x02, 0x4d, 0x7a, 0x4a, 0x79, 0x40, 0x8f, 0x79, 0xc0, 0xd5, 0xd8, 0x3d, 0x8f,
-	0x42, 0x96, 0x4e, 0x43, 0xa3, 0x68, 0xf8, 0x29, 0x0a, 0x19, 0x79, 0x04, 0x37, 0xe2, 0xe4, 0x38,
-	0xe0, 0x5e, 0x30, 0x76, 0xa9, 0xe7, 0x31, 0x29, 0xf9, 0x71, 0xc0, 0x70, 0x2e, 0x16, 0x1d, 0x62,
-	0x4d, 0x9d, 0xcc, 0xa2, 0xaf, 0x28, 0xa3, 0x24, 0x50, 0xdc, 0xa5, 0xe7, 0x38, 0x03, 0x8b, 0xce,
-	0x02, 0xb6, 0x3b, 0xe7, 0xe4, 0x5f, 0x60, 0x43, 0x32, 0x2f, 0x0a, 0x7d, 0x2a, 0xc6, 0xee, 0xc5,
-	0x10, 0xcc, 0x0c, 0xdc, 0xcc, 0x28, 0x9d, 0xe9, 0x58, 0x1e, 0x40, 0xcd, 0xa3, 0xae, 0xc7, 0x84,
-	0x1e, 0x5f, 0x8f, 0x2a, 0x96, 0xce, 0x40, 0xd5, 0xa3, 0x4f, 0x73, 0x90, 0x7c, 0x03, 0xeb, 0x34,
-	0x51, 0x91, 0x3b, 0xe2, 0x61, 0x24, 0xec, 0xfc, 0xba, 0x49, 0x3c, 0x14, 0xd4, 0x37, 0xab, 0x75,
-	0xd1, 0x59, 0xd3, 0x8c, 0x3d, 0x4d, 0x48, 0xa7, 0xfa, 0xc8, 0x98, 0x73, 0x67, 0xfa, 0x5f, 0x97,
-	0x38, 0x57, 0x0a, 0xce, 0x9a, 0x30, 0xe5, 0xfc, 0x6f, 0x70, 0x2b, 0xc6, 0x63, 0x4f, 0x30, 0xdf,
-	0x1d, 0x51, 0x1e, 0x2a, 0x16, 0xe2, 0xfc, 0xbc, 0xe1, 0xa1, 0x1f, 0xbd, 0xc1, 0x62, 0xac, 0xec,
-	0xac, 0x67, 0x9c, 0xbd, 0x9c, 0xf2, 0x23, 0x32, 0xc8, 0x3f, 0xc0, 0x5a, 0xae, 0x70, 0x4c, 0xbd,
-	0x57, 0x49, 0x6c, 0x9d, 0x6b, 0xe8, 0xbc, 0x92, 0x99, 0xbf, 0x45, 0x6b, 0xea, 0x77, 0x08, 0xab,
-	0x01, 0x55, 0x4c, 0x2a, 0x57, 0x30, 0xa9, 0x22, 0x41, 0x8f, 0x03, 0x66, 0x76, 0xa7, 0xea, 0x3b,
-	0x77, 0xa7, 0x65, 0xe3, 0xe9, 0x64, 0x8e, 0xda, 0x44, 0xfe, 0x15, 0x6e, 0xa5, 0xfd, 0x0b, 0xa6,
-	0xf4, 0x27, 0x1d, 0x85, 0x6e, 0xcc, 0x04, 0x8f, 0x7c, 0xd7, 0xa7, 0x63, 0x5d, 0x73, 0xe9, 0xa3,
-	0xe4, 0xa6, 0xe1, 0x38, 0x96, 0x72, 0x88, 0x8c, 0x1d, 0x3a, 0x96, 0x7a, 0xad, 0x8f, 0xa8, 0x54,
-	0x4c, 0xe8, 0x13, 0x45, 0xe0, 0xce, 0xd3, 0x30, 0x6b, 0xdd, 0xc0, 0x47, 0x29, 0xaa, 0x0f, 0x1e,
-	0x1e, 0x72, 0xc5, 0x69, 0xe0, 0xfa, 0xc7, 0xe6, 0x3a, 0xdd, 0xb4, 0x1f, 0x3c, 0xc2, 0x3b, 0xc7,
-	0x78, 0x9f, 0xfe, 0x1a, 0xc0, 0x13, 0x8c, 0x2a, 0xe6, 0xbb, 0x54, 0xb5, 0xc8, 0x3b, 0xf3, 0x2a,
-	0xa7, 0xec, 0x8e, 0xd2, 0x5f, 0x31, 0x0b, 0x4f, 0xf4, 0x38, 0xfb, 0xee, 0x28, 0x0a, 0xb9, 0x8a,
-	0x04, 0x0f, 0x87, 0xad, 0x1b, 0xe6, 0x2b, 0xb6, 0xa6, 0xbd, 0xcc, 0x42, 0xfe, 0x1e, 0x56, 0x63,
-	0x2a, 0xe8, 0x88, 0xe9, 0xf8, 0x69, 0x1c, 0x07, 0xa6, 0x46, 0x4f, 0x64, 0x6b, 0xcb, 0xec, 0x2a,
-	0x99, 0xb5, 0xa3, 0x8d, 0x3d, 0xb3, 0xb2, 0x26, 0xbc, 0xe2, 0xa1, 0x94, 0x2e, 0x0b, 0xf5, 0x80,
-	0xfa, 0xad, 0x8f, 0xb0, 0xa7, 0xdc, 0xeb, 0x70, 0x28, 0x65, 0xd7, 0xd8, 0xc8, 0xa7, 0x40, 0xb8,
-	0x74, 0x69, 0x22, 0x22, 0x41, 0xdd, 0x38, 0x7d, 0x48, 0x6a, 0x3d, 0x41, 0x8f, 0x06, 0x97, 0x1d,
-	0x34, 0xd8, 0x07, 0xa6, 0xf6, 0xcf, 0x25, 0x20, 0x17, 0xef, 0x17, 0xe4, 0x63, 0x68, 0x06, 0x11,
-	0xf5, 0x5d, 0x7a, 0xca, 0x04, 0x1d, 0x32, 0xf7, 0xf1, 0x88, 0x9b, 0x7d, 0xa5, 0xe4, 0xd4, 0xb5,
-	0xa1, 0x63, 0x70, 0x0d, 0x5f, 0xe0, 0x7e, 0xa1, 0xb9, 0xd7, 0x2f, 0x70, 0x35, 0xac, 0x83, 0x9b,
-	0xd4, 0x45, 0xf2, 0x0c, 0x92, 0x1b, 0x45, 0x61, 0x8d, 0xb7, 0x7f, 0x3b, 0x0f, 0xf5, 0xa9, 0x5b,
-	0x8a, 0xde, 0xa7, 0x54, 0xa4, 0x68, 0x90, 0xd6, 0x14, 0x25, 0xac, 0x29, 0x00, 0x21, 0x2c, 0x28,
-	0xf4, 0x1d, 0xcc, 0xa3, 0x3a, 0xa3, 0x94, 0x71, 0x1d, 0x19, 0x15, 0x83, 0x19, 0xca, 0x3d, 0xa8,
-	0x1e, 0x27, 0x83, 0x01, 0x13, 0x32, 0xe5, 0xcc, 0x20, 0x67, 0x29, 0x05, 0x0d, 0xe9, 0x36, 0xc0,
-	0x40, 0x30, 0x96, 0x32, 0x66, 0x91, 0x51, 0xd6, 0x88, 0x31, 0x3f, 0x84, 0xfa, 0x1b, 0xc1, 0x15,
-	0xd3, 0x5f, 0x6c, 0xca, 0x99, 0x43, 0x4e, 0x2d, 0x83, 0x0d, 0xf1, 0x0e, 0x54, 0x7c, 0x2e, 0xd4,
-	0x38, 0x25, 0xcd, 0x9b, 0x80, 0x11, 0xca, 0x3a, 0x92, 0x01, 0x3d, 0x4e, 0xed, 0x0b, 0xa6, 0x23,
-	0x8d, 0x64, 0xf9, 0x8c, 0x68, 0x1c, 0x67, 0xf9, 0x2c, 0x9a, 0x7c, 0x0c, 0x66, 0x28, 0x1f, 0x43,
-	0x33, 0xd6, 0xa3, 0xa9, 0xf4, 0x17, 0x60, 0x73, 0x2a, 0x23, 0xaf, 0xae, 0x0d, 0x7d, 0xc4, 0x33,
-	0x39, 0xea, 0x29, 0x7e, 0x6a, 0x13, 0x03, 0x23, 0x67, 0x30, 0x43, 0xc1, 0x13, 0x63, 0x82, 0x54,
-	0x31, 0x95, 0x9b, 0x45, 0xb3, 0x11, 0x48, 0x77, 0xdd, 0xc0, 0xf2, 0x96, 0xcc, 0x08, 0x64, 0xb0,
-	0x21, 0x7e, 0x08, 0x75, 0xf9, 0x86, 0xc6, 0xc5, 0x52, 0xb0, 0x6a, 0x04, 0x35, 0x9c, 0x95, 0x82,
-	0x64, 0x0b, 0x1a, 0xc8, 0x2b, 0xce, 0x6f, 0xcd, 0x28, 0x6a, 0xbc, 0x9f, 0xcf, 0xf1, 0x63, 0x58,
-	0x39, 0x49, 0x86, 0xcc, 0xd5, 0xc9, 0x49, 0x57, 0xf2, 0x73, 0x1b, 0xc0, 0x32, 0xd2, 0x89, 0x36,
-	0x1e, 0x6a, 0x5b, 0x8f, 0x9f, 0xe7, 0x41, 0x14, 0x5c, 0xf4, 0x3c, 0xb6, 0x56, 0x4c, 0x10, 0x19,
-	0xf9, 0x99, 0x60, 0x4c, 0x07, 0x51, 0xe0, 0x61, 0x28, 0xad, 0x55, 0x13, 0x44, 0x46, 0xc4, 0x48,
-	0xc8, 0x36, 0xdc, 0x28, 0x30, 0x05, 0x93, 0x4c, 0x9c, 0x32, 0xbf, 0xb5, 0x86, 0xe4, 0x66, 0x46,
-	0x76, 0x52, 0x83, 0xfe, 0xf6, 0x8b, 0x41, 0x27, 0x22, 0x0e, 0x12, 0xd9, 0x6a, 0x21, 0xbd, 0x91,
-	0x47, 0x6c, 0x70, 0x3c, 0x56, 0xe3, 0x38, 0xd0, 0x67, 0x90, 0xde, 0x2b, 0x4d, 0x7a, 0x1f, 0x18,
-	0x72, 0xc1, 0x60, 0x8a, 0xe8, 0x3f, 0x95, 0xa0, 0x36, 0x79, 0xfd, 0x26, 0xcb, 0x30, 0x37, 0x8a,
-	0x7c, 0x66, 0xdf, 0x8c, 0x4d, 0x43, 0x67, 0x87, 0x0b, 0xa1, 0x38, 0x66, 0xe6, 0xe5, 0xaf, 0x86,
-	0x78, 0x3e, 0x5e, 0x1b, 0x50, 0x96, 0x31, 0xd3, 0x1b, 0xdc, 0xc9, 0x79, 0xba, 0x40, 0x17, 0x11,
-	0xd8, 0x3b, 0x39, 0xc7, 0x77, 0x8e, 0xc8, 0x7b, 0xc5, 0x94, 0xeb, 0x45, 0x49, 0x68, 0xde, 0xbe,
-	0xe6, 0x9c, 0x8a, 0xc1, 0x9e, 0x6a, 0x48, 0x8f, 0x4e, 0x7c, 0x32, 0x96, 0xdc, 0xa3, 0x81, 0xeb,
-	0x45, 0x82, 0xa5, 0xcc, 0x39, 0x64, 0x36, 0xad, 0xe9, 0x69, 0x24, 0x98, 0xe1, 0xe3, 0xce, 0x30,
-	0x9c, 0xa6, 0xcf, 0x23, 0xbd, 0x91, 0x5a, 0x32, 0x76, 0xfb, 0x21, 0x2c, 0x15, 0x5f, 0x08, 0xc8,
-	0x1a, 0x2c, 0xa0, 0x57, 0xfa, 0xfa, 0x5e, 0x76, 0xe6, 0x75, 0x73, 0xd7, 0x6f, 0xff, 0x66, 0x06,
-	0x99, 0xf9, 0xfe, 0xa1, 0x99, 0x71, 0x52, 0x78, 0x1f, 0x9c, 0xf7, 0xe2, 0x44, 0x5f, 0x58, 0xee,
-	0xc2, 0x92, 0x3e, 0x59, 0xf4, 0xa9, 0xe4, 0xb1, 0x50, 0xa5, 0x3b, 0x58, 0x45, 0x63, 0x87, 0x06,
-	0xd2, 0x0b, 0x23, 0x2d, 0xdb, 0x2c, 0xc9, 0x0c, 0x4c, 0xd5, 0xa0, 0x96, 0x76, 0x17, 0x96, 0xb8,
-	0x1f, 0xb0, 0x8c, 0x34, 0x6b, 0x94, 0x34, 0x56, 0xa0, 0x84, 0xdc, 0xcb, 0x29, 0x73, 0x86, 0xa2,
-	0xb1, 0x42, 0x67, 0x3c, 0x7a, 0x43, 0xb9, 0xca, 0x48, 0xf3, 0xa6, 0x33, 0x83, 0x5a, 0x9a, 0xae,
-	0xdb, 0xc4, 0xeb, 0x8c, 0xb3, 0x80, 0x1c, 0xe0, 0xe2, 0xb5, 0x25, 0xe8, 0x55, 0x15, 0x0d, 0x94,
-	0x5b, 0x64, 0x2d, 0x22, 0xab, 0xa6, 0xf1, 0xdd, 0x9c, 0x79, 0x0f, 0xaa, 0x52, 0x31, 0x1a, 0x64,
-	0xb4, 0x32, 0xd2, 0x96, 0x10, 0x2c, 0x90, 0x86, 0x89, 0xae, 0x0c, 0x2c, 0x09, 0x0c, 0x09, 0x41,
-	0x4b, 0xfa, 0x14, 0x88, 0x21, 0x4d, 0x24, 0x59, 0x31, 0xdb, 0x3c, 0x5a, 0xf6, 0xf3, 0x4c, 0xdb,
-	0x5f, 0x43, 0x63, 0xfa, 0x59, 0xc5, 0xec, 0x41, 0x8a, 0x89, 0x01, 0xf5, 0x98, 0x5b, 0xb8, 0x67,
-	0x54, 0x33, 0x14, 0xdf, 0xe4, 0x7f, 0x5f, 0xca, 0x7c, 0x27, 0x8e, 0x08, 0xfb, 0xfe, 0x92, 0x4f,
-	0x33, 0xa4, 0x90, 0x9e, 0xea, 0x7d, 0xb8, 0xaf, 0x04, 0x0d, 0xe5, 0x88, 0x2b, 0x57, 0x9d, 0x88,
-	0x28, 0x19, 0x9e, 0xc4, 0x89, 0x32, 0xcb, 0x41, 0x47, 0xeb, 0x9a, 0xa2, 0x31, 0x3d, 0x3a, 0x36,
-	0x2d, 0xb7, 0x9f, 0x51, 0x71, 0x89, 0x1c, 0x32, 0xd1, 0x43, 0x1e, 0x79, 0x01, 0xf7, 0x04, 0xf3,
-	0x98, 0xde, 0x2f, 0xdf, 0x26, 0x67, 0x4e, 0x99, 0x3b, 0x29, 0xf5, 0x2a, 0xb5, 0xf6, 0x67, 0x50,
-	0x9d, 0x78, 0xbc, 0xc1, 0x13, 0x84, 0x9d, 0xf2, 0xc9, 0x81, 0x00, 0x03, 0xe1, 0x28, 0xfc, 0xae,
-	0x04, 0xf5, 0xa9, 0x07, 0x1a, 0x5d, 0x38, 0x9b, 0x17, 0x9e, 0x6c, 0x04, 0x16, 0xf0, 0xc9, 0xc6,
-	0x3f, 0xd3, 0x4b, 0x1b, 0x4d, 0x78, 0xc3, 0x4e, 0x9f, 0x30, 0x35, 0x80, 0x97, 0xc8, 0x5b, 0x50,
-	0xce, 0xde, 0x16, 0xed, 0x7f, 0x20, 0x19, 0x80, 0x17, 0x29, 0x11, 0x9d, 0x72, 0x5d, 0xa6, 0x32,
-	0xdf, 0xe5, 0x51, 0x6c, 0x8e, 0xc6, 0xaa, 0x53, 0x2f, 0xe0, 0xbb, 0x51, 0x2c, 0xb5, 0x10, 0x0b,
-	0x3d, 0x31, 0x8e, 0xf5, 0xcd, 0x7b, 0x0e, 0xcb, 0x8f, 0x1c, 0x68, 0xff, 0x32, 0x6b, 0xb2, 0xcc,
-	0x67, 0xed, 0x2d, 0x01, 0x7f, 0x03, 0xeb, 0x82, 0x51, 0xdf, 0x4d, 0xef, 0x86, 0x51, 0x78, 0x61,
-	0x96, 0x4a, 0xce, 0x9a, 0x66, 0x1c, 0x64, 0x84, 0x7c, 0x72, 0xbe, 0x00, 0x34, 0x49, 0x77, 0xc4,
-	0xc4, 0x90, 0xf9, 0xd3, 0x13, 0x52, 0x72, 0x96, 0xd1, 0xbc, 0x87, 0xd6, 0xdc, 0xed, 0x31, 0xac,
-	0x98, 0x09, 0xc4, 0x9e, 0x0b, 0x4e, 0x66, 0x35, 0x13, 0x34, 0x3a, 0x8c, 0x16, 0x5c, 0xb6, 0xa0,
-	0x41, 0x4f, 0x87, 0xc6, 0x41, 0x17, 0xc1, 0xa1, 0x37, 0x4e, 0x17, 0x76, 0x8d, 0x9e, 0x0e, 0x35,
-	0xf7, 0x85, 0x41, 0xc9, 0x3f, 0xc3, 0x06, 0x56, 0x09, 0x57, 0x64, 0x64, 0x16, 0x7a, 0x0b, 0x29,
-	0x97, 0xa5, 0xf4, 0x25, 0x18, 0xdb, 0x65, 0x39, 0x99, 0x0d, 0x60, 0xc5, 0xd8, 0xa7, 0x93, 0xfa,
-	0x12, 0x5a, 0x26, 0x29, 0x6d, 0x56, 0x2c, 0x2c, 0x3a, 0x9a, 0x3d, 0xc1, 0x24, 0xfd, 0xa3, 0x31,
-	0xe7, 0x8e, 0x1f, 0xeb, 0x4b, 0xde, 0xd0, 0x35, 0x41, 0xdb, 0xdc, 0xcc, 0xf6, 0x50, 0xa7, 0xa7,
-	0x43, 0xcd, 0x67, 0x36, 0xb9, 0xfb, 0xa0, 0xd3, 0x75, 0x5f, 0x27, 0x2c, 0x31, 0xe7, 0x0c, 0x6e,
-	0x11, 0x73, 0xce, 0x12, 0x3d, 0x1d, 0xfe, 0xa0, 0x41, 0x7d, 0xc8, 0xe8, 0x1a, 0x3a, 0x51, 0x3c,
-	0xbb, 0x64, 0xdb, 0x3d, 0x62, 0xc9, 0x8c, 0x6e, 0xc1, 0x64, 0x77, 0x89, 0xaf, 0x60, 0xf5, 0xf2,
-	0xc7, 0x3d, 0xf2, 0x01, 0xc0, 0x48, 0x9f, 0x0a, 0x71, 0xc4, 0x43, 0x65, 0x97, 0x47, 0x8e, 0xb4,
-	0xff, 0x58, 0x82, 0xd6, 0x55, 0x8f, 0x75, 0x7a, 0xab, 0xba, 0xe4, 0x65, 0xcb, 0x7c, 0x80, 0x0d,
-	0x7f, 0xfa, 0x55, 0xab, 0xf8, 0x91, 0x5e, 0x9f, 0xfc, 0x48, 0x1f, 0x42, 0x7d, 0xc0, 0x03, 0x96,
-	0x1e, 0x10, 0xb8, 0xb6, 0xcc, 0xf2, 0xa9, 0xe5, 0x30, 0xae, 0xb0, 0x49, 0x62, 0x14, 0x67, 0x7f,
-	0xe7, 0x15, 0x88, 0x07, 0xb1, 0xc2, 0x3a, 0x2c, 0x8f, 0x0a, 0x97, 0xbe, 0xb9, 0x56, 0x57, 0x33,
-	0x14, 0x57, 0xff, 0xff, 0x94, 0xa6, 0x46, 0x26, 0x5f, 0x53, 0xbf, 0x2e, 0xb9, 0xdb, 0x00, 0x85,
-	0x12, 0xcd, 0x6c, 0x7e, 0xe5, 0x24, 0x2b, 0xcf, 0xa6, 0x2a, 0xef, 0x99, 0xe9, 0xca, 0xfb, 0x78,
-	0x1e, 0x6f, 0x4d, 0x9f, 0xff, 0x39, 0x00, 0x00, 0xff, 0xff, 0x1e, 0x18, 0xd8, 0x73, 0x98, 0x1f,
-	0x00, 0x00,
+func init() { proto.RegisterFile("shared.proto", fileDescriptor_shared_c23f4d9a246b2a27) }
+
+var fileDescriptor_shared_c23f4d9a246b2a27 = []byte{
+	// 3133 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0x4d, 0x7b, 0x1b, 0x47,
+	0x72, 0x16, 0x04, 0x92, 0x22, 0x0a, 0xc4, 0x57, 0x4b, 0x24, 0x21, 0x4a, 0xb6, 0x68, 0xc8, 0xb6,
+	0x68, 0x7b, 0x97, 0xb6, 0xb4, 0xeb, 0x78, 0x37, 0x9b, 0x2f, 0x5a, 0x84, 0x56, 0xcc, 0x8a, 0x1f,
+	0x1e, 0x80, 0xb1, 0xb3, 0x97, 0x79, 0x9a, 0x33, 0x0d, 0xb0, 0x57, 0x83, 0x99, 0x71, 0x77, 0x0f,
+	0x45, 0xf0, 0xc9, 0x39, 0x97, 0xdc, 0x72, 0x4b, 0x6e, 0xf9, 0x05, 0x9b, 0x5b, 0x7e, 0x47, 0x72,
+	0xce, 0x6f, 0xc8, 0x6f, 0xc8, 0x53, 0xd5, 0xf3, 0x05, 0x10, 0x94, 0xe4, 0x3c, 0xd9, 0x1b, 0xfa,
+	0xad, 0xb7, 0xaa, 0xab, 0xfa, 0xa3, 0xba, 0xba, 0x07, 0xb0, 0xa6, 0xcf, 0xb9, 0x12, 0xfe, 0x6e,
+	0xac, 0x22, 0x13, 0xb1, 0xbb, 0xf1, 0x98, 0x87, 0x3c, 0x98, 0x5e, 0x89, 0x5d, 0x2f, 0x0a, 0x02,
+	0xe1, 0x99, 0x48, 0x6d, 0x3d, 0x1a, 0x47, 0xd1, 0x38, 0x10, 0x5f, 0x12, 0xe5, 0x2c, 0x19, 0x7d,
+	0x69, 0xe4, 0x44, 0x68, 0xc3, 0x27, 0xb1, 0xd5, 0xea, 0xfd, 0x0a, 0xe0, 0x28, 0x09, 0x82, 0x81,
+	0x51, 0x32, 0x1c, 0xb3, 0x7b, 0xb0, 0x7c, 0xc1, 0x03, 0xe9, 0x77, 0x2b, 0xdb, 0x95, 0x9d, 0x55,
+	0xc7, 0x36, 0x52, 0x34, 0x11, 0xdd, 0xdb, 0xdb, 0x95, 0x9d, 0x9a, 0x63, 0x1b, 0xbd, 0xef, 0xa1,
+	0x81, 0x9a, 0xc3, 0xcc, 0xe0, 0x0d, 0xca, 0x5f, 0x95, 0x95, 0xeb, 0xcf, 0xb6, 0x76, 0xad, 0x47,
+	0xbb, 0x99, 0x47, 0xbb, 0xb9, 0x81, 0xcc, 0xf0, 0x29, 0xb4, 0x4e, 0x22, 0x6d, 0xc6, 0x4a, 0xe8,
+	0xbf, 0x13, 0x4a, 0xcb, 0x28, 0x64, 0x0c, 0x96, 0x46, 0x49, 0x10, 0x90, 0xe5, 0x9a, 0x43, 0xbf,
+	0xb1, 0x3b, 0x7d, 0x1e, 0x29, 0x93, 0x79, 0x45, 0x0d, 0xd6, 0x85, 0x3b, 0x61, 0x32, 0x11, 0x4a,
+	0x7a, 0xdd, 0xea, 0x76, 0x65, 0xa7, 0xea, 0x64, 0xcd, 0xde, 0x63, 0x68, 0x38, 0x51, 0x20, 0x1c,
+	0x31, 0x12, 0x4a, 0x84, 0x9e, 0x40, 0xa3, 0x21, 0x9f, 0x88, 0xcc, 0x28, 0xfe, 0xee, 0x3d, 0x81,
+	0xce, 0x3e, 0x37, 0xfc, 0x8c, 0xeb, 0x77, 0x10, 0xff, 0x01, 0x3a, 0x8e, 0x08, 0xb8, 0x91, 0x51,
+	0x58, 0x10, 0x3f, 0x82, 0x35, 0x3f, 0xd5, 0x76, 0xa5, 0x7f, 0x49, 0x0a, 0xcb, 0x4e, 0x3d, 0xc3,
+	0x0e, 0xfc, 0x4b, 0xf6, 0x08, 0xea, 0xda, 0x3b, 0x17, 0x13, 0xee, 0x92, 0x49, 0xeb, 0x3b, 0x58,
+	0xe8, 0x88, 0x4f, 0x04, 0x7b, 0x0c, 0x0d, 0x95, 0x1a, 0xb6, 0x94, 0x2a, 0x51, 0xd6, 0x32, 0x10,
+	0x49, 0x3d, 0x0d, 0xcd, 0x83, 0xd0, 0x17, 0x97, 0xff, 0xbf, 0x5d, 0x7f, 0x00, 0x20, 0xd1, 0x6a,
+	0xb9, 0xdf, 0x1a, 0x21, 0xd4, 0xe9, 0xbf, 0x56, 0xa0, 0xf3, 0x22, 0x09, 0xbd, 0x3f, 0x49, 0xcc,
+	0xa3, 0xd4, 0xf0, 0x4c, 0xcc, 0x19, 0x48, 0xa4, 0x87, 0x50, 0xe3, 0x6a, 0x9c, 0x4c, 0x44, 0x68,
+	0x74, 0x77, 0xc9, 0x3a, 0x97, 0x03, 0xbd, 0x18, 0x9a, 0xdf, 0x25, 0x42, 0x4d, 0x7f, 0x92, 0x63,
+	0xf7, 0x61, 0x55, 0x45, 0x81, 0x15, 0xdf, 0x26, 0xf1, 0x1d, 0x6c, 0xa3, 0x68, 0x1b, 0xea, 0x23,
+	0x19, 0x8e, 0x85, 0x8a, 0x95, 0x0c, 0x0d, 0x39, 0xb4, 0xe6, 0x94, 0xa1, 0xde, 0x1b, 0x68, 0x53,
+	0x8f, 0x07, 0xe1, 0x28, 0x52, 0x13, 0x9a, 0x1b, 0xf6, 0x00, 0x6a, 0x3f, 0x22, 0x56, 0xea, 0x70,
+	0x95, 0x00, 0x34, 0xf9, 0x19, 0xb4, 0x43, 0x64, 0x06, 0xf2, 0x4a, 0xf8, 0x2e, 0xc1, 0xe9, 0x58,
+	0xb4, 0x0a, 0x9c, 0x4c, 0x96, 0xed, 0xe8, 0x6e, 0x75, 0xbb, 0xba, 0x53, 0xcd, 0xed, 0xe8, 0xde,
+	0xbf, 0x2c, 0xc1, 0x26, 0xd1, 0xfa, 0x97, 0x71, 0xc0, 0x65, 0xf8, 0xde, 0x0e, 0x7c, 0x02, 0x4d,
+	0x61, 0x55, 0xdc, 0x28, 0x31, 0x71, 0x92, 0x6d, 0x9d, 0x46, 0x8a, 0x1e, 0x13, 0x88, 0xb3, 0x91,
+	0xd1, 0x84, 0x52, 0x91, 0xca, 0x66, 0x23, 0x05, 0xfb, 0x88, 0x31, 0x5e, 0xd8, 0xb2, 0xbd, 0xd3,
+	0x94, 0x34, 0x9f, 0xfd, 0xf9, 0xee, 0x82, 0x34, 0xb4, 0x7b, 0x83, 0xbb, 0xbb, 0x29, 0xf4, 0x82,
+	0x80, 0xdc, 0x0f, 0xdb, 0x2c, 0x77, 0xa1, 0xa3, 0x44, 0x79, 0xa2, 0xbb, 0xfc, 0x7f, 0xef, 0x62,
+	0x40, 0x16, 0xf2, 0x2e, 0x6c, 0xb3, 0xb7, 0x07, 0x8d, 0x19, 0x17, 0xd8, 0x26, 0xdc, 0x1d, 0xf6,
+	0x7f, 0x18, 0xba, 0xfd, 0x1f, 0x4e, 0x5e, 0xed, 0x1d, 0x1c, 0xb9, 0x2f, 0x8e, 0x9d, 0xc3, 0xbd,
+	0x61, 0xfb, 0x16, 0x0a, 0xfe, 0x76, 0x70, 0x7c, 0x34, 0x2f, 0xa8, 0xf4, 0xfe, 0xa9, 0x92, 0xdb,
+	0xb0, 0x46, 0xd9, 0x36, 0x3c, 0x1c, 0x0c, 0xf7, 0x86, 0xfd, 0xc3, 0xfe, 0xd1, 0xd0, 0x7d, 0x75,
+	0xfc, 0xdb, 0x5c, 0x67, 0x70, 0x7c, 0xea, 0x3c, 0xef, 0xb7, 0x6f, 0xb1, 0x47, 0xf0, 0x60, 0xef,
+	0x74, 0x78, 0x9c, 0x0b, 0xe6, 0x08, 0x15, 0xf6, 0x00, 0x36, 0xfb, 0x3f, 0x0c, 0xfb, 0xce, 0xd1,
+	0xde, 0xab, 0x79, 0xe1, 0x6d, 0xb6, 0x05, 0x1b, 0xbf, 0xed, 0x1f, 0xf5, 0x9d, 0x83, 0xe7, 0xf3,
+	0xb2, 0x6a, 0xef, 0x8f, 0x75, 0x58, 0x19, 0x4c, 0xb5, 0x11, 0x13, 0x76, 0x0a, 0x4c, 0xd3, 0x2f,
+	0x57, 0x16, 0xc3, 0x41, 0x6b, 0xa2, 0xfe, 0xec, 0xd3, 0x85, 0x43, 0x68, 0x15, 0x4b, 0x83, 0xe7,
+	0x74, 0xf4, 0x3c, 0x84, 0x2b, 0x2c, 0x33, 0xeb, 0xa7, 0xeb, 0x67, 0x35, 0x65, 0xf9, 0xb8, 0xe7,
+	0x52, 0xa1, 0xf6, 0xa2, 0x38, 0xdb, 0xc7, 0x75, 0x8b, 0x0d, 0x10, 0x62, 0x3f, 0xc0, 0x5d, 0xdc,
+	0xf9, 0x7e, 0x12, 0x08, 0xe5, 0x6a, 0xc3, 0x8d, 0xd4, 0x46, 0x7a, 0x5d, 0x20, 0xbf, 0x9e, 0x2c,
+	0xf6, 0x2b, 0xe3, 0x0f, 0x32, 0xba, 0xc3, 0xf4, 0x35, 0x8c, 0x1d, 0x43, 0x7b, 0x22, 0x26, 0x91,
+	0x9a, 0x96, 0xcc, 0xd6, 0xc9, 0xec, 0xc7, 0x0b, 0xcd, 0x1e, 0x12, 0xb9, 0xb0, 0xd9, 0x9a, 0xcc,
+	0x02, 0xec, 0x15, 0xb4, 0xbc, 0x38, 0x99, 0x19, 0xbe, 0x35, 0xb2, 0xf7, 0x78, 0xa1, 0xbd, 0xe7,
+	0x27, 0xa7, 0xe5, 0xb1, 0x6b, 0x7a, 0x71, 0x52, 0x1e, 0xb8, 0x97, 0x80, 0x88, 0xab, 0xb2, 0x04,
+	0xa5, 0xbb, 0x8d, 0xed, 0xea, 0x4e, 0xfd, 0xd9, 0x47, 0x37, 0x19, 0xcb, 0x53, 0x99, 0xd3, 0xf0,
+	0xe2, 0x24, 0x6f, 0xe9, 0xcc, 0x52, 0x1e, 0xa5, 0xee, 0x36, 0xdf, 0x6e, 0xa9, 0x88, 0x11, 0x2d,
+	0xe5, 0x2d, 0xcd, 0x86, 0xc0, 0x42, 0x61, 0xde, 0x44, 0xea, 0x75, 0xd9, 0xaf, 0x16, 0x59, 0xfb,
+	0x64, 0xa1, 0xb5, 0x23, 0x4b, 0x2f, 0x7c, 0xeb, 0x84, 0x73, 0xc8, 0x8c, 0xd5, 0x92, 0x8f, 0xed,
+	0x77, 0x5b, 0x2d, 0xfc, 0xec, 0x84, 0x73, 0x88, 0x66, 0xbf, 0x83, 0x96, 0x2f, 0xf5, 0x8c, 0xa3,
+	0x1d, 0x32, 0xd9, 0x5b, 0x68, 0x72, 0x5f, 0xea, 0x92, 0x97, 0x4d, 0xbf, 0xdc, 0xd4, 0xec, 0x3b,
+	0xe8, 0x90, 0xb1, 0xd2, 0xdc, 0xea, 0x2e, 0xdb, 0xae, 0xde, 0xb8, 0x58, 0xd0, 0x5c, 0x79, 0x76,
+	0xdb, 0xfe, 0x2c, 0x50, 0xf8, 0x57, 0x0a, 0xf9, 0xee, 0x3b, 0xfc, 0x2b, 0xe2, 0x6d, 0xfa, 0xe5,
+	0xa6, 0x66, 0x63, 0xb8, 0x4f, 0xc6, 0x62, 0xae, 0x8c, 0xa4, 0x73, 0xb1, 0x14, 0xf6, 0x3d, 0x32,
+	0xfb, 0xc5, 0x8d, 0x66, 0x4f, 0x32, 0xa5, 0x22, 0xfe, 0x4d, 0x7f, 0x21, 0xae, 0xd9, 0x04, 0x1e,
+	0xcc, 0x75, 0x34, 0x33, 0x24, 0xeb, 0xd4, 0xd5, 0xcf, 0xdf, 0xdd, 0x55, 0x79, 0x6c, 0xee, 0xfb,
+	0x37, 0x48, 0x16, 0xc5, 0x55, 0x1a, 0xae, 0x8d, 0xf7, 0x8d, 0xab, 0x18, 0xb7, 0x4d, 0x7f, 0x21,
+	0x8e, 0x7b, 0xe4, 0x23, 0x3c, 0xe9, 0x5d, 0x5f, 0x2a, 0x32, 0x30, 0x75, 0xe7, 0xc3, 0xf4, 0x2f,
+	0xbb, 0x1f, 0xd2, 0x01, 0xf9, 0x01, 0x12, 0xf7, 0x33, 0xde, 0x6c, 0x54, 0xfe, 0x25, 0xfb, 0x1a,
+	0x36, 0x2f, 0x83, 0x68, 0xbc, 0x48, 0xff, 0x11, 0xe9, 0xdf, 0x43, 0xf1, 0x35, 0xb5, 0x4f, 0xa1,
+	0x45, 0x6a, 0x89, 0x16, 0xbe, 0x7b, 0x36, 0x35, 0x42, 0x77, 0xb7, 0xb7, 0x2b, 0x3b, 0x4b, 0x4e,
+	0x03, 0xe1, 0x53, 0x2d, 0xfc, 0x6f, 0x11, 0xec, 0xfd, 0x73, 0x15, 0x3a, 0xd7, 0x12, 0x2f, 0xeb,
+	0xc3, 0x92, 0x99, 0xc6, 0xb6, 0xe4, 0x6c, 0x3e, 0x7b, 0xfa, 0x7e, 0xe9, 0x3a, 0x45, 0x86, 0xd3,
+	0x58, 0x38, 0xa4, 0xce, 0x06, 0x50, 0xd7, 0x22, 0x18, 0xb9, 0xe7, 0x91, 0x36, 0xc2, 0x4f, 0x4b,
+	0xf0, 0xaf, 0xde, 0xcf, 0xda, 0x40, 0x04, 0xa3, 0x97, 0xa4, 0xf7, 0xf2, 0x96, 0x03, 0x3a, 0x6f,
+	0xb1, 0x13, 0x00, 0x3e, 0xe1, 0x57, 0xb8, 0x26, 0xa9, 0x3a, 0x41, 0x9b, 0x5f, 0xbe, 0x9f, 0xcd,
+	0x3d, 0xd2, 0x73, 0xf6, 0x07, 0x2f, 0x6f, 0x39, 0x35, 0x6b, 0xc4, 0xf1, 0x35, 0xfb, 0x06, 0x6a,
+	0x67, 0x51, 0x64, 0x5c, 0xbc, 0x9c, 0x74, 0xe1, 0x9d, 0xf7, 0x84, 0x55, 0x24, 0x63, 0xb3, 0x77,
+	0x04, 0x50, 0xc4, 0xcc, 0x36, 0x80, 0x0d, 0xfa, 0xaf, 0x5e, 0xb8, 0x2f, 0x8f, 0x07, 0xc3, 0xfe,
+	0xbe, 0x3b, 0xf8, 0xfb, 0xc1, 0xb0, 0x7f, 0xd8, 0xbe, 0xc5, 0xd6, 0xa1, 0xb3, 0x77, 0xb8, 0xf7,
+	0xfb, 0xe3, 0x23, 0xd7, 0xd9, 0x1f, 0x64, 0x70, 0x85, 0x75, 0xa0, 0xf1, 0xb2, 0xef, 0x1c, 0xff,
+	0xee, 0x34, 0x83, 0x6e, 0x7f, 0xbb, 0x02, 0x4b, 0xb8, 0xfc, 0x71, 0x52, 0x1e, 0xbc, 0x65, 0x40,
+	0xd8, 0x16, 0xac, 0xe2, 0x90, 0x96, 0x6e, 0x05, 0x79, 0x9b, 0xf5, 0x60, 0x8d, 0x2b, 0xef, 0x5c,
+	0x1a, 0xe1, 0x99, 0x44, 0x65, 0xe5, 0xee, 0x0c, 0x86, 0xa5, 0x60, 0x14, 0x0b, 0xc5, 0x8d, 0x0c,
+	0xc7, 0xae, 0x3d, 0x1d, 0xd3, 0xb3, 0xb2, 0x95, 0xe3, 0xe9, 0x31, 0xbe, 0x05, 0xab, 0x71, 0xc0,
+	0x0d, 0x7a, 0x91, 0x56, 0xbd, 0x79, 0x9b, 0x3d, 0x81, 0x56, 0xf6, 0xdb, 0x1d, 0xf1, 0x89, 0x0c,
+	0xa6, 0x54, 0x22, 0xd5, 0x9c, 0x66, 0x06, 0xbf, 0x20, 0x14, 0xfb, 0xcb, 0x89, 0x17, 0xf6, 0x4e,
+	0xd5, 0x5d, 0xb1, 0xfd, 0x65, 0x78, 0x76, 0xd5, 0xfa, 0x05, 0xac, 0x5f, 0x48, 0x65, 0x12, 0x2c,
+	0x47, 0xed, 0x2d, 0x24, 0xf5, 0xef, 0x0e, 0xf1, 0xef, 0xcd, 0x0a, 0x53, 0x27, 0x3f, 0x81, 0xe6,
+	0x6b, 0xa1, 0x42, 0x11, 0xe4, 0xd6, 0x57, 0x6d, 0x65, 0x69, 0xd1, 0xcc, 0xf6, 0x5f, 0xc0, 0x56,
+	0x5e, 0x92, 0xe7, 0x45, 0x84, 0x08, 0x8d, 0x1c, 0x49, 0xa1, 0xba, 0x35, 0x52, 0xe9, 0x66, 0x8c,
+	0x74, 0xfc, 0x73, 0x79, 0xef, 0xbf, 0x56, 0x61, 0xeb, 0xe6, 0x15, 0xc5, 0x36, 0x60, 0x45, 0x89,
+	0x71, 0x56, 0xe3, 0xd4, 0x9c, 0xb4, 0x85, 0xbe, 0xc9, 0x50, 0x1b, 0x1e, 0x7a, 0xc2, 0xf5, 0x02,
+	0xae, 0x75, 0x56, 0xf5, 0x66, 0xe8, 0x73, 0x04, 0xf1, 0x92, 0x92, 0xd3, 0xa4, 0x9f, 0xce, 0x06,
+	0x64, 0xd0, 0x81, 0x8f, 0xf6, 0x31, 0x57, 0x25, 0xd9, 0xe5, 0x23, 0x6d, 0xb1, 0x2f, 0xa0, 0xc3,
+	0x2f, 0xb8, 0x0c, 0xf8, 0x99, 0x0c, 0xa4, 0x99, 0xba, 0x57, 0x51, 0x28, 0xd2, 0x69, 0x68, 0x97,
+	0x05, 0xbf, 0x8f, 0x42, 0xc1, 0xbe, 0x84, 0xbb, 0x71, 0x72, 0x16, 0x48, 0x2f, 0x98, 0xba, 0xdc,
+	0xf3, 0x84, 0xd6, 0xf2, 0x2c, 0x10, 0x34, 0x17, 0xab, 0x0e, 0xcb, 0x44, 0x7b, 0xb9, 0x04, 0xaf,
+	0x28, 0x93, 0x24, 0x30, 0xd2, 0xe5, 0x57, 0x34, 0x03, 0xab, 0xce, 0x1d, 0x6a, 0xef, 0x5d, 0xb1,
+	0xbf, 0x82, 0x07, 0x5a, 0x78, 0x51, 0xe8, 0x73, 0x35, 0x75, 0xaf, 0xbb, 0x60, 0x67, 0xe0, 0x7e,
+	0x4e, 0xd9, 0x9b, 0xf7, 0xe5, 0x13, 0x68, 0x7a, 0xdc, 0xf5, 0x84, 0xc2, 0xf1, 0xf5, 0xb8, 0x11,
+	0xe9, 0x0c, 0x34, 0x3c, 0xfe, 0xbc, 0x00, 0xd9, 0x6f, 0x60, 0x8b, 0x27, 0x26, 0x72, 0x27, 0x32,
+	0x8c, 0x54, 0x36, 0xbf, 0x6e, 0x12, 0x8f, 0x15, 0xf7, 0xed, 0x6e, 0x5d, 0x75, 0x36, 0x91, 0x71,
+	0x88, 0x84, 0x74, 0xaa, 0x4f, 0xad, 0xb8, 0x50, 0xe6, 0x7f, 0x58, 0xa0, 0x5c, 0x2f, 0x29, 0xf3,
+	0x3f, 0x5c, 0x53, 0xfe, 0x1b, 0x78, 0x18, 0xd3, 0xb1, 0xa7, 0x84, 0xef, 0x4e, 0xb8, 0x0c, 0x8d,
+	0x08, 0x69, 0x7e, 0xde, 0xc8, 0xd0, 0x8f, 0xde, 0x50, 0x31, 0x56, 0x73, 0xb6, 0x72, 0xce, 0x61,
+	0x41, 0xf9, 0x9e, 0x18, 0xec, 0xcf, 0x60, 0xb3, 0xb0, 0x70, 0xc6, 0xbd, 0xd7, 0x49, 0x9c, 0x29,
+	0x37, 0x49, 0x79, 0x3d, 0x17, 0x7f, 0x4b, 0xd2, 0x54, 0xef, 0x04, 0x36, 0x02, 0x6e, 0x84, 0x36,
+	0xae, 0x12, 0xda, 0x44, 0x8a, 0x9f, 0x05, 0xc2, 0x66, 0xa7, 0xc6, 0x3b, 0xb3, 0xd3, 0x3d, 0xab,
+	0xe9, 0xe4, 0x8a, 0x28, 0x62, 0x7f, 0x0d, 0x0f, 0xd3, 0xfe, 0x95, 0x30, 0xb8, 0xa4, 0xa3, 0xd0,
+	0x8d, 0x85, 0x92, 0x91, 0xef, 0xfa, 0x7c, 0x8a, 0x35, 0x17, 0x1e, 0x25, 0xf7, 0x2d, 0xc7, 0xc9,
+	0x28, 0x27, 0xc4, 0xd8, 0xe7, 0x53, 0x8d, 0x7b, 0x7d, 0xc2, 0xb5, 0x11, 0x0a, 0x4f, 0x14, 0x45,
+	0x99, 0xa7, 0x6d, 0xf7, 0xba, 0x85, 0x4f, 0x53, 0x14, 0x0f, 0x1e, 0x19, 0x4a, 0x23, 0x79, 0xe0,
+	0xfa, 0x67, 0xf6, 0x3a, 0xdd, 0xc9, 0x16, 0x3c, 0xc1, 0xfb, 0x67, 0x74, 0x9f, 0xfe, 0x35, 0x80,
+	0xa7, 0x04, 0x37, 0xc2, 0x77, 0xb9, 0xe9, 0xb2, 0x77, 0xc6, 0x55, 0x4b, 0xd9, 0x7b, 0x06, 0x57,
+	0xb1, 0x08, 0xcf, 0x71, 0x9c, 0x7d, 0x77, 0x12, 0x85, 0xd2, 0x44, 0xf8, 0x7a, 0xd4, 0xbd, 0x6b,
+	0x57, 0x71, 0x26, 0x3a, 0xcc, 0x25, 0xec, 0x97, 0xb0, 0x11, 0x73, 0xc5, 0x27, 0x02, 0xfd, 0xe7,
+	0x71, 0x1c, 0xd8, 0x1a, 0x3d, 0xd1, 0xdd, 0x1d, 0x9b, 0x55, 0x72, 0xe9, 0x1e, 0x0a, 0x07, 0x24,
+	0x9b, 0xd5, 0x8a, 0xc7, 0x5a, 0xbb, 0x22, 0xc4, 0x01, 0xf5, 0xbb, 0x9f, 0x51, 0x4f, 0x85, 0xd6,
+	0xc9, 0x58, 0xeb, 0xbe, 0x95, 0xb1, 0x9f, 0x01, 0x93, 0xda, 0xe5, 0x89, 0x8a, 0x14, 0x77, 0xe3,
+	0xf4, 0x21, 0xa9, 0xfb, 0x8c, 0x34, 0xda, 0x52, 0xef, 0x91, 0x20, 0x7b, 0x60, 0xc2, 0x47, 0x0d,
+	0x76, 0xfd, 0x7e, 0xc1, 0x3e, 0x87, 0x4e, 0x10, 0x71, 0xdf, 0xe5, 0x17, 0x42, 0xf1, 0xb1, 0x70,
+	0x9f, 0x4e, 0xa4, 0xcd, 0x2b, 0x15, 0xa7, 0x85, 0x82, 0x3d, 0x8b, 0x23, 0x7c, 0x8d, 0xfb, 0x35,
+	0x72, 0x6f, 0x5f, 0xe3, 0x22, 0x8c, 0xce, 0xcd, 0xda, 0x25, 0x72, 0x95, 0xc8, 0xed, 0xb2, 0x61,
+	0xc4, 0x7b, 0xff, 0xb1, 0x0a, 0xad, 0xb9, 0x5b, 0x0a, 0xe6, 0x29, 0x13, 0x19, 0x1e, 0xa4, 0x35,
+	0x45, 0x85, 0x6a, 0x0a, 0x20, 0x88, 0x0a, 0x0a, 0xbc, 0x83, 0x79, 0x1c, 0x23, 0x4a, 0x19, 0xb7,
+	0x89, 0x51, 0xb7, 0x98, 0xa5, 0x3c, 0x86, 0xc6, 0x59, 0x32, 0x1a, 0x09, 0xa5, 0x53, 0x4e, 0x95,
+	0x38, 0x6b, 0x29, 0x68, 0x49, 0x1f, 0x00, 0x8c, 0x94, 0x10, 0x29, 0x63, 0x89, 0x18, 0x35, 0x44,
+	0xac, 0xf8, 0x09, 0xb4, 0xde, 0x28, 0x69, 0x04, 0xae, 0xd8, 0x94, 0xb3, 0x4c, 0x9c, 0x66, 0x0e,
+	0x5b, 0xe2, 0x23, 0xa8, 0xfb, 0x52, 0x99, 0x69, 0x4a, 0x5a, 0xb1, 0x0e, 0x13, 0x94, 0x77, 0xa4,
+	0x03, 0x7e, 0x96, 0xca, 0xef, 0xd8, 0x8e, 0x10, 0xc9, 0xe3, 0x99, 0xf0, 0x38, 0xce, 0xe3, 0x59,
+	0xb5, 0xf1, 0x58, 0xcc, 0x52, 0x3e, 0x87, 0x4e, 0x8c, 0xa3, 0x69, 0x70, 0x05, 0x64, 0x31, 0xd5,
+	0x88, 0xd7, 0x42, 0xc1, 0x90, 0xf0, 0xdc, 0x1c, 0xf7, 0x8c, 0xbc, 0xc8, 0x02, 0x03, 0x6b, 0xce,
+	0x62, 0x96, 0x42, 0x27, 0xc6, 0x0c, 0xa9, 0x6e, 0x2b, 0x37, 0x19, 0x96, 0x69, 0x4f, 0xa0, 0x95,
+	0x66, 0xdd, 0x20, 0xe3, 0xad, 0xd9, 0x11, 0xc8, 0x61, 0x4b, 0xfc, 0x14, 0x5a, 0xfa, 0x0d, 0x8f,
+	0xcb, 0xa5, 0x60, 0xc3, 0x1a, 0x44, 0x38, 0x2f, 0x05, 0xd9, 0x0e, 0xb4, 0x89, 0x57, 0x9e, 0xdf,
+	0xa6, 0xb5, 0x88, 0xf8, 0xb0, 0x98, 0xe3, 0xa7, 0xb0, 0x7e, 0x9e, 0x8c, 0x85, 0x8b, 0xc1, 0x69,
+	0x57, 0xcb, 0xab, 0xcc, 0x81, 0x7b, 0x44, 0x67, 0x28, 0x3c, 0x41, 0xd9, 0x40, 0x5e, 0x15, 0x4e,
+	0x94, 0x54, 0x70, 0x1e, 0xbb, 0xeb, 0xd6, 0x89, 0x9c, 0xfc, 0x42, 0x09, 0x81, 0x4e, 0x94, 0x78,
+	0xe4, 0x4a, 0x77, 0xc3, 0x3a, 0x91, 0x13, 0xc9, 0x13, 0xb6, 0x0b, 0x77, 0x4b, 0x4c, 0x25, 0xb4,
+	0x50, 0x17, 0xc2, 0xef, 0x6e, 0x12, 0xb9, 0x93, 0x93, 0x9d, 0x54, 0x80, 0x6b, 0xbf, 0xec, 0x74,
+	0xa2, 0xe2, 0x20, 0xd1, 0xdd, 0x2e, 0xd1, 0xdb, 0x85, 0xc7, 0x16, 0xa7, 0x63, 0x35, 0x8e, 0x03,
+	0x3c, 0x83, 0x30, 0x57, 0xda, 0xf0, 0x3e, 0xb4, 0xe4, 0x92, 0xc0, 0x06, 0xd7, 0x03, 0x1a, 0x4a,
+	0x57, 0x66, 0xc4, 0x96, 0x9d, 0x55, 0x04, 0x0f, 0x52, 0xce, 0xc7, 0x40, 0xa3, 0x88, 0x4f, 0x5f,
+	0x29, 0xa9, 0x6d, 0x57, 0x3d, 0xa2, 0xc7, 0x89, 0xb1, 0xac, 0x67, 0xb0, 0x3e, 0x37, 0xa9, 0xae,
+	0x2f, 0x02, 0xc3, 0x29, 0x51, 0x55, 0x9d, 0xbb, 0xb3, 0x53, 0xbb, 0x8f, 0x22, 0x1c, 0xb2, 0x62,
+	0xa7, 0xa4, 0xf4, 0xcf, 0x88, 0xde, 0xcc, 0xf7, 0x8b, 0x65, 0x3e, 0x85, 0xf5, 0xb9, 0x95, 0x90,
+	0xd2, 0x3f, 0x27, 0x3a, 0x9b, 0x59, 0x0f, 0x56, 0xe5, 0x97, 0xb0, 0x11, 0xc8, 0xd7, 0x22, 0x98,
+	0xba, 0xe9, 0xe3, 0x46, 0xac, 0x84, 0xd6, 0x58, 0x58, 0x7e, 0x61, 0x93, 0xa0, 0x95, 0xda, 0x34,
+	0x71, 0x92, 0xca, 0x7a, 0xff, 0x53, 0x81, 0xe6, 0xec, 0x7b, 0x04, 0xbe, 0x97, 0x4f, 0x22, 0x5f,
+	0x64, 0x8f, 0xe8, 0xb6, 0x81, 0xbe, 0x53, 0x66, 0x28, 0x2f, 0x22, 0xfb, 0x14, 0xda, 0x24, 0xbc,
+	0x58, 0x40, 0xf8, 0xf0, 0x13, 0x0b, 0xcc, 0xf8, 0xe7, 0x57, 0x69, 0xc6, 0x5a, 0x25, 0xe0, 0xf0,
+	0xfc, 0x8a, 0x1e, 0x7e, 0x22, 0xef, 0xb5, 0x30, 0xae, 0x17, 0x25, 0xa1, 0x7d, 0x0c, 0x5c, 0x76,
+	0xea, 0x16, 0x7b, 0x8e, 0x10, 0x2e, 0x97, 0xf8, 0x7c, 0xaa, 0xa5, 0xc7, 0x03, 0xd7, 0x8b, 0x94,
+	0x48, 0x99, 0xcb, 0xc4, 0xec, 0x64, 0xa2, 0xe7, 0x91, 0x12, 0x96, 0x4f, 0xa9, 0x72, 0x3c, 0x4f,
+	0x5f, 0x21, 0x7a, 0x3b, 0x95, 0xe4, 0xec, 0xde, 0x13, 0x58, 0x2b, 0x3f, 0x99, 0xb0, 0x4d, 0xb8,
+	0x43, 0x5a, 0xe9, 0xe7, 0x88, 0x9a, 0xb3, 0x82, 0xcd, 0x03, 0xbf, 0xf7, 0x6f, 0x55, 0x62, 0x16,
+	0x09, 0x15, 0x99, 0x71, 0x52, 0x7a, 0x30, 0x5d, 0xc1, 0x87, 0x1b, 0xff, 0x12, 0x63, 0xc2, 0xa3,
+	0x16, 0x8f, 0x69, 0x4f, 0x84, 0x26, 0x4d, 0xe9, 0x75, 0xc4, 0x4e, 0x2c, 0x84, 0x99, 0x22, 0xad,
+	0x63, 0x33, 0x92, 0x1d, 0x98, 0x86, 0x45, 0x33, 0xda, 0x47, 0xb0, 0x26, 0xfd, 0x40, 0xe4, 0xa4,
+	0x25, 0x6b, 0x09, 0xb1, 0x12, 0x25, 0x94, 0x5e, 0x41, 0x59, 0xb6, 0x14, 0xc4, 0x4a, 0x9d, 0xc9,
+	0xe8, 0x0d, 0x97, 0x26, 0x27, 0xad, 0xd8, 0xce, 0x2c, 0x9a, 0xd1, 0xb0, 0x90, 0x55, 0x3f, 0xe6,
+	0x9c, 0x3b, 0xc4, 0x01, 0xa9, 0x7e, 0xcc, 0x08, 0x98, 0x66, 0xa2, 0x91, 0x71, 0xcb, 0xac, 0x55,
+	0x62, 0x35, 0x11, 0x3f, 0x28, 0x98, 0x8f, 0xa1, 0xa1, 0x8d, 0xe0, 0x41, 0x4e, 0xab, 0x11, 0x6d,
+	0x8d, 0xc0, 0x12, 0x69, 0x9c, 0x60, 0xa9, 0x94, 0x91, 0xc0, 0x92, 0x08, 0xcc, 0x48, 0x3f, 0x03,
+	0x66, 0x49, 0x33, 0x41, 0xd6, 0xed, 0xb9, 0x47, 0x92, 0xa3, 0x22, 0xd2, 0xde, 0xaf, 0xa1, 0x3d,
+	0xff, 0xce, 0x64, 0x93, 0xb2, 0x11, 0x6a, 0xc4, 0x3d, 0xe1, 0x96, 0x2e, 0x5e, 0x8d, 0x1c, 0xa5,
+	0x8f, 0x14, 0xff, 0x59, 0xc9, 0x75, 0x67, 0xce, 0xcc, 0xec, 0x41, 0xaa, 0x98, 0x66, 0x48, 0x21,
+	0x9c, 0xea, 0x23, 0xf8, 0xd8, 0x28, 0x1e, 0xea, 0x89, 0x34, 0xae, 0x39, 0x57, 0x51, 0x32, 0x3e,
+	0x8f, 0xb3, 0x34, 0x81, 0xde, 0xba, 0xb6, 0x8a, 0x4e, 0xcf, 0xd2, 0xed, 0x8c, 0x3b, 0xcc, 0xa9,
+	0xb4, 0x45, 0x4e, 0x84, 0x1a, 0x10, 0x8f, 0xbd, 0x82, 0xc7, 0x4a, 0x78, 0x02, 0x0f, 0x90, 0xb7,
+	0x99, 0xb3, 0xc7, 0xee, 0xa3, 0x94, 0x7a, 0x93, 0xb5, 0xde, 0x57, 0xd0, 0x98, 0x79, 0xcd, 0xa2,
+	0x23, 0x55, 0x5c, 0xc8, 0xd9, 0x81, 0x00, 0x0b, 0xd1, 0x28, 0xfc, 0x7b, 0x05, 0x5a, 0x73, 0x2f,
+	0x56, 0x78, 0x93, 0xb0, 0x4f, 0x5e, 0xf9, 0x08, 0xdc, 0xc1, 0x36, 0x86, 0xff, 0x00, 0x6a, 0x24,
+	0xa2, 0x27, 0x87, 0xf4, 0x4d, 0x17, 0x01, 0xba, 0x55, 0x3f, 0x84, 0x5a, 0xfe, 0xd8, 0x9a, 0x7d,
+	0x14, 0xca, 0x01, 0xba, 0x59, 0xaa, 0xe8, 0x42, 0x62, 0xdd, 0x2e, 0x7c, 0x57, 0x46, 0xb1, 0xad,
+	0x15, 0x1a, 0x4e, 0xab, 0x84, 0x1f, 0x44, 0xb1, 0x46, 0x43, 0x22, 0xf4, 0xd4, 0x34, 0xc6, 0xa7,
+	0x88, 0x65, 0x4a, 0x5e, 0x05, 0xd0, 0xfb, 0xe3, 0x92, 0x8d, 0xb2, 0x98, 0xb5, 0xb7, 0x38, 0xfc,
+	0x1b, 0xd8, 0x52, 0x82, 0xfb, 0x6e, 0x7a, 0x59, 0x8e, 0xc2, 0x6b, 0xb3, 0x54, 0x71, 0x36, 0x91,
+	0x71, 0x9c, 0x13, 0x8a, 0xc9, 0xf9, 0x1a, 0x48, 0xa4, 0xdd, 0x89, 0x50, 0x63, 0xe1, 0xcf, 0x4f,
+	0x48, 0xc5, 0xb9, 0x47, 0xe2, 0x43, 0x92, 0x16, 0x6a, 0x4f, 0x61, 0xdd, 0x4e, 0x20, 0xf5, 0x5c,
+	0x52, 0xb2, 0xbb, 0x99, 0x91, 0xd0, 0x11, 0xbc, 0xa4, 0xb2, 0x03, 0x6d, 0x7e, 0x31, 0xb6, 0x0a,
+	0x01, 0x37, 0x22, 0xf4, 0xa6, 0xe9, 0xc6, 0x6e, 0xf2, 0x8b, 0x31, 0x72, 0x5f, 0x59, 0x94, 0xfd,
+	0x25, 0x3c, 0xa0, 0xb2, 0xe9, 0x86, 0x88, 0xec, 0x46, 0xef, 0x12, 0x65, 0x51, 0x48, 0xdf, 0x80,
+	0x95, 0x2d, 0x8a, 0xc9, 0x26, 0x80, 0x75, 0x2b, 0x9f, 0x0f, 0xea, 0x1b, 0xe8, 0xda, 0xa0, 0x50,
+	0x6c, 0x44, 0x58, 0x56, 0xb4, 0x39, 0xc1, 0x06, 0xfd, 0xbd, 0x15, 0x17, 0x8a, 0x9f, 0xe3, 0xad,
+	0x77, 0xec, 0x5a, 0xa7, 0xb3, 0xd8, 0x6c, 0x7a, 0x68, 0xf1, 0x8b, 0x31, 0xf2, 0x45, 0x16, 0xdc,
+	0xc7, 0x80, 0xe1, 0xe2, 0x17, 0xaf, 0xc4, 0x9e, 0x33, 0x94, 0x22, 0x96, 0x9d, 0x35, 0x7e, 0x31,
+	0xfe, 0x0e, 0x41, 0x3c, 0x64, 0xf0, 0x52, 0x91, 0x18, 0x99, 0xbf, 0x3a, 0x64, 0x39, 0x62, 0xcd,
+	0x8e, 0x6e, 0x49, 0x94, 0x65, 0x89, 0x5f, 0xc1, 0xc6, 0xe2, 0xd7, 0x4e, 0xf6, 0x21, 0xc0, 0x04,
+	0x4f, 0x85, 0x38, 0xc2, 0x6f, 0x77, 0xe9, 0xf6, 0x28, 0x90, 0xde, 0x7f, 0x57, 0xa0, 0x7b, 0xd3,
+	0xeb, 0x25, 0xa6, 0xaa, 0x05, 0x4f, 0x7d, 0x76, 0x01, 0xb6, 0xfd, 0xf9, 0x67, 0xbe, 0xf2, 0x22,
+	0xbd, 0x3d, 0xbb, 0x48, 0x9f, 0x40, 0x6b, 0x24, 0x03, 0x91, 0x1e, 0x10, 0xb4, 0xb7, 0xec, 0xf6,
+	0x69, 0x16, 0x30, 0xed, 0xb0, 0x59, 0x62, 0x14, 0xe7, 0xdf, 0x37, 0x4b, 0xc4, 0xe3, 0xd8, 0x50,
+	0x61, 0x5a, 0x78, 0x45, 0x5b, 0xdf, 0xbe, 0x33, 0x34, 0x72, 0x94, 0x76, 0xff, 0x3f, 0x56, 0xe6,
+	0x46, 0xa6, 0xd8, 0x53, 0x3f, 0x2d, 0xb8, 0x0f, 0x00, 0x4a, 0x35, 0xab, 0x4d, 0x7e, 0xb5, 0x24,
+	0xaf, 0x57, 0xe7, 0xae, 0x22, 0xd5, 0xf9, 0xab, 0x48, 0xef, 0xe7, 0x50, 0x1d, 0xf2, 0x31, 0x6b,
+	0x43, 0xf5, 0xb5, 0x98, 0xa6, 0xf3, 0x80, 0x3f, 0x17, 0xff, 0xa3, 0xe0, 0x6c, 0x85, 0x6e, 0x9d,
+	0xbf, 0xf8, 0xdf, 0x01, 0x00, 0x6e, 0x40, 0x2a, 0x28, 0xd8, 0x20, 0x00, 0x00,
 }
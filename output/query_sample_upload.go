@@ -0,0 +1,61 @@
+package output
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+	uuid "github.com/satori/go.uuid"
+)
+
+// uploadSeparateQuerySamples - Uploads the query text of samples longer than
+// minLength as separate encrypted objects, which the samples then reference
+// instead of sending their full text
+//
+// The query text itself stays unchanged (it still determines the fingerprint),
+// only the first minLength bytes of it are sent. Samples whose text could not be
+// uploaded are sent with their full text.
+func uploadSeparateQuerySamples(ctx context.Context, server state.Server, grant state.GrantLogs, collectionOpts state.CollectionOpts, logger *util.Logger, samples []state.PostgresQuerySample) []state.PostgresQuerySample {
+	minLength := server.Config.SeparateQuerySampleMinLength
+	if minLength <= 0 {
+		return samples
+	}
+
+	var uploader *logUploader
+	for idx, sample := range samples {
+		if len(sample.Query) <= minLength {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		if uploader == nil {
+			var err error
+			uploader, err = newLogUploader(grant.Logdata, grant.EncryptionKey, collectionOpts.CompressLogs, server.Config.Tags)
+			if err != nil {
+				logger.PrintError("%s", err)
+				return samples
+			}
+		}
+
+		object := state.PostgresQueryTextObject{UUID: uuid.NewV4(), S3CmkKeyID: grant.EncryptionKey.KeyId, ByteSize: int64(len(sample.Query)), Compressed: collectionOpts.CompressLogs}
+		var err error
+		object.S3Location, object.S3CekAlgo, err = uploader.upload(ctx, logger, []byte(sample.Query), object.UUID.String())
+		if err != nil {
+			logger.PrintError("Could not upload query sample text separately: %s", err)
+			continue
+		}
+
+		// Don't split a multi-byte character
+		object.SentLength = minLength
+		for object.SentLength > 0 && !utf8.RuneStart(sample.Query[object.SentLength]) {
+			object.SentLength--
+		}
+
+		samples[idx].QueryTextObject = &object
+	}
+
+	return samples
+}
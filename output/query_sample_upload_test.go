@@ -0,0 +1,110 @@
+package output
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pganalyze/collector/config"
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+func TestUploadAndSendLogsSeparateQuerySamples(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	localDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	var s3Location string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s3Location = r.FormValue("s3_location")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	grant := state.GrantLogs{
+		Valid:         true,
+		Logdata:       state.GrantS3{LocalDir: localDir},
+		Snapshot:      state.GrantS3{LocalDir: localDir},
+		EncryptionKey: state.GrantLogsEncryptionKey{Plaintext: key, CiphertextBlob: key},
+		APIBaseURL:    api.URL,
+	}
+	server := state.Server{Config: config.ServerConfig{APIBaseURL: api.URL, SeparateQuerySampleMinLength: 1000}}
+
+	largeQuery := "INSERT INTO events (payload) VALUES ('" + strings.Repeat("x", 2*1024*1024) + "')"
+	smallQuery := "SELECT 1"
+	logState := state.LogState{
+		CollectedAt: time.Now(),
+		QuerySamples: []state.PostgresQuerySample{
+			{Username: "app", Database: "app", Query: largeQuery, RuntimeMs: 5000},
+			{Username: "app", Database: "app", Query: smallQuery, RuntimeMs: 1000},
+		},
+	}
+
+	err = UploadAndSendLogs(context.Background(), server, grant, state.CollectionOpts{SubmitCollectedData: true}, logger, logState)
+	if err != nil {
+		t.Fatalf("Sending logs failed: %s", err)
+	}
+
+	compressed := readLocalArtifact(t, s3Location).Data
+	r, err := zlib.NewReader(strings.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("Could not decompress snapshot: %s", err)
+	}
+	data, _ := ioutil.ReadAll(r)
+	var s snapshot.CompactSnapshot
+	if err = proto.Unmarshal(data, &s); err != nil {
+		t.Fatalf("Could not decode snapshot: %s", err)
+	}
+	if len(data) >= 1024*1024 {
+		t.Errorf("Expected large query sample to not be part of the snapshot, got %d bytes", len(data))
+	}
+
+	ls := s.GetLogSnapshot()
+	if len(ls.LogFileReferences) != 0 {
+		t.Errorf("Expected no log files, got %d", len(ls.LogFileReferences))
+	}
+	if len(ls.QueryTextObjects) != 1 {
+		t.Fatalf("Expected one separately uploaded query text, got %d", len(ls.QueryTextObjects))
+	}
+	ref := ls.QueryTextObjects[0]
+	if len(ls.QuerySamples) != 2 {
+		t.Fatalf("Expected 2 query samples, got %d", len(ls.QuerySamples))
+	}
+	if sample := ls.QuerySamples[0]; !sample.HasQueryTextObject || sample.QueryTextObjectIdx != 0 || sample.QueryText != largeQuery[:1000] {
+		t.Errorf("Expected large query sample to reference its query text, got %v with %q", sample.HasQueryTextObject, sample.QueryText[:20])
+	}
+	if sample := ls.QuerySamples[1]; sample.HasQueryTextObject || sample.QueryText != smallQuery {
+		t.Errorf("Expected small query sample to be unchanged, got %v", sample)
+	}
+	// The fingerprint is based on the full query text, not the part that is sent
+	fingerprint := util.FingerprintQuery(largeQuery)
+	if queryRef := s.BaseRefs.QueryReferences[ls.QuerySamples[0].QueryIdx]; !bytes.Equal(fingerprint[:], queryRef.Fingerprint) {
+		t.Errorf("Expected fingerprint of the full query text, got %x", queryRef.Fingerprint)
+	}
+
+	uploaded := readLocalArtifact(t, ref.S3Location)
+	if length := uploaded.Metadata["x-amz-meta-x-amz-unencrypted-content-length"]; length != fmt.Sprintf("%d", len(largeQuery)) {
+		t.Errorf("Expected separately uploaded object to contain %d bytes, got %s", len(largeQuery), length)
+	}
+	if ref.ByteSize != int64(len(largeQuery)) {
+		t.Errorf("Expected query text reference size %d, got %d", len(largeQuery), ref.ByteSize)
+	}
+}
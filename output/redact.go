@@ -0,0 +1,63 @@
+package output
+
+import (
+	"regexp"
+	"strings"
+
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+)
+
+const redactedValue = "<redacted>"
+
+// Settings whose values may contain credentials (e.g. primary_conninfo) or
+// point at secrets, and therefore never get printed in debug output
+var secretSettingNameParts = []string{"conninfo", "password", "passphrase", "secret"}
+
+// Matches credentials that are embedded in other values, e.g. a password
+// inside a connection string, or the userinfo part of a postgres:// URL
+var passwordKeyValueRegexp = regexp.MustCompile(`(?i)(password\s*=\s*)('(?:[^'\\]|\\.)*'|[^\s]*)`)
+var urlUserInfoRegexp = regexp.MustCompile(`(?i)([a-z][a-z0-9+.-]*://[^:@/\s]*:)[^@/\s]*@`)
+
+// redactSnapshotSecrets - Removes secrets from the snapshot, before it gets
+// printed as part of debug output
+func redactSnapshotSecrets(s *snapshot.FullSnapshot) {
+	for _, setting := range s.Settings {
+		if isSecretSettingName(setting.Name) {
+			if setting.CurrentValue != "" {
+				setting.CurrentValue = redactedValue
+			}
+			redactNullString(setting.BootValue)
+			redactNullString(setting.ResetValue)
+			continue
+		}
+		setting.CurrentValue = redactEmbeddedSecrets(setting.CurrentValue)
+		if setting.BootValue != nil {
+			setting.BootValue.Value = redactEmbeddedSecrets(setting.BootValue.Value)
+		}
+		if setting.ResetValue != nil {
+			setting.ResetValue.Value = redactEmbeddedSecrets(setting.ResetValue.Value)
+		}
+	}
+}
+
+func isSecretSettingName(name string) bool {
+	name = strings.ToLower(name)
+	for _, part := range secretSettingNameParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactNullString(value *snapshot.NullString) {
+	if value != nil && value.Value != "" {
+		value.Value = redactedValue
+	}
+}
+
+func redactEmbeddedSecrets(value string) string {
+	value = passwordKeyValueRegexp.ReplaceAllString(value, "${1}"+redactedValue)
+	value = urlUserInfoRegexp.ReplaceAllString(value, "${1}"+redactedValue+"@")
+	return value
+}
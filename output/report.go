@@ -15,10 +15,10 @@ import (
 	"github.com/pganalyze/collector/util"
 )
 
-func submitReportRun(server state.Server, report reports.Report, logger *util.Logger, s3Location string) error {
+func submitReportRun(server state.Server, grant state.Grant, report reports.Report, logger *util.Logger, s3Location string) error {
 	data := url.Values{"s3_location": {s3Location}}
 
-	req, err := http.NewRequest("POST", server.Config.APIBaseURL+"/v2/reports/submit_run", strings.NewReader(data.Encode()))
+	req, err := http.NewRequest("POST", submitURL(server, grant.APIBaseURL, "/v2/reports/submit_run"), strings.NewReader(data.Encode()))
 	if err != nil {
 		return err
 	}
@@ -79,5 +79,5 @@ func SubmitReport(server state.Server, grant state.Grant, report reports.Report,
 		return err
 	}
 
-	return submitReportRun(server, report, logger, s3Location)
+	return submitReportRun(server, grant, report, logger, s3Location)
 }
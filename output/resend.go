@@ -0,0 +1,64 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pganalyze/collector/grant"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// ResendLastSnapshots - Uploads and submits the last full snapshot and log
+// snapshot of the server again, exactly as they were sent before
+//
+// Nothing is collected or recomputed, which makes this useful for debugging the
+// processing of a specific snapshot. Returns whether there was anything to resend.
+//
+// The upload policies of the logs grant the log snapshot was sent with have
+// usually expired by now, so it gets uploaded using a current logs grant.
+func ResendLastSnapshots(ctx context.Context, server state.Server, collectionOpts state.CollectionOpts, logger *util.Logger) (bool, error) {
+	full, logs := server.LastSentSnapshots.Get()
+	if full == nil && logs == nil {
+		return false, nil
+	}
+
+	if full != nil {
+		s3Location, err := uploadSnapshot(server.Grant, logger, *bytes.NewBuffer(full.CompressedData), full.UUID)
+		if err != nil {
+			logger.PrintError("Error uploading to S3: %s", err)
+			return true, err
+		}
+		err = submitSnapshot(server, collectionOpts, logger, s3Location, full.CollectedAt, true)
+		if err != nil {
+			return true, err
+		}
+		logger.PrintInfo("Resent full snapshot %s", full.UUID)
+	}
+
+	if logs != nil {
+		logsGrant, err := grant.GetCachedLogsGrant(ctx, server, collectionOpts, logger)
+		if err != nil {
+			return true, fmt.Errorf("could not get logs grant: %s", err)
+		}
+		if !logsGrant.Valid {
+			return true, fmt.Errorf("log collection disabled from server, can't resend log snapshot")
+		}
+		s3Location, err := uploadCompactSnapshot(ctx, logsGrant.Snapshot, logger, *bytes.NewBuffer(logs.CompressedData), logs.UUID)
+		if err != nil {
+			if util.IsUploadForbidden(err) {
+				server.LogsGrantCache.Invalidate()
+			}
+			logger.PrintError("Error uploading to S3: %s", err)
+			return true, err
+		}
+		err = submitCompactSnapshot(ctx, server, logsGrant.APIBaseURL, collectionOpts, logger, s3Location, logs.CollectedAt, true, "logs")
+		if err != nil {
+			return true, err
+		}
+		logger.PrintInfo("Resent log snapshot %s", logs.UUID)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,27 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/pganalyze/collector/config"
+)
+
+// SnapshotTooLargeError - Returned instead of uploading a snapshot whose
+// compressed size exceeds max_snapshot_upload_bytes
+type SnapshotTooLargeError struct {
+	Size             int64 // Compressed size, i.e. what would have been uploaded
+	UncompressedSize int64
+	Limit            int64
+}
+
+func (e SnapshotTooLargeError) Error() string {
+	return fmt.Sprintf("snapshot too large: %d bytes (%d bytes uncompressed), exceeds max_snapshot_upload_bytes of %d bytes", e.Size, e.UncompressedSize, e.Limit)
+}
+
+func checkSnapshotSize(serverConfig config.ServerConfig, uncompressedSize int, compressedSize int) error {
+	limit := serverConfig.MaxSnapshotUploadBytes
+	if limit > 0 && int64(compressedSize) > limit {
+		return SnapshotTooLargeError{Size: int64(compressedSize), UncompressedSize: int64(uncompressedSize), Limit: limit}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pganalyze/collector/config"
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+func largeSnapshot(relationCount int) snapshot.FullSnapshot {
+	var s snapshot.FullSnapshot
+	for i := 0; i < relationCount; i++ {
+		s.RelationReferences = append(s.RelationReferences, &snapshot.RelationReference{
+			SchemaName:   fmt.Sprintf("tenant_%d", i*7919%relationCount),
+			RelationName: fmt.Sprintf("events_%x", i*104729),
+		})
+	}
+	return s
+}
+
+func TestSubmitFullSnapshotSizeLimit(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	var submitted int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		submitted++
+	}))
+	defer api.Close()
+
+	localDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	server := state.Server{
+		Config: config.ServerConfig{APIBaseURL: api.URL, MaxSnapshotUploadBytes: 10 * 1024},
+		Grant:  state.Grant{Valid: true, LocalDir: localDir},
+	}
+	opts := state.CollectionOpts{SubmitCollectedData: true}
+
+	err = submitFull(largeSnapshot(50000), server, opts, logger, time.Now(), true)
+	tooLarge, ok := err.(SnapshotTooLargeError)
+	if !ok {
+		t.Fatalf("Expected snapshot to be rejected as too large, got %v", err)
+	}
+	if tooLarge.Size <= server.Config.MaxSnapshotUploadBytes || tooLarge.UncompressedSize <= tooLarge.Size {
+		t.Errorf("Expected compressed size above the limit of %d bytes, and uncompressed size above that, got %+v", server.Config.MaxSnapshotUploadBytes, tooLarge)
+	}
+	if !strings.HasPrefix(err.Error(), fmt.Sprintf("snapshot too large: %d bytes", tooLarge.Size)) {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+	if files, _ := ioutil.ReadDir(localDir); len(files) != 0 || submitted != 0 {
+		t.Errorf("Expected nothing to be uploaded or submitted, got %d files and %d submissions", len(files), submitted)
+	}
+
+	// Snapshots within the limit are uploaded as usual
+	err = submitFull(largeSnapshot(10), server, opts, logger, time.Now(), true)
+	if err != nil {
+		t.Fatalf("Expected small snapshot to be submitted, got %s", err)
+	}
+	if files, _ := ioutil.ReadDir(localDir); len(files) != 1 || submitted != 1 {
+		t.Errorf("Expected one upload and submission, got %d files and %d submissions", len(files), submitted)
+	}
+}
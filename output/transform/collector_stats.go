@@ -1,6 +1,8 @@
 package transform
 
 import (
+	"time"
+
 	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
 	"github.com/pganalyze/collector/state"
 )
@@ -14,6 +16,31 @@ func transformCollectorStats(s snapshot.FullSnapshot, newState state.PersistedSt
 		MemoryRssBytes:           diffState.CollectorStats.MemoryRssBytes,
 		ActiveGoroutines:         diffState.CollectorStats.ActiveGoroutines,
 		CgoCalls:                 diffState.CollectorStats.CgoCalls,
+		LogLinesStitched:         diffState.CollectorStats.LogLinesStitched,
+		LogLinesDropped:          diffState.CollectorStats.LogLinesDropped,
+		LogLinesFiltered:         diffState.CollectorStats.LogLinesFiltered,
+		LogLinesUnparseable:      diffState.CollectorStats.LogLinesUnparseable,
+		LogLinesOverflowed:       diffState.CollectorStats.LogLinesOverflowed,
+		QuerySamplesDropped:      diffState.CollectorStats.QuerySamplesDropped,
+		UploadedLogBytes:         diffState.CollectorStats.UploadedLogBytes,
+		UploadedSnapshotBytes:    diffState.CollectorStats.UploadedSnapshotBytes,
+		LogStitchingDuration:     transformDurationStats(diffState.CollectorStats.LogProcessing.Stitching),
+		LogAnalysisDuration:      transformDurationStats(diffState.CollectorStats.LogProcessing.Analysis),
+		LogUploadDuration:        transformDurationStats(diffState.CollectorStats.LogProcessing.Upload),
 	}
 	return s
 }
+
+// transformDurationStats - Returns nil when nothing was recorded (e.g. because
+// log collection is disabled)
+func transformDurationStats(stats state.DurationStats) *snapshot.DurationStatistic {
+	if stats.Count == 0 {
+		return nil
+	}
+	return &snapshot.DurationStatistic{
+		Count:   stats.Count,
+		TotalMs: float64(stats.Total) / float64(time.Millisecond),
+		MinMs:   float64(stats.Min) / float64(time.Millisecond),
+		MaxMs:   float64(stats.Max) / float64(time.Millisecond),
+	}
+}
@@ -82,6 +82,19 @@ func transformPostgresQuerySamples(s snapshot.CompactLogSnapshot, r snapshot.Com
 			ExplainOutput: sampleIn.ExplainOutput,
 			ExplainError:  sampleIn.ExplainError,
 		}
+		if object := sampleIn.QueryTextObject; object != nil {
+			sample.QueryText = sampleIn.Query[:object.SentLength]
+			sample.QueryTextObjectIdx = int32(len(s.QueryTextObjects))
+			sample.HasQueryTextObject = true
+			s.QueryTextObjects = append(s.QueryTextObjects, &snapshot.QueryTextObjectReference{
+				Uuid:       object.UUID.String(),
+				S3Location: object.S3Location,
+				S3CekAlgo:  object.S3CekAlgo,
+				S3CmkKeyId: object.S3CmkKeyID,
+				ByteSize:   object.ByteSize,
+				Compressed: object.Compressed,
+			})
+		}
 		s.QuerySamples = append(s.QuerySamples, &sample)
 	}
 
@@ -98,6 +111,7 @@ func transformSystemLogs(s snapshot.CompactLogSnapshot, r snapshot.CompactSnapsh
 			S3CmkKeyId:   logFileIn.S3CmkKeyID,
 			ByteSize:     logFileIn.ByteSize,
 			OriginalName: logFileIn.OriginalName,
+			Compressed:   logFileIn.Compressed,
 		})
 		for _, logLineIn := range logFileIn.LogLines {
 			logLine := transformSystemLogLine(&r, fileIdx, logLineIn)
@@ -13,11 +13,19 @@ func transformPostgres(s snapshot.FullSnapshot, newState state.PersistedState, d
 
 	s = transformPostgresVersion(s, transientState)
 	s = transformPostgresConfig(s, transientState)
-	s = transformPostgresReplication(s, transientState, roleOidToIdx)
+	s = transformPostgresReplication(s, newState, diffState, roleOidToIdx)
 	s = transformPostgresStatements(s, newState, diffState, transientState, roleOidToIdx, databaseOidToIdx)
-	s = transformPostgresRelations(s, newState, diffState, roleOidToIdx, databaseOidToIdx)
+	s, relationOidToIdx, indexOidToIdx := transformPostgresRelations(s, newState, diffState, roleOidToIdx, databaseOidToIdx)
 	s = transformPostgresFunctions(s, newState, diffState, roleOidToIdx, databaseOidToIdx)
-	s = transformPostgresBackendCounts(s, transientState, roleOidToIdx, databaseOidToIdx)
+	s = transformPostgresBackendCounts(s, newState, roleOidToIdx, databaseOidToIdx)
+	s = transformPostgresBackendCountChanges(s, diffState)
+	s = transformPostgresIOStats(s, diffState)
+	s = transformPostgresBgwriterStats(s, diffState)
+	s = transformPostgresWaitEvents(s, transientState)
+	s = transformPostgresProgress(s, newState, transientState, roleOidToIdx, databaseOidToIdx, relationOidToIdx, indexOidToIdx)
+	s = transformPostgresLocks(s, newState, transientState, databaseOidToIdx, relationOidToIdx)
+	s = transformPostgresCustomMetrics(s, transientState)
+	s = transformPostgresWraparound(s, newState, transientState, databaseOidToIdx, relationOidToIdx)
 
 	return s
 }
@@ -97,8 +105,17 @@ func transformPostgresDatabases(s snapshot.FullSnapshot, transientState state.Tr
 }
 
 func transformPostgresConfig(s snapshot.FullSnapshot, transientState state.TransientState) snapshot.FullSnapshot {
+	newlyPendingRestart := make(map[string]bool)
+	for _, name := range transientState.NewlyPendingRestartSettings {
+		newlyPendingRestart[name] = true
+	}
+
 	for _, setting := range transientState.Settings {
-		info := snapshot.Setting{Name: setting.Name}
+		info := snapshot.Setting{
+			Name:                setting.Name,
+			PendingRestart:      setting.PendingRestart,
+			NewlyPendingRestart: newlyPendingRestart[setting.Name],
+		}
 
 		if setting.CurrentValue.Valid {
 			info.CurrentValue = setting.CurrentValue.String
@@ -124,6 +141,7 @@ func transformPostgresConfig(s snapshot.FullSnapshot, transientState state.Trans
 
 		s.Settings = append(s.Settings, &info)
 	}
+	s.SettingsChangedOnly = transientState.SettingsChangedOnly
 
 	return s
 }
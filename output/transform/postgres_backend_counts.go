@@ -1,12 +1,14 @@
 package transform
 
 import (
+	"sort"
+
 	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
 	"github.com/pganalyze/collector/state"
 )
 
-func transformPostgresBackendCounts(s snapshot.FullSnapshot, transientState state.TransientState, roleOidToIdx OidToIdx, databaseOidToIdx OidToIdx) snapshot.FullSnapshot {
-	for _, backendCount := range transientState.BackendCounts {
+func transformPostgresBackendCounts(s snapshot.FullSnapshot, newState state.PersistedState, roleOidToIdx OidToIdx, databaseOidToIdx OidToIdx) snapshot.FullSnapshot {
+	for _, backendCount := range newState.BackendCounts {
 		backendCountStatistic := snapshot.BackendCountStatistic{
 			WaitingForLock: backendCount.WaitingForLock,
 			Count:          backendCount.Count,
@@ -22,22 +24,7 @@ func transformPostgresBackendCounts(s snapshot.FullSnapshot, transientState stat
 			backendCountStatistic.HasRoleIdx = true
 		}
 
-		switch backendCount.State {
-		case "unknown":
-			backendCountStatistic.State = snapshot.BackendCountStatistic_UNKNOWN_STATE
-		case "active":
-			backendCountStatistic.State = snapshot.BackendCountStatistic_ACTIVE
-		case "idle":
-			backendCountStatistic.State = snapshot.BackendCountStatistic_IDLE
-		case "idle in transaction":
-			backendCountStatistic.State = snapshot.BackendCountStatistic_IDLE_IN_TRANSACTION
-		case "idle in transaction (aborted)":
-			backendCountStatistic.State = snapshot.BackendCountStatistic_IDLE_IN_TRANSACTION_ABORTED
-		case "fastpath function call":
-			backendCountStatistic.State = snapshot.BackendCountStatistic_FASTPATH_FUNCTION_CALL
-		case "disabled":
-			backendCountStatistic.State = snapshot.BackendCountStatistic_DISABLED
-		}
+		backendCountStatistic.State = transformBackendState(backendCount.State)
 
 		switch backendCount.BackendType {
 		case "unknown":
@@ -68,3 +55,37 @@ func transformPostgresBackendCounts(s snapshot.FullSnapshot, transientState stat
 	}
 	return s
 }
+
+// transformPostgresBackendCountChanges - Sends the change in the number of
+// backends per state since the last run (not set on the first run)
+func transformPostgresBackendCountChanges(s snapshot.FullSnapshot, diffState state.DiffState) snapshot.FullSnapshot {
+	changes := make(map[snapshot.BackendCountStatistic_BackendState]int32)
+	for backendState, change := range diffState.BackendCounts {
+		changes[transformBackendState(backendState)] += change
+	}
+	for backendState, change := range changes {
+		s.BackendCountChanges = append(s.BackendCountChanges, &snapshot.BackendCountChange{State: backendState, Change: change})
+	}
+	sort.Slice(s.BackendCountChanges, func(i, j int) bool {
+		return s.BackendCountChanges[i].State < s.BackendCountChanges[j].State
+	})
+	return s
+}
+
+func transformBackendState(backendState string) snapshot.BackendCountStatistic_BackendState {
+	switch backendState {
+	case "active":
+		return snapshot.BackendCountStatistic_ACTIVE
+	case "idle":
+		return snapshot.BackendCountStatistic_IDLE
+	case "idle in transaction":
+		return snapshot.BackendCountStatistic_IDLE_IN_TRANSACTION
+	case "idle in transaction (aborted)":
+		return snapshot.BackendCountStatistic_IDLE_IN_TRANSACTION_ABORTED
+	case "fastpath function call":
+		return snapshot.BackendCountStatistic_FASTPATH_FUNCTION_CALL
+	case "disabled":
+		return snapshot.BackendCountStatistic_DISABLED
+	}
+	return snapshot.BackendCountStatistic_UNKNOWN_STATE
+}
@@ -0,0 +1,14 @@
+package transform
+
+import (
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+)
+
+func transformPostgresCustomMetrics(s snapshot.FullSnapshot, transientState state.TransientState) snapshot.FullSnapshot {
+	for _, metric := range transientState.CustomMetrics {
+		s.CustomMetrics = append(s.CustomMetrics, &snapshot.CustomMetric{Name: metric.Name, Value: metric.Value})
+	}
+
+	return s
+}
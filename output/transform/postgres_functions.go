@@ -30,6 +30,7 @@ func transformPostgresFunctions(s snapshot.FullSnapshot, newState state.Persiste
 			Strict:          function.Strict,
 			ReturnsSet:      function.ReturnsSet,
 			Volatile:        function.Volatile,
+			DefinitionHash:  function.DefinitionHash,
 		}
 		if function.SourceBin.Valid {
 			info.SourceBin = function.SourceBin.String
@@ -50,5 +51,22 @@ func transformPostgresFunctions(s snapshot.FullSnapshot, newState state.Persiste
 		}
 	}
 
+	s = transformFunctionChanges(s, diffState.FunctionDefinitions.Added, snapshot.FunctionChange_ADDED, databaseOidToIdx)
+	s = transformFunctionChanges(s, diffState.FunctionDefinitions.Removed, snapshot.FunctionChange_REMOVED, databaseOidToIdx)
+	s = transformFunctionChanges(s, diffState.FunctionDefinitions.Modified, snapshot.FunctionChange_MODIFIED, databaseOidToIdx)
+
+	return s
+}
+
+func transformFunctionChanges(s snapshot.FullSnapshot, keys []state.PostgresFunctionKey, changeType snapshot.FunctionChange_ChangeType, databaseOidToIdx OidToIdx) snapshot.FullSnapshot {
+	for _, key := range keys {
+		s.FunctionChanges = append(s.FunctionChanges, &snapshot.FunctionChange{
+			DatabaseIdx:  databaseOidToIdx[key.DatabaseOid],
+			SchemaName:   key.SchemaName,
+			FunctionName: key.FunctionName,
+			Arguments:    key.Arguments,
+			ChangeType:   changeType,
+		})
+	}
 	return s
 }
@@ -0,0 +1,54 @@
+package transform
+
+import (
+	"sort"
+
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+)
+
+func transformPostgresIOStats(s snapshot.FullSnapshot, diffState state.DiffState) snapshot.FullSnapshot {
+	backendTypes := make([]string, 0, len(diffState.IOStats))
+	for backendType := range diffState.IOStats {
+		backendTypes = append(backendTypes, backendType)
+	}
+	sort.Strings(backendTypes)
+
+	for _, backendType := range backendTypes {
+		stats := diffState.IOStats[backendType]
+		s.IoStatistics = append(s.IoStatistics, &snapshot.IOStatistic{
+			BackendType:      backendType,
+			ReadsPerSecond:   stats.ReadsPerSecond,
+			WritesPerSecond:  stats.WritesPerSecond,
+			ExtendsPerSecond: stats.ExtendsPerSecond,
+			HitsPerSecond:    stats.HitsPerSecond,
+			ReadTime:         stats.ReadTime,
+			WriteTime:        stats.WriteTime,
+			ExtendTime:       stats.ExtendTime,
+		})
+	}
+
+	return s
+}
+
+func transformPostgresBgwriterStats(s snapshot.FullSnapshot, diffState state.DiffState) snapshot.FullSnapshot {
+	stats := diffState.BgwriterStats
+	if stats == nil {
+		return s
+	}
+
+	s.BgwriterStatistic = &snapshot.BgwriterStatistic{
+		CheckpointsTimed:           stats.CheckpointsTimed,
+		CheckpointsRequested:       stats.CheckpointsRequested,
+		CheckpointWriteTime:        stats.CheckpointWriteTime,
+		CheckpointSyncTime:         stats.CheckpointSyncTime,
+		MaxwrittenClean:            stats.MaxwrittenClean,
+		BuffersBackendFsync:        stats.BuffersBackendFsync,
+		BuffersCheckpointPerSecond: stats.BuffersCheckpointPerSecond,
+		BuffersCleanPerSecond:      stats.BuffersCleanPerSecond,
+		BuffersBackendPerSecond:    stats.BuffersBackendPerSecond,
+		BuffersAllocPerSecond:      stats.BuffersAllocPerSecond,
+	}
+
+	return s
+}
@@ -0,0 +1,53 @@
+package transform
+
+import (
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+)
+
+func transformPostgresLocks(s snapshot.FullSnapshot, newState state.PersistedState, transientState state.TransientState, databaseOidToIdx OidToIdx, relationOidToIdx OidToIdx) snapshot.FullSnapshot {
+	// Relation OIDs are only unique within a database, and relations are only
+	// collected for the monitored database
+	relationDatabaseOids := make(map[state.Oid]state.Oid)
+	for _, relation := range newState.Relations {
+		relationDatabaseOids[relation.Oid] = relation.DatabaseOid
+	}
+
+	lockWaitIdxByPid := make(map[int32]int32)
+	for _, wait := range transientState.Locks.Waits {
+		w := snapshot.LockWait{
+			Pid:          wait.Pid,
+			LockType:     wait.LockType,
+			Mode:         wait.Mode,
+			BlockingPids: wait.BlockingPids,
+		}
+		if wait.DatabaseOid != 0 {
+			w.DatabaseIdx, w.HasDatabaseIdx = databaseOidToIdx[wait.DatabaseOid]
+		}
+		if databaseOid, ok := relationDatabaseOids[wait.RelationOid]; ok && wait.RelationOid != 0 && databaseOid == wait.DatabaseOid {
+			w.RelationIdx, w.HasRelationIdx = relationOidToIdx[wait.RelationOid]
+		}
+		lockWaitIdxByPid[wait.Pid] = int32(len(s.LockWaits))
+		s.LockWaits = append(s.LockWaits, &w)
+	}
+
+	for _, node := range transientState.Locks.BlockingTrees {
+		s.BlockingTrees = append(s.BlockingTrees, transformBlockingBackend(node, lockWaitIdxByPid))
+	}
+
+	return s
+}
+
+func transformBlockingBackend(node state.PostgresBlockingNode, lockWaitIdxByPid map[int32]int32) *snapshot.BlockingBackend {
+	b := snapshot.BlockingBackend{
+		Pid:       node.Pid,
+		State:     node.State.String,
+		QueryText: node.Query.String,
+		Cycle:     node.Cycle,
+	}
+	b.LockWaitIdx, b.HasLockWaitIdx = lockWaitIdxByPid[node.Pid]
+	for _, blocked := range node.Blocked {
+		b.Blocked = append(b.Blocked, transformBlockingBackend(blocked, lockWaitIdxByPid))
+	}
+	return &b
+}
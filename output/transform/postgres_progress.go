@@ -0,0 +1,91 @@
+package transform
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+)
+
+func transformPostgresProgress(s snapshot.FullSnapshot, newState state.PersistedState, transientState state.TransientState, roleOidToIdx OidToIdx, databaseOidToIdx OidToIdx, relationOidToIdx OidToIdx, indexOidToIdx OidToIdx) snapshot.FullSnapshot {
+	// Vacuum progress identifies objects by name, since it is shared with the
+	// activity snapshots that don't have the catalog available
+	roleNameToOid := make(map[string]state.Oid)
+	for _, role := range transientState.Roles {
+		roleNameToOid[role.Name] = role.Oid
+	}
+	databaseNameToOid := make(map[string]state.Oid)
+	databaseOidToName := make(map[state.Oid]string)
+	for _, database := range transientState.Databases {
+		databaseNameToOid[database.Name] = database.Oid
+		databaseOidToName[database.Oid] = database.Name
+	}
+	relationNameToOid := make(map[[3]string]state.Oid)
+	for _, relation := range newState.Relations {
+		relationNameToOid[[3]string{databaseOidToName[relation.DatabaseOid], relation.SchemaName, relation.RelationName}] = relation.Oid
+	}
+
+	for _, vacuum := range transientState.Progress.Vacuums {
+		v := snapshot.VacuumProgress{
+			VacuumIdentity:   vacuum.VacuumIdentity,
+			BackendIdentity:  vacuum.BackendIdentity,
+			SchemaName:       vacuum.SchemaName,
+			RelationName:     vacuum.RelationName,
+			Autovacuum:       vacuum.Autovacuum,
+			Phase:            vacuum.Phase,
+			HeapBlksTotal:    vacuum.HeapBlksTotal,
+			HeapBlksScanned:  vacuum.HeapBlksScanned,
+			HeapBlksVacuumed: vacuum.HeapBlksVacuumed,
+			IndexVacuumCount: vacuum.IndexVacuumCount,
+			MaxDeadTuples:    vacuum.MaxDeadTuples,
+			NumDeadTuples:    vacuum.NumDeadTuples,
+		}
+		v.StartedAt, _ = ptypes.TimestampProto(vacuum.StartedAt)
+		if oid, ok := databaseNameToOid[vacuum.DatabaseName]; ok {
+			v.DatabaseIdx, v.HasDatabaseIdx = databaseOidToIdx[oid]
+		}
+		if oid, ok := relationNameToOid[[3]string{vacuum.DatabaseName, vacuum.SchemaName, vacuum.RelationName}]; ok {
+			v.RelationIdx, v.HasRelationIdx = relationOidToIdx[oid]
+		}
+		if oid, ok := roleNameToOid[vacuum.RoleName]; ok {
+			v.RoleIdx, v.HasRoleIdx = roleOidToIdx[oid]
+		}
+		s.VacuumProgress = append(s.VacuumProgress, &v)
+	}
+
+	for _, createIndex := range transientState.Progress.CreateIndexes {
+		c := snapshot.CreateIndexProgress{
+			Pid:             createIndex.Pid,
+			Command:         createIndex.Command,
+			Phase:           createIndex.Phase,
+			LockersTotal:    createIndex.LockersTotal,
+			LockersDone:     createIndex.LockersDone,
+			BlocksTotal:     createIndex.BlocksTotal,
+			BlocksDone:      createIndex.BlocksDone,
+			TuplesTotal:     createIndex.TuplesTotal,
+			TuplesDone:      createIndex.TuplesDone,
+			PartitionsTotal: createIndex.PartitionsTotal,
+			PartitionsDone:  createIndex.PartitionsDone,
+		}
+		c.DatabaseIdx, c.HasDatabaseIdx = databaseOidToIdx[createIndex.DatabaseOid]
+		c.RelationIdx, c.HasRelationIdx = relationOidToIdx[createIndex.RelationOid]
+		if createIndex.IndexOid != 0 {
+			c.IndexIdx, c.HasIndexIdx = indexOidToIdx[createIndex.IndexOid]
+		}
+		s.CreateIndexProgress = append(s.CreateIndexProgress, &c)
+	}
+
+	for _, baseBackup := range transientState.Progress.BaseBackups {
+		b := snapshot.BaseBackupProgress{
+			Pid:                 baseBackup.Pid,
+			Phase:               baseBackup.Phase,
+			BackupTotal:         baseBackup.BackupTotal.Int64,
+			HasBackupTotal:      baseBackup.BackupTotal.Valid,
+			BackupStreamed:      baseBackup.BackupStreamed,
+			TablespacesTotal:    baseBackup.TablespacesTotal,
+			TablespacesStreamed: baseBackup.TablespacesStreamed,
+		}
+		s.BaseBackupProgress = append(s.BaseBackupProgress, &b)
+	}
+
+	return s
+}
@@ -7,7 +7,35 @@ import (
 	"github.com/pganalyze/collector/state"
 )
 
-func transformPostgresRelations(s snapshot.FullSnapshot, newState state.PersistedState, diffState state.DiffState, roleOidToIdx OidToIdx, databaseOidToIdx OidToIdx) snapshot.FullSnapshot {
+type bloatKey struct {
+	databaseOid state.Oid
+	schemaName  string
+	name        string
+}
+
+func transformPostgresRelations(s snapshot.FullSnapshot, newState state.PersistedState, diffState state.DiffState, roleOidToIdx OidToIdx, databaseOidToIdx OidToIdx) (snapshot.FullSnapshot, OidToIdx, OidToIdx) {
+	relationOidToIdx := make(OidToIdx)
+	indexOidToIdx := make(OidToIdx)
+
+	// Bloat estimates are matched by database as well, since the same schema and
+	// relation name can exist in several monitored databases
+	relationBloat := make(map[bloatKey]int64)
+	for _, bloat := range newState.BloatStats.Relations {
+		relationBloat[bloatKey{bloat.DatabaseOid, bloat.SchemaName, bloat.RelationName}] = bloat.BloatBytes
+	}
+	indexBloat := make(map[bloatKey]int64)
+	for _, bloat := range newState.BloatStats.Indices {
+		indexBloat[bloatKey{bloat.DatabaseOid, bloat.SchemaName, bloat.IndexName}] = bloat.BloatBytes
+	}
+	s.RelationStatisticsIntervalSecs = diffState.RelationStatsIntervalSecs
+	if !newState.BloatCollectedAt.IsZero() {
+		s.BloatCollectedAt, _ = ptypes.TimestampProto(newState.BloatCollectedAt)
+	}
+
+	// Parents are resolved once all relations have an idx, since they might come
+	// after their partitions
+	partitionParentOids := make(map[*snapshot.RelationInformation]state.Oid)
+
 	for _, relation := range newState.Relations {
 		ref := snapshot.RelationReference{
 			DatabaseIdx:  databaseOidToIdx[relation.DatabaseOid],
@@ -16,6 +44,7 @@ func transformPostgresRelations(s snapshot.FullSnapshot, newState state.Persiste
 		}
 		idx := int32(len(s.RelationReferences))
 		s.RelationReferences = append(s.RelationReferences, &ref)
+		relationOidToIdx[relation.Oid] = idx
 
 		// Information
 		info := snapshot.RelationInformation{
@@ -30,6 +59,10 @@ func transformPostgresRelations(s snapshot.FullSnapshot, newState state.Persiste
 			MinimumMultixactXid:    uint32(relation.MinimumMultixactXID),
 			ExclusivelyLocked:      relation.ExclusivelyLocked,
 			Options:                relation.Options,
+			IsPartition:            relation.IsPartition(),
+		}
+		if relation.IsPartition() {
+			partitionParentOids[&info] = relation.ParentRelationOid
 		}
 
 		if relation.ViewDefinition != "" {
@@ -73,30 +106,42 @@ func transformPostgresRelations(s snapshot.FullSnapshot, newState state.Persiste
 		stats, exists := diffState.RelationStats[relation.Oid]
 		if exists {
 			statistic := snapshot.RelationStatistic{
-				RelationIdx:   idx,
-				SizeBytes:     stats.SizeBytes,
-				SeqScan:       stats.SeqScan,
-				SeqTupRead:    stats.SeqTupRead,
-				IdxScan:       stats.IdxScan,
-				IdxTupFetch:   stats.IdxTupFetch,
-				NTupIns:       stats.NTupIns,
-				NTupUpd:       stats.NTupUpd,
-				NTupDel:       stats.NTupDel,
-				NTupHotUpd:    stats.NTupHotUpd,
-				NLiveTup:      stats.NLiveTup,
-				NDeadTup:      stats.NDeadTup,
-				HeapBlksRead:  stats.HeapBlksRead,
-				HeapBlksHit:   stats.HeapBlksHit,
-				IdxBlksRead:   stats.IdxBlksRead,
-				IdxBlksHit:    stats.IdxBlksHit,
-				ToastBlksRead: stats.ToastBlksRead,
-				ToastBlksHit:  stats.ToastBlksHit,
-				TidxBlksRead:  stats.TidxBlksRead,
-				TidxBlksHit:   stats.TidxBlksHit,
+				RelationIdx:    idx,
+				SizeBytes:      stats.SizeBytes,
+				MainSizeBytes:  stats.MainSizeBytes,
+				ToastSizeBytes: stats.ToastSizeBytes,
+				IndexSizeBytes: stats.IndexSizeBytes,
+				SeqScan:        stats.SeqScan,
+				SeqTupRead:     stats.SeqTupRead,
+				IdxScan:        stats.IdxScan,
+				IdxTupFetch:    stats.IdxTupFetch,
+				NTupIns:        stats.NTupIns,
+				NTupUpd:        stats.NTupUpd,
+				NTupDel:        stats.NTupDel,
+				NTupHotUpd:     stats.NTupHotUpd,
+				NLiveTup:       stats.NLiveTup,
+				NDeadTup:       stats.NDeadTup,
+				HeapBlksRead:   stats.HeapBlksRead,
+				HeapBlksHit:    stats.HeapBlksHit,
+				IdxBlksRead:    stats.IdxBlksRead,
+				IdxBlksHit:     stats.IdxBlksHit,
+				ToastBlksRead:  stats.ToastBlksRead,
+				ToastBlksHit:   stats.ToastBlksHit,
+				TidxBlksRead:   stats.TidxBlksRead,
+				TidxBlksHit:    stats.TidxBlksHit,
+
+				LastAutovacuum:  snapshot.NullTimeToNullTimestamp(stats.LastAutovacuum),
+				LastAutoanalyze: snapshot.NullTimeToNullTimestamp(stats.LastAutoanalyze),
+				DeadTupleRatio:  state.PostgresRelationStats(stats).DeadTupleRatio(),
+				VacuumOverdue:   stats.VacuumOverdue,
 			}
 			if stats.NModSinceAnalyze.Valid {
 				statistic.NModSinceAnalyze = stats.NModSinceAnalyze.Int64
 			}
+			if bloatBytes, exists := relationBloat[bloatKey{relation.DatabaseOid, relation.SchemaName, relation.RelationName}]; exists {
+				statistic.BloatBytes = bloatBytes
+				statistic.HasBloatEstimate = true
+			}
 			s.RelationStatistics = append(s.RelationStatistics, &statistic)
 
 			// Events
@@ -115,6 +160,7 @@ func transformPostgresRelations(s snapshot.FullSnapshot, newState state.Persiste
 			}
 			indexIdx := int32(len(s.IndexReferences))
 			s.IndexReferences = append(s.IndexReferences, &ref)
+			indexOidToIdx[index.IndexOid] = indexIdx
 
 			// Information
 			indexInfo := snapshot.IndexInformation{
@@ -147,12 +193,23 @@ func transformPostgresRelations(s snapshot.FullSnapshot, newState state.Persiste
 					IdxBlksRead: indexStats.IdxBlksRead,
 					IdxBlksHit:  indexStats.IdxBlksHit,
 				}
+				if bloatBytes, exists := indexBloat[bloatKey{relation.DatabaseOid, relation.SchemaName, index.Name}]; exists {
+					statistic.BloatBytes = bloatBytes
+					statistic.HasBloatEstimate = true
+				}
+				if unused, ok := newState.UnusedIndexes[index.IndexOid]; ok {
+					statistic.UnusedSince, _ = ptypes.TimestampProto(unused.Since)
+				}
 				s.IndexStatistics = append(s.IndexStatistics, &statistic)
 			}
 		}
 	}
 
-	return s
+	for info, parentOid := range partitionParentOids {
+		info.ParentRelationIdx, info.HasParentRelationIdx = relationOidToIdx[parentOid]
+	}
+
+	return s, relationOidToIdx, indexOidToIdx
 }
 
 func addRelationEvents(relationIdx int32, events []*snapshot.RelationEvent, count int64, lastTime null.Time, eventType snapshot.RelationEvent_EventType) []*snapshot.RelationEvent {
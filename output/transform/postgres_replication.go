@@ -6,9 +6,10 @@ import (
 	"github.com/pganalyze/collector/state"
 )
 
-func transformPostgresReplication(s snapshot.FullSnapshot, transientState state.TransientState, roleOidToIdx OidToIdx) snapshot.FullSnapshot {
-	r := transientState.Replication
-	s.Replication = &snapshot.Replication{InRecovery: r.InRecovery}
+func transformPostgresReplication(s snapshot.FullSnapshot, newState state.PersistedState, diffState state.DiffState, roleOidToIdx OidToIdx) snapshot.FullSnapshot {
+	r := newState.Replication
+	d := diffState.Replication
+	s.Replication = &snapshot.Replication{InRecovery: r.InRecovery, RoleChanged: d.RoleChanged}
 
 	if r.CurrentXlogLocation.Valid {
 		s.Replication.CurrentXlogLocation = r.CurrentXlogLocation.String
@@ -30,6 +31,11 @@ func transformPostgresReplication(s snapshot.FullSnapshot, transientState state.
 		s.Replication.ApplyByteLag = r.ApplyByteLag.Int64
 	}
 
+	if d.ApplyByteLagDelta.Valid {
+		s.Replication.ApplyByteLagDelta = d.ApplyByteLagDelta.Int64
+		s.Replication.HasApplyByteLagDelta = true
+	}
+
 	if r.ReplayTimestamp.Valid {
 		s.Replication.ReplayTimestamp, _ = ptypes.TimestampProto(r.ReplayTimestamp.Time)
 	}
@@ -72,10 +78,55 @@ func transformPostgresReplication(s snapshot.FullSnapshot, transientState state.
 		} else {
 			stats.ByteLag = -1
 		}
+		if delta, exists := d.StandbyByteLagDeltas[standby.Key()]; exists {
+			stats.ByteLagDelta = delta
+			stats.HasByteLagDelta = true
+		}
+		for _, key := range d.AddedStandbys {
+			if key == standby.Key() {
+				stats.NewlyConnected = true
+			}
+		}
 
 		s.Replication.StandbyStatistics = append(s.Replication.StandbyStatistics,
 			&stats)
 	}
 
+	for _, key := range d.RemovedStandbys {
+		s.Replication.DisconnectedStandbys = append(s.Replication.DisconnectedStandbys,
+			&snapshot.DisconnectedStandby{ClientAddr: key.ClientAddr, ApplicationName: key.ApplicationName})
+	}
+
+	stalledSlots := make(map[string]bool)
+	for _, slotName := range d.StalledLogicalSlots {
+		stalledSlots[slotName] = true
+	}
+	for _, slotIn := range r.LogicalSlots {
+		slot := snapshot.LogicalReplicationSlot{
+			SlotName:                 slotIn.SlotName,
+			Plugin:                   slotIn.Plugin,
+			Active:                   slotIn.Active,
+			RestartLsn:               slotIn.RestartLsn.String,
+			ConfirmedFlushLsn:        slotIn.ConfirmedFlushLsn.String,
+			RestartByteLag:           slotIn.RestartByteLag.Int64,
+			HasRestartByteLag:        slotIn.RestartByteLag.Valid,
+			ConfirmedFlushByteLag:    slotIn.ConfirmedFlushByteLag.Int64,
+			HasConfirmedFlushByteLag: slotIn.ConfirmedFlushByteLag.Valid,
+			Stalled:                  stalledSlots[slotIn.SlotName],
+		}
+		for idx, ref := range s.DatabaseReferences {
+			if ref.Name == slotIn.DatabaseName {
+				slot.DatabaseIdx = int32(idx)
+				slot.HasDatabaseIdx = true
+				break
+			}
+		}
+		if delta, ok := d.LogicalSlotByteLagDeltas[slotIn.SlotName]; ok {
+			slot.ConfirmedFlushByteLagDelta = delta
+			slot.HasConfirmedFlushByteLagDelta = true
+		}
+		s.Replication.LogicalSlots = append(s.Replication.LogicalSlots, &slot)
+	}
+
 	return s
 }
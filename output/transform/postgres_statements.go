@@ -73,6 +73,9 @@ func transformQueryStatistic(stats state.DiffedPostgresStatementStats, idx int32
 }
 
 func transformPostgresStatements(s snapshot.FullSnapshot, newState state.PersistedState, diffState state.DiffState, transientState state.TransientState, roleOidToIdx OidToIdx, databaseOidToIdx OidToIdx) snapshot.FullSnapshot {
+	s.QueryStatisticsReset = diffState.StatementStatsReset
+	s.QueryStatisticsIntervalSecs = diffState.StatementStatsIntervalSecs
+
 	// Statement stats from this snapshot
 	groupedStatements := groupStatements(transientState.Statements, diffState.StatementStats)
 	for key, value := range groupedStatements {
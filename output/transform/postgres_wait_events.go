@@ -0,0 +1,37 @@
+package transform
+
+import (
+	"sort"
+
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+)
+
+func transformPostgresWaitEvents(s snapshot.FullSnapshot, transientState state.TransientState) snapshot.FullSnapshot {
+	summary := transientState.WaitEvents
+	if summary.SampleCount == 0 {
+		return s
+	}
+
+	keys := make([]state.PostgresWaitEventKey, 0, len(summary.Counts))
+	for key := range summary.Counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].WaitEventType != keys[j].WaitEventType {
+			return keys[i].WaitEventType < keys[j].WaitEventType
+		}
+		return keys[i].WaitEvent < keys[j].WaitEvent
+	})
+
+	s.WaitEventSampleCount = int32(summary.SampleCount)
+	for _, key := range keys {
+		s.WaitEventStatistics = append(s.WaitEventStatistics, &snapshot.WaitEventStatistic{
+			WaitEventType: key.WaitEventType,
+			WaitEvent:     key.WaitEvent,
+			Count:         summary.Counts[key],
+		})
+	}
+
+	return s
+}
@@ -0,0 +1,54 @@
+package transform
+
+import (
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+)
+
+var wraparoundRisks = map[string]snapshot.WraparoundRisk{
+	state.WraparoundRiskLow:      snapshot.WraparoundRisk_WRAPAROUND_RISK_LOW,
+	state.WraparoundRiskElevated: snapshot.WraparoundRisk_WRAPAROUND_RISK_ELEVATED,
+	state.WraparoundRiskHigh:     snapshot.WraparoundRisk_WRAPAROUND_RISK_HIGH,
+	state.WraparoundRiskCritical: snapshot.WraparoundRisk_WRAPAROUND_RISK_CRITICAL,
+}
+
+func transformPostgresWraparound(s snapshot.FullSnapshot, newState state.PersistedState, transientState state.TransientState, databaseOidToIdx OidToIdx, relationOidToIdx OidToIdx) snapshot.FullSnapshot {
+	wraparound := transientState.Wraparound
+	if len(wraparound.Databases) == 0 && len(wraparound.Tables) == 0 {
+		return s
+	}
+
+	// Relation OIDs are only unique within a database, so the name has to match
+	// as well (TOAST tables are not collected as relations at all)
+	relationNames := make(map[state.Oid][2]string)
+	for _, relation := range newState.Relations {
+		relationNames[relation.Oid] = [2]string{relation.SchemaName, relation.RelationName}
+	}
+
+	w := snapshot.Wraparound{FreezeMaxAge: wraparound.FreezeMaxAge}
+	for _, database := range wraparound.Databases {
+		d := snapshot.DatabaseWraparound{
+			XidAge:              database.XIDAge,
+			FreezeMaxAgePercent: database.FreezeMaxAgePercent,
+			Risk:                wraparoundRisks[database.Risk],
+		}
+		d.DatabaseIdx, d.HasDatabaseIdx = databaseOidToIdx[database.DatabaseOid]
+		w.Databases = append(w.Databases, &d)
+	}
+	for _, table := range wraparound.Tables {
+		t := snapshot.TableWraparound{
+			SchemaName:          table.SchemaName,
+			RelationName:        table.RelationName,
+			XidAge:              table.XIDAge,
+			FreezeMaxAgePercent: table.FreezeMaxAgePercent,
+			Risk:                wraparoundRisks[table.Risk],
+		}
+		if relationNames[table.RelationOid] == [2]string{table.SchemaName, table.RelationName} {
+			t.RelationIdx, t.HasRelationIdx = relationOidToIdx[table.RelationOid]
+		}
+		w.Tables = append(w.Tables, &t)
+	}
+	s.Wraparound = &w
+
+	return s
+}
@@ -25,6 +25,11 @@ func SystemStateToCompactSystemSnapshot(systemState state.SystemState) snapshot.
 }
 
 func systemStateToFullSnapshot(s snapshot.FullSnapshot, newState state.PersistedState, diffState state.DiffState) snapshot.FullSnapshot {
+	// System information that was carried over from an earlier run (see
+	// system_collection_interval) was already sent back then
+	if !state.CollectedThisRun(newState, state.CollectionCategorySystem) {
+		return s
+	}
 	s.System = transformSystem(newState.System, diffState)
 	return s
 }
@@ -118,6 +123,13 @@ func transformSystem(systemState state.SystemState, diffState state.DiffState) *
 		HugePagesTotal:     systemState.Memory.HugePagesTotal,
 		HugePagesReserved:  systemState.Memory.HugePagesReserved,
 		HugePagesSurplus:   systemState.Memory.HugePagesSurplus,
+
+		SwapInBytes:          diffState.SystemMemoryStats.SwapInBytes,
+		SwapOutBytes:         diffState.SystemMemoryStats.SwapOutBytes,
+		AvailableBytesDelta:  diffState.SystemMemoryStats.AvailableBytesDelta,
+		FreeBytesDelta:       diffState.SystemMemoryStats.FreeBytesDelta,
+		SwapUsedBytesDelta:   diffState.SystemMemoryStats.SwapUsedBytesDelta,
+		LikelyMemoryPressure: diffState.SystemMemoryStats.LikelyMemoryPressure,
 	}
 
 	system.CpuInformation = &snapshot.CPUInformation{
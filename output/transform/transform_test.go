@@ -3,7 +3,11 @@ package transform_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/guregu/null"
 	"github.com/pganalyze/collector/output/pganalyze_collector"
 	"github.com/pganalyze/collector/output/transform"
 	"github.com/pganalyze/collector/state"
@@ -128,3 +132,571 @@ func TestStatements(t *testing.T) {
 		t.Errorf("\nExpected:%+v\n\tActual: %+v\n\n", string(expectedJSON), string(actualJSON))
 	}
 }
+
+func TestReplicationDiff(t *testing.T) {
+	replicaA := state.PostgresReplicationStandby{ClientAddr: "10.0.0.1", ApplicationName: "replica_a", ByteLag: null.IntFrom(1500)}
+	replicaB := state.PostgresReplicationStandby{ClientAddr: "10.0.0.2", ApplicationName: "replica_b", ByteLag: null.IntFrom(0)}
+	newState := state.PersistedState{Replication: state.PostgresReplication{Standbys: []state.PostgresReplicationStandby{replicaA, replicaB}}}
+	diffState := state.DiffState{Replication: state.DiffedPostgresReplication{
+		StandbyByteLagDeltas: map[state.PostgresReplicationStandbyKey]int64{replicaA.Key(): 500},
+		AddedStandbys:        []state.PostgresReplicationStandbyKey{replicaB.Key()},
+		RemovedStandbys:      []state.PostgresReplicationStandbyKey{{ClientAddr: "10.0.0.3", ApplicationName: "replica_c"}},
+	}}
+
+	r := transform.StateToSnapshot(newState, diffState, state.TransientState{}).Replication
+
+	stats := r.StandbyStatistics
+	if len(stats) != 2 || !stats[0].HasByteLagDelta || stats[0].ByteLagDelta != 500 || stats[0].NewlyConnected ||
+		stats[1].HasByteLagDelta || !stats[1].NewlyConnected {
+		t.Errorf("Unexpected standby statistics: %v", stats)
+	}
+	if len(r.DisconnectedStandbys) != 1 || r.DisconnectedStandbys[0].ClientAddr != "10.0.0.3" || r.DisconnectedStandbys[0].ApplicationName != "replica_c" {
+		t.Errorf("Unexpected disconnected standbys: %v", r.DisconnectedStandbys)
+	}
+}
+
+func TestLogicalReplicationSlots(t *testing.T) {
+	newState := state.PersistedState{Replication: state.PostgresReplication{LogicalSlots: []state.PostgresReplicationSlot{
+		{SlotName: "events", Plugin: "pgoutput", DatabaseName: "app", Active: true, RestartLsn: null.StringFrom("0/3000000"), ConfirmedFlushLsn: null.StringFrom("0/3000100"), RestartByteLag: null.IntFrom(2048), ConfirmedFlushByteLag: null.IntFrom(1024)},
+		{SlotName: "old_consumer", Plugin: "wal2json", DatabaseName: "dropped", RestartByteLag: null.IntFrom(4096)},
+	}}}
+	diffState := state.DiffState{Replication: state.DiffedPostgresReplication{
+		LogicalSlotByteLagDeltas: map[string]int64{"events": -512},
+		StalledLogicalSlots:      []string{"old_consumer"},
+	}}
+	transientState := state.TransientState{Databases: []state.PostgresDatabase{{Oid: 1, Name: "postgres"}, {Oid: 2, Name: "app"}}}
+
+	slots := transform.StateToSnapshot(newState, diffState, transientState).Replication.LogicalSlots
+
+	expected := []*pganalyze_collector.LogicalReplicationSlot{
+		{SlotName: "events", Plugin: "pgoutput", DatabaseIdx: 1, HasDatabaseIdx: true, Active: true, RestartLsn: "0/3000000", ConfirmedFlushLsn: "0/3000100", RestartByteLag: 2048, HasRestartByteLag: true, ConfirmedFlushByteLag: 1024, HasConfirmedFlushByteLag: true, ConfirmedFlushByteLagDelta: -512, HasConfirmedFlushByteLagDelta: true},
+		{SlotName: "old_consumer", Plugin: "wal2json", RestartByteLag: 4096, HasRestartByteLag: true, Stalled: true},
+	}
+	if len(slots) != len(expected) {
+		t.Fatalf("Expected %d logical slots, got %v", len(expected), slots)
+	}
+	for idx := range expected {
+		if !proto.Equal(expected[idx], slots[idx]) {
+			t.Errorf("Unexpected logical slot %d: %v", idx, slots[idx])
+		}
+	}
+}
+
+func TestRelationBloat(t *testing.T) {
+	bloatCollectedAt := time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+	newState := state.PersistedState{
+		Relations: []state.PostgresRelation{
+			{Oid: 1, DatabaseOid: 16384, SchemaName: "public", RelationName: "bloated", Indices: []state.PostgresIndex{{IndexOid: 2, Name: "bloated_pkey"}}},
+			{Oid: 3, DatabaseOid: 16384, SchemaName: "public", RelationName: "unknown"},
+			// Same name in another database, which has no bloat estimate
+			{Oid: 4, DatabaseOid: 16385, SchemaName: "public", RelationName: "bloated", Indices: []state.PostgresIndex{{IndexOid: 5, Name: "bloated_pkey"}}},
+		},
+		BloatStats: state.PostgresBloatStats{
+			Relations: []state.PostgresRelationBloat{{DatabaseOid: 16384, SchemaName: "public", RelationName: "bloated", TotalBytes: 8192, BloatBytes: 4096}},
+			Indices:   []state.PostgresIndexBloat{{DatabaseOid: 16384, SchemaName: "public", IndexName: "bloated_pkey", TotalBytes: 8192, BloatBytes: 1024}},
+		},
+		BloatCollectedAt: bloatCollectedAt,
+	}
+	diffState := state.DiffState{
+		RelationStats: state.DiffedPostgresRelationStatsMap{1: {}, 3: {}, 4: {}},
+		IndexStats:    state.DiffedPostgresIndexStatsMap{2: {}, 5: {}},
+	}
+
+	s := transform.StateToSnapshot(newState, diffState, state.TransientState{})
+
+	if collectedAt, _ := ptypes.Timestamp(s.BloatCollectedAt); !collectedAt.Equal(bloatCollectedAt) {
+		t.Errorf("Expected bloat collection time %s, got %s", bloatCollectedAt, collectedAt)
+	}
+	if len(s.RelationStatistics) != 3 || !s.RelationStatistics[0].HasBloatEstimate || s.RelationStatistics[0].BloatBytes != 4096 || s.RelationStatistics[1].HasBloatEstimate || s.RelationStatistics[2].HasBloatEstimate {
+		t.Errorf("Unexpected relation statistics: %v", s.RelationStatistics)
+	}
+	if len(s.IndexStatistics) != 2 || !s.IndexStatistics[0].HasBloatEstimate || s.IndexStatistics[0].BloatBytes != 1024 || s.IndexStatistics[1].HasBloatEstimate {
+		t.Errorf("Unexpected index statistics: %v", s.IndexStatistics)
+	}
+}
+
+func TestRelationSizes(t *testing.T) {
+	newState := state.PersistedState{
+		Relations: []state.PostgresRelation{{Oid: 1, SchemaName: "public", RelationName: "items"}},
+	}
+	diffState := state.DiffState{
+		RelationStats: state.DiffedPostgresRelationStatsMap{1: {SizeBytes: 9000, MainSizeBytes: 2000, ToastSizeBytes: 7000, IndexSizeBytes: 3000}},
+	}
+
+	s := transform.StateToSnapshot(newState, diffState, state.TransientState{})
+
+	expected := &pganalyze_collector.RelationStatistic{SizeBytes: 9000, MainSizeBytes: 2000, ToastSizeBytes: 7000, IndexSizeBytes: 3000,
+		LastAutovacuum: &pganalyze_collector.NullTimestamp{}, LastAutoanalyze: &pganalyze_collector.NullTimestamp{}}
+	if len(s.RelationStatistics) != 1 || !proto.Equal(expected, s.RelationStatistics[0]) {
+		t.Errorf("Unexpected relation statistics: %v", s.RelationStatistics)
+	}
+}
+
+func TestRelationPartitions(t *testing.T) {
+	newState := state.PersistedState{
+		Relations: []state.PostgresRelation{
+			{Oid: 2, SchemaName: "public", RelationName: "events_2018_10", ParentRelationOid: 1},
+			{Oid: 1, SchemaName: "public", RelationName: "events"},
+			{Oid: 4, SchemaName: "public", RelationName: "items_2018_10", ParentRelationOid: 3},
+		},
+	}
+
+	s := transform.StateToSnapshot(newState, state.DiffState{}, state.TransientState{})
+
+	expected := []*pganalyze_collector.RelationInformation{
+		{RelationIdx: 0, Fillfactor: 100, IsPartition: true, ParentRelationIdx: 1, HasParentRelationIdx: true},
+		{RelationIdx: 1, Fillfactor: 100},
+		// The parent was not collected
+		{RelationIdx: 2, Fillfactor: 100, IsPartition: true},
+	}
+	if len(s.RelationInformations) != len(expected) {
+		t.Fatalf("Expected %d relation informations, got %v", len(expected), s.RelationInformations)
+	}
+	for idx := range expected {
+		if !proto.Equal(expected[idx], s.RelationInformations[idx]) {
+			t.Errorf("Unexpected relation information %d: %v", idx, s.RelationInformations[idx])
+		}
+	}
+}
+
+func TestRelationVacuumStats(t *testing.T) {
+	lastAutovacuum := time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+	newState := state.PersistedState{
+		Relations: []state.PostgresRelation{
+			{Oid: 1, SchemaName: "public", RelationName: "items"},
+			{Oid: 2, SchemaName: "public", RelationName: "events"},
+		},
+	}
+	diffState := state.DiffState{
+		RelationStats: state.DiffedPostgresRelationStatsMap{
+			1: {NLiveTup: 750, NDeadTup: 250, LastAutovacuum: null.TimeFrom(lastAutovacuum)},
+			2: {NLiveTup: 100, NDeadTup: 900, VacuumOverdue: true},
+		},
+	}
+
+	s := transform.StateToSnapshot(newState, diffState, state.TransientState{})
+
+	lastAutovacuumTs, _ := ptypes.TimestampProto(lastAutovacuum)
+	expected := []*pganalyze_collector.RelationStatistic{
+		{RelationIdx: 0, NLiveTup: 750, NDeadTup: 250, DeadTupleRatio: 0.25,
+			LastAutovacuum: &pganalyze_collector.NullTimestamp{Valid: true, Value: lastAutovacuumTs}, LastAutoanalyze: &pganalyze_collector.NullTimestamp{}},
+		{RelationIdx: 1, NLiveTup: 100, NDeadTup: 900, DeadTupleRatio: 0.9, VacuumOverdue: true,
+			LastAutovacuum: &pganalyze_collector.NullTimestamp{}, LastAutoanalyze: &pganalyze_collector.NullTimestamp{}},
+	}
+	if len(s.RelationStatistics) != len(expected) {
+		t.Fatalf("Expected %d relation statistics, got %v", len(expected), s.RelationStatistics)
+	}
+	for idx := range expected {
+		if !proto.Equal(expected[idx], s.RelationStatistics[idx]) {
+			t.Errorf("Unexpected relation statistic %d: %v", idx, s.RelationStatistics[idx])
+		}
+	}
+}
+
+func TestUnusedIndexes(t *testing.T) {
+	unusedSince := time.Date(2018, time.October, 1, 12, 0, 0, 0, time.UTC)
+	newState := state.PersistedState{
+		Relations: []state.PostgresRelation{
+			{Oid: 1, SchemaName: "public", RelationName: "items", Indices: []state.PostgresIndex{{IndexOid: 2, Name: "items_pkey"}, {IndexOid: 3, Name: "items_unused_idx"}}},
+		},
+		UnusedIndexes: state.PostgresIndexUnusedMap{3: {Since: unusedSince, RelationOid: 1}},
+	}
+	diffState := state.DiffState{
+		IndexStats: state.DiffedPostgresIndexStatsMap{2: {IdxScan: 5}, 3: {}},
+	}
+
+	s := transform.StateToSnapshot(newState, diffState, state.TransientState{})
+
+	if len(s.IndexStatistics) != 2 || s.IndexStatistics[0].UnusedSince != nil {
+		t.Fatalf("Unexpected index statistics: %v", s.IndexStatistics)
+	}
+	if since, _ := ptypes.Timestamp(s.IndexStatistics[1].UnusedSince); !since.Equal(unusedSince) {
+		t.Errorf("Expected index to be unused since %s, got %s", unusedSince, since)
+	}
+}
+
+func TestSystemMemoryDiff(t *testing.T) {
+	diffState := state.DiffState{SystemMemoryStats: state.DiffedMemoryStats{AvailableBytesDelta: -4096, SwapInBytes: 1000, LikelyMemoryPressure: true}}
+
+	memory := transform.StateToSnapshot(state.PersistedState{}, diffState, state.TransientState{}).System.MemoryStatistic
+
+	if memory.AvailableBytesDelta != -4096 || memory.SwapInBytes != 1000 || !memory.LikelyMemoryPressure {
+		t.Errorf("Unexpected memory statistic: %v", memory)
+	}
+}
+
+func TestCollectorStats(t *testing.T) {
+	diffState := state.DiffState{CollectorStats: state.DiffedCollectorStats{
+		LogLinesUnparseable: 3,
+		UploadedLogBytes:    1024,
+		LogProcessing: state.LogProcessingStats{
+			Upload: state.DurationStats{Count: 2, Total: 3 * time.Second, Min: time.Second, Max: 2 * time.Second},
+		},
+	}}
+
+	stats := transform.StateToSnapshot(state.PersistedState{}, diffState, state.TransientState{}).CollectorStatistic
+
+	if stats.LogLinesUnparseable != 3 || stats.UploadedLogBytes != 1024 {
+		t.Errorf("Unexpected collector statistic: %v", stats)
+	}
+	expectedUpload := &pganalyze_collector.DurationStatistic{Count: 2, TotalMs: 3000, MinMs: 1000, MaxMs: 2000}
+	if !proto.Equal(expectedUpload, stats.LogUploadDuration) {
+		t.Errorf("Unexpected log upload duration: %v", stats.LogUploadDuration)
+	}
+	if stats.LogStitchingDuration != nil {
+		t.Errorf("Expected no log stitching duration without recorded timings, got %v", stats.LogStitchingDuration)
+	}
+}
+
+func TestIOStats(t *testing.T) {
+	diffState := state.DiffState{IOStats: state.DiffedPostgresIOStatsMap{
+		"client backend": {ReadsPerSecond: 2, HitsPerSecond: 10, ReadTime: 6},
+		"checkpointer":   {WritesPerSecond: 0.5, WriteTime: 2},
+	}}
+
+	stats := transform.StateToSnapshot(state.PersistedState{}, diffState, state.TransientState{}).IoStatistics
+
+	expected := []*pganalyze_collector.IOStatistic{
+		{BackendType: "checkpointer", WritesPerSecond: 0.5, WriteTime: 2},
+		{BackendType: "client backend", ReadsPerSecond: 2, HitsPerSecond: 10, ReadTime: 6},
+	}
+	if len(stats) != len(expected) {
+		t.Fatalf("Expected %d I/O statistics, got %d: %v", len(expected), len(stats), stats)
+	}
+	for idx := range expected {
+		if !proto.Equal(expected[idx], stats[idx]) {
+			t.Errorf("Unexpected I/O statistic %d: %v", idx, stats[idx])
+		}
+	}
+}
+
+func TestBgwriterStats(t *testing.T) {
+	s := transform.StateToSnapshot(state.PersistedState{}, state.DiffState{}, state.TransientState{})
+	if s.BgwriterStatistic != nil {
+		t.Errorf("Expected no background writer statistic on the first run, got %v", s.BgwriterStatistic)
+	}
+
+	diffState := state.DiffState{BgwriterStats: &state.DiffedPostgresBgwriterStats{CheckpointsTimed: 1, CheckpointWriteTime: 500, BuffersCheckpointPerSecond: 10, BuffersAllocPerSecond: 5}}
+	s = transform.StateToSnapshot(state.PersistedState{}, diffState, state.TransientState{})
+
+	expected := &pganalyze_collector.BgwriterStatistic{CheckpointsTimed: 1, CheckpointWriteTime: 500, BuffersCheckpointPerSecond: 10, BuffersAllocPerSecond: 5}
+	if !proto.Equal(expected, s.BgwriterStatistic) {
+		t.Errorf("Unexpected background writer statistic: %v", s.BgwriterStatistic)
+	}
+}
+
+func TestBackendCountChanges(t *testing.T) {
+	s := transform.StateToSnapshot(state.PersistedState{}, state.DiffState{}, state.TransientState{})
+	if len(s.BackendCountChanges) != 0 {
+		t.Errorf("Expected no backend count changes on the first run, got %v", s.BackendCountChanges)
+	}
+
+	diffState := state.DiffState{BackendCounts: state.DiffedPostgresBackendCounts{"idle in transaction": 12, "active": -2, "idle": 0}}
+	s = transform.StateToSnapshot(state.PersistedState{}, diffState, state.TransientState{})
+
+	expected := &pganalyze_collector.FullSnapshot{BackendCountChanges: []*pganalyze_collector.BackendCountChange{
+		{State: pganalyze_collector.BackendCountStatistic_ACTIVE, Change: -2},
+		{State: pganalyze_collector.BackendCountStatistic_IDLE, Change: 0},
+		{State: pganalyze_collector.BackendCountStatistic_IDLE_IN_TRANSACTION, Change: 12},
+	}}
+	if !proto.Equal(expected, &pganalyze_collector.FullSnapshot{BackendCountChanges: s.BackendCountChanges}) {
+		t.Errorf("Unexpected backend count changes: %v", s.BackendCountChanges)
+	}
+}
+
+func TestStatementStatsReset(t *testing.T) {
+	s := transform.StateToSnapshot(state.PersistedState{}, state.DiffState{StatementStatsReset: true}, state.TransientState{})
+	if !s.QueryStatisticsReset {
+		t.Errorf("Expected the pg_stat_statements reset to be sent")
+	}
+
+	s = transform.StateToSnapshot(state.PersistedState{}, state.DiffState{}, state.TransientState{})
+	if s.QueryStatisticsReset {
+		t.Errorf("Expected no pg_stat_statements reset to be sent")
+	}
+}
+
+func TestWaitEvents(t *testing.T) {
+	transientState := state.TransientState{WaitEvents: state.PostgresWaitEventSummary{
+		SampleCount: 3,
+		Counts: state.PostgresWaitEventCounts{
+			{WaitEventType: "LWLock", WaitEvent: "WALWrite"}:    2,
+			{WaitEventType: "Lock", WaitEvent: "transactionid"}: 5,
+			{WaitEventType: "IO", WaitEvent: "DataFileRead"}:    7,
+		},
+	}}
+
+	s := transform.StateToSnapshot(state.PersistedState{}, state.DiffState{}, transientState)
+
+	expected := []*pganalyze_collector.WaitEventStatistic{
+		{WaitEventType: "IO", WaitEvent: "DataFileRead", Count: 7},
+		{WaitEventType: "LWLock", WaitEvent: "WALWrite", Count: 2},
+		{WaitEventType: "Lock", WaitEvent: "transactionid", Count: 5},
+	}
+	if s.WaitEventSampleCount != 3 || len(s.WaitEventStatistics) != len(expected) {
+		t.Fatalf("Expected %d wait events from 3 samples, got %d from %d samples", len(expected), len(s.WaitEventStatistics), s.WaitEventSampleCount)
+	}
+	for idx := range expected {
+		if !proto.Equal(expected[idx], s.WaitEventStatistics[idx]) {
+			t.Errorf("Unexpected wait event statistic %d: %v", idx, s.WaitEventStatistics[idx])
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	startedAt := time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+	newState := state.PersistedState{
+		Relations: []state.PostgresRelation{
+			{Oid: 100, DatabaseOid: 1, SchemaName: "public", RelationName: "items", Indices: []state.PostgresIndex{{IndexOid: 101, RelationOid: 100, Name: "items_pkey"}}},
+		},
+	}
+	transientState := state.TransientState{
+		Roles:     []state.PostgresRole{{Oid: 10, Name: "postgres"}},
+		Databases: []state.PostgresDatabase{{Oid: 1, Name: "app"}},
+		Progress: state.PostgresProgress{
+			Vacuums: []state.PostgresVacuumProgress{
+				{VacuumIdentity: 1, DatabaseName: "app", SchemaName: "public", RelationName: "items", RoleName: "postgres", StartedAt: startedAt, Phase: "scanning heap", HeapBlksTotal: 100, HeapBlksScanned: 10},
+			},
+			CreateIndexes: []state.PostgresCreateIndexProgress{
+				{Pid: 42, DatabaseOid: 1, RelationOid: 100, Command: "CREATE INDEX", Phase: "building index", BlocksTotal: 100, BlocksDone: 50},
+				{Pid: 43, DatabaseOid: 1, RelationOid: 100, IndexOid: 101, Command: "REINDEX", Phase: "waiting for old snapshots"},
+			},
+			BaseBackups: []state.PostgresBaseBackupProgress{
+				{Pid: 44, Phase: "streaming database files", BackupTotal: null.IntFrom(2048), BackupStreamed: 1024, TablespacesTotal: 1},
+				{Pid: 45, Phase: "initializing"},
+			},
+		},
+	}
+
+	s := transform.StateToSnapshot(newState, state.DiffState{}, transientState)
+
+	startedAtTs, _ := ptypes.TimestampProto(startedAt)
+	expectedVacuums := []*pganalyze_collector.VacuumProgress{
+		{VacuumIdentity: 1, DatabaseIdx: 0, HasDatabaseIdx: true, RelationIdx: 0, HasRelationIdx: true, RoleIdx: 0, HasRoleIdx: true, SchemaName: "public", RelationName: "items", StartedAt: startedAtTs, Phase: "scanning heap", HeapBlksTotal: 100, HeapBlksScanned: 10},
+	}
+	expectedCreateIndexes := []*pganalyze_collector.CreateIndexProgress{
+		{Pid: 42, HasDatabaseIdx: true, HasRelationIdx: true, Command: "CREATE INDEX", Phase: "building index", BlocksTotal: 100, BlocksDone: 50},
+		{Pid: 43, HasDatabaseIdx: true, HasRelationIdx: true, HasIndexIdx: true, Command: "REINDEX", Phase: "waiting for old snapshots"},
+	}
+	expectedBaseBackups := []*pganalyze_collector.BaseBackupProgress{
+		{Pid: 44, Phase: "streaming database files", BackupTotal: 2048, HasBackupTotal: true, BackupStreamed: 1024, TablespacesTotal: 1},
+		{Pid: 45, Phase: "initializing"},
+	}
+	if len(s.VacuumProgress) != len(expectedVacuums) || len(s.CreateIndexProgress) != len(expectedCreateIndexes) || len(s.BaseBackupProgress) != len(expectedBaseBackups) {
+		t.Fatalf("Unexpected progress: %v %v %v", s.VacuumProgress, s.CreateIndexProgress, s.BaseBackupProgress)
+	}
+	for idx := range expectedVacuums {
+		if !proto.Equal(expectedVacuums[idx], s.VacuumProgress[idx]) {
+			t.Errorf("Unexpected vacuum progress %d: %v", idx, s.VacuumProgress[idx])
+		}
+	}
+	for idx := range expectedCreateIndexes {
+		if !proto.Equal(expectedCreateIndexes[idx], s.CreateIndexProgress[idx]) {
+			t.Errorf("Unexpected create index progress %d: %v", idx, s.CreateIndexProgress[idx])
+		}
+	}
+	for idx := range expectedBaseBackups {
+		if !proto.Equal(expectedBaseBackups[idx], s.BaseBackupProgress[idx]) {
+			t.Errorf("Unexpected base backup progress %d: %v", idx, s.BaseBackupProgress[idx])
+		}
+	}
+}
+
+func TestSettingsPendingRestart(t *testing.T) {
+	transientState := state.TransientState{
+		Settings: []state.PostgresSetting{
+			{Name: "max_connections", CurrentValue: null.StringFrom("100"), PendingRestart: true},
+			{Name: "shared_buffers", CurrentValue: null.StringFrom("16384"), PendingRestart: true},
+			{Name: "work_mem", CurrentValue: null.StringFrom("4096")},
+		},
+		NewlyPendingRestartSettings: []string{"shared_buffers"},
+	}
+
+	s := transform.StateToSnapshot(state.PersistedState{}, state.DiffState{}, transientState)
+
+	expected := []*pganalyze_collector.Setting{
+		{Name: "max_connections", CurrentValue: "100", PendingRestart: true},
+		{Name: "shared_buffers", CurrentValue: "16384", PendingRestart: true, NewlyPendingRestart: true},
+		{Name: "work_mem", CurrentValue: "4096"},
+	}
+	if len(s.Settings) != len(expected) {
+		t.Fatalf("Expected %d settings, got %v", len(expected), s.Settings)
+	}
+	for idx := range expected {
+		if !proto.Equal(expected[idx], s.Settings[idx]) {
+			t.Errorf("Unexpected setting %d: %v", idx, s.Settings[idx])
+		}
+	}
+}
+
+func TestSettingsChangedOnly(t *testing.T) {
+	transientState := state.TransientState{
+		Settings:            []state.PostgresSetting{{Name: "work_mem", CurrentValue: null.StringFrom("8192")}},
+		SettingsChangedOnly: true,
+	}
+
+	s := transform.StateToSnapshot(state.PersistedState{}, state.DiffState{}, transientState)
+
+	if !s.SettingsChangedOnly || len(s.Settings) != 1 {
+		t.Errorf("Expected only the changed setting to be sent, got %v (changed only: %t)", s.Settings, s.SettingsChangedOnly)
+	}
+}
+
+func TestLocks(t *testing.T) {
+	newState := state.PersistedState{
+		Relations: []state.PostgresRelation{{Oid: 100, DatabaseOid: 2, SchemaName: "public", RelationName: "users"}},
+	}
+	transientState := state.TransientState{
+		Databases: []state.PostgresDatabase{{Oid: 1, Name: "postgres"}, {Oid: 2, Name: "app"}},
+		Locks: state.PostgresLocks{
+			Waits: []state.PostgresLockWait{
+				{Pid: 200, LockType: "relation", Mode: "AccessExclusiveLock", DatabaseOid: 2, RelationOid: 100, BlockingPids: []int32{100}},
+				{Pid: 300, LockType: "relation", Mode: "AccessShareLock", DatabaseOid: 1, RelationOid: 100, BlockingPids: []int32{200}},
+				{Pid: 400, LockType: "transactionid", Mode: "ShareLock", BlockingPids: []int32{100}},
+			},
+			BlockingTrees: []state.PostgresBlockingNode{{
+				Pid: 100, State: null.StringFrom("idle in transaction"), Query: null.StringFrom("SELECT * FROM users"),
+				Blocked: []state.PostgresBlockingNode{
+					{Pid: 200, State: null.StringFrom("active"), Query: null.StringFrom("ALTER TABLE users ADD COLUMN age int"), WaitingForMode: "AccessExclusiveLock", WaitingForRelation: 100,
+						Blocked: []state.PostgresBlockingNode{{Pid: 300, WaitingForMode: "AccessShareLock", WaitingForRelation: 100}}},
+					{Pid: 400, WaitingForMode: "ShareLock"},
+				},
+			}},
+		},
+	}
+
+	s := transform.StateToSnapshot(newState, state.DiffState{}, transientState)
+
+	expectedWaits := []*pganalyze_collector.LockWait{
+		{Pid: 200, LockType: "relation", Mode: "AccessExclusiveLock", DatabaseIdx: 1, HasDatabaseIdx: true, RelationIdx: 0, HasRelationIdx: true, BlockingPids: []int32{100}},
+		{Pid: 300, LockType: "relation", Mode: "AccessShareLock", DatabaseIdx: 0, HasDatabaseIdx: true, BlockingPids: []int32{200}},
+		{Pid: 400, LockType: "transactionid", Mode: "ShareLock", BlockingPids: []int32{100}},
+	}
+	if len(s.LockWaits) != len(expectedWaits) {
+		t.Fatalf("Expected %d lock waits, got %v", len(expectedWaits), s.LockWaits)
+	}
+	for idx := range expectedWaits {
+		if !proto.Equal(expectedWaits[idx], s.LockWaits[idx]) {
+			t.Errorf("Unexpected lock wait %d: %v", idx, s.LockWaits[idx])
+		}
+	}
+
+	expectedTrees := []*pganalyze_collector.BlockingBackend{{
+		Pid: 100, State: "idle in transaction", QueryText: "SELECT * FROM users",
+		Blocked: []*pganalyze_collector.BlockingBackend{
+			{Pid: 200, State: "active", QueryText: "ALTER TABLE users ADD COLUMN age int", LockWaitIdx: 0, HasLockWaitIdx: true,
+				Blocked: []*pganalyze_collector.BlockingBackend{{Pid: 300, LockWaitIdx: 1, HasLockWaitIdx: true}}},
+			{Pid: 400, LockWaitIdx: 2, HasLockWaitIdx: true},
+		},
+	}}
+	if len(s.BlockingTrees) != len(expectedTrees) || !proto.Equal(expectedTrees[0], s.BlockingTrees[0]) {
+		t.Errorf("Unexpected blocking trees: %v", s.BlockingTrees)
+	}
+}
+
+func TestWraparound(t *testing.T) {
+	newState := state.PersistedState{
+		Relations: []state.PostgresRelation{{Oid: 100, DatabaseOid: 2, SchemaName: "public", RelationName: "events"}},
+	}
+	transientState := state.TransientState{
+		Databases: []state.PostgresDatabase{{Oid: 1, Name: "postgres"}, {Oid: 2, Name: "app"}},
+		Wraparound: state.AssessWraparound(state.PostgresWraparound{
+			FreezeMaxAge: 200000000,
+			Databases: []state.PostgresWraparoundDatabase{
+				{DatabaseOid: 1, DatabaseName: "postgres", XIDAge: 1000000},
+				{DatabaseOid: 2, DatabaseName: "app", XIDAge: 1650000000},
+			},
+			Tables: []state.PostgresWraparoundTable{
+				{RelationOid: 200, SchemaName: "pg_toast", RelationName: "pg_toast_100", XIDAge: 160000000},
+				{RelationOid: 100, SchemaName: "public", RelationName: "events", XIDAge: 1650000000},
+			},
+		}),
+	}
+
+	s := transform.StateToSnapshot(newState, state.DiffState{}, transientState)
+
+	expected := pganalyze_collector.Wraparound{
+		FreezeMaxAge: 200000000,
+		Databases: []*pganalyze_collector.DatabaseWraparound{
+			{DatabaseIdx: 1, HasDatabaseIdx: true, XidAge: 1650000000, FreezeMaxAgePercent: 825, Risk: pganalyze_collector.WraparoundRisk_WRAPAROUND_RISK_CRITICAL},
+			{DatabaseIdx: 0, HasDatabaseIdx: true, XidAge: 1000000, FreezeMaxAgePercent: 0.5, Risk: pganalyze_collector.WraparoundRisk_WRAPAROUND_RISK_LOW},
+		},
+		Tables: []*pganalyze_collector.TableWraparound{
+			{SchemaName: "public", RelationName: "events", RelationIdx: 0, HasRelationIdx: true, XidAge: 1650000000, FreezeMaxAgePercent: 825, Risk: pganalyze_collector.WraparoundRisk_WRAPAROUND_RISK_CRITICAL},
+			{SchemaName: "pg_toast", RelationName: "pg_toast_100", XidAge: 160000000, FreezeMaxAgePercent: 80, Risk: pganalyze_collector.WraparoundRisk_WRAPAROUND_RISK_ELEVATED},
+		},
+	}
+	if !proto.Equal(&expected, s.Wraparound) {
+		t.Errorf("Expected wraparound %v, got %v", expected, s.Wraparound)
+	}
+
+	s = transform.StateToSnapshot(newState, state.DiffState{}, state.TransientState{})
+	if s.Wraparound != nil {
+		t.Errorf("Expected no wraparound information when none was collected, got %v", s.Wraparound)
+	}
+}
+
+func TestCustomMetrics(t *testing.T) {
+	transientState := state.TransientState{CustomMetrics: []state.PostgresCustomMetric{
+		{Name: "job_queue_length", Value: 42},
+		{Name: "pending_invoices", Value: 1.5},
+	}}
+
+	s := transform.StateToSnapshot(state.PersistedState{}, state.DiffState{}, transientState)
+
+	expected := []*pganalyze_collector.CustomMetric{
+		{Name: "job_queue_length", Value: 42},
+		{Name: "pending_invoices", Value: 1.5},
+	}
+	if len(s.CustomMetrics) != len(expected) {
+		t.Fatalf("Expected %d custom metrics, got %v", len(expected), s.CustomMetrics)
+	}
+	for idx := range expected {
+		if !proto.Equal(expected[idx], s.CustomMetrics[idx]) {
+			t.Errorf("Unexpected custom metric %d: %v", idx, s.CustomMetrics[idx])
+		}
+	}
+}
+
+func TestFunctionChanges(t *testing.T) {
+	newState := state.PersistedState{Functions: []state.PostgresFunction{{Oid: 1, SchemaName: "public", FunctionName: "f", DefinitionHash: "abc"}}}
+	diffState := state.DiffState{FunctionDefinitions: state.DiffedPostgresFunctionDefinitions{
+		Removed:  []state.PostgresFunctionKey{{SchemaName: "public", FunctionName: "g", Arguments: "integer"}},
+		Modified: []state.PostgresFunctionKey{{SchemaName: "public", FunctionName: "f"}},
+	}}
+
+	s := transform.StateToSnapshot(newState, diffState, state.TransientState{})
+
+	expected := []*pganalyze_collector.FunctionChange{
+		{SchemaName: "public", FunctionName: "g", Arguments: "integer", ChangeType: pganalyze_collector.FunctionChange_REMOVED},
+		{SchemaName: "public", FunctionName: "f", ChangeType: pganalyze_collector.FunctionChange_MODIFIED},
+	}
+	if len(s.FunctionChanges) != len(expected) {
+		t.Fatalf("Expected %d function changes, got %d: %v", len(expected), len(s.FunctionChanges), s.FunctionChanges)
+	}
+	for idx := range expected {
+		if !proto.Equal(expected[idx], s.FunctionChanges[idx]) {
+			t.Errorf("Unexpected function change %d: %v", idx, s.FunctionChanges[idx])
+		}
+	}
+	if s.FunctionInformations[0].DefinitionHash != "abc" {
+		t.Errorf("Expected the definition hash to be sent, got %q", s.FunctionInformations[0].DefinitionHash)
+	}
+}
+
+func TestSystemCarriedOver(t *testing.T) {
+	collectedAt := time.Date(2018, time.October, 16, 12, 10, 0, 0, time.UTC)
+	newState := state.PersistedState{
+		CollectedAt:         collectedAt,
+		CategoryCollectedAt: map[state.CollectionCategory]time.Time{state.CollectionCategorySystem: collectedAt.Add(-10 * time.Minute)},
+	}
+
+	if s := transform.StateToSnapshot(newState, state.DiffState{}, state.TransientState{}); s.System != nil {
+		t.Errorf("Expected carried over system information to be left out, got %v", s.System)
+	}
+
+	newState.CategoryCollectedAt[state.CollectionCategorySystem] = collectedAt
+	if s := transform.StateToSnapshot(newState, state.DiffState{}, state.TransientState{}); s.System == nil {
+		t.Errorf("Expected system information collected this run to be sent")
+	}
+}
@@ -0,0 +1,92 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// OutputTransport - Stores a file produced by the collector (a snapshot, or a
+// log file), and returns the location that gets submitted to reference it
+//
+// The metadata is kept together with the file, e.g. the encryption details of
+// log files that are needed to decrypt them.
+type OutputTransport interface {
+	Upload(ctx context.Context, logger *util.Logger, data []byte, filename string, metadata map[string]string) (string, error)
+}
+
+// S3Transport - Uploads files with a S3 POST policy - this works for any HTTP
+// endpoint that accepts the same multipart form
+type S3Transport struct {
+	URL    string
+	Fields map[string]string
+}
+
+// Upload - Sends the file to S3, with the metadata passed as additional form fields
+func (t S3Transport) Upload(ctx context.Context, logger *util.Logger, data []byte, filename string, metadata map[string]string) (string, error) {
+	formFields := make(map[string]string)
+	for k, v := range t.Fields {
+		formFields[k] = v
+	}
+	for k, v := range metadata {
+		formFields[k] = v
+	}
+
+	return uploadToS3(ctx, t.URL, formFields, logger, data, filename)
+}
+
+// LocalDirTransport - Writes files to a local directory, for setups that can't
+// reach S3 (e.g. air-gapped deployments)
+//
+// Any metadata is written next to the file, as JSON in "<filename>.metadata.json".
+type LocalDirTransport struct {
+	Dir string
+}
+
+// Upload - Writes the file to the directory, creating it if necessary
+func (t LocalDirTransport) Upload(ctx context.Context, logger *util.Logger, data []byte, filename string, metadata map[string]string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	location := filepath.Join(t.Dir, filename)
+	err := os.MkdirAll(filepath.Dir(location), 0755)
+	if err != nil {
+		logger.PrintError("Error creating target directory: %s", err)
+		return "", err
+	}
+
+	err = ioutil.WriteFile(location, data, 0644)
+	if err != nil {
+		logger.PrintError("Error writing local file: %s", err)
+		return "", err
+	}
+
+	if len(metadata) > 0 {
+		metadataJSON, err := json.Marshal(metadata)
+		if err != nil {
+			return "", err
+		}
+		err = ioutil.WriteFile(location+".metadata.json", metadataJSON, 0644)
+		if err != nil {
+			logger.PrintError("Error writing local file: %s", err)
+			return "", err
+		}
+	}
+
+	return location, nil
+}
+
+// transportForGrant - Writes to the grant's local directory when there is no
+// S3 URL, and uploads to S3 otherwise
+func transportForGrant(s3 state.GrantS3) OutputTransport {
+	if s3.S3URL == "" && s3.LocalDir != "" {
+		return LocalDirTransport{Dir: s3.LocalDir}
+	}
+	return S3Transport{URL: s3.S3URL, Fields: s3.S3Fields}
+}
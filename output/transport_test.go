@@ -0,0 +1,227 @@
+package output
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/config"
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+	uuid "github.com/satori/go.uuid"
+)
+
+type storedArtifact struct {
+	Data     string
+	Metadata map[string]string
+}
+
+// fakeS3 - Accepts S3 POST policy uploads, and keeps the uploaded files by key
+func fakeS3(t *testing.T, artifacts map[string]storedArtifact) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseMultipartForm(1024 * 1024)
+		if err != nil {
+			t.Errorf("Could not parse upload: %s", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("Upload is missing file: %s", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := ioutil.ReadAll(file)
+
+		metadata := make(map[string]string)
+		for key, values := range r.MultipartForm.Value {
+			if key != "policy" {
+				metadata[key] = values[0]
+			}
+		}
+		if r.FormValue("policy") != "test-policy" {
+			t.Errorf("Expected grant fields to be sent, got policy %q", r.FormValue("policy"))
+		}
+
+		key := header.Filename
+		artifacts[key] = storedArtifact{Data: string(data), Metadata: metadata}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "<PostResponse><Key>%s</Key></PostResponse>", key)
+	}))
+}
+
+func readLocalArtifact(t *testing.T, location string) storedArtifact {
+	data, err := ioutil.ReadFile(location)
+	if err != nil {
+		t.Fatalf("Could not read uploaded file: %s", err)
+	}
+	artifact := storedArtifact{Data: string(data), Metadata: make(map[string]string)}
+	metadataJSON, err := ioutil.ReadFile(location + ".metadata.json")
+	if err == nil {
+		err = json.Unmarshal(metadataJSON, &artifact.Metadata)
+		if err != nil {
+			t.Fatalf("Could not decode metadata: %s", err)
+		}
+	}
+	return artifact
+}
+
+func TestOutputTransports(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	s3Artifacts := make(map[string]storedArtifact)
+	server := fakeS3(t, s3Artifacts)
+	defer server.Close()
+
+	localDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	s3Transport := transportForGrant(state.GrantS3{S3URL: server.URL, S3Fields: map[string]string{"policy": "test-policy"}})
+	if _, ok := s3Transport.(S3Transport); !ok {
+		t.Errorf("Expected S3 transport for grant with S3 URL, got %T", s3Transport)
+	}
+	localTransport := transportForGrant(state.GrantS3{LocalDir: localDir})
+	if _, ok := localTransport.(LocalDirTransport); !ok {
+		t.Errorf("Expected local directory transport for grant without S3 URL, got %T", localTransport)
+	}
+
+	uploads := []struct {
+		filename string
+		data     string
+		metadata map[string]string
+	}{
+		{"snapshot", "snapshot data", nil},
+		{"logfile", "encrypted log data", map[string]string{"x-amz-meta-x-amz-iv": "iv", "x-amz-meta-x-amz-cek-alg": "AES/GCM/NoPadding"}},
+	}
+
+	for _, upload := range uploads {
+		s3Location, err := s3Transport.Upload(context.Background(), logger, []byte(upload.data), upload.filename, upload.metadata)
+		if err != nil {
+			t.Fatalf("S3 upload of %s failed: %s", upload.filename, err)
+		}
+		localLocation, err := localTransport.Upload(context.Background(), logger, []byte(upload.data), upload.filename, upload.metadata)
+		if err != nil {
+			t.Fatalf("Local upload of %s failed: %s", upload.filename, err)
+		}
+		if localLocation != filepath.Join(localDir, upload.filename) {
+			t.Errorf("Expected local upload of %s to be written to %s, got %s", upload.filename, filepath.Join(localDir, upload.filename), localLocation)
+		}
+
+		if diff := pretty.Compare(s3Artifacts[s3Location], readLocalArtifact(t, localLocation)); diff != "" {
+			t.Errorf("Artifacts of %s differ: (-s3 +local)\n%s", upload.filename, diff)
+		}
+	}
+}
+
+func readLocalSnapshot(t *testing.T, location string, s proto.Message) {
+	r, err := zlib.NewReader(strings.NewReader(readLocalArtifact(t, location).Data))
+	if err != nil {
+		t.Fatalf("Could not decompress snapshot: %s", err)
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Could not decompress snapshot: %s", err)
+	}
+	if err = proto.Unmarshal(data, s); err != nil {
+		t.Fatalf("Could not decode snapshot: %s", err)
+	}
+}
+
+func TestSnapshotTags(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	localDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	var s3Location string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s3Location = r.FormValue("s3_location")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	server := state.Server{
+		Config: config.ServerConfig{APIBaseURL: api.URL, Tags: map[string]string{"team": "payments", "env": "production"}},
+		Grant:  state.Grant{Valid: true, LocalDir: localDir},
+	}
+	expected := []*snapshot.Tag{{Key: "env", Value: "production"}, {Key: "team", Value: "payments"}}
+
+	err = SubmitFull(snapshot.FullSnapshot{}, server, state.CollectionOpts{SubmitCollectedData: true}, logger, time.Now())
+	if err != nil {
+		t.Fatalf("Submitting snapshot failed: %s", err)
+	}
+	var full snapshot.FullSnapshot
+	readLocalSnapshot(t, s3Location, &full)
+	if diff := pretty.Compare(expected, full.Tags); diff != "" {
+		t.Errorf("Unexpected full snapshot tags: (-want +got)\n%s", diff)
+	}
+	if metadata := readLocalArtifact(t, s3Location).Metadata; len(metadata) != 0 {
+		t.Errorf("Expected no upload metadata, got %v", metadata)
+	}
+
+	err = uploadAndSubmitCompactSnapshot(context.Background(), snapshot.CompactSnapshot{}, state.GrantS3{LocalDir: localDir}, api.URL, server, state.CollectionOpts{SubmitCollectedData: true}, logger, time.Now(), true, "logs")
+	if err != nil {
+		t.Fatalf("Submitting compact snapshot failed: %s", err)
+	}
+	var compact snapshot.CompactSnapshot
+	readLocalSnapshot(t, s3Location, &compact)
+	if diff := pretty.Compare(expected, compact.Tags); diff != "" {
+		t.Errorf("Unexpected compact snapshot tags: (-want +got)\n%s", diff)
+	}
+}
+
+func TestLogUploadTagMetadata(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	localDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	tmpFile, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatalf("Could not create tempfile: %s", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	tmpFile.WriteString("2018-10-16 10:47:11 UTC [24217]: [1-1] LOG:  checkpoint starting: time\n")
+
+	tags := map[string]string{"env": "production", "Team": "payments"}
+	expected := map[string]string{"x-amz-meta-tag-env": "production", "x-amz-meta-tag-team": "payments"}
+
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	encryptionKey := state.GrantLogsEncryptionKey{Plaintext: key, CiphertextBlob: key}
+	logFiles := []state.LogFile{{UUID: uuid.NewV4(), TmpFile: tmpFile}}
+	logFiles = EncryptAndUploadLogfiles(context.Background(), state.GrantS3{LocalDir: localDir}, encryptionKey, false, tags, logger, logFiles)
+
+	metadata := readLocalArtifact(t, logFiles[0].S3Location).Metadata
+	for key, value := range expected {
+		if metadata[key] != value {
+			t.Errorf("Expected log file metadata %s to be %q, got %q", key, value, metadata[key])
+		}
+	}
+	if metadata["x-amz-meta-x-amz-key-v2"] == "" {
+		t.Errorf("Expected log file metadata to include the encryption envelope, got %v", metadata)
+	}
+}
@@ -2,13 +2,13 @@ package output
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
-	"os"
-	"path/filepath"
+	"strings"
 
 	"github.com/pganalyze/collector/state"
 	"github.com/pganalyze/collector/util"
@@ -20,45 +20,59 @@ type s3UploadResponse struct {
 	Key      string
 }
 
-func uploadCompactSnapshot(s3 state.GrantS3, logger *util.Logger, data bytes.Buffer, filename string) (string, error) {
-	if s3.S3URL == "" {
+// submitURL - Returns the URL for submitting data that was uploaded with a grant
+// from the given API endpoint - this is never another endpoint, since the
+// uploaded data is only accessible to the one that handed out the grant
+func submitURL(server state.Server, grantAPIBaseURL string, path string) string {
+	if grantAPIBaseURL == "" {
+		grantAPIBaseURL = server.Config.APIBaseURL
+	}
+	return grantAPIBaseURL + path
+}
+
+// tagMetadata - Returns the configured server tags as S3 object metadata fields
+func tagMetadata(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	metadata := make(map[string]string)
+	for key, value := range tags {
+		// S3 stores metadata keys in lower case, so this is done upfront to
+		// keep local directory uploads consistent
+		metadata["x-amz-meta-tag-"+strings.ToLower(key)] = value
+	}
+	return metadata
+}
+
+func uploadCompactSnapshot(ctx context.Context, s3 state.GrantS3, logger *util.Logger, data bytes.Buffer, filename string) (string, error) {
+	if s3.S3URL == "" && s3.LocalDir == "" {
 		return "", fmt.Errorf("Error - can't upload without valid S3 URL")
 	}
 
 	logger.PrintVerbose("Successfully prepared S3 request - size of request body: %.4f MB", float64(data.Len())/1024.0/1024.0)
 
-	return uploadToS3(s3.S3URL, s3.S3Fields, logger, data.Bytes(), filename)
+	location, err := transportForGrant(s3).Upload(ctx, logger, data.Bytes(), filename, nil)
+	if err == nil {
+		state.RecordUploadedSnapshotBytes(data.Len())
+	}
+	return location, err
 }
 
 func uploadSnapshot(grant state.Grant, logger *util.Logger, data bytes.Buffer, filename string) (string, error) {
-	var err error
-
 	if !grant.Valid {
 		return "", fmt.Errorf("Error - can't upload without valid S3 grant")
 	}
 
-	if grant.S3URL == "" && grant.LocalDir != "" {
-		location := grant.LocalDir + filename
-		err = os.MkdirAll(filepath.Dir(location), 0755)
-		if err != nil {
-			logger.PrintError("Error creating target directory: %s", err)
-			return "", err
-		}
-
-		err = ioutil.WriteFile(location, data.Bytes(), 0644)
-		if err != nil {
-			logger.PrintError("Error writing local file: %s", err)
-			return "", err
-		}
-		return location, nil
-	}
-
 	logger.PrintVerbose("Successfully prepared S3 request - size of request body: %.4f MB", float64(data.Len())/1024.0/1024.0)
 
-	return uploadToS3(grant.S3URL, grant.S3Fields, logger, data.Bytes(), filename)
+	location, err := transportForGrant(grant.S3()).Upload(context.Background(), logger, data.Bytes(), filename, nil)
+	if err == nil {
+		state.RecordUploadedSnapshotBytes(data.Len())
+	}
+	return location, err
 }
 
-func uploadToS3(S3URL string, S3Fields map[string]string, logger *util.Logger, data []byte, filename string) (string, error) {
+func uploadToS3(ctx context.Context, S3URL string, S3Fields map[string]string, logger *util.Logger, data []byte, filename string) (string, error) {
 	var err error
 	var formBytes bytes.Buffer
 
@@ -83,6 +97,7 @@ func uploadToS3(S3URL string, S3Fields map[string]string, logger *util.Logger, d
 	if err != nil {
 		return "", err
 	}
+	req = req.WithContext(ctx)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
 	resp, err := http.DefaultClient.Do(req)
@@ -97,7 +112,7 @@ func uploadToS3(S3URL string, S3Fields map[string]string, logger *util.Logger, d
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("Bad S3 upload return code %s (should be 201 Created), body: %s", resp.Status, body)
+		return "", util.S3UploadError{Status: resp.Status, StatusCode: resp.StatusCode, Body: body}
 	}
 
 	var s3Resp s3UploadResponse
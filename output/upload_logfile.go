@@ -2,11 +2,14 @@ package output
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"regexp"
 
 	"github.com/aws/aws-sdk-go/service/s3/s3crypto"
 	"github.com/pganalyze/collector/state"
@@ -45,20 +48,73 @@ func (kh *keyHandler) GenerateCipherData(keySize, ivSize int) (s3crypto.CipherDa
 	return cd, nil
 }
 
-func EncryptAndUploadLogfiles(s3 state.GrantS3, encryptionKey state.GrantLogsEncryptionKey, logger *util.Logger, logFiles []state.LogFile) []state.LogFile {
-	if len(logFiles) == 0 {
-		return logFiles
+// compressLogContent - Compresses log file contents using gzip, before they get encrypted
+func compressLogContent(content []byte) ([]byte, error) {
+	var compressedContent bytes.Buffer
+
+	w := gzip.NewWriter(&compressedContent)
+	_, err := w.Write(content)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return compressedContent.Bytes(), nil
+}
+
+var kmsKeyIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+var kmsKeyARNRegexp = regexp.MustCompile(`^arn:aws[\w-]*:kms:[\w-]+:\d{12}:(key/[0-9a-fA-F-]{36}|alias/[\w/_-]+)$`)
+var kmsAliasRegexp = regexp.MustCompile(`^alias/[\w/_-]+$`)
+
+// validateKMSKeyID - Checks that a KMS key is specified in one of the formats
+// accepted by S3 for server-side encryption
+func validateKMSKeyID(keyID string) error {
+	if kmsKeyIDRegexp.MatchString(keyID) || kmsKeyARNRegexp.MatchString(keyID) || kmsAliasRegexp.MatchString(keyID) {
+		return nil
+	}
+	return fmt.Errorf("\"%s\" is not a KMS key ID, key ARN, alias name or alias ARN", keyID)
+}
+
+// grantUsesKMSKey - Whether the grant's S3 upload policy uses server-side
+// encryption with the given KMS key (the fields are signed by the API, so they
+// can't be added by the collector)
+func grantUsesKMSKey(s3 state.GrantS3, keyID string) bool {
+	return s3.S3Fields["x-amz-server-side-encryption"] == "aws:kms" &&
+		s3.S3Fields["x-amz-server-side-encryption-aws-kms-key-id"] == keyID
+}
+
+// logUploader - Encrypts log data with the grant's encryption key, and uploads
+// it (to S3, or the grant's local directory), with the server tags attached as
+// object metadata
+type logUploader struct {
+	transport OutputTransport
+	encryptor s3crypto.ContentCipher
+	compress  bool
+	metadata  map[string]string
+}
+
+func newLogUploader(s3 state.GrantS3, encryptionKey state.GrantLogsEncryptionKey, compress bool, tags map[string]string) (*logUploader, error) {
+	encryptor, err := newContentCipher(encryptionKey)
+	if err != nil {
+		return nil, err
 	}
 
+	return &logUploader{transport: transportForGrant(s3), encryptor: encryptor, compress: compress, metadata: tagMetadata(tags)}, nil
+}
+
+// newContentCipher - Returns the cipher that encrypts log data with the given
+// key from a logs grant
+func newContentCipher(encryptionKey state.GrantLogsEncryptionKey) (s3crypto.ContentCipher, error) {
 	plaintextKey, err := base64.StdEncoding.DecodeString(encryptionKey.Plaintext)
 	if err != nil {
-		logger.PrintError("Could not decode log encryption key (plaintext)")
-		return logFiles
+		return nil, fmt.Errorf("Could not decode log encryption key (plaintext)")
 	}
 	ciphertextKey, err := base64.StdEncoding.DecodeString(encryptionKey.CiphertextBlob)
 	if err != nil {
-		logger.PrintError("Could not decode log encryption key (encrypted)")
-		return logFiles
+		return nil, fmt.Errorf("Could not decode log encryption key (encrypted)")
 	}
 
 	kh := keyHandler{plaintextKey: plaintextKey, ciphertextKey: ciphertextKey, cmkID: encryptionKey.KeyId}
@@ -66,65 +122,126 @@ func EncryptAndUploadLogfiles(s3 state.GrantS3, encryptionKey state.GrantLogsEnc
 
 	encryptor, err := builder.ContentCipher()
 	if err != nil {
-		logger.PrintError("Could not load content cipher: %s", err)
-		return logFiles
+		return nil, fmt.Errorf("Could not load content cipher: %s", err)
 	}
 
-	for idx, logFile := range logFiles {
-		content, _ := ioutil.ReadFile(logFile.TmpFile.Name())
+	return encryptor, nil
+}
 
-		dst := &bytesReadWriteSeeker{}
-		md5 := newMD5Reader(bytes.NewReader(content))
-		reader, err := encryptor.EncryptContents(md5)
-		if err != nil {
-			logger.PrintError("%s", err)
-			return logFiles
-		}
+// encryptContent - Encrypts the content, and returns it together with the key
+// envelope (as S3 object metadata fields) that is needed to decrypt it
+func encryptContent(encryptor s3crypto.ContentCipher, content []byte) ([]byte, map[string]string, error) {
+	dst := &bytesReadWriteSeeker{}
+	md5 := newMD5Reader(bytes.NewReader(content))
+	reader, err := encryptor.EncryptContents(md5)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		_, err = io.Copy(dst, reader)
-		if err != nil {
-			logger.PrintError("%s", err)
-			return logFiles
-		}
+	_, err = io.Copy(dst, reader)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		data := encryptor.GetCipherData()
-		env, err := encodeMeta(md5, data)
-		if err != nil {
-			logger.PrintError("%s", err)
-			return logFiles
-		}
+	data := encryptor.GetCipherData()
+	env, err := encodeMeta(md5, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dst.Seek(0, 0)
+	encryptedContent, err := ioutil.ReadAll(dst)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	envelope := make(map[string]string)
+	envelope["x-amz-meta-x-amz-key-v2"] = env.CipherKey
+	envelope["x-amz-meta-x-amz-iv"] = env.IV
+	envelope["x-amz-meta-x-amz-matdesc"] = env.MatDesc
+	envelope["x-amz-meta-x-amz-wrap-alg"] = env.WrapAlg
+	envelope["x-amz-meta-x-amz-cek-alg"] = env.CEKAlg
+	envelope["x-amz-meta-x-amz-tag-len"] = env.TagLen
+	envelope["x-amz-meta-x-amz-unencrypted-content-md5"] = env.UnencryptedMD5
+	envelope["x-amz-meta-x-amz-unencrypted-content-length"] = env.UnencryptedContentLen
+
+	return encryptedContent, envelope, nil
+}
 
-		dst.Seek(0, 0)
-		encryptedContent, err := ioutil.ReadAll(dst)
+// EncryptLogContent - Encrypts log data with the encryption key of a logs grant,
+// the same way as for uploads, for data that is kept locally instead
+//
+// Returns the encrypted content and its key envelope (in the format of S3
+// client-side encryption metadata, with the data key encrypted by KMS).
+func EncryptLogContent(encryptionKey state.GrantLogsEncryptionKey, content []byte) ([]byte, map[string]string, error) {
+	encryptor, err := newContentCipher(encryptionKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return encryptContent(encryptor, content)
+}
+
+// upload - Encrypts and uploads the content under the given name, returning
+// its location and the content encryption algorithm
+func (u *logUploader) upload(ctx context.Context, logger *util.Logger, content []byte, name string) (string, string, error) {
+	var err error
+	uploadContent := content
+	if u.compress {
+		uploadContent, err = compressLogContent(content)
 		if err != nil {
-			logger.PrintError("%s", err)
-			return logFiles
+			return "", "", fmt.Errorf("Could not compress log file: %s", err)
 		}
+	}
 
-		formFields := make(map[string]string)
-		for k, v := range s3.S3Fields {
-			formFields[k] = v
-		}
+	encryptedContent, formFields, err := encryptContent(u.encryptor, uploadContent)
+	if err != nil {
+		return "", "", err
+	}
+	for key, value := range u.metadata {
+		formFields[key] = value
+	}
+
+	s3Location, err := u.transport.Upload(ctx, logger, encryptedContent, name, formFields)
+	if err != nil {
+		return "", "", fmt.Errorf("Log S3 upload failed: %s", err)
+	}
+	state.RecordUploadedLogBytes(len(encryptedContent))
 
-		formFields["x-amz-meta-x-amz-key-v2"] = env.CipherKey
-		formFields["x-amz-meta-x-amz-iv"] = env.IV
-		formFields["x-amz-meta-x-amz-matdesc"] = env.MatDesc
-		formFields["x-amz-meta-x-amz-wrap-alg"] = env.WrapAlg
-		formFields["x-amz-meta-x-amz-cek-alg"] = env.CEKAlg
-		formFields["x-amz-meta-x-amz-tag-len"] = env.TagLen
-		formFields["x-amz-meta-x-amz-unencrypted-content-md5"] = env.UnencryptedMD5
-		formFields["x-amz-meta-x-amz-unencrypted-content-length"] = env.UnencryptedContentLen
+	return s3Location, formFields["x-amz-meta-x-amz-cek-alg"], nil
+}
+
+// EncryptAndUploadLogfiles - Encrypts each log file and uploads it (to S3, or the
+// grant's local directory), stopping early when the context gets cancelled
+// (remaining files won't have a S3 location)
+func EncryptAndUploadLogfiles(ctx context.Context, s3 state.GrantS3, encryptionKey state.GrantLogsEncryptionKey, compress bool, tags map[string]string, logger *util.Logger, logFiles []state.LogFile) []state.LogFile {
+	if len(logFiles) == 0 {
+		return logFiles
+	}
 
-		s3Location, err := uploadToS3(s3.S3URL, formFields, logger, encryptedContent, logFile.UUID.String())
+	uploader, err := newLogUploader(s3, encryptionKey, compress, tags)
+	if err != nil {
+		logger.PrintError("%s", err)
+		return logFiles
+	}
+
+	for idx, logFile := range logFiles {
+		if ctx.Err() != nil {
+			return logFiles
+		}
+
+		content, _ := logFile.ReadContent()
+		s3Location, cekAlgo, err := uploader.upload(ctx, logger, content, logFile.UUID.String())
 		if err != nil {
-			logger.PrintError("Log S3 upload failed: %s", err)
+			logger.PrintError("%s", err)
 			return logFiles
 		}
 
 		logFile.S3Location = s3Location
-		logFile.S3CekAlgo = env.CEKAlg
+		logFile.S3CekAlgo = cekAlgo
 		logFile.S3CmkKeyID = encryptionKey.KeyId
 		logFile.ByteSize = int64(len(content))
+		logFile.Compressed = compress
 
 		logFiles[idx] = logFile
 	}
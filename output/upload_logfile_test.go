@@ -0,0 +1,145 @@
+package output
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestCompressLogContent(t *testing.T) {
+	content := []byte("2018-10-16 10:47:11 UTC [24217]: [1-1] user=postgres,db=postgres - PG-00000 LOG:  duration: 3205.800 ms  statement: SELECT 1\n" +
+		"2018-10-16 10:47:12 UTC [24217]: [2-1] user=postgres,db=postgres - PG-42P01 ERROR:  relation \"x\" does not exist at character 15\n" +
+		"2018-10-16 10:47:12 UTC [24217]: [3-1] user=postgres,db=postgres - PG-42P01 STATEMENT:  SELECT * FROM x;\n")
+
+	compressed, err := compressLogContent(content)
+	if err != nil {
+		t.Fatalf("Error compressing: %s", err)
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("Error reading compressed content: %s", err)
+	}
+	decompressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Error decompressing: %s", err)
+	}
+
+	if !bytes.Equal(content, decompressed) {
+		t.Errorf("Decompressed content does not match:\n\texpected %q\n\tactual %q", content, decompressed)
+	}
+}
+
+var kmsKeyIDTests = []struct {
+	keyID string
+	valid bool
+}{
+	{"1234abcd-12ab-34cd-56ef-1234567890ab", true},
+	{"arn:aws:kms:us-east-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab", true},
+	{"alias/ExampleAlias", true},
+	{"arn:aws:kms:us-east-2:111122223333:alias/ExampleAlias", true},
+	{"arn:aws-us-gov:kms:us-gov-west-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab", true},
+	{"ExampleAlias", false},
+	{"1234abcd", false},
+	{"arn:aws:kms:us-east-2:111122223333:1234abcd-12ab-34cd-56ef-1234567890ab", false},
+	{"arn:aws:s3:::my-bucket", false},
+}
+
+func TestValidateKMSKeyID(t *testing.T) {
+	for _, test := range kmsKeyIDTests {
+		err := validateKMSKeyID(test.keyID)
+		if test.valid && err != nil {
+			t.Errorf("Expected %q to be valid, got error: %s", test.keyID, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("Expected %q to be invalid", test.keyID)
+		}
+	}
+}
+
+func TestUploadAndSendLogsInvalidKMSKeyID(t *testing.T) {
+	server := state.Server{Config: config.ServerConfig{LogEncryptionKeyID: "not-a-key"}}
+	grant := state.GrantLogs{Valid: true, EncryptionKey: state.GrantLogsEncryptionKey{CiphertextBlob: "blob"}}
+
+	err := UploadAndSendLogs(context.Background(), server, grant, state.CollectionOpts{SubmitCollectedData: true}, &util.Logger{}, state.LogState{})
+	if err == nil {
+		t.Errorf("Expected error for invalid log_encryption_key_id, got none")
+	}
+}
+
+func TestUploadAndSendLogsGrantWithoutKMSKey(t *testing.T) {
+	keyID := "alias/ExampleAlias"
+	server := state.Server{Config: config.ServerConfig{LogEncryptionKeyID: keyID}}
+	opts := state.CollectionOpts{SubmitCollectedData: true}
+	logState := state.LogState{LogFiles: []state.LogFile{{Content: []byte("log line\n")}}}
+
+	// A grant without the key in its policy is never used for uploading
+	grant := state.GrantLogs{Valid: true, Logdata: state.GrantS3{S3URL: "http://127.0.0.1:1/"}, EncryptionKey: state.GrantLogsEncryptionKey{CiphertextBlob: "blob"}}
+	err := UploadAndSendLogs(context.Background(), server, grant, opts, &util.Logger{}, logState)
+	if err == nil || !strings.Contains(err.Error(), "KMS key") {
+		t.Errorf("Expected error for grant without the KMS key, got: %v", err)
+	}
+
+	grant.Logdata.S3Fields = map[string]string{"x-amz-server-side-encryption": "aws:kms", "x-amz-server-side-encryption-aws-kms-key-id": "alias/OtherAlias"}
+	err = UploadAndSendLogs(context.Background(), server, grant, opts, &util.Logger{}, logState)
+	if err == nil || !strings.Contains(err.Error(), "KMS key") {
+		t.Errorf("Expected error for grant with another KMS key, got: %v", err)
+	}
+
+	grant.Logdata.S3Fields["x-amz-server-side-encryption-aws-kms-key-id"] = keyID
+	if !grantUsesKMSKey(grant.Logdata, keyID) {
+		t.Errorf("Expected grant with the KMS key to be used")
+	}
+}
+
+func TestEncryptAndUploadLogfilesUploadedBytes(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	localDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	tmpFile, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatalf("Could not create tempfile: %s", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	tmpFile.WriteString(strings.Repeat("2018-10-16 10:47:11 UTC [24217]: [1-1] LOG:  checkpoint starting: time\n", 100))
+
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	encryptionKey := state.GrantLogsEncryptionKey{Plaintext: key, CiphertextBlob: key}
+	logFiles := []state.LogFile{{UUID: uuid.NewV4(), TmpFile: tmpFile}}
+
+	logBytesBefore, _ := state.GetUploadedBytes()
+	logFiles = EncryptAndUploadLogfiles(context.Background(), state.GrantS3{LocalDir: localDir}, encryptionKey, true, nil, logger, logFiles)
+	logBytesAfter, _ := state.GetUploadedBytes()
+
+	uploaded, err := ioutil.ReadFile(logFiles[0].S3Location)
+	if err != nil {
+		t.Fatalf("Could not read uploaded log file: %s", err)
+	}
+	if logBytesAfter-logBytesBefore != int64(len(uploaded)) {
+		t.Errorf("Expected %d uploaded log bytes, got %d", len(uploaded), logBytesAfter-logBytesBefore)
+	}
+	if len(uploaded) >= 100*72 {
+		t.Errorf("Expected compressed size to be counted, got %d bytes", len(uploaded))
+	}
+	if !logFiles[0].Compressed {
+		t.Errorf("Expected log file to be marked as compressed")
+	}
+	if metadata, _ := ioutil.ReadFile(logFiles[0].S3Location + ".metadata.json"); bytes.Contains(metadata, []byte("content-encoding")) {
+		t.Errorf("Expected compression to not be sent as upload metadata, got %s", metadata)
+	}
+}
@@ -30,6 +30,8 @@ func (report BloatReport) ReportType() string {
 
 // Run the report
 func (report *BloatReport) Run(server state.Server, logger *util.Logger, connection *sql.DB) (err error) {
+	// Reports are requested on-demand, so they always get fresh estimates
+	// instead of the ones from the last full snapshot
 	report.Data, err = postgres.GetBloatStats(logger, connection)
 	if err != nil {
 		return
@@ -23,12 +23,12 @@ func processActivityForServer(server state.Server, globalCollectionOpts state.Co
 		return false, errors.Wrap(err, "could not get default grant for activity snapshot")
 	}
 
-	connection, err = postgres.EstablishConnection(server, logger, globalCollectionOpts, "")
+	connection, releaseConnection, err := postgres.AcquireConnection(server, logger, globalCollectionOpts)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to connect to database")
 	}
 
-	defer connection.Close()
+	defer releaseConnection()
 
 	activity.Version, err = postgres.GetPostgresVersion(logger, connection)
 	if err != nil {
@@ -61,6 +61,9 @@ func processActivityForServer(server state.Server, globalCollectionOpts state.Co
 
 // CollectActivityFromAllServers - Collects activity from all servers and sends them to the pganalyze service
 func CollectActivityFromAllServers(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) {
+	if globalCollectionOpts.Pause.Paused(logger) {
+		return
+	}
 	for _, server := range servers {
 		if !server.Config.EnableActivity {
 			continue
@@ -0,0 +1,37 @@
+package runner
+
+import (
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// CollectOnce - Runs a single full snapshot and log download for all servers,
+// and returns the exit code for the collector process (0 if everything
+// succeeded, 1 otherwise)
+//
+// This is intended for running the collector from cron, and doesn't tail local
+// log files or receive syslog messages, since that requires a long-running process.
+func CollectOnce(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) int {
+	for _, server := range servers {
+		if server.Config.LogLocation != "" || server.Config.LogDockerTail != "" || server.Config.LogSyslogServer != "" {
+			logger.WithPrefix(server.Config.SectionName).PrintWarning("Skipping log tailing, which is not supported when collecting once")
+		}
+	}
+
+	// The state file is only written once the logs were downloaded as well, so
+	// it includes where reading them left off
+	snapshotOpts := globalCollectionOpts
+	snapshotOpts.WriteStateUpdate = false
+	success := CollectAllServers(servers, snapshotOpts, logger)
+	if !DownloadLogsFromAllServers(servers, globalCollectionOpts, logger) {
+		success = false
+	}
+	if globalCollectionOpts.WriteStateUpdate {
+		writeStateFile(servers, globalCollectionOpts, logger)
+	}
+
+	if !success {
+		return 1
+	}
+	return 0
+}
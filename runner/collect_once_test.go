@@ -0,0 +1,56 @@
+package runner_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/runner"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+func TestCollectOnceExitCode(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	opts := state.CollectionOpts{CollectOnce: true, ForceEmptyGrant: true}
+
+	if exitCode := runner.CollectOnce(nil, opts, logger); exitCode != 0 {
+		t.Errorf("Expected exit code 0 without any failures, got %d", exitCode)
+	}
+
+	// Nothing listens on this port, so collecting fails
+	servers := []state.Server{{
+		Config:     config.ServerConfig{SectionName: "unreachable", DbHost: "127.0.0.1", DbPort: 1, DbName: "postgres", DbSslMode: "disable"},
+		StateMutex: &sync.Mutex{},
+	}}
+	if exitCode := runner.CollectOnce(servers, opts, logger); exitCode != 1 {
+		t.Errorf("Expected exit code 1 when collection fails, got %d", exitCode)
+	}
+}
+
+func TestCollectOnceWritesLogPositions(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	dir, err := ioutil.TempDir("", "collect_once")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	opts := state.CollectionOpts{CollectOnce: true, ForceEmptyGrant: true, WriteStateUpdate: true, StateFilename: filepath.Join(dir, "state")}
+
+	serverConfig := config.ServerConfig{SectionName: "unreachable", DbHost: "127.0.0.1", DbPort: 1, DbName: "postgres", DbSslMode: "disable"}
+	position := state.RdsLogsPosition{LastDownloadedAt: time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC), Markers: map[string]string{"error/postgresql.log.2018-10-16-12": "12:3456"}}
+	servers := []state.Server{{Config: serverConfig, StateMutex: &sync.Mutex{}, PrevState: state.PersistedState{RdsLogsPosition: position}}}
+	runner.CollectOnce(servers, opts, logger)
+
+	readServers := []state.Server{{Config: serverConfig, StateMutex: &sync.Mutex{}}}
+	runner.ReadStateFile(readServers, opts, logger)
+	readPosition := readServers[0].PrevState.RdsLogsPosition
+	if !readPosition.LastDownloadedAt.Equal(position.LastDownloadedAt) || readPosition.Markers["error/postgresql.log.2018-10-16-12"] != "12:3456" {
+		t.Errorf("Expected log position to be kept in the state file, got %v", readPosition)
+	}
+}
@@ -1,23 +1,84 @@
 package runner
 
 import (
+	"time"
+
 	"github.com/pganalyze/collector/state"
 	"github.com/pganalyze/collector/util"
 )
 
 func diffState(logger *util.Logger, prevState state.PersistedState, newState state.PersistedState, collectedIntervalSecs uint32) (diffState state.DiffState) {
-	diffState.StatementStats = diffStatements(newState.StatementStats, prevState.StatementStats)
-	diffState.RelationStats = diffRelationStats(newState.RelationStats, prevState.RelationStats)
-	diffState.IndexStats = diffIndexStats(newState.IndexStats, prevState.IndexStats)
-	diffState.SystemCPUStats = diffSystemCPUStats(newState.System.CPUStats, prevState.System.CPUStats)
-	diffState.SystemNetworkStats = diffSystemNetworkStats(newState.System.NetworkStats, prevState.System.NetworkStats, collectedIntervalSecs)
-	diffState.SystemDiskStats = diffSystemDiskStats(newState.System.DiskStats, prevState.System.DiskStats, collectedIntervalSecs)
+	// Data of categories that was carried over (because they are not due yet, or
+	// due to throttling) has nothing new to diff, and is left out. The next diff
+	// then covers the time since the data was actually collected, instead of
+	// the last run.
+	if state.CollectedThisRun(newState, state.CollectionCategoryStatements) {
+		diffState.StatementStats, diffState.StatementStatsReset = diffStatements(newState.StatementStats, prevState.StatementStats)
+		if diffState.StatementStatsReset {
+			logger.PrintVerbose("Detected a reset of pg_stat_statements since the last run, using current values for statements whose counters went down")
+		}
+		diffState.StatementStatsIntervalSecs = categoryIntervalSecs(prevState, newState, state.CollectionCategoryStatements, collectedIntervalSecs)
+	}
+
+	if state.CollectedThisRun(newState, state.CollectionCategoryRelations) {
+		diffState.RelationStats = diffRelationStats(newState.RelationStats, prevState.RelationStats, newState.Relations)
+		diffState.IndexStats = diffIndexStats(newState.IndexStats, prevState.IndexStats)
+		diffState.RelationStatsIntervalSecs = categoryIntervalSecs(prevState, newState, state.CollectionCategoryRelations, collectedIntervalSecs)
+	}
+
+	if state.CollectedThisRun(newState, state.CollectionCategorySystem) {
+		systemIntervalSecs := categoryIntervalSecs(prevState, newState, state.CollectionCategorySystem, collectedIntervalSecs)
+		diffState.SystemCPUStats = diffSystemCPUStats(newState.System.CPUStats, prevState.System.CPUStats)
+		diffState.SystemNetworkStats = diffSystemNetworkStats(newState.System.NetworkStats, prevState.System.NetworkStats, systemIntervalSecs)
+		diffState.SystemDiskStats = diffSystemDiskStats(newState.System.DiskStats, prevState.System.DiskStats, systemIntervalSecs)
+		diffState.SystemMemoryStats = diffSystemMemoryStats(logger, newState.System.Memory, prevState.System.Memory)
+	}
+
+	diffState.IOStats = newState.IOStats.DiffSince(prevState.IOStats, collectedIntervalSecs)
 	diffState.CollectorStats = diffCollectorStats(newState.CollectorStats, prevState.CollectorStats)
 
+	// Only diff replication and backend counts on follow-up runs, otherwise all
+	// standbys would show up as added, and all connections as newly opened
+	if !prevState.CollectedAt.IsZero() {
+		diffState.Replication = diffReplication(logger, newState.Replication, prevState.Replication)
+		diffState.BackendCounts = diffBackendCounts(logger, newState.BackendCounts, prevState.BackendCounts)
+		diffState.FunctionDefinitions = diffFunctionDefinitions(logger, newState.Functions, prevState.Functions)
+	}
+
+	// Previous states without background writer statistics (e.g. written by an
+	// older collector version) would report all checkpoints since the last
+	// statistics reset as having happened since the last run
+	if !prevState.CollectedAt.IsZero() && prevState.BgwriterStats != (state.PostgresBgwriterStats{}) {
+		bgwriterStats := newState.BgwriterStats.DiffSince(prevState.BgwriterStats, collectedIntervalSecs)
+		diffState.BgwriterStats = &bgwriterStats
+	}
+
 	return
 }
 
-func diffStatements(new state.PostgresStatementStatsMap, prev state.PostgresStatementStatsMap) (diff state.DiffedPostgresStatementStatsMap) {
+// categoryIntervalSecs - Returns the seconds between the previous and the
+// current collection of a category, or the interval of the whole snapshot if
+// there was no previous collection
+func categoryIntervalSecs(prevState state.PersistedState, newState state.PersistedState, category state.CollectionCategory, collectedIntervalSecs uint32) uint32 {
+	prevCollectedAt := prevState.CategoryCollectedAtOrRun(category)
+	collectedAt := newState.CategoryCollectedAtOrRun(category)
+	if prevCollectedAt.IsZero() || !collectedAt.After(prevCollectedAt) {
+		return collectedIntervalSecs
+	}
+	secs := uint32(collectedAt.Sub(prevCollectedAt) / time.Second)
+	if secs == 0 {
+		return 1 // Avoid divide by zero errors for fast consecutive runs
+	}
+	return secs
+}
+
+// diffStatements - Returns the statement statistics since the previous run
+//
+// Statements are matched by their full key. pg_stat_statements keeps separate
+// counters for each database and role, so a query that shows up under a new
+// database or role OID (e.g. after the database was recreated) started counting
+// from zero, and is treated as a new statement.
+func diffStatements(new state.PostgresStatementStatsMap, prev state.PostgresStatementStatsMap) (diff state.DiffedPostgresStatementStatsMap, reset bool) {
 	followUpRun := len(prev) > 0
 	diff = make(state.DiffedPostgresStatementStatsMap)
 
@@ -25,7 +86,12 @@ func diffStatements(new state.PostgresStatementStatsMap, prev state.PostgresStat
 		var diffedStatement state.DiffedPostgresStatementStats
 
 		prevStatement, exists := prev[key]
-		if exists {
+		if exists && statement.WasResetSince(prevStatement) {
+			// Counters started again from zero after the reset, so everything
+			// we see now happened since then
+			diffedStatement = statement.DiffSince(state.PostgresStatementStats{})
+			reset = true
+		} else if exists {
 			diffedStatement = statement.DiffSince(prevStatement)
 		} else if followUpRun { // New statement since the last run
 			diffedStatement = statement.DiffSince(state.PostgresStatementStats{})
@@ -39,7 +105,7 @@ func diffStatements(new state.PostgresStatementStatsMap, prev state.PostgresStat
 	return
 }
 
-func diffRelationStats(new state.PostgresRelationStatsMap, prev state.PostgresRelationStatsMap) (diff state.DiffedPostgresRelationStatsMap) {
+func diffRelationStats(new state.PostgresRelationStatsMap, prev state.PostgresRelationStatsMap, relations []state.PostgresRelation) (diff state.DiffedPostgresRelationStatsMap) {
 	followUpRun := len(prev) > 0
 
 	diff = make(state.DiffedPostgresRelationStatsMap)
@@ -52,6 +118,10 @@ func diffRelationStats(new state.PostgresRelationStatsMap, prev state.PostgresRe
 		}
 	}
 
+	// Partitioned tables get the sum of their partitions, which is based on the
+	// per-partition diffs so partitions coming and going don't skew the totals
+	diff.AggregatePartitions(relations)
+
 	return
 }
 
@@ -71,6 +141,16 @@ func diffIndexStats(new state.PostgresIndexStatsMap, prev state.PostgresIndexSta
 	return
 }
 
+// diffUnusedIndexes - Updates since when indexes have been without any scans,
+// which is kept in the persisted state so it survives collector restarts
+func diffUnusedIndexes(logger *util.Logger, newState state.PersistedState, transientState state.TransientState, prevState state.PersistedState) state.PostgresIndexUnusedMap {
+	unused := state.TrackUnusedIndexes(newState.Relations, newState.IndexStats, transientState.DatabaseOidsWithLocalCatalog, prevState.UnusedIndexes, newState.CollectedAt)
+	if len(unused) > 0 {
+		logger.PrintVerbose("Found %d indexes without any scans since the last statistics reset", len(unused))
+	}
+	return unused
+}
+
 func diffSystemCPUStats(new state.CPUStatisticMap, prev state.CPUStatisticMap) (diff state.DiffedSystemCPUStatsMap) {
 	diff = make(state.DiffedSystemCPUStatsMap)
 	for cpuID, stats := range new {
@@ -129,3 +209,66 @@ func diffCollectorStats(new state.CollectorStats, prev state.CollectorStats) (di
 	diff = new.DiffSince(prev)
 	return
 }
+
+func diffReplication(logger *util.Logger, new state.PostgresReplication, prev state.PostgresReplication) (diff state.DiffedPostgresReplication) {
+	diff = new.DiffSince(prev)
+
+	if diff.RoleChanged {
+		if new.InRecovery {
+			logger.PrintVerbose("Server changed from primary to standby since the last run")
+		} else {
+			logger.PrintVerbose("Server changed from standby to primary since the last run")
+		}
+	}
+	for _, standby := range diff.AddedStandbys {
+		logger.PrintVerbose("Standby %s (%s) connected since the last run", standby.ApplicationName, standby.ClientAddr)
+	}
+	for _, standby := range diff.RemovedStandbys {
+		logger.PrintVerbose("Standby %s (%s) disconnected since the last run", standby.ApplicationName, standby.ClientAddr)
+	}
+	for _, slotName := range diff.StalledLogicalSlots {
+		logger.PrintWarning("Logical replication slot %s did not advance since the last run, and retains WAL until its consumer catches up", slotName)
+	}
+
+	return
+}
+
+func diffBackendCounts(logger *util.Logger, new state.PostgresBackendCounts, prev state.PostgresBackendCounts) (diff state.DiffedPostgresBackendCounts) {
+	diff = new.DiffSince(prev)
+
+	for backendState, delta := range diff {
+		if delta != 0 {
+			logger.PrintVerbose("Number of \"%s\" backends changed by %+d since the last run", backendState, delta)
+		}
+	}
+
+	return
+}
+
+// diffFunctionDefinitions - Detects function definition changes, the source of
+// modified functions is only kept (and logged) in verbose mode
+func diffFunctionDefinitions(logger *util.Logger, new []state.PostgresFunction, prev []state.PostgresFunction) (diff state.DiffedPostgresFunctionDefinitions) {
+	diff = state.DiffFunctionDefinitions(new, prev, logger.Verbose)
+
+	for _, key := range diff.Added {
+		logger.PrintVerbose("Function %s.%s(%s) was added since the last run", key.SchemaName, key.FunctionName, key.Arguments)
+	}
+	for _, key := range diff.Removed {
+		logger.PrintVerbose("Function %s.%s(%s) was removed since the last run", key.SchemaName, key.FunctionName, key.Arguments)
+	}
+	for _, key := range diff.Modified {
+		logger.PrintVerbose("Function %s.%s(%s) was modified since the last run, new source:\n%s", key.SchemaName, key.FunctionName, key.Arguments, diff.ModifiedSources[key].After)
+	}
+
+	return
+}
+
+func diffSystemMemoryStats(logger *util.Logger, new state.Memory, prev state.Memory) (diff state.DiffedMemoryStats) {
+	diff = new.DiffSince(prev)
+
+	if diff.LikelyMemoryPressure {
+		logger.PrintVerbose("System is likely under memory pressure (available memory changed by %d bytes, %d bytes swapped in since the last run)", diff.AvailableBytesDelta, diff.SwapInBytes)
+	}
+
+	return
+}
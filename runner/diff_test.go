@@ -0,0 +1,171 @@
+package runner
+
+import (
+	"io/ioutil"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+var statementKey1 = state.PostgresStatementKey{DatabaseOid: 1, UserOid: 10, QueryID: 1}
+var statementKey2 = state.PostgresStatementKey{DatabaseOid: 1, UserOid: 10, QueryID: 2}
+
+var diffStatementsTests = []struct {
+	prev          state.PostgresStatementStatsMap
+	new           state.PostgresStatementStatsMap
+	expected      state.DiffedPostgresStatementStatsMap
+	expectedReset bool
+}{
+	{
+		// Counters going up
+		state.PostgresStatementStatsMap{statementKey1: {Calls: 10, TotalTime: 100, Rows: 10}},
+		state.PostgresStatementStatsMap{statementKey1: {Calls: 15, TotalTime: 150, Rows: 20}},
+		state.DiffedPostgresStatementStatsMap{statementKey1: {Calls: 5, TotalTime: 50, Rows: 10}},
+		false,
+	},
+	{
+		// Reset of pg_stat_statements in between, the current values are used
+		// instead of negative differences
+		state.PostgresStatementStatsMap{
+			statementKey1: {Calls: 10, TotalTime: 100, Rows: 10},
+			statementKey2: {Calls: 5, TotalTime: 50, Rows: 5},
+		},
+		state.PostgresStatementStatsMap{
+			statementKey1: {Calls: 3, TotalTime: 30, Rows: 3},
+			statementKey2: {Calls: 7, TotalTime: 70, Rows: 7},
+		},
+		state.DiffedPostgresStatementStatsMap{
+			statementKey1: {Calls: 3, TotalTime: 30, Rows: 3},
+			statementKey2: {Calls: 2, TotalTime: 20, Rows: 2},
+		},
+		true,
+	},
+	{
+		// Only a counter other than calls went down
+		state.PostgresStatementStatsMap{statementKey1: {Calls: 10, TotalTime: 100, SharedBlksHit: 500}},
+		state.PostgresStatementStatsMap{statementKey1: {Calls: 12, TotalTime: 120, SharedBlksHit: 20}},
+		state.DiffedPostgresStatementStatsMap{statementKey1: {Calls: 12, TotalTime: 120, SharedBlksHit: 20}},
+		true,
+	},
+	{
+		// Database got recreated with a new OID, its statements start counting
+		// from zero under the new key
+		state.PostgresStatementStatsMap{statementKey1: {Calls: 10, TotalTime: 100, Rows: 10}},
+		state.PostgresStatementStatsMap{{DatabaseOid: 2, UserOid: 10, QueryID: 1}: {Calls: 3, TotalTime: 30, Rows: 3}},
+		state.DiffedPostgresStatementStatsMap{{DatabaseOid: 2, UserOid: 10, QueryID: 1}: {Calls: 3, TotalTime: 30, Rows: 3}},
+		false,
+	},
+}
+
+func TestDiffStatements(t *testing.T) {
+	for _, test := range diffStatementsTests {
+		diff, reset := diffStatements(test.new, test.prev)
+
+		cfg := pretty.CompareConfig
+		cfg.SkipZeroFields = true
+		if d := cfg.Compare(test.expected, diff); d != "" {
+			t.Errorf("diffStatements: result diff: (-want +got)\n%s", d)
+		}
+		if reset != test.expectedReset {
+			t.Errorf("diffStatements: expected reset %t, got %t", test.expectedReset, reset)
+		}
+	}
+}
+
+func TestDiffStateCarriedOverRelations(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	start := time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+	relations := state.CollectionCategoryRelations
+
+	collected := state.PersistedState{
+		CollectedAt:         start,
+		CategoryCollectedAt: map[state.CollectionCategory]time.Time{relations: start},
+		RelationStats:       state.PostgresRelationStatsMap{1: {SeqScan: 10}},
+	}
+	// Throttled run, which carries over the relation statistics
+	carriedOver := state.PersistedState{
+		CollectedAt:         start.Add(10 * time.Minute),
+		CategoryCollectedAt: collected.CategoryCollectedAt,
+		RelationStats:       collected.RelationStats,
+	}
+	next := state.PersistedState{
+		CollectedAt:         start.Add(20 * time.Minute),
+		CategoryCollectedAt: map[state.CollectionCategory]time.Time{relations: start.Add(20 * time.Minute)},
+		RelationStats:       state.PostgresRelationStatsMap{1: {SeqScan: 30}},
+	}
+
+	diff := diffState(logger, collected, carriedOver, 600)
+	if len(diff.RelationStats) != 0 || diff.RelationStatsIntervalSecs != 0 {
+		t.Errorf("Expected carried over relation statistics to be left out, got %v over %d seconds", diff.RelationStats, diff.RelationStatsIntervalSecs)
+	}
+
+	diff = diffState(logger, carriedOver, next, 600)
+	if diff.RelationStats[1].SeqScan != 20 || diff.RelationStatsIntervalSecs != 1200 {
+		t.Errorf("Expected 20 sequential scans over 1200 seconds, got %d over %d seconds", diff.RelationStats[1].SeqScan, diff.RelationStatsIntervalSecs)
+	}
+}
+
+func TestDiffStateCategoryIntervals(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	start := time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+	system := state.CollectionCategorySystem
+	statements := state.CollectionCategoryStatements
+
+	collected := state.PersistedState{
+		CollectedAt:         start,
+		CategoryCollectedAt: map[state.CollectionCategory]time.Time{system: start, statements: start},
+		System:              state.SystemState{NetworkStats: state.NetworkStatsMap{"eth0": {ReceiveThroughputBytes: 1000}}},
+		StatementStats:      state.PostgresStatementStatsMap{statementKey1: {Calls: 10}},
+	}
+	// Neither category is due yet, so their data is carried over
+	skipped := state.PersistedState{
+		CollectedAt:         start.Add(10 * time.Minute),
+		CategoryCollectedAt: collected.CategoryCollectedAt,
+		System:              collected.System,
+		StatementStats:      collected.StatementStats,
+	}
+	next := state.PersistedState{
+		CollectedAt:         start.Add(20 * time.Minute),
+		CategoryCollectedAt: map[state.CollectionCategory]time.Time{system: start.Add(20 * time.Minute), statements: start.Add(20 * time.Minute)},
+		System:              state.SystemState{NetworkStats: state.NetworkStatsMap{"eth0": {ReceiveThroughputBytes: 121000}}},
+		StatementStats:      state.PostgresStatementStatsMap{statementKey1: {Calls: 30}},
+	}
+
+	diff := diffState(logger, collected, skipped, 600)
+	if len(diff.SystemNetworkStats) != 0 || len(diff.StatementStats) != 0 || diff.StatementStatsIntervalSecs != 0 {
+		t.Errorf("Expected skipped categories to be left out, got network %v and statements %v", diff.SystemNetworkStats, diff.StatementStats)
+	}
+
+	diff = diffState(logger, skipped, next, 600)
+	if rate := diff.SystemNetworkStats["eth0"].ReceiveThroughputBytesPerSecond; rate != 100 {
+		t.Errorf("Expected network rate over the 1200 seconds since the last collection to be 100 bytes/s, got %d", rate)
+	}
+	if diff.StatementStats[statementKey1].Calls != 20 || diff.StatementStatsIntervalSecs != 1200 {
+		t.Errorf("Expected 20 calls over 1200 seconds, got %d over %d seconds", diff.StatementStats[statementKey1].Calls, diff.StatementStatsIntervalSecs)
+	}
+}
+
+func TestDiffStateBgwriterStats(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+	first := time.Date(2018, time.September, 28, 7, 0, 0, 0, time.UTC)
+	second := first.Add(10 * time.Minute)
+
+	newState := state.PersistedState{CollectedAt: second, BgwriterStats: state.PostgresBgwriterStats{CheckpointsTimed: 12, BuffersAlloc: 6000}}
+
+	// The previous state has no background writer statistics, so there is nothing to diff against
+	diff := diffState(logger, state.PersistedState{CollectedAt: first}, newState, 600)
+	if diff.BgwriterStats != nil {
+		t.Errorf("Expected no background writer statistics without previous ones, got %+v", diff.BgwriterStats)
+	}
+
+	prevState := state.PersistedState{CollectedAt: first, BgwriterStats: state.PostgresBgwriterStats{CheckpointsTimed: 10, BuffersAlloc: 3000}}
+	diff = diffState(logger, prevState, newState, 600)
+	expected := &state.DiffedPostgresBgwriterStats{CheckpointsTimed: 2, BuffersAllocPerSecond: 5}
+	if d := pretty.Compare(expected, diff.BgwriterStats); d != "" {
+		t.Errorf("Background writer statistics diff: (-want +got)\n%s", d)
+	}
+}
@@ -0,0 +1,34 @@
+package runner
+
+import (
+	"github.com/pganalyze/collector/input"
+	"github.com/pganalyze/collector/input/postgres"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// TestExplainForAllServers - Test that query plans can be collected
+func TestExplainForAllServers(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) {
+	if !globalCollectionOpts.TestRun {
+		return
+	}
+
+	for _, server := range servers {
+		prefixedLogger := logger.WithPrefixAndRememberErrors(server.Config.SectionName)
+		prefixedLogger.PrintInfo("Testing EXPLAIN collection...")
+
+		connection, err := postgres.EstablishConnection(server, prefixedLogger, globalCollectionOpts, "")
+		if err != nil {
+			prefixedLogger.PrintError("ERROR - Failed to connect to database: %s", err)
+			continue
+		}
+
+		err = input.TestExplain(connection, prefixedLogger)
+		connection.Close()
+		if err != nil {
+			prefixedLogger.PrintError("ERROR - EXPLAIN test failed: %s", err)
+		} else {
+			prefixedLogger.PrintInfo("EXPLAIN test successful")
+		}
+	}
+}
@@ -1,8 +1,7 @@
 package runner
 
 import (
-	"database/sql"
-	"encoding/gob"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -12,53 +11,23 @@ import (
 	raven "github.com/getsentry/raven-go"
 	"github.com/pganalyze/collector/config"
 	"github.com/pganalyze/collector/grant"
-	"github.com/pganalyze/collector/input"
-	"github.com/pganalyze/collector/input/postgres"
 	"github.com/pganalyze/collector/output"
 	"github.com/pganalyze/collector/state"
 	"github.com/pganalyze/collector/util"
 )
 
 func collectDiffAndSubmit(server state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (state.PersistedState, error) {
-	var newState state.PersistedState
-	var err error
-	var connection *sql.DB
-
-	connection, err = postgres.EstablishConnection(server, logger, globalCollectionOpts, "")
+	result, err := RunOnce(context.Background(), server, globalCollectionOpts, logger)
 	if err != nil {
-		return newState, fmt.Errorf("Failed to connect to database: %s", err)
+		return result.State, err
 	}
 
-	newState, transientState, err := input.CollectFull(server, connection, globalCollectionOpts, logger)
+	err = output.SubmitFull(result.FullSnapshot, server, globalCollectionOpts, logger, result.State.CollectedAt)
 	if err != nil {
-		connection.Close()
-		return newState, err
-	}
-
-	// This is the easiest way to avoid opening multiple connections to different databases on the same instance
-	connection.Close()
-
-	collectedIntervalSecs := uint32(newState.CollectedAt.Sub(server.PrevState.CollectedAt) / time.Second)
-	if collectedIntervalSecs == 0 {
-		collectedIntervalSecs = 1 // Avoid divide by zero errors for fast consecutive runs
-	}
-
-	diffState := diffState(logger, server.PrevState, newState, collectedIntervalSecs)
-
-	transientState.HistoricStatementStats = server.PrevState.UnidentifiedStatementStats
-
-	err = output.SendFull(server, globalCollectionOpts, logger, newState, diffState, transientState, collectedIntervalSecs)
-	if err != nil {
-		return newState, err
-	}
-
-	// After we've done all processing, and in case we did a reset, make sure the
-	// next snapshot has an empty reference point
-	if transientState.ResetStatementStats != nil {
-		newState.StatementStats = transientState.ResetStatementStats
+		return result.State, err
 	}
 
-	return newState, nil
+	return result.State, nil
 }
 
 func capturePanic(f func()) (err interface{}, stackTrace []byte) {
@@ -137,36 +106,44 @@ func writeStateFile(servers []state.Server, globalCollectionOpts state.Collectio
 	}
 	defer file.Close()
 
-	encoder := gob.NewEncoder(file)
-	encoder.Encode(stateOnDisk)
+	err = state.EncodeStateOnDisk(file, stateOnDisk)
+	if err != nil {
+		logger.PrintWarning("Could not write out state file to %s because of error: %s", globalCollectionOpts.StateFilename, err)
+	}
 }
 
 // ReadStateFile - This reads in the prevState structs from the state file - only run this on initial bootup and SIGHUP!
 func ReadStateFile(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) {
-	var stateOnDisk state.StateOnDisk
-
 	file, err := os.Open(globalCollectionOpts.StateFilename)
 	if err != nil {
 		logger.PrintVerbose("Did not open state file: %s", err)
 		return
 	}
-	decoder := gob.NewDecoder(file)
-	err = decoder.Decode(&stateOnDisk)
+	defer file.Close()
+
+	// A state file that can't be decoded (e.g. because the collector crashed while
+	// writing it) is ignored, and we start fresh as if there was no state file
+	stateOnDisk, err := state.DecodeStateOnDisk(file)
 	if err != nil {
 		logger.PrintVerbose("Could not decode state file: %s", err)
 		return
 	}
-	defer file.Close()
 
 	if stateOnDisk.FormatVersion < state.StateOnDiskFormatVersion {
 		logger.PrintVerbose("Ignoring state file since the on-disk format has changed")
 		return
 	}
 
+	now := time.Now()
 	for idx, server := range servers {
 		prevState, exist := stateOnDisk.PrevStateByServer[server.Config.Identifier]
 		if exist {
 			prefixedLogger := logger.WithPrefix(server.Config.SectionName)
+			if prevState.IsStale(now, server.Config.MaxStateAge) {
+				prefixedLogger.PrintVerbose("Not diffing against statistics from on-disk file since they were collected more than %s ago", server.Config.MaxStateAge)
+				servers[idx].PrevState = prevState.WithoutDiffBaseline()
+				continue
+			}
 			prefixedLogger.PrintVerbose("Successfully recovered state from on-disk file")
 			servers[idx].PrevState = prevState
 		}
@@ -188,7 +165,13 @@ func runCompletionCallback(callbackType string, callbackCmd string, sectionName
 }
 
 // CollectAllServers - Collects statistics from all servers and sends them as full snapshots to the pganalyze service
-func CollectAllServers(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) {
+//
+// Returns whether all servers were collected successfully.
+func CollectAllServers(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (allSuccessful bool) {
+	allSuccessful = true
+	if globalCollectionOpts.Pause.Paused(logger) {
+		return
+	}
 	for idx, server := range servers {
 		var err error
 
@@ -202,6 +185,7 @@ func CollectAllServers(servers []state.Server, globalCollectionOpts state.Collec
 		newState, grant, err := processDatabase(server, globalCollectionOpts, prefixedLogger)
 		if err != nil {
 			servers[idx].StateMutex.Unlock()
+			allSuccessful = false
 			prefixedLogger.PrintError("Could not process database: %s", err)
 			if grant.Valid && !globalCollectionOpts.TestRun && globalCollectionOpts.SubmitCollectedData {
 				server.Grant = grant
@@ -215,8 +199,15 @@ func CollectAllServers(servers []state.Server, globalCollectionOpts state.Collec
 			}
 		} else {
 			servers[idx].Grant = grant
+			servers[idx].SharedGrant.Set(grant)
+			newState.CloudWatchLogsPosition = servers[idx].PrevState.CloudWatchLogsPosition
+			newState.AzureLogsPosition = servers[idx].PrevState.AzureLogsPosition
+			newState.RdsLogsPosition = servers[idx].PrevState.RdsLogsPosition
 			servers[idx].PrevState = newState
 			servers[idx].StateMutex.Unlock()
+			if globalCollectionOpts.SubmitCollectedData {
+				server.SubmissionTimes.RecordSnapshot(time.Now())
+			}
 			if server.Config.SuccessCallback != "" {
 				go runCompletionCallback("success", server.Config.SuccessCallback, server.Config.SectionName, "full", nil, prefixedLogger)
 			}
@@ -226,4 +217,6 @@ func CollectAllServers(servers []state.Server, globalCollectionOpts state.Collec
 	if globalCollectionOpts.WriteStateUpdate {
 		writeStateFile(servers, globalCollectionOpts, logger)
 	}
+
+	return
 }
@@ -1,41 +1,116 @@
 package runner
 
 import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/pganalyze/collector/grant"
 	"github.com/pganalyze/collector/input"
+	"github.com/pganalyze/collector/input/system/azure"
+	"github.com/pganalyze/collector/input/system/logs"
+	"github.com/pganalyze/collector/input/system/rds"
 	"github.com/pganalyze/collector/input/system/selfhosted"
 	"github.com/pganalyze/collector/output"
 	"github.com/pganalyze/collector/state"
 	"github.com/pganalyze/collector/util"
+	"github.com/pganalyze/collector/util/awsutil"
 	"github.com/pkg/errors"
 )
 
-func downloadLogsForServer(server state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (bool, error) {
-	grant, err := grant.GetLogsGrant(server, globalCollectionOpts, logger)
+// downloadLogsForServer - Downloads the log files of a server and sends them,
+// returning the position to continue at next time, which only moves ahead once
+// the logs were sent
+func downloadLogsForServer(server state.Server, position state.RdsLogsPosition, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (bool, state.RdsLogsPosition, error) {
+	grant, err := grant.GetCachedLogsGrant(context.Background(), server, globalCollectionOpts, logger)
 	if err != nil {
-		return false, errors.Wrap(err, "could not get log grant")
+		return false, position, errors.Wrap(err, "could not get log grant")
 	}
 
 	if !grant.Valid {
 		logger.PrintVerbose("Log collection disabled from server, skipping")
-		return false, nil
+		return false, position, nil
 	}
 
 	// TODO: We'll need to pass a connection here for EXPLAINs to run (or hand them over to the next full snapshot run)
-	logState, err := input.DownloadLogs(server, nil, globalCollectionOpts, logger)
+	logState, newPosition, err := input.DownloadLogs(server, position, nil, globalCollectionOpts, logger)
+	defer func() {
+		err := logState.Cleanup()
+		if err != nil {
+			logger.PrintError("Could not remove log tempfiles: %s", err)
+		}
+	}()
 	if err != nil {
-		logState.Cleanup()
-		return false, errors.Wrap(err, "could not collect logs")
+		return false, position, errors.Wrap(err, "could not collect logs")
 	}
 
-	err = output.UploadAndSendLogs(server, grant, globalCollectionOpts, logger, logState)
+	err = output.UploadAndSendLogs(context.Background(), server, grant, globalCollectionOpts, logger, logState)
 	if err != nil {
-		logState.Cleanup()
-		return false, errors.Wrap(err, "failed to upload/send logs")
+		if util.IsUploadForbidden(err) {
+			server.LogsGrantCache.Invalidate()
+		}
+		return false, position, errors.Wrap(err, "failed to upload/send logs")
+	}
+
+	return true, newPosition, nil
+}
+
+// Log lines read from CloudWatch Logs that were not ready to be sent yet, by config section
+var pendingCloudWatchLogLines = make(map[string][]state.LogLine)
+var pendingCloudWatchLogLinesMutex sync.Mutex
+
+func downloadCloudWatchLogsForServer(server state.Server, position state.CloudWatchLogsPosition, globalCollectionOpts state.CollectionOpts, logger *util.Logger, logTestSucceeded chan<- bool) (state.CloudWatchLogsPosition, error) {
+	pendingCloudWatchLogLinesMutex.Lock()
+	defer pendingCloudWatchLogLinesMutex.Unlock()
+
+	sess := awsutil.GetAwsSession(server.Config)
+	source := rds.NewCloudWatchLogSource(cloudwatchlogs.New(sess), server.Config.AwsCloudWatchLogGroup, position)
+
+	pendingLogLines, err := logs.AnalyzeSourceInGroupsAndSend(context.Background(), server, source, pendingCloudWatchLogLines[server.Config.SectionName], globalCollectionOpts, logger, logTestSucceeded)
+	pendingCloudWatchLogLines[server.Config.SectionName] = pendingLogLines
+
+	return source.Position(), err
+}
+
+// Log lines read from Azure Log Analytics that were not ready to be sent yet, by config section
+var pendingAzureLogLines = make(map[string][]state.LogLine)
+var pendingAzureLogLinesMutex sync.Mutex
+
+// Log Analytics clients by config section, kept so their access tokens are
+// reused between runs (also protected by pendingAzureLogLinesMutex)
+var azureLogAnalyticsClients = make(map[string]azureLogAnalyticsClient)
+
+type azureLogAnalyticsClient struct {
+	workspaceID          string
+	authConnectionString string
+	client               azure.LogAnalyticsAPI
+}
+
+func downloadAzureLogsForServer(server state.Server, position state.AzureLogsPosition, globalCollectionOpts state.CollectionOpts, logger *util.Logger, logTestSucceeded chan<- bool) (state.AzureLogsPosition, error) {
+	pendingAzureLogLinesMutex.Lock()
+	defer pendingAzureLogLinesMutex.Unlock()
+
+	// The client is set up again when the configuration changed on reload
+	cached, ok := azureLogAnalyticsClients[server.Config.SectionName]
+	if !ok || cached.workspaceID != server.Config.AzureLogAnalyticsWorkspaceID || cached.authConnectionString != server.Config.AzureAuthConnectionString {
+		client, err := azure.NewLogAnalyticsClient(server.Config)
+		if err != nil {
+			return position, errors.Wrap(err, "could not set up Log Analytics client")
+		}
+		cached = azureLogAnalyticsClient{
+			workspaceID:          server.Config.AzureLogAnalyticsWorkspaceID,
+			authConnectionString: server.Config.AzureAuthConnectionString,
+			client:               client,
+		}
+		azureLogAnalyticsClients[server.Config.SectionName] = cached
 	}
+	client := cached.client
+	source := azure.NewLogSource(client, server.Config.AzureDbServerName, position)
+
+	pendingLogLines, err := logs.AnalyzeSourceInGroupsAndSend(context.Background(), server, source, pendingAzureLogLines[server.Config.SectionName], globalCollectionOpts, logger, logTestSucceeded)
+	pendingAzureLogLines[server.Config.SectionName] = pendingLogLines
 
-	logState.Cleanup()
-	return true, nil
+	return source.Position(), err
 }
 
 // TestLogsForAllServers - Test log download/tailing
@@ -56,35 +131,133 @@ func TestLogsForAllServers(servers []state.Server, globalCollectionOpts state.Co
 				prefixedLogger.PrintInfo("Log test successful")
 				hasSuccessfulLocalServers = true
 			}
+		} else if server.Config.AwsCloudWatchLogGroup != "" {
+			prefixedLogger.PrintInfo("Testing CloudWatch Logs download...")
+			server.StateMutex.Lock()
+			position := server.PrevState.CloudWatchLogsPosition
+			server.StateMutex.Unlock()
+
+			// Lines are usually not ready to be sent yet during the test, but in case
+			// they are, any results are ignored - reading from the log group is the test
+			logTestSucceeded := make(chan bool)
+			go func() {
+				for range logTestSucceeded {
+				}
+			}()
+			_, err := downloadCloudWatchLogsForServer(server, position, globalCollectionOpts, prefixedLogger, logTestSucceeded)
+			close(logTestSucceeded)
+			if err != nil {
+				prefixedLogger.PrintError("Could not download logs from CloudWatch Logs for server: %s", err)
+			} else {
+				prefixedLogger.PrintInfo("Log test successful")
+			}
+		} else if server.Config.AzureLogAnalyticsWorkspaceID != "" {
+			prefixedLogger.PrintInfo("Testing Azure Log Analytics download...")
+			server.StateMutex.Lock()
+			position := server.PrevState.AzureLogsPosition
+			server.StateMutex.Unlock()
+
+			logTestSucceeded := make(chan bool)
+			go func() {
+				for range logTestSucceeded {
+				}
+			}()
+			_, err := downloadAzureLogsForServer(server, position, globalCollectionOpts, prefixedLogger, logTestSucceeded)
+			close(logTestSucceeded)
+			if err != nil {
+				prefixedLogger.PrintError("Could not download logs from Azure Log Analytics for server: %s", err)
+			} else {
+				prefixedLogger.PrintInfo("Log test successful")
+			}
 		} else if server.Config.EnableLogs {
 			prefixedLogger.PrintInfo("Testing log download...")
-			_, err := downloadLogsForServer(server, globalCollectionOpts, prefixedLogger)
+			server.StateMutex.Lock()
+			position := server.PrevState.RdsLogsPosition
+			server.StateMutex.Unlock()
+
+			_, _, err := downloadLogsForServer(server, position, globalCollectionOpts, prefixedLogger)
 			if err != nil {
 				prefixedLogger.PrintError("Could not download logs for server: %s", err)
 			} else {
 				prefixedLogger.PrintInfo("Log test successful")
 			}
 		}
+
+		printUnparseableLogLines(server, prefixedLogger)
 	}
 
 	return
 }
 
+// printUnparseableLogLines - Reports the log lines seen during the test that
+// could not be parsed, to help with unsupported log_line_prefix settings
+func printUnparseableLogLines(server state.Server, logger *util.Logger) {
+	unparseable := logs.TakeUnparseableLogLines(server)
+	if unparseable.Count == 0 {
+		return
+	}
+
+	logger.PrintWarning("Could not parse %d log lines - check that your log_line_prefix is supported", unparseable.Count)
+	for _, sample := range unparseable.Samples {
+		logger.PrintInfo("  Unparseable line: %s", sample)
+	}
+}
+
 // DownloadLogsFromAllServers - Downloads logs from all servers that are remote systems and sends them to the pganalyze service
-func DownloadLogsFromAllServers(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) {
+//
+// Returns whether the logs of all servers were collected successfully.
+func DownloadLogsFromAllServers(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (allSuccessful bool) {
+	allSuccessful = true
+	if globalCollectionOpts.Pause.Paused(logger) {
+		return
+	}
 	if !globalCollectionOpts.CollectLogs {
 		return
 	}
 
-	for _, server := range servers {
-		if !server.Config.EnableLogs {
+	for idx, server := range servers {
+		if !server.Config.EnableLogs && server.Config.AwsCloudWatchLogGroup == "" && server.Config.AzureLogAnalyticsWorkspaceID == "" {
 			continue
 		}
 
 		prefixedLogger := logger.WithPrefixAndRememberErrors(server.Config.SectionName)
 
-		success, err := downloadLogsForServer(server, globalCollectionOpts, prefixedLogger)
+		var success bool
+		var err error
+		if server.Config.AwsCloudWatchLogGroup != "" {
+			servers[idx].StateMutex.Lock()
+			position := servers[idx].PrevState.CloudWatchLogsPosition
+			servers[idx].StateMutex.Unlock()
+
+			// Lines that were read are sent (or kept as pending) even if reading
+			// stopped early, so we always continue after them on the next run
+			position, err = downloadCloudWatchLogsForServer(server, position, globalCollectionOpts, prefixedLogger, nil)
+			servers[idx].StateMutex.Lock()
+			servers[idx].PrevState.CloudWatchLogsPosition = position
+			servers[idx].StateMutex.Unlock()
+			success = err == nil
+		} else if server.Config.AzureLogAnalyticsWorkspaceID != "" {
+			servers[idx].StateMutex.Lock()
+			position := servers[idx].PrevState.AzureLogsPosition
+			servers[idx].StateMutex.Unlock()
+
+			position, err = downloadAzureLogsForServer(server, position, globalCollectionOpts, prefixedLogger, nil)
+			servers[idx].StateMutex.Lock()
+			servers[idx].PrevState.AzureLogsPosition = position
+			servers[idx].StateMutex.Unlock()
+			success = err == nil
+		} else {
+			servers[idx].StateMutex.Lock()
+			position := servers[idx].PrevState.RdsLogsPosition
+			servers[idx].StateMutex.Unlock()
+
+			success, position, err = downloadLogsForServer(server, position, globalCollectionOpts, prefixedLogger)
+			servers[idx].StateMutex.Lock()
+			servers[idx].PrevState.RdsLogsPosition = position
+			servers[idx].StateMutex.Unlock()
+		}
 		if err != nil {
+			allSuccessful = false
 			prefixedLogger.PrintError("Could not collect logs for server: %s", err)
 			if server.Config.ErrorCallback != "" {
 				go runCompletionCallback("error", server.Config.ErrorCallback, server.Config.SectionName, "logs", err, prefixedLogger)
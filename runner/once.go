@@ -0,0 +1,108 @@
+package runner
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pganalyze/collector/input"
+	"github.com/pganalyze/collector/input/postgres"
+	"github.com/pganalyze/collector/metrics"
+	"github.com/pganalyze/collector/output"
+	snapshot "github.com/pganalyze/collector/output/pganalyze_collector"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// Snapshot - Result of a single collection cycle run using RunOnce
+type Snapshot struct {
+	// The full snapshot, as it would be submitted to the pganalyze service
+	FullSnapshot snapshot.FullSnapshot
+
+	// State to keep as the server's PrevState, so the next cycle can calculate
+	// differences against it
+	State state.PersistedState
+}
+
+// RunOnce - Runs a single full collection for the server, and returns the
+// resulting snapshot without uploading it
+//
+// Statistics that are counters get diffed against server.PrevState, so the
+// first run for a server (without a previous state) only returns the baseline.
+// The context is checked before connecting and after collecting, the
+// collection itself can't be interrupted.
+func RunOnce(ctx context.Context, server state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) (Snapshot, error) {
+	var result Snapshot
+	var err error
+	var connection *sql.DB
+
+	if err = ctx.Err(); err != nil {
+		return result, err
+	}
+
+	connection, releaseConnection, err := postgres.AcquireConnection(server, logger, globalCollectionOpts)
+	if err != nil {
+		return result, fmt.Errorf("Failed to connect to database: %s", err)
+	}
+
+	newState, transientState, err := input.CollectFull(server, connection, globalCollectionOpts, logger)
+	if err != nil {
+		releaseConnection()
+		result.State = newState
+		return result, err
+	}
+
+	// This is the easiest way to avoid opening multiple connections to different databases on the same instance
+	// (a reused connection stays open though, see db_reuse_connection)
+	releaseConnection()
+
+	if err = ctx.Err(); err != nil {
+		return result, err
+	}
+
+	collectedIntervalSecs := uint32(newState.CollectedAt.Sub(server.PrevState.CollectedAt) / time.Second)
+	if collectedIntervalSecs == 0 {
+		collectedIntervalSecs = 1 // Avoid divide by zero errors for fast consecutive runs
+	}
+
+	newState.UnusedIndexes = diffUnusedIndexes(logger, newState, transientState, server.PrevState)
+	diffState := diffState(logger, server.PrevState, newState, collectedIntervalSecs)
+
+	if metrics.DefaultExporter.Enabled() {
+		metrics.DefaultExporter.Update(server.Config.SectionName, newState, diffState, transientState, collectedIntervalSecs)
+	}
+
+	// Minute-level query statistics can only be matched to their queries with the
+	// query texts, so they are kept until the next run that collects those
+	if state.CollectedThisRun(newState, state.CollectionCategoryStatements) {
+		transientState.HistoricStatementStats = server.PrevState.UnidentifiedStatementStats
+	} else {
+		newState.UnidentifiedStatementStats = server.PrevState.UnidentifiedStatementStats
+	}
+
+	// Partitions are only left out of what we send, the state we keep for the
+	// next run stays complete
+	sentState := newState
+	if server.Config.AggregatePartitions {
+		sentState.Relations = state.WithoutPartitions(newState.Relations)
+	}
+
+	// Same for the activity counters of tables that were barely used since the last run
+	sentDiffState := diffState
+	if server.Config.RelationStatsMinChange > 0 {
+		sentDiffState.RelationStats = diffState.RelationStats.WithMinActivity(server.Config.RelationStatsMinChange)
+	}
+
+	result.FullSnapshot = output.BuildFull(logger, sentState, sentDiffState, transientState, collectedIntervalSecs)
+
+	// After we've done all processing, and in case we did a reset, make sure the
+	// next snapshot has an empty reference point
+	if transientState.ResetStatementStats != nil {
+		newState.StatementStats = transientState.ResetStatementStats
+	}
+
+	result.State = newState
+
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package runner_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/runner"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+func TestRunOnceCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Nothing listens on this port, a connection attempt would fail differently
+	server := state.Server{Config: config.ServerConfig{DbHost: "127.0.0.1", DbPort: 1, DbName: "postgres"}}
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	_, err := runner.RunOnce(ctx, server, state.CollectionOpts{}, logger)
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got: %v", err)
+	}
+}
+
+// Runs two collections against the database in $DATABASE_URL, since most
+// statistics are only returned as differences to the previous collection
+func ExampleRunOnce() {
+	server := state.Server{Config: config.ServerConfig{DbURL: os.Getenv("DATABASE_URL")}}
+	opts := state.CollectionOpts{CollectPostgresRelations: true, CollectPostgresSettings: true, CollectPostgresLocks: true}
+	logger := &util.Logger{Destination: log.New(os.Stderr, "", 0)}
+
+	result, err := runner.RunOnce(context.Background(), server, opts, logger)
+	if err != nil {
+		fmt.Printf("Collection failed: %s\n", err)
+		return
+	}
+
+	server.PrevState = result.State
+	result, err = runner.RunOnce(context.Background(), server, opts, logger)
+	if err != nil {
+		fmt.Printf("Collection failed: %s\n", err)
+		return
+	}
+
+	fmt.Printf("Collected %d relations over %d seconds\n", len(result.FullSnapshot.RelationInformations), result.FullSnapshot.CollectedIntervalSecs)
+}
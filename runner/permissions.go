@@ -0,0 +1,68 @@
+package runner
+
+import (
+	"github.com/pganalyze/collector/input"
+	"github.com/pganalyze/collector/input/postgres"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// CheckPermissionsForAllServers - Reports which views and functions the
+// monitoring role of each server can't access, and how to grant access
+//
+// Returns whether all servers have the permissions needed.
+func CheckPermissionsForAllServers(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) bool {
+	allGranted := true
+
+	for _, server := range servers {
+		prefixedLogger := logger.WithPrefixAndRememberErrors(server.Config.SectionName)
+		prefixedLogger.PrintInfo("Checking permissions...")
+
+		connection, err := postgres.EstablishConnection(server, prefixedLogger, globalCollectionOpts, "")
+		if err != nil {
+			prefixedLogger.PrintError("ERROR - Failed to connect to database: %s", err)
+			allGranted = false
+			continue
+		}
+
+		problems, err := input.CheckPermissions(connection, prefixedLogger)
+		connection.Close()
+		if err != nil {
+			prefixedLogger.PrintError("ERROR - Permission check failed: %s", err)
+			allGranted = false
+			continue
+		}
+		if len(problems) == 0 {
+			prefixedLogger.PrintInfo("Permission check successful")
+			continue
+		}
+
+		allGranted = false
+		for _, problem := range problems {
+			prefixedLogger.PrintError("Missing access to %s: %s", problem.Check, problem.Message)
+		}
+		remediations := permissionRemediations(problems)
+		if len(remediations) > 0 {
+			prefixedLogger.PrintInfo("To fix this, run the following as a superuser:")
+			for _, remediation := range remediations {
+				prefixedLogger.PrintInfo("  %s", remediation)
+			}
+		}
+	}
+
+	return allGranted
+}
+
+// permissionRemediations - Returns the distinct remediations of the problems,
+// in the order they first appear
+func permissionRemediations(problems []postgres.PermissionProblem) (remediations []string) {
+	seen := make(map[string]bool)
+	for _, problem := range problems {
+		if problem.Remediation == "" || seen[problem.Remediation] {
+			continue
+		}
+		seen[problem.Remediation] = true
+		remediations = append(remediations, problem.Remediation)
+	}
+	return
+}
@@ -18,12 +18,12 @@ func gatherQueryStatsForServer(server state.Server, globalCollectionOpts state.C
 	isHeroku := server.Config.SystemType == "heroku"
 	collectedAt := time.Now()
 
-	connection, err = postgres.EstablishConnection(server, logger, globalCollectionOpts, "")
+	connection, releaseConnection, err := postgres.AcquireConnection(server, logger, globalCollectionOpts)
 	if err != nil {
 		return newState, errors.Wrap(err, "failed to connect to database")
 	}
 
-	defer connection.Close()
+	defer releaseConnection()
 
 	postgresVersion, err := postgres.GetPostgresVersion(logger, connection)
 	if err != nil {
@@ -46,7 +46,7 @@ func gatherQueryStatsForServer(server state.Server, globalCollectionOpts state.C
 		return newState, nil
 	}
 
-	diffedStatementStats := diffStatements(newState.StatementStats, server.PrevState.StatementStats)
+	diffedStatementStats, _ := diffStatements(newState.StatementStats, server.PrevState.StatementStats)
 	collectedIntervalSecs := uint32(newState.LastStatementStatsAt.Sub(server.PrevState.LastStatementStatsAt) / time.Second)
 
 	timeKey := state.PostgresStatementStatsTimeKey{CollectedAt: collectedAt, CollectedIntervalSecs: collectedIntervalSecs}
@@ -60,6 +60,9 @@ func gatherQueryStatsForServer(server state.Server, globalCollectionOpts state.C
 }
 
 func GatherQueryStatsFromAllServers(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) {
+	if globalCollectionOpts.Pause.Paused(logger) {
+		return
+	}
 	for idx, server := range servers {
 		if server.Config.QueryStatsInterval != 60 {
 			continue
@@ -77,6 +80,9 @@ func GatherQueryStatsFromAllServers(servers []state.Server, globalCollectionOpts
 				go runCompletionCallback("error", server.Config.ErrorCallback, server.Config.SectionName, "query_stats", err, prefixedLogger)
 			}
 		} else {
+			newState.CloudWatchLogsPosition = servers[idx].PrevState.CloudWatchLogsPosition
+			newState.AzureLogsPosition = servers[idx].PrevState.AzureLogsPosition
+			newState.RdsLogsPosition = servers[idx].PrevState.RdsLogsPosition
 			servers[idx].PrevState = newState
 			servers[idx].StateMutex.Unlock()
 			prefixedLogger.PrintVerbose("Successfully collected high frequency query statistics")
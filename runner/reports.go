@@ -138,6 +138,9 @@ func RunTestReport(servers []state.Server, globalCollectionOpts state.Collection
 
 // RunRequestedReports - Retrieves current report requests from the server, runs them and submits their data
 func RunRequestedReports(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) {
+	if globalCollectionOpts.Pause.Paused(logger) {
+		return
+	}
 	for _, server := range servers {
 		if !server.Config.EnableReports {
 			continue
@@ -0,0 +1,61 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/pganalyze/collector/output"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// SetupSnapshotResend - Resends the last snapshots of all servers whenever the
+// collector receives SIGUSR1, until stopped
+func SetupSnapshotResend(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger, wg *sync.WaitGroup) chan<- bool {
+	trigger := make(chan os.Signal, 1)
+	signal.Notify(trigger, syscall.SIGUSR1)
+	return resendOnTrigger(trigger, func() { signal.Stop(trigger) }, servers, globalCollectionOpts, logger, wg)
+}
+
+func resendOnTrigger(trigger <-chan os.Signal, cleanup func(), servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger, wg *sync.WaitGroup) chan<- bool {
+	stop := make(chan bool)
+
+	go func() {
+		defer cleanup()
+		for {
+			select {
+			case <-trigger:
+				wg.Add(1)
+				ResendLastSnapshotsForAllServers(servers, globalCollectionOpts, logger)
+				wg.Done()
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return stop
+}
+
+// ResendLastSnapshotsForAllServers - Uploads and submits the snapshots that were
+// last sent for each server again, without collecting new data
+func ResendLastSnapshotsForAllServers(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) {
+	for idx := range servers {
+		// The grant gets replaced by full snapshot runs
+		servers[idx].StateMutex.Lock()
+		server := servers[idx]
+		servers[idx].StateMutex.Unlock()
+
+		prefixedLogger := logger.WithPrefix(server.Config.SectionName)
+
+		resent, err := output.ResendLastSnapshots(context.Background(), server, globalCollectionOpts, prefixedLogger)
+		if err != nil {
+			prefixedLogger.PrintError("Could not resend last snapshots: %s", err)
+		} else if !resent {
+			prefixedLogger.PrintInfo("Nothing to resend, no snapshot was sent yet")
+		}
+	}
+}
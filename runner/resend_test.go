@@ -0,0 +1,112 @@
+package runner
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+func TestResendOnTrigger(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	submitted := make(chan string, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		submitted <- r.FormValue("s3_location")
+	}))
+	defer api.Close()
+
+	localDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	cached := state.SentSnapshot{UUID: "4a5f1c5e-0b6c-4f5e-9d3a-6f0c1d2e3f40", CollectedAt: time.Now(), CompressedData: []byte("previously sent snapshot")}
+	server := state.Server{
+		Config:            config.ServerConfig{APIBaseURL: api.URL},
+		Grant:             state.Grant{Valid: true, LocalDir: localDir},
+		StateMutex:        &sync.Mutex{},
+		LastSentSnapshots: &state.LastSentSnapshots{},
+	}
+	server.LastSentSnapshots.RecordFull(cached)
+
+	trigger := make(chan os.Signal, 1)
+	wg := sync.WaitGroup{}
+	stop := resendOnTrigger(trigger, func() {}, []state.Server{server}, state.CollectionOpts{SubmitCollectedData: true}, logger, &wg)
+	defer func() { stop <- true }()
+
+	trigger <- syscall.SIGUSR1
+
+	select {
+	case s3Location := <-submitted:
+		data, err := ioutil.ReadFile(s3Location)
+		if err != nil {
+			t.Fatalf("Could not read uploaded snapshot: %s", err)
+		}
+		if string(data) != string(cached.CompressedData) {
+			t.Errorf("Expected cached snapshot to be uploaded unchanged, got %q", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected last snapshot to be resent")
+	}
+}
+
+func TestResendLastLogSnapshotWithCurrentGrant(t *testing.T) {
+	logger := &util.Logger{Destination: log.New(ioutil.Discard, "", 0)}
+
+	localDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(localDir)
+
+	grantRequests := 0
+	submitted := make(chan string, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/snapshots/grant_logs" {
+			grantRequests++
+			fmt.Fprintf(w, `{"snapshot": {"local_dir": %q}}`, localDir)
+			return
+		}
+		submitted <- r.FormValue("s3_location")
+	}))
+	defer api.Close()
+
+	cached := state.SentSnapshot{UUID: "0c6e2b8e-54a1-4f0e-8d8e-2b7f3c1d9a10", CollectedAt: time.Now(), CompressedData: []byte("previously sent log snapshot")}
+	server := state.Server{
+		Config:            config.ServerConfig{APIBaseURL: api.URL},
+		StateMutex:        &sync.Mutex{},
+		LogsGrantCache:    &state.GrantLogsCache{},
+		LastSentSnapshots: &state.LastSentSnapshots{},
+	}
+	server.LastSentSnapshots.RecordLogs(cached)
+
+	ResendLastSnapshotsForAllServers([]state.Server{server}, state.CollectionOpts{SubmitCollectedData: true}, logger)
+
+	select {
+	case s3Location := <-submitted:
+		data, err := ioutil.ReadFile(s3Location)
+		if err != nil {
+			t.Fatalf("Could not read uploaded snapshot: %s", err)
+		}
+		if string(data) != string(cached.CompressedData) {
+			t.Errorf("Expected cached log snapshot to be uploaded unchanged, got %q", data)
+		}
+	default:
+		t.Fatal("Expected last log snapshot to be resent")
+	}
+	if grantRequests != 1 {
+		t.Errorf("Expected a current logs grant to be requested once, got %d requests", grantRequests)
+	}
+}
@@ -0,0 +1,35 @@
+package runner
+
+import (
+	"github.com/pganalyze/collector/input"
+	"github.com/pganalyze/collector/input/postgres"
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+// SampleWaitEventsFromAllServers - Samples the wait events of all servers that
+// have them enabled, which get sent with the next full snapshot
+func SampleWaitEventsFromAllServers(servers []state.Server, globalCollectionOpts state.CollectionOpts, logger *util.Logger) {
+	if globalCollectionOpts.Pause.Paused(logger) {
+		return
+	}
+	for _, server := range servers {
+		if !server.Config.CollectWaitEvents {
+			continue
+		}
+
+		prefixedLogger := logger.WithPrefix(server.Config.SectionName)
+
+		connection, releaseConnection, err := postgres.AcquireConnection(server, prefixedLogger, globalCollectionOpts)
+		if err != nil {
+			prefixedLogger.PrintWarning("Could not sample wait events, failed to connect to database: %s", err)
+			continue
+		}
+
+		err = input.SampleWaitEvents(server, connection, prefixedLogger)
+		releaseConnection()
+		if err != nil {
+			prefixedLogger.PrintWarning("Could not sample wait events: %s", err)
+		}
+	}
+}
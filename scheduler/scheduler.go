@@ -1,6 +1,8 @@
 package scheduler
 
 import (
+	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/gorhill/cronexpr"
@@ -9,13 +11,49 @@ import (
 
 type Group struct {
 	interval *cronexpr.Expression
+	offset   time.Duration // Delay of all runs, chosen once at startup (see GetSchedulerGroups)
+	jitter   time.Duration // Maximum random delay added to each individual run
+}
+
+// Seeded separately, so that collectors started at the same time don't end up
+// with the same jitter
+var jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+var jitterRandMutex sync.Mutex
+
+// randomDuration - Returns a random duration between zero (inclusive) and max (exclusive)
+var randomDuration = func(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	jitterRandMutex.Lock()
+	defer jitterRandMutex.Unlock()
+	return time.Duration(jitterRand.Int63n(int64(max)))
+}
+
+// scheduledAfter - Returns the next point in time the group is scheduled at,
+// including the startup offset, but not the jitter of individual runs
+func (group Group) scheduledAfter(t time.Time) time.Time {
+	return group.interval.Next(t.Add(-group.offset)).Add(group.offset)
+}
+
+// nextRun - Returns when the group runs next, with a random delay of up to the
+// group's jitter - which is limited to half the interval, so that runs never
+// get skipped or run back to back
+func (group Group) nextRun(t time.Time) time.Time {
+	scheduled := group.scheduledAfter(t)
+	jitter := group.jitter
+	if period := group.scheduledAfter(scheduled).Sub(scheduled); jitter > period/2 {
+		jitter = period / 2
+	}
+	return scheduled.Add(randomDuration(jitter))
 }
 
 func (group Group) Schedule(runner func(), logger *util.Logger, logName string) chan bool {
 	stop := make(chan bool)
 	go func() {
 		for {
-			delay := group.interval.Next(time.Now()).Sub(time.Now())
+			timeNow := time.Now()
+			delay := group.nextRun(timeNow).Sub(timeNow)
 
 			logger.PrintVerbose("Scheduled next run for %s in %+v", logName, delay)
 
@@ -34,13 +72,16 @@ func (group Group) Schedule(runner func(), logger *util.Logger, logName string)
 
 // ScheduleSecondary - Behaves almost like Schedule, but ignores the point in time
 // where the primary group also has a run (to avoid overlapping statistics)
+//
+// Runs of the secondary group don't get any jitter, so that they can be
+// matched up with the runs of the primary group.
 func (group Group) ScheduleSecondary(runner func(), logger *util.Logger, logName string, primaryGroup Group) chan bool {
 	stop := make(chan bool)
 	go func() {
 		for {
 			timeNow := time.Now()
-			delay := group.interval.Next(timeNow).Sub(timeNow)
-			delayPrimary := primaryGroup.interval.Next(timeNow).Sub(timeNow)
+			delay := group.scheduledAfter(timeNow).Sub(timeNow)
+			delayPrimary := primaryGroup.scheduledAfter(timeNow).Sub(timeNow)
 
 			// Make sure to not run more often than once a second - this can happen
 			// due to rounding errors in the interval logic
@@ -66,7 +107,12 @@ func (group Group) ScheduleSecondary(runner func(), logger *util.Logger, logName
 	return stop
 }
 
-func GetSchedulerGroups() (groups map[string]Group, err error) {
+// GetSchedulerGroups - Returns the groups that collection runs are scheduled in
+//
+// To spread out the load on the pganalyze API when many collectors got started
+// at the same time, all groups get delayed by the same random offset of up to
+// startupJitter, and each run gets delayed by up to cycleJitter.
+func GetSchedulerGroups(startupJitter time.Duration, cycleJitter time.Duration) (groups map[string]Group, err error) {
 	tenSecondInterval, err := cronexpr.Parse("*/10 * * * * * *")
 	if err != nil {
 		return
@@ -87,13 +133,16 @@ func GetSchedulerGroups() (groups map[string]Group, err error) {
 		return
 	}
 
+	offset := randomDuration(startupJitter)
+
 	groups = make(map[string]Group)
 
-	groups["stats"] = Group{interval: tenMinuteInterval}
-	groups["reports"] = Group{interval: oneMinuteInterval}
-	groups["logs"] = Group{interval: thirtySecondInterval}
-	groups["activity"] = Group{interval: tenSecondInterval}
-	groups["query_stats"] = Group{interval: oneMinuteInterval}
+	groups["stats"] = Group{interval: tenMinuteInterval, offset: offset, jitter: cycleJitter}
+	groups["reports"] = Group{interval: oneMinuteInterval, offset: offset, jitter: cycleJitter}
+	groups["logs"] = Group{interval: thirtySecondInterval, offset: offset, jitter: cycleJitter}
+	groups["activity"] = Group{interval: tenSecondInterval, offset: offset, jitter: cycleJitter}
+	groups["query_stats"] = Group{interval: oneMinuteInterval, offset: offset}
+	groups["wait_events"] = Group{interval: oneMinuteInterval, offset: offset, jitter: cycleJitter}
 
 	return
 }
@@ -6,7 +6,7 @@ import (
 )
 
 func TestScheduler(t *testing.T) {
-	groups, err := GetSchedulerGroups()
+	groups, err := GetSchedulerGroups(0, 0)
 	if err != nil {
 		t.Errorf("Error: %v\n", err)
 	}
@@ -18,4 +18,47 @@ func TestScheduler(t *testing.T) {
 	if expectedNextRun != actualNextRun {
 		t.Errorf("\nNext run:\n\texpected %s\n\tactual %s\n\n", expectedNextRun, actualNextRun)
 	}
+	if nextRun := groups["stats"].nextRun(someTime); nextRun != expectedNextRun {
+		t.Errorf("\nNext run without jitter:\n\texpected %s\n\tactual %s\n\n", expectedNextRun, nextRun)
+	}
+}
+
+var schedulerJitterTests = []struct {
+	group       string
+	startTime   time.Time
+	earliestRun time.Time
+	latestRun   time.Time // Exclusive
+}{
+	// Offset of 20s, plus up to 5s of jitter
+	{"stats", time.Date(2013, 1, 1, 0, 5, 0, 0, time.UTC), time.Date(2013, 1, 1, 0, 10, 20, 0, time.UTC), time.Date(2013, 1, 1, 0, 10, 25, 0, time.UTC)},
+	// Still before the offset run of the current interval
+	{"stats", time.Date(2013, 1, 1, 0, 10, 10, 0, time.UTC), time.Date(2013, 1, 1, 0, 10, 20, 0, time.UTC), time.Date(2013, 1, 1, 0, 10, 25, 0, time.UTC)},
+	{"stats", time.Date(2013, 1, 1, 0, 10, 21, 0, time.UTC), time.Date(2013, 1, 1, 0, 20, 20, 0, time.UTC), time.Date(2013, 1, 1, 0, 20, 25, 0, time.UTC)},
+	// The offset exceeds the 10 second interval, and jitter is limited to half the interval
+	{"activity", time.Date(2013, 1, 1, 0, 5, 1, 0, time.UTC), time.Date(2013, 1, 1, 0, 5, 10, 0, time.UTC), time.Date(2013, 1, 1, 0, 5, 15, 0, time.UTC)},
+	// No jitter for the secondary group, to match up with the primary group
+	{"query_stats", time.Date(2013, 1, 1, 0, 5, 30, 0, time.UTC), time.Date(2013, 1, 1, 0, 6, 20, 0, time.UTC), time.Date(2013, 1, 1, 0, 6, 20, 1, time.UTC)},
+}
+
+func TestSchedulerJitter(t *testing.T) {
+	origRandomDuration := randomDuration
+	defer func() { randomDuration = origRandomDuration }()
+
+	// Pick the maximum startup offset, so the expected run times are known
+	randomDuration = func(max time.Duration) time.Duration { return max - 1 }
+	groups, err := GetSchedulerGroups(20*time.Second+1, 5*time.Second)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	randomDuration = origRandomDuration
+	for _, test := range schedulerJitterTests {
+		for i := 0; i < 100; i++ {
+			nextRun := groups[test.group].nextRun(test.startTime)
+			if nextRun.Before(test.earliestRun) || !nextRun.Before(test.latestRun) {
+				t.Errorf("%s from %s: expected next run between %s and %s, got %s", test.group, test.startTime, test.earliestRun, test.latestRun, nextRun)
+				break
+			}
+		}
+	}
 }
@@ -0,0 +1,82 @@
+package state
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+const defaultLogUploadMaxRetries = 5
+const defaultLogUploadRetryBaseDelay = 10 * time.Second
+
+// Validate - Checks for contradictory option combinations, and fills in defaults
+// for options that were left at their zero value
+func (opts *CollectionOpts) Validate() error {
+	if opts.DebugLogs && !opts.CollectLogs {
+		return fmt.Errorf("debugging logs requires log collection to be enabled")
+	}
+	if opts.TestRunLogs && !opts.CollectLogs {
+		return fmt.Errorf("testing logs requires log collection to be enabled")
+	}
+	if opts.TestExplain && !opts.CollectExplain {
+		return fmt.Errorf("testing EXPLAIN requires EXPLAIN collection to be enabled")
+	}
+	if opts.ForceEmptyGrant && opts.SubmitCollectedData {
+		return fmt.Errorf("collected data can't be submitted without a grant")
+	}
+	if opts.DebugSnapshot && opts.SubmitCollectedData {
+		return fmt.Errorf("debugging the snapshot can't be combined with submitting collected data")
+	}
+	if opts.WriteStateUpdate && opts.StateFilename == "" {
+		return fmt.Errorf("writing state updates requires a state filename")
+	}
+
+	exclusiveModes := 0
+	for _, enabled := range []bool{opts.DebugLogs, opts.DebugSnapshot, opts.DiscoverLogLocation, opts.TestReport != ""} {
+		if enabled {
+			exclusiveModes++
+		}
+	}
+	if exclusiveModes > 1 {
+		return fmt.Errorf("only one of debugging logs, debugging the snapshot, discovering the log location or testing a report can be used at a time")
+	}
+	if opts.CollectOnce && (opts.TestRun || opts.DebugLogs || opts.DebugSnapshot || opts.DiscoverLogLocation) {
+		return fmt.Errorf("collecting once can't be combined with test runs, dry runs or debugging options")
+	}
+
+	if opts.LogUploadMaxRetries < 0 {
+		return fmt.Errorf("log upload max retries can't be negative (got %d)", opts.LogUploadMaxRetries)
+	}
+	if opts.LogUploadRetryBaseDelay < 0 {
+		return fmt.Errorf("log upload retry delay can't be negative (got %s)", opts.LogUploadRetryBaseDelay)
+	}
+	if opts.LogTempDir != "" {
+		if err := checkDirWritable(opts.LogTempDir); err != nil {
+			return fmt.Errorf("log temp directory %s is not writable: %s", opts.LogTempDir, err)
+		}
+	}
+
+	if opts.CollectorApplicationName == "" {
+		opts.CollectorApplicationName = "pganalyze_collector"
+	}
+	if opts.LogUploadMaxRetries == 0 {
+		opts.LogUploadMaxRetries = defaultLogUploadMaxRetries
+	}
+	if opts.LogUploadRetryBaseDelay == 0 {
+		opts.LogUploadRetryBaseDelay = defaultLogUploadRetryBaseDelay
+	}
+
+	return nil
+}
+
+// checkDirWritable - Creates (and removes) a file in the directory, to find
+// problems at startup instead of when logs are first processed
+func checkDirWritable(dir string) error {
+	file, err := ioutil.TempFile(dir, "")
+	if err != nil {
+		return err
+	}
+	file.Close()
+	return os.Remove(file.Name())
+}
@@ -0,0 +1,73 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pganalyze/collector/state"
+)
+
+var invalidCollectionOptsTests = []struct {
+	description string
+	opts        state.CollectionOpts
+}{
+	{"debug logs without log collection", state.CollectionOpts{DebugLogs: true}},
+	{"log test without log collection", state.CollectionOpts{TestRun: true, TestRunLogs: true}},
+	{"explain test without explain collection", state.CollectionOpts{TestRun: true, TestExplain: true}},
+	{"submitting data with forced empty grant", state.CollectionOpts{ForceEmptyGrant: true, SubmitCollectedData: true}},
+	{"state update without state file", state.CollectionOpts{WriteStateUpdate: true}},
+	{"debug logs and log location discovery", state.CollectionOpts{CollectLogs: true, DebugLogs: true, DiscoverLogLocation: true}},
+	{"log location discovery and test report", state.CollectionOpts{DiscoverLogLocation: true, TestReport: "bloat"}},
+	{"debug snapshot while submitting data", state.CollectionOpts{DebugSnapshot: true, SubmitCollectedData: true}},
+	{"debug logs and debug snapshot", state.CollectionOpts{CollectLogs: true, DebugLogs: true, DebugSnapshot: true}},
+	{"collect once with test run", state.CollectionOpts{CollectOnce: true, TestRun: true}},
+	{"negative max retries", state.CollectionOpts{LogUploadMaxRetries: -1}},
+	{"negative retry delay", state.CollectionOpts{LogUploadRetryBaseDelay: -1 * time.Second}},
+	{"missing log temp directory", state.CollectionOpts{LogTempDir: "/nonexistent/pganalyze-collector"}},
+}
+
+func TestCollectionOptsValidateInvalid(t *testing.T) {
+	for _, test := range invalidCollectionOptsTests {
+		opts := test.opts
+		if err := opts.Validate(); err == nil {
+			t.Errorf("Expected %s to be rejected", test.description)
+		}
+	}
+}
+
+var validCollectionOptsTests = []struct {
+	description string
+	opts        state.CollectionOpts
+}{
+	{"regular run", state.CollectionOpts{SubmitCollectedData: true, CollectLogs: true, WriteStateUpdate: true, StateFilename: "/tmp/state"}},
+	{"test run", state.CollectionOpts{SubmitCollectedData: true, TestRun: true, CollectLogs: true}},
+	{"dry run", state.CollectionOpts{TestRun: true, ForceEmptyGrant: true}},
+	{"collect once", state.CollectionOpts{SubmitCollectedData: true, CollectLogs: true, CollectOnce: true, WriteStateUpdate: true, StateFilename: "/tmp/state"}},
+	{"debug logs", state.CollectionOpts{CollectLogs: true, DebugLogs: true}},
+	{"debug snapshot", state.CollectionOpts{TestRun: true, ForceEmptyGrant: true, DebugSnapshot: true}},
+}
+
+func TestCollectionOptsValidateValid(t *testing.T) {
+	for _, test := range validCollectionOptsTests {
+		opts := test.opts
+		if err := opts.Validate(); err != nil {
+			t.Errorf("Expected %s to be valid, got error: %s", test.description, err)
+		}
+	}
+}
+
+func TestCollectionOptsValidateDefaults(t *testing.T) {
+	opts := state.CollectionOpts{LogUploadMaxRetries: 2}
+	if err := opts.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if opts.CollectorApplicationName != "pganalyze_collector" {
+		t.Errorf("Expected default application name, got %q", opts.CollectorApplicationName)
+	}
+	if opts.LogUploadMaxRetries != 2 {
+		t.Errorf("Expected explicitly set max retries to be kept, got %d", opts.LogUploadMaxRetries)
+	}
+	if opts.LogUploadRetryBaseDelay != 10*time.Second {
+		t.Errorf("Expected default retry delay, got %s", opts.LogUploadRetryBaseDelay)
+	}
+}
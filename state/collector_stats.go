@@ -1,5 +1,7 @@
 package state
 
+import "time"
+
 type CollectorStats struct {
 	GoVersion string
 
@@ -11,6 +13,57 @@ type CollectorStats struct {
 	ActiveGoroutines int32
 
 	CgoCalls int64
+
+	LogLinesStitched int64 // Log lines without level and PID that were concatenated onto the previous line
+	LogLinesDropped  int64 // Log lines without level and PID that were dropped, since there was no previous line
+	LogLinesFiltered int64 // Log lines that were not sent because their classification is denied
+
+	LogLinesUnparseable int64 // Dropped log lines that didn't follow any other line, usually due to an unsupported log_line_prefix
+
+	LogLinesOverflowed int64 // Log lines that were dropped because too many lines were waiting to be sent
+
+	QuerySamplesDropped int64 // Query samples that were dropped because too many were collected in a single batch
+
+	UploadedLogBytes      int64 // Bytes of log files uploaded, after compression and encryption
+	UploadedSnapshotBytes int64 // Bytes of snapshots and reports uploaded, after compression
+
+	// Timings of log processing since the previous full snapshot (not running totals)
+	LogProcessing LogProcessingStats
+}
+
+// LogProcessingStats - Time spent in the phases of analyzing and sending log lines
+type LogProcessingStats struct {
+	Stitching DurationStats // Concatenating lines without level and PID, and finding the lines that are ready
+	Analysis  DurationStats // Writing tempfiles, and analyzing the lines of each backend
+	Upload    DurationStats // Getting the grant, uploading and submitting (or the debug/test output instead)
+}
+
+// DurationStats - Summary of the durations of an operation that ran repeatedly
+type DurationStats struct {
+	Count int64
+	Total time.Duration
+	Min   time.Duration
+	Max   time.Duration
+}
+
+// Add - Records another duration
+func (s *DurationStats) Add(d time.Duration) {
+	if s.Count == 0 || d < s.Min {
+		s.Min = d
+	}
+	if d > s.Max {
+		s.Max = d
+	}
+	s.Count++
+	s.Total += d
+}
+
+// Avg - Returns the average duration, or zero if nothing was recorded
+func (s DurationStats) Avg() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
 }
 
 type DiffedCollectorStats CollectorStats
@@ -24,5 +77,14 @@ func (curr CollectorStats) DiffSince(prev CollectorStats) DiffedCollectorStats {
 		MemoryRssBytes:           curr.MemoryRssBytes,
 		ActiveGoroutines:         curr.ActiveGoroutines,
 		CgoCalls:                 curr.CgoCalls - prev.CgoCalls,
+		LogLinesStitched:         curr.LogLinesStitched - prev.LogLinesStitched,
+		LogLinesDropped:          curr.LogLinesDropped - prev.LogLinesDropped,
+		LogLinesFiltered:         curr.LogLinesFiltered - prev.LogLinesFiltered,
+		LogLinesUnparseable:      curr.LogLinesUnparseable - prev.LogLinesUnparseable,
+		LogLinesOverflowed:       curr.LogLinesOverflowed - prev.LogLinesOverflowed,
+		QuerySamplesDropped:      curr.QuerySamplesDropped - prev.QuerySamplesDropped,
+		UploadedLogBytes:         curr.UploadedLogBytes - prev.UploadedLogBytes,
+		UploadedSnapshotBytes:    curr.UploadedSnapshotBytes - prev.UploadedSnapshotBytes,
+		LogProcessing:            curr.LogProcessing,
 	}
 }
@@ -0,0 +1,35 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+)
+
+func TestDurationStats(t *testing.T) {
+	var stats state.DurationStats
+	if stats.Avg() != 0 {
+		t.Errorf("Expected zero average without durations, got %s", stats.Avg())
+	}
+
+	for _, d := range []time.Duration{30 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond} {
+		stats.Add(d)
+	}
+
+	expected := state.DurationStats{Count: 3, Total: 60 * time.Millisecond, Min: 10 * time.Millisecond, Max: 30 * time.Millisecond}
+	if diff := pretty.Compare(expected, stats); diff != "" {
+		t.Errorf("Unexpected stats: (-want +got)\n%s", diff)
+	}
+	if stats.Avg() != 20*time.Millisecond {
+		t.Errorf("Expected average of 20ms, got %s", stats.Avg())
+	}
+
+	curr := state.CollectorStats{LogLinesDropped: 5, LogProcessing: state.LogProcessingStats{Upload: stats}}
+	prev := state.CollectorStats{LogLinesDropped: 2, LogProcessing: state.LogProcessingStats{Upload: state.DurationStats{Count: 10}}}
+	diff := curr.DiffSince(prev)
+	if diff.LogLinesDropped != 3 || diff.LogProcessing != curr.LogProcessing {
+		t.Errorf("Expected counters to be diffed and timings to be passed through, got %+v", diff)
+	}
+}
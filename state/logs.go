@@ -1,7 +1,12 @@
 package state
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/pganalyze/collector/output/pganalyze_collector"
@@ -13,6 +18,106 @@ type GrantLogs struct {
 	Logdata       GrantS3                `json:"logdata"`
 	Snapshot      GrantS3                `json:"snapshot"`
 	EncryptionKey GrantLogsEncryptionKey `json:"encryption_key"`
+
+	APIBaseURL string `json:"-"` // Endpoint the grant was received from, which uploads get submitted to
+}
+
+// ExpiresAt - Returns when the S3 upload policies of the grant expire, or the
+// zero time if that is unknown
+func (g GrantLogs) ExpiresAt() time.Time {
+	var expiresAt time.Time
+	for _, s3 := range []GrantS3{g.Logdata, g.Snapshot} {
+		policyExpiresAt := s3.PolicyExpiresAt()
+		if policyExpiresAt.IsZero() {
+			return time.Time{}
+		}
+		if expiresAt.IsZero() || policyExpiresAt.Before(expiresAt) {
+			expiresAt = policyExpiresAt
+		}
+	}
+	return expiresAt
+}
+
+// PolicyExpiresAt - Returns the expiration of the S3 POST policy contained in
+// the fields, or the zero time if there is no (readable) policy
+func (g GrantS3) PolicyExpiresAt() time.Time {
+	policyJSON, err := base64.StdEncoding.DecodeString(g.S3Fields["policy"])
+	if err != nil {
+		return time.Time{}
+	}
+	var policy struct {
+		Expiration time.Time `json:"expiration"`
+	}
+	err = json.Unmarshal(policyJSON, &policy)
+	if err != nil {
+		return time.Time{}
+	}
+	return policy.Expiration
+}
+
+// Reusable - Whether the upload policies of the grant can be used for more than
+// one upload, see GrantS3.Reusable
+func (g GrantLogs) Reusable() bool {
+	return g.Logdata.Reusable() && g.Snapshot.Reusable()
+}
+
+// Reusable - Whether the S3 POST policy can be used for more than one upload,
+// which is the case when the object key contains "${filename}" (S3 replaces it
+// with the name of each uploaded file) - policies with a fixed key would
+// overwrite the same object on every upload
+func (g GrantS3) Reusable() bool {
+	return strings.Contains(g.S3Fields["key"], "${filename}")
+}
+
+// GrantLogsRefreshMargin - How long before it expires a cached logs grant gets
+// replaced, so uploads that are in progress don't run into the expiry
+const GrantLogsRefreshMargin = 5 * time.Minute
+
+// GrantLogsCache - Keeps the logs grant of a server while it can still be used,
+// to avoid requesting a new grant for every log upload
+//
+// All methods can be called on a nil cache, which never returns a grant.
+type GrantLogsCache struct {
+	mutex     sync.Mutex
+	grant     GrantLogs
+	expiresAt time.Time
+}
+
+// Get - Returns the cached grant, unless there is none or it is close to expiring
+func (c *GrantLogsCache) Get(now time.Time) (GrantLogs, bool) {
+	if c == nil {
+		return GrantLogs{}, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.grant.Valid || !now.Before(c.expiresAt.Add(-GrantLogsRefreshMargin)) {
+		return GrantLogs{}, false
+	}
+	return c.grant, true
+}
+
+// Set - Caches a newly received grant - grants that are not valid, can only be
+// used for a single upload, or don't have a known expiry, clear the cache instead
+func (c *GrantLogsCache) Set(grant GrantLogs) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.expiresAt = grant.ExpiresAt()
+	if !grant.Valid || !grant.Reusable() || c.expiresAt.IsZero() {
+		c.grant = GrantLogs{}
+		c.expiresAt = time.Time{}
+		return
+	}
+	c.grant = grant
+}
+
+// Invalidate - Clears the cache, e.g. after S3 rejected an upload using the grant
+func (c *GrantLogsCache) Invalidate() {
+	c.Set(GrantLogs{})
 }
 
 type GrantLogsEncryptionKey struct {
@@ -28,6 +133,28 @@ type LogState struct {
 	QuerySamples []PostgresQuerySample
 }
 
+// CloudWatchLogsPosition - Where reading logs from a CloudWatch Logs log group
+// left off, so we can continue there on the next run
+type CloudWatchLogsPosition struct {
+	LogStreamName      string // Log stream that was read most recently
+	NextToken          string // Token for continuing to read the log stream
+	LastEventTimestamp int64  // Timestamp (in milliseconds) of the last event that was read, used when the token can't be used
+}
+
+// AzureLogsPosition - Where reading logs from an Azure Log Analytics workspace
+// left off, so we can continue there on the next run
+type AzureLogsPosition struct {
+	LastIngestedAt          time.Time // Ingestion time of the last record that was read
+	RecordsAtLastIngestedAt int       // Records that were read with exactly that ingestion time, to skip when continuing
+}
+
+// RdsLogsPosition - Where reading each Amazon RDS log file left off, so we can
+// continue there on the next run
+type RdsLogsPosition struct {
+	LastDownloadedAt time.Time         // When log files were last downloaded, files not written since then are skipped
+	Markers          map[string]string // Marker for continuing to read each log file, by log file name
+}
+
 // LogFile - Log file that we are uploading for reference in log line metadata
 type LogFile struct {
 	LogLines []LogLine
@@ -40,7 +167,15 @@ type LogFile struct {
 	ByteSize     int64
 	OriginalName string
 
+	// Whether the content was compressed with gzip before it got encrypted and
+	// uploaded (ByteSize is the uncompressed size)
+	Compressed bool
+
 	TmpFile *os.File
+
+	// Content of the file when it is kept in memory, because no tempfile could
+	// be created (only used if TmpFile is nil)
+	Content []byte
 }
 
 // LogLine - "Line" in a PostgreSQL log file - can be multiple lines if they belong together
@@ -52,7 +187,11 @@ type LogLine struct {
 	ByteContentStart int64
 	ByteEnd          int64
 
-	OccurredAt  time.Time
+	OccurredAt time.Time
+	// Resolution of OccurredAt as written to the log - time.Second for %t,
+	// time.Millisecond for %m in log_line_prefix (zero if unknown)
+	OccurredAtPrecision time.Duration
+
 	Username    string
 	Database    string
 	Query       string
@@ -62,6 +201,10 @@ type LogLine struct {
 	// for associating related loglines with each other
 	CollectedAt time.Time
 
+	// Only used for collector-internal bookkeeping, counts the failed attempts at
+	// sending the line, so that each batch of lines has its own retry budget
+	SendFailures int
+
 	LogLevel   pganalyze_collector.LogLineInformation_LogLevel
 	BackendPid int32
 
@@ -74,12 +217,50 @@ type LogLine struct {
 	RelatedPids []int32
 }
 
-func (logFile LogFile) Cleanup() {
-	os.Remove(logFile.TmpFile.Name())
+// OccurredBefore - Whether the line was definitely logged before the given
+// time, taking into account that OccurredAt is truncated to its precision
+func (l LogLine) OccurredBefore(t time.Time) bool {
+	if l.OccurredAtPrecision > 0 {
+		t = t.Truncate(l.OccurredAtPrecision)
+	}
+	return l.OccurredAt.Before(t)
+}
+
+// ReadContent - Returns the content of the temporary file, or the in-memory
+// content if there is no temporary file
+func (logFile LogFile) ReadContent() ([]byte, error) {
+	if logFile.TmpFile == nil {
+		return logFile.Content, nil
+	}
+	return ioutil.ReadFile(logFile.TmpFile.Name())
+}
+
+// Cleanup - Closes and removes the temporary file, calling this multiple times is safe
+func (logFile LogFile) Cleanup() error {
+	if logFile.TmpFile == nil {
+		return nil
+	}
+
+	// The contents are not needed anymore, so closing errors (including the file
+	// having been closed already) don't matter
+	logFile.TmpFile.Close()
+
+	err := os.Remove(logFile.TmpFile.Name())
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
 }
 
-func (ls LogState) Cleanup() {
+// Cleanup - Removes the temporary files of all log files, and returns the first
+// error that occurred (but still tries to remove the remaining files)
+func (ls LogState) Cleanup() error {
+	var firstErr error
 	for _, logFile := range ls.LogFiles {
-		logFile.Cleanup()
+		err := logFile.Cleanup()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
+	return firstErr
 }
@@ -0,0 +1,118 @@
+package state_test
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pganalyze/collector/state"
+)
+
+func TestLogStateCleanup(t *testing.T) {
+	tmpFile, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatalf("Could not create tempfile: %s", err)
+	}
+	logState := state.LogState{LogFiles: []state.LogFile{{TmpFile: tmpFile}, {}}}
+
+	// Repeated calls must not fail, even though the file is already gone
+	for i := 0; i < 2; i++ {
+		err = logState.Cleanup()
+		if err != nil {
+			t.Errorf("Cleanup call %d: unexpected error: %s", i+1, err)
+		}
+	}
+
+	if _, err := os.Stat(tmpFile.Name()); !os.IsNotExist(err) {
+		t.Errorf("Expected tempfile to be removed, got: %v", err)
+	}
+}
+
+func TestLogStateCleanupRemovalFailure(t *testing.T) {
+	// Removing a non-empty directory fails regardless of permissions (which
+	// would not prevent removal when the tests run as root)
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create tempdir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	err = ioutil.WriteFile(filepath.Join(dir, "content"), []byte("x"), 0600)
+	if err != nil {
+		t.Fatalf("Could not write file: %s", err)
+	}
+	file, err := os.Open(dir)
+	if err != nil {
+		t.Fatalf("Could not open tempdir: %s", err)
+	}
+
+	logState := state.LogState{LogFiles: []state.LogFile{{TmpFile: file}}}
+	if err = logState.Cleanup(); err == nil {
+		t.Errorf("Expected error when tempfile can't be removed, got none")
+	}
+}
+
+func grantS3ExpiringAt(expiresAt time.Time) state.GrantS3 {
+	policy := `{"expiration": "` + expiresAt.UTC().Format("2006-01-02T15:04:05.000Z") + `", "conditions": []}`
+	return state.GrantS3{S3Fields: map[string]string{"key": "uploads/${filename}", "policy": base64.StdEncoding.EncodeToString([]byte(policy))}}
+}
+
+func TestGrantLogsCache(t *testing.T) {
+	expiresAt := time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+	grant := state.GrantLogs{Valid: true, Logdata: grantS3ExpiringAt(expiresAt.Add(time.Hour)), Snapshot: grantS3ExpiringAt(expiresAt)}
+
+	if !grant.ExpiresAt().Equal(expiresAt) {
+		t.Errorf("Expected grant to expire with its earliest policy at %s, got %s", expiresAt, grant.ExpiresAt())
+	}
+
+	cache := &state.GrantLogsCache{}
+	cache.Set(grant)
+
+	tests := []struct {
+		now      time.Time
+		expected bool
+	}{
+		{expiresAt.Add(-time.Hour), true},
+		{expiresAt.Add(-state.GrantLogsRefreshMargin - time.Second), true},
+		{expiresAt.Add(-state.GrantLogsRefreshMargin), false},
+		{expiresAt, false},
+		{expiresAt.Add(time.Hour), false},
+	}
+	for _, test := range tests {
+		_, ok := cache.Get(test.now)
+		if ok != test.expected {
+			t.Errorf("At %s: expected cached grant to be returned: %v, got %v", test.now, test.expected, ok)
+		}
+	}
+
+	cache.Invalidate()
+	if _, ok := cache.Get(expiresAt.Add(-time.Hour)); ok {
+		t.Errorf("Expected no grant to be returned after invalidation")
+	}
+
+	cache.Set(grant)
+	cache.Set(state.GrantLogs{})
+	if _, ok := cache.Get(expiresAt.Add(-time.Hour)); ok {
+		t.Errorf("Expected no grant to be returned after an invalid grant was received")
+	}
+
+	cache.Set(state.GrantLogs{Valid: true})
+	if _, ok := cache.Get(expiresAt.Add(-time.Hour)); ok {
+		t.Errorf("Expected a grant without known expiry not to be cached")
+	}
+
+	singleUse := grantS3ExpiringAt(expiresAt)
+	singleUse.S3Fields["key"] = "uploads/logfile"
+	cache.Set(state.GrantLogs{Valid: true, Logdata: singleUse, Snapshot: grantS3ExpiringAt(expiresAt)})
+	if _, ok := cache.Get(expiresAt.Add(-time.Hour)); ok {
+		t.Errorf("Expected a grant with a fixed upload key not to be cached")
+	}
+
+	var nilCache *state.GrantLogsCache
+	nilCache.Set(grant)
+	if _, ok := nilCache.Get(expiresAt.Add(-time.Hour)); ok {
+		t.Errorf("Expected nil cache to never return a grant")
+	}
+}
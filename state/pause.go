@@ -0,0 +1,39 @@
+package state
+
+import (
+	"os"
+	"sync"
+
+	"github.com/pganalyze/collector/util"
+)
+
+// PauseControl - Pauses all collection and sending while a sentinel file exists
+// (e.g. during maintenance windows), without stopping the collector, so that its
+// in-memory state and grant caches are kept
+type PauseControl struct {
+	File string
+
+	mutex  sync.Mutex
+	paused bool
+}
+
+// Paused - Whether collection is paused right now, which gets checked before
+// every run, logging when collection gets paused or resumed
+func (p *PauseControl) Paused(logger *util.Logger) bool {
+	if p == nil || p.File == "" {
+		return false
+	}
+
+	_, err := os.Stat(p.File)
+	paused := err == nil
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if paused && !p.paused {
+		logger.PrintInfo("Pausing collection, since %s exists - remove it to resume", p.File)
+	} else if !paused && p.paused {
+		logger.PrintInfo("Resuming collection, since %s was removed", p.File)
+	}
+	p.paused = paused
+	return paused
+}
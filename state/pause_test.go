@@ -0,0 +1,61 @@
+package state_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pganalyze/collector/state"
+	"github.com/pganalyze/collector/util"
+)
+
+func TestPauseControl(t *testing.T) {
+	var output bytes.Buffer
+	logger := &util.Logger{Destination: log.New(&output, "", 0)}
+
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	pauseFile := filepath.Join(dir, "paused")
+
+	var unset *state.PauseControl
+	if unset.Paused(logger) {
+		t.Errorf("Expected collection without pause control to never be paused")
+	}
+
+	pause := &state.PauseControl{File: pauseFile}
+	steps := []struct {
+		createFile     bool
+		expectedPaused bool
+		expectedLog    string
+	}{
+		{false, false, ""},
+		{true, true, "Pausing collection, since " + pauseFile + " exists"},
+		{true, true, ""}, // Transitions are only logged once
+		{false, false, "Resuming collection, since " + pauseFile + " was removed"},
+		{false, false, ""},
+	}
+	for idx, step := range steps {
+		if step.createFile {
+			ioutil.WriteFile(pauseFile, nil, 0600)
+		} else {
+			os.Remove(pauseFile)
+		}
+		output.Reset()
+
+		if paused := pause.Paused(logger); paused != step.expectedPaused {
+			t.Errorf("Step %d: expected paused to be %v, got %v", idx, step.expectedPaused, paused)
+		}
+		if step.expectedLog == "" && output.Len() != 0 {
+			t.Errorf("Step %d: expected nothing to be logged, got %q", idx, output.String())
+		} else if !strings.Contains(output.String(), step.expectedLog) {
+			t.Errorf("Step %d: expected %q to be logged, got %q", idx, step.expectedLog, output.String())
+		}
+	}
+}
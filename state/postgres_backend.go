@@ -41,6 +41,13 @@ type PostgresBackend struct {
 	State null.String
 }
 
+// PostgresBackendCounts - Number of backends, grouped by database, role, state and type
+type PostgresBackendCounts []PostgresBackendCount
+
+// DiffedPostgresBackendCounts - Change in the number of backends per state
+// (e.g. "idle in transaction") since the last run
+type DiffedPostgresBackendCounts map[string]int32
+
 type PostgresBackendCount struct {
 	DatabaseOid    null.Int // OID of the database
 	RoleOid        null.Int // OID of the user
@@ -49,3 +56,30 @@ type PostgresBackendCount struct {
 	WaitingForLock bool     // True if this backend is currently waiting on a heavyweight lock
 	Count          int32    // Number of this kind of backends
 }
+
+// CountByState - Returns the total number of backends for each state
+func (counts PostgresBackendCounts) CountByState() map[string]int32 {
+	byState := make(map[string]int32)
+	for _, count := range counts {
+		byState[count.State] += count.Count
+	}
+	return byState
+}
+
+// DiffSince - Calculate the change in backends per state between two runs,
+// states that are only present in one of the runs are counted as zero in the other
+func (curr PostgresBackendCounts) DiffSince(prev PostgresBackendCounts) DiffedPostgresBackendCounts {
+	diff := make(DiffedPostgresBackendCounts)
+
+	prevByState := prev.CountByState()
+	for state, count := range curr.CountByState() {
+		diff[state] = count - prevByState[state]
+	}
+	for state, count := range prevByState {
+		if _, exists := diff[state]; !exists {
+			diff[state] = -count
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,67 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/guregu/null"
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+)
+
+var backendCountsDiffTests = []struct {
+	description string
+	curr        state.PostgresBackendCounts
+	prev        state.PostgresBackendCounts
+	expected    state.DiffedPostgresBackendCounts
+}{
+	{
+		"idle in transaction spike",
+		state.PostgresBackendCounts{
+			{DatabaseOid: null.IntFrom(1), State: "active", BackendType: "client backend", Count: 3},
+			{DatabaseOid: null.IntFrom(1), State: "idle in transaction", BackendType: "client backend", Count: 25},
+			{DatabaseOid: null.IntFrom(2), State: "idle in transaction", BackendType: "client backend", Count: 15},
+		},
+		state.PostgresBackendCounts{
+			{DatabaseOid: null.IntFrom(1), State: "active", BackendType: "client backend", Count: 4},
+			{DatabaseOid: null.IntFrom(1), State: "idle in transaction", BackendType: "client backend", Count: 2},
+		},
+		state.DiffedPostgresBackendCounts{"active": -1, "idle in transaction": 38},
+	},
+	{
+		"state appearing and disappearing",
+		state.PostgresBackendCounts{
+			{State: "idle", BackendType: "client backend", Count: 5},
+		},
+		state.PostgresBackendCounts{
+			{State: "idle in transaction (aborted)", BackendType: "client backend", Count: 2},
+		},
+		state.DiffedPostgresBackendCounts{"idle": 5, "idle in transaction (aborted)": -2},
+	},
+	{
+		"unchanged",
+		state.PostgresBackendCounts{{State: "idle", Count: 5}},
+		state.PostgresBackendCounts{{State: "idle", Count: 5}},
+		state.DiffedPostgresBackendCounts{"idle": 0},
+	},
+	{
+		"no previous run",
+		state.PostgresBackendCounts{{State: "active", Count: 1}},
+		nil,
+		state.DiffedPostgresBackendCounts{"active": 1},
+	},
+	{
+		"no backends",
+		nil,
+		nil,
+		state.DiffedPostgresBackendCounts{},
+	},
+}
+
+func TestPostgresBackendCountsDiffSince(t *testing.T) {
+	for _, test := range backendCountsDiffTests {
+		diff := test.curr.DiffSince(test.prev)
+		if cmp := pretty.Compare(test.expected, diff); cmp != "" {
+			t.Errorf("%s: diff: (-want +got)\n%s", test.description, cmp)
+		}
+	}
+}
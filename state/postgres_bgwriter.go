@@ -0,0 +1,63 @@
+package state
+
+// PostgresBgwriterStats - Counters of the background writer and checkpointer
+//
+// Before Postgres 17 these all come from pg_stat_bgwriter. Since Postgres 17
+// the checkpointer counters are in pg_stat_checkpointer instead, and writes by
+// regular backends are only tracked in pg_stat_io (so BuffersBackend and
+// BuffersBackendFsync stay zero).
+//
+// See https://www.postgresql.org/docs/17/monitoring-stats.html#MONITORING-PG-STAT-CHECKPOINTER-VIEW
+type PostgresBgwriterStats struct {
+	CheckpointsTimed     int64   // Number of scheduled checkpoints that have been performed
+	CheckpointsRequested int64   // Number of requested checkpoints that have been performed
+	CheckpointWriteTime  float64 // Time spent writing files to disk during checkpoints, in milliseconds
+	CheckpointSyncTime   float64 // Time spent synchronizing files to disk during checkpoints, in milliseconds
+	BuffersCheckpoint    int64   // Number of buffers written during checkpoints
+	BuffersClean         int64   // Number of buffers written by the background writer
+	MaxwrittenClean      int64   // Number of times the background writer stopped a cleaning scan because it had written too many buffers
+	BuffersBackend       int64   // Number of buffers written directly by a backend (before Postgres 17)
+	BuffersBackendFsync  int64   // Number of times a backend had to execute its own fsync call (before Postgres 17)
+	BuffersAlloc         int64   // Number of buffers allocated
+}
+
+// DiffedPostgresBgwriterStats - Background writer and checkpointer activity since the last run
+type DiffedPostgresBgwriterStats struct {
+	CheckpointsTimed     int64
+	CheckpointsRequested int64
+	CheckpointWriteTime  float64 // In milliseconds
+	CheckpointSyncTime   float64 // In milliseconds
+	MaxwrittenClean      int64
+	BuffersBackendFsync  int64
+
+	BuffersCheckpointPerSecond float64
+	BuffersCleanPerSecond      float64
+	BuffersBackendPerSecond    float64
+	BuffersAllocPerSecond      float64
+}
+
+// DiffSince - Calculate the background writer and checkpointer activity since the last run
+//
+// If any of the counters went backwards (due to pg_stat_reset_shared), the
+// current values are diffed against zero.
+func (curr PostgresBgwriterStats) DiffSince(prev PostgresBgwriterStats, collectedIntervalSecs uint32) DiffedPostgresBgwriterStats {
+	if curr.CheckpointsTimed < prev.CheckpointsTimed || curr.CheckpointsRequested < prev.CheckpointsRequested ||
+		curr.BuffersCheckpoint < prev.BuffersCheckpoint || curr.BuffersClean < prev.BuffersClean ||
+		curr.BuffersBackend < prev.BuffersBackend || curr.BuffersAlloc < prev.BuffersAlloc {
+		prev = PostgresBgwriterStats{}
+	}
+
+	return DiffedPostgresBgwriterStats{
+		CheckpointsTimed:     curr.CheckpointsTimed - prev.CheckpointsTimed,
+		CheckpointsRequested: curr.CheckpointsRequested - prev.CheckpointsRequested,
+		CheckpointWriteTime:  curr.CheckpointWriteTime - prev.CheckpointWriteTime,
+		CheckpointSyncTime:   curr.CheckpointSyncTime - prev.CheckpointSyncTime,
+		MaxwrittenClean:      curr.MaxwrittenClean - prev.MaxwrittenClean,
+		BuffersBackendFsync:  curr.BuffersBackendFsync - prev.BuffersBackendFsync,
+
+		BuffersCheckpointPerSecond: float64(curr.BuffersCheckpoint-prev.BuffersCheckpoint) / float64(collectedIntervalSecs),
+		BuffersCleanPerSecond:      float64(curr.BuffersClean-prev.BuffersClean) / float64(collectedIntervalSecs),
+		BuffersBackendPerSecond:    float64(curr.BuffersBackend-prev.BuffersBackend) / float64(collectedIntervalSecs),
+		BuffersAllocPerSecond:      float64(curr.BuffersAlloc-prev.BuffersAlloc) / float64(collectedIntervalSecs),
+	}
+}
@@ -0,0 +1,36 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+)
+
+func TestBgwriterStatsDiffSince(t *testing.T) {
+	prev := state.PostgresBgwriterStats{CheckpointsTimed: 10, CheckpointsRequested: 2, CheckpointWriteTime: 1000, CheckpointSyncTime: 50, BuffersCheckpoint: 6000, BuffersClean: 1200, MaxwrittenClean: 3, BuffersBackend: 600, BuffersAlloc: 12000}
+	curr := state.PostgresBgwriterStats{CheckpointsTimed: 11, CheckpointsRequested: 4, CheckpointWriteTime: 1500, CheckpointSyncTime: 60, BuffersCheckpoint: 12000, BuffersClean: 1800, MaxwrittenClean: 4, BuffersBackend: 660, BuffersBackendFsync: 1, BuffersAlloc: 15000}
+
+	expected := state.DiffedPostgresBgwriterStats{
+		CheckpointsTimed:           1,
+		CheckpointsRequested:       2,
+		CheckpointWriteTime:        500,
+		CheckpointSyncTime:         10,
+		MaxwrittenClean:            1,
+		BuffersBackendFsync:        1,
+		BuffersCheckpointPerSecond: 100,
+		BuffersCleanPerSecond:      10,
+		BuffersBackendPerSecond:    1,
+		BuffersAllocPerSecond:      50,
+	}
+	if diff := pretty.Compare(expected, curr.DiffSince(prev, 60)); diff != "" {
+		t.Errorf("Diff: (-want +got)\n%s", diff)
+	}
+
+	// Reset since the last run
+	reset := state.PostgresBgwriterStats{CheckpointsTimed: 1, BuffersCheckpoint: 600, BuffersAlloc: 300}
+	expected = state.DiffedPostgresBgwriterStats{CheckpointsTimed: 1, BuffersCheckpointPerSecond: 10, BuffersAllocPerSecond: 5}
+	if diff := pretty.Compare(expected, reset.DiffSince(curr, 60)); diff != "" {
+		t.Errorf("Diff after reset: (-want +got)\n%s", diff)
+	}
+}
@@ -0,0 +1,8 @@
+package state
+
+// PostgresCustomMetric - Value returned by one of the server's custom metric
+// queries (see config.ServerConfig.CustomMetrics)
+type PostgresCustomMetric struct {
+	Name  string
+	Value float64
+}
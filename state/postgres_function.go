@@ -1,6 +1,13 @@
 package state
 
-import "github.com/guregu/null"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/guregu/null"
+)
 
 // PostgresFunction - Function/Stored Procedure that runs on the PostgreSQL server
 type PostgresFunction struct {
@@ -21,6 +28,94 @@ type PostgresFunction struct {
 	Strict          bool        `json:"strict"`
 	ReturnsSet      bool        `json:"returns_set"`
 	Volatile        string      `json:"volatile"`
+
+	// Hash of all of the above that make up the definition, to detect changes
+	// without having to keep the source around (see HashDefinition)
+	DefinitionHash string `json:"definition_hash"`
+}
+
+// PostgresFunctionKey - Identifies a function across runs by its signature,
+// since the OID changes when it gets dropped and created again
+type PostgresFunctionKey struct {
+	DatabaseOid  Oid
+	SchemaName   string
+	FunctionName string
+	Arguments    string
+}
+
+// Key - Returns the signature of the function
+func (f PostgresFunction) Key() PostgresFunctionKey {
+	return PostgresFunctionKey{DatabaseOid: f.DatabaseOid, SchemaName: f.SchemaName, FunctionName: f.FunctionName, Arguments: f.Arguments}
+}
+
+// HashDefinition - Calculates the hash of the function definition (its source,
+// signature and settings), which changes whenever the function gets replaced
+// with a different definition
+func (f PostgresFunction) HashDefinition() string {
+	fields := []string{
+		f.Language, f.Source, f.SourceBin.String, strconv.FormatBool(f.SourceBin.Valid),
+		strings.Join(f.Config, ","), f.Arguments, f.Result,
+		strconv.FormatBool(f.Aggregate), strconv.FormatBool(f.Window), strconv.FormatBool(f.SecurityDefiner),
+		strconv.FormatBool(f.Leakproof), strconv.FormatBool(f.Strict), strconv.FormatBool(f.ReturnsSet), f.Volatile,
+	}
+	hash := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
+
+// PostgresFunctionSourceChange - Source of a modified function before and after the change
+type PostgresFunctionSourceChange struct {
+	Before string
+	After  string
+}
+
+// DiffedPostgresFunctionDefinitions - Functions that were added, removed or
+// replaced with a different definition since the last run
+type DiffedPostgresFunctionDefinitions struct {
+	Added    []PostgresFunctionKey
+	Removed  []PostgresFunctionKey
+	Modified []PostgresFunctionKey
+
+	// Only set when requested, otherwise changes are known by their hash alone
+	ModifiedSources map[PostgresFunctionKey]PostgresFunctionSourceChange
+}
+
+// DiffFunctionDefinitions - Compares the function definitions of two runs,
+// optionally including the source of modified functions
+//
+// Functions from a previous run without a hash (i.e. from an older collector
+// version) are not reported as modified.
+func DiffFunctionDefinitions(curr []PostgresFunction, prev []PostgresFunction, includeSource bool) (diff DiffedPostgresFunctionDefinitions) {
+	prevFunctions := make(map[PostgresFunctionKey]PostgresFunction)
+	for _, function := range prev {
+		prevFunctions[function.Key()] = function
+	}
+
+	currKeys := make(map[PostgresFunctionKey]bool)
+	for _, function := range curr {
+		key := function.Key()
+		currKeys[key] = true
+
+		prevFunction, exists := prevFunctions[key]
+		if !exists {
+			diff.Added = append(diff.Added, key)
+		} else if prevFunction.DefinitionHash != "" && function.DefinitionHash != prevFunction.DefinitionHash {
+			diff.Modified = append(diff.Modified, key)
+			if includeSource {
+				if diff.ModifiedSources == nil {
+					diff.ModifiedSources = make(map[PostgresFunctionKey]PostgresFunctionSourceChange)
+				}
+				diff.ModifiedSources[key] = PostgresFunctionSourceChange{Before: prevFunction.Source, After: function.Source}
+			}
+		}
+	}
+
+	for _, function := range prev {
+		if !currKeys[function.Key()] {
+			diff.Removed = append(diff.Removed, function.Key())
+		}
+	}
+
+	return
 }
 
 // PostgresFunctionStats - Statistics about a single PostgreSQL function
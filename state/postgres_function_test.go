@@ -0,0 +1,91 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+)
+
+func testFunction(name string, source string) state.PostgresFunction {
+	function := state.PostgresFunction{DatabaseOid: 1, SchemaName: "public", FunctionName: name, Language: "plpgsql", Arguments: "id integer", Result: "integer", Source: source}
+	function.DefinitionHash = function.HashDefinition()
+	return function
+}
+
+var keyA = state.PostgresFunctionKey{DatabaseOid: 1, SchemaName: "public", FunctionName: "a", Arguments: "id integer"}
+var keyB = state.PostgresFunctionKey{DatabaseOid: 1, SchemaName: "public", FunctionName: "b", Arguments: "id integer"}
+var keyC = state.PostgresFunctionKey{DatabaseOid: 1, SchemaName: "public", FunctionName: "c", Arguments: "id integer"}
+
+var functionDefinitionDiffTests = []struct {
+	curr          []state.PostgresFunction
+	prev          []state.PostgresFunction
+	includeSource bool
+	expected      state.DiffedPostgresFunctionDefinitions
+}{
+	// Nothing changed
+	{
+		[]state.PostgresFunction{testFunction("a", "BEGIN RETURN 1; END")},
+		[]state.PostgresFunction{testFunction("a", "BEGIN RETURN 1; END")},
+		false,
+		state.DiffedPostgresFunctionDefinitions{},
+	},
+	// Modified (hash only), added and dropped
+	{
+		[]state.PostgresFunction{testFunction("a", "BEGIN RETURN 2; END"), testFunction("c", "BEGIN RETURN 3; END")},
+		[]state.PostgresFunction{testFunction("a", "BEGIN RETURN 1; END"), testFunction("b", "BEGIN RETURN 1; END")},
+		false,
+		state.DiffedPostgresFunctionDefinitions{
+			Added:    []state.PostgresFunctionKey{keyC},
+			Removed:  []state.PostgresFunctionKey{keyB},
+			Modified: []state.PostgresFunctionKey{keyA},
+		},
+	},
+	// Modified with the source requested
+	{
+		[]state.PostgresFunction{testFunction("a", "BEGIN RETURN 2; END")},
+		[]state.PostgresFunction{testFunction("a", "BEGIN RETURN 1; END")},
+		true,
+		state.DiffedPostgresFunctionDefinitions{
+			Modified:        []state.PostgresFunctionKey{keyA},
+			ModifiedSources: map[state.PostgresFunctionKey]state.PostgresFunctionSourceChange{keyA: {Before: "BEGIN RETURN 1; END", After: "BEGIN RETURN 2; END"}},
+		},
+	},
+	// Overloaded function with different arguments is a different function
+	{
+		[]state.PostgresFunction{testFunction("a", "BEGIN RETURN 1; END"), {DatabaseOid: 1, SchemaName: "public", FunctionName: "a", Arguments: "", DefinitionHash: "x"}},
+		[]state.PostgresFunction{testFunction("a", "BEGIN RETURN 1; END")},
+		false,
+		state.DiffedPostgresFunctionDefinitions{
+			Added: []state.PostgresFunctionKey{{DatabaseOid: 1, SchemaName: "public", FunctionName: "a"}},
+		},
+	},
+	// State from an older version without hashes
+	{
+		[]state.PostgresFunction{testFunction("a", "BEGIN RETURN 2; END")},
+		[]state.PostgresFunction{{DatabaseOid: 1, SchemaName: "public", FunctionName: "a", Arguments: "id integer", Source: "BEGIN RETURN 1; END"}},
+		false,
+		state.DiffedPostgresFunctionDefinitions{},
+	},
+}
+
+func TestDiffFunctionDefinitions(t *testing.T) {
+	for idx, test := range functionDefinitionDiffTests {
+		actual := state.DiffFunctionDefinitions(test.curr, test.prev, test.includeSource)
+		if diff := pretty.Compare(test.expected, actual); diff != "" {
+			t.Errorf("Test %d: result diff: (-want +got)\n%s", idx, diff)
+		}
+	}
+}
+
+func TestHashDefinition(t *testing.T) {
+	function := testFunction("a", "BEGIN RETURN 1; END")
+	if function.DefinitionHash != testFunction("a", "BEGIN RETURN 1; END").HashDefinition() {
+		t.Errorf("Expected the same definition to have the same hash")
+	}
+
+	function.Volatile = "i"
+	if function.HashDefinition() == function.DefinitionHash {
+		t.Errorf("Expected a changed volatility to change the hash")
+	}
+}
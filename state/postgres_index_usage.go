@@ -0,0 +1,67 @@
+package state
+
+import "time"
+
+// PostgresIndexUnused - Tracks since when an index has had no scans at all
+// (since the last statistics reset), to find candidates for removal
+type PostgresIndexUnused struct {
+	Since time.Time
+
+	// Identifies the index in addition to its Oid, in case the Oid gets reused
+	// by a different index after the original one was dropped
+	RelationOid Oid
+	IndexDef    string
+
+	// Database the index is in, so that it can be kept while the database
+	// couldn't be collected
+	DatabaseOid Oid
+}
+
+type PostgresIndexUnusedMap map[Oid]PostgresIndexUnused
+
+// TrackUnusedIndexes - Returns the indexes that currently have no scans,
+// together with the time they were first seen without scans
+//
+// Indexes keep the time from the previous run, unless they were scanned since
+// (in which case they are left out), or a different index took over the Oid
+// (in which case the time starts over). Indexes in databases that were not
+// collected this time (e.g. since connecting failed) are kept as they were.
+func TrackUnusedIndexes(relations []PostgresRelation, stats PostgresIndexStatsMap, collectedDatabaseOids []Oid, prev PostgresIndexUnusedMap, now time.Time) PostgresIndexUnusedMap {
+	unused := make(PostgresIndexUnusedMap)
+
+	collected := make(map[Oid]bool)
+	for _, databaseOid := range collectedDatabaseOids {
+		collected[databaseOid] = true
+	}
+	for indexOid, prevEntry := range prev {
+		// Entries from before the database was tracked are treated as collected
+		if prevEntry.DatabaseOid != 0 && !collected[prevEntry.DatabaseOid] {
+			unused[indexOid] = prevEntry
+		}
+	}
+
+	for _, relation := range relations {
+		for _, index := range relation.Indices {
+			indexStats, exists := stats[index.IndexOid]
+			if !exists || indexStats.IdxScan > 0 {
+				continue
+			}
+
+			entry := PostgresIndexUnused{Since: now, RelationOid: index.RelationOid, IndexDef: index.IndexDef, DatabaseOid: relation.DatabaseOid}
+			if prevEntry, ok := prev[index.IndexOid]; ok && prevEntry.RelationOid == entry.RelationOid && prevEntry.IndexDef == entry.IndexDef {
+				entry.Since = prevEntry.Since
+			}
+			unused[index.IndexOid] = entry
+		}
+	}
+	return unused
+}
+
+// UnusedFor - Returns how long the index has been unused, zero if it was scanned
+func (unused PostgresIndexUnusedMap) UnusedFor(indexOid Oid, now time.Time) time.Duration {
+	entry, ok := unused[indexOid]
+	if !ok {
+		return 0
+	}
+	return now.Sub(entry.Since)
+}
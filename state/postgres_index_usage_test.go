@@ -0,0 +1,79 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+)
+
+func TestTrackUnusedIndexes(t *testing.T) {
+	first := time.Date(2018, time.September, 28, 7, 0, 0, 0, time.UTC)
+	second := first.Add(10 * time.Minute)
+	third := second.Add(10 * time.Minute)
+
+	relations := []state.PostgresRelation{{
+		Oid: 1,
+		Indices: []state.PostgresIndex{
+			{RelationOid: 1, IndexOid: 11, IndexDef: "CREATE INDEX a ON t USING btree (a)"},
+			{RelationOid: 1, IndexOid: 12, IndexDef: "CREATE INDEX b ON t USING btree (b)"},
+		},
+	}}
+	stats := state.PostgresIndexStatsMap{11: {IdxScan: 0}, 12: {IdxScan: 5}}
+
+	// First run: only the index without scans is tracked, starting now
+	unused := state.TrackUnusedIndexes(relations, stats, nil, nil, first)
+	expected := state.PostgresIndexUnusedMap{11: {Since: first, RelationOid: 1, IndexDef: "CREATE INDEX a ON t USING btree (a)"}}
+	if diff := pretty.Compare(expected, unused); diff != "" {
+		t.Errorf("First run: unused indexes diff: (-want +got)\n%s", diff)
+	}
+
+	// Still unused: the time from the first run is kept
+	unused = state.TrackUnusedIndexes(relations, stats, nil, unused, second)
+	if diff := pretty.Compare(expected, unused); diff != "" {
+		t.Errorf("Second run: unused indexes diff: (-want +got)\n%s", diff)
+	}
+	if unusedFor := unused.UnusedFor(11, second); unusedFor != 10*time.Minute {
+		t.Errorf("Expected index to be unused for 10m, got %s", unusedFor)
+	}
+	if unusedFor := unused.UnusedFor(12, second); unusedFor != 0 {
+		t.Errorf("Expected scanned index to not be unused, got %s", unusedFor)
+	}
+
+	// Scanned since: no longer tracked
+	scanned := state.TrackUnusedIndexes(relations, state.PostgresIndexStatsMap{11: {IdxScan: 1}, 12: {IdxScan: 5}}, nil, unused, third)
+	if len(scanned) != 0 {
+		t.Errorf("Expected no unused indexes after a scan, got %v", scanned)
+	}
+
+	// Oid reused by a different index: the time starts over
+	recreated := []state.PostgresRelation{{
+		Oid:     2,
+		Indices: []state.PostgresIndex{{RelationOid: 2, IndexOid: 11, IndexDef: "CREATE INDEX c ON u USING btree (c)"}},
+	}}
+	unused = state.TrackUnusedIndexes(recreated, state.PostgresIndexStatsMap{11: {IdxScan: 0}}, nil, unused, third)
+	expected = state.PostgresIndexUnusedMap{11: {Since: third, RelationOid: 2, IndexDef: "CREATE INDEX c ON u USING btree (c)"}}
+	if diff := pretty.Compare(expected, unused); diff != "" {
+		t.Errorf("Recreated index: unused indexes diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestTrackUnusedIndexesDatabaseNotCollected(t *testing.T) {
+	first := time.Date(2018, time.September, 28, 7, 0, 0, 0, time.UTC)
+	second := first.Add(10 * time.Minute)
+
+	relations := []state.PostgresRelation{
+		{Oid: 1, DatabaseOid: 100, Indices: []state.PostgresIndex{{RelationOid: 1, IndexOid: 11, IndexDef: "CREATE INDEX a ON t USING btree (a)"}}},
+		{Oid: 2, DatabaseOid: 200, Indices: []state.PostgresIndex{{RelationOid: 2, IndexOid: 21, IndexDef: "CREATE INDEX b ON u USING btree (b)"}}},
+	}
+	stats := state.PostgresIndexStatsMap{11: {IdxScan: 0}, 21: {IdxScan: 0}}
+	unused := state.TrackUnusedIndexes(relations, stats, []state.Oid{100, 200}, nil, first)
+
+	// Database 200 could not be collected: its index keeps the time from the first run
+	unused = state.TrackUnusedIndexes(relations[:1], state.PostgresIndexStatsMap{11: {IdxScan: 2}}, []state.Oid{100}, unused, second)
+	expected := state.PostgresIndexUnusedMap{21: {Since: first, RelationOid: 2, IndexDef: "CREATE INDEX b ON u USING btree (b)", DatabaseOid: 200}}
+	if diff := pretty.Compare(expected, unused); diff != "" {
+		t.Errorf("Unused indexes diff: (-want +got)\n%s", diff)
+	}
+}
@@ -0,0 +1,69 @@
+package state
+
+// PostgresIOStatsKey - Identifies a row of pg_stat_io (Postgres 16+)
+type PostgresIOStatsKey struct {
+	BackendType string // Type of backend (e.g. "client backend" or "checkpointer")
+	Object      string // Target object of the I/O operations (e.g. "relation")
+	Context     string // Context of the I/O operations (e.g. "normal" or "vacuum")
+}
+
+// PostgresIOStats - I/O operations for a combination of backend type, object and context
+//
+// See https://www.postgresql.org/docs/16/monitoring-stats.html#MONITORING-PG-STAT-IO-VIEW
+type PostgresIOStats struct {
+	Reads      int64   // Number of read operations
+	ReadTime   float64 // Time spent in read operations in milliseconds (if track_io_timing is enabled, otherwise zero)
+	Writes     int64   // Number of write operations
+	WriteTime  float64 // Time spent in write operations in milliseconds (if track_io_timing is enabled, otherwise zero)
+	Extends    int64   // Number of relation extend operations
+	ExtendTime float64 // Time spent in extend operations in milliseconds (if track_io_timing is enabled, otherwise zero)
+	Hits       int64   // Number of times a desired block was found in a shared buffer
+	Evictions  int64   // Number of times a block has been written out from a shared or local buffer in order to make it available for another use
+}
+
+type PostgresIOStatsMap map[PostgresIOStatsKey]PostgresIOStats
+
+// DiffedPostgresIOStats - I/O activity of a backend type since the last run
+type DiffedPostgresIOStats struct {
+	ReadsPerSecond   float64
+	WritesPerSecond  float64
+	ExtendsPerSecond float64
+	HitsPerSecond    float64
+
+	ReadTime   float64 // Time spent in read operations since the last run, in milliseconds
+	WriteTime  float64 // Time spent in write operations since the last run, in milliseconds
+	ExtendTime float64 // Time spent in extend operations since the last run, in milliseconds
+}
+
+// DiffedPostgresIOStatsMap - I/O activity since the last run, by backend type
+type DiffedPostgresIOStatsMap map[string]DiffedPostgresIOStats
+
+// DiffSince - Calculate the I/O activity since the last run, grouped by backend type
+//
+// Rows that are new, or whose counters went backwards (due to pg_stat_reset_shared('io')),
+// are diffed against zero.
+func (curr PostgresIOStatsMap) DiffSince(prev PostgresIOStatsMap, collectedIntervalSecs uint32) DiffedPostgresIOStatsMap {
+	diff := make(DiffedPostgresIOStatsMap)
+	if len(prev) == 0 {
+		return diff
+	}
+
+	for key, stats := range curr {
+		prevStats := prev[key]
+		if stats.Reads < prevStats.Reads || stats.Writes < prevStats.Writes || stats.Extends < prevStats.Extends || stats.Hits < prevStats.Hits {
+			prevStats = PostgresIOStats{}
+		}
+
+		d := diff[key.BackendType]
+		d.ReadsPerSecond += float64(stats.Reads-prevStats.Reads) / float64(collectedIntervalSecs)
+		d.WritesPerSecond += float64(stats.Writes-prevStats.Writes) / float64(collectedIntervalSecs)
+		d.ExtendsPerSecond += float64(stats.Extends-prevStats.Extends) / float64(collectedIntervalSecs)
+		d.HitsPerSecond += float64(stats.Hits-prevStats.Hits) / float64(collectedIntervalSecs)
+		d.ReadTime += stats.ReadTime - prevStats.ReadTime
+		d.WriteTime += stats.WriteTime - prevStats.WriteTime
+		d.ExtendTime += stats.ExtendTime - prevStats.ExtendTime
+		diff[key.BackendType] = d
+	}
+
+	return diff
+}
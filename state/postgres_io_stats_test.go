@@ -0,0 +1,37 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+)
+
+func TestIOStatsDiffSince(t *testing.T) {
+	normal := state.PostgresIOStatsKey{BackendType: "client backend", Object: "relation", Context: "normal"}
+	vacuum := state.PostgresIOStatsKey{BackendType: "client backend", Object: "relation", Context: "vacuum"}
+	checkpointer := state.PostgresIOStatsKey{BackendType: "checkpointer", Object: "relation", Context: "normal"}
+
+	prev := state.PostgresIOStatsMap{
+		normal:       {Reads: 100, ReadTime: 10, Hits: 1000},
+		vacuum:       {Reads: 50},
+		checkpointer: {Writes: 500, WriteTime: 100},
+	}
+	curr := state.PostgresIOStatsMap{
+		normal:       {Reads: 160, ReadTime: 16, Hits: 1600, Extends: 10},
+		vacuum:       {Reads: 110},
+		checkpointer: {Writes: 20, WriteTime: 2}, // Reset since the last run
+	}
+
+	expected := state.DiffedPostgresIOStatsMap{
+		"client backend": {ReadsPerSecond: 2, ExtendsPerSecond: 0.16666666666666666, HitsPerSecond: 10, ReadTime: 6},
+		"checkpointer":   {WritesPerSecond: 0.3333333333333333, WriteTime: 2},
+	}
+	if diff := pretty.Compare(expected, curr.DiffSince(prev, 60)); diff != "" {
+		t.Errorf("Diff: (-want +got)\n%s", diff)
+	}
+
+	if diff := curr.DiffSince(nil, 60); len(diff) != 0 {
+		t.Errorf("Expected no diff on the first run, got %v", diff)
+	}
+}
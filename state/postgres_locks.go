@@ -0,0 +1,157 @@
+package state
+
+import (
+	"sort"
+
+	"github.com/guregu/null"
+)
+
+// PostgresLocks - Locks that backends were waiting for at the time of the
+// snapshot, and the blocking relationships between backends derived from them
+//
+// These are point-in-time values, and are therefore not diffed between runs.
+type PostgresLocks struct {
+	Waits         []PostgresLockWait
+	BlockingTrees []PostgresBlockingNode
+}
+
+// PostgresLockWait - Lock that a backend is waiting for, together with the
+// backends that block it (as reported by pg_blocking_pids)
+type PostgresLockWait struct {
+	Pid          int32
+	LockType     string
+	Mode         string
+	DatabaseOid  Oid // Zero for locks on shared objects and those not related to a database
+	RelationOid  Oid // Zero unless the lock is on a relation
+	BlockingPids []int32
+}
+
+// PostgresLockBackend - Activity of a backend that's involved in lock waits
+type PostgresLockBackend struct {
+	Pid   int32
+	State null.String
+	Query null.String // Can be empty or NULL when the monitoring user lacks permissions
+}
+
+// PostgresBlockingNode - Backend in a blocking tree, together with the
+// backends that are waiting for it
+//
+// The roots of the trees are the backends that block others without waiting
+// for a lock themselves. Each backend appears under its first blocker only
+// (the complete blocking relationships are in the lock waits), since a queue
+// of waiters that each block the ones behind them would otherwise make the
+// trees grow exponentially.
+type PostgresBlockingNode struct {
+	Pid   int32
+	State null.String
+	Query null.String
+
+	// Lock this backend is waiting for (empty for the root of a tree)
+	WaitingForMode     string
+	WaitingForRelation Oid
+
+	// Set when this backend already appears further up in the tree, i.e. the
+	// backends form a cycle (a deadlock Postgres has not resolved yet) - the
+	// backends it blocks are not repeated in that case
+	Cycle bool
+
+	Blocked []PostgresBlockingNode
+}
+
+// Maximum number of backends included in the blocking trees of a snapshot
+const maxBlockingTreeNodes = 1000
+
+// BuildBlockingTrees - Constructs the blocking trees from the lock waits, with
+// the backends that are not waiting for a lock as the roots
+//
+// Cycles without such a backend are still included, rooted at their lowest
+// PID. Trees and the backends in them are ordered by PID.
+func BuildBlockingTrees(waits []PostgresLockWait, backends []PostgresLockBackend) []PostgresBlockingNode {
+	backendsByPid := make(map[int32]PostgresLockBackend, len(backends))
+	for _, backend := range backends {
+		backendsByPid[backend.Pid] = backend
+	}
+	waitsByPid := make(map[int32]PostgresLockWait, len(waits))
+	parentByPid := make(map[int32]int32)
+	blockedByPid := make(map[int32][]int32)
+	for _, wait := range waits {
+		waitsByPid[wait.Pid] = wait
+		if len(wait.BlockingPids) > 0 {
+			parentByPid[wait.Pid] = wait.BlockingPids[0]
+			blockedByPid[wait.BlockingPids[0]] = append(blockedByPid[wait.BlockingPids[0]], wait.Pid)
+		}
+	}
+
+	var blockingPids []int32
+	for pid, blocked := range blockedByPid {
+		sort.Slice(blocked, func(i, j int) bool { return blocked[i] < blocked[j] })
+		blockingPids = append(blockingPids, pid)
+	}
+	sort.Slice(blockingPids, func(i, j int) bool { return blockingPids[i] < blockingPids[j] })
+
+	// Since each backend has a single parent, a backend can only be reached
+	// twice by going around a cycle
+	visited := make(map[int32]bool)
+	nodeCount := 0
+	var build func(pid int32) PostgresBlockingNode
+	build = func(pid int32) PostgresBlockingNode {
+		nodeCount++
+		backend := backendsByPid[pid]
+		node := PostgresBlockingNode{Pid: pid, State: backend.State, Query: backend.Query}
+		if wait, ok := waitsByPid[pid]; ok {
+			node.WaitingForMode = wait.Mode
+			node.WaitingForRelation = wait.RelationOid
+		}
+		if visited[pid] {
+			node.Cycle = true
+			return node
+		}
+		visited[pid] = true
+		for _, blockedPid := range blockedByPid[pid] {
+			if nodeCount >= maxBlockingTreeNodes {
+				break
+			}
+			node.Blocked = append(node.Blocked, build(blockedPid))
+		}
+		return node
+	}
+
+	var trees []PostgresBlockingNode
+	for _, pid := range blockingPids {
+		if _, waiting := waitsByPid[pid]; !waiting && nodeCount < maxBlockingTreeNodes {
+			trees = append(trees, build(pid))
+		}
+	}
+	for _, pid := range blockingPids {
+		if visited[pid] || nodeCount >= maxBlockingTreeNodes {
+			continue
+		}
+		// Backends that are not reachable from a root are part of a cycle, or
+		// blocked by one - follow the blockers to the cycle, and root the tree
+		// at its lowest PID
+		root := pid
+		seen := make(map[int32]bool)
+		for {
+			if seen[root] {
+				cycleStart := root
+				for next := parentByPid[cycleStart]; next != cycleStart; next = parentByPid[next] {
+					if next < root {
+						root = next
+					}
+				}
+				break
+			}
+			seen[root] = true
+			parent, ok := parentByPid[root]
+			if !ok {
+				break
+			}
+			root = parent
+		}
+		if !visited[root] {
+			trees = append(trees, build(root))
+		}
+	}
+
+	return trees
+}
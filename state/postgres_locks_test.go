@@ -0,0 +1,134 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/guregu/null"
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+)
+
+func TestBuildBlockingTrees(t *testing.T) {
+	// 100 holds a lock that 200 and 400 wait for, and 300 waits for 200 (400 is
+	// also blocked by 200, but only appears under its first blocker)
+	waits := []state.PostgresLockWait{
+		{Pid: 300, LockType: "transactionid", Mode: "ShareLock", BlockingPids: []int32{200}},
+		{Pid: 200, LockType: "relation", Mode: "AccessExclusiveLock", RelationOid: 16384, BlockingPids: []int32{100}},
+		{Pid: 400, LockType: "relation", Mode: "RowExclusiveLock", RelationOid: 16384, BlockingPids: []int32{100, 200}},
+	}
+	backends := []state.PostgresLockBackend{
+		{Pid: 100, State: null.StringFrom("idle in transaction"), Query: null.StringFrom("SELECT * FROM users")},
+		{Pid: 200, State: null.StringFrom("active"), Query: null.StringFrom("ALTER TABLE users ADD COLUMN age int")},
+		{Pid: 300, State: null.StringFrom("active"), Query: null.StringFrom("UPDATE accounts SET balance = 0")},
+	}
+
+	expected := []state.PostgresBlockingNode{{
+		Pid:   100,
+		State: null.StringFrom("idle in transaction"),
+		Query: null.StringFrom("SELECT * FROM users"),
+		Blocked: []state.PostgresBlockingNode{{
+			Pid: 200, State: null.StringFrom("active"), Query: null.StringFrom("ALTER TABLE users ADD COLUMN age int"),
+			WaitingForMode: "AccessExclusiveLock", WaitingForRelation: 16384,
+			Blocked: []state.PostgresBlockingNode{
+				{Pid: 300, State: null.StringFrom("active"), Query: null.StringFrom("UPDATE accounts SET balance = 0"), WaitingForMode: "ShareLock"},
+			},
+		}, {
+			Pid: 400, WaitingForMode: "RowExclusiveLock", WaitingForRelation: 16384,
+		}},
+	}}
+
+	trees := state.BuildBlockingTrees(waits, backends)
+	if diff := pretty.Compare(trees, expected); diff != "" {
+		t.Errorf("BuildBlockingTrees: result diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestBuildBlockingTreesCycle(t *testing.T) {
+	// 500 and 600 wait for each other, without any backend that isn't waiting
+	waits := []state.PostgresLockWait{
+		{Pid: 600, Mode: "ShareLock", BlockingPids: []int32{500}},
+		{Pid: 500, Mode: "ShareLock", BlockingPids: []int32{600}},
+	}
+
+	expected := []state.PostgresBlockingNode{{
+		Pid: 500, WaitingForMode: "ShareLock",
+		Blocked: []state.PostgresBlockingNode{{
+			Pid: 600, WaitingForMode: "ShareLock",
+			Blocked: []state.PostgresBlockingNode{{Pid: 500, WaitingForMode: "ShareLock", Cycle: true}},
+		}},
+	}}
+
+	trees := state.BuildBlockingTrees(waits, nil)
+	if diff := pretty.Compare(trees, expected); diff != "" {
+		t.Errorf("BuildBlockingTrees: result diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestBuildBlockingTreesQueue(t *testing.T) {
+	// 100 holds an advisory lock, and each of the 40 waiters queued for it is
+	// blocked by 100 and all waiters ahead of it
+	var waits []state.PostgresLockWait
+	for i := int32(1); i <= 40; i++ {
+		wait := state.PostgresLockWait{Pid: 100 + i, LockType: "advisory", Mode: "ExclusiveLock", BlockingPids: []int32{100}}
+		for j := int32(1); j < i; j++ {
+			wait.BlockingPids = append(wait.BlockingPids, 100+j)
+		}
+		waits = append(waits, wait)
+	}
+
+	trees := state.BuildBlockingTrees(waits, nil)
+	if len(trees) != 1 || trees[0].Pid != 100 {
+		t.Fatalf("Expected a single tree rooted at 100, got %d trees", len(trees))
+	}
+	if len(trees[0].Blocked) != 40 {
+		t.Errorf("Expected all 40 waiters directly under 100, got %d", len(trees[0].Blocked))
+	}
+	for _, node := range trees[0].Blocked {
+		if len(node.Blocked) != 0 {
+			t.Errorf("Expected waiter %d to not be repeated under other waiters, got %v", node.Pid, node.Blocked)
+		}
+	}
+}
+
+func TestBuildBlockingTreesNodeLimit(t *testing.T) {
+	var waits []state.PostgresLockWait
+	for i := int32(1); i <= 5000; i++ {
+		waits = append(waits, state.PostgresLockWait{Pid: 100 + i, Mode: "ExclusiveLock", BlockingPids: []int32{100}})
+	}
+
+	trees := state.BuildBlockingTrees(waits, nil)
+	if len(trees) != 1 || len(trees[0].Blocked) != 999 {
+		t.Errorf("Expected the tree to be limited to 1000 backends, got %d trees", len(trees))
+	}
+}
+
+func TestBuildBlockingTreesBlockedByCycle(t *testing.T) {
+	// 500 and 600 wait for each other, and 550 waits for 600
+	waits := []state.PostgresLockWait{
+		{Pid: 550, Mode: "ShareLock", BlockingPids: []int32{600}},
+		{Pid: 600, Mode: "ShareLock", BlockingPids: []int32{500}},
+		{Pid: 500, Mode: "ShareLock", BlockingPids: []int32{600}},
+	}
+
+	expected := []state.PostgresBlockingNode{{
+		Pid: 500, WaitingForMode: "ShareLock",
+		Blocked: []state.PostgresBlockingNode{{
+			Pid: 600, WaitingForMode: "ShareLock",
+			Blocked: []state.PostgresBlockingNode{
+				{Pid: 500, WaitingForMode: "ShareLock", Cycle: true},
+				{Pid: 550, WaitingForMode: "ShareLock"},
+			},
+		}},
+	}}
+
+	trees := state.BuildBlockingTrees(waits, nil)
+	if diff := pretty.Compare(trees, expected); diff != "" {
+		t.Errorf("BuildBlockingTrees: result diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestBuildBlockingTreesNoWaits(t *testing.T) {
+	if trees := state.BuildBlockingTrees(nil, nil); len(trees) != 0 {
+		t.Errorf("Expected no blocking trees, got %v", trees)
+	}
+}
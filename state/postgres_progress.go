@@ -0,0 +1,43 @@
+package state
+
+import "github.com/guregu/null"
+
+// PostgresProgress - Long-running operations that were in progress at the time
+// of the snapshot, as reported by the pg_stat_progress_* views
+//
+// These are point-in-time values, and are therefore not diffed between runs.
+//
+// See https://www.postgresql.org/docs/13/progress-reporting.html
+type PostgresProgress struct {
+	Vacuums       []PostgresVacuumProgress
+	CreateIndexes []PostgresCreateIndexProgress // Postgres 12+
+	BaseBackups   []PostgresBaseBackupProgress  // Postgres 13+
+}
+
+// PostgresCreateIndexProgress - CREATE INDEX or REINDEX thats currently running
+type PostgresCreateIndexProgress struct {
+	Pid             int32
+	DatabaseOid     Oid
+	RelationOid     Oid // Table the index is built on
+	IndexOid        Oid // Zero while the index is not created yet (or for REINDEX of a whole table)
+	Command         string
+	Phase           string
+	LockersTotal    int64
+	LockersDone     int64
+	BlocksTotal     int64
+	BlocksDone      int64
+	TuplesTotal     int64
+	TuplesDone      int64
+	PartitionsTotal int64
+	PartitionsDone  int64
+}
+
+// PostgresBaseBackupProgress - Base backup thats currently being streamed
+type PostgresBaseBackupProgress struct {
+	Pid                 int32
+	Phase               string
+	BackupTotal         null.Int // Estimated total bytes, unless estimation is disabled
+	BackupStreamed      int64
+	TablespacesTotal    int64
+	TablespacesStreamed int64
+}
@@ -24,6 +24,25 @@ type PostgresQuerySample struct {
 	ExplainFormat pganalyze_collector.QuerySample_ExplainFormat
 	ExplainSource pganalyze_collector.QuerySample_ExplainSource
 
+	// Set when the query text was uploaded as a separate object, in which case
+	// only its beginning is sent as part of the sample
+	QueryTextObject *PostgresQueryTextObject
+
 	// FUTURE: Could use parameters (and query values) to determine whether
 	// the given value is included in most_common_vals (and which most_common_freqs it has)
 }
+
+// PostgresQueryTextObject - Query text of a sample that was encrypted and
+// uploaded as a separate object, to keep it out of the log snapshot
+type PostgresQueryTextObject struct {
+	UUID       uuid.UUID
+	S3Location string
+	S3CekAlgo  string
+	S3CmkKeyID string
+	ByteSize   int64
+	Compressed bool
+
+	// Number of bytes at the beginning of the query text that are still sent as
+	// part of the sample
+	SentLength int
+}
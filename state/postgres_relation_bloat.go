@@ -1,6 +1,7 @@
 package state
 
 type PostgresRelationBloat struct {
+	DatabaseOid  Oid
 	SchemaName   string
 	RelationName string
 	TotalBytes   int64
@@ -8,10 +9,11 @@ type PostgresRelationBloat struct {
 }
 
 type PostgresIndexBloat struct {
-	SchemaName string
-	IndexName  string
-	TotalBytes int64
-	BloatBytes int64
+	DatabaseOid Oid
+	SchemaName  string
+	IndexName   string
+	TotalBytes  int64
+	BloatBytes  int64
 }
 
 type PostgresBloatStats struct {
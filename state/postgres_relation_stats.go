@@ -1,9 +1,16 @@
 package state
 
-import "github.com/guregu/null"
+import (
+	"time"
+
+	"github.com/guregu/null"
+)
 
 type PostgresRelationStats struct {
-	SizeBytes        int64
+	SizeBytes        int64     // Size of the table including its TOAST table, but without indexes
+	MainSizeBytes    int64     // Size of the main fork of the table, i.e. without TOAST, free space map and visibility map
+	ToastSizeBytes   int64     // Size of the table's TOAST table including its index (if any)
+	IndexSizeBytes   int64     // Total size of all indexes on this table
 	SeqScan          int64     // Number of sequential scans initiated on this table
 	SeqTupRead       int64     // Number of live rows fetched by sequential scans
 	IdxScan          int64     // Number of index scans initiated on this table
@@ -31,6 +38,10 @@ type PostgresRelationStats struct {
 	ToastBlksHit     int64     // Number of buffer hits in this table's TOAST table (if any)
 	TidxBlksRead     int64     // Number of disk blocks read from this table's TOAST table indexes (if any)
 	TidxBlksHit      int64     // Number of buffer hits in this table's TOAST table indexes (if any)
+
+	// Whether the table has many dead rows, and wasn't vacuumed for a long time
+	// when it was collected (see IsVacuumOverdue)
+	VacuumOverdue bool
 }
 
 type PostgresIndexStats struct {
@@ -53,6 +64,9 @@ type DiffedPostgresIndexStatsMap map[Oid]DiffedPostgresIndexStats
 func (curr PostgresRelationStats) DiffSince(prev PostgresRelationStats) DiffedPostgresRelationStats {
 	return DiffedPostgresRelationStats{
 		SizeBytes:        curr.SizeBytes,
+		MainSizeBytes:    curr.MainSizeBytes,
+		ToastSizeBytes:   curr.ToastSizeBytes,
+		IndexSizeBytes:   curr.IndexSizeBytes,
 		SeqScan:          curr.SeqScan - prev.SeqScan,
 		SeqTupRead:       curr.SeqTupRead - prev.SeqTupRead,
 		IdxScan:          curr.IdxScan - prev.IdxScan,
@@ -80,7 +94,30 @@ func (curr PostgresRelationStats) DiffSince(prev PostgresRelationStats) DiffedPo
 		ToastBlksHit:     curr.ToastBlksHit - prev.ToastBlksHit,
 		TidxBlksRead:     curr.TidxBlksRead - prev.TidxBlksRead,
 		TidxBlksHit:      curr.TidxBlksHit - prev.TidxBlksHit,
+		VacuumOverdue:    curr.VacuumOverdue,
+	}
+}
+
+// DeadTupleRatio - Returns the share of dead rows among all rows of the table,
+// or 0 if the table has no rows
+func (stats PostgresRelationStats) DeadTupleRatio() float64 {
+	if stats.NLiveTup+stats.NDeadTup <= 0 {
+		return 0
+	}
+	return float64(stats.NDeadTup) / float64(stats.NLiveTup+stats.NDeadTup)
+}
+
+// IsVacuumOverdue - Whether the share of dead rows reached the given ratio, and
+// the table was last vacuumed (manually or by autovacuum) longer ago than
+// overdueAfter, including tables that were never vacuumed
+//
+// Tables without any dead rows are never overdue.
+func (stats PostgresRelationStats) IsVacuumOverdue(now time.Time, overdueAfter time.Duration, deadTupleRatio float64) bool {
+	if stats.NDeadTup == 0 || stats.DeadTupleRatio() < deadTupleRatio {
+		return false
 	}
+	lastVacuum := latestTime(stats.LastVacuum, stats.LastAutovacuum)
+	return !lastVacuum.Valid || now.Sub(lastVacuum.Time) > overdueAfter
 }
 
 func (curr PostgresIndexStats) DiffSince(prev PostgresIndexStats) DiffedPostgresIndexStats {
@@ -93,3 +130,126 @@ func (curr PostgresIndexStats) DiffSince(prev PostgresIndexStats) DiffedPostgres
 		IdxBlksHit:  curr.IdxBlksHit - prev.IdxBlksHit,
 	}
 }
+
+// AggregatePartitions - Adds an entry for each partitioned table that sums up
+// the statistics of its partitions (including those of nested partitions)
+//
+// Partitioned tables don't have statistics of their own, and summing up the
+// already diffed partition statistics means that partitions being attached or
+// dropped between two runs don't cause the totals of the parent to jump.
+func (diff DiffedPostgresRelationStatsMap) AggregatePartitions(relations []PostgresRelation) {
+	parentOids := make(map[Oid]Oid)
+	partitionedTables := make(map[Oid]bool)
+	for _, relation := range relations {
+		if relation.IsPartition() {
+			parentOids[relation.Oid] = relation.ParentRelationOid
+		}
+		if relation.IsPartitionedTable() {
+			partitionedTables[relation.Oid] = true
+		}
+	}
+
+	totals := make(map[Oid]DiffedPostgresRelationStats)
+	for oid, stats := range diff {
+		if partitionedTables[oid] {
+			continue
+		}
+		for parentOid, ok := parentOids[oid]; ok; parentOid, ok = parentOids[parentOid] {
+			totals[parentOid] = totals[parentOid].add(stats)
+		}
+	}
+	for oid, stats := range totals {
+		diff[oid] = stats
+	}
+}
+
+// Activity - Returns the sum of all counters that changed since the previous
+// run, as a measure of how much the table was used
+func (stats DiffedPostgresRelationStats) Activity() int64 {
+	return stats.SeqScan + stats.SeqTupRead + stats.IdxScan + stats.IdxTupFetch +
+		stats.NTupIns + stats.NTupUpd + stats.NTupDel + stats.NTupHotUpd +
+		stats.VacuumCount + stats.AutovacuumCount + stats.AnalyzeCount + stats.AutoanalyzeCount +
+		stats.HeapBlksRead + stats.HeapBlksHit + stats.IdxBlksRead + stats.IdxBlksHit +
+		stats.ToastBlksRead + stats.ToastBlksHit + stats.TidxBlksRead + stats.TidxBlksHit
+}
+
+// WithMinActivity - Returns a copy where tables with less than the given
+// activity (see Activity) have their scan, row and block counters cleared
+//
+// All tables are kept, together with their sizes, row estimates and vacuum
+// information, since those matter even for tables that are barely used.
+func (diff DiffedPostgresRelationStatsMap) WithMinActivity(minActivity int64) DiffedPostgresRelationStatsMap {
+	filtered := make(DiffedPostgresRelationStatsMap)
+	for oid, stats := range diff {
+		if stats.Activity() < minActivity {
+			stats = stats.withoutActivityCounters()
+		}
+		filtered[oid] = stats
+	}
+	return filtered
+}
+
+func (stats DiffedPostgresRelationStats) withoutActivityCounters() DiffedPostgresRelationStats {
+	stats.SeqScan = 0
+	stats.SeqTupRead = 0
+	stats.IdxScan = 0
+	stats.IdxTupFetch = 0
+	stats.NTupIns = 0
+	stats.NTupUpd = 0
+	stats.NTupDel = 0
+	stats.NTupHotUpd = 0
+	stats.HeapBlksRead = 0
+	stats.HeapBlksHit = 0
+	stats.IdxBlksRead = 0
+	stats.IdxBlksHit = 0
+	stats.ToastBlksRead = 0
+	stats.ToastBlksHit = 0
+	stats.TidxBlksRead = 0
+	stats.TidxBlksHit = 0
+	return stats
+}
+
+func (a DiffedPostgresRelationStats) add(b DiffedPostgresRelationStats) DiffedPostgresRelationStats {
+	a.SizeBytes += b.SizeBytes
+	a.MainSizeBytes += b.MainSizeBytes
+	a.ToastSizeBytes += b.ToastSizeBytes
+	a.IndexSizeBytes += b.IndexSizeBytes
+	a.SeqScan += b.SeqScan
+	a.SeqTupRead += b.SeqTupRead
+	a.IdxScan += b.IdxScan
+	a.IdxTupFetch += b.IdxTupFetch
+	a.NTupIns += b.NTupIns
+	a.NTupUpd += b.NTupUpd
+	a.NTupDel += b.NTupDel
+	a.NTupHotUpd += b.NTupHotUpd
+	a.NLiveTup += b.NLiveTup
+	a.NDeadTup += b.NDeadTup
+	if b.NModSinceAnalyze.Valid {
+		a.NModSinceAnalyze = null.IntFrom(a.NModSinceAnalyze.Int64 + b.NModSinceAnalyze.Int64)
+	}
+	a.LastVacuum = latestTime(a.LastVacuum, b.LastVacuum)
+	a.LastAutovacuum = latestTime(a.LastAutovacuum, b.LastAutovacuum)
+	a.LastAnalyze = latestTime(a.LastAnalyze, b.LastAnalyze)
+	a.LastAutoanalyze = latestTime(a.LastAutoanalyze, b.LastAutoanalyze)
+	a.VacuumCount += b.VacuumCount
+	a.AutovacuumCount += b.AutovacuumCount
+	a.AnalyzeCount += b.AnalyzeCount
+	a.AutoanalyzeCount += b.AutoanalyzeCount
+	a.HeapBlksRead += b.HeapBlksRead
+	a.HeapBlksHit += b.HeapBlksHit
+	a.IdxBlksRead += b.IdxBlksRead
+	a.IdxBlksHit += b.IdxBlksHit
+	a.ToastBlksRead += b.ToastBlksRead
+	a.ToastBlksHit += b.ToastBlksHit
+	a.TidxBlksRead += b.TidxBlksRead
+	a.TidxBlksHit += b.TidxBlksHit
+	a.VacuumOverdue = a.VacuumOverdue || b.VacuumOverdue
+	return a
+}
+
+func latestTime(a null.Time, b null.Time) null.Time {
+	if !b.Valid || (a.Valid && a.Time.After(b.Time)) {
+		return a
+	}
+	return b
+}
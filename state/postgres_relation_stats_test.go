@@ -0,0 +1,203 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+)
+
+// events (parent) partitioned into events_2018_08, events_2018_09 and
+// events_2018_10, the latter being partitioned itself by events_2018_10_a and
+// events_2018_10_b - plus users, which isn't partitioned
+var partitionedRelations = []state.PostgresRelation{
+	{Oid: 1, RelationName: "events", RelationType: "p"},
+	{Oid: 2, RelationName: "events_2018_08", RelationType: "r", ParentRelationOid: 1},
+	{Oid: 3, RelationName: "events_2018_09", RelationType: "r", ParentRelationOid: 1},
+	{Oid: 4, RelationName: "events_2018_10", RelationType: "p", ParentRelationOid: 1},
+	{Oid: 5, RelationName: "events_2018_10_a", RelationType: "r", ParentRelationOid: 4},
+	{Oid: 6, RelationName: "events_2018_10_b", RelationType: "r", ParentRelationOid: 4},
+	{Oid: 7, RelationName: "users", RelationType: "r"},
+}
+
+var vacuumedAt = time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+
+var aggregatePartitionsTests = []struct {
+	diff     state.DiffedPostgresRelationStatsMap
+	expected state.DiffedPostgresRelationStatsMap
+}{
+	// Statistics of all partitions get summed up under their parents
+	{
+		state.DiffedPostgresRelationStatsMap{
+			2: {SizeBytes: 1000, SeqScan: 1, NLiveTup: 10, NModSinceAnalyze: null.IntFrom(5)},
+			3: {SizeBytes: 2000, SeqScan: 2, NLiveTup: 20, AutovacuumCount: 1, LastAutovacuum: null.TimeFrom(vacuumedAt)},
+			5: {SizeBytes: 3000, IdxScan: 3, NLiveTup: 30, AutovacuumCount: 1, LastAutovacuum: null.TimeFrom(vacuumedAt.Add(-time.Hour))},
+			6: {SizeBytes: 4000, IdxScan: 4, NLiveTup: 40},
+			7: {SizeBytes: 5000, SeqScan: 5, NLiveTup: 50},
+		},
+		state.DiffedPostgresRelationStatsMap{
+			1: {SizeBytes: 10000, SeqScan: 3, IdxScan: 7, NLiveTup: 100, NModSinceAnalyze: null.IntFrom(5), AutovacuumCount: 2, LastAutovacuum: null.TimeFrom(vacuumedAt)},
+			2: {SizeBytes: 1000, SeqScan: 1, NLiveTup: 10, NModSinceAnalyze: null.IntFrom(5)},
+			3: {SizeBytes: 2000, SeqScan: 2, NLiveTup: 20, AutovacuumCount: 1, LastAutovacuum: null.TimeFrom(vacuumedAt)},
+			4: {SizeBytes: 7000, IdxScan: 7, NLiveTup: 70, AutovacuumCount: 1, LastAutovacuum: null.TimeFrom(vacuumedAt.Add(-time.Hour))},
+			5: {SizeBytes: 3000, IdxScan: 3, NLiveTup: 30, AutovacuumCount: 1, LastAutovacuum: null.TimeFrom(vacuumedAt.Add(-time.Hour))},
+			6: {SizeBytes: 4000, IdxScan: 4, NLiveTup: 40},
+			7: {SizeBytes: 5000, SeqScan: 5, NLiveTup: 50},
+		},
+	},
+	// Partitioned tables without any partition statistics don't get an entry
+	{
+		state.DiffedPostgresRelationStatsMap{
+			7: {SizeBytes: 5000, SeqScan: 5, NLiveTup: 50},
+		},
+		state.DiffedPostgresRelationStatsMap{
+			7: {SizeBytes: 5000, SeqScan: 5, NLiveTup: 50},
+		},
+	},
+}
+
+func TestAggregatePartitions(t *testing.T) {
+	for i, test := range aggregatePartitionsTests {
+		test.diff.AggregatePartitions(partitionedRelations)
+		if diff := pretty.Compare(test.expected, test.diff); diff != "" {
+			t.Errorf("test %d: result diff: (-want +got)\n%s", i, diff)
+		}
+	}
+}
+
+func TestAggregatePartitionsWithPartitionChurn(t *testing.T) {
+	prev := state.PostgresRelationStatsMap{
+		2: {SizeBytes: 1000, SeqScan: 10, NTupIns: 100},
+		3: {SizeBytes: 2000, SeqScan: 20, NTupIns: 200},
+	}
+	// events_2018_08 got dropped, and events_2018_10_a was attached since the last run
+	curr := state.PostgresRelationStatsMap{
+		3: {SizeBytes: 2500, SeqScan: 25, NTupIns: 250},
+		5: {SizeBytes: 500, SeqScan: 1, NTupIns: 50},
+	}
+
+	diff := make(state.DiffedPostgresRelationStatsMap)
+	for oid, stats := range curr {
+		diff[oid] = stats.DiffSince(prev[oid])
+	}
+	diff.AggregatePartitions(partitionedRelations)
+
+	// The parent only reflects activity since the last run, instead of going
+	// backwards because the counters of the dropped partition are gone
+	expected := state.DiffedPostgresRelationStats{SizeBytes: 3000, SeqScan: 6, NTupIns: 100}
+	if d := pretty.Compare(expected, diff[1]); d != "" {
+		t.Errorf("partitioned table statistics: (-want +got)\n%s", d)
+	}
+}
+
+func TestWithoutPartitions(t *testing.T) {
+	var names []string
+	for _, relation := range state.WithoutPartitions(partitionedRelations) {
+		names = append(names, relation.RelationName)
+	}
+	expected := []string{"events", "users"}
+	if diff := pretty.Compare(expected, names); diff != "" {
+		t.Errorf("result diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRelationStatsWithMinActivity(t *testing.T) {
+	lastAutovacuum := null.TimeFrom(time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC))
+	prev := state.PostgresRelationStatsMap{
+		2: {SizeBytes: 1000, SeqScan: 10, NTupIns: 100},
+		3: {SizeBytes: 2000, SeqScan: 20, NTupIns: 200, HeapBlksHit: 500},
+		7: {SizeBytes: 3000, IdxScan: 5, NDeadTup: 100},
+	}
+	curr := state.PostgresRelationStatsMap{
+		2: {SizeBytes: 1000, SeqScan: 10, NTupIns: 100},                                                                                        // Unchanged
+		3: {SizeBytes: 2500, SeqScan: 25, NTupIns: 250, HeapBlksHit: 600},                                                                      // 5 + 50 + 100
+		7: {SizeBytes: 3000, IdxScan: 8, NLiveTup: 50, NDeadTup: 900, AutovacuumCount: 1, LastAutovacuum: lastAutovacuum, VacuumOverdue: true}, // 3 + 1
+	}
+
+	diff := make(state.DiffedPostgresRelationStatsMap)
+	for oid, stats := range curr {
+		diff[oid] = stats.DiffSince(prev[oid])
+	}
+	filtered := diff.WithMinActivity(10)
+
+	// Tables below the minimum keep everything but their activity counters
+	expected := state.DiffedPostgresRelationStatsMap{
+		2: {SizeBytes: 1000},
+		3: {SizeBytes: 2500, SeqScan: 5, NTupIns: 50, HeapBlksHit: 100},
+		7: {SizeBytes: 3000, NLiveTup: 50, NDeadTup: 900, AutovacuumCount: 1, LastAutovacuum: lastAutovacuum, VacuumOverdue: true},
+	}
+	if d := pretty.Compare(expected, filtered); d != "" {
+		t.Errorf("filtered statistics: (-want +got)\n%s", d)
+	}
+
+	// The full diff and state stay untouched, so the next diff is still correct
+	if len(diff) != 3 || len(curr) != 3 {
+		t.Errorf("Expected full diff and state to be retained, got %d diffed and %d current relations", len(diff), len(curr))
+	}
+	next := state.PostgresRelationStatsMap{7: {SizeBytes: 3000, IdxScan: 20, AutovacuumCount: 1}}
+	if activity := next[7].DiffSince(curr[7]).Activity(); activity != 12 {
+		t.Errorf("Expected activity of 12 since the retained state, got %d", activity)
+	}
+}
+
+func TestRelationStatsDiffSinceSizes(t *testing.T) {
+	// A table that stores most of its data in large text values, and therefore
+	// in its TOAST table
+	prev := state.PostgresRelationStats{SizeBytes: 90000000, MainSizeBytes: 8000000, ToastSizeBytes: 81000000, IndexSizeBytes: 2000000, NTupIns: 100}
+	curr := state.PostgresRelationStats{SizeBytes: 120000000, MainSizeBytes: 9000000, ToastSizeBytes: 110000000, IndexSizeBytes: 2500000, NTupIns: 150}
+
+	expected := state.DiffedPostgresRelationStats{SizeBytes: 120000000, MainSizeBytes: 9000000, ToastSizeBytes: 110000000, IndexSizeBytes: 2500000, NTupIns: 50}
+	if diff := pretty.Compare(expected, curr.DiffSince(prev)); diff != "" {
+		t.Errorf("Diff: (-want +got)\n%s", diff)
+	}
+
+	diff := state.DiffedPostgresRelationStatsMap{
+		2: {SizeBytes: 1000, MainSizeBytes: 200, ToastSizeBytes: 700, IndexSizeBytes: 300},
+		3: {SizeBytes: 2000, MainSizeBytes: 1800, ToastSizeBytes: 0, IndexSizeBytes: 400},
+	}
+	diff.AggregatePartitions(partitionedRelations)
+	expectedParent := state.DiffedPostgresRelationStats{SizeBytes: 3000, MainSizeBytes: 2000, ToastSizeBytes: 700, IndexSizeBytes: 700}
+	if diff := pretty.Compare(expectedParent, diff[1]); diff != "" {
+		t.Errorf("Partitioned table diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRelationStatsIsVacuumOverdue(t *testing.T) {
+	now := time.Date(2018, time.October, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		description string
+		stats       state.PostgresRelationStats
+		ratio       float64
+		overdue     bool
+	}{
+		{"far overdue", state.PostgresRelationStats{NLiveTup: 600, NDeadTup: 400, LastAutovacuum: null.TimeFrom(now.Add(-30 * 24 * time.Hour))}, 0.4, true},
+		{"recently vacuumed by autovacuum", state.PostgresRelationStats{NLiveTup: 600, NDeadTup: 400, LastAutovacuum: null.TimeFrom(now.Add(-1 * time.Hour))}, 0.4, false},
+		{"recently vacuumed manually", state.PostgresRelationStats{NLiveTup: 600, NDeadTup: 400, LastVacuum: null.TimeFrom(now.Add(-1 * time.Hour)), LastAutovacuum: null.TimeFrom(now.Add(-30 * 24 * time.Hour))}, 0.4, false},
+		{"never vacuumed", state.PostgresRelationStats{NLiveTup: 600, NDeadTup: 400}, 0.4, true},
+		{"few dead rows", state.PostgresRelationStats{NLiveTup: 990, NDeadTup: 10, LastAutovacuum: null.TimeFrom(now.Add(-30 * 24 * time.Hour))}, 0.01, false},
+		{"empty table", state.PostgresRelationStats{}, 0, false},
+	}
+
+	for _, test := range tests {
+		if ratio := test.stats.DeadTupleRatio(); ratio != test.ratio {
+			t.Errorf("%s: expected dead tuple ratio %v, got %v", test.description, test.ratio, ratio)
+		}
+		if overdue := test.stats.IsVacuumOverdue(now, 24*time.Hour, 0.2); overdue != test.overdue {
+			t.Errorf("%s: expected overdue to be %v, got %v", test.description, test.overdue, overdue)
+		}
+	}
+
+	// The flag is kept through diffs, and partitioned tables are overdue if any partition is
+	curr := state.PostgresRelationStats{NDeadTup: 400, VacuumOverdue: true}
+	if !curr.DiffSince(state.PostgresRelationStats{}).VacuumOverdue {
+		t.Errorf("Expected diff to keep the overdue flag")
+	}
+	diff := state.DiffedPostgresRelationStatsMap{2: {VacuumOverdue: true}, 3: {}}
+	diff.AggregatePartitions(partitionedRelations)
+	if !diff[1].VacuumOverdue {
+		t.Errorf("Expected partitioned table with an overdue partition to be overdue")
+	}
+}
@@ -24,6 +24,10 @@ type PostgresRelation struct {
 	FrozenXID              Xid
 	MinimumMultixactXID    Xid
 
+	// For partitions of a declaratively partitioned table (Postgres 10+), the Oid
+	// of the partitioned table they are attached to, otherwise 0
+	ParentRelationOid Oid
+
 	// True if another process is currently holding an AccessExclusiveLock on this
 	// relation, this also means we don't collect columns/index/constraints data
 	ExclusivelyLocked bool
@@ -65,6 +69,29 @@ type PostgresConstraint struct {
 	ForeignMatchType  string  // Foreign key match type: f = full, p = partial, s = simple
 }
 
+// IsPartition - Whether this relation is a partition of a declaratively partitioned table
+func (r PostgresRelation) IsPartition() bool {
+	return r.ParentRelationOid != 0
+}
+
+// IsPartitionedTable - Whether this relation is a declaratively partitioned
+// table, which doesn't hold any data (or statistics) itself
+func (r PostgresRelation) IsPartitionedTable() bool {
+	return r.RelationType == "p"
+}
+
+// WithoutPartitions - Returns the relations that are not partitions, for
+// reporting partitioned tables as a single relation
+func WithoutPartitions(relations []PostgresRelation) []PostgresRelation {
+	var result []PostgresRelation
+	for _, relation := range relations {
+		if !relation.IsPartition() {
+			result = append(result, relation)
+		}
+	}
+	return result
+}
+
 // Fillfactor - Returns the FILLFACTOR storage parameter set on the table, or the default (100)
 func (r PostgresRelation) Fillfactor() int32 {
 	fstr, exists := r.Options["fillfactor"]
@@ -12,6 +12,7 @@ type PostgresReplication struct {
 	// Data available on primary
 	CurrentXlogLocation null.String
 	Standbys            []PostgresReplicationStandby
+	LogicalSlots        []PostgresReplicationSlot // Postgres 9.4+
 
 	// Data available on standby
 	IsStreaming        null.Bool
@@ -42,3 +43,131 @@ type PostgresReplicationStandby struct {
 	ReplayLocation null.String
 	ByteLag        null.Int
 }
+
+// PostgresReplicationSlot - Logical replication slot on the primary
+type PostgresReplicationSlot struct {
+	SlotName     string
+	Plugin       string
+	DatabaseName string
+	Active       bool
+
+	RestartLsn        null.String
+	ConfirmedFlushLsn null.String // Postgres 9.6+
+
+	// WAL the server retains for this slot, and WAL the consumer has not yet
+	// confirmed to have received (both in bytes behind the current WAL position)
+	RestartByteLag        null.Int
+	ConfirmedFlushByteLag null.Int
+}
+
+// Position - Returns how far the slot's consumer has progressed, which is the
+// confirmed flush position where available
+func (slot PostgresReplicationSlot) Position() null.String {
+	if slot.ConfirmedFlushLsn.Valid {
+		return slot.ConfirmedFlushLsn
+	}
+	return slot.RestartLsn
+}
+
+// PostgresReplicationStandbyKey - Identifies a standby across collector runs
+// (the PID and client port change whenever the standby reconnects)
+type PostgresReplicationStandbyKey struct {
+	ClientAddr      string
+	ApplicationName string
+}
+
+// DiffedPostgresReplication - Changes in replication state since the last run
+type DiffedPostgresReplication struct {
+	// Server switched between primary and standby, in which case there are no
+	// other diffs, since the data is not comparable between the two roles
+	RoleChanged bool
+
+	// Change in apply lag of this server (only available on standby)
+	ApplyByteLagDelta null.Int
+
+	// Change in byte lag for standbys that were connected in both runs (only available on primary)
+	StandbyByteLagDeltas map[PostgresReplicationStandbyKey]int64
+
+	AddedStandbys   []PostgresReplicationStandbyKey
+	RemovedStandbys []PostgresReplicationStandbyKey
+
+	// Change in confirmed flush lag for logical slots that existed in both runs,
+	// by slot name (only available on primary)
+	LogicalSlotByteLagDeltas map[string]int64
+
+	// Logical slots that did not advance since the last run, while the server
+	// wrote more WAL - these retain WAL until their consumer catches up, and
+	// can fill up the disk
+	StalledLogicalSlots []string
+}
+
+// Key - Returns the identifier used to match up this standby between runs
+func (standby PostgresReplicationStandby) Key() PostgresReplicationStandbyKey {
+	return PostgresReplicationStandbyKey{ClientAddr: standby.ClientAddr, ApplicationName: standby.ApplicationName}
+}
+
+// DiffSince - Calculate the diff between two replication state runs
+func (curr PostgresReplication) DiffSince(prev PostgresReplication) DiffedPostgresReplication {
+	var diff DiffedPostgresReplication
+
+	if curr.InRecovery != prev.InRecovery {
+		diff.RoleChanged = true
+		return diff
+	}
+
+	if curr.ApplyByteLag.Valid && prev.ApplyByteLag.Valid {
+		diff.ApplyByteLagDelta = null.IntFrom(curr.ApplyByteLag.Int64 - prev.ApplyByteLag.Int64)
+	}
+
+	prevStandbys := make(map[PostgresReplicationStandbyKey]PostgresReplicationStandby)
+	for _, standby := range prev.Standbys {
+		prevStandbys[standby.Key()] = standby
+	}
+
+	diff.StandbyByteLagDeltas = make(map[PostgresReplicationStandbyKey]int64)
+	currStandbys := make(map[PostgresReplicationStandbyKey]bool)
+	for _, standby := range curr.Standbys {
+		key := standby.Key()
+		currStandbys[key] = true
+
+		prevStandby, exists := prevStandbys[key]
+		if !exists {
+			diff.AddedStandbys = append(diff.AddedStandbys, key)
+		} else if standby.ByteLag.Valid && prevStandby.ByteLag.Valid {
+			diff.StandbyByteLagDeltas[key] = standby.ByteLag.Int64 - prevStandby.ByteLag.Int64
+		}
+	}
+	for _, standby := range prev.Standbys {
+		if !currStandbys[standby.Key()] {
+			diff.RemovedStandbys = append(diff.RemovedStandbys, standby.Key())
+		}
+	}
+
+	prevSlots := make(map[string]PostgresReplicationSlot)
+	for _, slot := range prev.LogicalSlots {
+		prevSlots[slot.SlotName] = slot
+	}
+
+	diff.LogicalSlotByteLagDeltas = make(map[string]int64)
+	for _, slot := range curr.LogicalSlots {
+		prevSlot, exists := prevSlots[slot.SlotName]
+		if !exists {
+			continue
+		}
+		lag := slot.ConfirmedFlushByteLag
+		prevLag := prevSlot.ConfirmedFlushByteLag
+		if !lag.Valid || !prevLag.Valid {
+			lag = slot.RestartByteLag
+			prevLag = prevSlot.RestartByteLag
+		}
+		if lag.Valid && prevLag.Valid {
+			diff.LogicalSlotByteLagDeltas[slot.SlotName] = lag.Int64 - prevLag.Int64
+		}
+		if slot.Position().Valid && slot.Position() == prevSlot.Position() &&
+			curr.CurrentXlogLocation.Valid && curr.CurrentXlogLocation != prev.CurrentXlogLocation {
+			diff.StalledLogicalSlots = append(diff.StalledLogicalSlots, slot.SlotName)
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,88 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/guregu/null"
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+)
+
+var replicaA = state.PostgresReplicationStandbyKey{ClientAddr: "10.0.0.1", ApplicationName: "replica_a"}
+var replicaB = state.PostgresReplicationStandbyKey{ClientAddr: "10.0.0.2", ApplicationName: "replica_b"}
+var replicaC = state.PostgresReplicationStandbyKey{ClientAddr: "10.0.0.3", ApplicationName: "replica_c"}
+
+func standby(key state.PostgresReplicationStandbyKey, byteLag int64) state.PostgresReplicationStandby {
+	return state.PostgresReplicationStandby{ClientAddr: key.ClientAddr, ApplicationName: key.ApplicationName, ByteLag: null.IntFrom(byteLag)}
+}
+
+var replicationDiffTests = []struct {
+	curr     state.PostgresReplication
+	prev     state.PostgresReplication
+	expected state.DiffedPostgresReplication
+}{
+	// Primary with one standby staying, one added and one removed
+	{
+		state.PostgresReplication{Standbys: []state.PostgresReplicationStandby{standby(replicaA, 1500), standby(replicaC, 0)}},
+		state.PostgresReplication{Standbys: []state.PostgresReplicationStandby{standby(replicaA, 1000), standby(replicaB, 200)}},
+		state.DiffedPostgresReplication{
+			StandbyByteLagDeltas: map[state.PostgresReplicationStandbyKey]int64{replicaA: 500},
+			AddedStandbys:        []state.PostgresReplicationStandbyKey{replicaC},
+			RemovedStandbys:      []state.PostgresReplicationStandbyKey{replicaB},
+		},
+	},
+	// Standby with changing apply lag
+	{
+		state.PostgresReplication{InRecovery: true, ApplyByteLag: null.IntFrom(100)},
+		state.PostgresReplication{InRecovery: true, ApplyByteLag: null.IntFrom(400)},
+		state.DiffedPostgresReplication{
+			ApplyByteLagDelta:    null.IntFrom(-300),
+			StandbyByteLagDeltas: map[state.PostgresReplicationStandbyKey]int64{},
+		},
+	},
+	// Primary with an active logical slot that advanced, and a stuck slot that
+	// didn't, while the server wrote more WAL
+	{
+		state.PostgresReplication{CurrentXlogLocation: null.StringFrom("0/5000000"), LogicalSlots: []state.PostgresReplicationSlot{
+			{SlotName: "cdc_active", Active: true, ConfirmedFlushLsn: null.StringFrom("0/4FFF000"), ConfirmedFlushByteLag: null.IntFrom(4096)},
+			{SlotName: "cdc_stuck", Active: false, ConfirmedFlushLsn: null.StringFrom("0/1000000"), ConfirmedFlushByteLag: null.IntFrom(67108864)},
+		}},
+		state.PostgresReplication{CurrentXlogLocation: null.StringFrom("0/3000000"), LogicalSlots: []state.PostgresReplicationSlot{
+			{SlotName: "cdc_active", Active: true, ConfirmedFlushLsn: null.StringFrom("0/2FFE000"), ConfirmedFlushByteLag: null.IntFrom(8192)},
+			{SlotName: "cdc_stuck", Active: false, ConfirmedFlushLsn: null.StringFrom("0/1000000"), ConfirmedFlushByteLag: null.IntFrom(33554432)},
+		}},
+		state.DiffedPostgresReplication{
+			StandbyByteLagDeltas:     map[state.PostgresReplicationStandbyKey]int64{},
+			LogicalSlotByteLagDeltas: map[string]int64{"cdc_active": -4096, "cdc_stuck": 33554432},
+			StalledLogicalSlots:      []string{"cdc_stuck"},
+		},
+	},
+	// Idle primary, where slots not advancing is expected
+	{
+		state.PostgresReplication{CurrentXlogLocation: null.StringFrom("0/3000000"), LogicalSlots: []state.PostgresReplicationSlot{
+			{SlotName: "cdc_idle", Active: true, RestartLsn: null.StringFrom("0/3000000"), RestartByteLag: null.IntFrom(0)},
+		}},
+		state.PostgresReplication{CurrentXlogLocation: null.StringFrom("0/3000000"), LogicalSlots: []state.PostgresReplicationSlot{
+			{SlotName: "cdc_idle", Active: true, RestartLsn: null.StringFrom("0/3000000"), RestartByteLag: null.IntFrom(0)},
+		}},
+		state.DiffedPostgresReplication{
+			StandbyByteLagDeltas:     map[state.PostgresReplicationStandbyKey]int64{},
+			LogicalSlotByteLagDeltas: map[string]int64{"cdc_idle": 0},
+		},
+	},
+	// Primary that got demoted to standby
+	{
+		state.PostgresReplication{InRecovery: true, ApplyByteLag: null.IntFrom(100)},
+		state.PostgresReplication{Standbys: []state.PostgresReplicationStandby{standby(replicaA, 1000)}},
+		state.DiffedPostgresReplication{RoleChanged: true},
+	},
+}
+
+func TestPostgresReplicationDiffSince(t *testing.T) {
+	for idx, test := range replicationDiffTests {
+		diff := test.curr.DiffSince(test.prev)
+		if d := pretty.Compare(test.expected, diff); d != "" {
+			t.Errorf("Test %d: replication diff: (-want +got)\n%s", idx, d)
+		}
+	}
+}
@@ -11,4 +11,49 @@ type PostgresSetting struct {
 	Source       null.String `json:"source"`
 	SourceFile   null.String `json:"sourcefile"`
 	SourceLine   null.String `json:"sourceline"`
+
+	// Whether the setting was changed in the configuration file, but only gets
+	// applied once the server is restarted (always false before Postgres 9.5)
+	PendingRestart bool `json:"pending_restart"`
+}
+
+// PendingRestartSettingNames - Returns the names of all settings that are
+// waiting for a restart to be applied, in the order they were collected
+func PendingRestartSettingNames(settings []PostgresSetting) (names []string) {
+	for _, setting := range settings {
+		if setting.PendingRestart {
+			names = append(names, setting.Name)
+		}
+	}
+	return
+}
+
+// NewlyPendingRestart - Returns the settings that are pending a restart now,
+// but were not in the given previously pending settings
+func NewlyPendingRestart(prevPending []string, settings []PostgresSetting) (names []string) {
+	prev := make(map[string]bool, len(prevPending))
+	for _, name := range prevPending {
+		prev[name] = true
+	}
+	for _, name := range PendingRestartSettingNames(settings) {
+		if !prev[name] {
+			names = append(names, name)
+		}
+	}
+	return
+}
+
+// ChangedSettings - Returns the settings that are new or differ in any column
+// from the given previously collected settings
+func ChangedSettings(prevSettings []PostgresSetting, settings []PostgresSetting) (changed []PostgresSetting) {
+	prev := make(map[string]PostgresSetting, len(prevSettings))
+	for _, setting := range prevSettings {
+		prev[setting.Name] = setting
+	}
+	for _, setting := range settings {
+		if prevSetting, exists := prev[setting.Name]; !exists || prevSetting != setting {
+			changed = append(changed, setting)
+		}
+	}
+	return
 }
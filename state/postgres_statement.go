@@ -80,6 +80,19 @@ func (curr PostgresStatementStats) DiffSince(prev PostgresStatementStats) Diffed
 	}
 }
 
+// WasResetSince - Returns whether any of the counters went down since prev,
+// which means the statistics were reset in between (e.g. by someone running
+// pg_stat_statements_reset() outside of the collector)
+func (curr PostgresStatementStats) WasResetSince(prev PostgresStatementStats) bool {
+	return curr.Calls < prev.Calls || curr.TotalTime < prev.TotalTime || curr.Rows < prev.Rows ||
+		curr.SharedBlksHit < prev.SharedBlksHit || curr.SharedBlksRead < prev.SharedBlksRead ||
+		curr.SharedBlksDirtied < prev.SharedBlksDirtied || curr.SharedBlksWritten < prev.SharedBlksWritten ||
+		curr.LocalBlksHit < prev.LocalBlksHit || curr.LocalBlksRead < prev.LocalBlksRead ||
+		curr.LocalBlksDirtied < prev.LocalBlksDirtied || curr.LocalBlksWritten < prev.LocalBlksWritten ||
+		curr.TempBlksRead < prev.TempBlksRead || curr.TempBlksWritten < prev.TempBlksWritten ||
+		curr.BlkReadTime < prev.BlkReadTime || curr.BlkWriteTime < prev.BlkWriteTime
+}
+
 // Add - Adds the statistics of one diffed statement to another, returning the result as a copy
 func (stmt DiffedPostgresStatementStats) Add(other DiffedPostgresStatementStats) DiffedPostgresStatementStats {
 	return DiffedPostgresStatementStats{
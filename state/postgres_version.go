@@ -9,6 +9,12 @@ const (
 	PostgresVersion96 = 90600
 	PostgresVersion10 = 100000
 	PostgresVersion11 = 110000
+	PostgresVersion12 = 120000
+	PostgresVersion13 = 130000
+	PostgresVersion14 = 140000
+	PostgresVersion15 = 150000
+	PostgresVersion16 = 160000
+	PostgresVersion17 = 170000
 
 	// MinRequiredPostgresVersion - We require PostgreSQL 9.2 or newer, since pg_stat_statements only started being usable then
 	MinRequiredPostgresVersion = PostgresVersion92
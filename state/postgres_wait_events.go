@@ -0,0 +1,60 @@
+package state
+
+import "sync"
+
+// PostgresWaitEventKey - Kind of wait a backend was in when pg_stat_activity
+// was sampled
+//
+// Before Postgres 9.6 only waiting on locks is known, which is reported as
+// WaitEventType "Lock" with an empty WaitEvent.
+type PostgresWaitEventKey struct {
+	WaitEventType string
+	WaitEvent     string
+}
+
+// PostgresWaitEventCounts - Number of backends that were waiting, by wait event
+type PostgresWaitEventCounts map[PostgresWaitEventKey]int64
+
+// PostgresWaitEventSummary - Wait event counts summed up across all samples
+// taken since the last full snapshot
+type PostgresWaitEventSummary struct {
+	SampleCount int
+	Counts      PostgresWaitEventCounts
+}
+
+// WaitEventSampler - Collects wait event samples in between full snapshots,
+// which take the summary of all samples so far
+type WaitEventSampler struct {
+	mutex   sync.Mutex
+	summary PostgresWaitEventSummary
+}
+
+// Add - Adds the counts of one sample to the summary
+func (s *WaitEventSampler) Add(counts PostgresWaitEventCounts) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.summary.Counts == nil {
+		s.summary.Counts = make(PostgresWaitEventCounts)
+	}
+	for key, count := range counts {
+		s.summary.Counts[key] += count
+	}
+	s.summary.SampleCount++
+}
+
+// Take - Returns the summary of all samples added so far, and starts over
+func (s *WaitEventSampler) Take() PostgresWaitEventSummary {
+	if s == nil {
+		return PostgresWaitEventSummary{}
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	summary := s.summary
+	s.summary = PostgresWaitEventSummary{}
+	return summary
+}
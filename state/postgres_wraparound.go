@@ -0,0 +1,111 @@
+package state
+
+import "sort"
+
+// Risk of transaction ID wraparound, derived from the age of the oldest
+// unfrozen transaction ID of a database or table
+const (
+	WraparoundRiskLow      = "low"      // Well below autovacuum_freeze_max_age
+	WraparoundRiskElevated = "elevated" // Anti-wraparound autovacuum will start soon
+	WraparoundRiskHigh     = "high"     // Past autovacuum_freeze_max_age, i.e. autovacuum is not keeping up
+	WraparoundRiskCritical = "critical" // Close enough to wraparound that Postgres 14+ VACUUM enters failsafe mode
+)
+
+// Percentage of autovacuum_freeze_max_age from which the risk is elevated
+const wraparoundElevatedPercent = 75.0
+
+// Age at which the risk is critical, which matches the default of
+// vacuum_failsafe_age (Postgres stops accepting writes at about 2.1 billion)
+const wraparoundCriticalAge = 1600000000
+
+// PostgresWraparound - How close the databases and tables are to transaction
+// ID wraparound, at the time of the snapshot
+//
+// These are point-in-time values, and are therefore not diffed between runs.
+type PostgresWraparound struct {
+	FreezeMaxAge int64 // Value of autovacuum_freeze_max_age
+
+	// All databases, and the tables of the monitored database (db_name, not any
+	// other databases) with the oldest unfrozen transaction IDs, ordered by how
+	// close they are to wraparound
+	Databases []PostgresWraparoundDatabase
+	Tables    []PostgresWraparoundTable
+}
+
+// PostgresWraparoundDatabase - Age of datfrozenxid for a database
+type PostgresWraparoundDatabase struct {
+	DatabaseOid  Oid
+	DatabaseName string
+	XIDAge       int64
+
+	FreezeMaxAgePercent float64 // XIDAge as a percentage of autovacuum_freeze_max_age
+	Risk                string
+}
+
+// PostgresWraparoundTable - Age of relfrozenxid for a table (including
+// materialized views and TOAST tables)
+type PostgresWraparoundTable struct {
+	RelationOid  Oid
+	SchemaName   string
+	RelationName string
+	XIDAge       int64
+
+	// For TOAST tables the table they belong to, otherwise the same as SchemaName
+	// and RelationName (used for the schema and table filters)
+	OwnerSchemaName   string
+	OwnerRelationName string
+
+	FreezeMaxAgePercent float64 // XIDAge as a percentage of autovacuum_freeze_max_age
+	Risk                string
+}
+
+// WraparoundRisk - Returns how far the given transaction ID age is towards
+// autovacuum_freeze_max_age (in percent), and the risk derived from it
+func WraparoundRisk(xidAge int64, freezeMaxAge int64) (percent float64, risk string) {
+	if freezeMaxAge > 0 {
+		percent = float64(xidAge) / float64(freezeMaxAge) * 100
+	}
+
+	switch {
+	case xidAge >= wraparoundCriticalAge:
+		risk = WraparoundRiskCritical
+	case freezeMaxAge > 0 && xidAge > freezeMaxAge:
+		risk = WraparoundRiskHigh
+	case percent >= wraparoundElevatedPercent:
+		risk = WraparoundRiskElevated
+	default:
+		risk = WraparoundRiskLow
+	}
+	return
+}
+
+// AssessWraparound - Fills in the percentage towards autovacuum_freeze_max_age
+// and the risk for all databases and tables, and orders them by their age
+func AssessWraparound(wraparound PostgresWraparound) PostgresWraparound {
+	for i := range wraparound.Databases {
+		database := &wraparound.Databases[i]
+		database.FreezeMaxAgePercent, database.Risk = WraparoundRisk(database.XIDAge, wraparound.FreezeMaxAge)
+	}
+	for i := range wraparound.Tables {
+		table := &wraparound.Tables[i]
+		table.FreezeMaxAgePercent, table.Risk = WraparoundRisk(table.XIDAge, wraparound.FreezeMaxAge)
+	}
+
+	sort.SliceStable(wraparound.Databases, func(i, j int) bool {
+		return wraparound.Databases[i].XIDAge > wraparound.Databases[j].XIDAge
+	})
+	sort.SliceStable(wraparound.Tables, func(i, j int) bool {
+		return wraparound.Tables[i].XIDAge > wraparound.Tables[j].XIDAge
+	})
+	return wraparound
+}
+
+// AtRisk - Returns the databases whose risk of wraparound is high or critical
+func (wraparound PostgresWraparound) AtRisk() (databases []PostgresWraparoundDatabase) {
+	for _, database := range wraparound.Databases {
+		if database.Risk == WraparoundRiskHigh || database.Risk == WraparoundRiskCritical {
+			databases = append(databases, database)
+		}
+	}
+	return
+}
@@ -0,0 +1,69 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+)
+
+var wraparoundRiskTests = []struct {
+	xidAge       int64
+	freezeMaxAge int64
+	percent      float64
+	risk         string
+}{
+	{1000000, 200000000, 0.5, state.WraparoundRiskLow},
+	{149000000, 200000000, 74.5, state.WraparoundRiskLow},
+	{150000000, 200000000, 75, state.WraparoundRiskElevated},
+	{200000000, 200000000, 100, state.WraparoundRiskElevated},
+	{210000000, 200000000, 105, state.WraparoundRiskHigh},
+	{1650000000, 200000000, 825, state.WraparoundRiskCritical},
+	// A raised autovacuum_freeze_max_age doesn't make ages close to wraparound any less critical
+	{1700000000, 2000000000, 85, state.WraparoundRiskCritical},
+}
+
+func TestWraparoundRisk(t *testing.T) {
+	for _, test := range wraparoundRiskTests {
+		percent, risk := state.WraparoundRisk(test.xidAge, test.freezeMaxAge)
+		if percent != test.percent || risk != test.risk {
+			t.Errorf("WraparoundRisk(%d, %d): expected %.1f%% / %s, got %.1f%% / %s", test.xidAge, test.freezeMaxAge, test.percent, test.risk, percent, risk)
+		}
+	}
+}
+
+func TestAssessWraparound(t *testing.T) {
+	wraparound := state.AssessWraparound(state.PostgresWraparound{
+		FreezeMaxAge: 200000000,
+		Databases: []state.PostgresWraparoundDatabase{
+			{DatabaseOid: 1, DatabaseName: "template1", XIDAge: 5000000},
+			{DatabaseOid: 16384, DatabaseName: "app", XIDAge: 250000000},
+			{DatabaseOid: 16385, DatabaseName: "reporting", XIDAge: 160000000},
+		},
+		Tables: []state.PostgresWraparoundTable{
+			{RelationOid: 16400, SchemaName: "public", RelationName: "users", XIDAge: 20000000},
+			{RelationOid: 16410, SchemaName: "public", RelationName: "events", XIDAge: 250000000},
+		},
+	})
+
+	expected := state.PostgresWraparound{
+		FreezeMaxAge: 200000000,
+		Databases: []state.PostgresWraparoundDatabase{
+			{DatabaseOid: 16384, DatabaseName: "app", XIDAge: 250000000, FreezeMaxAgePercent: 125, Risk: state.WraparoundRiskHigh},
+			{DatabaseOid: 16385, DatabaseName: "reporting", XIDAge: 160000000, FreezeMaxAgePercent: 80, Risk: state.WraparoundRiskElevated},
+			{DatabaseOid: 1, DatabaseName: "template1", XIDAge: 5000000, FreezeMaxAgePercent: 2.5, Risk: state.WraparoundRiskLow},
+		},
+		Tables: []state.PostgresWraparoundTable{
+			{RelationOid: 16410, SchemaName: "public", RelationName: "events", XIDAge: 250000000, FreezeMaxAgePercent: 125, Risk: state.WraparoundRiskHigh},
+			{RelationOid: 16400, SchemaName: "public", RelationName: "users", XIDAge: 20000000, FreezeMaxAgePercent: 10, Risk: state.WraparoundRiskLow},
+		},
+	}
+	if diff := pretty.Compare(expected, wraparound); diff != "" {
+		t.Errorf("AssessWraparound: (-want +got)\n%s", diff)
+	}
+
+	atRisk := wraparound.AtRisk()
+	if len(atRisk) != 1 || atRisk[0].DatabaseName != "app" {
+		t.Errorf("Expected only app to be at risk, got %v", atRisk)
+	}
+}
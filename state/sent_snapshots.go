@@ -0,0 +1,53 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// SentSnapshot - A snapshot exactly as it was uploaded, so it can be sent again
+// without collecting or processing anything
+type SentSnapshot struct {
+	UUID           string
+	CollectedAt    time.Time
+	CompressedData []byte // zlib-compressed protocol buffers
+}
+
+// LastSentSnapshots - Holds the most recently sent full snapshot and log
+// snapshot of a server, for resending them when debugging
+type LastSentSnapshots struct {
+	mutex sync.Mutex
+	full  *SentSnapshot
+	logs  *SentSnapshot
+}
+
+// RecordFull - Remembers a successfully sent full snapshot
+func (l *LastSentSnapshots) RecordFull(snapshot SentSnapshot) {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	l.full = &snapshot
+	l.mutex.Unlock()
+}
+
+// RecordLogs - Remembers a successfully sent log snapshot
+func (l *LastSentSnapshots) RecordLogs(snapshot SentSnapshot) {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	l.logs = &snapshot
+	l.mutex.Unlock()
+}
+
+// Get - Returns the last sent full snapshot and log snapshot, nil if there
+// wasn't one yet
+func (l *LastSentSnapshots) Get() (full *SentSnapshot, logs *SentSnapshot) {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.full, l.logs
+}
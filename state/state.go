@@ -20,9 +20,40 @@ type PersistedState struct {
 	Relations []PostgresRelation
 	Functions []PostgresFunction
 
+	Replication   PostgresReplication
+	BackendCounts PostgresBackendCounts
+	IOStats       PostgresIOStatsMap // Postgres 16+
+	BgwriterStats PostgresBgwriterStats
+
+	// Indexes without any scans since the last statistics reset, and since when
+	// they have been seen like that
+	UnusedIndexes PostgresIndexUnusedMap
+
+	// Bloat estimates are expensive, so they are only collected once per
+	// BloatCollectionInterval and carried over between runs otherwise
+	BloatStats       PostgresBloatStats
+	BloatCollectedAt time.Time
+
+	// When each category with its own collection interval was last collected,
+	// data of categories that are not due yet is carried over between runs
+	CategoryCollectedAt map[CollectionCategory]time.Time
+
+	// Settings that were changed but only get applied after a restart, so we
+	// can point out settings that newly started waiting for one
+	PendingRestartSettings []string
+
+	// All settings of the last run, to only send the ones that changed when
+	// settings_changed_only is enabled (not kept otherwise)
+	Settings []PostgresSetting
+
 	System         SystemState
 	CollectorStats CollectorStats
 
+	// Updated by log downloads (not by full snapshots), and carried over between them
+	CloudWatchLogsPosition CloudWatchLogsPosition
+	AzureLogsPosition      AzureLogsPosition
+	RdsLogsPosition        RdsLogsPosition
+
 	// Incremented every run, indicates whether we should run a pg_stat_statements_reset()
 	// on behalf of the user. Only activates once it reaches GrantFeatures.StatementReset,
 	// and is reset afterwards.
@@ -31,10 +62,59 @@ type PersistedState struct {
 	// Keep track of when we last collected statement stats, to calculate time distance
 	LastStatementStatsAt time.Time
 
-	// All statement stats that have not been identified (will be cleared by the next full snapshot)
+	// All statement stats that have not been identified (will be cleared by the next full snapshot that collects query statistics)
 	UnidentifiedStatementStats HistoricStatementStatsMap
 }
 
+// IsStale - Whether the state was collected too long ago to diff against it,
+// a maxAge of zero disables the check
+func (ps PersistedState) IsStale(now time.Time, maxAge time.Duration) bool {
+	return maxAge > 0 && now.Sub(ps.CollectedAt) > maxAge
+}
+
+// WithoutDiffBaseline - Returns the state without the statistics that get
+// diffed against, for state that is too old for that (see IsStale)
+//
+// Everything that is not used for diffs is kept, e.g. where reading logs left
+// off, and since when indexes are unused.
+func (ps PersistedState) WithoutDiffBaseline() PersistedState {
+	return PersistedState{
+		UnusedIndexes:          ps.UnusedIndexes,
+		BloatStats:             ps.BloatStats,
+		BloatCollectedAt:       ps.BloatCollectedAt,
+		PendingRestartSettings: ps.PendingRestartSettings,
+		CloudWatchLogsPosition: ps.CloudWatchLogsPosition,
+		AzureLogsPosition:      ps.AzureLogsPosition,
+		RdsLogsPosition:        ps.RdsLogsPosition,
+		StatementResetCounter:  ps.StatementResetCounter,
+	}
+}
+
+// CollectionCategory - Part of the full snapshot that can be collected on its
+// own interval (see ServerConfig.SystemCollectionInterval and others)
+type CollectionCategory string
+
+const (
+	CollectionCategorySystem     CollectionCategory = "system"
+	CollectionCategoryStatements CollectionCategory = "statements"
+	CollectionCategoryRelations  CollectionCategory = "relations"
+)
+
+// CategoryCollectedAtOrRun - When the data of the category was collected, which
+// is before CollectedAt if it was carried over from an earlier run
+func (ps PersistedState) CategoryCollectedAtOrRun(category CollectionCategory) time.Time {
+	if collectedAt, ok := ps.CategoryCollectedAt[category]; ok && !collectedAt.IsZero() {
+		return collectedAt
+	}
+	return ps.CollectedAt
+}
+
+// CollectedThisRun - Whether the data of the category was collected in the run
+// of the state, instead of being carried over from an earlier run
+func CollectedThisRun(ps PersistedState, category CollectionCategory) bool {
+	return ps.CategoryCollectedAtOrRun(category).Equal(ps.CollectedAt)
+}
+
 // TransientState - State thats only used within a collector run (and not needed for diffs)
 type TransientState struct {
 	// Databases we connected to and fetched local catalog data (e.g. schema)
@@ -50,9 +130,28 @@ type TransientState struct {
 	// in order to enable the next snapshot to be able to diff against something
 	ResetStatementStats PostgresStatementStatsMap
 
-	Replication   PostgresReplication
-	Settings      []PostgresSetting
-	BackendCounts []PostgresBackendCount
+	Settings []PostgresSetting
+
+	// Whether Settings only contains the settings that changed since the last run
+	SettingsChangedOnly bool
+
+	// Settings that started waiting for a restart to be applied since the last run
+	NewlyPendingRestartSettings []string
+
+	// Wait events sampled from pg_stat_activity since the last full snapshot
+	WaitEvents PostgresWaitEventSummary
+
+	// Progress of long-running operations at the time of the snapshot
+	Progress PostgresProgress
+
+	// Lock waits and the blocking trees between backends at the time of the snapshot
+	Locks PostgresLocks
+
+	// Transaction ID ages of databases and tables, and how close they are to wraparound
+	Wraparound PostgresWraparound
+
+	// Values of the server's custom metric queries
+	CustomMetrics []PostgresCustomMetric
 
 	Version PostgresVersion
 
@@ -66,9 +165,27 @@ type DiffState struct {
 	IndexStats     DiffedPostgresIndexStatsMap
 	FunctionStats  DiffedPostgresFunctionStatsMap
 
+	// Seconds covered by the statistics of categories with their own collection
+	// interval, zero if they were carried over this run and hence not diffed
+	StatementStatsIntervalSecs uint32
+	RelationStatsIntervalSecs  uint32
+
+	FunctionDefinitions DiffedPostgresFunctionDefinitions
+
+	// Whether pg_stat_statements counters went down since the last run, because
+	// the statistics were reset outside of the collector. Affected statements
+	// have their current values in StatementStats, instead of a difference.
+	StatementStatsReset bool
+
 	SystemCPUStats     DiffedSystemCPUStatsMap
 	SystemNetworkStats DiffedNetworkStatsMap
 	SystemDiskStats    DiffedDiskStatsMap
+	SystemMemoryStats  DiffedMemoryStats
+
+	Replication   DiffedPostgresReplication
+	BackendCounts DiffedPostgresBackendCounts
+	IOStats       DiffedPostgresIOStatsMap
+	BgwriterStats *DiffedPostgresBgwriterStats // Not set on the first run
 
 	CollectorStats DiffedCollectorStats
 }
@@ -94,6 +211,14 @@ type CollectionOpts struct {
 	CollectExplain           bool
 	CollectSystemInformation bool
 
+	CompressLogs bool // Compress log files using gzip before encrypting and uploading them
+
+	LogUploadMaxRetries     int           // How often sending a batch of log lines is retried, before the batch gets dropped
+	LogUploadRetryBaseDelay time.Duration // Wait time before the first retry, doubled for every subsequent retry
+	LogTempDir              string        // Directory for log tempfiles, defaults to the OS temp directory
+	LogDeadLetterDir        string        // Directory that dropped batches of log lines are written to, instead of discarding them
+	LogDeadLetterPlaintext  bool          // Write dropped batches unencrypted when no log encryption key is available
+
 	CollectorApplicationName string
 
 	DiffStatements bool
@@ -102,12 +227,18 @@ type CollectionOpts struct {
 	TestRun             bool
 	TestReport          string
 	TestRunLogs         bool
+	TestExplain         bool // Only test that query plans can be collected
+	CheckPermissions    bool // Only check that the monitoring role can access everything that gets collected
 	DebugLogs           bool
+	DebugSnapshot       bool // Print the full snapshot as JSON (with secrets redacted), instead of submitting it
 	DiscoverLogLocation bool
+	CollectOnce         bool // Run a single collection and exit, sending all log lines instead of holding back fresh ones
 
 	StateFilename    string
 	WriteStateUpdate bool
 	ForceEmptyGrant  bool
+
+	Pause *PauseControl // Only set for the scheduled runs, which get skipped while paused
 }
 
 type GrantConfig struct {
@@ -118,11 +249,16 @@ type GrantConfig struct {
 }
 
 type GrantFeatures struct {
-	Logs    bool `json:"logs"`
-	Explain bool `json:"explain"`
+	Logs         bool  `json:"logs"`
+	Explain      bool  `json:"explain"`
+	QuerySamples *bool `json:"query_samples"` // Whether query samples are collected from the logs (log lines are sent regardless), enabled if not set
 
 	StatementResetFrequency int   `json:"statement_reset_frequency"`
 	StatementTimeoutMs      int32 `json:"statement_timeout_ms"` // Statement timeout for all SQL statements sent to the database (defaults to 30s)
+
+	// Log classifications that may be sent, if not empty (e.g. during controlled
+	// rollouts), which takes precedence over the local log_classification_deny_list
+	LogClassificationAllowList []int32 `json:"log_classification_allow_list"`
 }
 
 type Grant struct {
@@ -131,21 +267,93 @@ type Grant struct {
 	S3URL    string            `json:"s3_url"`
 	S3Fields map[string]string `json:"s3_fields"`
 	LocalDir string            `json:"local_dir"`
+
+	APIBaseURL string `json:"-"` // Endpoint the grant was received from, which uploads get submitted to
+}
+
+// CollectQuerySamples - Whether query samples should be collected from the logs
+//
+// Samples are kept until we received a grant from the server, so log lines
+// processed before the first full snapshot don't lose their samples. Servers
+// that don't know about the feature yet don't send it, which keeps samples
+// enabled as well.
+func (g Grant) CollectQuerySamples() bool {
+	return !g.Valid || g.Config.Features.QuerySamples == nil || *g.Config.Features.QuerySamples
+}
+
+// IsLogClassificationAllowed - Whether the server allows sending log lines with
+// the given classification, which it does for all of them unless it specified
+// an allow list
+func (g Grant) IsLogClassificationAllowed(classification int32) bool {
+	if len(g.Config.Features.LogClassificationAllowList) == 0 {
+		return true
+	}
+	for _, allowed := range g.Config.Features.LogClassificationAllowList {
+		if classification == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// SharedGrant - Grant of the last full snapshot, shared by all copies of a
+// server (log tails keep the copy they were started with, which doesn't see
+// grants received later)
+//
+// All methods can be called on a nil SharedGrant, which never has a grant.
+type SharedGrant struct {
+	mutex sync.Mutex
+	grant Grant
+}
+
+// Get - Returns the last grant that was set
+func (g *SharedGrant) Get() Grant {
+	if g == nil {
+		return Grant{}
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.grant
+}
+
+// Set - Replaces the grant, after a full snapshot received a new one
+func (g *SharedGrant) Set(grant Grant) {
+	if g == nil {
+		return
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.grant = grant
 }
 
 func (g Grant) S3() GrantS3 {
-	return GrantS3{S3URL: g.S3URL, S3Fields: g.S3Fields}
+	return GrantS3{S3URL: g.S3URL, S3Fields: g.S3Fields, LocalDir: g.LocalDir}
 }
 
 type GrantS3 struct {
 	S3URL    string            `json:"s3_url"`
 	S3Fields map[string]string `json:"s3_fields"`
+	LocalDir string            `json:"local_dir"` // Files are written to this directory instead, if there is no S3 URL
 }
 
 type Server struct {
-	Config           config.ServerConfig
-	PrevState        PersistedState
-	StateMutex       *sync.Mutex
-	RequestedSslMode string
-	Grant            Grant
+	Config            config.ServerConfig
+	PrevState         PersistedState
+	StateMutex        *sync.Mutex
+	RequestedSslMode  string
+	Grant             Grant
+	SharedGrant       *SharedGrant
+	LogsGrantCache    *GrantLogsCache
+	SubmissionTimes   *SubmissionTimes
+	WaitEventSampler  *WaitEventSampler
+	LastSentSnapshots *LastSentSnapshots
+}
+
+// CurrentGrant - Returns the grant of the last full snapshot, even when this is
+// a copy of the server made before it was received
+func (s Server) CurrentGrant() Grant {
+	if grant := s.SharedGrant.Get(); grant.Valid {
+		return grant
+	}
+	return s.Grant
 }
@@ -0,0 +1,65 @@
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// EncodeStateOnDisk - Writes the state gzip-compressed, followed by a SHA-256
+// checksum of the compressed data, so that incomplete writes can be detected
+func EncodeStateOnDisk(w io.Writer, stateOnDisk StateOnDisk) error {
+	var compressed bytes.Buffer
+
+	gz := gzip.NewWriter(&compressed)
+	err := gob.NewEncoder(gz).Encode(stateOnDisk)
+	if err != nil {
+		return err
+	}
+	err = gz.Close()
+	if err != nil {
+		return err
+	}
+
+	checksum := sha256.Sum256(compressed.Bytes())
+
+	_, err = w.Write(compressed.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(checksum[:])
+	return err
+}
+
+// DecodeStateOnDisk - Reads state written by EncodeStateOnDisk, returning an
+// error if the data is truncated or otherwise doesn't match its checksum
+func DecodeStateOnDisk(r io.Reader) (StateOnDisk, error) {
+	var stateOnDisk StateOnDisk
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return stateOnDisk, err
+	}
+	if len(data) < sha256.Size {
+		return stateOnDisk, fmt.Errorf("state file is too short (%d bytes)", len(data))
+	}
+
+	compressed := data[:len(data)-sha256.Size]
+	checksum := sha256.Sum256(compressed)
+	if !bytes.Equal(checksum[:], data[len(data)-sha256.Size:]) {
+		return stateOnDisk, fmt.Errorf("state file checksum mismatch, file is incomplete or corrupted")
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return stateOnDisk, err
+	}
+	defer gz.Close()
+
+	err = gob.NewDecoder(gz).Decode(&stateOnDisk)
+	return stateOnDisk, err
+}
@@ -0,0 +1,68 @@
+package state_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pganalyze/collector/config"
+	"github.com/pganalyze/collector/state"
+)
+
+func encodedTestStateOnDisk(t *testing.T) []byte {
+	stateOnDisk := state.StateOnDisk{
+		FormatVersion: state.StateOnDiskFormatVersion,
+		PrevStateByServer: map[config.ServerIdentifier]state.PersistedState{
+			{SystemID: "test"}: {CollectedAt: time.Unix(1500000000, 0).UTC(), StatementResetCounter: 3},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := state.EncodeStateOnDisk(&buf, stateOnDisk); err != nil {
+		t.Fatalf("Unexpected error encoding state: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestStateOnDiskRoundtrip(t *testing.T) {
+	data := encodedTestStateOnDisk(t)
+
+	stateOnDisk, err := state.DecodeStateOnDisk(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Unexpected error decoding state: %s", err)
+	}
+	if stateOnDisk.FormatVersion != state.StateOnDiskFormatVersion {
+		t.Errorf("Expected format version %d, got %d", state.StateOnDiskFormatVersion, stateOnDisk.FormatVersion)
+	}
+	prevState := stateOnDisk.PrevStateByServer[config.ServerIdentifier{SystemID: "test"}]
+	if prevState.StatementResetCounter != 3 || !prevState.CollectedAt.Equal(time.Unix(1500000000, 0)) {
+		t.Errorf("Unexpected persisted state after decoding: %+v", prevState)
+	}
+}
+
+func TestStateOnDiskTruncated(t *testing.T) {
+	data := encodedTestStateOnDisk(t)
+
+	for _, length := range []int{0, 10, len(data) / 2, len(data) - 1} {
+		stateOnDisk, err := state.DecodeStateOnDisk(bytes.NewReader(data[:length]))
+		if err == nil {
+			t.Errorf("Expected error for state file truncated to %d of %d bytes", length, len(data))
+		}
+		if len(stateOnDisk.PrevStateByServer) != 0 {
+			t.Errorf("Expected no state for file truncated to %d bytes, got %d entries", length, len(stateOnDisk.PrevStateByServer))
+		}
+	}
+}
+
+func TestStateOnDiskChecksumMismatch(t *testing.T) {
+	data := encodedTestStateOnDisk(t)
+	data[len(data)/2] ^= 0xff
+
+	stateOnDisk, err := state.DecodeStateOnDisk(bytes.NewReader(data))
+	if err == nil {
+		t.Errorf("Expected error for corrupted state file")
+	}
+	if len(stateOnDisk.PrevStateByServer) != 0 {
+		t.Errorf("Expected no state for corrupted file, got %d entries", len(stateOnDisk.PrevStateByServer))
+	}
+}
@@ -0,0 +1,89 @@
+package state_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pganalyze/collector/state"
+)
+
+var isStaleTests = []struct {
+	description  string
+	collectedAgo time.Duration
+	maxAge       time.Duration
+	expected     bool
+}{
+	{"fresh", 5 * time.Minute, time.Hour, false},
+	{"borderline", time.Hour, time.Hour, false},
+	{"stale", time.Hour + time.Second, time.Hour, true},
+	{"stale by days", 72 * time.Hour, time.Hour, true},
+	{"check disabled", 72 * time.Hour, 0, false},
+}
+
+func TestPersistedStateIsStale(t *testing.T) {
+	now := time.Now()
+
+	for _, test := range isStaleTests {
+		ps := state.PersistedState{CollectedAt: now.Add(-test.collectedAgo)}
+		actual := ps.IsStale(now, test.maxAge)
+		if actual != test.expected {
+			t.Errorf("%s: expected IsStale to return %t, got %t", test.description, test.expected, actual)
+		}
+	}
+}
+
+func TestPersistedStateIsStaleWithoutCollectedAt(t *testing.T) {
+	var ps state.PersistedState
+	if !ps.IsStale(time.Now(), time.Hour) {
+		t.Errorf("Expected state without collection time to be stale")
+	}
+}
+
+var collectQuerySamplesTests = []struct {
+	description string
+	grantJSON   string
+	expected    bool
+}{
+	{"feature not sent", `{"valid": true, "config": {"features": {"logs": true}}}`, true},
+	{"feature enabled", `{"valid": true, "config": {"features": {"query_samples": true}}}`, true},
+	{"feature disabled", `{"valid": true, "config": {"features": {"query_samples": false}}}`, false},
+}
+
+func TestGrantCollectQuerySamples(t *testing.T) {
+	for _, test := range collectQuerySamplesTests {
+		var grant state.Grant
+		err := json.Unmarshal([]byte(test.grantJSON), &grant)
+		if err != nil {
+			t.Fatalf("%s: %s", test.description, err)
+		}
+		if actual := grant.CollectQuerySamples(); actual != test.expected {
+			t.Errorf("%s: expected CollectQuerySamples to return %t, got %t", test.description, test.expected, actual)
+		}
+	}
+}
+
+func TestPersistedStateWithoutDiffBaseline(t *testing.T) {
+	now := time.Now()
+	ps := state.PersistedState{
+		CollectedAt:            now.Add(-72 * time.Hour),
+		RelationStats:          state.PostgresRelationStatsMap{1: {SeqScan: 10}},
+		StatementStats:         state.PostgresStatementStatsMap{},
+		CategoryCollectedAt:    map[state.CollectionCategory]time.Time{state.CollectionCategoryRelations: now.Add(-72 * time.Hour)},
+		UnusedIndexes:          state.PostgresIndexUnusedMap{2: {Since: now.Add(-96 * time.Hour)}},
+		BloatStats:             state.PostgresBloatStats{DatabaseName: "app"},
+		PendingRestartSettings: []string{"shared_buffers"},
+		CloudWatchLogsPosition: state.CloudWatchLogsPosition{NextToken: "token"},
+		AzureLogsPosition:      state.AzureLogsPosition{RecordsAtLastIngestedAt: 3},
+		RdsLogsPosition:        state.RdsLogsPosition{Markers: map[string]string{"error/postgresql.log": "1:2"}},
+	}
+
+	kept := ps.WithoutDiffBaseline()
+	if !kept.CollectedAt.IsZero() || kept.RelationStats != nil || kept.StatementStats != nil || kept.CategoryCollectedAt != nil {
+		t.Errorf("Expected statistics to diff against to be removed, got %+v", kept)
+	}
+	if len(kept.UnusedIndexes) != 1 || kept.BloatStats.DatabaseName != "app" || len(kept.PendingRestartSettings) != 1 ||
+		kept.CloudWatchLogsPosition.NextToken != "token" || kept.AzureLogsPosition.RecordsAtLastIngestedAt != 3 || len(kept.RdsLogsPosition.Markers) != 1 {
+		t.Errorf("Expected state that is not diffed against to be kept, got %+v", kept)
+	}
+}
@@ -0,0 +1,45 @@
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// SubmissionTimes - Tracks when data for a server was last submitted
+// successfully, shared between the goroutines that send full snapshots and logs
+type SubmissionTimes struct {
+	mutex      sync.Mutex
+	snapshotAt time.Time
+	logsAt     time.Time
+}
+
+// RecordSnapshot - Remembers a successfully submitted full snapshot
+func (t *SubmissionTimes) RecordSnapshot(at time.Time) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	t.snapshotAt = at
+	t.mutex.Unlock()
+}
+
+// RecordLogs - Remembers a successfully submitted log snapshot
+func (t *SubmissionTimes) RecordLogs(at time.Time) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	t.logsAt = at
+	t.mutex.Unlock()
+}
+
+// Get - Returns when the last full snapshot and log snapshot were submitted,
+// zero if there wasn't one yet
+func (t *SubmissionTimes) Get() (snapshotAt time.Time, logsAt time.Time) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.snapshotAt, t.logsAt
+}
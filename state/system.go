@@ -107,6 +107,8 @@ type Memory struct {
 	AvailableBytes  uint64
 	SwapUsedBytes   uint64
 	SwapTotalBytes  uint64
+	SwapInBytes     uint64 // Total amount of memory swapped in since boot (0 if not known)
+	SwapOutBytes    uint64 // Total amount of memory swapped out since boot (0 if not known)
 
 	HugePagesSizeBytes uint64
 	HugePagesFree      uint64
@@ -117,6 +119,58 @@ type Memory struct {
 	ApplicationBytes uint64
 }
 
+// DiffedMemoryStats - Changes in system memory usage since the last run
+type DiffedMemoryStats struct {
+	AvailableBytesDelta int64
+	FreeBytesDelta      int64
+	SwapUsedBytesDelta  int64
+	SwapInBytes         uint64 // Memory swapped in since the last run
+	SwapOutBytes        uint64 // Memory swapped out since the last run
+
+	// Heuristic for whether the system is likely running out of memory (and at
+	// risk of the OOM killer terminating Postgres backends), based on available
+	// memory dropping sharply or a spike in swap activity
+	LikelyMemoryPressure bool
+}
+
+// Thresholds used to detect memory pressure, relative to the total memory and swap size
+const (
+	memoryPressureAvailableDropRatio = 0.2  // Available memory dropped by this much of total memory
+	memoryPressureSwapInRatio        = 0.01 // Memory swapped in, relative to total memory
+	memoryPressureSwapUsedRatio      = 0.1  // Increase in used swap, relative to total swap
+)
+
+// DiffSince - Calculate the change in memory usage between two runs, returning
+// all zeros when there is no previous run to compare against
+func (curr Memory) DiffSince(prev Memory) DiffedMemoryStats {
+	var diff DiffedMemoryStats
+
+	if curr.TotalBytes == 0 || prev.TotalBytes == 0 {
+		return diff
+	}
+
+	diff.AvailableBytesDelta = int64(curr.AvailableBytes) - int64(prev.AvailableBytes)
+	diff.FreeBytesDelta = int64(curr.FreeBytes) - int64(prev.FreeBytes)
+	diff.SwapUsedBytesDelta = int64(curr.SwapUsedBytes) - int64(prev.SwapUsedBytes)
+
+	// Swap counters start over when the system is restarted
+	if curr.SwapInBytes >= prev.SwapInBytes {
+		diff.SwapInBytes = curr.SwapInBytes - prev.SwapInBytes
+	}
+	if curr.SwapOutBytes >= prev.SwapOutBytes {
+		diff.SwapOutBytes = curr.SwapOutBytes - prev.SwapOutBytes
+	}
+
+	totalBytes := float64(curr.TotalBytes)
+	if float64(-diff.AvailableBytesDelta) >= totalBytes*memoryPressureAvailableDropRatio ||
+		float64(diff.SwapInBytes) >= totalBytes*memoryPressureSwapInRatio ||
+		(curr.SwapTotalBytes > 0 && float64(diff.SwapUsedBytesDelta) >= float64(curr.SwapTotalBytes)*memoryPressureSwapUsedRatio) {
+		diff.LikelyMemoryPressure = true
+	}
+
+	return diff
+}
+
 type CPUInformation struct {
 	Model             string
 	CacheSizeBytes    int32
@@ -0,0 +1,63 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/pganalyze/collector/state"
+)
+
+const gigabyte = 1024 * 1024 * 1024
+
+var memoryDiffTests = []struct {
+	description string
+	curr        state.Memory
+	prev        state.Memory
+	expected    state.DiffedMemoryStats
+}{
+	{
+		"first run",
+		state.Memory{TotalBytes: 16 * gigabyte, AvailableBytes: 8 * gigabyte, SwapInBytes: 4 * gigabyte},
+		state.Memory{},
+		state.DiffedMemoryStats{},
+	},
+	{
+		"steady state",
+		state.Memory{TotalBytes: 16 * gigabyte, AvailableBytes: 8 * gigabyte, FreeBytes: 2 * gigabyte, SwapInBytes: 1000, SwapOutBytes: 2000},
+		state.Memory{TotalBytes: 16 * gigabyte, AvailableBytes: 8*gigabyte + 4096, FreeBytes: 2 * gigabyte, SwapInBytes: 1000, SwapOutBytes: 1000},
+		state.DiffedMemoryStats{AvailableBytesDelta: -4096, SwapOutBytes: 1000},
+	},
+	{
+		"swap-in spike",
+		state.Memory{TotalBytes: 16 * gigabyte, AvailableBytes: 7 * gigabyte, SwapTotalBytes: 4 * gigabyte, SwapUsedBytes: gigabyte, SwapInBytes: 3 * gigabyte},
+		state.Memory{TotalBytes: 16 * gigabyte, AvailableBytes: 7 * gigabyte, SwapTotalBytes: 4 * gigabyte, SwapUsedBytes: gigabyte, SwapInBytes: 2 * gigabyte},
+		state.DiffedMemoryStats{SwapInBytes: gigabyte, LikelyMemoryPressure: true},
+	},
+	{
+		"swap usage spike",
+		state.Memory{TotalBytes: 16 * gigabyte, AvailableBytes: 7 * gigabyte, SwapTotalBytes: 4 * gigabyte, SwapUsedBytes: 2 * gigabyte},
+		state.Memory{TotalBytes: 16 * gigabyte, AvailableBytes: 7 * gigabyte, SwapTotalBytes: 4 * gigabyte, SwapUsedBytes: gigabyte},
+		state.DiffedMemoryStats{SwapUsedBytesDelta: gigabyte, LikelyMemoryPressure: true},
+	},
+	{
+		"sharp drop in available memory",
+		state.Memory{TotalBytes: 16 * gigabyte, AvailableBytes: 2 * gigabyte},
+		state.Memory{TotalBytes: 16 * gigabyte, AvailableBytes: 8 * gigabyte},
+		state.DiffedMemoryStats{AvailableBytesDelta: -6 * gigabyte, LikelyMemoryPressure: true},
+	},
+	{
+		"swap counters reset after restart",
+		state.Memory{TotalBytes: 16 * gigabyte, AvailableBytes: 8 * gigabyte, SwapInBytes: 100},
+		state.Memory{TotalBytes: 16 * gigabyte, AvailableBytes: 8 * gigabyte, SwapInBytes: 2 * gigabyte},
+		state.DiffedMemoryStats{},
+	},
+}
+
+func TestMemoryDiffSince(t *testing.T) {
+	for _, test := range memoryDiffTests {
+		diff := test.curr.DiffSince(test.prev)
+		if cmp := pretty.Compare(test.expected, diff); cmp != "" {
+			t.Errorf("%s: diff: (-want +got)\n%s", test.description, cmp)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package state
+
+import "sync/atomic"
+
+// Running totals since collector start, read through GetUploadedBytes
+var (
+	uploadedLogBytes      int64 // Log files, after compression and encryption
+	uploadedSnapshotBytes int64 // Snapshots and reports, after compression
+)
+
+// RecordUploadedLogBytes - Adds the size of an uploaded log file, as it was sent
+func RecordUploadedLogBytes(bytes int) {
+	atomic.AddInt64(&uploadedLogBytes, int64(bytes))
+}
+
+// RecordUploadedSnapshotBytes - Adds the size of an uploaded snapshot or report, as it was sent
+func RecordUploadedSnapshotBytes(bytes int) {
+	atomic.AddInt64(&uploadedSnapshotBytes, int64(bytes))
+}
+
+// GetUploadedBytes - Returns the number of bytes of log files and snapshots
+// that were uploaded since startup, as they were sent (i.e. compressed)
+func GetUploadedBytes() (logBytes int64, snapshotBytes int64) {
+	return atomic.LoadInt64(&uploadedLogBytes), atomic.LoadInt64(&uploadedSnapshotBytes)
+}
@@ -0,0 +1,54 @@
+package awsutil
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/pganalyze/collector/config"
+)
+
+// rdsAuthTokenExpiry - How long RDS accepts an IAM authentication token for
+const rdsAuthTokenExpiry = 15 * time.Minute
+
+// GetRdsAuthToken - Generates an IAM authentication token for the configured
+// database user, to be used as the password when connecting to RDS or Aurora
+//
+// Tokens expire after 15 minutes, so a new one needs to be generated for each
+// connection.
+func GetRdsAuthToken(config config.ServerConfig) (string, error) {
+	host := config.DbHost
+	if host == "" {
+		host = config.GetDbHost()
+	}
+	port := config.DbPort
+	if port == 0 {
+		port = config.GetDbPort()
+	}
+	if port == 0 {
+		port = 5432
+	}
+	username := config.DbUsername
+	if username == "" {
+		username = config.GetDbUsername()
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("https://%s:%d/", host, port), nil)
+	if err != nil {
+		return "", err
+	}
+	values := req.URL.Query()
+	values.Set("Action", "connect")
+	values.Set("DBUser", username)
+	req.URL.RawQuery = values.Encode()
+
+	signer := v4.NewSigner(GetAwsSession(config).Config.Credentials)
+	_, err = signer.Presign(req, nil, "rds-db", config.AwsRegion, rdsAuthTokenExpiry, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(req.URL.String(), "https://"), nil
+}
@@ -118,7 +118,7 @@ func (reader RdsCloudWatchReader) GetRdsFloatMetric(metricName string, unit stri
 	resp, err := reader.svc.GetMetricStatistics(params)
 
 	if err != nil {
-		reader.logger.PrintVerbose(err.Error())
+		reader.logger.PrintVerbose("%s", err)
 		return 0.0
 	}
 
@@ -0,0 +1,145 @@
+package azureutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Endpoints for getting tokens, which are only changed in tests
+var InstanceMetadataTokenURL = "http://169.254.169.254/metadata/identity/oauth2/token"
+var LoginBaseURL = "https://login.microsoftonline.com"
+
+// Tokens are renewed this long before they expire, to account for clock skew
+// and the time the request using them takes
+const tokenExpiryMargin = 5 * time.Minute
+
+// TokenSource - Gets access tokens for an Azure API, either for a service
+// principal or the managed identity of the VM the collector runs on, and
+// caches them until shortly before they expire
+type TokenSource struct {
+	resource string
+	appID    string
+	tenantID string
+	appKey   string
+
+	mutex     sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewTokenSource - Sets up getting tokens for the given resource (e.g.
+// "https://api.loganalytics.io"), according to a connection string in the
+// format of Azure's AzureServicesAuthConnectionString:
+//
+//	"" or "RunAs=App"                                       system-assigned managed identity
+//	"RunAs=App;AppId=<client id>"                           user-assigned managed identity
+//	"RunAs=App;AppId=<id>;TenantId=<id>;AppKey=<secret>"    service principal
+func NewTokenSource(connectionString string, resource string) (*TokenSource, error) {
+	s := &TokenSource{resource: resource}
+
+	for _, part := range strings.Split(connectionString, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		keyValue := strings.SplitN(part, "=", 2)
+		if len(keyValue) != 2 {
+			return nil, fmt.Errorf("invalid Azure connection string: expected key=value, got %q", part)
+		}
+		switch strings.ToLower(strings.TrimSpace(keyValue[0])) {
+		case "runas":
+			if !strings.EqualFold(strings.TrimSpace(keyValue[1]), "App") {
+				return nil, fmt.Errorf("invalid Azure connection string: only RunAs=App is supported")
+			}
+		case "appid":
+			s.appID = strings.TrimSpace(keyValue[1])
+		case "tenantid":
+			s.tenantID = strings.TrimSpace(keyValue[1])
+		case "appkey":
+			s.appKey = strings.TrimSpace(keyValue[1])
+		default:
+			return nil, fmt.Errorf("invalid Azure connection string: unknown key %q", keyValue[0])
+		}
+	}
+
+	if (s.tenantID != "" || s.appKey != "") && (s.appID == "" || s.tenantID == "" || s.appKey == "") {
+		return nil, fmt.Errorf("invalid Azure connection string: a service principal needs AppId, TenantId and AppKey")
+	}
+
+	return s, nil
+}
+
+// Token - Returns a valid access token, requesting a new one if needed
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.token != "" && time.Now().Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	var req *http.Request
+	var err error
+	if s.appKey != "" {
+		form := url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {s.appID},
+			"client_secret": {s.appKey},
+			"resource":      {s.resource},
+		}
+		req, err = http.NewRequest("POST", LoginBaseURL+"/"+url.PathEscape(s.tenantID)+"/oauth2/token", strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		query := url.Values{"api-version": {"2018-02-01"}, "resource": {s.resource}}
+		if s.appID != "" {
+			query.Set("client_id", s.appID)
+		}
+		req, err = http.NewRequest("GET", InstanceMetadataTokenURL+"?"+query.Encode(), nil)
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Metadata", "true")
+	}
+
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Azure token request failed: %s: %s", resp.Status, body)
+	}
+
+	// Both endpoints return expires_in as a string of seconds
+	var token struct {
+		AccessToken string      `json:"access_token"`
+		ExpiresIn   json.Number `json:"expires_in"`
+	}
+	err = json.Unmarshal(body, &token)
+	if err != nil {
+		return "", fmt.Errorf("Azure token response could not be decoded: %s", err)
+	}
+	expiresIn, err := token.ExpiresIn.Int64()
+	if err != nil || token.AccessToken == "" {
+		return "", fmt.Errorf("Azure token response is missing the token or its expiry")
+	}
+
+	s.token = token.AccessToken
+	s.expiresAt = time.Now().Add(time.Duration(expiresIn)*time.Second - tokenExpiryMargin)
+	return s.token, nil
+}
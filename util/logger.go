@@ -1,8 +1,10 @@
 package util
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 )
 
 type Logger struct {
@@ -12,24 +14,69 @@ type Logger struct {
 	Destination    *log.Logger
 	RememberErrors bool
 	ErrorMessages  []string
+
+	// Output one JSON object per line, instead of free-form text (the destination
+	// should not add its own prefix or timestamps in that case)
+	JSON bool
+}
+
+// jsonLogLine - Structured format of a log line when JSON output is enabled
+type jsonLogLine struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Server  string `json:"server,omitempty"` // Config section name, when logging for a specific server
+	Message string `json:"message"`
+}
+
+var jsonLogLevels = map[string]string{
+	"V": "verbose",
+	"I": "info",
+	"W": "warning",
+	"E": "error",
 }
 
 func (logger *Logger) WithPrefix(prefix string) *Logger {
-	return &Logger{Verbose: logger.Verbose, Quiet: logger.Quiet, Destination: logger.Destination, Prefix: &prefix}
+	return &Logger{Verbose: logger.Verbose, Quiet: logger.Quiet, Destination: logger.Destination, Prefix: &prefix, JSON: logger.JSON}
 }
 
 func (logger *Logger) WithPrefixAndRememberErrors(prefix string) *Logger {
-	return &Logger{Verbose: logger.Verbose, Quiet: logger.Quiet, Destination: logger.Destination, Prefix: &prefix, RememberErrors: true}
+	return &Logger{Verbose: logger.Verbose, Quiet: logger.Quiet, Destination: logger.Destination, Prefix: &prefix, RememberErrors: true, JSON: logger.JSON}
 }
 
-func (logger *Logger) print(logLevel string, format string, args ...interface{}) {
+func (logger *Logger) printJSON(logLevel string, message string) {
+	line := jsonLogLine{
+		Time:    time.Now().UTC().Format(time.RFC3339Nano),
+		Level:   jsonLogLevels[logLevel],
+		Message: message,
+	}
 	if logger.Prefix != nil {
-		format = fmt.Sprintf("[%s] %s", *logger.Prefix, format)
+		line.Server = *logger.Prefix
+	}
+
+	lineJSON, err := json.Marshal(line)
+	if err != nil {
+		// Can't happen for a struct of strings, but don't lose the message if it does
+		logger.Destination.Printf("%s %s", logLevel, line.Message)
+		return
 	}
+	logger.Destination.Print(string(lineJSON))
+}
 
-	format = fmt.Sprintf("%s %s", logLevel, format)
+// print - Outputs the message, with any secrets (e.g. passwords contained in
+// connection errors) masked
+func (logger *Logger) print(logLevel string, format string, args ...interface{}) {
+	message := RedactSecrets(fmt.Sprintf(format, args...))
+
+	if logger.JSON {
+		logger.printJSON(logLevel, message)
+		return
+	}
+
+	if logger.Prefix != nil {
+		message = fmt.Sprintf("[%s] %s", *logger.Prefix, message)
+	}
 
-	logger.Destination.Printf(format, args...)
+	logger.Destination.Printf("%s %s", logLevel, message)
 }
 
 func (logger *Logger) PrintVerbose(format string, args ...interface{}) {
@@ -54,7 +101,7 @@ func (logger *Logger) PrintWarning(format string, args ...interface{}) {
 
 func (logger *Logger) PrintError(format string, args ...interface{}) {
 	if logger.RememberErrors {
-		logger.ErrorMessages = append(logger.ErrorMessages, fmt.Sprintf(format, args...))
+		logger.ErrorMessages = append(logger.ErrorMessages, RedactSecrets(fmt.Sprintf(format, args...)))
 	}
 
 	logger.print("E", format, args...)
@@ -0,0 +1,24 @@
+package util
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// S3UploadError - Returned when S3 rejected an upload
+type S3UploadError struct {
+	Status     string
+	StatusCode int
+	Body       []byte
+}
+
+func (e S3UploadError) Error() string {
+	return fmt.Sprintf("Bad S3 upload return code %s (should be 201 Created), body: %s", e.Status, e.Body)
+}
+
+// IsUploadForbidden - Whether S3 refused an upload because it wasn't permitted,
+// which usually means that the grant used for the upload expired
+func IsUploadForbidden(err error) bool {
+	uploadErr, ok := err.(S3UploadError)
+	return ok && uploadErr.StatusCode == http.StatusForbidden
+}